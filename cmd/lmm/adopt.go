@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	adoptName    string
+	adoptVersion string
+	adoptProfile string
+)
+
+var adoptCmd = &cobra.Command{
+	Use:   "adopt <path>...",
+	Short: "Bring untracked files in the mod directory under management",
+	Long: `Turn files that were copied into the game's mod directory by hand into
+a managed local mod.
+
+The files are moved into the cache, checksummed, and deployed back to the
+same paths with the profile's link method, so the new mod takes part in
+conflict detection, verify, profiles, and purge like any other.
+
+Paths may be files or directories, either relative to the mod directory
+or absolute. Inside a directory, symlinks and files another mod already
+owns are left alone. 'lmm import' with no arguments lists candidates.
+
+Examples:
+  lmm adopt SkyUI --name "SkyUI" --game skyrim-se
+  lmm adopt mods/sodium-0.5.jar mods/iris-1.6.jar --name "Shaders" --version 1.0`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAdopt,
+}
+
+func init() {
+	adoptCmd.Flags().StringVarP(&adoptName, "name", "n", "", "name for the new mod (required)")
+	adoptCmd.Flags().StringVar(&adoptVersion, "version", "", "version to record (default: unknown)")
+	adoptCmd.Flags().StringVarP(&adoptProfile, "profile", "p", "", "profile to add the mod to (default: active profile)")
+	_ = adoptCmd.MarkFlagRequired("name")
+
+	rootCmd.AddCommand(adoptCmd)
+}
+
+func runAdopt(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doAdopt(ctx, svc, game, args)
+	})
+}
+
+func doAdopt(ctx context.Context, svc *core.Service, game *domain.Game, args []string) error {
+	profileName, err := resolveProfile(svc, game.ID, adoptProfile)
+	if err != nil {
+		return err
+	}
+
+	// A relative path that exists from the working directory is what the
+	// user pointed at (shell completion, "cd mods && lmm adopt foo.jar");
+	// anything else is taken relative to the mod directory.
+	paths := make([]string, len(args))
+	for i, a := range args {
+		paths[i] = a
+		if filepath.IsAbs(a) {
+			continue
+		}
+		if _, err := os.Lstat(a); err == nil {
+			if abs, err := filepath.Abs(a); err == nil {
+				paths[i] = abs
+			}
+		}
+	}
+
+	result, err := svc.AdoptFiles(ctx, game, profileName, paths, core.AdoptOptions{Name: adoptName, Version: adoptVersion})
+	if err != nil {
+		return fmt.Errorf("adopt failed: %w", err)
+	}
+
+	for _, w := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	if verbose {
+		for _, f := range result.Files {
+			fmt.Printf("  + %s\n", f)
+		}
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Printf("Skipped %d file(s) that are symlinks or belong to another mod.\n", len(result.Skipped))
+		if verbose {
+			for _, f := range result.Skipped {
+				fmt.Printf("  - %s\n", f)
+			}
+		}
+	}
+
+	fmt.Printf("✓ Adopted: %s\n", result.Mod.Name)
+	fmt.Printf("  ID: %s\n", result.Mod.ID)
+	fmt.Printf("  Files deployed: %d\n", len(result.Files))
+	fmt.Printf("  Added to profile: %s\n", profileName)
+	return nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAdoptCmd_AdoptsHandCopiedDirectory drops a directory into the mod path
+// by hand and adopts it, then checks the mod is recorded and owns its files.
+func TestAdoptCmd_AdoptsHandCopiedDirectory(t *testing.T) {
+	configDir = t.TempDir()
+	dataDir = t.TempDir()
+
+	svc, err := core.NewService(core.ServiceConfig{ConfigDir: configDir, DataDir: dataDir, CacheDir: filepath.Join(dataDir, "cache")})
+	require.NoError(t, err)
+	modPath := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", InstallPath: modPath, ModPath: modPath}
+	require.NoError(t, svc.AddGame(game))
+	require.NoError(t, svc.Close())
+
+	require.NoError(t, os.MkdirAll(filepath.Join(modPath, "RaceMenu"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(modPath, "RaceMenu", "racemenu.esp"), []byte("plugin"), 0644))
+
+	oldGameID, oldName, oldVersion, oldProfile := gameID, adoptName, adoptVersion, adoptProfile
+	gameID = "g1"
+	t.Cleanup(func() {
+		gameID, adoptName, adoptVersion, adoptProfile = oldGameID, oldName, oldVersion, oldProfile
+		rootCmd.SetArgs(nil)
+	})
+
+	rootCmd.SetArgs([]string{"adopt", "RaceMenu", "--name", "RaceMenu", "--version", "0.4", "--game", game.ID})
+	out := captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "✓ Adopted: RaceMenu")
+	assert.Contains(t, out, "Files deployed: 1")
+
+	svc, err = core.NewService(core.ServiceConfig{ConfigDir: configDir, DataDir: dataDir, CacheDir: filepath.Join(dataDir, "cache")})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = svc.Close() })
+	mods, err := svc.GetInstalledMods(game.ID, "default")
+	require.NoError(t, err)
+	require.Len(t, mods, 1)
+	assert.Equal(t, domain.SourceLocal, mods[0].SourceID)
+	assert.Equal(t, "0.4", mods[0].Version)
+	_, owner, found, err := svc.GetFileOwner(game.ID, "default", "RaceMenu/racemenu.esp")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, mods[0].ID, owner)
+}
@@ -22,8 +22,8 @@ var authCmd = &cobra.Command{
 	Short: "Manage authentication for mod sources",
 	Long: `Manage authentication credentials for mod sources.
 
-NexusMods and CurseForge are validated live against the source's API when
-you log in. Any other registered source that declares auth support (a
+NexusMods, CurseForge and mod.io are validated live against the source's
+API when you log in. Any other registered source that declares auth support (a
 custom source with auth enabled in its definition - see 'lmm source
 --help') also accepts a stored API key; it is simply stored and exercised
 on first use, since custom sources have no generic validation endpoint.
@@ -43,18 +43,20 @@ var authLoginCmd = &cobra.Command{
 
 If no source is specified, you are prompted to choose from every
 registered source that declares auth support - the built-ins (NexusMods,
-CurseForge) plus any auth-capable custom source (see 'lmm source
+CurseForge, mod.io) plus any auth-capable custom source (see 'lmm source
 --help'), sorted by ID. A custom source's key is stored and exercised on
 first use, since there is no generic way to validate it live.
 
 Built-in sources:
   - nexusmods
   - curseforge
+  - modio
 
 Examples:
   lmm auth login                # Interactive selection (all auth-capable sources)
   lmm auth login nexusmods      # Authenticate with NexusMods
   lmm auth login curseforge     # Authenticate with CurseForge
+  lmm auth login modio          # Authenticate with mod.io
   lmm auth login my-custom-src  # Store a key for a registered custom source
 
 For NexusMods:
@@ -67,6 +69,11 @@ For CurseForge:
   2. Create a project and generate an API key
   3. Copy your API key
 
+For mod.io:
+  1. Visit https://mod.io/me/access
+  2. Under "API Access", create a read-only API key
+  3. Copy your API key
+
 For a custom source, either enter the key at the prompt, or skip login
 entirely and set an environment variable instead: LMM_MYSOURCE_API_KEY
 for a source with id "mysource" (id uppercased, dashes become
@@ -82,7 +89,7 @@ var authLogoutCmd = &cobra.Command{
 
 If no source is specified, you are prompted to choose from every
 registered source that declares auth support - the built-ins (NexusMods,
-CurseForge) plus any auth-capable custom source, sorted by ID. Any
+CurseForge, mod.io) plus any auth-capable custom source, sorted by ID. Any
 source with a stored token can also be named positionally to remove it -
 including a custom source whose definition file was later deleted, which
 would otherwise leave its stored token unremovable through the
@@ -90,7 +97,8 @@ interactive picker.
 
 Built-in sources:
   - nexusmods
-  - curseforge`,
+  - curseforge
+  - modio`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runAuthLogout,
 }
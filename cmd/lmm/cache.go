@@ -0,0 +1,381 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	cacheKeepPrevious int
+	cacheOlderThan    string
+	cacheDryRun       bool
+)
+
+type cacheEntryJSON struct {
+	Source     string   `json:"source,omitempty"`
+	Mod        string   `json:"mod,omitempty"`
+	Key        string   `json:"key"`
+	Version    string   `json:"version"`
+	Size       int64    `json:"size"`
+	CachedAt   string   `json:"cached_at"`
+	Path       string   `json:"path"`
+	References []string `json:"references"`
+}
+
+type cacheUsageJSON struct {
+	Game         string `json:"game"`
+	Path         string `json:"path"`
+	Versions     int    `json:"versions"`
+	Size         int64  `json:"size"`
+	Unreferenced int64  `json:"unreferenced"`
+	Quota        int64  `json:"quota,omitempty"`
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and clean up the mod cache",
+	Long: `Inspect and clean up the mod cache.
+
+Every downloaded mod version stays in the cache so profile switches,
+redeploys and rollbacks never download it again. A version is referenced
+while any profile lists it, any profile has it installed or as its
+previous version (the rollback target), or a lock pins it. Unreferenced
+versions are what 'lmm cache gc' removes.
+
+Set cache_quota on a game in games.yaml (e.g. cache_quota: 20GB) to
+remove unreferenced versions automatically, oldest first, whenever an
+install or update leaves the cache over the quota.
+
+Set cache_dedup: true in config.yaml to store each distinct file once:
+cached files are hashed (SHA-256) into a blob store and hardlinked into
+their version directories, so versions and games sharing files share the
+disk space. 'lmm cache dedup' converts an existing cache.`,
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List cached mod versions and what references them",
+	Long: `List every cached mod version for a game, with its size and the
+profiles, installed records and locks that keep it.
+
+Examples:
+  lmm cache list --game skyrim-se
+  lmm cache list --game skyrim-se --json`,
+	Args: cobra.NoArgs,
+	RunE: runCacheList,
+}
+
+var cacheDuCmd = &cobra.Command{
+	Use:   "du",
+	Short: "Show cache disk usage per game",
+	Long: `Show how much disk space each game's cache uses, and how much of it is
+unreferenced. Covers every configured game unless --game is given.
+
+Examples:
+  lmm cache du
+  lmm cache du --game skyrim-se`,
+	Args: cobra.NoArgs,
+	RunE: runCacheDu,
+}
+
+var cacheGcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove unreferenced cached mod versions",
+	Long: `Remove cached mod versions nothing references any more.
+
+Versions a profile lists, a profile has installed or as its previous
+version, or a lock pins are always kept.
+
+Examples:
+  lmm cache gc --game skyrim-se --dry-run
+  lmm cache gc --game skyrim-se --keep-previous 1
+  lmm cache gc --game skyrim-se --older-than 30d`,
+	Args: cobra.NoArgs,
+	RunE: runCacheGc,
+}
+
+var cacheDedupCmd = &cobra.Command{
+	Use:   "dedup",
+	Short: "Convert cached mods to deduplicated storage",
+	Long: `Convert the existing cache to content-addressed storage in place: every
+cached file is hashed and hardlinked to a single stored copy of its
+content, so identical files across versions and games take space once.
+Covers every configured game unless --game is given, and is safe to run
+again. Set cache_dedup: true in config.yaml so new downloads are stored
+the same way.
+
+Examples:
+  lmm cache dedup
+  lmm cache dedup --game skyrim-se`,
+	Args: cobra.NoArgs,
+	RunE: runCacheDedup,
+}
+
+func init() {
+	cacheGcCmd.Flags().IntVar(&cacheKeepPrevious, "keep-previous", 0, "also keep the N most recently cached unreferenced versions of each mod")
+	cacheGcCmd.Flags().StringVar(&cacheOlderThan, "older-than", "", "only remove versions cached longer ago than this (e.g. 72h, 30d, 2w)")
+	cacheGcCmd.Flags().BoolVar(&cacheDryRun, "dry-run", false, "show what would be removed without removing it")
+
+	cacheCmd.AddCommand(cacheListCmd)
+	cacheCmd.AddCommand(cacheDuCmd)
+	cacheCmd.AddCommand(cacheGcCmd)
+	cacheCmd.AddCommand(cacheDedupCmd)
+
+	rootCmd.AddCommand(cacheCmd)
+}
+
+func runCacheList(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doCacheList(svc, game)
+	})
+}
+
+func doCacheList(svc *core.Service, game *domain.Game) error {
+	entries, err := svc.ListCacheEntries(game)
+	if err != nil {
+		return fmt.Errorf("listing cache: %w", err)
+	}
+
+	if jsonOutput {
+		rows := make([]cacheEntryJSON, len(entries))
+		for i, e := range entries {
+			rows[i] = cacheEntryJSON{
+				Source: e.SourceID, Mod: e.ModID, Key: e.Key, Version: e.Version, Size: e.Size,
+				CachedAt: e.ModTime.UTC().Format(time.RFC3339), Path: e.Path, References: e.References,
+			}
+			if rows[i].References == nil {
+				rows[i].References = []string{}
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rows); err != nil {
+			return fmt.Errorf("encoding json: %w", err)
+		}
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No cached mods for %s.\n", game.Name)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "MOD\tVERSION\tSIZE\tCACHED\tREFERENCED BY"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, "---\t-------\t----\t------\t-------------"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	var total, unreferenced int64
+	for _, e := range entries {
+		refs := "-"
+		if e.Referenced() {
+			refs = strings.Join(e.References, ", ")
+		} else {
+			unreferenced += e.Size
+		}
+		total += e.Size
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Key, e.Version, formatSize(e.Size), e.ModTime.Local().Format("2006-01-02"), refs); err != nil {
+			return fmt.Errorf("writing row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flushing table: %w", err)
+	}
+	if err := printTable(&buf, 2, nil); err != nil {
+		return err
+	}
+	fmt.Printf("\n%d version(s), %s total, %s unreferenced\n", len(entries), formatSize(total), formatSize(unreferenced))
+	return nil
+}
+
+func runCacheDu(cmd *cobra.Command, args []string) error {
+	return withCacheGames(cmd, func(ctx context.Context, svc *core.Service, games []*domain.Game) error {
+		return doCacheDu(svc, games)
+	})
+}
+
+// withCacheGames runs fn over the game named by --game, or every configured
+// game without it. Unlike most commands, no --game means all games rather
+// than the default one, so requireGame is deliberately not consulted.
+func withCacheGames(cmd *cobra.Command, fn func(ctx context.Context, svc *core.Service, games []*domain.Game) error) error {
+	return withService(cmd, func(ctx context.Context, svc *core.Service) error {
+		games := svc.ListGames()
+		if gameID != "" {
+			game, err := svc.GetGame(gameID)
+			if err != nil {
+				return fmt.Errorf("%w: %s", err, gameID)
+			}
+			games = []*domain.Game{game}
+		}
+		return fn(ctx, svc, games)
+	})
+}
+
+func doCacheDu(svc *core.Service, games []*domain.Game) error {
+	rows := make([]cacheUsageJSON, 0, len(games))
+	for _, game := range games {
+		entries, err := svc.ListCacheEntries(game)
+		if err != nil {
+			return fmt.Errorf("%s: listing cache: %w", game.ID, err)
+		}
+		row := cacheUsageJSON{Game: game.ID, Path: svc.GetGameCachePath(game), Versions: len(entries), Quota: game.CacheQuota}
+		for _, e := range entries {
+			row.Size += e.Size
+			if !e.Referenced() {
+				row.Unreferenced += e.Size
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rows); err != nil {
+			return fmt.Errorf("encoding json: %w", err)
+		}
+		return nil
+	}
+	if len(rows) == 0 {
+		fmt.Println("No games configured.")
+		return nil
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "GAME\tVERSIONS\tSIZE\tUNREFERENCED\tQUOTA"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, "----\t--------\t----\t------------\t-----"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	var total int64
+	for _, r := range rows {
+		quota := "-"
+		if r.Quota > 0 {
+			quota = formatSize(r.Quota)
+		}
+		total += r.Size
+		if _, err := fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", r.Game, r.Versions, formatSize(r.Size), formatSize(r.Unreferenced), quota); err != nil {
+			return fmt.Errorf("writing row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flushing table: %w", err)
+	}
+	if err := printTable(&buf, 2, nil); err != nil {
+		return err
+	}
+	if len(rows) > 1 {
+		fmt.Printf("\nTotal: %s\n", formatSize(total))
+	}
+	return nil
+}
+
+func runCacheGc(cmd *cobra.Command, args []string) error {
+	olderThan, err := parseAge(cacheOlderThan)
+	if err != nil {
+		return fmt.Errorf("--older-than: %w", err)
+	}
+	if cacheKeepPrevious < 0 {
+		return fmt.Errorf("--keep-previous must not be negative")
+	}
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doCacheGc(ctx, svc, game, core.CacheGCOptions{KeepPrevious: cacheKeepPrevious, OlderThan: olderThan, DryRun: cacheDryRun})
+	})
+}
+
+func doCacheGc(ctx context.Context, svc *core.Service, game *domain.Game, opts core.CacheGCOptions) error {
+	result, err := svc.GarbageCollectCache(ctx, game, opts)
+	if result != nil {
+		verb := "Removed"
+		if opts.DryRun {
+			verb = "Would remove"
+		}
+		for _, e := range result.Removed {
+			fmt.Printf("  %s %s %s (%s)\n", verb, e.Key, e.Version, formatSize(e.Size))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("cleaning cache: %w", err)
+	}
+
+	switch {
+	case len(result.Removed) == 0:
+		fmt.Println("Nothing to remove.")
+	case opts.DryRun:
+		fmt.Printf("Would free %s from %d version(s); cache would be %s.\n", formatSize(result.Freed), len(result.Removed), formatSize(result.Size))
+	default:
+		fmt.Printf("✓ Freed %s from %d version(s); cache is now %s.\n", formatSize(result.Freed), len(result.Removed), formatSize(result.Size))
+	}
+	return nil
+}
+
+func runCacheDedup(cmd *cobra.Command, args []string) error {
+	return withCacheGames(cmd, func(ctx context.Context, svc *core.Service, games []*domain.Game) error {
+		return doCacheDedup(ctx, svc, games)
+	})
+}
+
+func doCacheDedup(ctx context.Context, svc *core.Service, games []*domain.Game) error {
+	var total cache.DedupStats
+	for _, game := range games {
+		stats, err := svc.DedupCache(ctx, game)
+		if err != nil {
+			return fmt.Errorf("%s: deduplicating cache: %w", game.ID, err)
+		}
+		fmt.Printf("  %s: %d file(s), %d linked to shared copies, %s freed\n", game.ID, stats.Files, stats.Linked, formatSize(stats.Saved))
+		if stats.Skipped > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %d file(s) could not be linked and were left as copies\n", game.ID, stats.Skipped)
+		}
+		total.Add(stats)
+	}
+	fmt.Printf("✓ Freed %s\n", formatSize(total.Saved))
+	if !svc.CacheDedup() {
+		fmt.Println("Set cache_dedup: true in config.yaml to store new downloads deduplicated too.")
+	}
+	return nil
+}
+
+// printCacheQuota reports a CacheQuotaEnforced event from an install or
+// update.
+func printCacheQuota(p core.DeployProgress) {
+	fmt.Printf("Cache over quota: removed %d unreferenced version(s), freed %s\n", p.Total, formatSize(p.TotalBytes))
+}
+
+// parseAge parses a --older-than value: a Go duration ("72h") or a whole
+// number of days or weeks ("30d", "2w"). Empty means no age limit.
+func parseAge(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	units := map[byte]time.Duration{'d': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if unit, ok := units[s[len(s)-1]]; ok {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid age %q", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q (use e.g. 72h, 30d or 2w)", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCacheCmdTest caches two versions of one mod and has the profile
+// reference only the newer one.
+func setupCacheCmdTest(t *testing.T) *domain.Game {
+	t.Helper()
+
+	configDir = t.TempDir()
+	dataDir = t.TempDir()
+
+	svc, err := core.NewService(core.ServiceConfig{ConfigDir: configDir, DataDir: dataDir, CacheDir: filepath.Join(dataDir, "cache")})
+	require.NoError(t, err)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkSymlink}
+	require.NoError(t, svc.AddGame(game))
+	for _, v := range []string{"1.0", "2.0"} {
+		require.NoError(t, svc.GetGameCache(game).Store(game.ID, "nexusmods", "42", v, "mod.pak", []byte("data")))
+	}
+	require.NoError(t, svc.Close())
+	require.NoError(t, config.SaveProfile(configDir, &domain.Profile{
+		Name: "default", GameID: game.ID,
+		Mods: []domain.ModReference{{SourceID: "nexusmods", ModID: "42", Version: "2.0"}},
+	}))
+
+	oldGameID, oldJSON := gameID, jsonOutput
+	gameID, jsonOutput = "", false
+	t.Cleanup(func() {
+		gameID, jsonOutput = oldGameID, oldJSON
+		cacheKeepPrevious, cacheOlderThan, cacheDryRun = 0, "", false
+		rootCmd.SetArgs(nil)
+	})
+	return game
+}
+
+func TestCacheCmd_ListGcDu(t *testing.T) {
+	game := setupCacheCmdTest(t)
+
+	rootCmd.SetArgs([]string{"cache", "list", "--game", game.ID})
+	out := captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "nexusmods-42")
+	assert.Contains(t, out, "profile default")
+	assert.Contains(t, out, "2 version(s)")
+
+	rootCmd.SetArgs([]string{"cache", "gc", "--game", game.ID, "--dry-run"})
+	out = captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "Would remove nexusmods-42 1.0")
+	cacheDryRun = false
+
+	rootCmd.SetArgs([]string{"cache", "gc", "--game", game.ID})
+	out = captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "Removed nexusmods-42 1.0")
+	assert.Contains(t, out, "✓ Freed")
+
+	gameID = ""
+	rootCmd.SetArgs([]string{"cache", "du", "--json"})
+	out = captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	var rows []cacheUsageJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, "g1", rows[0].Game)
+	assert.Equal(t, 1, rows[0].Versions)
+	assert.Zero(t, rows[0].Unreferenced)
+}
+
+func TestParseAge(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"":    0,
+		"72h": 72 * time.Hour,
+		"30d": 30 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+	} {
+		got, err := parseAge(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, bad := range []string{"d", "-3d", "soon", "-1h"} {
+		_, err := parseAge(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestCacheCmd_Dedup(t *testing.T) {
+	setupCacheCmdTest(t)
+
+	// Both cached versions hold the same bytes.
+	rootCmd.SetArgs([]string{"cache", "dedup"})
+	out := captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "g1: 2 file(s), 1 linked to shared copies")
+	assert.Contains(t, out, "cache_dedup: true", "points at the setting that keeps new downloads deduplicated")
+}
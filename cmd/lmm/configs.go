@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+
+	"github.com/spf13/cobra"
+)
+
+var configsProfile string
+
+type configEditJSON struct {
+	Path     string `json:"path"`
+	ModID    string `json:"mod_id"`
+	SourceID string `json:"source_id"`
+	ModName  string `json:"mod_name"`
+}
+
+var configsCmd = &cobra.Command{
+	Use:   "configs",
+	Short: "Manage local edits to mods' config files",
+	Long: `Manage local edits to the config files mods deploy (.ini, .json,
+.toml, .xml).
+
+A deployed config file counts as edited when its content in the game
+directory differs from the mod's cached copy. That requires a real copy
+in the game directory: with the copy or reflink link method every edit
+shows up; with symlink or hardlink, an in-place edit writes straight
+through to the cache and cannot be told apart from the mod's own file.
+
+'lmm update' keeps these edits: it three-way merges old upstream, new
+upstream, and your copy. A conflicting merge keeps the new upstream file
+and saves your copy as <file>.lmm-local under lmm's data directory
+(config-conflicts/<game>/<profile>/), outside the game directory.
+
+Deploys and profile switches still re-deploy the mod's own copy, so use
+'lmm configs save' to make edits permanent in the profile.`,
+}
+
+var configsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List locally edited config files",
+	Long: `List the deployed config files whose content differs from the mod's
+cached copy.
+
+Examples:
+  lmm configs list --game skyrim-se
+  lmm configs list --game skyrim-se --json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigsList,
+}
+
+var configsSaveCmd = &cobra.Command{
+	Use:   "save [path ...]",
+	Short: "Store local config edits in the profile",
+	Long: `Store locally edited config files in the profile so every deploy and
+profile switch re-applies them.
+
+An .ini file is stored as ini_patches: only the keys that differ from
+the mod's copy, so later upstream changes to other keys still come
+through. Other formats are stored whole as overrides. Paths are as
+'lmm configs list' prints them; with none, every edited file is saved.
+
+Examples:
+  lmm configs save --game skyrim-se
+  lmm configs save SKSE/Plugins/settings.ini --game skyrim-se`,
+	RunE: runConfigsSave,
+}
+
+func init() {
+	configsCmd.PersistentFlags().StringVarP(&configsProfile, "profile", "p", "", "profile (default: active profile)")
+
+	configsCmd.AddCommand(configsListCmd)
+	configsCmd.AddCommand(configsSaveCmd)
+
+	rootCmd.AddCommand(configsCmd)
+}
+
+func runConfigsList(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doConfigsList(svc, game)
+	})
+}
+
+func doConfigsList(svc *core.Service, game *domain.Game) error {
+	profileName, err := resolveProfile(svc, game.ID, configsProfile)
+	if err != nil {
+		return err
+	}
+	edits, err := svc.ModifiedConfigs(game, profileName)
+	if err != nil {
+		return fmt.Errorf("checking config files: %w", err)
+	}
+
+	if jsonOutput {
+		rows := make([]configEditJSON, len(edits))
+		for i, e := range edits {
+			rows[i] = configEditJSON{Path: e.Path, ModID: e.ModID, SourceID: e.SourceID, ModName: e.ModName}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rows); err != nil {
+			return fmt.Errorf("encoding json: %w", err)
+		}
+		return nil
+	}
+
+	if len(edits) == 0 {
+		fmt.Println("No locally edited config files.")
+		return nil
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "PATH\tMOD"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, "----\t---"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	for _, e := range edits {
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", e.Path, e.ModName); err != nil {
+			return fmt.Errorf("writing row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flushing table: %w", err)
+	}
+	return printTable(&buf, 2, nil)
+}
+
+func runConfigsSave(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doConfigsSave(svc, game, args)
+	})
+}
+
+func doConfigsSave(svc *core.Service, game *domain.Game, paths []string) error {
+	profileName, err := resolveProfile(svc, game.ID, configsProfile)
+	if err != nil {
+		return err
+	}
+	saved, err := svc.SaveConfigEdits(game, profileName, paths)
+	if err != nil {
+		return fmt.Errorf("saving config edits: %w", err)
+	}
+	if len(saved) == 0 {
+		fmt.Println("No locally edited config files to save.")
+		return nil
+	}
+	for _, sc := range saved {
+		if sc.Keys > 0 {
+			fmt.Printf("✓ %s: saved %d key(s) as ini_patches\n", sc.ProfilePath, sc.Keys)
+		} else {
+			fmt.Printf("✓ %s: saved as override\n", sc.ProfilePath)
+		}
+	}
+	fmt.Printf("Profile %s updated.\n", profileName)
+	return nil
+}
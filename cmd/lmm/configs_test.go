@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConfigsCmd_ListAndSave copy-deploys a mod shipping an INI file, edits
+// the deployed copy, and drives `configs list` and `configs save` end to end.
+func TestConfigsCmd_ListAndSave(t *testing.T) {
+	configDir = t.TempDir()
+	dataDir = t.TempDir()
+
+	svc, err := core.NewService(core.ServiceConfig{ConfigDir: configDir, DataDir: dataDir, CacheDir: filepath.Join(dataDir, "cache")})
+	require.NoError(t, err)
+	install := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", InstallPath: install, ModPath: install, LinkMethod: domain.LinkCopy, LinkMethodExplicit: true}
+	require.NoError(t, svc.AddGame(game))
+	seedConflictMod(t, svc, game, "a", "Mod A", true, map[string][]byte{"settings.ini": []byte("[General]\nfSpeed=1.0\n")})
+	_, err = svc.DeployProfile(context.Background(), game, "default", core.DeployOptions{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, svc.Close())
+
+	oldGameID, oldProfile, oldJSON := gameID, configsProfile, jsonOutput
+	gameID, configsProfile, jsonOutput = "g1", "", false
+	t.Cleanup(func() {
+		gameID, configsProfile, jsonOutput = oldGameID, oldProfile, oldJSON
+		rootCmd.SetArgs(nil)
+	})
+
+	require.NoError(t, os.WriteFile(filepath.Join(install, "settings.ini"), []byte("[General]\nfSpeed=2.0\n"), 0644))
+
+	rootCmd.SetArgs([]string{"configs", "list", "--game", game.ID, "--json"})
+	out := captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	var rows []configEditJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, configEditJSON{Path: "settings.ini", ModID: "a", SourceID: "src", ModName: "Mod A"}, rows[0])
+	jsonOutput = false
+
+	rootCmd.SetArgs([]string{"configs", "save", "--game", game.ID})
+	out = captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "✓ settings.ini: saved 1 key(s) as ini_patches")
+
+	profile, err := config.LoadProfile(configDir, game.ID, "default")
+	require.NoError(t, err)
+	require.NotNil(t, profile.IniPatches["settings.ini"]["General"]["fSpeed"])
+	assert.Equal(t, "2.0", *profile.IniPatches["settings.ini"]["General"]["fSpeed"])
+}
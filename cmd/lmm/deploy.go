@@ -25,7 +25,7 @@ var deployCmd = &cobra.Command{
 	Short: "Deploy mods to game directory",
 	Long: `Deploy mod files from cache to game directory.
 
-Use this when changing deployment methods (symlink, hardlink, copy)
+Use this when changing deployment methods (symlink, hardlink, copy, reflink)
 or if mod files need to be refreshed.
 
 Without a mod ID, deploys all enabled mods in the current profile.
@@ -43,6 +43,7 @@ Examples:
   lmm deploy --game skyrim-se
   lmm deploy --game skyrim-se --all
   lmm deploy --game skyrim-se --method hardlink
+  lmm deploy --game skyrim-se --method reflink
   lmm deploy --game skyrim-se --purge
   lmm deploy 12345 --game skyrim-se
   lmm deploy 12345 --game skyrim-se --source curseforge
@@ -54,7 +55,7 @@ Examples:
 func init() {
 	deployCmd.Flags().StringVarP(&deploySource, "source", "s", "", "mod source for deploying a single mod ID (default: auto-detect when the game has one configured source, prompt when it has several)")
 	deployCmd.Flags().StringVarP(&deployProfile, "profile", "p", "", "profile (default: active profile)")
-	deployCmd.Flags().StringVarP(&deployMethod, "method", "m", "", "link method: symlink, hardlink, or copy (default: game's configured method)")
+	deployCmd.Flags().StringVarP(&deployMethod, "method", "m", "", "link method: symlink, hardlink, copy, or reflink (default: game's configured method)")
 	deployCmd.Flags().BoolVar(&deployPurge, "purge", false, "purge all deployed mods before deploying")
 	deployCmd.Flags().BoolVarP(&deployAll, "all", "a", false, "deploy all mods including disabled ones")
 	deployCmd.Flags().BoolVarP(&deployForce, "force", "f", false, "continue even if hooks fail")
@@ -76,16 +77,9 @@ func doDeploy(ctx context.Context, service *core.Service, game *domain.Game, arg
 
 	var linkMethodOverride *domain.LinkMethod
 	if deployMethod != "" {
-		var m domain.LinkMethod
-		switch deployMethod {
-		case "symlink":
-			m = domain.LinkSymlink
-		case "hardlink":
-			m = domain.LinkHardlink
-		case "copy":
-			m = domain.LinkCopy
-		default:
-			return fmt.Errorf("invalid link method: %s (use: symlink, hardlink, or copy)", deployMethod)
+		m, ok := domain.ParseLinkMethod(deployMethod)
+		if !ok {
+			return fmt.Errorf("invalid link method: %s (use one of: %s)", deployMethod, domain.ValidLinkMethods)
 		}
 		linkMethodOverride = &m
 	}
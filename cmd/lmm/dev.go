@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	devProfile  string
+	devLinkAs   string
+	devLinkName string
+	devWatch    bool
+)
+
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Link mods you are developing straight into the game",
+	Long: `Deploy a mod's working directory straight into the game for testing.
+
+A dev mod is symlinked file by file from the working directory, with
+nothing copied into the cache, so a saved edit is live in the game at
+once. Dev mods are skipped by update checks and verify, and are not
+written to the profile: the path only exists on this machine.`,
+}
+
+var devLinkCmd = &cobra.Command{
+	Use:   "link <path>",
+	Short: "Link a working directory into the game as a dev mod",
+	Long: `Link every file in a working directory into the game's mod directory.
+
+The directory is laid out like the mod's contents in the mod directory.
+Dot-files and dot-directories (.git, .vscode) are left out. The mod ID
+defaults to the directory's name; link again to pick up added or removed
+files, or pass --watch to keep doing so until interrupted.
+
+Linking refuses to replace a file another mod owns or a file lmm does not
+track; nothing is linked in that case.
+
+Examples:
+  lmm dev link ~/Projects/mods/my-mod --game skyrim-se
+  lmm dev link ./build --as my-mod --watch`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDevLink,
+}
+
+var devUnlinkCmd = &cobra.Command{
+	Use:   "unlink <mod-id>",
+	Short: "Remove a dev mod's links from the game",
+	Long: `Remove every link a dev mod placed in the game directory and forget the
+mod. The working directory itself is left untouched.
+
+Examples:
+  lmm dev unlink my-mod --game skyrim-se`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDevUnlink,
+}
+
+func init() {
+	devCmd.PersistentFlags().StringVarP(&devProfile, "profile", "p", "", "profile (default: active profile)")
+
+	devLinkCmd.Flags().StringVar(&devLinkAs, "as", "", "mod ID to link as (default: the directory's name)")
+	devLinkCmd.Flags().StringVarP(&devLinkName, "name", "n", "", "display name (default: the mod ID)")
+	devLinkCmd.Flags().BoolVarP(&devWatch, "watch", "w", false, "keep running and re-link files as they are added or removed")
+
+	devCmd.AddCommand(devLinkCmd)
+	devCmd.AddCommand(devUnlinkCmd)
+	rootCmd.AddCommand(devCmd)
+}
+
+func runDevLink(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doDevLink(ctx, svc, game, args[0])
+	})
+}
+
+func doDevLink(ctx context.Context, svc *core.Service, game *domain.Game, path string) error {
+	profileName, err := resolveProfile(svc, game.ID, devProfile)
+	if err != nil {
+		return err
+	}
+
+	result, err := svc.DevLink(ctx, game, profileName, path, core.DevLinkOptions{ModID: devLinkAs, Name: devLinkName})
+	if err != nil {
+		return fmt.Errorf("dev link failed: %w", err)
+	}
+	if verbose {
+		for _, f := range result.Files {
+			fmt.Printf("  + %s\n", f)
+		}
+	}
+	fmt.Printf("✓ Linked: %s\n", result.Mod.Name)
+	fmt.Printf("  ID: %s\n", result.Mod.ID)
+	fmt.Printf("  From: %s\n", result.Mod.DevPath)
+	fmt.Printf("  Files linked: %d\n", len(result.Files))
+
+	if !devWatch {
+		return nil
+	}
+	fmt.Println("Watching for added or removed files (Ctrl+C to stop)...")
+	return svc.WatchDevMod(ctx, game, profileName, result.Mod.ID, func(r *core.DevSyncResult, err error) {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: re-link failed: %v\n", err)
+			return
+		}
+		for _, f := range r.Added {
+			fmt.Printf("  + %s\n", f)
+		}
+		for _, f := range r.Removed {
+			fmt.Printf("  - %s\n", f)
+		}
+	})
+}
+
+func runDevUnlink(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doDevUnlink(ctx, svc, game, args[0])
+	})
+}
+
+func doDevUnlink(ctx context.Context, svc *core.Service, game *domain.Game, modID string) error {
+	profileName, err := resolveProfile(svc, game.ID, devProfile)
+	if err != nil {
+		return err
+	}
+	removed, err := svc.DevUnlink(ctx, game, profileName, modID)
+	if err != nil {
+		return fmt.Errorf("dev unlink failed: %w", err)
+	}
+	fmt.Printf("✓ Unlinked: %s (%d link(s) removed)\n", modID, removed)
+	return nil
+}
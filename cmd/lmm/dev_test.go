@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDevCmd_LinkAndUnlink links a working directory as a dev mod and
+// unlinks it again, checking the game directory at each step.
+func TestDevCmd_LinkAndUnlink(t *testing.T) {
+	configDir = t.TempDir()
+	dataDir = t.TempDir()
+
+	svc, err := core.NewService(core.ServiceConfig{ConfigDir: configDir, DataDir: dataDir, CacheDir: filepath.Join(dataDir, "cache")})
+	require.NoError(t, err)
+	modPath := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", InstallPath: modPath, ModPath: modPath}
+	require.NoError(t, svc.AddGame(game))
+	require.NoError(t, svc.Close())
+
+	work := filepath.Join(t.TempDir(), "my-mod")
+	require.NoError(t, os.MkdirAll(filepath.Join(work, "plugins"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(work, "plugins", "my-mod.esp"), []byte("plugin"), 0644))
+
+	oldGameID, oldAs, oldName, oldWatch, oldProfile := gameID, devLinkAs, devLinkName, devWatch, devProfile
+	gameID = "g1"
+	t.Cleanup(func() {
+		gameID, devLinkAs, devLinkName, devWatch, devProfile = oldGameID, oldAs, oldName, oldWatch, oldProfile
+		rootCmd.SetArgs(nil)
+	})
+
+	rootCmd.SetArgs([]string{"dev", "link", work, "--game", game.ID})
+	out := captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "✓ Linked: my-mod")
+	assert.Contains(t, out, "Files linked: 1")
+
+	deployed := filepath.Join(modPath, "plugins", "my-mod.esp")
+	target, err := os.Readlink(deployed)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(work, "plugins", "my-mod.esp"), target)
+
+	rootCmd.SetArgs([]string{"dev", "unlink", "my-mod", "--game", game.ID})
+	out = captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "✓ Unlinked: my-mod (1 link(s) removed)")
+	_, err = os.Lstat(deployed)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -83,7 +83,7 @@ func doGameAdd(ctx context.Context, cmd *cobra.Command, reader *bufio.Reader, se
 	}
 	selected := sources[choice-1]
 
-	if catalog, ok := selected.(source.GameCatalog); ok {
+	if catalog, ok := source.GameCatalogOf(selected); ok {
 		return runGameAddCatalog(ctx, cmd, reader, catalog, selected.ID(), selected.Name())
 	}
 	return runGameAddManual(cmd, reader, selected.ID(), selected.Name())
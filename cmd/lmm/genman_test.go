@@ -127,8 +127,8 @@ func TestGenManTree_AngleBracketArgsSurviveForEveryCommand(t *testing.T) {
 	}
 	walk(rootCmd)
 
-	assert.Equal(t, 19, checked,
-		"expected exactly 19 commands with angle-bracket Use args; update this count if the command tree changed")
+	assert.Equal(t, 32, checked,
+		"expected exactly 32 commands with angle-bracket Use args; update this count if the command tree changed")
 }
 
 var angleBracketArgsRE = regexp.MustCompile(`<[^>]+>`)
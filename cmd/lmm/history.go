@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/db"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyLimit int
+	undoYes      bool
+)
+
+type operationJSON struct {
+	ID          int64             `json:"id"`
+	Time        string            `json:"time"`
+	Game        string            `json:"game"`
+	Profile     string            `json:"profile"`
+	Operation   string            `json:"operation"`
+	Mods        []db.OperationMod `json:"mods"`
+	OrderBefore []string          `json:"order_before,omitempty"`
+	OrderAfter  []string          `json:"order_after,omitempty"`
+	FromProfile string            `json:"from_profile,omitempty"`
+	UndoOf      int64             `json:"undo_of,omitempty"`
+	UndoneBy    int64             `json:"undone_by,omitempty"`
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show what lmm has changed",
+	Long: `Show the operation history, newest first: every install, uninstall,
+enable, disable, reorder, update, rollback, profile switch, profile
+import, deploy and purge, with the mods it touched and their versions and
+enabled state before and after. Covers every game unless --game is given.
+
+Examples:
+  lmm history
+  lmm history --game skyrim-se --limit 50`,
+	Args: cobra.NoArgs,
+	RunE: runHistory,
+}
+
+var undoCmd = &cobra.Command{
+	Use:   "undo [op-id]",
+	Short: "Revert an operation from the history",
+	Long: `Revert an operation from 'lmm history' by running its inverse: an
+enable is disabled, an install uninstalled (its cache is kept), an update
+or rollback rolled back, a reorder restored and a profile switch switched
+back. Without an ID, the latest operation that can be undone is reverted
+(for --game's game only, when given).
+
+Uninstalls, profile imports, deploys and purges cannot be undone (run
+'lmm purge' or 'lmm deploy' instead). An operation is also refused when
+the mods it touched have changed since, so undo never overwrites a later
+change. An undo is itself recorded, and undoing it
+redoes the original operation.
+
+Examples:
+  lmm undo
+  lmm undo 42 --yes`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runUndo,
+}
+
+func init() {
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "show at most this many entries (0 for all)")
+	undoCmd.Flags().BoolVarP(&undoYes, "yes", "y", false, "undo without asking for confirmation")
+
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(undoCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	return withService(cmd, func(ctx context.Context, svc *core.Service) error {
+		return doHistory(svc, gameID, historyLimit)
+	})
+}
+
+func doHistory(svc *core.Service, gameID string, limit int) error {
+	if gameID != "" {
+		if _, err := svc.GetGame(gameID); err != nil {
+			return fmt.Errorf("%w: %s", err, gameID)
+		}
+	}
+	ops, err := svc.History(gameID, limit)
+	if err != nil {
+		return fmt.Errorf("reading history: %w", err)
+	}
+
+	if jsonOutput {
+		rows := make([]operationJSON, len(ops))
+		for i, op := range ops {
+			rows[i] = operationJSON{
+				ID: op.ID, Time: op.CreatedAt.UTC().Format(time.RFC3339), Game: op.GameID, Profile: op.Profile,
+				Operation: op.Operation, Mods: op.Mods, OrderBefore: op.OrderBefore, OrderAfter: op.OrderAfter,
+				FromProfile: op.FromProfile, UndoOf: op.UndoOf, UndoneBy: op.UndoneBy,
+			}
+			if rows[i].Mods == nil {
+				rows[i].Mods = []db.OperationMod{}
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rows); err != nil {
+			return fmt.Errorf("encoding json: %w", err)
+		}
+		return nil
+	}
+
+	if len(ops) == 0 {
+		fmt.Println("No operations recorded.")
+		return nil
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "ID\tWHEN\tGAME\tPROFILE\tOPERATION\tDETAILS"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, "--\t----\t----\t-------\t---------\t-------"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	for _, op := range ops {
+		details := describeOperation(&op)
+		switch {
+		case op.UndoneBy != 0:
+			details += fmt.Sprintf(" (undone by #%d)", op.UndoneBy)
+		case op.UndoOf != 0:
+			details += fmt.Sprintf(" (undo of #%d)", op.UndoOf)
+		}
+		if _, err := fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", op.ID, op.CreatedAt.Local().Format("2006-01-02 15:04"), op.GameID, op.Profile, op.Operation, details); err != nil {
+			return fmt.Errorf("writing row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flushing table: %w", err)
+	}
+	return printTable(&buf, 2, nil)
+}
+
+// describeOperation summarizes what op changed in one line.
+func describeOperation(op *db.Operation) string {
+	switch op.Operation {
+	case db.OpReorder:
+		return fmt.Sprintf("load order of %d mod(s)", len(op.OrderAfter))
+	case db.OpSwitch:
+		return "from " + op.FromProfile
+	case db.OpImport:
+		return fmt.Sprintf("%d mod(s) changed", len(op.Mods))
+	case db.OpDeploy:
+		return fmt.Sprintf("%d mod(s) deployed", len(op.Mods))
+	case db.OpPurge:
+		return fmt.Sprintf("%d mod(s) purged", len(op.Mods))
+	}
+
+	parts := make([]string, len(op.Mods))
+	for i, m := range op.Mods {
+		name := m.Name
+		if name == "" {
+			name = m.SourceID + ":" + m.ModID
+		}
+		switch {
+		case op.Operation == db.OpEnable || op.Operation == db.OpDisable:
+			parts[i] = name
+		case m.AfterVersion == "":
+			parts[i] = name + " " + m.BeforeVersion
+		case m.BeforeVersion == "" || m.BeforeVersion == m.AfterVersion:
+			parts[i] = name + " " + m.AfterVersion
+		default:
+			parts[i] = fmt.Sprintf("%s %s → %s", name, m.BeforeVersion, m.AfterVersion)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func runUndo(cmd *cobra.Command, args []string) error {
+	return withService(cmd, func(ctx context.Context, svc *core.Service) error {
+		return doUndo(ctx, svc, gameID, args)
+	})
+}
+
+func doUndo(ctx context.Context, svc *core.Service, gameID string, args []string) error {
+	var op *db.Operation
+	if len(args) == 1 {
+		id, err := strconv.ParseInt(strings.TrimPrefix(args[0], "#"), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid operation ID %q", args[0])
+		}
+		if op, err = svc.GetOperation(id); err != nil {
+			return fmt.Errorf("%w: #%d", err, id)
+		}
+	} else {
+		var err error
+		if op, err = svc.LatestUndoable(gameID); err != nil {
+			if errors.Is(err, core.ErrNothingToUndo) {
+				fmt.Println("Nothing to undo.")
+				return nil
+			}
+			return fmt.Errorf("reading history: %w", err)
+		}
+	}
+
+	game, err := svc.GetGame(op.GameID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, op.GameID)
+	}
+	if err := core.Reversible(op); err != nil {
+		return fmt.Errorf("cannot undo #%d %s: %w", op.ID, op.Operation, err)
+	}
+
+	fmt.Printf("Undo #%d %s in %s/%s: %s\n", op.ID, op.Operation, op.GameID, op.Profile, describeOperation(op))
+	if !undoYes {
+		fmt.Print("\nProceed? [Y/n]: ")
+		input, err := readPromptLine()
+		if err != nil {
+			return err
+		}
+		if input != "" && input != "y" && input != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	result, err := svc.Undo(ctx, game, op)
+	if result != nil {
+		if verbose {
+			for _, n := range result.Notes {
+				fmt.Printf("  %s\n", n)
+			}
+		}
+		for _, w := range result.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", w)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Undid #%d %s\n", op.ID, op.Operation)
+	return nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoUndo_RevertsLatestReversibleOperation(t *testing.T) {
+	svc, game, src := setupDoProfileImportTest(t)
+	require.NoError(t, svc.AddGame(game))
+	src.AddMod(&domain.Mod{ID: "mod1", SourceID: "test-src", Name: "Mod One", Version: "1.0", GameID: "g1"},
+		[]domain.DownloadableFile{{ID: "main", FileName: "mod1.esp", IsPrimary: true}})
+	src.AddDownload("main", []byte("mod1 content"))
+
+	data := buildImportProfileData(t, "g1", "target", []domain.ModReference{{SourceID: "test-src", ModID: "mod1", Version: "1.0"}})
+	withStdin(t, "y\n", func() {
+		_ = captureStdout(t, func() error {
+			return doProfileImport(context.Background(), svc, game, data, nil)
+		})
+	})
+	_, err := svc.DisableMod(context.Background(), game, "target", "test-src", "mod1")
+	require.NoError(t, err)
+
+	out := captureStdout(t, func() error {
+		return doHistory(svc, "", 0)
+	})
+	assert.Regexp(t, `(?m)^2 +\S+ \S+ +g1 +target +disable +Mod One$`, out)
+	assert.Regexp(t, `(?m)^1 +\S+ \S+ +g1 +target +import +1 mod\(s\) changed$`, out)
+
+	oldYes := undoYes
+	undoYes = true
+	t.Cleanup(func() { undoYes = oldYes })
+
+	out = captureStdout(t, func() error {
+		return doUndo(context.Background(), svc, "", nil)
+	})
+	assert.Equal(t, "Undo #2 disable in g1/target: Mod One\n✓ Undid #2 disable\n", out)
+	mod, err := svc.GetInstalledMod("test-src", "mod1", "g1", "target")
+	require.NoError(t, err)
+	assert.True(t, mod.Enabled)
+
+	out = captureStdout(t, func() error {
+		return doHistory(svc, "g1", 1)
+	})
+	assert.Regexp(t, `(?m)^3 .* enable +Mod One \(undo of #2\)$`, out)
+
+	out = captureStdout(t, func() error {
+		return doUndo(context.Background(), svc, "", nil)
+	})
+	assert.Equal(t, "Nothing to undo.\n", out)
+
+	err = doUndo(context.Background(), svc, "", []string{"1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot undo #1 import: imports cannot be undone")
+}
@@ -696,6 +696,8 @@ func doInstall(ctx context.Context, service *core.Service, game *domain.Game, ar
 			}
 		case core.InstallWarning:
 			fmt.Fprintf(os.Stderr, "Warning: %s\n", p.Detail)
+		case core.CacheQuotaEnforced:
+			printCacheQuota(p)
 		}
 	}
 
@@ -832,6 +834,8 @@ func doInstallBatch(ctx context.Context, service *core.Service, game *domain.Gam
 			}
 		case core.InstallWarning:
 			fmt.Fprintf(os.Stderr, "Warning: %s\n", p.Detail)
+		case core.CacheQuotaEnforced:
+			printCacheQuota(p)
 		}
 	}
 
@@ -1326,6 +1330,10 @@ func batchInstallMods(ctx context.Context, service *core.Service, game *domain.G
 		if !skipVerify && downloadResult.Checksum != "" {
 			if err := service.SaveFileChecksum(sourceID, mod.ID, game.ID, profileName, selectedFile.ID, downloadResult.Checksum); err != nil {
 				fmt.Fprintf(os.Stderr, "  Warning: failed to save checksum: %v\n", err)
+			} else if downloadResult.SHA256 != "" {
+				if err := service.SaveFileDigest(sourceID, mod.ID, game.ID, profileName, selectedFile.ID, downloadResult.SHA256, downloadResult.Size); err != nil {
+					fmt.Fprintf(os.Stderr, "  Warning: failed to save SHA-256 digest: %v\n", err)
+				}
 			}
 		}
 
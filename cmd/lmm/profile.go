@@ -1,10 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"text/tabwriter"
 
@@ -39,18 +44,76 @@ var profileCreateCmd = &cobra.Command{
 	Short: "Create a new profile",
 	Long: `Create a new empty profile for the specified game.
 
+With --extends, the new profile extends an existing one instead: it starts
+out with the base's mods and settings, and stores only its own changes -
+mods it adds, removes, reorders or pins to another version. A later change
+to the base reaches every profile extending it (run 'lmm profile apply' on
+an extending profile to install what it gained).
+
 Examples:
-  lmm profile create survival --game skyrim-se`,
+  lmm profile create survival --game skyrim-se
+  lmm profile create hardcore --extends survival --game skyrim-se`,
 	Args: cobra.ExactArgs(1),
 	RunE: runProfileCreate,
 }
 
+var profileShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show a profile's mods and settings",
+	Long: `Show a profile's mods and settings. If no name is given, shows the
+current/default profile.
+
+For a profile that extends another, this shows the profile as written: the
+mods it adds or overrides, the inherited mods, overrides and INI patch
+keys it removes, and any load order it sets. With --resolved, it shows the effective profile instead -
+its base's mods with its own changes applied, in load order, each with the
+profile it comes from.
+
+Examples:
+  lmm profile show --game skyrim-se
+  lmm profile show survival --game skyrim-se --resolved
+  lmm profile show survival --game skyrim-se --resolved --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runProfileShow,
+}
+
+var profileDiffCmd = &cobra.Command{
+	Use:   "diff <a> [b]",
+	Short: "Compare two profiles, or a profile and the game",
+	Long: `Compare two profiles: mods added or removed, version and lock
+differences, load order moves, and link method, override and INI patch
+differences, reading from a to b.
+
+Either side may be a profile name or the path of a profile YAML file,
+such as one a teammate exported. With --deployed, a is compared with what
+is actually deployed for it: the deployed mods' versions and files, and
+the current content of its override files and patched INI keys. If a is
+omitted, the current/default profile is used.
+
+A version or file set that one side leaves out does not count as a
+difference.
+
+Examples:
+  lmm profile diff survival hardcore --game skyrim-se
+  lmm profile diff survival teammate.yaml --game skyrim-se
+  lmm profile diff --deployed --game skyrim-se
+  lmm profile diff survival hardcore --game skyrim-se --json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if profileDiffDeployed {
+			return cobra.MaximumNArgs(1)(cmd, args)
+		}
+		return cobra.ExactArgs(2)(cmd, args)
+	},
+	RunE: runProfileDiff,
+}
+
 var profileDeleteCmd = &cobra.Command{
 	Use:   "delete <name>",
 	Short: "Delete a profile",
 	Long: `Delete a profile and its configuration.
 
 Note: This does not remove the installed mods, only the profile configuration.
+A profile other profiles extend cannot be deleted until they are.
 
 Examples:
   lmm profile delete old-profile --game skyrim-se`,
@@ -94,14 +157,76 @@ confirmation prompt. Use --no-install to skip that entirely and just
 save the profile, installing nothing. Use --force to overwrite an
 existing profile with the same name instead of failing.
 
+Use --frozen to install exactly what a lockfile pins (see 'lmm profile
+lock'): the lockfile is read from beside the profile file (survival.yaml
+pairs with survival.lock), every mod to download must be in it, and any
+download whose SHA-256 differs from the locked one is refused.
+
 Examples:
   lmm profile import survival.yaml --game skyrim-se
   lmm profile import survival.yaml --game skyrim-se --no-install
-  lmm profile import survival.yaml --game skyrim-se --force`,
+  lmm profile import survival.yaml --game skyrim-se --force
+  lmm profile import survival.yaml --game skyrim-se --frozen`,
 	Args: cobra.ExactArgs(1),
 	RunE: runProfileImport,
 }
 
+var profileLockCmd = &cobra.Command{
+	Use:   "lock <name>",
+	Short: "Pin a profile's mod files to their checksums",
+	Long: `Write a lockfile recording the SHA-256 and size of every file the
+profile's mods were downloaded from, next to the profile.
+
+An exported profile names files by source ID only, so a file re-uploaded
+under the same ID would install silently elsewhere. Ship the lockfile with
+the export (as <name>.lock beside <name>.yaml) and use
+'lmm profile import --frozen' or 'lmm profile apply --frozen' to refuse
+anything that does not match. Every mod must be installed at the
+profile's version; mods installed before lockfiles existed need
+reinstalling once so their checksums are recorded.
+
+Examples:
+  lmm profile lock survival --game skyrim-se`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileLock,
+}
+
+var profilePackCmd = &cobra.Command{
+	Use:   "pack <name>",
+	Short: "Bundle a profile and its cached mods into one file",
+	Long: `Write a profile and every mod version it uses, straight from the cache,
+into a single .lmmpack archive.
+
+Unlike 'lmm profile export', a pack needs no network access or source
+account to install: 'lmm profile unpack' seeds the cache from it directly.
+Use it for LAN parties, offline machines, or mods that have since been
+removed upstream. Every mod in the profile must be fully cached; run
+'lmm deploy' first if some are not.
+
+Examples:
+  lmm profile pack survival --game skyrim-se
+  lmm profile pack survival --game skyrim-se -o /mnt/usb/survival.lmmpack`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfilePack,
+}
+
+var profileUnpackCmd = &cobra.Command{
+	Use:   "unpack <file>",
+	Short: "Import a profile from an .lmmpack",
+	Long: `Import a profile written by 'lmm profile pack', installing its mods from
+the pack without contacting any mod source.
+
+The pack's mod versions are added to the cache first (versions already
+cached are kept), then the profile is imported as with 'lmm profile import'.
+Mods keep their source identities, so 'lmm update' works for them later.
+
+Examples:
+  lmm profile unpack survival.lmmpack --game skyrim-se
+  lmm profile unpack survival.lmmpack --game skyrim-se --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileUnpack,
+}
+
 var profileSyncCmd = &cobra.Command{
 	Use:   "sync [name]",
 	Short: "Sync profile to match installed mods",
@@ -148,10 +273,15 @@ Use this after manually editing a profile YAML to apply those changes.
 If no name is given, uses the current/default profile. Prompts for
 confirmation before making any changes; pass -y/--yes to skip the prompt.
 
+With --frozen, every mod to install must be pinned in the profile's
+lockfile (see 'lmm profile lock') and every download must match its
+locked SHA-256.
+
 Examples:
   lmm profile apply --game skyrim-se
   lmm profile apply survival --game skyrim-se
-  lmm profile apply survival --game skyrim-se --yes`,
+  lmm profile apply survival --game skyrim-se --yes
+  lmm profile apply survival --game skyrim-se --frozen`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runProfileApply,
 }
@@ -159,27 +289,49 @@ Examples:
 var (
 	profileImportForce     bool
 	profileImportNoInstall bool
+	profileImportFrozen    bool
+	profilePackOutput      string
 	profileApplyYes        bool
+	profileApplyFrozen     bool
 	profileReorderProfile  string
+	profileCreateExtends   string
+	profileShowResolved    bool
+	profileDiffDeployed    bool
 )
 
 func init() {
 	profileCmd.AddCommand(profileListCmd)
 	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileShowCmd)
+	profileCmd.AddCommand(profileDiffCmd)
 	profileCmd.AddCommand(profileDeleteCmd)
 	profileCmd.AddCommand(profileSwitchCmd)
 	profileCmd.AddCommand(profileExportCmd)
 	profileCmd.AddCommand(profileImportCmd)
+	profileCmd.AddCommand(profileLockCmd)
+	profileCmd.AddCommand(profilePackCmd)
+	profileCmd.AddCommand(profileUnpackCmd)
 	profileCmd.AddCommand(profileSyncCmd)
 	profileCmd.AddCommand(profileReorderCmd)
 	profileCmd.AddCommand(profileApplyCmd)
 
+	profileCreateCmd.Flags().StringVar(&profileCreateExtends, "extends", "", "base profile for the new profile to extend")
+	profileShowCmd.Flags().BoolVar(&profileShowResolved, "resolved", false, "show the effective profile, with inherited mods merged in")
+	profileDiffCmd.Flags().BoolVar(&profileDiffDeployed, "deployed", false, "compare the profile with what is deployed in the game")
+
 	// Import flags
 	profileImportCmd.Flags().BoolVar(&profileImportForce, "force", false, "overwrite existing profile")
 	profileImportCmd.Flags().BoolVar(&profileImportNoInstall, "no-install", false, "skip installing missing mods")
+	profileImportCmd.Flags().BoolVar(&profileImportFrozen, "frozen", false, "refuse downloads that do not match the profile's lockfile")
+
+	// Pack/unpack flags
+	profilePackCmd.Flags().StringVarP(&profilePackOutput, "output", "o", "", "pack file to write (default: <name>.lmmpack)")
+	profileUnpackCmd.Flags().BoolVar(&profileImportForce, "force", false, "overwrite existing profile")
+	profileUnpackCmd.Flags().BoolVar(&profileImportNoInstall, "no-install", false, "only seed the cache and save the profile")
 
 	// Apply flags
 	profileApplyCmd.Flags().BoolVarP(&profileApplyYes, "yes", "y", false, "auto-confirm changes")
+	profileApplyCmd.Flags().BoolVar(&profileApplyFrozen, "frozen", false, "refuse downloads that do not match the profile's lockfile")
 
 	// Reorder flags
 	profileReorderCmd.Flags().StringVarP(&profileReorderProfile, "profile", "p", "", "profile (default: active profile)")
@@ -243,6 +395,15 @@ func runProfileCreate(cmd *cobra.Command, args []string) error {
 func doProfileCreate(service *core.Service, game *domain.Game, name string) error {
 	pm := getProfileManager(service)
 
+	if profileCreateExtends != "" {
+		profile, err := pm.Extend(game.ID, name, profileCreateExtends)
+		if err != nil {
+			return fmt.Errorf("creating profile: %w", err)
+		}
+		fmt.Printf("✓ Created profile: %s (extends %s, %d mod(s))\n", profile.Name, profile.Extends, len(profile.Mods))
+		return nil
+	}
+
 	profile, err := pm.Create(game.ID, name)
 	if err != nil {
 		return fmt.Errorf("creating profile: %w", err)
@@ -252,6 +413,428 @@ func doProfileCreate(service *core.Service, game *domain.Game, name string) erro
 	return nil
 }
 
+// profileShowJSON is 'profile show's output, in both the written and the
+// --resolved view; the Remove lists and Order only ever appear in the
+// written one.
+type profileShowJSON struct {
+	Name       string               `json:"name"`
+	GameID     string               `json:"game_id"`
+	Extends    string               `json:"extends,omitempty"`
+	Resolved   bool                 `json:"resolved"`
+	LinkMethod string               `json:"link_method,omitempty"`
+	Mods       []profileShowModJSON `json:"mods"`
+	Remove     []string             `json:"remove,omitempty"`
+	Order      []string             `json:"order,omitempty"`
+	Overrides  []string             `json:"overrides,omitempty"`
+	IniPatches []string             `json:"ini_patches,omitempty"`
+
+	RemoveOverrides  []string `json:"remove_overrides,omitempty"`
+	RemoveIniPatches []string `json:"remove_ini_patches,omitempty"` // "file [section] key"
+}
+
+type profileShowModJSON struct {
+	SourceID string `json:"source_id"`
+	ModID    string `json:"mod_id"`
+	Name     string `json:"name,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Locked   bool   `json:"locked,omitempty"`
+	From     string `json:"from,omitempty"` // --resolved only: the profile supplying the reference
+}
+
+func runProfileShow(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, service *core.Service, game *domain.Game) error {
+		var name string
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return doProfileShow(service, game, name)
+	})
+}
+
+func doProfileShow(service *core.Service, game *domain.Game, name string) error {
+	profileName, err := resolveProfile(service, game.ID, name)
+	if err != nil {
+		return err
+	}
+	pm := getProfileManager(service)
+
+	var show profileShowJSON
+	if profileShowResolved {
+		profile, err := pm.Get(game.ID, profileName)
+		if err != nil {
+			return fmt.Errorf("loading profile: %w", err)
+		}
+		origins, err := pm.ModOrigins(game.ID, profileName)
+		if err != nil {
+			return fmt.Errorf("loading profile: %w", err)
+		}
+		show = profileShowJSON{Name: profileName, GameID: game.ID, Extends: profile.Extends, Resolved: true}
+		if profile.LinkMethodExplicit {
+			show.LinkMethod = profile.LinkMethod.String()
+		}
+		for _, ref := range profile.Mods {
+			show.Mods = append(show.Mods, profileShowModJSON{
+				SourceID: ref.SourceID, ModID: ref.ModID, Version: ref.Version, Locked: ref.Locked,
+				From: origins[domain.ModKey(ref.SourceID, ref.ModID)],
+			})
+		}
+		show.Overrides = slices.Sorted(maps.Keys(profile.Overrides))
+		show.IniPatches = slices.Sorted(maps.Keys(profile.IniPatches))
+	} else {
+		layer, err := config.LoadProfileLayer(service.ConfigDir(), game.ID, profileName)
+		if err != nil {
+			return fmt.Errorf("loading profile: %w", err)
+		}
+		show = profileShowJSON{Name: profileName, GameID: game.ID, Extends: layer.Extends, LinkMethod: layer.LinkMethod, Order: layer.Order}
+		for _, ref := range layer.Mods {
+			show.Mods = append(show.Mods, profileShowModJSON{SourceID: ref.SourceID, ModID: ref.ModID, Version: ref.Version, Locked: ref.Locked})
+		}
+		for _, key := range layer.Remove {
+			show.Remove = append(show.Remove, domain.ModKey(key.SourceID, key.ModID))
+		}
+		show.Overrides = slices.Sorted(maps.Keys(layer.Overrides))
+		show.IniPatches = slices.Sorted(maps.Keys(layer.IniPatches))
+		show.RemoveOverrides = layer.RemoveOverrides
+		for _, file := range slices.Sorted(maps.Keys(layer.RemoveIniPatches)) {
+			for _, section := range slices.Sorted(maps.Keys(layer.RemoveIniPatches[file])) {
+				for _, key := range layer.RemoveIniPatches[file][section] {
+					show.RemoveIniPatches = append(show.RemoveIniPatches, fmt.Sprintf("%s [%s] %s", file, section, key))
+				}
+			}
+		}
+	}
+
+	installed, _ := service.GetInstalledMods(game.ID, profileName)
+	nameByKey := make(map[string]string)
+	for i := range installed {
+		nameByKey[domain.ModKey(installed[i].SourceID, installed[i].ID)] = installed[i].Name
+	}
+	for i := range show.Mods {
+		show.Mods[i].Name = nameByKey[domain.ModKey(show.Mods[i].SourceID, show.Mods[i].ModID)]
+	}
+
+	if jsonOutput {
+		if show.Mods == nil {
+			show.Mods = []profileShowModJSON{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(show); err != nil {
+			return fmt.Errorf("encoding json: %w", err)
+		}
+		return nil
+	}
+
+	header := "Profile: " + show.Name
+	if show.Extends != "" {
+		header += " (extends " + show.Extends + ")"
+	}
+	if show.Resolved {
+		header += ", resolved"
+	}
+	fmt.Println(header)
+	if show.LinkMethod != "" {
+		fmt.Printf("Link method: %s\n", show.LinkMethod)
+	}
+
+	fmt.Println()
+	if len(show.Mods) == 0 {
+		fmt.Println("No mods.")
+	} else {
+		var buf bytes.Buffer
+		w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+		head, sep := "#\tMOD\tNAME\tVERSION", "-\t---\t----\t-------"
+		if show.Resolved {
+			head, sep = head+"\tFROM", sep+"\t----"
+		}
+		if _, err := fmt.Fprintln(w, head); err != nil {
+			return fmt.Errorf("writing header: %w", err)
+		}
+		if _, err := fmt.Fprintln(w, sep); err != nil {
+			return fmt.Errorf("writing header: %w", err)
+		}
+		for i, m := range show.Mods {
+			name, version := m.Name, m.Version
+			if name == "" {
+				name = "(unknown)"
+			}
+			if m.Locked {
+				version += " (locked)"
+			}
+			row := fmt.Sprintf("%d\t%s\t%s\t%s", i+1, domain.ModKey(m.SourceID, m.ModID), name, version)
+			if show.Resolved {
+				row += "\t" + m.From
+			}
+			if _, err := fmt.Fprintln(w, row); err != nil {
+				return fmt.Errorf("writing row: %w", err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return fmt.Errorf("flushing table: %w", err)
+		}
+		if err := printTable(&buf, 2, nil); err != nil {
+			return err
+		}
+	}
+
+	for _, line := range []struct {
+		label string
+		items []string
+	}{
+		{"Removes", show.Remove},
+		{"Order", show.Order},
+		{"Overrides", show.Overrides},
+		{"INI patches", show.IniPatches},
+		{"Removes overrides", show.RemoveOverrides},
+		{"Removes INI patches", show.RemoveIniPatches},
+	} {
+		if len(line.items) > 0 {
+			fmt.Printf("%s: %s\n", line.label, strings.Join(line.items, ", "))
+		}
+	}
+	return nil
+}
+
+type profileDiffJSON struct {
+	From       string                  `json:"from"`
+	To         string                  `json:"to"`
+	Added      []profileDiffModJSON    `json:"added"`
+	Removed    []profileDiffModJSON    `json:"removed"`
+	Changed    []profileDiffChangeJSON `json:"changed"`
+	Moved      []profileDiffMoveJSON   `json:"moved"`
+	LinkMethod *profileDiffLinkJSON    `json:"link_method,omitempty"`
+	Overrides  []profileDiffKindJSON   `json:"overrides"`
+	IniPatches []profileDiffIniKeyJSON `json:"ini_patches"`
+}
+
+type profileDiffModJSON struct {
+	SourceID string   `json:"source_id"`
+	ModID    string   `json:"mod_id"`
+	Version  string   `json:"version,omitempty"`
+	FileIDs  []string `json:"file_ids,omitempty"`
+	Locked   bool     `json:"locked,omitempty"`
+}
+
+type profileDiffChangeJSON struct {
+	From profileDiffModJSON `json:"from"`
+	To   profileDiffModJSON `json:"to"`
+}
+
+type profileDiffMoveJSON struct {
+	SourceID string `json:"source_id"`
+	ModID    string `json:"mod_id"`
+	From     int    `json:"from"`
+	To       int    `json:"to"`
+}
+
+type profileDiffLinkJSON struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type profileDiffKindJSON struct {
+	Path string `json:"path"`
+	Kind string `json:"kind"`
+}
+
+type profileDiffIniKeyJSON struct {
+	File    string  `json:"file"`
+	Section string  `json:"section"`
+	Key     string  `json:"key"`
+	Kind    string  `json:"kind"`
+	From    *string `json:"from"`
+	To      *string `json:"to"`
+}
+
+func runProfileDiff(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, service *core.Service, game *domain.Game) error {
+		return doProfileDiff(service, game, args)
+	})
+}
+
+func doProfileDiff(service *core.Service, game *domain.Game, args []string) error {
+	var fromArg string
+	if len(args) > 0 {
+		fromArg = args[0]
+	}
+	if fromArg == "" {
+		name, err := resolveProfile(service, game.ID, "")
+		if err != nil {
+			return err
+		}
+		fromArg = name
+	}
+	from, err := loadDiffProfile(service, game, fromArg)
+	if err != nil {
+		return err
+	}
+
+	var to *domain.Profile
+	toLabel := "deployed"
+	if profileDiffDeployed {
+		if _, err := os.Stat(fromArg); err == nil {
+			return fmt.Errorf("--deployed compares a profile, not a file: %s", fromArg)
+		}
+		to, err = service.DeployedProfile(game, fromArg)
+		if err != nil {
+			return fmt.Errorf("reading deployed state: %w", err)
+		}
+	} else {
+		toLabel = args[1]
+		to, err = loadDiffProfile(service, game, toLabel)
+		if err != nil {
+			return err
+		}
+	}
+
+	diff := core.DiffProfiles(from, to)
+	if jsonOutput {
+		return printProfileDiffJSON(fromArg, toLabel, diff)
+	}
+
+	if diff.Empty() {
+		fmt.Printf("No differences between %s and %s.\n", fromArg, toLabel)
+		return nil
+	}
+	fmt.Printf("Comparing %s → %s\n", fromArg, toLabel)
+
+	// state is what a reference pins: its version and lock.
+	state := func(m domain.ModReference) string {
+		s := m.Version
+		if s == "" {
+			s = "(any version)"
+		}
+		if m.Locked {
+			s += " (locked)"
+		}
+		return s
+	}
+	describe := func(m domain.ModReference) string {
+		return domain.ModKey(m.SourceID, m.ModID) + " " + state(m)
+	}
+	if len(diff.Added) > 0 || len(diff.Removed) > 0 || len(diff.Changed) > 0 || len(diff.Moved) > 0 {
+		fmt.Println("\nMods:")
+		for _, m := range diff.Added {
+			fmt.Printf("  %s %s\n", colorGreen("+"), describe(m))
+		}
+		for _, m := range diff.Removed {
+			fmt.Printf("  %s %s\n", colorRed("-"), describe(m))
+		}
+		for _, c := range diff.Changed {
+			line := fmt.Sprintf("  %s %s → %s", colorYellow("~"), describe(c.From), state(c.To))
+			if len(c.From.FileIDs) > 0 && len(c.To.FileIDs) > 0 && !slices.Equal(c.From.FileIDs, c.To.FileIDs) {
+				line += fmt.Sprintf(", files %s → %s", strings.Join(c.From.FileIDs, ","), strings.Join(c.To.FileIDs, ","))
+			}
+			fmt.Println(line)
+		}
+		for _, mv := range diff.Moved {
+			fmt.Printf("  %s %s #%d → #%d\n", colorYellow("↕"), domain.ModKey(mv.SourceID, mv.ModID), mv.From, mv.To)
+		}
+	}
+	if diff.LinkMethod != nil {
+		orDefault := func(m string) string {
+			if m == "" {
+				return "(game default)"
+			}
+			return m
+		}
+		fmt.Printf("\nLink method: %s → %s\n", orDefault(diff.LinkMethod.From), orDefault(diff.LinkMethod.To))
+	}
+	marks := map[core.DiffKind]string{core.DiffAdded: colorGreen("+"), core.DiffRemoved: colorRed("-"), core.DiffChanged: colorYellow("~")}
+	if len(diff.Overrides) > 0 {
+		fmt.Println("\nOverrides:")
+		for _, o := range diff.Overrides {
+			fmt.Printf("  %s %s\n", marks[o.Kind], o.Path)
+		}
+	}
+	if len(diff.IniPatches) > 0 {
+		fmt.Println("\nINI patches:")
+		value := func(v *string) string {
+			if v == nil {
+				return "(deleted)"
+			}
+			return *v
+		}
+		for _, p := range diff.IniPatches {
+			line := fmt.Sprintf("  %s %s [%s] %s", marks[p.Kind], p.File, p.Section, p.Key)
+			switch p.Kind {
+			case core.DiffAdded:
+				line += " = " + value(p.To)
+			case core.DiffRemoved:
+				line += " = " + value(p.From)
+			default:
+				line += ": " + value(p.From) + " → " + value(p.To)
+			}
+			fmt.Println(line)
+		}
+	}
+	return nil
+}
+
+// loadDiffProfile loads one side of a profile diff: a profile YAML file when
+// arg names an existing file, otherwise the game's profile called arg.
+func loadDiffProfile(service *core.Service, game *domain.Game, arg string) (*domain.Profile, error) {
+	pm := getProfileManager(service)
+	if info, err := os.Stat(arg); err == nil && info.Mode().IsRegular() {
+		data, err := os.ReadFile(arg)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", arg, err)
+		}
+		profile, err := pm.ParseProfile(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", arg, err)
+		}
+		if profile.GameID != "" && profile.GameID != game.ID {
+			fmt.Fprintf(os.Stderr, "Warning: %s is a profile for %s, not %s\n", arg, profile.GameID, game.ID)
+		}
+		return profile, nil
+	}
+	profile, err := pm.Get(game.ID, arg)
+	if err != nil {
+		return nil, fmt.Errorf("loading profile %s: %w", arg, err)
+	}
+	return profile, nil
+}
+
+func printProfileDiffJSON(from, to string, diff *core.ProfileDiff) error {
+	mod := func(m domain.ModReference) profileDiffModJSON {
+		return profileDiffModJSON{SourceID: m.SourceID, ModID: m.ModID, Version: m.Version, FileIDs: m.FileIDs, Locked: m.Locked}
+	}
+	out := profileDiffJSON{
+		From: from, To: to,
+		Added: []profileDiffModJSON{}, Removed: []profileDiffModJSON{}, Changed: []profileDiffChangeJSON{},
+		Moved: []profileDiffMoveJSON{}, Overrides: []profileDiffKindJSON{}, IniPatches: []profileDiffIniKeyJSON{},
+	}
+	for _, m := range diff.Added {
+		out.Added = append(out.Added, mod(m))
+	}
+	for _, m := range diff.Removed {
+		out.Removed = append(out.Removed, mod(m))
+	}
+	for _, c := range diff.Changed {
+		out.Changed = append(out.Changed, profileDiffChangeJSON{From: mod(c.From), To: mod(c.To)})
+	}
+	for _, mv := range diff.Moved {
+		out.Moved = append(out.Moved, profileDiffMoveJSON{SourceID: mv.SourceID, ModID: mv.ModID, From: mv.From, To: mv.To})
+	}
+	if diff.LinkMethod != nil {
+		out.LinkMethod = &profileDiffLinkJSON{From: diff.LinkMethod.From, To: diff.LinkMethod.To}
+	}
+	for _, o := range diff.Overrides {
+		out.Overrides = append(out.Overrides, profileDiffKindJSON{Path: o.Path, Kind: string(o.Kind)})
+	}
+	for _, p := range diff.IniPatches {
+		out.IniPatches = append(out.IniPatches, profileDiffIniKeyJSON{File: p.File, Section: p.Section, Key: p.Key, Kind: string(p.Kind), From: p.From, To: p.To})
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return fmt.Errorf("encoding json: %w", err)
+	}
+	return nil
+}
+
 func runProfileDelete(cmd *cobra.Command, args []string) error {
 	return withGameService(cmd, func(ctx context.Context, service *core.Service, game *domain.Game) error {
 		return doProfileDelete(service, game, args[0])
@@ -295,10 +878,18 @@ func doProfileSwitch(ctx context.Context, service *core.Service, game *domain.Ga
 
 	if plan.NoChanges {
 		// No mod changes, just switch the default - ApplyProfileSwitch's
-		// three loops are all empty, so this is exactly a SetDefault call.
-		if _, err := service.ApplyProfileSwitch(ctx, game, plan, nil); err != nil {
+		// three loops are all empty, so this is exactly a SetDefault call
+		// (plus the save swap under save_isolation).
+		result, err := service.ApplyProfileSwitch(ctx, game, plan, nil)
+		if err != nil {
 			return err
 		}
+		for _, w := range result.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+		}
+		if result.SavesSwapped {
+			fmt.Printf("✓ Swapped saves: %s -> %s\n", plan.From, targetName)
+		}
 		fmt.Printf("✓ Switched to profile: %s\n", targetName)
 		return nil
 	}
@@ -324,6 +915,10 @@ func doProfileSwitch(ctx context.Context, service *core.Service, game *domain.Ga
 		}
 	}
 
+	if plan.SwapSaves {
+		fmt.Printf("Will swap saves: %s's saves are parked, %s's are restored\n", plan.From, targetName)
+	}
+
 	// Confirm
 	fmt.Print("\nProceed? [Y/n]: ")
 	input, err := readPromptLine()
@@ -386,11 +981,14 @@ func doProfileSwitch(ctx context.Context, service *core.Service, game *domain.Ga
 		return err
 	}
 	// #197 postsmoke fix: SwitchResult.Warnings (unconditional stderr,
-	// unlike .Notes above) - today, only a merged-pak sync failure for the
+	// unlike .Notes above) - a merged-pak sync or ini-patch failure for the
 	// target profile. Previously this whole result was discarded.
 	for _, w := range result.Warnings {
 		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
 	}
+	if result.SavesSwapped {
+		fmt.Printf("  ✓ Swapped saves: %s -> %s\n", plan.From, targetName)
+	}
 
 	fmt.Printf("\n✓ Switched to profile: %s\n", targetName)
 	return nil
@@ -421,7 +1019,13 @@ func runProfileImport(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("reading file: %w", err)
 	}
 	return withGameService(cmd, func(ctx context.Context, service *core.Service, game *domain.Game) error {
-		return doProfileImport(ctx, service, game, data)
+		var lock *domain.ProfileLock
+		if profileImportFrozen {
+			if lock, err = service.LoadProfileLock(core.LockPathFor(filePath)); err != nil {
+				return err
+			}
+		}
+		return doProfileImport(ctx, service, game, data, lock)
 	})
 }
 
@@ -435,8 +1039,8 @@ func runProfileImport(cmd *cobra.Command, args []string) error {
 // precedent core.InstallOptions.ConfirmConflicts established; promptErr
 // mirrors confirmInstallConflicts' own seam in install.go for propagating a
 // genuine stdin read failure verbatim instead of collapsing it into a
-// generic error.
-func doProfileImport(ctx context.Context, service *core.Service, game *domain.Game, data []byte) error {
+// generic error. A non-nil lock makes the import frozen.
+func doProfileImport(ctx context.Context, service *core.Service, game *domain.Game, data []byte, lock *domain.ProfileLock) error {
 	plan, err := service.PlanImport(ctx, game, data)
 	if err != nil {
 		// PlanImport's only failure mode is a parse error, already wrapped
@@ -444,6 +1048,19 @@ func doProfileImport(ctx context.Context, service *core.Service, game *domain.Ga
 		// wrapping exactly.
 		return err
 	}
+	return applyProfileImport(ctx, service, game, plan, lock, false)
+}
+
+// applyProfileImport is doProfileImport after planning, shared with
+// doProfileUnpack. fromPack only changes wording: a pack's mods are
+// installed from the cache it seeded, not downloaded.
+func applyProfileImport(ctx context.Context, service *core.Service, game *domain.Game, plan *core.ImportPlan, lock *domain.ProfileLock, fromPack bool) error {
+	fetchVerb := "need to be downloaded"
+	prompt := "\nDownload and install mods? [Y/n]: "
+	if fromPack {
+		fetchVerb = "to install from the pack"
+		prompt = "\nInstall mods? [Y/n]: "
+	}
 
 	// Show summary - printed purely from the plan, matching the
 	// pre-extraction CLI's preview exactly.
@@ -460,7 +1077,7 @@ func doProfileImport(ctx context.Context, service *core.Service, game *domain.Ga
 		}
 	}
 	if len(plan.Missing) > 0 {
-		fmt.Printf("  ↓ %d need to be downloaded:\n", len(plan.Missing))
+		fmt.Printf("  ↓ %d %s:\n", len(plan.Missing), fetchVerb)
 		for _, ref := range plan.Missing {
 			fmt.Printf("    - %s:%s v%s\n", ref.SourceID, ref.ModID, ref.Version)
 		}
@@ -475,10 +1092,10 @@ func doProfileImport(ctx context.Context, service *core.Service, game *domain.Ga
 	var promptErr error
 	declined := false
 
-	opts := core.ProfileImportOptions{Force: profileImportForce, NoInstall: profileImportNoInstall}
+	opts := core.ProfileImportOptions{Force: profileImportForce, NoInstall: profileImportNoInstall, Lock: lock}
 	if toDownloadCount > 0 && !profileImportNoInstall {
 		opts.ConfirmInstall = func(toDownload []domain.ModReference) bool {
-			fmt.Print("\nDownload and install mods? [Y/n]: ")
+			fmt.Print(prompt)
 			input, err := readPromptLine()
 			if err != nil {
 				promptErr = err
@@ -503,7 +1120,11 @@ func doProfileImport(ctx context.Context, service *core.Service, game *domain.Ga
 		case core.ImportSaved:
 			fmt.Printf("\n✓ Imported profile: %s\n", p.ModName)
 		case core.ImportInstalling:
-			fmt.Println("\nDownloading and installing mods...")
+			if fromPack {
+				fmt.Println("\nInstalling mods...")
+			} else {
+				fmt.Println("\nDownloading and installing mods...")
+			}
 		case core.ImportModInstalling:
 			fmt.Printf("  Installing %s:%s...\n", p.SourceID, p.ModID)
 		case core.ImportDownloading:
@@ -574,6 +1195,75 @@ func doProfileImport(ctx context.Context, service *core.Service, game *domain.Ga
 	return nil
 }
 
+func runProfileLock(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, service *core.Service, game *domain.Game) error {
+		return doProfileLock(service, game, args[0])
+	})
+}
+
+func doProfileLock(service *core.Service, game *domain.Game, name string) error {
+	lock, path, err := service.LockProfile(game, name)
+	if err != nil {
+		return err
+	}
+	files := 0
+	for _, m := range lock.Mods {
+		files += len(m.Files)
+	}
+	fmt.Printf("✓ Locked %d mod(s), %d file(s) to %s\n", len(lock.Mods), files, path)
+	return nil
+}
+
+func runProfilePack(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, service *core.Service, game *domain.Game) error {
+		return doProfilePack(ctx, service, game, args[0], profilePackOutput)
+	})
+}
+
+// doProfilePack writes the pack beside its destination first and renames it
+// into place, so a failed pack never leaves a truncated file behind.
+func doProfilePack(ctx context.Context, service *core.Service, game *domain.Game, name, output string) error {
+	if output == "" {
+		output = name + ".lmmpack"
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(output), ".lmm-pack-*")
+	if err != nil {
+		return fmt.Errorf("creating pack: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }() // no-op once renamed into place
+
+	result, err := service.PackProfile(ctx, game, name, tmp)
+	if cerr := tmp.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("closing pack: %w", cerr)
+	}
+	if err != nil {
+		return fmt.Errorf("packing profile %s: %w", name, err)
+	}
+	if err := os.Rename(tmpPath, output); err != nil {
+		return fmt.Errorf("writing pack: %w", err)
+	}
+	fmt.Printf("✓ Packed %d mod(s) (%s) into %s\n", result.Mods, formatSize(result.Size), output)
+	return nil
+}
+
+func runProfileUnpack(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, service *core.Service, game *domain.Game) error {
+		return doProfileUnpack(ctx, service, game, args[0])
+	})
+}
+
+func doProfileUnpack(ctx context.Context, service *core.Service, game *domain.Game, packPath string) error {
+	plan, err := service.UnpackProfile(ctx, game, packPath)
+	if err != nil {
+		return err
+	}
+	if plan.Seeded > 0 {
+		fmt.Printf("Added %d mod version(s) to the cache from %s\n", plan.Seeded, packPath)
+	}
+	return applyProfileImport(ctx, service, game, plan, nil, true)
+}
+
 func runProfileSync(cmd *cobra.Command, args []string) error {
 	return withGameService(cmd, func(ctx context.Context, service *core.Service, game *domain.Game) error {
 		return doProfileSync(ctx, service, game, args)
@@ -1008,6 +1698,21 @@ func doProfileApply(ctx context.Context, service *core.Service, game *domain.Gam
 		}
 	}
 
+	// --frozen refuses before the prompt, while nothing has changed yet.
+	var lock *domain.ProfileLock
+	if profileApplyFrozen {
+		lockPath, err := service.ProfileLockPath(game.ID, profileName)
+		if err != nil {
+			return err
+		}
+		if lock, err = service.LoadProfileLock(lockPath); err != nil {
+			return err
+		}
+		if err := core.CheckFrozen(lock, game, toInstall); err != nil {
+			return err
+		}
+	}
+
 	// Confirm unless --yes
 	if !profileApplyYes {
 		fmt.Print("\nProceed? [Y/n]: ")
@@ -1116,7 +1821,23 @@ func doProfileApply(ctx context.Context, service *core.Service, game *domain.Gam
 			for _, f := range filesToDownload {
 				downloadedFileIDs = append(downloadedFileIDs, f.ID)
 			}
-			if !service.GetGameCache(game).HasFileIDs(game.ID, mod.SourceID, mod.ID, mod.Version, downloadedFileIDs) {
+			// recorded collects the checksums and digests to save with the
+			// install: the downloads', or with --frozen, the cached files'
+			// records that matched the lock.
+			var recorded []core.DeployedFile
+			cached := service.GetGameCache(game).HasFileIDs(game.ID, mod.SourceID, mod.ID, mod.Version, downloadedFileIDs)
+			if cached && lock != nil {
+				// --frozen: a cached version counts only when its recorded
+				// digests match the lock (see core.FrozenCachedFiles).
+				var ok bool
+				recorded, ok, err = service.FrozenCachedFiles(lock, game, mod, downloadedFileIDs)
+				if err != nil {
+					fmt.Printf("    Error: checking cached files against the lockfile: %v\n", err)
+					continue
+				}
+				cached = ok
+			}
+			if !cached {
 				// Download each file
 				progressFn := func(p core.DownloadProgress) {
 					if p.TotalBytes > 0 {
@@ -1126,13 +1847,21 @@ func doProfileApply(ctx context.Context, service *core.Service, game *domain.Gam
 
 				downloadFailed := false
 				for _, selectedFile := range filesToDownload {
-					_, err = service.DownloadMod(ctx, ref.SourceID, game, mod, selectedFile, progressFn)
+					var downloadResult *core.DownloadModResult
+					if lock != nil {
+						downloadResult, err = service.DownloadModFrozen(ctx, lock, ref.SourceID, game, mod, selectedFile, progressFn)
+					} else {
+						downloadResult, err = service.DownloadMod(ctx, ref.SourceID, game, mod, selectedFile, progressFn)
+					}
 					if err != nil {
 						fmt.Println()
 						fmt.Printf("    Error: download failed: %v\n", err)
 						downloadFailed = true
 						break
 					}
+					if downloadResult.Checksum != "" {
+						recorded = append(recorded, core.DeployedFile{FileID: selectedFile.ID, Checksum: downloadResult.Checksum, SHA256: downloadResult.SHA256, Size: downloadResult.Size})
+					}
 				}
 				fmt.Println()
 
@@ -1188,6 +1917,15 @@ func doProfileApply(ctx context.Context, service *core.Service, game *domain.Gam
 				fmt.Printf("    Error: save failed: %v\n", err)
 				continue
 			}
+			for _, f := range recorded {
+				if err := service.SaveFileChecksum(mod.SourceID, mod.ID, game.ID, profileName, f.FileID, f.Checksum); err != nil {
+					fmt.Fprintf(os.Stderr, "    Warning: failed to save checksum: %v\n", err)
+				} else if f.SHA256 != "" {
+					if err := service.SaveFileDigest(mod.SourceID, mod.ID, game.ID, profileName, f.FileID, f.SHA256, f.Size); err != nil {
+						fmt.Fprintf(os.Stderr, "    Warning: failed to save SHA-256 digest: %v\n", err)
+					}
+				}
+			}
 
 			// Update profile with actual downloaded FileIDs
 			modRef := domain.ModReference{
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoProfileDiff(t *testing.T) {
+	svc, game := setupDoProfileSwitchTest(t)
+	pm := getProfileManager(svc)
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, pm.AddMod(game.ID, "default", domain.ModReference{SourceID: "src", ModID: id, Version: "1.0"}))
+	}
+	_, err := pm.Create(game.ID, "other")
+	require.NoError(t, err)
+	for _, ref := range []domain.ModReference{
+		{SourceID: "src", ModID: "c", Version: "2.0", Locked: true},
+		{SourceID: "src", ModID: "a", Version: "1.0"},
+		{SourceID: "src", ModID: "d", Version: "1.0"},
+	} {
+		require.NoError(t, pm.AddMod(game.ID, "other", ref))
+	}
+
+	oldDeployed, oldJSON := profileDiffDeployed, jsonOutput
+	t.Cleanup(func() { profileDiffDeployed, jsonOutput = oldDeployed, oldJSON })
+	profileDiffDeployed, jsonOutput = false, false
+
+	out := captureStdout(t, func() error {
+		return doProfileDiff(svc, game, []string{"default", "other"})
+	})
+	assert.Contains(t, out, "Comparing default → other\n")
+	assert.Contains(t, out, "+ src:d 1.0\n")
+	assert.Contains(t, out, "- src:b 1.0\n")
+	assert.Contains(t, out, "~ src:c 1.0 → 2.0 (locked)\n")
+	assert.Contains(t, out, "↕ src:a #1 → #2\n")
+
+	out = captureStdout(t, func() error {
+		return doProfileDiff(svc, game, []string{"default", "default"})
+	})
+	assert.Equal(t, "No differences between default and default.\n", out)
+
+	// An exported file compares like a profile.
+	data, err := pm.Export(game.ID, "other")
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "teammate.yaml")
+	require.NoError(t, os.WriteFile(file, data, 0644))
+
+	jsonOutput = true
+	out = captureStdout(t, func() error {
+		return doProfileDiff(svc, game, []string{"other", file})
+	})
+	var diff profileDiffJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &diff))
+	assert.Equal(t, "other", diff.From)
+	assert.Equal(t, file, diff.To)
+	assert.Empty(t, diff.Added)
+	assert.Empty(t, diff.Changed)
+
+	// Nothing is deployed yet, so every mod is missing from the game.
+	profileDiffDeployed = true
+	out = captureStdout(t, func() error {
+		return doProfileDiff(svc, game, nil)
+	})
+	require.NoError(t, json.Unmarshal([]byte(out), &diff))
+	assert.Equal(t, "default", diff.From)
+	assert.Equal(t, "deployed", diff.To)
+	assert.Len(t, diff.Removed, 3)
+}
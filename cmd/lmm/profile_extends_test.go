@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoProfileShow_ExtendedProfile(t *testing.T) {
+	svc, game := setupDoProfileSwitchTest(t)
+	pm := getProfileManager(svc)
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, pm.AddMod(game.ID, "default", domain.ModReference{SourceID: "src", ModID: id, Version: "1.0"}))
+	}
+
+	oldExtends, oldResolved, oldJSON := profileCreateExtends, profileShowResolved, jsonOutput
+	t.Cleanup(func() { profileCreateExtends, profileShowResolved, jsonOutput = oldExtends, oldResolved, oldJSON })
+
+	profileCreateExtends = "default"
+	out := captureStdout(t, func() error {
+		return doProfileCreate(svc, game, "survival")
+	})
+	assert.Equal(t, "✓ Created profile: survival (extends default, 3 mod(s))\n", out)
+
+	require.NoError(t, pm.RemoveMod(game.ID, "survival", "src", "b"))
+	require.NoError(t, pm.SetModLock(game.ID, "survival", "src", "c", "2.0"))
+
+	base, err := pm.Get(game.ID, "default")
+	require.NoError(t, err)
+	fov, vsync := "90", "1"
+	base.Overrides = map[string][]byte{"game.ini": []byte("base"), "other.ini": []byte("base")}
+	base.IniPatches = domain.IniPatches{"prefs.ini": {"Display": {"fov": &fov, "vsync": &vsync}}}
+	require.NoError(t, config.SaveProfile(svc.ConfigDir(), base))
+	survival, err := pm.Get(game.ID, "survival")
+	require.NoError(t, err)
+	delete(survival.Overrides, "other.ini")
+	delete(survival.IniPatches["prefs.ini"]["Display"], "vsync")
+	require.NoError(t, config.SaveProfile(svc.ConfigDir(), survival))
+
+	profileShowResolved = false
+	out = captureStdout(t, func() error {
+		return doProfileShow(svc, game, "survival")
+	})
+	assert.Contains(t, out, "Profile: survival (extends default)\n")
+	assert.Contains(t, out, "src:c")
+	assert.NotContains(t, out, "src:a", "the written view lists only the profile's own mods")
+	assert.Contains(t, out, "Removes: src:b\n")
+	assert.Contains(t, out, "Removes overrides: other.ini\n")
+	assert.Contains(t, out, "Removes INI patches: prefs.ini [Display] vsync\n")
+
+	profileShowResolved = true
+	out = captureStdout(t, func() error {
+		return doProfileShow(svc, game, "survival")
+	})
+	assert.Contains(t, out, "Profile: survival (extends default), resolved\n")
+	assert.Contains(t, out, "FROM")
+	assert.Contains(t, out, "2.0 (locked)")
+	assert.NotContains(t, out, "src:b")
+
+	jsonOutput = true
+	out = captureStdout(t, func() error {
+		return doProfileShow(svc, game, "survival")
+	})
+	var show profileShowJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &show))
+	assert.True(t, show.Resolved)
+	assert.Equal(t, "default", show.Extends)
+	assert.Equal(t, []profileShowModJSON{
+		{SourceID: "src", ModID: "a", Version: "1.0", From: "default"},
+		{SourceID: "src", ModID: "c", Version: "2.0", Locked: true, From: "survival"},
+	}, show.Mods)
+
+	// Deleting the base out from under its child is refused.
+	err = doProfileDelete(svc, game, "default")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrProfileExtended)
+}
@@ -122,7 +122,7 @@ func TestDoProfileImport_AllInstalled_PrintsSummaryAndSkipsInstallStep(t *testin
 	data := buildImportProfileData(t, "g1", "target", []domain.ModReference{{SourceID: "test-src", ModID: "mod1", Version: "1.0"}})
 
 	out := captureStdout(t, func() error {
-		return doProfileImport(context.Background(), svc, game, data)
+		return doProfileImport(context.Background(), svc, game, data, nil)
 	})
 
 	assert.Equal(t, "Importing profile: target\n\nFound 1 mod(s) in profile.\n  ✓ 1 already installed\n\n✓ Imported profile: target\n", out)
@@ -164,7 +164,7 @@ func TestDoProfileImport_NeedsRedownload_ReinstallsUsingStoredFileIDs(t *testing
 	var out string
 	withStdin(t, "y\n", func() {
 		out = captureStdout(t, func() error {
-			return doProfileImport(context.Background(), svc, game, data)
+			return doProfileImport(context.Background(), svc, game, data, nil)
 		})
 	})
 
@@ -204,7 +204,7 @@ func TestDoProfileImport_MissingWithInstall_AcceptedInstallsMod(t *testing.T) {
 	var out string
 	withStdin(t, "y\n", func() {
 		out = captureStdout(t, func() error {
-			return doProfileImport(context.Background(), svc, game, data)
+			return doProfileImport(context.Background(), svc, game, data, nil)
 		})
 	})
 
@@ -242,7 +242,7 @@ func TestDoProfileImport_PromptDeclined_NoInstallHappens(t *testing.T) {
 	var out string
 	withStdin(t, "n\n", func() {
 		out = captureStdout(t, func() error {
-			return doProfileImport(context.Background(), svc, game, data)
+			return doProfileImport(context.Background(), svc, game, data, nil)
 		})
 	})
 
@@ -296,7 +296,7 @@ func TestDoProfileImport_NoInstallFlag_SkipsPromptEntirely(t *testing.T) {
 
 	done := make(chan error, 1)
 	go func() {
-		done <- doProfileImport(context.Background(), svc, game, data)
+		done <- doProfileImport(context.Background(), svc, game, data, nil)
 	}()
 
 	var doErr error
@@ -344,7 +344,7 @@ func TestDoProfileImport_ForceOverwritesExistingProfile(t *testing.T) {
 	data := buildImportProfileData(t, "g1", "target", []domain.ModReference{{SourceID: "test-src", ModID: "new-mod", Version: "1.0"}})
 
 	out := captureStdout(t, func() error {
-		return doProfileImport(context.Background(), svc, game, data)
+		return doProfileImport(context.Background(), svc, game, data, nil)
 	})
 
 	assert.Equal(t, "Importing profile: target\n"+
@@ -390,7 +390,7 @@ func TestDoProfileImport_PromptReadFailure_PropagatesErrorWithoutSummary(t *test
 	t.Cleanup(func() { os.Stdin = oldStdin })
 
 	out, doErr := captureStdoutErr(t, func() error {
-		return doProfileImport(context.Background(), svc, game, data)
+		return doProfileImport(context.Background(), svc, game, data, nil)
 	})
 
 	require.Error(t, doErr, "a genuine stdin read failure must propagate, never be swallowed as a decline")
@@ -417,7 +417,7 @@ func TestDoProfileImport_ExistingProfileWithoutForce_ReturnsError(t *testing.T)
 	data := buildImportProfileData(t, "g1", "target", []domain.ModReference{{SourceID: "test-src", ModID: "new-mod", Version: "1.0"}})
 
 	out, doErr := captureStdoutErr(t, func() error {
-		return doProfileImport(context.Background(), svc, game, data)
+		return doProfileImport(context.Background(), svc, game, data, nil)
 	})
 
 	require.Error(t, doErr)
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDoProfileLock_FrozenApplyRefusesReupload locks an imported profile,
+// then has `profile apply --frozen` reinstall it from a source whose file now
+// serves different bytes.
+func TestDoProfileLock_FrozenApplyRefusesReupload(t *testing.T) {
+	svc, game, src := setupDoProfileImportTest(t)
+	src.AddMod(&domain.Mod{ID: "mod1", SourceID: "test-src", Name: "Mod One", Version: "1.0", GameID: "g1"},
+		[]domain.DownloadableFile{{ID: "main", FileName: "mod1.esp", IsPrimary: true}})
+	src.AddDownload("main", []byte("mod1 content"))
+
+	data := buildImportProfileData(t, "g1", "target", []domain.ModReference{{SourceID: "test-src", ModID: "mod1", Version: "1.0"}})
+	withStdin(t, "y\n", func() {
+		_ = captureStdout(t, func() error {
+			return doProfileImport(context.Background(), svc, game, data, nil)
+		})
+	})
+
+	lockPath, err := svc.ProfileLockPath(game.ID, "target")
+	require.NoError(t, err)
+	out := captureStdout(t, func() error {
+		return doProfileLock(svc, game, "target")
+	})
+	assert.Equal(t, "✓ Locked 1 mod(s), 1 file(s) to "+lockPath+"\n", out)
+
+	// Forget the install so apply has to download it again.
+	require.NoError(t, svc.DeleteInstalledMod("test-src", "mod1", game.ID, "target"))
+	require.NoError(t, svc.GetGameCache(game).Delete(game.ID, "test-src", "mod1", "1.0"))
+	src.AddDownload("main", []byte("re-uploaded content"))
+
+	oldYes, oldFrozen := profileApplyYes, profileApplyFrozen
+	profileApplyYes, profileApplyFrozen = true, true
+	t.Cleanup(func() { profileApplyYes, profileApplyFrozen = oldYes, oldFrozen })
+
+	out = captureStdout(t, func() error {
+		return doProfileApply(context.Background(), svc, game, []string{"target"})
+	})
+	assert.Contains(t, out, "Error: download failed: mod1.esp does not match the lockfile")
+	assert.NotContains(t, out, "✓ Installed")
+	assert.False(t, svc.GetGameCache(game).Exists(game.ID, "test-src", "mod1", "1.0"))
+
+	src.AddDownload("main", []byte("mod1 content"))
+	out = captureStdout(t, func() error {
+		return doProfileApply(context.Background(), svc, game, []string{"target"})
+	})
+	assert.Contains(t, out, "✓ Installed: Mod One")
+
+	// A cache entry a non-frozen apply refilled with the re-upload is not
+	// trusted either: --frozen downloads again and refuses it.
+	require.NoError(t, svc.DeleteInstalledMod("test-src", "mod1", game.ID, "target"))
+	require.NoError(t, svc.GetGameCache(game).Delete(game.ID, "test-src", "mod1", "1.0"))
+	src.AddDownload("main", []byte("re-uploaded content"))
+	profileApplyFrozen = false
+	out = captureStdout(t, func() error {
+		return doProfileApply(context.Background(), svc, game, []string{"target"})
+	})
+	require.Contains(t, out, "✓ Installed: Mod One")
+	require.NoError(t, svc.DeleteInstalledMod("test-src", "mod1", game.ID, "target"))
+	profileApplyFrozen = true
+	out = captureStdout(t, func() error {
+		return doProfileApply(context.Background(), svc, game, []string{"target"})
+	})
+	assert.Contains(t, out, "Error: download failed: mod1.esp does not match the lockfile")
+	assert.NotContains(t, out, "✓ Installed")
+}
+
+func TestDoProfileApply_FrozenRefusesUnpinnedModsBeforeChanges(t *testing.T) {
+	svc, game, _ := setupDoProfileImportTest(t)
+	pm := getProfileManager(svc)
+	_, err := pm.Create(game.ID, "target")
+	require.NoError(t, err)
+	require.NoError(t, pm.AddMod(game.ID, "target", domain.ModReference{SourceID: "test-src", ModID: "mod1", Version: "1.0"}))
+
+	oldYes, oldFrozen := profileApplyYes, profileApplyFrozen
+	profileApplyYes, profileApplyFrozen = true, true
+	t.Cleanup(func() { profileApplyYes, profileApplyFrozen = oldYes, oldFrozen })
+
+	err = doProfileApply(context.Background(), svc, game, []string{"target"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reading lockfile")
+
+	lockPath, err := svc.ProfileLockPath(game.ID, "target")
+	require.NoError(t, err)
+	require.NoError(t, config.SaveProfileLock(lockPath, &domain.ProfileLock{Format: domain.ProfileLockFormat, GameID: game.ID, Profile: "target"}))
+	out := captureStdout(t, func() error {
+		err = doProfileApply(context.Background(), svc, game, []string{"target"})
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lockfile does not pin: test-src:mod1 1.0")
+	assert.NotContains(t, out, "Installing missing mods")
+
+	err = doProfileLock(svc, game, "target")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "test-src:mod1 is not installed")
+}
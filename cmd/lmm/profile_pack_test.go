@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDoProfilePackUnpack round-trips a profile through an .lmmpack into a
+// second, source-less service: the unpack must install from the pack alone.
+func TestDoProfilePackUnpack(t *testing.T) {
+	svc, game, _ := setupDoProfileImportTest(t)
+	gameCache := svc.GetGameCache(game)
+	require.NoError(t, gameCache.Store(game.ID, "test-src", "mod1", "1.0", "mod1.esp", []byte("cached")))
+	require.NoError(t, cache.MarkFileComplete(gameCache.ModPath(game.ID, "test-src", "mod1", "1.0"), "main"))
+	require.NoError(t, svc.SaveInstalledMod(&domain.InstalledMod{
+		Mod:          domain.Mod{ID: "mod1", SourceID: "test-src", Name: "Mod One", Version: "1.0", GameID: "g1"},
+		ProfileName:  "default",
+		UpdatePolicy: domain.UpdateNotify,
+		Enabled:      true,
+		FileIDs:      []string{"main"},
+	}))
+	pm := getProfileManager(svc)
+	_, err := pm.Create(game.ID, "default")
+	require.NoError(t, err)
+	require.NoError(t, pm.AddMod(game.ID, "default", domain.ModReference{SourceID: "test-src", ModID: "mod1", Version: "1.0"}))
+
+	packPath := filepath.Join(t.TempDir(), "default.lmmpack")
+	out := captureStdout(t, func() error {
+		return doProfilePack(context.Background(), svc, game, "default", packPath)
+	})
+	assert.Equal(t, "✓ Packed 1 mod(s) (6 B) into "+packPath+"\n", out)
+
+	offline, err := core.NewService(core.ServiceConfig{ConfigDir: t.TempDir(), DataDir: t.TempDir(), CacheDir: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, offline.Close()) })
+	target := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkSymlink}
+
+	oldStdin := os.Stdin
+	r, w, perr := os.Pipe()
+	require.NoError(t, perr)
+	_, err = w.WriteString("y\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = oldStdin; _ = r.Close() })
+
+	out = captureStdout(t, func() error {
+		return doProfileUnpack(context.Background(), offline, target, packPath)
+	})
+	assert.Contains(t, out, "Added 1 mod version(s) to the cache from "+packPath)
+	assert.Contains(t, out, "↓ 1 to install from the pack:")
+	assert.Contains(t, out, "✓ Installed: Mod One")
+	assert.Contains(t, out, "Installed: 1\n")
+	assert.NotContains(t, out, "Error")
+
+	content, err := os.ReadFile(filepath.Join(target.ModPath, "mod1.esp"))
+	require.NoError(t, err)
+	assert.Equal(t, "cached", string(content))
+}
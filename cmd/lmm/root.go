@@ -18,7 +18,10 @@ import (
 	"github.com/DonovanMods/linux-mod-manager/internal/source/curseforge"
 	"github.com/DonovanMods/linux-mod-manager/internal/source/custom"
 	"github.com/DonovanMods/linux-mod-manager/internal/source/icarus"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/modio"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/modrinth"
 	"github.com/DonovanMods/linux-mod-manager/internal/source/nexusmods"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/thunderstore"
 	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
 
 	"github.com/muesli/termenv"
@@ -110,7 +113,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&gameID, "game", "g", "", "game ID to operate on")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVar(&noHooks, "no-hooks", false, "disable all hooks")
-	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output in JSON format (list, status, search, update, conflicts, verify, mod show, source list, game list)")
+	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output in JSON format (list, status, search, update, conflicts, verify, mod show, source list, game list, saves list, snapshot list, configs list, history, profile show, profile diff)")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output (NO_COLOR env is also honored)")
 }
 
@@ -367,6 +370,9 @@ var builtinSourceFactories = []func() source.ModSource{
 	func() source.ModSource { return nexusmods.New(nil, "") },
 	func() source.ModSource { return curseforge.New(nil, "") },
 	func() source.ModSource { return icarus.New(nil, icarusFirestoreProjectID) },
+	func() source.ModSource { return modrinth.New(nil, "") },
+	func() source.ModSource { return thunderstore.New(nil) },
+	func() source.ModSource { return modio.New(nil, "") },
 }
 
 // registerSources registers all available mod sources with the service
@@ -400,7 +406,7 @@ func registerSource(svc *core.Service, src source.ModSource) {
 	// skips a pointless per-source SQLite read for auth-incapable sources.
 	// Both halves matter: custom API/manifest sources implement SetAPIKey
 	// even when their definition declares no auth (the key would be unused).
-	if setter, ok := src.(interface{ SetAPIKey(string) }); ok && source.CapabilitiesOf(src).Auth {
+	if setter, ok := src.(interface{ SetAPIKey(string) }); ok && authCapable(src) {
 		if key := getSourceAPIKey(svc, id, envKeyFor(src)); key != "" {
 			setter.SetAPIKey(key)
 		}
@@ -408,6 +414,16 @@ func registerSource(svc *core.Service, src source.ModSource) {
 	svc.RegisterSource(src)
 }
 
+// authCapable reports source.CapabilitiesOf(src).Auth, except that an exec
+// source answers from its definition: asking the plugin would start it on
+// every lmm invocation, before anything needs it.
+func authCapable(src source.ModSource) bool {
+	if d, ok := src.(interface{ AuthDeclared() bool }); ok {
+		return d.AuthDeclared()
+	}
+	return source.CapabilitiesOf(src).Auth
+}
+
 // envKeyFor returns the environment variable name consulted for src's API
 // key: src's own EnvKeyProvider when implemented (preserves legacy names
 // like NEXUSMODS_API_KEY), otherwise the derived LMM_<ID>_API_KEY
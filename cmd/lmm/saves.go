@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+
+	"github.com/spf13/cobra"
+)
+
+var savesProfile string
+
+type saveBackupJSON struct {
+	ID        string `json:"id"`
+	Profile   string `json:"profile"`
+	CreatedAt string `json:"created_at"`
+	Size      int64  `json:"size"`
+	Path      string `json:"path"`
+}
+
+var savesCmd = &cobra.Command{
+	Use:   "saves",
+	Short: "Back up and restore save games",
+	Long: `Back up and restore a game's save files.
+
+Requires saves_path in the game's games.yaml entry. With save_isolation:
+true, every profile keeps its own saves: switching profiles parks the
+current profile's saves in <saves_path>.lmm-profiles/<profile> and moves
+the target profile's saves into place. Without it, all profiles share
+saves_path and backups are simply filed under the profile named.
+
+Backups are timestamped zip archives stored under the data directory
+(saves/<game>/<profile>/).`,
+}
+
+var savesBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Create a timestamped backup of a profile's saves",
+	Long: `Create a timestamped zip backup of a profile's saves.
+
+For an inactive profile under save_isolation, its parked saves are
+backed up; the live saves directory is not touched.
+
+Examples:
+  lmm saves backup --game skyrim-se
+  lmm saves backup --game skyrim-se --profile survival`,
+	Args: cobra.NoArgs,
+	RunE: runSavesBackup,
+}
+
+var savesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List a profile's save backups",
+	Long: `List a profile's save backups, oldest first.
+
+Examples:
+  lmm saves list --game skyrim-se
+  lmm saves list --game skyrim-se --profile survival --json`,
+	Args: cobra.NoArgs,
+	RunE: runSavesList,
+}
+
+var savesRestoreCmd = &cobra.Command{
+	Use:   "restore <backup-id>",
+	Short: "Restore a profile's saves from a backup",
+	Long: `Replace a profile's saves with the contents of a backup.
+
+The current saves are backed up first (unless empty), so a restore can
+itself be undone with another restore. Use 'lmm saves list' to find
+backup IDs.
+
+Examples:
+  lmm saves restore 20261018T153045Z --game skyrim-se`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSavesRestore,
+}
+
+func init() {
+	savesCmd.PersistentFlags().StringVarP(&savesProfile, "profile", "p", "", "profile (default: active profile)")
+
+	savesCmd.AddCommand(savesBackupCmd)
+	savesCmd.AddCommand(savesListCmd)
+	savesCmd.AddCommand(savesRestoreCmd)
+
+	rootCmd.AddCommand(savesCmd)
+}
+
+func runSavesBackup(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doSavesBackup(svc, game)
+	})
+}
+
+func doSavesBackup(svc *core.Service, game *domain.Game) error {
+	profileName, err := resolveProfile(svc, game.ID, savesProfile)
+	if err != nil {
+		return err
+	}
+	backup, err := svc.BackupSaves(game, profileName)
+	if err != nil {
+		return fmt.Errorf("backing up saves: %w", err)
+	}
+	fmt.Printf("✓ Backed up saves for profile %s: %s (%s)\n", profileName, backup.ID, formatSize(backup.Size))
+	return nil
+}
+
+func runSavesList(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doSavesList(svc, game)
+	})
+}
+
+func doSavesList(svc *core.Service, game *domain.Game) error {
+	profileName, err := resolveProfile(svc, game.ID, savesProfile)
+	if err != nil {
+		return err
+	}
+	backups, err := svc.ListSaveBackups(game, profileName)
+	if err != nil {
+		return fmt.Errorf("listing save backups: %w", err)
+	}
+
+	if jsonOutput {
+		rows := make([]saveBackupJSON, len(backups))
+		for i, b := range backups {
+			rows[i] = saveBackupJSON{
+				ID: b.ID, Profile: b.Profile, CreatedAt: b.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+				Size: b.Size, Path: b.Path,
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rows); err != nil {
+			return fmt.Errorf("encoding json: %w", err)
+		}
+		return nil
+	}
+
+	if len(backups) == 0 {
+		fmt.Printf("No save backups for profile %s.\n", profileName)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "ID\tCREATED\tSIZE"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, "--\t-------\t----"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	for _, b := range backups {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", b.ID, b.CreatedAt.Local().Format("2006-01-02 15:04:05"), formatSize(b.Size)); err != nil {
+			return fmt.Errorf("writing row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flushing table: %w", err)
+	}
+	return printTable(&buf, 2, nil)
+}
+
+func runSavesRestore(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doSavesRestore(svc, game, args[0])
+	})
+}
+
+func doSavesRestore(svc *core.Service, game *domain.Game, backupID string) error {
+	profileName, err := resolveProfile(svc, game.ID, savesProfile)
+	if err != nil {
+		return err
+	}
+	safety, err := svc.RestoreSaves(game, profileName, backupID)
+	if safety != nil {
+		fmt.Printf("Backed up current saves as %s\n", safety.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("restoring saves: %w", err)
+	}
+	fmt.Printf("✓ Restored saves for profile %s from %s\n", profileName, backupID)
+	return nil
+}
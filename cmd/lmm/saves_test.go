@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSavesCmdTest registers a game with a populated saves_path and points
+// the package globals at it, so the real `saves` command tree runs end to end.
+func setupSavesCmdTest(t *testing.T) *domain.Game {
+	t.Helper()
+
+	configDir = t.TempDir()
+	dataDir = t.TempDir()
+
+	svc, err := core.NewService(core.ServiceConfig{ConfigDir: configDir, DataDir: dataDir, CacheDir: filepath.Join(dataDir, "cache")})
+	require.NoError(t, err)
+	game := &domain.Game{
+		ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkSymlink,
+		SavesPath: filepath.Join(t.TempDir(), "Saves"),
+	}
+	require.NoError(t, svc.AddGame(game))
+	require.NoError(t, svc.Close())
+
+	require.NoError(t, os.MkdirAll(game.SavesPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(game.SavesPath, "slot1.sav"), []byte("original"), 0644))
+
+	oldGameID, oldProfile, oldJSON := gameID, savesProfile, jsonOutput
+	gameID = "g1"
+	savesProfile = ""
+	jsonOutput = false
+	t.Cleanup(func() {
+		gameID, savesProfile, jsonOutput = oldGameID, oldProfile, oldJSON
+		rootCmd.SetArgs(nil)
+	})
+	return game
+}
+
+func TestSavesCmd_BackupListRestore(t *testing.T) {
+	game := setupSavesCmdTest(t)
+
+	rootCmd.SetArgs([]string{"saves", "backup", "--game", game.ID})
+	out := captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "✓ Backed up saves for profile default")
+
+	rootCmd.SetArgs([]string{"saves", "list", "--game", game.ID, "--json"})
+	out = captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	var rows []saveBackupJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, "default", rows[0].Profile)
+	jsonOutput = false
+
+	require.NoError(t, os.WriteFile(filepath.Join(game.SavesPath, "slot1.sav"), []byte("changed"), 0644))
+
+	rootCmd.SetArgs([]string{"saves", "restore", rows[0].ID, "--game", game.ID})
+	out = captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "Backed up current saves as")
+	assert.Contains(t, out, "✓ Restored saves for profile default from "+rows[0].ID)
+
+	data, err := os.ReadFile(filepath.Join(game.SavesPath, "slot1.sav"))
+	require.NoError(t, err)
+	assert.Equal(t, "original", string(data))
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotNote string
+	snapshotYes  bool
+)
+
+type snapshotJSON struct {
+	ID            string   `json:"id"`
+	Note          string   `json:"note,omitempty"`
+	CreatedAt     string   `json:"created_at"`
+	ActiveProfile string   `json:"active_profile"`
+	Profiles      []string `json:"profiles"`
+	Mods          int      `json:"mods"`
+	Path          string   `json:"path"`
+}
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Capture and restore whole-game restore points",
+	Long: `Capture and restore whole-game restore points.
+
+A snapshot records, in one step, every profile file of the game (load
+order, overrides, ini patches and lockfiles included), the installed-mod
+and deployed-file records, and the merged pak fingerprint. Take one
+before a risky session - a mass update, a load-order experiment - and
+restore it if the session goes wrong.
+
+Snapshots are stored under the data directory (snapshots/<game>/<id>/).
+Mod files are not copied: a snapshot references the cached versions it
+needs, and cache gc keeps those for as long as the snapshot exists.`,
+}
+
+var snapshotCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Take a snapshot of a game",
+	Long: `Take a snapshot of a game's profiles and mod state.
+
+Examples:
+  lmm snapshot create --game skyrim-se
+  lmm snapshot create --game skyrim-se --note "before updating everything"`,
+	Args: cobra.NoArgs,
+	RunE: runSnapshotCreate,
+}
+
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List a game's snapshots",
+	Long: `List a game's snapshots, oldest first.
+
+Examples:
+  lmm snapshot list --game skyrim-se
+  lmm snapshot list --game skyrim-se --json`,
+	Args: cobra.NoArgs,
+	RunE: runSnapshotList,
+}
+
+var snapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <snapshot-id>",
+	Short: "Restore a game to a snapshot",
+	Long: `Bring a game back to a snapshot.
+
+The profile files and mod records are replaced with the snapshot's, then
+the snapshot's active profile is redeployed - downloading any version
+the cache no longer holds - and converged, so files deployed since the
+snapshot are removed from the game directory. Profiles created since the
+snapshot are removed.
+
+The current state is snapshotted first, so a restore can itself be
+undone by restoring that snapshot. Use 'lmm snapshot list' to find
+snapshot IDs.
+
+Examples:
+  lmm snapshot restore 20261018T153045Z --game skyrim-se
+  lmm snapshot restore 20261018T153045Z --game skyrim-se --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSnapshotRestore,
+}
+
+var snapshotDeleteCmd = &cobra.Command{
+	Use:   "delete <snapshot-id>",
+	Short: "Delete a snapshot",
+	Long: `Delete a snapshot. The cached mod versions only it referenced become
+eligible for cache gc.
+
+Examples:
+  lmm snapshot delete 20261018T153045Z --game skyrim-se`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSnapshotDelete,
+}
+
+func init() {
+	snapshotCreateCmd.Flags().StringVar(&snapshotNote, "note", "", "describe the snapshot")
+	snapshotRestoreCmd.Flags().BoolVarP(&snapshotYes, "yes", "y", false, "restore without asking for confirmation")
+
+	snapshotCmd.AddCommand(snapshotCreateCmd)
+	snapshotCmd.AddCommand(snapshotListCmd)
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+	snapshotCmd.AddCommand(snapshotDeleteCmd)
+
+	rootCmd.AddCommand(snapshotCmd)
+}
+
+func runSnapshotCreate(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doSnapshotCreate(svc, game)
+	})
+}
+
+func doSnapshotCreate(svc *core.Service, game *domain.Game) error {
+	snap, err := svc.CreateSnapshot(game, snapshotNote)
+	if err != nil {
+		return fmt.Errorf("creating snapshot: %w", err)
+	}
+	fmt.Printf("✓ Created snapshot %s of %s (%d profile(s), %d mod record(s))\n", snap.ID, game.Name, len(snap.Profiles), len(snap.Mods))
+	return nil
+}
+
+func runSnapshotList(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doSnapshotList(svc, game)
+	})
+}
+
+func doSnapshotList(svc *core.Service, game *domain.Game) error {
+	snaps, err := svc.ListSnapshots(game)
+	if err != nil {
+		return fmt.Errorf("listing snapshots: %w", err)
+	}
+
+	if jsonOutput {
+		rows := make([]snapshotJSON, len(snaps))
+		for i, s := range snaps {
+			rows[i] = snapshotJSON{
+				ID: s.ID, Note: s.Note, CreatedAt: s.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+				ActiveProfile: s.ActiveProfile, Profiles: s.Profiles, Mods: len(s.Mods), Path: s.Path,
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rows); err != nil {
+			return fmt.Errorf("encoding json: %w", err)
+		}
+		return nil
+	}
+
+	if len(snaps) == 0 {
+		fmt.Printf("No snapshots for %s.\n", game.Name)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "ID\tCREATED\tACTIVE\tPROFILES\tMODS\tNOTE"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, "--\t-------\t------\t--------\t----\t----"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	for _, s := range snaps {
+		row := fmt.Sprintf("%s\t%s\t%s\t%d\t%d", s.ID, s.CreatedAt.Local().Format("2006-01-02 15:04:05"), s.ActiveProfile, len(s.Profiles), len(s.Mods))
+		if s.Note != "" {
+			row += "\t" + s.Note
+		}
+		if _, err := fmt.Fprintln(w, row); err != nil {
+			return fmt.Errorf("writing row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flushing table: %w", err)
+	}
+	return printTable(&buf, 2, nil)
+}
+
+func runSnapshotRestore(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		return doSnapshotRestore(ctx, svc, game, args[0])
+	})
+}
+
+func doSnapshotRestore(ctx context.Context, svc *core.Service, game *domain.Game, id string) error {
+	snaps, err := svc.ListSnapshots(game)
+	if err != nil {
+		return fmt.Errorf("listing snapshots: %w", err)
+	}
+	var snap *core.Snapshot
+	for i := range snaps {
+		if snaps[i].ID == id {
+			snap = &snaps[i]
+		}
+	}
+	if snap == nil {
+		return fmt.Errorf("snapshot %s not found for game %s", id, game.ID)
+	}
+
+	fmt.Printf("Restore %s to snapshot %s (%s): profiles %s, active %s, %d mod record(s)\n",
+		game.Name, snap.ID, snap.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+		strings.Join(snap.Profiles, ", "), snap.ActiveProfile, len(snap.Mods))
+	if snap.Note != "" {
+		fmt.Printf("  %s\n", snap.Note)
+	}
+	if !snapshotYes {
+		fmt.Print("\nProceed? [Y/n]: ")
+		input, err := readPromptLine()
+		if err != nil {
+			return err
+		}
+		if input != "" && input != "y" && input != "yes" {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	progress := func(p core.DeployProgress) {
+		switch p.Phase {
+		case core.DeployRedownloading:
+			fmt.Printf("  %s %s - cache missing, re-downloading...\n", colorYellow("⚠"), p.ModName)
+		case core.DeployDownloading:
+			fmt.Printf("\r  ⬇ %s: %.1f%%", p.ModName, p.Percent)
+		case core.DeployDownloadDone:
+			fmt.Println()
+		case core.DeployDownloadFailed, core.DeploySkipped:
+			fmt.Printf("  %s %s - %s\n", colorRed("✗"), p.ModName, p.Detail)
+		case core.DeployDeployed:
+			fmt.Printf("  %s %s\n", colorGreen("✓"), p.ModName)
+		case core.DeployNote:
+			if verbose {
+				fmt.Printf("  %s\n", p.Detail)
+			}
+		case core.DeployWarning, core.DeployBeforeAllForced:
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", p.Detail)
+		}
+	}
+
+	result, err := svc.RestoreSnapshot(ctx, game, id, progress)
+	if result != nil {
+		if result.Safety != nil {
+			fmt.Printf("Snapshotted current state as %s\n", result.Safety.ID)
+		}
+		for _, w := range result.Warnings {
+			fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("restoring snapshot: %w", err)
+	}
+
+	if verbose {
+		for _, f := range result.Converge.Removed {
+			fmt.Printf("  removed %s (%s)\n", f.Path, f.Reason)
+		}
+	}
+	if result.MergedPakDiffers {
+		fmt.Fprintln(os.Stderr, "Warning: the merged pak was rebuilt and differs from the snapshot's (has the base game pak changed?)")
+	}
+	fmt.Printf("✓ Restored %s to snapshot %s: deployed %d mod(s) in profile %s, removed %d stale file(s)\n",
+		game.Name, id, result.Deploy.Deployed, result.Profile, len(result.Converge.Removed))
+	return nil
+}
+
+func runSnapshotDelete(cmd *cobra.Command, args []string) error {
+	return withGameService(cmd, func(ctx context.Context, svc *core.Service, game *domain.Game) error {
+		if err := svc.DeleteSnapshot(game, args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Deleted snapshot %s\n", args[0])
+		return nil
+	})
+}
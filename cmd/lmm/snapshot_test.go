@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSnapshotCmdTest registers a game whose default profile has one mod
+// deployed, and points the package globals at it.
+func setupSnapshotCmdTest(t *testing.T) *domain.Game {
+	t.Helper()
+
+	configDir = t.TempDir()
+	dataDir = t.TempDir()
+
+	svc, err := core.NewService(core.ServiceConfig{ConfigDir: configDir, DataDir: dataDir, CacheDir: filepath.Join(dataDir, "cache")})
+	require.NoError(t, err)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkSymlink}
+	require.NoError(t, svc.AddGame(game))
+	pm := svc.NewProfileManager()
+	_, err = pm.Create(game.ID, "default")
+	require.NoError(t, err)
+	require.NoError(t, pm.SetDefault(game.ID, "default"))
+	require.NoError(t, svc.GetGameCache(game).Store(game.ID, "src", "m1", "1.0", "m1.esp", []byte("one")))
+	require.NoError(t, svc.SaveInstalledMod(&domain.InstalledMod{
+		Mod:         domain.Mod{ID: "m1", SourceID: "src", Name: "Mod One", Version: "1.0", GameID: game.ID},
+		ProfileName: "default", Enabled: true,
+	}))
+	require.NoError(t, pm.AddMod(game.ID, "default", domain.ModReference{SourceID: "src", ModID: "m1", Version: "1.0"}))
+	_, err = svc.DeployProfile(context.Background(), game, "default", core.DeployOptions{}, nil)
+	require.NoError(t, err)
+	require.NoError(t, svc.Close())
+
+	oldGameID, oldNote, oldYes, oldJSON := gameID, snapshotNote, snapshotYes, jsonOutput
+	t.Cleanup(func() {
+		gameID, snapshotNote, snapshotYes, jsonOutput = oldGameID, oldNote, oldYes, oldJSON
+		rootCmd.SetArgs(nil)
+	})
+	return game
+}
+
+func TestSnapshotCmd_CreateListRestoreDelete(t *testing.T) {
+	game := setupSnapshotCmdTest(t)
+
+	rootCmd.SetArgs([]string{"snapshot", "create", "--game", game.ID, "--note", "before session"})
+	out := captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "✓ Created snapshot")
+	assert.Contains(t, out, "(1 profile(s), 1 mod record(s))")
+	snapshotNote = ""
+
+	rootCmd.SetArgs([]string{"snapshot", "list", "--game", game.ID, "--json"})
+	out = captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	var rows []snapshotJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	require.Len(t, rows, 1)
+	assert.Equal(t, "before session", rows[0].Note)
+	assert.Equal(t, "default", rows[0].ActiveProfile)
+	assert.Equal(t, 1, rows[0].Mods)
+	jsonOutput = false
+
+	// The session breaks the deployment.
+	require.NoError(t, os.Remove(filepath.Join(game.ModPath, "m1.esp")))
+
+	rootCmd.SetArgs([]string{"snapshot", "restore", rows[0].ID, "--game", game.ID, "--yes"})
+	out = captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "Snapshotted current state as")
+	assert.Contains(t, out, "✓ Restored Game to snapshot "+rows[0].ID+": deployed 1 mod(s) in profile default")
+	_, err := os.Lstat(filepath.Join(game.ModPath, "m1.esp"))
+	assert.NoError(t, err)
+
+	rootCmd.SetArgs([]string{"snapshot", "delete", rows[0].ID, "--game", game.ID})
+	out = captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "✓ Deleted snapshot "+rows[0].ID)
+
+	rootCmd.SetArgs([]string{"snapshot", "list", "--game", game.ID})
+	out = captureStdout(t, func() error { return rootCmd.ExecuteContext(context.Background()) })
+	assert.Contains(t, out, "before restoring "+rows[0].ID, "only the safety snapshot is left")
+}
@@ -7,11 +7,13 @@ import (
 	"io"
 	"sort"
 	"text/tabwriter"
+	"time"
 
 	"github.com/DonovanMods/linux-mod-manager/internal/core"
 	"github.com/DonovanMods/linux-mod-manager/internal/domain"
 	"github.com/DonovanMods/linux-mod-manager/internal/source"
 	"github.com/DonovanMods/linux-mod-manager/internal/source/custom"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/minisign"
 	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
 	"github.com/spf13/cobra"
 )
@@ -31,7 +33,7 @@ section of the project README for the file format.`,
 type sourceInfo struct {
 	ID           string `json:"id"`
 	Name         string `json:"name"`
-	Type         string `json:"type"` // "built-in", "directory", "manifest", "api", or "error"
+	Type         string `json:"type"` // "built-in", "directory", "manifest", "api", "exec", "releases", or "error"
 	Auth         string `json:"auth"` // "yes", "no", "n/a"
 	Capabilities string `json:"capabilities"`
 	// InUse marks a row as one of the active game's configured sources.
@@ -43,6 +45,19 @@ type sourceInfo struct {
 	// callers already depend on.
 	InUse bool   `json:"in_use,omitempty"`
 	Error string `json:"error,omitempty"`
+	// Mirrors is the source's mirror health record, filled only with -v
+	// and only for sources that have served files with mirrors.
+	Mirrors []mirrorInfo `json:"mirrors,omitempty"`
+}
+
+// mirrorInfo is one host's download record under `lmm source list -v`.
+type mirrorInfo struct {
+	Host          string `json:"host"`
+	Successes     int    `json:"successes"`
+	Failures      int    `json:"failures"`
+	LastSuccessAt string `json:"last_success_at,omitempty"` // RFC 3339
+	LastFailureAt string `json:"last_failure_at,omitempty"` // RFC 3339
+	LastError     string `json:"last_error,omitempty"`
 }
 
 var sourceAll bool
@@ -59,9 +74,15 @@ IN USE column. With no game resolvable, --all has no effect: the full
 registry is shown either way, exactly as when no game exists at all.
 Definitions that failed to load are always shown, in every view.
 
+With -v, also show the mirror health of sources whose files list mirrors:
+for each host, how many downloads succeeded and failed, when it last did
+each, and its last error. Downloads try hosts that worked before first and
+hosts that failed within the last hour last.
+
 Examples:
   lmm source list
   lmm source list --all
+  lmm source list -v
   lmm source list --json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// registerCustomSources' init-time stderr warnings would double up on
@@ -185,6 +206,11 @@ Examples:
 					Error: le.Err.Error(),
 				})
 			}
+			if verbose {
+				if err := addMirrorHealth(svc, rows); err != nil {
+					return err
+				}
+			}
 
 			if jsonOutput {
 				return json.NewEncoder(cmd.OutOrStdout()).Encode(rows)
@@ -212,11 +238,77 @@ Examples:
 				}
 				fmt.Fprintln(w, line+"\t"+r.Error)
 			}
-			return w.Flush()
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			if verbose {
+				return printMirrorHealth(cmd.OutOrStdout(), rows)
+			}
+			return nil
 		})
 	},
 }
 
+// addMirrorHealth attaches each registered source's mirror health record to
+// its row.
+func addMirrorHealth(svc *core.Service, rows []sourceInfo) error {
+	records, err := svc.ListMirrorHealth("")
+	if err != nil {
+		return err
+	}
+	bySource := make(map[string][]mirrorInfo)
+	for _, h := range records {
+		info := mirrorInfo{Host: h.Host, Successes: h.Successes, Failures: h.Failures, LastError: h.LastError}
+		if !h.LastSuccessAt.IsZero() {
+			info.LastSuccessAt = h.LastSuccessAt.Format(time.RFC3339)
+		}
+		if !h.LastFailureAt.IsZero() {
+			info.LastFailureAt = h.LastFailureAt.Format(time.RFC3339)
+		}
+		bySource[h.SourceID] = append(bySource[h.SourceID], info)
+	}
+	for i := range rows {
+		if rows[i].Type != "error" {
+			rows[i].Mirrors = bySource[rows[i].ID]
+		}
+	}
+	return nil
+}
+
+// printMirrorHealth renders the -v mirror health section below the source
+// table.
+func printMirrorHealth(out io.Writer, rows []sourceInfo) error {
+	fmt.Fprintln(out)
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	found := false
+	for _, r := range rows {
+		for _, m := range r.Mirrors {
+			if !found {
+				fmt.Fprintln(out, "Mirror health:")
+				fmt.Fprintln(w, "SOURCE\tHOST\tOK\tFAILED\tLAST OK\tLAST FAILURE\tLAST ERROR")
+				found = true
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\n", r.ID, m.Host, m.Successes, m.Failures,
+				formatMirrorTime(m.LastSuccessAt), formatMirrorTime(m.LastFailureAt), m.LastError)
+		}
+	}
+	if !found {
+		fmt.Fprintln(out, "Mirror health: no mirrored downloads recorded yet.")
+		return nil
+	}
+	return w.Flush()
+}
+
+// formatMirrorTime renders an RFC 3339 mirrorInfo timestamp as a local
+// "YYYY-MM-DD HH:MM", or "never".
+func formatMirrorTime(stamp string) string {
+	t, err := time.Parse(time.RFC3339, stamp)
+	if err != nil {
+		return "never"
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
 var (
 	sourceProbe   bool
 	sourceProbeID string
@@ -227,9 +319,14 @@ var sourceValidateCmd = &cobra.Command{
 	Short: "Validate a source definition file",
 	Long: `Parse and validate a user-defined source definition YAML file, reporting any problems.
 
+A manifest definition with a public_key also has its manifest's
+signature checked: the manifest and <manifest>.minisig are fetched and
+verified against the key.
+
 With --probe, also perform a live smoke test: a directory scan, a
-manifest fetch+parse, or an API call. For an api-type definition with no
-search endpoint, --id supplies a known mod ID to probe get_mod with.
+manifest or release listing fetch, an API call, or starting an exec plugin
+and calling it. For an api or exec source that cannot search, --id supplies a known
+mod ID to probe get_mod with.
 
 Examples:
   lmm source validate ~/.config/lmm/sources/my-source.yaml
@@ -242,15 +339,47 @@ Examples:
 			return err
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "%s: valid (%s source %q)\n", args[0], def.Type, def.ID)
-		if !sourceProbe {
+		signed := def.Type == custom.TypeManifest && def.Manifest.PublicKey != ""
+		if !signed && !sourceProbe {
 			return nil
 		}
 		return withService(cmd, func(ctx context.Context, svc *core.Service) error {
+			if signed {
+				if err := verifyManifestSignature(ctx, cmd, svc, def); err != nil {
+					return err
+				}
+			}
+			if !sourceProbe {
+				return nil
+			}
 			return probeSource(ctx, cmd, svc, def)
 		})
 	},
 }
 
+// verifyManifestSignature fetches a signed manifest definition's manifest and
+// signature and checks them against its public_key, with the source's API
+// key applied so protected manifests can be reached.
+func verifyManifestSignature(ctx context.Context, cmd *cobra.Command, svc *core.Service, def custom.SourceDefinition) error {
+	m, err := custom.NewManifest(def)
+	if err != nil {
+		return fmt.Errorf("signature: constructing source: %w", err)
+	}
+	if key := getSourceAPIKey(svc, def.ID, envKeyFor(m)); key != "" {
+		m.SetAPIKey(key)
+	}
+	trusted, err := m.VerifySignature(ctx)
+	if err != nil {
+		return fmt.Errorf("signature: %w", err)
+	}
+	pk, err := minisign.ParsePublicKey(def.Manifest.PublicKey)
+	if err != nil {
+		return fmt.Errorf("signature: %w", err) // unreachable after Validate
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "signature: ok — signed by key %s (trusted comment: %s)\n", pk.ID, trusted)
+	return nil
+}
+
 // probeSource constructs the definition's source and performs one live
 // operation against it, so users can smoke-test a definition before relying
 // on it (design §8).
@@ -259,6 +388,11 @@ func probeSource(ctx context.Context, cmd *cobra.Command, svc *core.Service, def
 	if err != nil {
 		return fmt.Errorf("probe: constructing source: %w", err)
 	}
+	// The probed source is never registered, so Service.Close will not stop
+	// an exec plugin for us.
+	if c, ok := src.(io.Closer); ok {
+		defer c.Close() //nolint:errcheck
+	}
 	if a, ok := src.(interface{ SetAPIKey(string) }); ok {
 		// envKeyFor(src) rather than envKeyForSourceID(def.ID) directly: today
 		// no custom type implements EnvKeyProvider, so both resolve to the
@@ -272,14 +406,14 @@ func probeSource(ctx context.Context, cmd *cobra.Command, svc *core.Service, def
 	}
 
 	switch def.Type {
-	case custom.TypeDirectory, custom.TypeManifest:
+	case custom.TypeDirectory, custom.TypeManifest, custom.TypeReleases:
 		res, err := src.Search(ctx, source.SearchQuery{PageSize: 1})
 		if err != nil {
 			return fmt.Errorf("probe: %w", err)
 		}
 		fmt.Fprintf(cmd.OutOrStdout(), "probe: ok — %d mod(s) visible\n", res.TotalCount)
-	case custom.TypeAPI:
-		if def.API.Endpoints.Search != nil {
+	case custom.TypeAPI, custom.TypeExec:
+		if source.CapabilitiesOf(src).Search {
 			res, err := src.Search(ctx, source.SearchQuery{PageSize: 1})
 			if err != nil {
 				return fmt.Errorf("probe: %w", err)
@@ -288,7 +422,7 @@ func probeSource(ctx context.Context, cmd *cobra.Command, svc *core.Service, def
 			return nil
 		}
 		if sourceProbeID == "" {
-			return fmt.Errorf("probe: this definition has no search endpoint; provide a known mod id with --id to probe get_mod")
+			return fmt.Errorf("probe: this source cannot search; provide a known mod id with --id to probe get_mod")
 		}
 		mod, err := src.GetMod(ctx, "", sourceProbeID)
 		if err != nil {
@@ -301,16 +435,16 @@ func probeSource(ctx context.Context, cmd *cobra.Command, svc *core.Service, def
 
 // isCustomSource reports whether src is a user-defined source (as opposed to
 // a built-in like NexusMods/CurseForge): a self-reported type of exactly
-// "directory", "manifest", or "api". "built-in" and the "unknown" fallback
-// both answer false — conservative on the unknown side so the definitions
-// reclassify loop (the only call site) reports a collision/error row rather
+// "directory", "manifest", "api", "exec", or "releases". "built-in" and the
+// "unknown" fallback both answer false — conservative on the unknown side so
+// the definitions reclassify loop (the only call site) reports a collision/error row rather
 // than assuming an unlabeled source is the definition's own. Unreachable in
 // practice: LoadSourceDefinitions guarantees ID uniqueness within a load, so
 // a registered source matching a definition's ID is either a built-in or
 // that definition's own constructed source — never an unrelated third party.
 func isCustomSource(src source.ModSource) bool {
 	switch source.TypeLabelOf(src) {
-	case "directory", "manifest", "api":
+	case "directory", "manifest", "api", "exec", "releases":
 		return true
 	}
 	return false
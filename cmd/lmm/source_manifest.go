@@ -0,0 +1,234 @@
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/source/custom"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/minisign"
+	"github.com/spf13/cobra"
+)
+
+var sourceManifestCmd = &cobra.Command{
+	Use:   "manifest",
+	Short: "Tools for publishing manifest sources",
+	Long: `Tools for publishers of manifest sources.
+
+'generate' builds a manifest from a folder of release archives, so sizes,
+checksums and versions never need to be maintained by hand.
+
+A manifest definition with a public_key only accepts a manifest whose
+detached minisign signature (<manifest>.minisig, next to the manifest)
+verifies against that key. Create a key pair once with 'keygen', then
+re-sign the manifest with 'sign' every time it changes. Signatures are
+minisign-compatible: 'minisign -Vm mods.yaml -P <public key>' verifies
+them, and keys made with 'minisign -G -W' can sign.`,
+}
+
+var sourceManifestKeygenForce bool
+
+var sourceManifestKeygenCmd = &cobra.Command{
+	Use:   "keygen <secret-key-file>",
+	Short: "Create a manifest signing key pair",
+	Long: `Create a minisign key pair for signing manifests.
+
+The secret key is written unencrypted to <secret-key-file> (mode 0600) and
+the public key to <secret-key-file>.pub. The public key is also printed:
+put it in the manifest definition's public_key field, and keep the secret
+key out of the published repository.
+
+Examples:
+  lmm source manifest keygen ~/.config/lmm/manifest.key`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runManifestKeygen(cmd, args[0])
+	},
+}
+
+var sourceManifestSignKey string
+
+var sourceManifestSignCmd = &cobra.Command{
+	Use:   "sign <manifest>",
+	Short: "Sign a manifest",
+	Long: `Write a detached minisign signature of a manifest to <manifest>.minisig.
+
+Publish the signature next to the manifest. Its trusted comment records the
+signing time and the manifest's file name.
+
+Examples:
+  lmm source manifest sign --key ~/.config/lmm/manifest.key mods.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runManifestSign(cmd, sourceManifestSignKey, args[0])
+	},
+}
+
+var (
+	sourceManifestGenerateURL       string
+	sourceManifestGenerateMirrors   []string
+	sourceManifestGenerateOutput    string
+	sourceManifestGenerateGameIDs   []string
+	sourceManifestGenerateAllowHTTP bool
+)
+
+var sourceManifestGenerateCmd = &cobra.Command{
+	Use:   "generate <archive-dir>",
+	Short: "Build a manifest from a folder of release archives",
+	Long: `Build a manifest from the release archives (.zip, .jar, .7z, .rar) in a folder.
+
+Each archive becomes a file of its mod. The mod comes from the archive's
+metadata file when it has one (ModInfo.xml, fabric.mod.json, mods.toml or
+a Thunderstore manifest.json; the name it declares is the mod ID),
+otherwise from the file name: "MyMod-1.2.0.zip" is mod MyMod, version 1.2.0. Sizes and sha256
+checksums are computed, and the newest version's files are marked primary.
+
+--url builds each download URL. {filename}, {mod_id} and {version} are
+replaced; a URL without placeholders is a base the file name is appended to.
+--mirror adds a mirror URL, built the same way, to every file; lmm falls
+back to mirrors in order when the main URL fails. Without --mirror, files
+already in the manifest keep the mirrors they list.
+
+With --output, an existing manifest at that path is merged rather than
+replaced: hand-written fields (game_ids, dependencies, summaries) are kept,
+and files whose archives are gone stay listed so older versions can still
+be installed. Without --output the manifest is printed. Re-sign a signed
+manifest after regenerating it.
+
+Examples:
+  lmm source manifest generate ./dist --url https://example.com/mods/ -o mods.yaml
+  lmm source manifest generate ./dist -o mods.yaml --game-id 7d2d \
+    --url 'https://github.com/me/mods/releases/download/{mod_id}-{version}/{filename}'
+  lmm source manifest generate ./dist -o mods.yaml --url https://files.example.com/mods/ \
+    --mirror https://backup.example.org/mods/`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runManifestGenerate(cmd, args[0])
+	},
+}
+
+func runManifestGenerate(cmd *cobra.Command, dir string) error {
+	opts := custom.GenerateOptions{
+		Dir:             dir,
+		URLTemplate:     sourceManifestGenerateURL,
+		MirrorTemplates: sourceManifestGenerateMirrors,
+		GameIDs:         sourceManifestGenerateGameIDs,
+		AllowHTTP:       sourceManifestGenerateAllowHTTP,
+	}
+	output := sourceManifestGenerateOutput
+	if output != "" {
+		existing, err := os.ReadFile(output)
+		switch {
+		case err == nil:
+			opts.Existing = existing
+		case !errors.Is(err, os.ErrNotExist):
+			return fmt.Errorf("reading existing manifest: %w", err)
+		}
+	}
+
+	res, err := custom.GenerateManifest(opts)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err := cmd.OutOrStdout().Write(res.Manifest)
+		return err
+	}
+	if err := os.WriteFile(output, res.Manifest, 0644); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Wrote %s: %d mod(s), %d new file(s), %d updated\n", output, res.Mods, res.Added, res.Updated)
+	if _, err := os.Stat(output + ".minisig"); err == nil {
+		fmt.Fprintf(out, "%s.minisig no longer matches; re-sign with 'lmm source manifest sign'\n", output)
+	}
+	return nil
+}
+
+func runManifestKeygen(cmd *cobra.Command, path string) error {
+	pubPath := path + ".pub"
+	if !sourceManifestKeygenForce {
+		for _, p := range []string{path, pubPath} {
+			if _, err := os.Stat(p); err == nil {
+				return fmt.Errorf("%s already exists (use --force to overwrite)", p)
+			} else if !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+
+	key, err := minisign.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	secret, err := key.MarshalText()
+	if err != nil {
+		return err
+	}
+	public, err := key.Public().MarshalText()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, secret, 0600); err != nil {
+		return fmt.Errorf("writing secret key: %w", err)
+	}
+	if err := os.WriteFile(pubPath, public, 0644); err != nil {
+		return fmt.Errorf("writing public key: %w", err)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Secret key: %s\n", path)
+	fmt.Fprintf(out, "Public key: %s\n\n", pubPath)
+	fmt.Fprintln(out, "Add this to the manifest source definition:")
+	fmt.Fprintf(out, "  public_key: %s\n", key.Public())
+	return nil
+}
+
+func runManifestSign(cmd *cobra.Command, keyPath, manifestPath string) error {
+	keyData, err := os.ReadFile(keyPath)
+	if err != nil {
+		return fmt.Errorf("reading secret key: %w", err)
+	}
+	key, err := minisign.ParsePrivateKey(keyData)
+	if err != nil {
+		return fmt.Errorf("%s: %w", keyPath, err)
+	}
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return fmt.Errorf("reading manifest: %w", err)
+	}
+
+	comment := fmt.Sprintf("timestamp:%d\tfile:%s", time.Now().Unix(), filepath.Base(manifestPath))
+	sig, err := minisign.Sign(key, data, comment)
+	if err != nil {
+		return err
+	}
+	sigPath := manifestPath + ".minisig"
+	if err := os.WriteFile(sigPath, sig, 0644); err != nil {
+		return fmt.Errorf("writing signature: %w", err)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Signed %s with key %s: %s\n", manifestPath, key.ID, sigPath)
+	return nil
+}
+
+func init() {
+	sourceManifestGenerateCmd.Flags().StringVar(&sourceManifestGenerateURL, "url", "", "download URL template or base URL (required)")
+	sourceManifestGenerateCmd.Flags().StringArrayVar(&sourceManifestGenerateMirrors, "mirror", nil, "mirror URL template or base URL, tried after --url (repeatable)")
+	sourceManifestGenerateCmd.Flags().StringVarP(&sourceManifestGenerateOutput, "output", "o", "", "manifest file to write, merging with it if it exists")
+	sourceManifestGenerateCmd.Flags().StringSliceVar(&sourceManifestGenerateGameIDs, "game-id", nil, "game_ids for mods new to the manifest (repeatable)")
+	sourceManifestGenerateCmd.Flags().BoolVar(&sourceManifestGenerateAllowHTTP, "allow-http", false, "permit plain http:// download URLs")
+	_ = sourceManifestGenerateCmd.MarkFlagRequired("url")
+
+	sourceManifestKeygenCmd.Flags().BoolVar(&sourceManifestKeygenForce, "force", false, "overwrite existing key files")
+	sourceManifestSignCmd.Flags().StringVar(&sourceManifestSignKey, "key", "", "minisign secret key file (required)")
+	_ = sourceManifestSignCmd.MarkFlagRequired("key")
+
+	sourceManifestCmd.AddCommand(sourceManifestGenerateCmd)
+	sourceManifestCmd.AddCommand(sourceManifestKeygenCmd)
+	sourceManifestCmd.AddCommand(sourceManifestSignCmd)
+	sourceCmd.AddCommand(sourceManifestCmd)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetSourceManifestFlags restores the manifest subcommands' flag vars,
+// which persist across Execute() calls like the --probe ones do.
+func resetSourceManifestFlags(t *testing.T) {
+	t.Helper()
+	sourceManifestKeygenForce = false
+	sourceManifestSignKey = ""
+	sourceManifestGenerateURL = ""
+	sourceManifestGenerateMirrors = nil
+	sourceManifestGenerateOutput = ""
+	sourceManifestGenerateGameIDs = nil
+	sourceManifestGenerateAllowHTTP = false
+}
+
+func TestSourceManifestKeygenSignValidate(t *testing.T) {
+	resetSourceManifestFlags(t)
+	resetSourceProbeFlags(t)
+	t.Cleanup(func() { resetSourceManifestFlags(t); resetSourceProbeFlags(t) })
+	configDir = t.TempDir()
+	dataDir = t.TempDir()
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "manifest.key")
+	out, err := runSourceCmd(t, "source", "manifest", "keygen", keyPath)
+	require.NoError(t, err)
+	_, line, ok := strings.Cut(out, "public_key: ")
+	require.True(t, ok, out)
+	publicKey := strings.TrimSpace(line)
+
+	info, err := os.Stat(keyPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), "the secret key is private")
+	pub, err := os.ReadFile(keyPath + ".pub")
+	require.NoError(t, err)
+	assert.Contains(t, string(pub), publicKey)
+
+	_, err = runSourceCmd(t, "source", "manifest", "keygen", keyPath)
+	assert.ErrorContains(t, err, "--force", "an existing key is not overwritten")
+
+	manifestPath := filepath.Join(dir, "mods.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, []byte("version: 1\nmods: []\n"), 0644))
+	defPath := filepath.Join(dir, "signed.yaml")
+	require.NoError(t, os.WriteFile(defPath, []byte(`
+id: signed-mods
+name: Signed Mods
+type: manifest
+manifest:
+  url: `+manifestPath+`
+  public_key: `+publicKey+`
+`), 0644))
+
+	_, err = runSourceCmd(t, "source", "validate", defPath)
+	assert.ErrorContains(t, err, "signature", "validate checks the signature of a signed source")
+
+	out, err = runSourceCmd(t, "source", "manifest", "sign", "--key", keyPath, manifestPath)
+	require.NoError(t, err)
+	assert.Contains(t, out, manifestPath+".minisig")
+
+	out, err = runSourceCmd(t, "source", "validate", defPath)
+	require.NoError(t, err)
+	assert.Contains(t, out, "signature: ok")
+	assert.Contains(t, out, "file:mods.yaml")
+
+	require.NoError(t, os.WriteFile(manifestPath, []byte("version: 1\nmods: [evil]\n"), 0644))
+	_, err = runSourceCmd(t, "source", "validate", defPath)
+	assert.ErrorContains(t, err, "verification failed")
+}
+
+func TestSourceManifestSignRequiresKey(t *testing.T) {
+	resetSourceManifestFlags(t)
+	t.Cleanup(func() { resetSourceManifestFlags(t) })
+
+	_, err := runSourceCmd(t, "source", "manifest", "sign", filepath.Join(t.TempDir(), "mods.yaml"))
+	assert.ErrorContains(t, err, "key")
+}
+
+func TestSourceManifestGenerate(t *testing.T) {
+	resetSourceManifestFlags(t)
+	t.Cleanup(func() { resetSourceManifestFlags(t) })
+
+	dist := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dist, "PlainMod-1.0.zip"), []byte("v1"), 0644))
+	out, err := runSourceCmd(t, "source", "manifest", "generate", dist, "--url", "https://dl.test/", "--mirror", "https://backup.test/{filename}")
+	require.NoError(t, err)
+	assert.Contains(t, out, "url: https://dl.test/PlainMod-1.0.zip", "without --output the manifest is printed")
+	assert.Contains(t, out, "- https://backup.test/PlainMod-1.0.zip")
+	sourceManifestGenerateMirrors = nil
+
+	manifestPath := filepath.Join(t.TempDir(), "mods.yaml")
+	_, err = runSourceCmd(t, "source", "manifest", "generate", dist, "--url", "https://dl.test/", "-o", manifestPath, "--game-id", "7d2d")
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(filepath.Join(dist, "PlainMod-1.0.zip")))
+	require.NoError(t, os.WriteFile(filepath.Join(dist, "PlainMod-1.1.zip"), []byte("v2"), 0644))
+	require.NoError(t, os.WriteFile(manifestPath+".minisig", []byte("old"), 0644))
+
+	out, err = runSourceCmd(t, "source", "manifest", "generate", dist, "--url", "https://dl.test/", "-o", manifestPath)
+	require.NoError(t, err)
+	assert.Contains(t, out, "1 mod(s), 1 new file(s), 0 updated")
+	assert.Contains(t, out, "re-sign", "a stale signature is pointed out")
+
+	data, err := os.ReadFile(manifestPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "PlainMod-1.0.zip", "the older version stays listed")
+	assert.Contains(t, string(data), "PlainMod-1.1.zip")
+	assert.Contains(t, string(data), "7d2d")
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +19,7 @@ import (
 	"github.com/DonovanMods/linux-mod-manager/internal/source/custom"
 	"github.com/DonovanMods/linux-mod-manager/internal/source/nexusmods"
 	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/db"
 )
 
 func TestSourceCmd_Structure(t *testing.T) {
@@ -668,3 +670,49 @@ func rowIDs(rows []sourceInfo) []string {
 	}
 	return ids
 }
+
+func TestSourceListCmd_VerboseShowsMirrorHealth(t *testing.T) {
+	gameID = "" // see TestSourceListCmd_ErrorRows' runList comment
+	configDir = t.TempDir()
+	dataDir = t.TempDir()
+	t.Cleanup(func() { verbose = false; jsonOutput = false })
+
+	// -v is a root persistent flag, which runSourceCmd's stand-in root lacks.
+	verbose = true
+	out, err := runSourceCmd(t, "source", "list")
+	require.NoError(t, err)
+	assert.Contains(t, out, "no mirrored downloads recorded yet")
+
+	database, err := db.New(filepath.Join(dataDir, "lmm.db"))
+	require.NoError(t, err)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, database.RecordMirrorSuccess("nexusmods", "mirror.example.org", at))
+	require.NoError(t, database.RecordMirrorFailure("nexusmods", "files.example.com", "HTTP error: 503", at))
+	require.NoError(t, database.Close())
+
+	out, err = runSourceCmd(t, "source", "list")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Mirror health:")
+	assert.Regexp(t, `nexusmods\s+files\.example\.com\s+0\s+1\s+never\s+\S+ \S+\s+HTTP error: 503`, out)
+	assert.Regexp(t, `nexusmods\s+mirror\.example\.org\s+1\s+0\s+`, out)
+
+	verbose = false
+	out, err = runSourceCmd(t, "source", "list")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "Mirror health", "mirror health is only shown with -v")
+
+	verbose = true
+	jsonOutput = true
+	out, err = runSourceCmd(t, "source", "list")
+	require.NoError(t, err)
+	var rows []sourceInfo
+	require.NoError(t, json.Unmarshal([]byte(out), &rows))
+	for _, r := range rows {
+		if r.ID == "nexusmods" {
+			require.Len(t, r.Mirrors, 2)
+			assert.Equal(t, mirrorInfo{Host: "files.example.com", Failures: 1, LastFailureAt: "2026-03-01T12:00:00Z", LastError: "HTTP error: 503"}, r.Mirrors[0])
+			return
+		}
+	}
+	t.Fatalf("no nexusmods row in %+v", rows)
+}
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/DonovanMods/linux-mod-manager/internal/core"
 	"github.com/DonovanMods/linux-mod-manager/internal/domain"
@@ -24,6 +25,9 @@ var (
 	updateAll     bool
 	updateDryRun  bool
 	updateForce   bool
+
+	updateRollbackTo   string
+	updateRollbackList bool
 )
 
 type updateJSONOutput struct {
@@ -51,6 +55,16 @@ type updateJSONOutput struct {
 type updateSkippedJSON struct {
 	Pinned int `json:"pinned"`
 	Local  int `json:"local"`
+	Dev    int `json:"dev"`
+}
+
+type modVersionJSON struct {
+	Version    string   `json:"version"`
+	FileIDs    []string `json:"file_ids"`
+	DeployedAt string   `json:"deployed_at,omitempty"`
+	Cached     bool     `json:"cached"`
+	Current    bool     `json:"current,omitempty"`
+	Previous   bool     `json:"previous,omitempty"`
 }
 
 type updateModJSON struct {
@@ -163,11 +177,13 @@ Examples:
 var updateRollbackCmd = &cobra.Command{
 	Use:   "rollback <mod-id>",
 	Short: "Rollback a mod to its previous version",
-	Long: `Rollback a mod to the version before the last update.
+	Long: `Rollback a mod to the version before the last update, or with --to
+to any version ever deployed in the profile. --list shows those versions,
+most recently deployed first, and whether the cache still holds each one.
 
-The previous version must still be available in the cache. If the same
-mod ID is installed from more than one source in the profile, use
--s/--source to disambiguate.
+A version the cache no longer holds is downloaded again from its source.
+If the same mod ID is installed from more than one source in the profile,
+use -s/--source to disambiguate.
 
 --json prints the single-mod document (see 'lmm update --help') with
 status "rolled_back", or status "skipped" with reason "locked" when the
@@ -175,7 +191,9 @@ mod is locked (unlock or move the lock to roll back).
 
 Examples:
   lmm update rollback 12345 --game skyrim-se
-  lmm update rollback 12345 --game skyrim-se --source nexusmods`,
+  lmm update rollback 12345 --game skyrim-se --source nexusmods
+  lmm update rollback 12345 --game skyrim-se --list
+  lmm update rollback 12345 --game skyrim-se --to 1.2.0`,
 	Args: cobra.ExactArgs(1),
 	RunE: runUpdateRollback,
 }
@@ -190,6 +208,9 @@ func init() {
 	updateRollbackCmd.Flags().StringVarP(&updateSource, "source", "s", "", "mod source (default: the sole configured source; prompts when several are configured)")
 	updateRollbackCmd.Flags().StringVarP(&updateProfile, "profile", "p", "", "profile (default: active profile)")
 	updateRollbackCmd.Flags().BoolVarP(&updateForce, "force", "f", false, "continue even if hooks fail")
+	updateRollbackCmd.Flags().StringVar(&updateRollbackTo, "to", "", "roll back to this version from the mod's version history")
+	updateRollbackCmd.Flags().BoolVar(&updateRollbackList, "list", false, "list the versions the mod can be rolled back to")
+	updateRollbackCmd.MarkFlagsMutuallyExclusive("to", "list")
 
 	updateCmd.AddCommand(updateRollbackCmd)
 	rootCmd.AddCommand(updateCmd)
@@ -344,7 +365,7 @@ func doUpdate(ctx context.Context, service *core.Service, game *domain.Game, arg
 			skips := core.CountUpdateSkips(installed)
 			out := updateJSONOutput{
 				GameID: game.ID, Profile: profileName, Updates: []updateModJSON{},
-				Skipped: updateSkippedJSON{Pinned: skips.Pinned, Local: skips.Local},
+				Skipped: updateSkippedJSON{Pinned: skips.Pinned, Local: skips.Local, Dev: skips.Dev},
 			}
 			if checkErr != nil {
 				out.Error = checkErr.Error()
@@ -396,7 +417,7 @@ func doUpdate(ctx context.Context, service *core.Service, game *domain.Game, arg
 		skips := core.CountUpdateSkips(installed)
 		out := updateJSONOutput{
 			GameID: game.ID, Profile: profileName, Updates: make([]updateModJSON, len(updates)),
-			Skipped: updateSkippedJSON{Pinned: skips.Pinned, Local: skips.Local},
+			Skipped: updateSkippedJSON{Pinned: skips.Pinned, Local: skips.Local, Dev: skips.Dev},
 		}
 		if checkErr != nil {
 			out.Error = checkErr.Error()
@@ -791,6 +812,14 @@ func applyUpdate(ctx context.Context, service *core.Service, game *domain.Game,
 			if verbose && !jsonOutput {
 				fmt.Printf("  %s\n", p.Detail)
 			}
+		case core.UpdateConfigMerged:
+			if !jsonOutput {
+				fmt.Printf("  %s\n", p.Detail)
+			}
+		case core.CacheQuotaEnforced:
+			if !jsonOutput {
+				printCacheQuota(p)
+			}
 		}
 	}
 
@@ -830,10 +859,10 @@ func runUpdateRollback(cmd *cobra.Command, args []string) error {
 // "Rolling back %s %s → %s..." header (using its own GetInstalledMod call,
 // which also reproduces doUpdateRollback's pre-extraction guard errors
 // verbatim: "mod not found: %s" and, before ApplyRollback is ever called,
-// the same PreviousVersion/cache-existence checks ApplyRollback repeats
-// internally - so the header never prints when either guard would fail,
-// matching the pre-extraction ordering exactly; a locked mod is likewise
-// refused as a skip before the header, #143) -> calls
+// the same Service.RollbackTarget resolution ApplyRollback repeats
+// internally - so the header never prints for a version that cannot be
+// rolled back to; a locked mod is likewise refused as a skip before the
+// header, #143) -> calls
 // Service.ApplyRollback, printing from its progress events exactly like
 // applyUpdate does for ApplyUpdate (forced-hook warnings, after_each hook
 // warnings, and the --verbose-gated link-method note all reuse the SAME
@@ -859,13 +888,13 @@ func doUpdateRollback(ctx context.Context, service *core.Service, game *domain.G
 		return fmt.Errorf("mod not found: %s", modID)
 	}
 
-	if mod.PreviousVersion == "" {
-		return fmt.Errorf("no previous version available for rollback")
+	if updateRollbackList {
+		return printModVersions(service, game, mod)
 	}
 
-	// Check if previous version exists in cache
-	if !service.GetGameCache(game).Exists(game.ID, mod.SourceID, mod.ID, mod.PreviousVersion) {
-		return fmt.Errorf("previous version %s not found in cache", mod.PreviousVersion)
+	target, err := service.RollbackTarget(game, mod, updateRollbackTo)
+	if err != nil {
+		return err
 	}
 
 	// #143: refuse a locked mod up front, mirroring applySingleUpdate's
@@ -880,31 +909,43 @@ func doUpdateRollback(ctx context.Context, service *core.Service, game *domain.G
 		if ref := prof.FindRef(mod.SourceID, mod.ID); ref != nil && ref.Locked {
 			if jsonOutput {
 				return emitSingleUpdateJSON(singleUpdateJSON{
-					ModID: mod.ID, Name: mod.Name, FromVersion: mod.Version, ToVersion: mod.PreviousVersion, Status: "skipped", Reason: "locked",
+					ModID: mod.ID, Name: mod.Name, FromVersion: mod.Version, ToVersion: target.Version, Status: "skipped", Reason: "locked",
 				})
 			}
-			fmt.Printf("Rollback available: %s → %s — but %s is locked at v%s.\n", mod.Version, mod.PreviousVersion, mod.Name, ref.Version)
+			fmt.Printf("Rollback available: %s → %s — but %s is locked at v%s.\n", mod.Version, target.Version, mod.Name, ref.Version)
 			// -s/-p on both remedies for the same reason as applySingleUpdate's
 			// locked branch (#142 round 5): a bare copy-paste could resolve
 			// against the wrong profile or an ambiguous source.
-			fmt.Printf("Move the lock: lmm mod lock -s %s -p %s %s %s   |   Unlock: lmm mod unlock -s %s -p %s %s\n", mod.SourceID, profileName, mod.ID, mod.PreviousVersion, mod.SourceID, profileName, mod.ID)
+			fmt.Printf("Move the lock: lmm mod lock -s %s -p %s %s %s   |   Unlock: lmm mod unlock -s %s -p %s %s\n", mod.SourceID, profileName, mod.ID, target.Version, mod.SourceID, profileName, mod.ID)
 			return nil
 		}
 	}
 
 	if !jsonOutput {
-		fmt.Printf("Rolling back %s %s → %s...\n", mod.Name, mod.Version, mod.PreviousVersion)
+		fmt.Printf("Rolling back %s %s → %s...\n", mod.Name, mod.Version, target.Version)
+		if !target.Cached {
+			fmt.Printf("  %s is no longer cached; downloading it again\n", target.Version)
+		}
 	}
 
 	opts := core.RollbackOptions{
-		Hooks:       getResolvedHooks(service, game, profileName),
-		HookRunner:  getHookRunner(service),
-		HookContext: makeHookContext(game),
-		Force:       updateForce,
+		Hooks:         getResolvedHooks(service, game, profileName),
+		HookRunner:    getHookRunner(service),
+		HookContext:   makeHookContext(game),
+		Force:         updateForce,
+		TargetVersion: updateRollbackTo,
 	}
 
 	progress := func(p core.DeployProgress) {
 		switch p.Phase {
+		case core.UpdateDownloading:
+			if verbose && !jsonOutput {
+				fmt.Printf("\r  Downloading: %.1f%%", p.Percent)
+			}
+		case core.UpdateDownloadDone:
+			if verbose && !jsonOutput {
+				fmt.Println()
+			}
 		case core.UpdateBeforeEachForced, core.UpdateWarning:
 			fmt.Fprintf(os.Stderr, "Warning: %s\n", p.Detail)
 		case core.UpdateNote:
@@ -930,11 +971,82 @@ func doUpdateRollback(ctx context.Context, service *core.Service, game *domain.G
 	return nil
 }
 
+// printModVersions prints the version history of mod for
+// 'lmm update rollback --list'.
+func printModVersions(service *core.Service, game *domain.Game, mod *domain.InstalledMod) error {
+	versions, err := service.ModVersionHistory(game, mod.ProfileName, mod.SourceID, mod.ID)
+	if err != nil {
+		return fmt.Errorf("reading version history: %w", err)
+	}
+
+	if jsonOutput {
+		rows := make([]modVersionJSON, len(versions))
+		for i, v := range versions {
+			rows[i] = modVersionJSON{Version: v.Version, FileIDs: v.FileIDs, Cached: v.Cached, Current: v.Current, Previous: v.Previous}
+			if rows[i].FileIDs == nil {
+				rows[i].FileIDs = []string{}
+			}
+			if !v.DeployedAt.IsZero() {
+				rows[i].DeployedAt = v.DeployedAt.UTC().Format(time.RFC3339)
+			}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rows); err != nil {
+			return fmt.Errorf("encoding json: %w", err)
+		}
+		return nil
+	}
+
+	if len(versions) == 0 {
+		fmt.Printf("No version history for %s.\n", mod.Name)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "VERSION\tFILES\tDEPLOYED\tCACHED\tSTATUS"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	if _, err := fmt.Fprintln(w, "-------\t-----\t--------\t------\t------"); err != nil {
+		return fmt.Errorf("writing header: %w", err)
+	}
+	for _, v := range versions {
+		files := strings.Join(v.FileIDs, ",")
+		if files == "" {
+			files = "-"
+		}
+		deployed := "unknown"
+		if !v.DeployedAt.IsZero() {
+			deployed = v.DeployedAt.Local().Format("2006-01-02 15:04")
+		}
+		cached := "no"
+		if v.Cached {
+			cached = "yes"
+		}
+		row := strings.Join([]string{v.Version, files, deployed, cached}, "\t")
+		switch {
+		case v.Current:
+			row += "\tcurrent"
+		case v.Previous:
+			row += "\tprevious"
+		}
+		if _, err := fmt.Fprintln(w, row); err != nil {
+			return fmt.Errorf("writing row: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("flushing table: %w", err)
+	}
+	return printTable(&buf, 2, nil)
+}
+
 // printSkipped notes the mods CheckUpdates filtered out, if any. No-op at zero
 // so the common case stays quiet.
 //
-// Pinned and local get separate lines because the remedies differ: a pin is a
-// reversible choice, a local mod has no remote and never will.
+// Each reason gets its own line because the remedies differ: a pin is a
+// reversible choice, a local mod has no remote and never will, and a dev mod
+// is updated by editing its working directory.
 //
 // Emits no leading blank line: when every mod is skipped this is the whole
 // output, and a leading newline would render as a stray blank first line.
@@ -946,6 +1058,9 @@ func printSkipped(skips core.UpdateSkips) {
 	if skips.Local > 0 {
 		fmt.Printf("%d local mod%s skipped (no remote source to check).\n", skips.Local, plural(skips.Local))
 	}
+	if skips.Dev > 0 {
+		fmt.Printf("%d dev mod%s skipped (linked from a working directory).\n", skips.Dev, plural(skips.Dev))
+	}
 }
 
 func plural(n int) string {
@@ -75,8 +75,8 @@ func TestDoUpdateRollback_NoPreviousVersion_ReturnsExactError(t *testing.T) {
 
 // TestDoUpdateRollback_MissingCache_ReturnsExactError guards the second
 // guard: PreviousVersion is set, but its cache entry has since been
-// removed - doUpdateRollback must fail with the exact pre-extraction error
-// text, again before the header ever prints.
+// removed and the source no longer serves its file - the header announces
+// the download, and the failure names the version.
 func TestDoUpdateRollback_MissingCache_ReturnsExactError(t *testing.T) {
 	svc, game, _ := setupRollbackReadyMod(t)
 	require.NoError(t, svc.GetGameCache(game).Delete("g1", "test-src", "mod1", "1.0"))
@@ -87,8 +87,64 @@ func TestDoUpdateRollback_MissingCache_ReturnsExactError(t *testing.T) {
 		return nil
 	})
 	require.Error(t, callErr)
-	assert.Equal(t, "previous version 1.0 not found in cache", callErr.Error())
-	assert.Empty(t, out, "the header must never print when this guard fails")
+	assert.Contains(t, callErr.Error(), "previous version 1.0 not found in cache and could not be downloaded again: ")
+	assert.Equal(t, "Rolling back Mod One 2.0 → 1.0...\n  1.0 is no longer cached; downloading it again\n", out)
+
+	updated, err := svc.GetInstalledMod("test-src", "mod1", "g1", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0", updated.Version)
+}
+
+// TestDoUpdateRollback_ToVersion_ListsAndRedownloads: after two updates,
+// --list shows all three versions and --to reaches the first one, whose
+// cache entry has been GC'd, by downloading it again.
+func TestDoUpdateRollback_ToVersion_ListsAndRedownloads(t *testing.T) {
+	svc, game, mod := setupRollbackReadyMod(t)
+	registered, err := svc.GetSource("test-src")
+	require.NoError(t, err)
+	src := registered.(*fakeUpdateSource)
+	src.AddMod(&domain.Mod{ID: "mod1", SourceID: "test-src", Name: "Mod One", Version: "3.0", GameID: "g1"},
+		[]domain.DownloadableFile{
+			{ID: "old-1", FileName: "mod1-old.esp", Version: "1.0"},
+			{ID: "new-2", FileName: "mod1-newer.esp", Version: "3.0", IsPrimary: true},
+		})
+	src.AddDownload("old-1", []byte("old-content"))
+	src.AddDownload("new-2", []byte("newer-content"))
+	require.NoError(t, captureStdoutOnlyErr(t, func() error {
+		return applySingleUpdate(context.Background(), svc, game, mod, "default")
+	}))
+	require.NoError(t, svc.GetGameCache(game).Delete("g1", "test-src", "mod1", "1.0"))
+
+	updateRollbackList = true
+	out := captureStdout(t, func() error {
+		return doUpdateRollback(context.Background(), svc, game, "mod1")
+	})
+	assert.Regexp(t, `(?m)^VERSION +FILES +DEPLOYED +CACHED +STATUS$`, out)
+	assert.Regexp(t, `(?m)^3\.0 +new-2 +\S+ \S+ +yes +current$`, out)
+	assert.Regexp(t, `(?m)^2\.0 +new-1 +\S+ \S+ +yes +previous$`, out)
+	assert.Regexp(t, `(?m)^1\.0 +old-1 +\S+ \S+ +no$`, out)
+
+	updateRollbackList = false
+	updateRollbackTo = "1.0"
+	out = captureStdout(t, func() error {
+		return doUpdateRollback(context.Background(), svc, game, "mod1")
+	})
+	assert.Contains(t, out, "Rolling back Mod One 3.0 → 1.0...\n  1.0 is no longer cached; downloading it again\n")
+	assert.Contains(t, out, "✓ Rolled back: Mod One 3.0 → 1.0")
+
+	updated, err := svc.GetInstalledMod("test-src", "mod1", "g1", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", updated.Version)
+	assert.Equal(t, []string{"old-1"}, updated.FileIDs)
+	assert.Equal(t, "3.0", updated.PreviousVersion)
+	content, err := os.ReadFile(filepath.Join(game.ModPath, "mod1-old.esp"))
+	require.NoError(t, err)
+	assert.Equal(t, "old-content", string(content))
+
+	updateRollbackTo = "9.9"
+	err = doUpdateRollback(context.Background(), svc, game, "mod1")
+	require.Error(t, err)
+	assert.Equal(t, "version 9.9 of Mod One was never deployed in profile default (known: 1.0, 3.0, 2.0)", err.Error())
 }
 
 // TestDoUpdateRollback_Locked_RefusesBeforeHeader_Text (#143 polish): a
@@ -230,6 +230,7 @@ func setupDoUpdateTest(t *testing.T) (*core.Service, *domain.Game, *fakeUpdateSo
 
 	oldSource, oldProfile, oldAll, oldDryRun, oldForce := updateSource, updateProfile, updateAll, updateDryRun, updateForce
 	oldVerbose, oldNoColor, oldNoHooks := verbose, noColor, noHooks
+	oldRollbackTo, oldRollbackList := updateRollbackTo, updateRollbackList
 	updateSource = "test-src"
 	updateProfile = ""
 	updateAll = false
@@ -238,9 +239,11 @@ func setupDoUpdateTest(t *testing.T) (*core.Service, *domain.Game, *fakeUpdateSo
 	verbose = false
 	noColor = true
 	noHooks = false
+	updateRollbackTo, updateRollbackList = "", false
 	t.Cleanup(func() {
 		updateSource, updateProfile, updateAll, updateDryRun, updateForce = oldSource, oldProfile, oldAll, oldDryRun, oldForce
 		verbose, noColor, noHooks = oldVerbose, oldNoColor, oldNoHooks
+		updateRollbackTo, updateRollbackList = oldRollbackTo, oldRollbackList
 	})
 
 	return svc, game, src
@@ -31,7 +31,7 @@ type verifyFileJSON struct {
 	ModName string `json:"mod_name"`
 	FileID  string `json:"file_id"`
 	Status  string `json:"status"`         // ok, missing, no_checksum, file_count_mismatch, skipped, version_mismatch, version_unverifiable, stale_compile, stale_deployment, fixed_stale_deployment, conversion_failed, needs_reingest, fixed_needs_reingest
-	Note    string `json:"note,omitempty"` // optional detail: a blocked cache rename, sibling-repair results, a --fix repair/redownload failure reason, a file-count-check lookup failure, a stale-deployment reason ("no longer provided by <source>/<mod>" | "dangling link into lmm cache"), a convergence per-item error (e.g. an unsafe deployed-file record skipped), a pak-conversion failure reason (conversion_failed), or why/whether a pak needed re-ingesting (needs_reingest / fixed_needs_reingest) - omitted when there's nothing extra to add
+	Note    string `json:"note,omitempty"` // optional detail: a blocked cache rename, sibling-repair results, a --fix repair/redownload failure reason, a file-count-check lookup failure, a stale-deployment reason ("no longer provided by <source>/<mod>" | "dangling link into lmm cache"), a convergence per-item error (e.g. an unsafe deployed-file record skipped), a pak-conversion failure reason (conversion_failed), why/whether a pak needed re-ingesting (needs_reingest / fixed_needs_reingest), or a drifted ini patch key's expected and actual value (ini_drift / fixed_ini_drift) - omitted when there's nothing extra to add
 }
 
 var verifyCmd = &cobra.Command{
@@ -132,6 +132,12 @@ mod-id filter - and it runs even when the profile has no installed mods
 at all, since a game dir can still hold stray lmm-deployed files after
 everything is uninstalled (#217).
 
+verify also checks the profile's ini_patches: a patched key whose value
+was changed on disk, went missing, or (for a deleted key) reappeared is
+reported as INI DRIFT, naming the file, section, key, and the expected
+and actual values. --fix re-applies the patches. This check is
+profile-wide too.
+
 Mods installed from a local source, mods requiring manual download, and
 mods with no recorded file IDs are skipped silently - there is nothing
 to check against. If the source can't be reached, the mod is reported
@@ -174,8 +180,8 @@ OK case - this is never counted in issues or warnings.
 status, note}], issues, warnings}; status is one of "ok", "missing",
 "no_checksum", "file_count_mismatch", "skipped", "version_mismatch",
 "version_unverifiable", "stale_compile", "stale_deployment",
-"fixed_stale_deployment", "conversion_failed", "needs_reingest", or
-"fixed_needs_reingest"; note adds detail where there's something extra to
+"fixed_stale_deployment", "conversion_failed", "needs_reingest",
+"fixed_needs_reingest", "ini_drift", or "fixed_ini_drift"; note adds detail where there's something extra to
 say - a blocked cache rename, sibling-repair results, a --fix repair or
 redownload failure's reason, why a successful re-download stored no
 checksum, a file-count-check lookup failure, a --fix refusal on a locked
@@ -183,16 +189,18 @@ record ("locked"), a locked record's pending convergence detail, a
 stale-deployment row's reason (populated on both "stale_deployment" and
 "fixed_stale_deployment"), a pak's conversion-failure reason
 ("conversion_failed"), or why/whether a pak needed re-ingesting
-(populated on both "needs_reingest" and "fixed_needs_reingest") - and is
-omitted otherwise. issues counts MISSING files and VERSION MISMATCH rows
-(a successful --fix repair of either decrements it back out; a locked
-VERSION MISMATCH stays counted since --fix refuses it); warnings counts
-everything else that isn't OK, including "stale_deployment",
-"conversion_failed", and "needs_reingest" rows (never
-"fixed_stale_deployment" or "fixed_needs_reingest" - a successful --fix
-removal/re-ingest is a resolved problem, not an outstanding one, the same
-convention as a successful re-download or version repair). Lock-pending-convergence rows
-are informational only and count toward neither.
+(populated on both "needs_reingest" and "fixed_needs_reingest"), or a
+drifted ini patch key's expected and actual values (populated on both
+"ini_drift" and "fixed_ini_drift") - and is omitted otherwise. issues
+counts MISSING files and VERSION MISMATCH rows (a successful --fix repair
+of either decrements it back out; a locked VERSION MISMATCH stays counted
+since --fix refuses it); warnings counts everything else that isn't OK,
+including "stale_deployment", "conversion_failed", "needs_reingest", and
+"ini_drift" rows (never a fixed_* row - a successful --fix removal,
+re-ingest, or ini re-apply is a resolved problem, not an outstanding one,
+the same convention as a successful re-download or version repair).
+Lock-pending-convergence rows are informational only and count toward
+neither.
 
 Examples:
   lmm verify --game skyrim-se           # Verify all mods
@@ -405,6 +413,12 @@ func renderVerifyFinding(ev core.VerifyEvent) {
 		// reports per-path, not per-mod-file).
 		fmt.Printf("%s %s - STALE DEPLOYMENT (%s)\n", colorYellow("?"), f.FileID, f.Note)
 
+	case "ini_drift":
+		fmt.Printf("%s %s - INI DRIFT (%s)\n", colorYellow("?"), f.FileID, f.Note)
+
+	case "fixed_ini_drift":
+		fmt.Println(colorGreen(fmt.Sprintf("Fixed: re-applied ini patch to %s (%s)", f.FileID, f.Note)))
+
 	case "fixed_stale_deployment":
 		// The WHOLE line is green (Variant "fixed_green") - unlike a
 		// version repair, which prints a plain main line and a separate
@@ -444,9 +458,10 @@ func renderVerifySkipped(f core.VerifyFinding) {
 	case strings.HasPrefix(f.Note, "convergence: "):
 		fmt.Printf("%s %s\n", colorYellow("?"), f.Note)
 
-	// mergedPakStalenessPass: CheckMergedPakStaleness itself failed - Note
-	// is already the full message.
-	case strings.HasPrefix(f.Note, "could not check merged pak staleness: "):
+	// mergedPakStalenessPass / iniPatchesPass: the check itself failed -
+	// Note is already the full message.
+	case strings.HasPrefix(f.Note, "could not check merged pak staleness: "),
+		strings.HasPrefix(f.Note, "could not check ini patches: "):
 		fmt.Printf("%s %s\n", colorYellow("?"), f.Note)
 
 	// fileCountPrePass: the installed-mod lookup itself failed (a genuine
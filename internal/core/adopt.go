@@ -0,0 +1,281 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
+
+	"github.com/google/uuid"
+)
+
+// adoptedFileID is the single synthetic file ID an adopted mod's cache entry
+// and installed-file row are keyed by, the same way directory sources
+// declare "main": there is no source file behind the content.
+const adoptedFileID = "adopted"
+
+// AdoptOptions configures AdoptFiles.
+type AdoptOptions struct {
+	Name    string // required: the new local mod's name
+	Version string // optional; "unknown" when empty
+}
+
+// AdoptResult reports what AdoptFiles brought under management.
+type AdoptResult struct {
+	Mod      *domain.Mod
+	Files    []string // mod_path-relative paths, sorted
+	Skipped  []string // files inside adopted directories left alone (symlinks or owned by another mod)
+	Checksum string
+	Warnings []string
+}
+
+// AdoptFiles turns untracked files in the game's mod_path into a managed
+// local mod: the files are copied into the cache, the originals are removed
+// and the cached copies redeployed through the profile's linker, and the mod
+// is recorded with a checksum and added to the profile like any install.
+//
+// paths are absolute or mod_path-relative and may name files or
+// directories; directories are walked. A named file that is a symlink or
+// already owned by a mod is an error. The same inside a named directory is
+// skipped and reported in AdoptResult.Skipped, since a shared directory
+// (Data/, plugins/) commonly mixes managed and hand-copied files.
+//
+// A failure before the mod is recorded puts the original files back.
+func (s *Service) AdoptFiles(ctx context.Context, game *domain.Game, profileName string, paths []string, opts AdoptOptions) (*AdoptResult, error) {
+	name := strings.TrimSpace(opts.Name)
+	if name == "" {
+		return nil, fmt.Errorf("a mod name is required")
+	}
+	if game.ModPath == "" {
+		return nil, fmt.Errorf("game has no mod_path configured")
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no paths to adopt")
+	}
+
+	result := &AdoptResult{}
+	rels, skipped, err := s.AdoptableFiles(game, profileName, paths)
+	if err != nil {
+		return nil, err
+	}
+	result.Skipped = skipped
+	if len(rels) == 0 {
+		return nil, fmt.Errorf("no untracked files to adopt")
+	}
+
+	version := opts.Version
+	if version == "" {
+		version = "unknown"
+	}
+	mod := &domain.Mod{
+		ID:       uuid.New().String(),
+		SourceID: domain.SourceLocal,
+		Name:     name,
+		Version:  version,
+		GameID:   game.ID,
+	}
+
+	gameCache := s.GetGameCache(game)
+	cachePath, stagePath, err := prepareUnseededStaging(gameCache, game, mod)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(stagePath) //nolint:errcheck
+	for _, rel := range rels {
+		if err := copyFileStreaming(filepath.Join(game.ModPath, rel), filepath.Join(stagePath, rel)); err != nil {
+			return nil, fmt.Errorf("copying %s to cache: %w", rel, err)
+		}
+	}
+	checksum, err := digestDirectoryMembers(stagePath, rels)
+	if err != nil {
+		return nil, fmt.Errorf("fingerprinting adopted files: %w", err)
+	}
+	if err := commitStagedCacheWithMarker(gameCache, cachePath, stagePath, adoptedFileID, rels); err != nil {
+		return nil, err
+	}
+
+	// From here on the cache holds a verified copy of every original, so
+	// undo restores them from there.
+	undo := func() {
+		restoreAdoptedFiles(gameCache, game, mod, rels)
+		_ = gameCache.Delete(game.ID, mod.SourceID, mod.ID, mod.Version) //nolint:errcheck // best-effort recovery on an already-erroring path
+	}
+
+	for _, rel := range rels {
+		if err := os.Remove(filepath.Join(game.ModPath, rel)); err != nil && !os.IsNotExist(err) {
+			undo()
+			return nil, fmt.Errorf("removing original %s: %w", rel, err)
+		}
+	}
+
+	linkMethod, err := s.GetEffectiveLinkMethod(game, profileName)
+	if err != nil {
+		undo()
+		return nil, err
+	}
+	installer := s.NewInstallerWithLinker(game, s.GetLinker(linkMethod))
+	if err := installer.Install(ctx, game, mod, profileName); err != nil {
+		undo()
+		return nil, fmt.Errorf("deploying adopted files: %w", err)
+	}
+
+	fileIDs := []string{adoptedFileID}
+	installedMod := &domain.InstalledMod{
+		Mod:          *mod,
+		ProfileName:  profileName,
+		UpdatePolicy: domain.UpdateNotify,
+		Enabled:      true,
+		Deployed:     true,
+		LinkMethod:   linkMethod,
+		FileIDs:      fileIDs,
+	}
+	if err := s.SaveInstalledMod(installedMod); err != nil {
+		_ = installer.Uninstall(ctx, game, mod, profileName) //nolint:errcheck // best-effort recovery on an already-erroring path
+		undo()
+		return nil, fmt.Errorf("saving mod: %w", err)
+	}
+
+	result.Mod = mod
+	result.Files = rels
+	result.Checksum = checksum
+
+	if err := s.SaveFileChecksum(mod.SourceID, mod.ID, game.ID, profileName, adoptedFileID, checksum); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to save checksum: %v", err))
+	}
+
+	pm := s.NewProfileManager()
+	if err := ensureProfileExists(pm, game.ID, profileName); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("could not create profile: %v", err))
+	}
+	modRef := domain.ModReference{SourceID: mod.SourceID, ModID: mod.ID, Version: mod.Version, FileIDs: fileIDs}
+	if err := pm.UpsertMod(game.ID, profileName, modRef); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("could not update profile: %v", err))
+	}
+
+	if syncWarnings, err := s.syncMergedPak(ctx, game, profileName); err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("syncing merged pak: %v", err))
+	} else {
+		result.Warnings = append(result.Warnings, syncWarnings...)
+	}
+
+	return result, nil
+}
+
+// AdoptableFiles resolves paths exactly as AdoptFiles does, without changing
+// anything: the sorted, de-duplicated mod_path-relative files it would
+// adopt, plus the directory members it would skip.
+func (s *Service) AdoptableFiles(game *domain.Game, profileName string, paths []string) (rels, skipped []string, err error) {
+	modPath, err := filepath.Abs(game.ModPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving mod_path: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	add := func(rel string, explicit bool) error {
+		if seen[rel] {
+			return nil
+		}
+		if strings.HasPrefix(filepath.Base(rel), cache.ReservedPrefix) {
+			if explicit {
+				return fmt.Errorf("%s: names starting with %q are reserved", rel, cache.ReservedPrefix)
+			}
+			return nil
+		}
+		sourceID, modID, owned, err := s.GetFileOwner(game.ID, profileName, filepath.ToSlash(rel))
+		if err != nil {
+			return fmt.Errorf("checking owner of %s: %w", rel, err)
+		}
+		if owned {
+			if explicit {
+				return fmt.Errorf("%s is already managed by %s:%s", rel, sourceID, modID)
+			}
+			skipped = append(skipped, rel)
+			return nil
+		}
+		seen[rel] = true
+		rels = append(rels, rel)
+		return nil
+	}
+
+	for _, p := range paths {
+		abs := p
+		if !filepath.IsAbs(abs) {
+			abs = filepath.Join(modPath, abs)
+		}
+		abs = filepath.Clean(abs)
+		if !pathWithinRoot(modPath, abs) || abs == modPath {
+			return nil, nil, fmt.Errorf("%s is not inside mod_path %s", p, modPath)
+		}
+		rel, err := filepath.Rel(modPath, abs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("resolving %s: %w", p, err)
+		}
+
+		info, err := os.Lstat(abs)
+		if err != nil {
+			return nil, nil, err
+		}
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			// Usually a file some mod deployed; say which one when it is.
+			if sourceID, modID, owned, err := s.GetFileOwner(game.ID, profileName, filepath.ToSlash(rel)); err == nil && owned {
+				return nil, nil, fmt.Errorf("%s is already managed by %s:%s", rel, sourceID, modID)
+			}
+			return nil, nil, fmt.Errorf("%s is a symlink; only real files can be adopted", rel)
+		case info.Mode().IsRegular():
+			if err := add(rel, true); err != nil {
+				return nil, nil, err
+			}
+		case info.IsDir():
+			err := filepath.WalkDir(abs, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if d.IsDir() {
+					if path != abs && strings.HasPrefix(d.Name(), cache.ReservedPrefix) {
+						return fs.SkipDir
+					}
+					return nil
+				}
+				member, err := filepath.Rel(modPath, path)
+				if err != nil {
+					return err
+				}
+				if !d.Type().IsRegular() {
+					skipped = append(skipped, member)
+					return nil
+				}
+				return add(member, false)
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("walking %s: %w", rel, err)
+			}
+		default:
+			return nil, nil, fmt.Errorf("%s is not a regular file or directory", rel)
+		}
+	}
+
+	sort.Strings(rels)
+	sort.Strings(skipped)
+	return rels, skipped, nil
+}
+
+// restoreAdoptedFiles puts adopted originals back from the cache, replacing
+// whatever the failed redeploy left at each path. Best-effort: it runs only
+// on paths that are already returning an error.
+func restoreAdoptedFiles(gameCache *cache.Cache, game *domain.Game, mod *domain.Mod, rels []string) {
+	for _, rel := range rels {
+		dst := filepath.Join(game.ModPath, rel)
+		if err := os.Remove(dst); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		_ = copyFileStreaming(gameCache.GetFilePath(game.ID, mod.SourceID, mod.ID, mod.Version, rel), dst) //nolint:errcheck // best-effort
+	}
+}
@@ -0,0 +1,101 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeModPathFile(t *testing.T, game *domain.Game, rel, content string) {
+	t.Helper()
+	path := filepath.Join(game.ModPath, rel)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestAdoptFiles_CachesRecordsAndRedeploys(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir()}
+	writeModPathFile(t, game, "RaceMenu/racemenu.esp", "plugin")
+	writeModPathFile(t, game, "RaceMenu/textures/skin.dds", "texture")
+
+	result, err := svc.AdoptFiles(context.Background(), game, "default", []string{"RaceMenu"}, core.AdoptOptions{Name: "RaceMenu", Version: "0.4"})
+	require.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+	assert.Equal(t, []string{"RaceMenu/racemenu.esp", "RaceMenu/textures/skin.dds"}, result.Files)
+	assert.NotEmpty(t, result.Checksum)
+
+	mod := result.Mod
+	assert.Equal(t, domain.SourceLocal, mod.SourceID)
+	installed, err := svc.GetInstalledMod(mod.SourceID, mod.ID, game.ID, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "RaceMenu", installed.Name)
+	assert.Equal(t, "0.4", installed.Version)
+	assert.True(t, installed.Deployed)
+
+	// The game directory now holds the linker's deployment of the cached copy.
+	deployed := filepath.Join(game.ModPath, "RaceMenu", "racemenu.esp")
+	info, err := os.Lstat(deployed)
+	require.NoError(t, err)
+	assert.NotZero(t, info.Mode()&os.ModeSymlink, "the default symlink method redeploys the file as a link")
+	target, err := os.Readlink(deployed)
+	require.NoError(t, err)
+	assert.Equal(t, svc.GetGameCache(game).GetFilePath(game.ID, mod.SourceID, mod.ID, mod.Version, "RaceMenu/racemenu.esp"), target)
+
+	_, owner, found, err := svc.GetFileOwner(game.ID, "default", "RaceMenu/textures/skin.dds")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, mod.ID, owner)
+
+	profile, err := svc.NewProfileManager().Get(game.ID, "default")
+	require.NoError(t, err)
+	require.Len(t, profile.Mods, 1)
+	assert.Equal(t, mod.ID, profile.Mods[0].ModID)
+
+	res, err := svc.Verify(context.Background(), game, "default", core.VerifyOptions{Tier: core.VerifyLocal}, nil)
+	require.NoError(t, err)
+	assert.Zero(t, res.Issues)
+	assert.Zero(t, res.Warnings)
+}
+
+func TestAdoptFiles_OwnedFiles(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir()}
+	seedUpdatableMod(t, svc, game, "src", "mod1", "Mod One", "1.0", []string{"f1"},
+		map[string][]byte{"Data/mod1.esp": []byte("managed")})
+	writeModPathFile(t, game, "Data/handmade.esp", "hand-copied")
+
+	_, err := svc.AdoptFiles(context.Background(), game, "default", []string{"Data/mod1.esp"}, core.AdoptOptions{Name: "Mine"})
+	assert.ErrorContains(t, err, "already managed by src:mod1")
+
+	// Inside a named directory, the managed file is skipped rather than fatal.
+	result, err := svc.AdoptFiles(context.Background(), game, "default", []string{"Data"}, core.AdoptOptions{Name: "Mine"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Data/handmade.esp"}, result.Files)
+	assert.Equal(t, []string{"Data/mod1.esp"}, result.Skipped)
+
+	_, owner, _, err := svc.GetFileOwner(game.ID, "default", "Data/mod1.esp")
+	require.NoError(t, err)
+	assert.Equal(t, "mod1", owner)
+}
+
+func TestAdoptFiles_RejectsPathsOutsideModPath(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir()}
+	outside := filepath.Join(t.TempDir(), "stray.esp")
+	require.NoError(t, os.WriteFile(outside, []byte("x"), 0644))
+
+	for _, p := range []string{outside, "../stray.esp", "."} {
+		_, err := svc.AdoptFiles(context.Background(), game, "default", []string{p}, core.AdoptOptions{Name: "Stray"})
+		assert.ErrorContains(t, err, "is not inside mod_path", p)
+	}
+	_, err := svc.AdoptFiles(context.Background(), game, "default", []string{outside}, core.AdoptOptions{})
+	assert.ErrorContains(t, err, "a mod name is required")
+}
@@ -0,0 +1,312 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+)
+
+// CacheEntry is one cached mod version and what still needs it.
+type CacheEntry struct {
+	cache.Entry
+	// SourceID and ModID identify the mod the entry belongs to. They are
+	// empty for an entry nothing references whose directory name does not
+	// start with a registered source ID.
+	SourceID string
+	ModID    string
+	// References describes everything keeping the entry, e.g. "profile
+	// default", "locked in survival", "previous version in default",
+	// "snapshot 20261018T153045Z". An entry with no references is what
+	// cache gc removes.
+	References []string
+}
+
+// Referenced reports whether anything still needs the entry.
+func (e CacheEntry) Referenced() bool {
+	return len(e.References) > 0
+}
+
+// CacheGCOptions selects which unreferenced cache entries GarbageCollectCache
+// removes. Referenced entries are never removed.
+type CacheGCOptions struct {
+	// KeepPrevious keeps the N most recently cached unreferenced versions of
+	// each mod, as extra rollback targets beyond the recorded previous
+	// version.
+	KeepPrevious int
+	// OlderThan, when positive, only removes entries cached at least that
+	// long ago.
+	OlderThan time.Duration
+	// DryRun reports what would be removed without deleting anything.
+	DryRun bool
+}
+
+// CacheGCResult reports a garbage collection or quota pass.
+type CacheGCResult struct {
+	Removed []CacheEntry // entries deleted (or, on a dry run, that would be)
+	Freed   int64        // bytes Removed held
+	Size    int64        // cache size afterwards
+}
+
+// ListCacheEntries lists every mod version in game's cache with the profile
+// refs, installed records, locks and snapshots that reference it. It fails
+// if any profile or snapshot cannot be read, since its references would be
+// unknown.
+func (s *Service) ListCacheEntries(game *domain.Game) ([]CacheEntry, error) {
+	raw, err := s.GetGameCache(game).Entries(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	refs, owners, err := s.cacheReferences(game)
+	if err != nil {
+		return nil, err
+	}
+
+	var sourceIDs []string
+	for _, src := range s.ListSources() {
+		sourceIDs = append(sourceIDs, src.ID())
+	}
+	sourceIDs = append(sourceIDs, domain.SourceMerged)
+
+	entries := make([]CacheEntry, 0, len(raw))
+	for _, e := range raw {
+		entry := CacheEntry{Entry: e, References: refs[cacheRefKey(e.Key, e.Version)]}
+		if owner, ok := owners[e.Key]; ok {
+			entry.SourceID, entry.ModID = owner[0], owner[1]
+		} else {
+			entry.SourceID, entry.ModID = splitCacheKey(e.Key, sourceIDs)
+		}
+		if entry.SourceID == domain.SourceMerged {
+			// Rebuilt from the deployed mods on every sync, but removing it
+			// would leave the game's merged pak without its cached source.
+			entry.References = append(entry.References, "merged pak")
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// GarbageCollectCache removes the cached versions of game's mods that no
+// profile, installed record, lock or snapshot references, subject to opts.
+func (s *Service) GarbageCollectCache(ctx context.Context, game *domain.Game, opts CacheGCOptions) (*CacheGCResult, error) {
+	entries, err := s.ListCacheEntries(game)
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first, so KeepPrevious keeps the most recent versions.
+	candidates := unreferencedEntries(entries)
+	slices.SortStableFunc(candidates, func(a, b CacheEntry) int { return b.ModTime.Compare(a.ModTime) })
+	kept := make(map[string]int)
+	var doomed []CacheEntry
+	for _, e := range candidates {
+		if kept[e.Key] < opts.KeepPrevious {
+			kept[e.Key]++
+			continue
+		}
+		if opts.OlderThan > 0 && time.Since(e.ModTime) < opts.OlderThan {
+			continue
+		}
+		doomed = append(doomed, e)
+	}
+	return s.removeCacheEntries(ctx, game, entries, doomed, -1, opts.DryRun)
+}
+
+// EnforceCacheQuota removes game's unreferenced cache entries, oldest first,
+// until the cache fits within game.CacheQuota. It returns a nil result when
+// the game has no quota or the cache is already within it. A cache that is
+// still over quota afterwards holds only referenced versions; that is
+// reported through the result's Size, not as an error.
+func (s *Service) EnforceCacheQuota(ctx context.Context, game *domain.Game) (*CacheGCResult, error) {
+	if game.CacheQuota <= 0 {
+		return nil, nil
+	}
+	entries, err := s.ListCacheEntries(game)
+	if err != nil {
+		return nil, err
+	}
+	if totalCacheSize(entries) <= game.CacheQuota {
+		return nil, nil
+	}
+
+	candidates := unreferencedEntries(entries)
+	slices.SortStableFunc(candidates, func(a, b CacheEntry) int { return a.ModTime.Compare(b.ModTime) })
+	return s.removeCacheEntries(ctx, game, entries, candidates, game.CacheQuota, false)
+}
+
+// enforceCacheQuotaAfter runs EnforceCacheQuota at the end of an install or
+// update that has already committed. Removals are announced with a
+// CacheQuotaEnforced event; a failure is only a warning, returned for the
+// caller to record under its own warning phase.
+func (s *Service) enforceCacheQuotaAfter(ctx context.Context, game *domain.Game, emit func(DeployProgress)) (*CacheGCResult, string) {
+	result, err := s.EnforceCacheQuota(ctx, game)
+	if err != nil {
+		return result, fmt.Sprintf("enforcing cache quota: %v", err)
+	}
+	if result != nil && len(result.Removed) > 0 {
+		emit(DeployProgress{Phase: CacheQuotaEnforced, Total: len(result.Removed), TotalBytes: result.Freed})
+	}
+	return result, ""
+}
+
+// removeCacheEntries deletes doomed in order, stopping once the cache fits
+// within quota (a negative quota deletes them all).
+func (s *Service) removeCacheEntries(ctx context.Context, game *domain.Game, all, doomed []CacheEntry, quota int64, dryRun bool) (*CacheGCResult, error) {
+	gameCache := s.GetGameCache(game)
+	result := &CacheGCResult{Size: totalCacheSize(all)}
+	for _, e := range doomed {
+		if quota >= 0 && result.Size <= quota {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if !dryRun {
+			if err := gameCache.DeleteEntry(e.Entry); err != nil {
+				return result, fmt.Errorf("removing %s %s: %w", e.Key, e.Version, err)
+			}
+		}
+		result.Removed = append(result.Removed, e)
+		result.Freed += e.Size
+		result.Size -= e.Size
+	}
+	if !dryRun && len(result.Removed) > 0 {
+		// A deduplicated entry only held links; its content goes with the
+		// last version using it.
+		if _, err := gameCache.PruneBlobs(); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// DedupCache converts game's existing cache entries to content-addressed
+// storage in place (see cache.DedupDir), then drops blobs nothing links to.
+// It works whether or not cache_dedup is on; with it off, entries cached
+// afterwards are plain copies again.
+func (s *Service) DedupCache(ctx context.Context, game *domain.Game) (cache.DedupStats, error) {
+	var stats cache.DedupStats
+	gameCache := s.GetGameCache(game)
+	entries, err := gameCache.Entries(game.ID)
+	if err != nil {
+		return stats, err
+	}
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+		entryStats, err := gameCache.DedupDir(e.Path)
+		stats.Add(entryStats)
+		if err != nil {
+			return stats, err
+		}
+	}
+	if _, err := gameCache.PruneBlobs(); err != nil {
+		return stats, err
+	}
+	return stats, nil
+}
+
+// cacheReferences collects what references each cached version of game's
+// mods, keyed by cacheRefKey, along with the source and mod ID behind each
+// cache directory name it saw.
+func (s *Service) cacheReferences(game *domain.Game) (map[string][]string, map[string][2]string, error) {
+	refs := make(map[string][]string)
+	owners := make(map[string][2]string)
+	add := func(sourceID, modID, version, why string) {
+		if version == "" {
+			return
+		}
+		key := sourceID + "-" + modID
+		owners[key] = [2]string{sourceID, modID}
+		refKey := cacheRefKey(key, version)
+		if !slices.Contains(refs[refKey], why) {
+			refs[refKey] = append(refs[refKey], why)
+		}
+	}
+
+	// Profiles are loaded strictly: a profile that cannot be read may
+	// reference anything, so nothing is safe to collect.
+	names, err := config.ListProfiles(s.configDir, game.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, name := range names {
+		profile, err := config.LoadProfile(s.configDir, game.ID, name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("loading profile %s: %w", name, err)
+		}
+		for _, ref := range profile.Mods {
+			why := "profile " + name
+			if ref.Locked {
+				why = "locked in " + name
+			}
+			add(ref.SourceID, ref.ModID, ref.Version, why)
+		}
+	}
+
+	installed, err := s.db.GetInstalledVersions(game.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, v := range installed {
+		add(v.SourceID, v.ModID, v.Version, "installed in "+v.ProfileName)
+		add(v.SourceID, v.ModID, v.PreviousVersion, "previous version in "+v.ProfileName)
+	}
+
+	// Snapshots reference the cache instead of copying mod files, so they
+	// pin their versions too - and, like profiles, an unreadable one blocks
+	// collection until it is deleted.
+	snaps, err := s.ListSnapshots(game)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, snap := range snaps {
+		for _, v := range snap.Mods {
+			add(v.SourceID, v.ModID, v.Version, "snapshot "+snap.ID)
+		}
+	}
+	return refs, owners, nil
+}
+
+func cacheRefKey(key, version string) string {
+	return key + "/" + version
+}
+
+// splitCacheKey recovers the source and mod ID from a "<source>-<modID>"
+// cache directory name by the longest matching source ID, since both halves
+// may themselves contain dashes.
+func splitCacheKey(key string, sourceIDs []string) (sourceID, modID string) {
+	for _, id := range sourceIDs {
+		if strings.HasPrefix(key, id+"-") && len(id) > len(sourceID) {
+			sourceID = id
+		}
+	}
+	if sourceID == "" {
+		return "", ""
+	}
+	return sourceID, strings.TrimPrefix(key, sourceID+"-")
+}
+
+func unreferencedEntries(entries []CacheEntry) []CacheEntry {
+	var out []CacheEntry
+	for _, e := range entries {
+		if !e.Referenced() {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func totalCacheSize(entries []CacheEntry) int64 {
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+	return total
+}
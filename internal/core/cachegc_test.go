@@ -0,0 +1,173 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCacheGC caches five versions of two mods, each a year older than the
+// next, and references three of them: a locked profile ref, an installed
+// version and that install's previous version. The other two are
+// unreferenced.
+func setupCacheGC(t *testing.T) (*core.Service, *domain.Game) {
+	t.Helper()
+	configDir := t.TempDir()
+	svc, err := core.NewService(core.ServiceConfig{ConfigDir: configDir, DataDir: t.TempDir(), CacheDir: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, svc.Close()) })
+
+	game := &domain.Game{ID: "testgame", Name: "Test Game", ModPath: t.TempDir()}
+	require.NoError(t, svc.AddGame(game))
+
+	gameCache := svc.GetGameCache(game)
+	now := time.Now()
+	for i, v := range []struct{ mod, version string }{
+		{"a", "1.0"}, {"a", "2.0"}, {"a", "3.0"}, {"a", "4.0"}, {"b", "1.0"},
+	} {
+		require.NoError(t, gameCache.Store(game.ID, "nexusmods", v.mod, v.version, "mod.pak", make([]byte, 100)))
+		age := now.AddDate(-(5 - i), 0, 0)
+		require.NoError(t, os.Chtimes(gameCache.ModPath(game.ID, "nexusmods", v.mod, v.version), age, age))
+	}
+
+	require.NoError(t, config.SaveProfile(configDir, &domain.Profile{
+		Name: "default", GameID: game.ID,
+		Mods: []domain.ModReference{{SourceID: "nexusmods", ModID: "b", Version: "1.0", Locked: true}},
+	}))
+	require.NoError(t, svc.SaveInstalledMod(&domain.InstalledMod{
+		Mod:         domain.Mod{ID: "a", SourceID: "nexusmods", Name: "A", Version: "3.0", GameID: game.ID},
+		ProfileName: "default",
+	}))
+	require.NoError(t, svc.UpdateModVersion("nexusmods", "a", game.ID, "default", "4.0"))
+	return svc, game
+}
+
+func versionsOf(entries []core.CacheEntry) []string {
+	var out []string
+	for _, e := range entries {
+		out = append(out, e.ModID+"@"+e.Version)
+	}
+	return out
+}
+
+func TestListCacheEntries_References(t *testing.T) {
+	svc, game := setupCacheGC(t)
+
+	entries, err := svc.ListCacheEntries(game)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+	refs := map[string][]string{}
+	for _, e := range entries {
+		assert.Equal(t, "nexusmods", e.SourceID)
+		refs[e.ModID+"@"+e.Version] = e.References
+	}
+	assert.Empty(t, refs["a@1.0"])
+	assert.Empty(t, refs["a@2.0"])
+	assert.Equal(t, []string{"previous version in default"}, refs["a@3.0"])
+	assert.Equal(t, []string{"installed in default"}, refs["a@4.0"])
+	assert.Equal(t, []string{"locked in default"}, refs["b@1.0"])
+}
+
+func TestGarbageCollectCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dry run removes nothing", func(t *testing.T) {
+		svc, game := setupCacheGC(t)
+		result, err := svc.GarbageCollectCache(ctx, game, core.CacheGCOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"a@1.0", "a@2.0"}, versionsOf(result.Removed))
+		entries, err := svc.ListCacheEntries(game)
+		require.NoError(t, err)
+		assert.Len(t, entries, 5)
+	})
+
+	t.Run("removes only unreferenced versions", func(t *testing.T) {
+		svc, game := setupCacheGC(t)
+		result, err := svc.GarbageCollectCache(ctx, game, core.CacheGCOptions{})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"a@1.0", "a@2.0"}, versionsOf(result.Removed))
+		assert.Positive(t, result.Freed)
+		entries, err := svc.ListCacheEntries(game)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"a@3.0", "a@4.0", "b@1.0"}, versionsOf(entries))
+	})
+
+	t.Run("keep previous keeps the newest unreferenced versions", func(t *testing.T) {
+		svc, game := setupCacheGC(t)
+		result, err := svc.GarbageCollectCache(ctx, game, core.CacheGCOptions{KeepPrevious: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a@1.0"}, versionsOf(result.Removed))
+	})
+
+	t.Run("older than spares recent versions", func(t *testing.T) {
+		svc, game := setupCacheGC(t)
+		result, err := svc.GarbageCollectCache(ctx, game, core.CacheGCOptions{OlderThan: 1640 * 24 * time.Hour})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a@1.0"}, versionsOf(result.Removed), "a@2.0 was cached four years ago, a@1.0 five")
+	})
+
+	t.Run("an unreadable profile aborts the collection", func(t *testing.T) {
+		svc, game := setupCacheGC(t)
+		require.NoError(t, os.WriteFile(filepath.Join(svc.ConfigDir(), "games", "testgame", "profiles", "broken.yaml"), []byte("mods: [\n"), 0644))
+		_, err := svc.GarbageCollectCache(ctx, game, core.CacheGCOptions{})
+		require.Error(t, err)
+		entries, err := svc.GetGameCache(game).Entries(game.ID)
+		require.NoError(t, err)
+		assert.Len(t, entries, 5)
+	})
+}
+
+func TestEnforceCacheQuota(t *testing.T) {
+	ctx := context.Background()
+	svc, game := setupCacheGC(t)
+
+	game.CacheQuota = 1 << 30
+	result, err := svc.EnforceCacheQuota(ctx, game)
+	require.NoError(t, err)
+	assert.Nil(t, result, "a cache within its quota is left alone")
+
+	entries, err := svc.ListCacheEntries(game)
+	require.NoError(t, err)
+	game.CacheQuota = 4 * entries[0].Size
+	result, err = svc.EnforceCacheQuota(ctx, game)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, []string{"a@1.0"}, versionsOf(result.Removed), "oldest first, and only until the cache fits")
+	assert.Equal(t, game.CacheQuota, result.Size)
+
+	game.CacheQuota = 1
+	result, err = svc.EnforceCacheQuota(ctx, game)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a@2.0"}, versionsOf(result.Removed), "referenced versions are kept even over quota")
+	assert.Greater(t, result.Size, game.CacheQuota)
+}
+
+func TestDedupCache(t *testing.T) {
+	ctx := context.Background()
+	svc, game := setupCacheGC(t)
+
+	// Every version in setupCacheGC holds the same 100 bytes.
+	stats, err := svc.DedupCache(ctx, game)
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.Files)
+	assert.Equal(t, 4, stats.Linked)
+	assert.Equal(t, int64(400), stats.Saved)
+
+	// Collecting the unreferenced versions keeps the blob the rest still use.
+	_, err = svc.GarbageCollectCache(ctx, game, core.CacheGCOptions{})
+	require.NoError(t, err)
+	entries, err := svc.ListCacheEntries(game)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	data, err := os.ReadFile(filepath.Join(entries[0].Path, "mod.pak"))
+	require.NoError(t, err)
+	assert.Len(t, data, 100)
+}
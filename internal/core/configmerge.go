@@ -0,0 +1,459 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+)
+
+// configLocalSuffix names the file an update leaves the user's edited copy
+// of a config file in when the three-way merge conflicts (or the new
+// version dropped the file altogether). These copies live under
+// configConflictsDir, never in the game directory, so nothing lmm does not
+// track is left among the mod's files.
+const configLocalSuffix = ".lmm-local"
+
+// maxMergeCells caps the LCS table mergeLines builds (base lines x side
+// lines). Config files are small; anything past this is reported as a
+// conflict rather than risking a multi-hundred-megabyte allocation.
+const maxMergeCells = 4_000_000
+
+// configExtensions are the deployed file types lmm treats as user-editable
+// configuration: edits to these survive updates via a three-way merge.
+var configExtensions = map[string]bool{".ini": true, ".json": true, ".toml": true, ".xml": true}
+
+func isConfigFile(relPath string) bool {
+	return configExtensions[strings.ToLower(filepath.Ext(relPath))]
+}
+
+// ConfigEdit is one deployed config file whose content in the game directory
+// no longer matches the mod's cached copy - the user edited it after deploy.
+// Path is relative to the game's mod directory, like deployed-file tracking.
+type ConfigEdit struct {
+	SourceID, ModID, ModName string
+	Path                     string
+}
+
+// ConfigMerge reports what ApplyUpdate did with one locally edited config
+// file. Conflicts counts the hunks both sides changed differently; when it
+// is non-zero (or the new version no longer ships the file) the new upstream
+// file is left deployed and the user's copy is saved to LocalCopy instead.
+type ConfigMerge struct {
+	Path      string
+	Conflicts int
+	Removed   bool   // the new version no longer ships this file
+	LocalCopy string // absolute path of the saved local copy, when one was written
+}
+
+// configSnapshot is a locally edited config file captured before an update
+// replaces it: base is the old cached (upstream) content, local what the
+// game directory held.
+type configSnapshot struct {
+	rel         string
+	base, local []byte
+	mode        os.FileMode
+}
+
+// localConfigEdit reports whether the deployed copy of relPath (a file of
+// mod's cache entry) differs from the cache. Only a file that is no longer
+// a link into the cache can diverge: with the symlink or hardlink method an
+// in-place edit writes through to the cache itself, so those are never
+// reported (unless an editor replaced the link with a fresh file, which
+// this does catch). A missing deployed file or cache file is not an edit.
+func localConfigEdit(gameCache *cache.Cache, game *domain.Game, mod *domain.Mod, relPath string) (*configSnapshot, error) {
+	dst := filepath.Join(game.ModPath, relPath)
+	info, err := os.Lstat(dst)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, nil
+	}
+	src := gameCache.GetFilePath(game.ID, mod.SourceID, mod.ID, mod.Version, relPath)
+	srcInfo, err := os.Stat(src)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if os.SameFile(info, srcInfo) {
+		return nil, nil
+	}
+	local, err := os.ReadFile(dst)
+	if err != nil {
+		return nil, err
+	}
+	base, err := os.ReadFile(src)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(local, base) {
+		return nil, nil
+	}
+	return &configSnapshot{rel: relPath, base: base, local: local, mode: info.Mode().Perm()}, nil
+}
+
+// snapshotConfigEdits captures every locally edited config file mod has
+// deployed, sorted by path.
+func (s *Service) snapshotConfigEdits(game *domain.Game, profileName string, mod *domain.Mod) ([]configSnapshot, error) {
+	paths, err := s.db.GetDeployedFilesForMod(game.ID, profileName, mod.SourceID, mod.ID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	gameCache := s.GetGameCache(game)
+	var snaps []configSnapshot
+	for _, rel := range paths {
+		if !isConfigFile(rel) {
+			continue
+		}
+		snap, err := localConfigEdit(gameCache, game, mod, rel)
+		if err != nil {
+			return nil, fmt.Errorf("checking %s for local edits: %w", rel, err)
+		}
+		if snap != nil {
+			snaps = append(snaps, *snap)
+		}
+	}
+	return snaps, nil
+}
+
+// writeDeployedConfig replaces the deployed file at dst with a regular file
+// holding data. The old entry is removed first so that a link into the
+// cache is replaced rather than written through.
+func writeDeployedConfig(dst string, data []byte, mode os.FileMode) error {
+	if err := os.Remove(dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, mode)
+}
+
+// restoreConfigSnapshots puts the user's edited copies back after an
+// update that replaced the files failed to commit and was compensated.
+// Best-effort, like the compensation it follows.
+func restoreConfigSnapshots(game *domain.Game, snaps []configSnapshot) {
+	for _, snap := range snaps {
+		_ = writeDeployedConfig(filepath.Join(game.ModPath, snap.rel), snap.local, snap.mode) //nolint:errcheck // best-effort recovery on an already-erroring path
+	}
+}
+
+// mergeConfigSnapshots carries each snapshot's local edits over to newMod's
+// freshly deployed copy with a three-way merge (old upstream, new upstream,
+// local). A clean merge replaces the deployed file; a conflicting one, or a
+// file the new version dropped, leaves upstream in place and saves the
+// user's copy under localDir (mirroring the file's path relative to the mod
+// directory) with configLocalSuffix.
+func mergeConfigSnapshots(gameCache *cache.Cache, game *domain.Game, newMod *domain.Mod, snaps []configSnapshot, localDir string) ([]ConfigMerge, error) {
+	var merges []ConfigMerge
+	for _, snap := range snaps {
+		dst := filepath.Join(game.ModPath, snap.rel)
+		theirs, err := os.ReadFile(gameCache.GetFilePath(game.ID, newMod.SourceID, newMod.ID, newMod.Version, snap.rel))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return merges, fmt.Errorf("reading updated %s: %w", snap.rel, err)
+		}
+		m := ConfigMerge{Path: snap.rel}
+		var merged []byte
+		if err != nil {
+			m.Removed = true
+		} else {
+			merged, m.Conflicts = mergeLines(snap.base, snap.local, theirs)
+		}
+		if m.Removed || m.Conflicts > 0 {
+			m.LocalCopy = filepath.Join(localDir, snap.rel) + configLocalSuffix
+			if err := os.MkdirAll(filepath.Dir(m.LocalCopy), 0755); err != nil {
+				return merges, fmt.Errorf("saving local copy of %s: %w", snap.rel, err)
+			}
+			if err := os.WriteFile(m.LocalCopy, snap.local, snap.mode); err != nil {
+				return merges, fmt.Errorf("saving local copy of %s: %w", snap.rel, err)
+			}
+		} else if !bytes.Equal(merged, theirs) {
+			if err := writeDeployedConfig(dst, merged, snap.mode); err != nil {
+				return merges, fmt.Errorf("writing merged %s: %w", snap.rel, err)
+			}
+		}
+		merges = append(merges, m)
+	}
+	return merges, nil
+}
+
+// configConflictsDir returns the directory holding the edited config copies
+// updates in profileName could not merge.
+func (s *Service) configConflictsDir(gameID, profileName string) string {
+	return filepath.Join(s.dataDir, "config-conflicts", gameID, profileName)
+}
+
+// describeConfigMerge renders m as the one-line message ApplyUpdate reports.
+func describeConfigMerge(m ConfigMerge) string {
+	switch {
+	case m.Removed:
+		return fmt.Sprintf("%s was removed upstream; your edited copy was saved to %s", m.Path, m.LocalCopy)
+	case m.Conflicts > 0:
+		return fmt.Sprintf("%s: %d conflicting change(s) with upstream; kept the new upstream file and saved your copy to %s", m.Path, m.Conflicts, m.LocalCopy)
+	default:
+		return fmt.Sprintf("Merged local edits into updated %s", m.Path)
+	}
+}
+
+// mergeLines is a line-based three-way merge (diff3): hunks only one side
+// changed relative to base take that side, hunks both sides changed
+// identically are taken once, and hunks both changed differently are a
+// conflict - resolved to theirs in the returned content, and counted. Line
+// endings are compared and emitted verbatim.
+func mergeLines(base, local, theirs []byte) ([]byte, int) {
+	if bytes.Equal(local, theirs) || bytes.Equal(base, theirs) {
+		return local, 0
+	}
+	if bytes.Equal(base, local) {
+		return theirs, 0
+	}
+	o, a, b := splitKeepEOL(base), splitKeepEOL(local), splitKeepEOL(theirs)
+	if len(o)*len(a) > maxMergeCells || len(o)*len(b) > maxMergeCells {
+		return theirs, 1
+	}
+	ma, mb := lcsMatches(o, a), lcsMatches(o, b)
+
+	var out []string
+	conflicts := 0
+	emit := func(ol, al, bl []string) {
+		switch {
+		case equalLines(al, ol):
+			out = append(out, bl...)
+		case equalLines(bl, ol), equalLines(al, bl):
+			out = append(out, al...)
+		default:
+			conflicts++
+			out = append(out, bl...)
+		}
+	}
+	io, ia, ib := 0, 0, 0
+	for k := range o {
+		if ma[k] < 0 || mb[k] < 0 {
+			continue
+		}
+		// o[k] is stable: matched on both sides. Everything between the
+		// previous stable line and this one is a hunk.
+		if k > io || ma[k] > ia || mb[k] > ib {
+			emit(o[io:k], a[ia:ma[k]], b[ib:mb[k]])
+		}
+		out = append(out, o[k])
+		io, ia, ib = k+1, ma[k]+1, mb[k]+1
+	}
+	if io < len(o) || ia < len(a) || ib < len(b) {
+		emit(o[io:], a[ia:], b[ib:])
+	}
+	return []byte(strings.Join(out, "")), conflicts
+}
+
+// splitKeepEOL splits data into lines, each keeping its own terminator.
+func splitKeepEOL(data []byte) []string {
+	return strings.SplitAfter(string(data), "\n")
+}
+
+func equalLines(x, y []string) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lcsMatches returns, for each line of o, the index of the line of x it is
+// paired with in a longest common subsequence, or -1.
+func lcsMatches(o, x []string) []int {
+	n, m := len(o), len(x)
+	// dp[i][j] = LCS length of o[i:] and x[j:], flattened.
+	dp := make([]int32, (n+1)*(m+1))
+	at := func(i, j int) int { return i*(m+1) + j }
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if o[i] == x[j] {
+				dp[at(i, j)] = dp[at(i+1, j+1)] + 1
+			} else {
+				dp[at(i, j)] = max(dp[at(i+1, j)], dp[at(i, j+1)])
+			}
+		}
+	}
+	match := make([]int, n)
+	for i := range match {
+		match[i] = -1
+	}
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case o[i] == x[j]:
+			match[i] = j
+			i++
+			j++
+		case dp[at(i+1, j)] >= dp[at(i, j+1)]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
+
+// ModifiedConfigs lists the config files (.ini, .json, .toml, .xml) that
+// profileName's installed mods deployed and the user has since edited in
+// place, sorted by path. See localConfigEdit for which link methods can
+// surface an edit.
+func (s *Service) ModifiedConfigs(game *domain.Game, profileName string) ([]ConfigEdit, error) {
+	edits, _, err := s.modifiedConfigSnapshots(game, profileName)
+	return edits, err
+}
+
+// modifiedConfigSnapshots is ModifiedConfigs plus each edit's snapshot, in
+// the same order.
+func (s *Service) modifiedConfigSnapshots(game *domain.Game, profileName string) ([]ConfigEdit, []configSnapshot, error) {
+	mods, err := s.GetInstalledMods(game.ID, profileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var edits []ConfigEdit
+	var all []configSnapshot
+	for i := range mods {
+		snaps, err := s.snapshotConfigEdits(game, profileName, &mods[i].Mod)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, snap := range snaps {
+			edits = append(edits, ConfigEdit{SourceID: mods[i].SourceID, ModID: mods[i].ID, ModName: mods[i].Name, Path: snap.rel})
+			all = append(all, snap)
+		}
+	}
+	order := make([]int, len(edits))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return edits[order[i]].Path < edits[order[j]].Path })
+	sortedEdits := make([]ConfigEdit, len(order))
+	sortedSnaps := make([]configSnapshot, len(order))
+	for i, idx := range order {
+		sortedEdits[i], sortedSnaps[i] = edits[idx], all[idx]
+	}
+	return sortedEdits, sortedSnaps, nil
+}
+
+// SavedConfig reports how SaveConfigEdits stored one edited file in the
+// profile: as IniPatches (Keys changed keys) or as a whole-file Override.
+type SavedConfig struct {
+	ConfigEdit
+	ProfilePath string // path the profile records it under, relative to the game install dir
+	Keys        int    // number of ini_patches keys written; 0 for an override
+}
+
+// SaveConfigEdits records locally edited config files in profileName so the
+// edits are re-applied on every deploy and profile switch. An .ini file is
+// stored as ini_patches - only the keys that differ from the mod's copy -
+// so it keeps merging cleanly with future upstream changes; other formats
+// are stored whole as overrides. paths (mod-directory-relative, as
+// ModifiedConfigs reports them) narrows the set; empty saves every edit.
+// Profile entries are keyed relative to the install directory, so a mod
+// directory outside it cannot be saved.
+func (s *Service) SaveConfigEdits(game *domain.Game, profileName string, paths []string) ([]SavedConfig, error) {
+	edits, snaps, err := s.modifiedConfigSnapshots(game, profileName)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) > 0 {
+		byPath := make(map[string]int, len(edits))
+		for i, e := range edits {
+			byPath[filepath.ToSlash(e.Path)] = i
+		}
+		var pickedEdits []ConfigEdit
+		var pickedSnaps []configSnapshot
+		for _, p := range paths {
+			i, ok := byPath[filepath.ToSlash(filepath.Clean(p))]
+			if !ok {
+				return nil, fmt.Errorf("%s is not a locally edited config file", p)
+			}
+			pickedEdits = append(pickedEdits, edits[i])
+			pickedSnaps = append(pickedSnaps, snaps[i])
+		}
+		edits, snaps = pickedEdits, pickedSnaps
+	}
+	if len(edits) == 0 {
+		return nil, nil
+	}
+
+	profile, err := config.LoadProfile(s.configDir, game.ID, profileName)
+	if err != nil {
+		return nil, err
+	}
+	base, err := installBase(game)
+	if err != nil {
+		return nil, err
+	}
+	modBase, err := filepath.Abs(game.ModPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving mod path: %w", err)
+	}
+
+	var saved []SavedConfig
+	for i, e := range edits {
+		snap := snaps[i]
+		rel, err := filepath.Rel(base, filepath.Join(modBase, e.Path))
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return nil, fmt.Errorf("%s: mod directory is outside the game install directory", e.Path)
+		}
+		rel = filepath.ToSlash(rel)
+		sc := SavedConfig{ConfigEdit: e, ProfilePath: rel}
+		if strings.EqualFold(filepath.Ext(e.Path), ".ini") {
+			patches := diffINI(snap.base, snap.local)
+			if profile.IniPatches == nil {
+				profile.IniPatches = domain.IniPatches{}
+			}
+			created := profile.IniPatches[rel] == nil
+			if created {
+				profile.IniPatches[rel] = map[string]map[string]*string{}
+			}
+			for section, keys := range patches {
+				if profile.IniPatches[rel][section] == nil {
+					profile.IniPatches[rel][section] = map[string]*string{}
+				}
+				for key, value := range keys {
+					profile.IniPatches[rel][section][key] = value
+					sc.Keys++
+				}
+			}
+			if sc.Keys > 0 {
+				saved = append(saved, sc)
+				continue
+			}
+			// Only comments or formatting changed: no key-level patch can
+			// express that, so fall through and keep the whole file. Patches
+			// the profile already had for it stay; they apply on top of the
+			// override.
+			if created {
+				delete(profile.IniPatches, rel)
+			}
+		}
+		if profile.Overrides == nil {
+			profile.Overrides = map[string][]byte{}
+		}
+		profile.Overrides[rel] = snap.local
+		saved = append(saved, sc)
+	}
+	if err := config.SaveProfile(s.configDir, profile); err != nil {
+		return nil, fmt.Errorf("saving profile: %w", err)
+	}
+	return saved, nil
+}
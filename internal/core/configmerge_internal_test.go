@@ -0,0 +1,44 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeLines(t *testing.T) {
+	base := "a=1\nb=2\nc=3\nd=4\n"
+
+	tests := []struct {
+		name          string
+		local, theirs string
+		want          string
+		conflicts     int
+	}{
+		{"local only", "a=1\nb=20\nc=3\nd=4\n", base, "a=1\nb=20\nc=3\nd=4\n", 0},
+		{"upstream only", base, "a=1\nb=2\nc=3\nd=4\ne=5\n", "a=1\nb=2\nc=3\nd=4\ne=5\n", 0},
+		{"disjoint edits", "a=1\nb=20\nc=3\nd=4\n", "a=1\nb=2\nc=3\nd=40\n", "a=1\nb=20\nc=3\nd=40\n", 0},
+		{"same edit both sides", "a=1\nb=9\nc=3\nd=4\n", "a=1\nb=9\nc=3\nd=4\ne=5\n", "a=1\nb=9\nc=3\nd=4\ne=5\n", 0},
+		{"insert and delete", "a=1\nx=0\nb=2\nc=3\nd=4\n", "a=1\nb=2\nd=4\n", "a=1\nx=0\nb=2\nd=4\n", 0},
+		{"conflict takes upstream", "a=1\nb=20\nc=3\nd=4\n", "a=1\nb=200\nc=3\nd=4\n", "a=1\nb=200\nc=3\nd=4\n", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflicts := mergeLines([]byte(base), []byte(tt.local), []byte(tt.theirs))
+			assert.Equal(t, tt.want, string(got))
+			assert.Equal(t, tt.conflicts, conflicts)
+		})
+	}
+}
+
+func TestDiffINI(t *testing.T) {
+	base := []byte("[Display]\r\niSize W=1920\r\nbFull=1\r\n[Audio]\r\nfVolume=1.0\r\n")
+	local := []byte("[Display]\r\niSize W=2560\r\n; comment\r\n[audio]\r\nfVolume=1.0\r\nbMute=1\r\n")
+
+	v := func(s string) *string { return &s }
+	assert.Equal(t, map[string]map[string]*string{
+		"Display": {"iSize W": v("2560"), "bFull": nil},
+		"audio":   {"bMute": v("1")},
+	}, diffINI(base, local))
+	assert.Empty(t, diffINI(base, base))
+}
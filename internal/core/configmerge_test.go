@@ -0,0 +1,152 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupConfigUpdate seeds a copy-deployed mod shipping settings.ini and a
+// source offering version 2.0 with newIni, then applies local to the
+// deployed copy. It returns the ready-to-apply update.
+func setupConfigUpdate(t *testing.T, svc *core.Service, game *domain.Game, local, newIni string) domain.Update {
+	t.Helper()
+	old := seedUpdatableMod(t, svc, game, "src", "mod1", "Mod One", "1.0", []string{"old-1"},
+		map[string][]byte{"settings.ini": []byte("[General]\nfSpeed=1.0\nbHud=1\n"), "mod1.esp": []byte("plugin")})
+	require.NoError(t, os.WriteFile(filepath.Join(game.ModPath, "settings.ini"), []byte(local), 0644))
+
+	mock := &multiFileDownloadSource{
+		mockSourceWithDownloads: newMockSourceWithDownloads("src"),
+		files:                   []domain.DownloadableFile{{ID: "new-1", Name: "Settings", FileName: "settings.ini", IsPrimary: true}},
+	}
+	t.Cleanup(mock.Close)
+	svc.RegisterSource(mock)
+	mock.AddMod("g1", &domain.Mod{ID: "mod1", SourceID: "src", Name: "Mod One", Version: "2.0", GameID: "g1"})
+	mock.AddDownload("new-1", []byte(newIni))
+	return domain.Update{InstalledMod: *old, NewVersion: "2.0"}
+}
+
+func TestApplyUpdate_MergesLocallyEditedConfig(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkCopy, LinkMethodExplicit: true}
+	upd := setupConfigUpdate(t, svc, game,
+		"[General]\nfSpeed=2.5\nbHud=1\n",
+		"[General]\nfSpeed=1.0\nbHud=1\nbNewOption=0\n")
+
+	var merged []string
+	result, err := svc.ApplyUpdate(context.Background(), game, "default", upd, core.UpdateOptions{}, func(p core.DeployProgress) {
+		if p.Phase == core.UpdateConfigMerged {
+			merged = append(merged, p.Detail)
+		}
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+	require.Len(t, result.ConfigMerges, 1)
+	assert.Equal(t, core.ConfigMerge{Path: "settings.ini"}, result.ConfigMerges[0])
+	assert.Equal(t, []string{"Merged local edits into updated settings.ini"}, merged)
+
+	got, err := os.ReadFile(filepath.Join(game.ModPath, "settings.ini"))
+	require.NoError(t, err)
+	assert.Equal(t, "[General]\nfSpeed=2.5\nbHud=1\nbNewOption=0\n", string(got))
+}
+
+func TestApplyUpdate_ConfigConflictKeepsUpstreamAndSavesLocalCopy(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkCopy, LinkMethodExplicit: true}
+	upd := setupConfigUpdate(t, svc, game,
+		"[General]\nfSpeed=2.5\nbHud=1\n",
+		"[General]\nfSpeed=1.5\nbHud=1\n")
+
+	result, err := svc.ApplyUpdate(context.Background(), game, "default", upd, core.UpdateOptions{}, nil)
+	require.NoError(t, err)
+	require.Len(t, result.ConfigMerges, 1)
+	assert.Equal(t, 1, result.ConfigMerges[0].Conflicts)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "settings.ini: 1 conflicting change(s)")
+
+	got, err := os.ReadFile(filepath.Join(game.ModPath, "settings.ini"))
+	require.NoError(t, err)
+	assert.Equal(t, "[General]\nfSpeed=1.5\nbHud=1\n", string(got))
+	localCopy := result.ConfigMerges[0].LocalCopy
+	assert.False(t, strings.HasPrefix(localCopy, game.ModPath), "the local copy is kept out of the game directory")
+	local, err := os.ReadFile(localCopy)
+	require.NoError(t, err)
+	assert.Equal(t, "[General]\nfSpeed=2.5\nbHud=1\n", string(local))
+}
+
+func TestSaveConfigEdits_StoresIniKeysAndOtherFormatsWhole(t *testing.T) {
+	svc := newFlowsTestService(t)
+	install := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", InstallPath: install, ModPath: filepath.Join(install, "Data"), LinkMethod: domain.LinkCopy, LinkMethodExplicit: true}
+	seedUpdatableMod(t, svc, game, "src", "mod1", "Mod One", "1.0", []string{"f1"}, map[string][]byte{
+		"settings.ini": []byte("[General]\nfSpeed=1.0\nbHud=1\n"),
+		"ui.json":      []byte(`{"scale": 1}`),
+		"mod1.esp":     []byte("plugin"),
+	})
+	require.NoError(t, os.WriteFile(filepath.Join(game.ModPath, "settings.ini"), []byte("[General]\nfSpeed=2.5\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(game.ModPath, "ui.json"), []byte(`{"scale": 2}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(game.ModPath, "mod1.esp"), []byte("not a config"), 0644))
+
+	edits, err := svc.ModifiedConfigs(game, "default")
+	require.NoError(t, err)
+	require.Len(t, edits, 2)
+	assert.Equal(t, "settings.ini", edits[0].Path)
+	assert.Equal(t, "ui.json", edits[1].Path)
+	assert.Equal(t, "Mod One", edits[0].ModName)
+
+	saved, err := svc.SaveConfigEdits(game, "default", nil)
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Equal(t, "Data/settings.ini", saved[0].ProfilePath)
+	assert.Equal(t, 2, saved[0].Keys)
+	assert.Equal(t, 0, saved[1].Keys)
+
+	profile, err := config.LoadProfile(svc.ConfigDir(), game.ID, "default")
+	require.NoError(t, err)
+	fSpeed := profile.IniPatches["Data/settings.ini"]["General"]["fSpeed"]
+	require.NotNil(t, fSpeed)
+	assert.Equal(t, "2.5", *fSpeed)
+	hud, ok := profile.IniPatches["Data/settings.ini"]["General"]["bHud"]
+	assert.True(t, ok)
+	assert.Nil(t, hud)
+	assert.Equal(t, `{"scale": 2}`, string(profile.Overrides["Data/ui.json"]))
+
+	_, err = svc.SaveConfigEdits(game, "default", []string{"mod1.esp"})
+	assert.ErrorContains(t, err, "not a locally edited config file")
+}
+
+func TestSaveConfigEdits_FormattingOnlyEditKeepsExistingPatches(t *testing.T) {
+	svc := newFlowsTestService(t)
+	install := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", InstallPath: install, ModPath: filepath.Join(install, "Data"), LinkMethod: domain.LinkCopy, LinkMethodExplicit: true}
+	seedUpdatableMod(t, svc, game, "src", "mod1", "Mod One", "1.0", []string{"f1"}, map[string][]byte{
+		"settings.ini": []byte("[General]\nfSpeed=1.0\n"),
+	})
+	profile, err := config.LoadProfile(svc.ConfigDir(), game.ID, "default")
+	require.NoError(t, err)
+	speed := "3.0"
+	profile.IniPatches = domain.IniPatches{"Data/settings.ini": {"General": {"fSpeed": &speed}}}
+	require.NoError(t, config.SaveProfile(svc.ConfigDir(), profile))
+	require.NoError(t, os.WriteFile(filepath.Join(game.ModPath, "settings.ini"), []byte("; tuned\n[General]\nfSpeed=1.0\n"), 0644))
+
+	saved, err := svc.SaveConfigEdits(game, "default", nil)
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, 0, saved[0].Keys)
+
+	profile, err = config.LoadProfile(svc.ConfigDir(), game.ID, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "; tuned\n[General]\nfSpeed=1.0\n", string(profile.Overrides["Data/settings.ini"]))
+	got := profile.IniPatches["Data/settings.ini"]["General"]["fSpeed"]
+	require.NotNil(t, got, "the profile's own patches for the file survive")
+	assert.Equal(t, "3.0", *got)
+}
@@ -11,8 +11,10 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/DonovanMods/linux-mod-manager/internal/domain"
@@ -25,7 +27,7 @@ import (
 // find").
 type ConvergedFile struct {
 	Path     string // game-dir-relative
-	Reason   string // "no longer provided by <source>/<mod>" | "dangling link into lmm cache"
+	Reason   string // "no longer provided by <source>/<mod>" | "<source>/<mod> is no longer installed" | "dangling link into lmm cache"
 	SourceID string // owning mod when known ("" for sweep finds with no row)
 	ModID    string
 }
@@ -52,7 +54,9 @@ type ConvergeResult struct {
 //     installed mod still claims it, even if the row's own owning mod no
 //     longer does - this protects in-flight ownership churn (a file that
 //     changed hands between mods, or is about to on the next deploy) from
-//     being yanked out from under a still-valid claim.
+//     being yanked out from under a still-valid claim. Rows whose owning
+//     mod is no longer installed in the profile at all are judged the same
+//     way (a snapshot restore leaves these behind).
 //
 //     A mod whose cache entry is WHOLLY absent (deployableFiles returns
 //     fs.ErrNotExist, not "zero files") is a special case (fix round 2
@@ -199,6 +203,49 @@ func (s *Service) ConvergeDeployedFiles(ctx context.Context, game *domain.Game,
 		}
 	}
 
+	// Rows whose owning mod is no longer installed in the profile at all
+	// (left behind by a snapshot restore, or a crash mid-uninstall): nothing
+	// else will ever judge them, so they are judged like any other row. The
+	// merged pak's synthetic owner (domain.SourceMerged) never has an
+	// installed row - SyncMergedPak owns that file's lifecycle, not this.
+	owners, err := s.db.ListDeployedFiles(game.ID, profileName)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("listing deployed files: %w", err))
+	}
+	installed := make(map[string]bool, len(mods))
+	for _, m := range mods {
+		installed[domain.ModKey(m.SourceID, m.ID)] = true
+	}
+	for _, path := range slices.Sorted(maps.Keys(owners)) {
+		owner := owners[path]
+		if owner.SourceID == domain.SourceMerged || installed[domain.ModKey(owner.SourceID, owner.ModID)] || !filepath.IsLocal(path) {
+			continue
+		}
+		handled[path] = true
+		if provided[path] {
+			continue
+		}
+
+		cf := ConvergedFile{
+			Path:     path,
+			Reason:   fmt.Sprintf("%s/%s is no longer installed", owner.SourceID, owner.ModID),
+			SourceID: owner.SourceID,
+			ModID:    owner.ModID,
+		}
+		if dryRun {
+			result.Removed = append(result.Removed, cf)
+			continue
+		}
+		if err := lnk.Undeploy(filepath.Join(game.ModPath, path)); err != nil {
+			errs = append(errs, fmt.Errorf("undeploying %s: %w", path, err))
+			continue
+		}
+		result.Removed = append(result.Removed, cf)
+		if err := s.db.DeleteDeployedFile(game.ID, profileName, path); err != nil {
+			errs = append(errs, fmt.Errorf("deleting deployed-file record for %s: %w", path, err))
+		}
+	}
+
 	// --- Sweep pass ---
 	if err := ctx.Err(); err != nil {
 		return result, err
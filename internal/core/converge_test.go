@@ -59,6 +59,55 @@ func TestConverge_RowDrivenStaleRemoved(t *testing.T) {
 	assert.Equal(t, []string{"a.esp"}, rows, "gone.esp's row must be deleted")
 }
 
+func TestConverge_RowOfUninstalledModRemoved(t *testing.T) {
+	svc := newFlowsTestService(t)
+	gameDir := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: gameDir, LinkMethod: domain.LinkSymlink, LinkMethodExplicit: true}
+
+	seedInstalledMod(t, svc, game, "src", "m1", "1.0", true, map[string][]byte{"orphan.esp": []byte("o")})
+	installer := svc.GetInstaller(game)
+	require.NoError(t, installer.Install(context.Background(), game, &domain.Mod{ID: "m1", SourceID: "src", Version: "1.0", GameID: "g1"}, "default"))
+
+	// The install record goes, the deployed file and its row stay.
+	require.NoError(t, svc.DeleteInstalledMod("src", "m1", "g1", "default"))
+
+	result, err := svc.ConvergeDeployedFiles(context.Background(), game, "default", false)
+	require.NoError(t, err)
+	require.Len(t, result.Removed, 1)
+	assert.Equal(t, "orphan.esp", result.Removed[0].Path)
+	assert.Equal(t, "src/m1 is no longer installed", result.Removed[0].Reason)
+
+	_, err = os.Lstat(filepath.Join(gameDir, "orphan.esp"))
+	assert.True(t, os.IsNotExist(err))
+	rows, err := svc.GetDeployedFilesForMod("g1", "default", "src", "m1")
+	require.NoError(t, err)
+	assert.Empty(t, rows)
+}
+
+// TestConverge_MergedPakRowKept guards the uninstalled-owner pass against the
+// merged pak's synthetic owner: domain.SourceMerged never has an installed
+// row, yet its deployed pak is live, not stale.
+func TestConverge_MergedPakRowKept(t *testing.T) {
+	svc := newFlowsTestService(t)
+	gameDir := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: gameDir, LinkMethod: domain.LinkSymlink, LinkMethodExplicit: true}
+
+	require.NoError(t, svc.GetGameCache(game).Store("g1", domain.SourceMerged, "merged-pak", "merged", "zzz_LMM_Merged_P.pak", []byte("pak")))
+	installer := svc.GetInstaller(game)
+	require.NoError(t, installer.Install(context.Background(), game, &domain.Mod{ID: "merged-pak", SourceID: domain.SourceMerged, Version: "merged", GameID: "g1"}, "default"))
+
+	for _, dryRun := range []bool{true, false} {
+		result, err := svc.ConvergeDeployedFiles(context.Background(), game, "default", dryRun)
+		require.NoError(t, err)
+		assert.Empty(t, result.Removed, "dryRun=%v", dryRun)
+	}
+	_, err := os.Lstat(filepath.Join(gameDir, "zzz_LMM_Merged_P.pak"))
+	assert.NoError(t, err, "the merged pak must stay deployed")
+	rows, err := svc.GetDeployedFilesForMod("g1", "default", domain.SourceMerged, "merged-pak")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"zzz_LMM_Merged_P.pak"}, rows)
+}
+
 func TestConverge_SharedPathProtectedByUnion(t *testing.T) {
 	svc := newFlowsTestService(t)
 	gameDir := t.TempDir()
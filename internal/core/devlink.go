@@ -0,0 +1,376 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/linker"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
+)
+
+// devModVersion is the version every dev mod is recorded at: the working
+// directory has no release to name.
+const devModVersion = "dev"
+
+// DevLinkOptions configures DevLink.
+type DevLinkOptions struct {
+	ModID string // --as: defaults to the directory's base name
+	Name  string // display name; defaults to the mod ID
+}
+
+// DevLinkResult reports what DevLink deployed.
+type DevLinkResult struct {
+	Mod   *domain.InstalledMod
+	Files []string // mod_path-relative paths now linked, sorted
+}
+
+// DevSyncResult reports what SyncDevMod changed in the game directory.
+type DevSyncResult struct {
+	Added   []string // newly linked, sorted
+	Removed []string // links whose source file is gone, sorted
+}
+
+// Changed reports whether the sync touched anything.
+func (r *DevSyncResult) Changed() bool {
+	return len(r.Added) > 0 || len(r.Removed) > 0
+}
+
+// DevLink deploys a working directory straight into the game's mod_path as a
+// dev mod: every file is symlinked from dir itself, nothing is cached, so an
+// edit is live in the game as soon as it is saved. Files added or removed
+// later are picked up by SyncDevMod (lmm dev link --watch, or any deploy).
+//
+// The mod is recorded with SourceDev and DevPath set, but is not added to the
+// profile: the path is specific to this machine, so it has no place in an
+// exported or shared profile. Update checks and verify skip it.
+//
+// Linking the same ID again relinks from the new directory. A path owned by
+// another mod, or an untracked file the link would replace, is an error and
+// nothing is deployed.
+func (s *Service) DevLink(ctx context.Context, game *domain.Game, profileName, dir string, opts DevLinkOptions) (*DevLinkResult, error) {
+	if game.ModPath == "" {
+		return nil, fmt.Errorf("game has no mod_path configured")
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", dir, err)
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	modPath, err := filepath.Abs(game.ModPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolving mod_path: %w", err)
+	}
+	if pathWithinRoot(modPath, abs) || pathWithinRoot(abs, modPath) {
+		return nil, fmt.Errorf("%s overlaps mod_path %s; keep the working directory outside the game", dir, modPath)
+	}
+
+	modID := strings.TrimSpace(opts.ModID)
+	if modID == "" {
+		modID = filepath.Base(abs)
+	}
+	if modID == "" || modID == "." || modID == string(filepath.Separator) || strings.ContainsAny(modID, `/\`) {
+		return nil, fmt.Errorf("invalid mod ID %q", modID)
+	}
+	name := strings.TrimSpace(opts.Name)
+	if name == "" {
+		name = modID
+	}
+
+	mod := &domain.InstalledMod{
+		Mod: domain.Mod{
+			ID:       modID,
+			SourceID: domain.SourceDev,
+			Name:     name,
+			Version:  devModVersion,
+			GameID:   game.ID,
+		},
+		ProfileName:  profileName,
+		UpdatePolicy: domain.UpdatePinned,
+		Enabled:      true,
+		LinkMethod:   domain.LinkSymlink,
+		DevPath:      abs,
+	}
+
+	previous, err := s.GetInstalledMod(domain.SourceDev, modID, game.ID, profileName)
+	switch {
+	case err == nil && previous.DevPath != abs:
+		// Relinking from another directory: drop the old links first so a
+		// file only the old tree had does not linger.
+		if err := s.devInstaller(game).Uninstall(ctx, game, &previous.Mod, profileName); err != nil {
+			return nil, fmt.Errorf("unlinking previous %s: %w", previous.DevPath, err)
+		}
+	case err != nil && !errors.Is(err, domain.ErrModNotFound):
+		return nil, fmt.Errorf("checking existing dev mod: %w", err)
+	}
+
+	// The row goes in first: the installer reads DevPath from it.
+	if err := s.SaveInstalledMod(mod); err != nil {
+		return nil, fmt.Errorf("saving dev mod: %w", err)
+	}
+	if _, err := s.devInstaller(game).syncDevLinks(ctx, game, mod, profileName); err != nil {
+		if previous == nil {
+			_ = s.DeleteInstalledMod(domain.SourceDev, modID, game.ID, profileName) //nolint:errcheck // best-effort recovery on an already-erroring path
+		}
+		return nil, err
+	}
+	if err := s.SetModDeployed(domain.SourceDev, modID, game.ID, profileName, true); err != nil {
+		return nil, fmt.Errorf("marking dev mod deployed: %w", err)
+	}
+	mod.Deployed = true
+
+	files, err := s.GetDeployedFilesForMod(game.ID, profileName, domain.SourceDev, modID)
+	if err != nil {
+		return nil, err
+	}
+	return &DevLinkResult{Mod: mod, Files: files}, nil
+}
+
+// SyncDevMod re-links a dev mod against its working directory: files added
+// since the last sync are linked, links to files that are gone are removed.
+// Edits to existing files need nothing, the links already point at them.
+func (s *Service) SyncDevMod(ctx context.Context, game *domain.Game, profileName, modID string) (*DevSyncResult, error) {
+	mod, err := s.getDevMod(game, profileName, modID)
+	if err != nil {
+		return nil, err
+	}
+	return s.devInstaller(game).syncDevLinks(ctx, game, mod, profileName)
+}
+
+// DevUnlink removes a dev mod's links from the game directory and forgets
+// the mod. The working directory itself is never touched. Returns the number
+// of links removed.
+func (s *Service) DevUnlink(ctx context.Context, game *domain.Game, profileName, modID string) (int, error) {
+	mod, err := s.getDevMod(game, profileName, modID)
+	if err != nil {
+		return 0, err
+	}
+	files, err := s.GetDeployedFilesForMod(game.ID, profileName, domain.SourceDev, modID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.devInstaller(game).Uninstall(ctx, game, &mod.Mod, profileName); err != nil {
+		return 0, fmt.Errorf("unlinking %s: %w", mod.Name, err)
+	}
+	if err := s.DeleteInstalledMod(domain.SourceDev, modID, game.ID, profileName); err != nil {
+		return len(files), fmt.Errorf("removing dev mod record: %w", err)
+	}
+	return len(files), nil
+}
+
+func (s *Service) getDevMod(game *domain.Game, profileName, modID string) (*domain.InstalledMod, error) {
+	mod, err := s.GetInstalledMod(domain.SourceDev, modID, game.ID, profileName)
+	if err != nil {
+		if errors.Is(err, domain.ErrModNotFound) {
+			return nil, fmt.Errorf("no dev mod %q in profile %s", modID, profileName)
+		}
+		return nil, err
+	}
+	if !mod.IsDev() {
+		return nil, fmt.Errorf("%s has no working directory recorded; unlink and link it again", modID)
+	}
+	return mod, nil
+}
+
+// devInstaller returns an Installer for dev mods. The linker argument only
+// matters for cached mods; dev mods are always symlinked.
+func (s *Service) devInstaller(game *domain.Game) *Installer {
+	return s.NewInstallerWithLinker(game, linker.NewSymlink())
+}
+
+// devFiles lists the files a dev mod's working directory provides, relative
+// to it and slash-separated like every other deployed path. Dot-entries
+// (.git, .vscode, editor swap files) and lmm's reserved names are skipped;
+// so is anything that is not a regular file or a link to one.
+func devFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		base := d.Name()
+		if strings.HasPrefix(base, ".") || strings.HasPrefix(base, cache.ReservedPrefix) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// syncDevLinks makes the game directory match a dev mod's working directory.
+// Conflicts are checked for every new file before anything changes, so a
+// failed sync leaves the previous links as they were.
+func (i *Installer) syncDevLinks(ctx context.Context, game *domain.Game, mod *domain.InstalledMod, profileName string) (*DevSyncResult, error) {
+	if i.db == nil {
+		return nil, fmt.Errorf("dev mods require file tracking")
+	}
+	want, err := devFiles(mod.DevPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", mod.DevPath, err)
+	}
+	have, err := i.db.GetDeployedFilesForMod(game.ID, profileName, mod.SourceID, mod.ID)
+	if err != nil {
+		return nil, err
+	}
+	tracked := make(map[string]bool, len(have))
+	for _, f := range have {
+		tracked[f] = true
+	}
+	wanted := make(map[string]bool, len(want))
+	for _, f := range want {
+		wanted[f] = true
+	}
+
+	lnk := linker.NewSymlink()
+	var conflicts []string
+	for _, f := range want {
+		src := filepath.Join(mod.DevPath, f)
+		dst := filepath.Join(game.ModPath, f)
+		if tracked[f] {
+			// Ours already, but someone may have replaced the link.
+			if target, err := os.Readlink(dst); err == nil && target == src {
+				continue
+			}
+		}
+		owner, err := i.db.GetFileOwner(game.ID, profileName, f)
+		if err != nil {
+			return nil, fmt.Errorf("checking owner of %s: %w", f, err)
+		}
+		if owner != nil && (owner.SourceID != mod.SourceID || owner.ModID != mod.ID) {
+			conflicts = append(conflicts, fmt.Sprintf("%s (owned by %s)", f, domain.ModKey(owner.SourceID, owner.ModID)))
+			continue
+		}
+		if owner == nil {
+			if target, err := os.Readlink(dst); err == nil && target == src {
+				continue
+			}
+			if _, err := os.Lstat(dst); err == nil {
+				conflicts = append(conflicts, fmt.Sprintf("%s (untracked file in the game directory)", f))
+			}
+		}
+	}
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("dev link would replace %d file(s): %s", len(conflicts), strings.Join(conflicts, ", "))
+	}
+
+	result := &DevSyncResult{}
+	for _, f := range have {
+		if wanted[f] {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if err := lnk.Undeploy(filepath.Join(game.ModPath, f)); err != nil {
+			return result, fmt.Errorf("unlinking %s: %w", f, err)
+		}
+		if err := i.db.DeleteDeployedFile(game.ID, profileName, f); err != nil {
+			return result, fmt.Errorf("removing tracking for %s: %w", f, err)
+		}
+		result.Removed = append(result.Removed, f)
+	}
+	for _, f := range want {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		src := filepath.Join(mod.DevPath, f)
+		dst := filepath.Join(game.ModPath, f)
+		if target, err := os.Readlink(dst); err == nil && target == src && tracked[f] {
+			continue
+		}
+		if err := lnk.Deploy(src, dst); err != nil {
+			return result, fmt.Errorf("linking %s: %w", f, err)
+		}
+		if err := i.db.SaveDeployedFile(game.ID, profileName, f, mod.SourceID, mod.ID); err != nil {
+			_ = lnk.Undeploy(dst) //nolint:errcheck // best-effort recovery on an already-erroring path
+			return result, fmt.Errorf("tracking %s: %w", f, err)
+		}
+		if !tracked[f] {
+			result.Added = append(result.Added, f)
+		}
+	}
+	if len(result.Removed) > 0 {
+		linker.CleanupEmptyDirs(game.ModPath)
+	}
+	return result, nil
+}
+
+// uninstallDev removes every link a dev mod has deployed, by its tracked
+// rows: there is no cache listing to go by.
+func (i *Installer) uninstallDev(ctx context.Context, game *domain.Game, mod *domain.Mod, profileName string) error {
+	if i.db == nil {
+		return fmt.Errorf("dev mods require file tracking")
+	}
+	files, err := i.db.GetDeployedFilesForMod(game.ID, profileName, mod.SourceID, mod.ID)
+	if err != nil {
+		return err
+	}
+	lnk := linker.NewSymlink()
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !filepath.IsLocal(f) {
+			continue
+		}
+		if err := lnk.Undeploy(filepath.Join(game.ModPath, f)); err != nil {
+			return fmt.Errorf("undeploying %s: %w", f, err)
+		}
+	}
+	if err := i.db.DeleteDeployedFiles(game.ID, profileName, mod.SourceID, mod.ID); err != nil {
+		return fmt.Errorf("removing file tracking: %w", err)
+	}
+	linker.CleanupEmptyDirs(game.ModPath)
+	return nil
+}
+
+// installDev deploys a dev mod through the Installer entry point the rest of
+// the deploy paths use, reading its working directory from the installed row.
+func (i *Installer) installDev(ctx context.Context, game *domain.Game, mod *domain.Mod, profileName string) error {
+	if i.db == nil {
+		return fmt.Errorf("dev mods require file tracking")
+	}
+	installed, err := i.db.GetInstalledMod(mod.SourceID, mod.ID, game.ID, profileName)
+	if err != nil {
+		return fmt.Errorf("looking up dev mod %s: %w", mod.ID, err)
+	}
+	if !installed.IsDev() {
+		return fmt.Errorf("dev mod %s has no working directory recorded", mod.ID)
+	}
+	_, err = i.syncDevLinks(ctx, game, installed, profileName)
+	return err
+}
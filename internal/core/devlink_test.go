@@ -0,0 +1,166 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDevFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, rel)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+func TestDevLink_LinksSyncsAndUnlinks(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkCopy, LinkMethodExplicit: true}
+	work := filepath.Join(t.TempDir(), "my-mod")
+	writeDevFile(t, work, "plugins/my-mod.esp", "v1")
+	writeDevFile(t, work, ".git/HEAD", "ref: refs/heads/main")
+
+	result, err := svc.DevLink(context.Background(), game, "default", work, core.DevLinkOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "my-mod", result.Mod.ID)
+	assert.Equal(t, []string{"plugins/my-mod.esp"}, result.Files, "dot-directories stay out of the game")
+
+	// Symlinked straight from the working directory despite the copy
+	// method, and never cached: an edit is live immediately.
+	deployed := filepath.Join(game.ModPath, "plugins", "my-mod.esp")
+	target, err := os.Readlink(deployed)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(work, "plugins", "my-mod.esp"), target)
+	assert.False(t, svc.GetGameCache(game).Exists(game.ID, domain.SourceDev, "my-mod", "dev"))
+	writeDevFile(t, work, "plugins/my-mod.esp", "v2")
+	content, err := os.ReadFile(deployed)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", string(content))
+
+	installed, err := svc.GetInstalledMod(domain.SourceDev, "my-mod", game.ID, "default")
+	require.NoError(t, err)
+	assert.True(t, installed.IsDev())
+	assert.Equal(t, work, installed.DevPath)
+	assert.True(t, installed.Deployed)
+	assert.False(t, core.UpdateCheckable(*installed))
+
+	writeDevFile(t, work, "textures/new.dds", "tex")
+	require.NoError(t, os.Remove(filepath.Join(work, "plugins", "my-mod.esp")))
+	sync, err := svc.SyncDevMod(context.Background(), game, "default", "my-mod")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"textures/new.dds"}, sync.Added)
+	assert.Equal(t, []string{"plugins/my-mod.esp"}, sync.Removed)
+	_, err = os.Lstat(deployed)
+	assert.True(t, os.IsNotExist(err))
+
+	res, err := svc.Verify(context.Background(), game, "default", core.VerifyOptions{Tier: core.VerifyLocal}, nil)
+	require.NoError(t, err)
+	assert.Zero(t, res.Issues)
+	assert.Zero(t, res.Warnings, "verify leaves dev mods alone")
+
+	removed, err := svc.DevUnlink(context.Background(), game, "default", "my-mod")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	_, err = os.Lstat(filepath.Join(game.ModPath, "textures", "new.dds"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(work, "textures", "new.dds"))
+	assert.NoError(t, err, "the working directory is never touched")
+	_, err = svc.GetInstalledMod(domain.SourceDev, "my-mod", game.ID, "default")
+	assert.ErrorIs(t, err, domain.ErrModNotFound)
+}
+
+func TestDevLink_RefusesToReplaceFiles(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir()}
+	seedUpdatableMod(t, svc, game, "src", "mod1", "Mod One", "1.0", []string{"f1"},
+		map[string][]byte{"Data/shared.esp": []byte("managed")})
+	writeModPathFile(t, game, "Data/hand.esp", "hand-copied")
+
+	work := t.TempDir()
+	writeDevFile(t, work, "Data/shared.esp", "mine")
+	writeDevFile(t, work, "Data/hand.esp", "mine")
+	writeDevFile(t, work, "Data/fresh.esp", "mine")
+
+	_, err := svc.DevLink(context.Background(), game, "default", work, core.DevLinkOptions{ModID: "wip"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "Data/shared.esp (owned by src:mod1)")
+	assert.ErrorContains(t, err, "Data/hand.esp (untracked file in the game directory)")
+
+	_, err = os.Lstat(filepath.Join(game.ModPath, "Data", "fresh.esp"))
+	assert.True(t, os.IsNotExist(err), "a refused link deploys nothing")
+	_, err = svc.GetInstalledMod(domain.SourceDev, "wip", game.ID, "default")
+	assert.ErrorIs(t, err, domain.ErrModNotFound)
+}
+
+func TestDeployProfile_PurgeRelinksDevMods(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir()}
+	seedUpdatableMod(t, svc, game, "src", "mod1", "Mod One", "1.0", []string{"f1"},
+		map[string][]byte{"Data/mod1.esp": []byte("managed")})
+	work := t.TempDir()
+	writeDevFile(t, work, "Data/wip.esp", "mine")
+	_, err := svc.DevLink(context.Background(), game, "default", work, core.DevLinkOptions{ModID: "wip"})
+	require.NoError(t, err)
+	writeDevFile(t, work, "Data/wip2.esp", "more")
+
+	_, err = svc.DeployProfile(context.Background(), game, "default", core.DeployOptions{Purge: true}, nil)
+	require.NoError(t, err)
+
+	for _, rel := range []string{"Data/mod1.esp", "Data/wip.esp", "Data/wip2.esp"} {
+		_, err := os.Lstat(filepath.Join(game.ModPath, rel))
+		assert.NoError(t, err, rel)
+	}
+	_, owner, found, err := svc.GetFileOwner(game.ID, "default", "Data/wip2.esp")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "wip", owner)
+}
+
+func TestWatchDevMod_LinksNewFiles(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir()}
+	work := t.TempDir()
+	writeDevFile(t, work, "a.txt", "a")
+	_, err := svc.DevLink(context.Background(), game, "default", work, core.DevLinkOptions{ModID: "wip"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	synced := make(chan *core.DevSyncResult, 4)
+	done := make(chan error, 1)
+	go func() {
+		done <- svc.WatchDevMod(ctx, game, "default", "wip", func(r *core.DevSyncResult, err error) {
+			if err == nil {
+				synced <- r
+			}
+		})
+	}()
+
+	// Give the watcher time to register before the change it should see.
+	time.Sleep(100 * time.Millisecond)
+	writeDevFile(t, work, "sub/b.txt", "b")
+
+	var got []string
+	for len(got) < 1 {
+		select {
+		case r := <-synced:
+			got = append(got, r.Added...)
+		case <-ctx.Done():
+			t.Fatal("watcher never re-linked the new file")
+		}
+	}
+	assert.Contains(t, got, "sub/b.txt")
+	_, err = os.Lstat(filepath.Join(game.ModPath, "sub", "b.txt"))
+	assert.NoError(t, err)
+
+	cancel()
+	assert.NoError(t, <-done, "cancelling the watch is a normal stop")
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
+
+	"golang.org/x/sys/unix"
+)
+
+// devWatchSettle is how long the watcher waits for a burst of events (a
+// build writing many files, a git checkout) to go quiet before re-linking.
+const devWatchSettle = 250 * time.Millisecond
+
+// devWatchMask covers the events that change which files exist. Content
+// writes are not watched: the links already point at the files.
+const devWatchMask = unix.IN_CREATE | unix.IN_DELETE | unix.IN_MOVED_FROM | unix.IN_MOVED_TO | unix.IN_DELETE_SELF | unix.IN_ONLYDIR
+
+// WatchDevMod watches a dev mod's working directory with inotify and calls
+// SyncDevMod whenever files are added or removed, reporting each sync that
+// changed something (or failed) to onSync. It blocks until ctx is cancelled,
+// which is a normal stop and returns nil. A failed sync does not stop the
+// watch: the author is usually mid-edit and the next change may fix it.
+func (s *Service) WatchDevMod(ctx context.Context, game *domain.Game, profileName, modID string, onSync func(*DevSyncResult, error)) error {
+	mod, err := s.getDevMod(game, profileName, modID)
+	if err != nil {
+		return err
+	}
+	if onSync == nil {
+		onSync = func(*DevSyncResult, error) {}
+	}
+
+	fd, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		return fmt.Errorf("starting inotify: %w", err)
+	}
+	defer unix.Close(fd) //nolint:errcheck
+
+	if err := addDevWatches(fd, mod.DevPath); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 64*(unix.SizeofInotifyEvent+unix.NAME_MAX+1))
+	pollFDs := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+	dirty := false
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+		// A short poll timeout doubles as the settle window and keeps ctx
+		// cancellation responsive without a second goroutine.
+		n, err := unix.Poll(pollFDs, int(devWatchSettle/time.Millisecond))
+		if err != nil {
+			if errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return fmt.Errorf("waiting for inotify events: %w", err)
+		}
+		if n == 0 {
+			if !dirty {
+				continue
+			}
+			dirty = false
+			// New directories need their own watch before the next event.
+			if err := addDevWatches(fd, mod.DevPath); err != nil {
+				onSync(nil, err)
+				continue
+			}
+			result, err := s.SyncDevMod(ctx, game, profileName, modID)
+			if ctx.Err() != nil {
+				return nil
+			}
+			if err != nil || result.Changed() {
+				onSync(result, err)
+			}
+			continue
+		}
+		for {
+			read, err := unix.Read(fd, buf)
+			if err != nil {
+				if errors.Is(err, unix.EAGAIN) || errors.Is(err, unix.EINTR) {
+					break
+				}
+				return fmt.Errorf("reading inotify events: %w", err)
+			}
+			if read <= 0 {
+				break
+			}
+			dirty = true
+		}
+	}
+}
+
+// addDevWatches watches every directory under root that devFiles would
+// descend into. Adding a watch that already exists is a no-op in inotify, so
+// this is safe to repeat after each burst of changes.
+func addDevWatches(fd int, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// A directory removed mid-walk is the change being watched for.
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && (strings.HasPrefix(d.Name(), ".") || strings.HasPrefix(d.Name(), cache.ReservedPrefix)) {
+			return fs.SkipDir
+		}
+		if _, err := unix.InotifyAddWatch(fd, path, devWatchMask); err != nil {
+			if errors.Is(err, unix.ENOENT) {
+				return nil
+			}
+			return fmt.Errorf("watching %s: %w", path, err)
+		}
+		return nil
+	})
+}
@@ -3,7 +3,9 @@ package core
 import (
 	"context"
 	"crypto/md5"
+	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -13,7 +15,10 @@ import (
 	neturl "net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
 )
 
 const (
@@ -38,6 +43,8 @@ type DownloadResult struct {
 	Size     int64  // Bytes downloaded
 	Checksum string // MD5 hash of downloaded file (recorded in the DB)
 	SHA256   string // SHA-256 of downloaded file (compared against source-declared checksums)
+	SHA512   string // SHA-512 of downloaded file
+	SHA1     string // SHA-1 of downloaded file
 }
 
 // Downloader handles HTTP file downloads with progress tracking
@@ -238,12 +245,14 @@ func (d *Downloader) downloadOnce(ctx context.Context, url, destPath string, hea
 	totalBytes := resp.ContentLength
 	md5Hasher := md5.New()
 	shaHasher := sha256.New()
+	sha512Hasher := sha512.New()
+	sha1Hasher := sha1.New()
 	reader := &progressReader{
 		reader:     resp.Body,
 		totalBytes: totalBytes,
 		progressFn: progressFn,
 	}
-	teeReader := io.TeeReader(reader, io.MultiWriter(md5Hasher, shaHasher))
+	teeReader := io.TeeReader(reader, io.MultiWriter(md5Hasher, shaHasher, sha512Hasher, sha1Hasher))
 
 	written, err := io.Copy(file, teeReader)
 	if err != nil {
@@ -264,9 +273,44 @@ func (d *Downloader) downloadOnce(ctx context.Context, url, destPath string, hea
 		Size:     written,
 		Checksum: hex.EncodeToString(md5Hasher.Sum(nil)),
 		SHA256:   hex.EncodeToString(shaHasher.Sum(nil)),
+		SHA512:   hex.EncodeToString(sha512Hasher.Sum(nil)),
+		SHA1:     hex.EncodeToString(sha1Hasher.Sum(nil)),
 	}, nil
 }
 
+// verifyDownloadChecksums compares a download against every checksum the
+// source declared for it. SHA-1 and MD5 are only consulted when the source
+// declares nothing stronger: they are there for sources that publish nothing
+// else.
+func verifyDownloadChecksums(file *domain.DownloadableFile, got *DownloadResult) error {
+	checks := []struct{ algo, want, got string }{
+		{"sha256", file.SHA256, got.SHA256},
+		{"sha512", file.SHA512, got.SHA512},
+	}
+	if file.SHA256 == "" && file.SHA512 == "" {
+		checks = append(checks,
+			struct{ algo, want, got string }{"sha1", file.SHA1, got.SHA1},
+			struct{ algo, want, got string }{"md5", file.MD5, got.Checksum})
+	}
+	for _, c := range checks {
+		if c.want != "" && !strings.EqualFold(c.want, c.got) {
+			return &checksumMismatchError{fileName: file.FileName, algo: c.algo, want: c.want, got: c.got}
+		}
+	}
+	return nil
+}
+
+// checksumMismatchError is a download whose bytes differ from a checksum
+// declared for them.
+type checksumMismatchError struct {
+	fileName, algo, want, got string
+}
+
+func (e *checksumMismatchError) Error() string {
+	return fmt.Sprintf("verifying download of %s: %s mismatch: source declares %s, downloaded file is %s",
+		e.fileName, e.algo, e.want, e.got)
+}
+
 // progressReader wraps an io.Reader to track download progress
 type progressReader struct {
 	reader     io.Reader
@@ -14,6 +14,7 @@ import (
 	"github.com/DonovanMods/linux-mod-manager/internal/source"
 	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
 	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/db"
 )
 
 // ReorderProfileMods persists mods as gameID/profileName's new load order
@@ -33,15 +34,26 @@ import (
 // match ReorderMods' own existing bare-error signature rather than
 // inventing a new result type for one warning slice.
 func (s *Service) ReorderProfileMods(gameID, profileName string, mods []domain.ModReference) error {
+	return s.reorderProfileMods(context.Background(), gameID, profileName, mods)
+}
+
+// reorderProfileMods is ReorderProfileMods with a context, so Undo can link
+// the reorder it records to the entry it reverts.
+func (s *Service) reorderProfileMods(ctx context.Context, gameID, profileName string, mods []domain.ModReference) error {
 	pm := NewProfileManager(s.configDir, s.db)
+	orderBefore := s.loadOrder(gameID, profileName)
 	if err := pm.ReorderMods(gameID, profileName, mods); err != nil {
 		return err
 	}
+	s.recordOperation(ctx, db.Operation{
+		GameID: gameID, Profile: profileName, Operation: db.OpReorder,
+		OrderBefore: orderBefore, OrderAfter: s.loadOrder(gameID, profileName),
+	})
 	game, ok := s.games[gameID]
 	if !ok {
 		return nil // an unknown game has no merged pak to sync either
 	}
-	_, _ = s.syncMergedPak(context.Background(), game, profileName) //nolint:errcheck // best-effort, see doc comment
+	_, _ = s.syncMergedPak(ctx, game, profileName) //nolint:errcheck // best-effort, see doc comment
 	return nil
 }
 
@@ -107,7 +119,7 @@ func (s *Service) EnableMod(ctx context.Context, game *domain.Game, profileName,
 		return &EnableResult{}, nil
 	}
 
-	if !s.GetGameCache(game).Exists(game.ID, sourceID, modID, mod.Version) {
+	if !mod.IsDev() && !s.GetGameCache(game).Exists(game.ID, sourceID, modID, mod.Version) {
 		return nil, fmt.Errorf("mod not found in cache - try reinstalling with 'lmm install --id %s'", modID)
 	}
 
@@ -138,6 +150,9 @@ func (s *Service) EnableMod(ctx context.Context, game *domain.Game, profileName,
 	}
 
 	result.Changed = true
+	s.recordOperation(ctx, db.Operation{GameID: game.ID, Profile: profileName, Operation: db.OpEnable, Mods: []db.OperationMod{{
+		SourceID: sourceID, ModID: modID, Name: mod.Name, BeforeVersion: mod.Version, AfterVersion: mod.Version, AfterEnabled: true,
+	}}})
 	return result, nil
 }
 
@@ -218,6 +233,9 @@ func (s *Service) DisableMod(ctx context.Context, game *domain.Game, profileName
 	}
 
 	result.Changed = true
+	s.recordOperation(ctx, db.Operation{GameID: game.ID, Profile: profileName, Operation: db.OpDisable, Mods: []db.OperationMod{{
+		SourceID: sourceID, ModID: modID, Name: mod.Name, BeforeVersion: mod.Version, AfterVersion: mod.Version, BeforeEnabled: true,
+	}}})
 	return result, nil
 }
 
@@ -328,6 +346,9 @@ func (s *Service) UninstallMod(ctx context.Context, game *domain.Game, profileNa
 	if err := s.DeleteInstalledMod(mod.SourceID, modID, game.ID, profileName); err != nil {
 		return result, fmt.Errorf("failed to remove mod record: %w", err)
 	}
+	s.recordOperation(ctx, db.Operation{GameID: game.ID, Profile: profileName, Operation: db.OpUninstall, Mods: []db.OperationMod{{
+		SourceID: mod.SourceID, ModID: modID, Name: mod.Name, BeforeVersion: mod.Version, BeforeEnabled: mod.Enabled,
+	}}})
 
 	if err := s.NewProfileManager().RemoveMod(game.ID, profileName, mod.SourceID, modID); err != nil {
 		// Don't fail if not in profile. Always recorded, historical "Note: "
@@ -868,6 +889,12 @@ const (
 	// own SetModLinkMethod failure, mirroring doUpdateRollback's
 	// textually-identical verbose-gated print exactly.
 	UpdateNote
+	// UpdateConfigMerged fires once per locally edited config file ApplyUpdate
+	// carried over to the new version cleanly (see ConfigMerge). Detail is
+	// the one-line "Merged local edits into updated <path>" message; a merge
+	// that conflicted (or whose file the new version dropped) fires
+	// UpdateWarning instead, since the user has something to resolve.
+	UpdateConfigMerged
 
 	// --- PurgeProfile progress events (#61, TUI Phase 6 prep): the
 	// standalone `lmm purge` command's flow, extending this same enum.
@@ -964,6 +991,14 @@ const (
 	// (4-space indent, matching ApplyProfileSwitch's own SwitchInstallNote
 	// convention).
 	ImportNote
+
+	// CacheQuotaEnforced fires once at the end of a successful ApplyInstall
+	// or ApplyUpdate whose game has a cache_quota the cache exceeded, when
+	// unreferenced versions were removed to get back under it (see
+	// EnforceCacheQuota). Total is the number of versions removed and
+	// TotalBytes the bytes freed; the CLI formats the sizes. A failed quota
+	// pass is an InstallWarning/UpdateWarning instead.
+	CacheQuotaEnforced
 )
 
 // DeployProgress reports incremental status during DeployProfile. Index and
@@ -995,7 +1030,8 @@ type DeployProgress struct {
 	// doInstall's downloadSelectedFiles prints a byte-count readout even
 	// when the total size is unknown ("Downloaded %s" vs "%.1f%% (%s /
 	// %s)"), so the CLI needs the raw numbers, not just Percent, to
-	// reproduce that byte-identically. Zero for every other phase.
+	// reproduce that byte-identically. CacheQuotaEnforced reuses TotalBytes
+	// for the bytes it freed. Zero for every other phase.
 	Downloaded int64
 	TotalBytes int64
 	// File identifies which of the primary mod's selected files an
@@ -1069,11 +1105,16 @@ type DeployProgress struct {
 //
 // On error, the returned result carries any diagnostics accumulated before
 // the failure; callers should surface them alongside the error.
+//
+// ReflinkFallbacks counts the files a reflink deploy copied because the
+// filesystem could not clone them; when non-zero a Warning says so, since
+// those copies take their full size on disk.
 type DeployResult struct {
-	Deployed int
-	Skipped  []string
-	Warnings []string
-	Notes    []string
+	Deployed         int
+	Skipped          []string
+	Warnings         []string
+	Notes            []string
+	ReflinkFallbacks int
 }
 
 // errNoDeployFiles mirrors cmd/lmm's errNoDownloadableFiles for the
@@ -1705,6 +1746,17 @@ func (s *Service) DeployProfile(ctx context.Context, game *domain.Game, profileN
 		}
 	}
 
+	// Recorded on every return once something touched the game directory,
+	// so a cancelled or failed deploy still shows up in the history.
+	statesBefore := s.modStates(game.ID, profileName)
+	var deployedKeys []string
+	defer func() {
+		if result.Deployed > 0 || opts.Purge {
+			s.recordOperation(ctx, db.Operation{GameID: game.ID, Profile: profileName, Operation: db.OpDeploy,
+				Mods: changedMods(statesBefore, s.modStates(game.ID, profileName), deployedKeys...)})
+		}
+	}()
+
 	var enabledBeforePurge map[string]bool
 	if opts.Purge {
 		mods, err := s.GetInstalledMods(game.ID, profileName)
@@ -1737,7 +1789,8 @@ func (s *Service) DeployProfile(ctx context.Context, game *domain.Game, profileN
 		}
 		linkMethod = method
 	}
-	installer := s.NewInstallerWithLinker(game, s.GetLinker(linkMethod))
+	lnk := s.GetLinker(linkMethod)
+	installer := s.NewInstallerWithLinker(game, lnk)
 
 	var modsToDeploy []*domain.InstalledMod
 	if opts.ModID != "" {
@@ -1768,6 +1821,22 @@ func (s *Service) DeployProfile(ctx context.Context, game *domain.Game, profileN
 				modsToDeploy = append(modsToDeploy, &mods[i])
 			}
 		}
+
+		// Dev mods are kept out of the profile (their path only exists on
+		// this machine), so load order never lists them. Deploy them last:
+		// the mod being worked on should be what the game sees.
+		all, err := s.GetInstalledMods(game.ID, profileName)
+		if err != nil {
+			return result, fmt.Errorf("getting installed mods: %w", err)
+		}
+		for i := range all {
+			if !all[i].IsDev() {
+				continue
+			}
+			if opts.All || all[i].Enabled || enabledBeforePurge[domain.ModKey(all[i].SourceID, all[i].ID)] {
+				modsToDeploy = append(modsToDeploy, &all[i])
+			}
+		}
 	}
 
 	if len(modsToDeploy) == 0 {
@@ -1815,7 +1884,7 @@ func (s *Service) DeployProfile(ctx context.Context, game *domain.Game, profileN
 			continue
 		}
 
-		if !s.GetGameCache(game).Exists(game.ID, mod.SourceID, mod.ID, mod.Version) {
+		if !mod.IsDev() && !s.GetGameCache(game).Exists(game.ID, mod.SourceID, mod.ID, mod.Version) {
 			if skipped := s.redeployFromSource(ctx, game, mod, base, emit, result); skipped {
 				continue
 			}
@@ -1838,12 +1907,15 @@ func (s *Service) DeployProfile(ctx context.Context, game *domain.Game, profileN
 			continue
 		}
 
-		if err := s.SetModLinkMethod(mod.SourceID, mod.ID, game.ID, profileName, linkMethod); err != nil {
-			msg := fmt.Sprintf("Warning: could not update link method: %v", err)
-			result.Notes = append(result.Notes, msg)
-			evt := base
-			evt.Phase, evt.Detail = DeployNote, msg
-			emit(evt)
+		// A dev mod is always symlinked, whatever the profile's method.
+		if !mod.IsDev() {
+			if err := s.SetModLinkMethod(mod.SourceID, mod.ID, game.ID, profileName, linkMethod); err != nil {
+				msg := fmt.Sprintf("Warning: could not update link method: %v", err)
+				result.Notes = append(result.Notes, msg)
+				evt := base
+				evt.Phase, evt.Detail = DeployNote, msg
+				emit(evt)
+			}
 		}
 		if err := s.SetModDeployed(mod.SourceID, mod.ID, game.ID, profileName, true); err != nil {
 			msg := fmt.Sprintf("Warning: could not mark as deployed: %v", err)
@@ -1854,6 +1926,7 @@ func (s *Service) DeployProfile(ctx context.Context, game *domain.Game, profileN
 		}
 
 		result.Deployed++
+		deployedKeys = append(deployedKeys, domain.ModKey(mod.SourceID, mod.ID))
 		evt := base
 		evt.Phase = DeployDeployed
 		emit(evt)
@@ -1874,12 +1947,26 @@ func (s *Service) DeployProfile(ctx context.Context, game *domain.Game, profileN
 		deferredWarnings = append(deferredWarnings, DeployProgress{Phase: DeployWarning, Detail: msg})
 	}
 
-	if profile, err := config.LoadProfile(s.configDir, game.ID, profileName); err == nil && len(profile.Overrides) > 0 {
+	if rl, ok := lnk.(*linker.ReflinkLinker); ok && rl.Fallbacks() > 0 {
+		result.ReflinkFallbacks = rl.Fallbacks()
+		msg := fmt.Sprintf("%s cannot reflink from the cache: copied %d file(s) instead (full disk usage)", game.ModPath, result.ReflinkFallbacks)
+		result.Warnings = append(result.Warnings, msg)
+		emit(DeployProgress{Phase: DeployWarning, Detail: msg})
+	}
+
+	if profile, err := config.LoadProfile(s.configDir, game.ID, profileName); err == nil {
 		if err := ApplyProfileOverrides(game, profile); err != nil {
 			msg := fmt.Sprintf("applying profile overrides: %v", err)
 			result.Warnings = append(result.Warnings, msg)
 			emit(DeployProgress{Phase: DeployWarning, Detail: msg})
 		}
+		// After overrides, so a key patch lands on top of a whole-file
+		// override of the same file rather than being clobbered by it.
+		if err := ApplyIniPatches(game, profile); err != nil {
+			msg := fmt.Sprintf("applying ini patches: %v", err)
+			result.Warnings = append(result.Warnings, msg)
+			emit(DeployProgress{Phase: DeployWarning, Detail: msg})
+		}
 	}
 
 	if syncWarnings, syncErr := s.syncMergedPak(ctx, game, profileName); syncErr != nil {
@@ -2235,6 +2322,15 @@ type PurgeResult struct {
 // doPurge, which never checked ctx mid-loop.
 func (s *Service) PurgeProfile(ctx context.Context, game *domain.Game, profileName string, mods []domain.InstalledMod, opts PurgeOptions, progress func(DeployProgress)) (*PurgeResult, error) {
 	result := &PurgeResult{}
+	// Recorded on every return once a mod was purged, so a cancelled purge
+	// still shows up in the history.
+	statesBefore := s.modStates(game.ID, profileName)
+	defer func() {
+		if result.Purged > 0 {
+			s.recordOperation(ctx, db.Operation{GameID: game.ID, Profile: profileName, Operation: db.OpPurge,
+				Mods: changedMods(statesBefore, s.modStates(game.ID, profileName), s.purgedKeys(game.ID, profileName, mods)...)})
+		}
+	}()
 	err := s.purgeMods(ctx, game, profileName, mods, purgeSpec{
 		uninstall: opts.Uninstall,
 		hooks:     opts.Hooks,
@@ -2303,6 +2399,12 @@ type SwitchPlan struct {
 
 	NoChanges     bool // To's mod set matches From's content-wise; only SetDefault is needed
 	AlreadyActive bool // To is already the active default profile; nothing to plan
+
+	// SwapSaves is set when the game opts into save_isolation: applying the
+	// plan parks From's saves and brings To's into the game's saves_path
+	// (see swapProfileSaves). Independent of NoChanges - two profiles with
+	// identical mod sets still keep separate saves.
+	SwapSaves bool
 }
 
 // PlanProfileSwitch computes the diff between game's currently-active
@@ -2438,6 +2540,7 @@ func (s *Service) PlanProfileSwitch(ctx context.Context, game *domain.Game, targ
 		ToDisable: toDisable, ToEnable: toEnable, ToInstall: toInstall,
 		PriorVersions: priorVersions,
 		NoChanges:     len(toDisable) == 0 && len(toEnable) == 0 && len(toInstall) == 0,
+		SwapSaves:     game.SaveIsolation && game.SavesPath != "",
 	}, nil
 }
 
@@ -2467,8 +2570,11 @@ type SwitchResult struct {
 	Notes                        []string
 	// Warnings holds diagnostics that must reach the user unconditionally
 	// (#197 postsmoke fix), unlike Notes' --verbose-only display contract -
-	// today, only a merged-pak sync failure for plan.To.
+	// a merged-pak sync or ini-patch failure for plan.To.
 	Warnings []string
+	// SavesSwapped reports that plan.SwapSaves was honored: the game's
+	// saves_path now holds plan.To's saves.
+	SavesSwapped bool
 }
 
 // ApplyProfileSwitch executes a plan produced by PlanProfileSwitch: disables
@@ -2494,8 +2600,14 @@ type SwitchResult struct {
 // SEPARATE PlanProfileSwitch call from whichever one built the confirmation
 // modal the user actually saw - see that method's own doc comment for the
 // resulting preview/apply drift this can introduce.
-func (s *Service) ApplyProfileSwitch(ctx context.Context, game *domain.Game, plan *SwitchPlan, progress func(DeployProgress)) (*SwitchResult, error) {
-	result := &SwitchResult{}
+//
+// When plan.SwapSaves is set, saves are swapped BEFORE any mod is touched,
+// so a failed swap aborts the switch with nothing changed; any later error
+// return swaps them back, since live saves must always belong to whichever
+// profile is the default (the next switch parks them under that name).
+func (s *Service) ApplyProfileSwitch(ctx context.Context, game *domain.Game, plan *SwitchPlan, progress func(DeployProgress)) (result *SwitchResult, err error) {
+	result = &SwitchResult{}
+	statesBefore := s.modStates(game.ID, plan.To)
 	emit := func(p DeployProgress) {
 		if progress != nil {
 			progress(p)
@@ -2516,6 +2628,23 @@ func (s *Service) ApplyProfileSwitch(ctx context.Context, game *domain.Game, pla
 	}
 	pm := s.NewProfileManager()
 
+	if plan.SwapSaves {
+		if err := swapProfileSaves(game, plan.From, plan.To); err != nil {
+			return result, fmt.Errorf("swapping saves: %w", err)
+		}
+		result.SavesSwapped = true
+		defer func() {
+			if err == nil {
+				return
+			}
+			if rbErr := swapProfileSaves(game, plan.To, plan.From); rbErr != nil {
+				err = &domain.DeployError{Primary: err, Rollback: fmt.Errorf("swapping saves back: %w", rbErr)}
+				return
+			}
+			result.SavesSwapped = false
+		}()
+	}
+
 	totalDisable := len(plan.ToDisable)
 	for idx := range plan.ToDisable {
 		// Task 6 item d (cancel-then-drain): checked between mods, never
@@ -2741,6 +2870,15 @@ func (s *Service) ApplyProfileSwitch(ctx context.Context, game *domain.Game, pla
 		return result, fmt.Errorf("setting default profile: %w", err)
 	}
 
+	// A profile's INI patches are part of what switching to it means, so
+	// they are merged here too, not only on the next deploy. Failure is a
+	// warning: the mods themselves switched fine.
+	if toProfile, err := pm.Get(game.ID, plan.To); err == nil {
+		if err := ApplyIniPatches(game, toProfile); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("could not apply ini patches: %v", err))
+		}
+	}
+
 	// #197 postsmoke fix: Warnings, not Notes - SwitchResult.Notes is
 	// --verbose-gated in the CLI, so a sync failure here used to be
 	// silent by default.
@@ -2750,6 +2888,11 @@ func (s *Service) ApplyProfileSwitch(ctx context.Context, game *domain.Game, pla
 		result.Warnings = append(result.Warnings, syncWarnings...)
 	}
 
+	if plan.From != plan.To {
+		s.recordOperation(ctx, db.Operation{GameID: game.ID, Profile: plan.To, Operation: db.OpSwitch, FromProfile: plan.From,
+			Mods: changedMods(statesBefore, s.modStates(game.ID, plan.To))})
+	}
+
 	return result, nil
 }
 
@@ -3264,6 +3407,11 @@ type InstallResult struct {
 	// deployed. Always false for a non-DeployCompile game.
 	MergedPakSyncFailed bool
 
+	// CacheQuota reports the cache_quota pass run after a successful
+	// install (see EnforceCacheQuota); nil when the game has no quota or
+	// the cache was within it.
+	CacheQuota *CacheGCResult
+
 	Warnings []string
 	Notes    []string
 }
@@ -3642,6 +3790,7 @@ func (s *Service) resolveStrictInstallFiles(ctx context.Context, plan *InstallPl
 // error (see InstallResult's doc comment).
 func (s *Service) ApplyInstall(ctx context.Context, game *domain.Game, plan *InstallPlan, opts InstallOptions, progress func(DeployProgress)) (*InstallResult, error) {
 	result := &InstallResult{}
+	statesBefore := s.modStates(game.ID, plan.Profile)
 	emit := func(p DeployProgress) {
 		if progress != nil {
 			progress(p)
@@ -3829,6 +3978,22 @@ func (s *Service) ApplyInstall(ctx context.Context, game *domain.Game, plan *Ins
 		}
 	}
 
+	// Last, so the quota sees this install's own cache entries and refs.
+	var quotaWarning string
+	if result.CacheQuota, quotaWarning = s.enforceCacheQuotaAfter(ctx, game, emit); quotaWarning != "" {
+		result.Warnings = append(result.Warnings, quotaWarning)
+		emit(DeployProgress{Phase: InstallWarning, Detail: quotaWarning})
+	}
+
+	if len(result.Installed) > 0 {
+		after := s.modStates(game.ID, plan.Profile)
+		var always []string
+		if primary := domain.ModKey(plan.Mod.SourceID, plan.Mod.ID); after[primary].AfterVersion != "" {
+			always = append(always, primary)
+		}
+		s.recordOperation(ctx, db.Operation{GameID: game.ID, Profile: plan.Profile, Operation: db.OpInstall, Mods: changedMods(statesBefore, after, always...)})
+	}
+
 	return result, nil
 }
 
@@ -4004,7 +4169,7 @@ func (s *Service) applyInstallBatchMod(ctx context.Context, game *domain.Game, p
 			evt := base
 			evt.Phase, evt.Detail = InstallChecksumComputed, downloadResult.Checksum
 			emit(evt)
-			checksums = append(checksums, fileChecksum{fileID: file.ID, checksum: downloadResult.Checksum})
+			checksums = append(checksums, fileChecksum{fileID: file.ID, checksum: downloadResult.Checksum, sha256: downloadResult.SHA256, size: downloadResult.Size})
 		}
 
 		filesExtracted += downloadResult.FilesExtracted
@@ -4040,7 +4205,7 @@ func (s *Service) applyInstallBatchMod(ctx context.Context, game *domain.Game, p
 	}
 
 	for _, cs := range checksums {
-		if err := s.SaveFileChecksum(mod.SourceID, mod.ID, game.ID, plan.Profile, cs.fileID, cs.checksum); err != nil {
+		if err := s.saveFileChecksums(mod.SourceID, mod.ID, game.ID, plan.Profile, cs); err != nil {
 			msg := fmt.Sprintf("failed to save checksum: %v", err)
 			result.Warnings = append(result.Warnings, msg)
 			evt := base
@@ -4085,9 +4250,24 @@ func (s *Service) applyInstallBatchMod(ctx context.Context, game *domain.Game, p
 // applyInstallPrimary's later SaveFileChecksum loop is deterministic (the
 // pre-extraction CLI's own map-based fileChecksums had no ordering
 // guarantee across multiple files, so this is a harmless, if anything more
-// correct, deviation - see the task report).
+// correct, deviation - see the task report). sha256 and size are the
+// archive's digest for profile lockfiles, saved alongside the checksum.
 type fileChecksum struct {
 	fileID, checksum string
+	sha256           string
+	size             int64
+}
+
+// saveFileChecksums records cs's checksum and, when the download produced
+// one, its digest.
+func (s *Service) saveFileChecksums(sourceID, modID, gameID, profileName string, cs fileChecksum) error {
+	if err := s.SaveFileChecksum(sourceID, modID, gameID, profileName, cs.fileID, cs.checksum); err != nil {
+		return err
+	}
+	if cs.sha256 == "" {
+		return nil
+	}
+	return s.SaveFileDigest(sourceID, modID, gameID, profileName, cs.fileID, cs.sha256, cs.size)
 }
 
 // applyInstallPrimary installs plan.Mod - doInstall's OWN single-mod
@@ -4204,7 +4384,7 @@ func (s *Service) applyInstallPrimary(ctx context.Context, game *domain.Game, pl
 			evt := base
 			evt.Phase, evt.Index, evt.Total, evt.File, evt.Detail = InstallChecksumComputed, i+1, filesTotal, file, downloadResult.Checksum
 			emit(evt)
-			checksums = append(checksums, fileChecksum{fileID: file.ID, checksum: downloadResult.Checksum})
+			checksums = append(checksums, fileChecksum{fileID: file.ID, checksum: downloadResult.Checksum, sha256: downloadResult.SHA256, size: downloadResult.Size})
 		}
 
 		result.FilesDeployed += downloadResult.FilesExtracted
@@ -4317,7 +4497,7 @@ func (s *Service) applyInstallPrimary(ctx context.Context, game *domain.Game, pl
 	}
 
 	for _, fc := range checksums {
-		if err := s.SaveFileChecksum(plan.SourceID, mod.ID, game.ID, plan.Profile, fc.fileID, fc.checksum); err != nil {
+		if err := s.saveFileChecksums(plan.SourceID, mod.ID, game.ID, plan.Profile, fc); err != nil {
 			msg := fmt.Sprintf("failed to save checksum for file %s: %v", fc.fileID, err)
 			result.Warnings = append(result.Warnings, msg)
 			emit(DeployProgress{Phase: InstallWarning, Detail: msg, ModName: mod.Name, ModID: mod.ID})
@@ -4407,6 +4587,13 @@ type UpdateApplyResult struct {
 	Applied  []string
 	Warnings []string
 	Notes    []string
+	// ConfigMerges holds one entry per locally edited config file the update
+	// carried across (see ConfigMerge) - clean merges and conflicts alike.
+	ConfigMerges []ConfigMerge
+	// CacheQuota reports the cache_quota pass run after a successful update
+	// (see EnforceCacheQuota); nil when the game has no quota or the cache
+	// was within it.
+	CacheQuota *CacheGCResult
 }
 
 // ErrModLocked reports an update apply refused because the profile ref is
@@ -4484,10 +4671,18 @@ func LockedRefRefusalError(mod domain.Mod, profileName string, ref *domain.ModRe
 // SetModLinkMethod is NOT rolled back, matching applyUpdate exactly (it only
 // ever produced a --verbose-gated Note).
 //
+// Config files (.ini/.json/.toml/.xml) the user edited in the game
+// directory are snapshotted just before Replace and, once every write above
+// has committed, three-way merged (old upstream, new upstream, local) into
+// the new deployment - see mergeConfigSnapshots. Each compensation path
+// writes the snapshots back after its reverse replace, so a failed update
+// never costs the user their edits either.
+//
 // progress may be nil. On error, the returned result carries any
 // diagnostics accumulated before the failure - callers should surface them
 // alongside the error (see UpdateApplyResult's doc comment).
 func (s *Service) ApplyUpdate(ctx context.Context, game *domain.Game, profileName string, upd domain.Update, opts UpdateOptions, progress func(DeployProgress)) (*UpdateApplyResult, error) {
+	statesBefore := s.modStates(game.ID, profileName)
 	result := &UpdateApplyResult{}
 	emit := func(p DeployProgress) {
 		if progress != nil {
@@ -4562,6 +4757,7 @@ func (s *Service) ApplyUpdate(ctx context.Context, game *domain.Game, profileNam
 	newMod.Version = effectiveVersion
 
 	var downloadedFileIDs []string
+	var checksums []fileChecksum
 	for _, file := range filesToDownload {
 		progressFn := func(p DownloadProgress) {
 			if p.TotalBytes > 0 {
@@ -4570,10 +4766,14 @@ func (s *Service) ApplyUpdate(ctx context.Context, game *domain.Game, profileNam
 				emit(dl)
 			}
 		}
-		if _, err := s.DownloadMod(ctx, mod.SourceID, game, newMod, file, progressFn); err != nil {
+		downloadResult, err := s.DownloadMod(ctx, mod.SourceID, game, newMod, file, progressFn)
+		if err != nil {
 			return result, fmt.Errorf("downloading update: %w", err)
 		}
 		downloadedFileIDs = append(downloadedFileIDs, file.ID)
+		if downloadResult.Checksum != "" {
+			checksums = append(checksums, fileChecksum{fileID: file.ID, checksum: downloadResult.Checksum, sha256: downloadResult.SHA256, size: downloadResult.Size})
+		}
 	}
 	emit(DeployProgress{Phase: UpdateDownloadDone, ModName: mod.Name, ModID: mod.ID, SourceID: mod.SourceID})
 
@@ -4627,7 +4827,16 @@ func (s *Service) ApplyUpdate(ctx context.Context, game *domain.Game, profileNam
 	// commit must restore the old IDs' members and remove the uncommitted
 	// new file's sole members, not leave both deployed. See
 	// Installer.ReplaceForUpdate / resolveSharedDirUpdate.
+	// Locally edited config files are captured before Replace overwrites
+	// them (with the copy method, the game directory holds the only copy of
+	// the user's edits) and merged into the new version once it commits.
+	configSnaps, err := s.snapshotConfigEdits(game, profileName, &mod.Mod)
+	if err != nil {
+		return result, fmt.Errorf("checking config files for local edits: %w", err)
+	}
+
 	if err := installer.ReplaceForUpdate(ctx, game, &mod.Mod, newMod, profileName, mod.FileIDs, downloadedFileIDs); err != nil {
+		restoreConfigSnapshots(game, configSnaps)
 		return result, fmt.Errorf("deploying update: %w", err)
 	}
 
@@ -4650,9 +4859,22 @@ func (s *Service) ApplyUpdate(ctx context.Context, game *domain.Game, profileNam
 
 	if err := s.ApplyModUpdate(mod.SourceID, mod.ID, game.ID, profileName, effectiveVersion, downloadedFileIDs); err != nil {
 		_ = installer.ReplaceForUpdate(ctx, game, newMod, &mod.Mod, profileName, downloadedFileIDs, mod.FileIDs) //nolint:errcheck // best-effort recovery on an already-erroring path
+		restoreConfigSnapshots(game, configSnaps)
 		return result, fmt.Errorf("updating database: %w", err)
 	}
 
+	// ApplyModUpdate rewrote the file rows, dropping the old files'
+	// checksums; the new ones are saved against the rows it created.
+	for _, cs := range checksums {
+		if err := s.saveFileChecksums(mod.SourceID, mod.ID, game.ID, profileName, cs); err != nil {
+			msg := fmt.Sprintf("failed to save checksum for file %s: %v", cs.fileID, err)
+			result.Warnings = append(result.Warnings, msg)
+			evt := base
+			evt.Phase, evt.Detail = UpdateWarning, msg
+			emit(evt)
+		}
+	}
+
 	if err := s.SetModLinkMethod(mod.SourceID, mod.ID, game.ID, profileName, linkMethod); err != nil {
 		msg := fmt.Sprintf("Warning: could not update link method: %v", err)
 		result.Notes = append(result.Notes, msg)
@@ -4666,11 +4888,33 @@ func (s *Service) ApplyUpdate(ctx context.Context, game *domain.Game, profileNam
 	if err := pm.UpsertMod(game.ID, profileName, modRef); err != nil {
 		_ = s.RollbackModVersion(mod.SourceID, mod.ID, game.ID, profileName)                                     //nolint:errcheck // best-effort recovery on an already-erroring path
 		_ = installer.ReplaceForUpdate(ctx, game, newMod, &mod.Mod, profileName, downloadedFileIDs, mod.FileIDs) //nolint:errcheck // best-effort recovery on an already-erroring path
+		restoreConfigSnapshots(game, configSnaps)
 		return result, fmt.Errorf("updating profile: %w", err)
 	}
 
 	result.Applied = append(result.Applied, fmt.Sprintf("%s %s → %s", mod.Name, mod.Version, effectiveVersion))
 
+	// The update itself has committed; a failed merge is reported, not
+	// rolled back.
+	merges, mergeErr := mergeConfigSnapshots(s.GetGameCache(game), game, newMod, configSnaps, s.configConflictsDir(game.ID, profileName))
+	for _, m := range merges {
+		result.ConfigMerges = append(result.ConfigMerges, m)
+		evt := base
+		evt.Phase, evt.Detail = UpdateConfigMerged, describeConfigMerge(m)
+		if m.LocalCopy != "" {
+			evt.Phase = UpdateWarning
+			result.Warnings = append(result.Warnings, evt.Detail)
+		}
+		emit(evt)
+	}
+	if mergeErr != nil {
+		msg := fmt.Sprintf("merging local config edits: %v", mergeErr)
+		result.Warnings = append(result.Warnings, msg)
+		evt := base
+		evt.Phase, evt.Detail = UpdateWarning, msg
+		emit(evt)
+	}
+
 	// #197 postsmoke fix: also emit UpdateWarning - appending to
 	// result.Warnings alone is not loud enough, since applyUpdate
 	// (cmd/lmm/update.go) discards ApplyUpdate's result entirely
@@ -4688,6 +4932,15 @@ func (s *Service) ApplyUpdate(ctx context.Context, game *domain.Game, profileNam
 		}
 	}
 
+	var quotaWarning string
+	if result.CacheQuota, quotaWarning = s.enforceCacheQuotaAfter(ctx, game, emit); quotaWarning != "" {
+		result.Warnings = append(result.Warnings, quotaWarning)
+		emit(DeployProgress{Phase: UpdateWarning, Detail: quotaWarning})
+	}
+
+	s.recordOperation(ctx, db.Operation{GameID: game.ID, Profile: profileName, Operation: db.OpUpdate,
+		Mods: changedMods(statesBefore, s.modStates(game.ID, profileName), domain.ModKey(upd.InstalledMod.SourceID, upd.InstalledMod.ID))})
+
 	return result, nil
 }
 
@@ -4706,11 +4959,15 @@ func (s *Service) ApplyUpdate(ctx context.Context, game *domain.Game, profileNam
 // being rolled back TO) - matching doUpdateRollback's own --force check
 // exactly. As with UpdateOptions, there is no before_all/after_all pair:
 // doUpdateRollback never ran one.
+//
+// TargetVersion, when set, rolls back to that version from the mod's version
+// history (see RollbackTarget) instead of PreviousVersion.
 type RollbackOptions struct {
-	Hooks       *ResolvedHooks
-	HookRunner  *HookRunner
-	HookContext HookContext
-	Force       bool
+	Hooks         *ResolvedHooks
+	HookRunner    *HookRunner
+	HookContext   HookContext
+	Force         bool
+	TargetVersion string
 }
 
 // RollbackResult reports the outcome of ApplyRollback.
@@ -4754,7 +5011,7 @@ type RollbackResult struct {
 }
 
 // ApplyRollback rolls the installed mod identified by sourceID/modID back to
-// its PreviousVersion, following cmd/lmm/update.go's pre-extraction
+// its PreviousVersion (or opts.TargetVersion), following cmd/lmm/update.go's pre-extraction
 // doUpdateRollback ordering exactly: GetInstalledMod -> guard checks ->
 // hooks -> installer.ReplaceForUpdate(current -> previous) - the extracted
 // CLI's plain Replace step, now carrying the reversed file-ID transition
@@ -4763,23 +5020,25 @@ type RollbackResult struct {
 // swap, with a compensating reverse-replace on failure) -> SetModLinkMethod
 // -> reload -> ProfileManager.UpsertMod (compensating BOTH the DB swap and
 // the Replace on failure). This is a behavior-preserving extraction - see the
-// task report for the full mapping. Unlike ApplyUpdate, there is no
-// download step at all - the previous version's files already live in the
-// cache (ApplyUpdate itself guarantees this: it never deletes a mod's OLD
-// cache entry - see ApplyUpdate's own doc comment) - so the FIRST thing this
-// function's caller-visible behavior depends on is that cache entry still
-// existing.
+// task report for the full mapping. The target version's files normally
+// still live in the cache (ApplyUpdate never deletes a mod's OLD cache
+// entry - see ApplyUpdate's own doc comment); when a cache GC or a manual
+// delete has removed them, they are downloaded again from the source
+// (UpdateDownloading/UpdateDownloadDone progress) before any hook runs.
+//
+// Guards, checked before anything else: the target must resolve (see
+// RollbackTarget - "no previous version available for rollback" for a mod
+// that has never been updated, or a version never deployed in the profile),
+// and a target missing from the cache must download again ("previous
+// version %s not found in cache and could not be downloaded again: ..."
+// for a plain rollback, "version %s ..." for a TargetVersion one). The
+// CLI's own "mod not found: %s" wrapping of a failed GetInstalledMod is
+// preserved here too.
 //
-// Guards, checked before anything else, in order: mod.PreviousVersion must
-// be non-empty ("no previous version available for rollback" - a mod that
-// has never been updated, or has already been rolled back once, has no
-// second previous version to roll back to), and the previous version must
-// still exist in the game's cache ("previous version %s not found in
-// cache" - defends against a cache entry pruned or manually deleted between
-// the update and the rollback). Both mirror doUpdateRollback's own two
-// precondition checks verbatim, including their exact error text (the CLI's
-// own "mod not found: %s" wrapping of a failed GetInstalledMod is preserved
-// here too, for the same reason).
+// A plain rollback commits with RollbackModVersion (the DB swap); a
+// TargetVersion one with SetModVersions, making the version rolled back
+// from the new PreviousVersion, so a plain rollback afterwards returns to
+// it. Compensation below applies to either.
 //
 // Hook failure semantics mirror doUpdateRollback's own two, independently
 // Force-gated before_each hooks (uninstall.before_each for the CURRENT
@@ -4810,6 +5069,7 @@ type RollbackResult struct {
 // should surface them alongside the error (see RollbackResult's doc
 // comment).
 func (s *Service) ApplyRollback(ctx context.Context, game *domain.Game, profileName, sourceID, modID string, opts RollbackOptions, progress func(DeployProgress)) (*RollbackResult, error) {
+	statesBefore := s.modStates(game.ID, profileName)
 	result := &RollbackResult{}
 	emit := func(p DeployProgress) {
 		if progress != nil {
@@ -4835,20 +5095,43 @@ func (s *Service) ApplyRollback(ctx context.Context, game *domain.Game, profileN
 	// (A missing/unreadable profile falls through - matches ApplyUpdate's
 	// own precedent: a lock cannot exist in an unloadable profile.)
 
-	if mod.PreviousVersion == "" {
-		return result, fmt.Errorf("no previous version available for rollback")
+	target, err := s.RollbackTarget(game, mod, opts.TargetVersion)
+	if err != nil {
+		return result, err
 	}
 
-	if !s.GetGameCache(game).Exists(game.ID, mod.SourceID, mod.ID, mod.PreviousVersion) {
-		return result, fmt.Errorf("previous version %s not found in cache", mod.PreviousVersion)
+	var checksums []fileChecksum
+	if !target.Cached {
+		if checksums, err = s.downloadVersionToCache(ctx, game, mod, target.Version, target.FileIDs, emit); err != nil && !s.legacyCacheEntry(game, mod, target.Version) {
+			what := "version"
+			if opts.TargetVersion == "" {
+				what = "previous version"
+			}
+			return result, fmt.Errorf("%s %s not found in cache and could not be downloaded again: %w", what, target.Version, err)
+		}
 	}
 
 	result.ModName = mod.Name
 	result.FromVersion = mod.Version
-	result.ToVersion = mod.PreviousVersion
+	result.ToVersion = target.Version
 
 	base := DeployProgress{ModName: mod.Name, ModID: mod.ID, SourceID: mod.SourceID}
 
+	// A plain rollback (or one to the previous version by name) swaps; any
+	// other target moves the current version into PreviousVersion.
+	commit := func() error {
+		if target.Previous {
+			return s.RollbackModVersion(mod.SourceID, mod.ID, game.ID, profileName)
+		}
+		return s.SetModVersions(mod.SourceID, mod.ID, game.ID, profileName, target.Version, target.FileIDs, mod.Version, mod.FileIDs)
+	}
+	uncommit := func() error {
+		if target.Previous {
+			return s.RollbackModVersion(mod.SourceID, mod.ID, game.ID, profileName)
+		}
+		return s.SetModVersions(mod.SourceID, mod.ID, game.ID, profileName, mod.Version, mod.FileIDs, mod.PreviousVersion, mod.PreviousFileIDs)
+	}
+
 	hookCtx := opts.HookContext
 	hookCtx.ModID, hookCtx.ModName, hookCtx.ModVersion = mod.ID, mod.Name, mod.Version
 	if err := runHook(ctx, opts.HookRunner, &hookCtx, "uninstall.before_each", opts.Hooks.GetUninstallBeforeEach()); err != nil {
@@ -4869,7 +5152,7 @@ func (s *Service) ApplyRollback(ctx context.Context, game *domain.Game, profileN
 	installer := s.NewInstallerWithLinker(game, s.GetLinker(linkMethod))
 
 	prevMod := mod.Mod
-	prevMod.Version = mod.PreviousVersion
+	prevMod.Version = target.Version
 
 	hookCtx.ModID, hookCtx.ModName, hookCtx.ModVersion = prevMod.ID, prevMod.Name, prevMod.Version
 	if err := runHook(ctx, opts.HookRunner, &hookCtx, "install.before_each", opts.Hooks.GetInstallBeforeEach()); err != nil {
@@ -4888,7 +5171,7 @@ func (s *Service) ApplyRollback(ctx context.Context, game *domain.Game, profileN
 	// shared cache dir) narrows to the restored file's members instead of
 	// deploying the union; see ReplaceForUpdate/resolveSharedDirUpdate. On a
 	// normal different-version rollback this behaves exactly like Replace.
-	if err := installer.ReplaceForUpdate(ctx, game, &mod.Mod, &prevMod, profileName, mod.FileIDs, mod.PreviousFileIDs); err != nil {
+	if err := installer.ReplaceForUpdate(ctx, game, &mod.Mod, &prevMod, profileName, mod.FileIDs, target.FileIDs); err != nil {
 		return result, fmt.Errorf("deploying previous version: %w", err)
 	}
 
@@ -4909,10 +5192,19 @@ func (s *Service) ApplyRollback(ctx context.Context, game *domain.Game, profileN
 		emit(evt)
 	}
 
-	if err := s.RollbackModVersion(mod.SourceID, mod.ID, game.ID, profileName); err != nil {
-		_ = installer.ReplaceForUpdate(ctx, game, &prevMod, &mod.Mod, profileName, mod.PreviousFileIDs, mod.FileIDs) //nolint:errcheck // best-effort recovery on an already-erroring path
+	if err := commit(); err != nil {
+		_ = installer.ReplaceForUpdate(ctx, game, &prevMod, &mod.Mod, profileName, target.FileIDs, mod.FileIDs) //nolint:errcheck // best-effort recovery on an already-erroring path
 		return result, fmt.Errorf("updating database: %w", err)
 	}
+	for _, cs := range checksums {
+		if err := s.saveFileChecksums(mod.SourceID, mod.ID, game.ID, profileName, cs); err != nil {
+			msg := fmt.Sprintf("failed to save checksum for file %s: %v", cs.fileID, err)
+			result.Warnings = append(result.Warnings, msg)
+			evt := base
+			evt.Phase, evt.Detail = UpdateWarning, msg
+			emit(evt)
+		}
+	}
 
 	if err := s.SetModLinkMethod(mod.SourceID, mod.ID, game.ID, profileName, linkMethod); err != nil {
 		msg := fmt.Sprintf("Warning: could not update link method: %v", err)
@@ -4934,8 +5226,8 @@ func (s *Service) ApplyRollback(ctx context.Context, game *domain.Game, profileN
 		Version:  rolledBackMod.Version,
 		FileIDs:  rolledBackMod.FileIDs,
 	}); err != nil {
-		_ = s.RollbackModVersion(mod.SourceID, mod.ID, game.ID, profileName)                                         //nolint:errcheck // best-effort recovery on an already-erroring path
-		_ = installer.ReplaceForUpdate(ctx, game, &prevMod, &mod.Mod, profileName, mod.PreviousFileIDs, mod.FileIDs) //nolint:errcheck // best-effort recovery on an already-erroring path
+		_ = uncommit()                                                                                          //nolint:errcheck // best-effort recovery on an already-erroring path
+		_ = installer.ReplaceForUpdate(ctx, game, &prevMod, &mod.Mod, profileName, target.FileIDs, mod.FileIDs) //nolint:errcheck // best-effort recovery on an already-erroring path
 		return result, fmt.Errorf("updating profile: %w", err)
 	}
 
@@ -4957,6 +5249,9 @@ func (s *Service) ApplyRollback(ctx context.Context, game *domain.Game, profileN
 		}
 	}
 
+	s.recordOperation(ctx, db.Operation{GameID: game.ID, Profile: profileName, Operation: db.OpRollback,
+		Mods: changedMods(statesBefore, s.modStates(game.ID, profileName), domain.ModKey(sourceID, modID))})
+
 	return result, nil
 }
 
@@ -4996,6 +5291,10 @@ type ImportPlan struct {
 	// ProfileImportOptions.Force) produce the authoritative error.
 	Exists bool
 
+	// Seeded counts the cache versions UnpackProfile seeded from a pack
+	// before planning; zero for a plain PlanImport.
+	Seeded int
+
 	// data is the raw import bytes, preserved so ApplyImport can hand them
 	// to ProfileManager.ImportWithOptions unchanged - PlanImport parses via
 	// ParseProfile purely for preview, without persisting anything.
@@ -5021,6 +5320,11 @@ type ImportPlan struct {
 	// version doesn't serve) rather than merely installed over. Private,
 	// like storedFileIDs: pure plan-to-apply plumbing no preview renders.
 	priorVersions map[string]domain.InstalledMod
+
+	// packed maps domain.ModKey keys to the metadata an .lmmpack carried
+	// (UnpackProfile only). ApplyImport installs such a mod straight from
+	// the cache the pack seeded, without asking its source for anything.
+	packed map[string]modpackMod
 }
 
 // PlanImport parses data (an exported profile) and categorizes its mods
@@ -5133,6 +5437,12 @@ type ProfileImportOptions struct {
 	// means proceed unconditionally, matching InstallOptions.ConfirmConflicts'
 	// own "nil = proceed" convention.
 	ConfirmInstall func(toDownload []domain.ModReference) bool
+
+	// Lock, when non-nil, makes the import frozen (--frozen): every mod to
+	// download must be pinned in it, checked before the profile is even
+	// saved, and every download must match its pinned SHA-256 (see
+	// DownloadModFrozen).
+	Lock *domain.ProfileLock
 }
 
 // ProfileImportResult reports the outcome of ApplyImport. As with every other
@@ -5190,17 +5500,30 @@ func (s *Service) ApplyImport(ctx context.Context, game *domain.Game, plan *Impo
 		}
 	}
 
+	toDownload := make([]domain.ModReference, 0, len(plan.NeedsRedownload)+len(plan.Missing))
+	toDownload = append(toDownload, plan.NeedsRedownload...)
+	toDownload = append(toDownload, plan.Missing...)
+
+	if opts.Lock != nil && !opts.NoInstall {
+		if err := CheckFrozen(opts.Lock, game, toDownload); err != nil {
+			return result, err
+		}
+	}
+
 	pm := s.NewProfileManager()
+	statesBefore := s.modStates(game.ID, plan.Profile.Name)
 	profile, err := pm.ImportWithOptions(plan.data, opts.Force)
 	if err != nil {
 		return result, fmt.Errorf("importing profile: %w", err)
 	}
 	result.ProfileName = profile.Name
 	emit(DeployProgress{Phase: ImportSaved, ModName: profile.Name})
-
-	toDownload := make([]domain.ModReference, 0, len(plan.NeedsRedownload)+len(plan.Missing))
-	toDownload = append(toDownload, plan.NeedsRedownload...)
-	toDownload = append(toDownload, plan.Missing...)
+	// Recorded on every return from here on: the profile itself changed
+	// even when nothing gets installed.
+	defer func() {
+		s.recordOperation(ctx, db.Operation{GameID: game.ID, Profile: profile.Name, Operation: db.OpImport,
+			Mods: changedMods(statesBefore, s.modStates(game.ID, profile.Name))})
+	}()
 
 	if len(toDownload) == 0 {
 		return result, nil
@@ -5242,76 +5565,123 @@ func (s *Service) ApplyImport(ctx context.Context, game *domain.Game, plan *Impo
 			emit(evt)
 		}
 
-		mod, err := s.GetMod(ctx, ref.SourceID, game.ID, ref.ModID)
-		if err != nil {
-			fail(fmt.Sprintf("failed to fetch mod: %v", err))
-			continue
-		}
-		base.ModName = mod.Name
+		// A mod an .lmmpack seeded deploys from that cache entry with its
+		// metadata from the pack - no source lookup, so this works offline.
+		key := domain.ModKey(ref.SourceID, ref.ModID)
+		var mod *domain.Mod
+		var downloadedFileIDs []string
+		var checksums []fileChecksum
+		packed, isPacked := plan.packed[key]
+		if isPacked && opts.Lock != nil {
+			// Frozen: the packed version deploys only when the pack's
+			// digests match the lock; otherwise it is downloaded and
+			// checked like any other.
+			packedMod := packed.mod(game.ID)
+			_, isPacked = matchLockPins(opts.Lock, &packedMod, packed.FileIDs, packed.recorded())
+		}
+		if isPacked && s.GetGameCache(game).Exists(game.ID, packed.SourceID, packed.ModID, packed.Version) &&
+			(len(packed.FileIDs) == 0 || s.GetGameCache(game).HasFileIDs(game.ID, packed.SourceID, packed.ModID, packed.Version, packed.FileIDs)) {
+			packedMod := packed.mod(game.ID)
+			mod = &packedMod
+			downloadedFileIDs = packed.FileIDs
+			base.ModName = mod.Name
+		} else {
+			isPacked = false
+			fetched, err := s.GetMod(ctx, ref.SourceID, game.ID, ref.ModID)
+			if err != nil {
+				fail(fmt.Sprintf("failed to fetch mod: %v", err))
+				continue
+			}
+			mod = fetched
+			base.ModName = mod.Name
 
-		files, err := s.GetModFiles(ctx, ref.SourceID, mod)
-		if err != nil {
-			fail(fmt.Sprintf("failed to get files: %v", err))
-			continue
-		}
-		if len(files) == 0 {
-			fail("no downloadable files")
-			continue
-		}
+			files, err := s.GetModFiles(ctx, ref.SourceID, mod)
+			if err != nil {
+				fail(fmt.Sprintf("failed to get files: %v", err))
+				continue
+			}
+			if len(files) == 0 {
+				fail("no downloadable files")
+				continue
+			}
 
-		// Select files to download - use the DB-stored FileIDs for a
-		// redownload, or the imported profile's own FileIDs for a fresh
-		// install (:541-552's rule; see ImportPlan.storedFileIDs' doc
-		// comment for why this can't just be ref.FileIDs uniformly).
-		key := domain.ModKey(ref.SourceID, ref.ModID)
-		var fileIDsToUse []string
-		if stored, ok := plan.storedFileIDs[key]; ok {
-			fileIDsToUse = stored
-		} else if len(ref.FileIDs) > 0 {
-			fileIDsToUse = ref.FileIDs
-		}
-		filesToDownload, _, err := selectVersionedDeployFiles(files, ref.Version, fileIDsToUse, false)
-		if err != nil {
-			fail(err.Error())
-			continue
-		}
+			// Select files to download - use the DB-stored FileIDs for a
+			// redownload, or the imported profile's own FileIDs for a fresh
+			// install (:541-552's rule; see ImportPlan.storedFileIDs' doc
+			// comment for why this can't just be ref.FileIDs uniformly).
+			var fileIDsToUse []string
+			if stored, ok := plan.storedFileIDs[key]; ok {
+				fileIDsToUse = stored
+			} else if len(ref.FileIDs) > 0 {
+				fileIDsToUse = ref.FileIDs
+			}
+			filesToDownload, _, err := selectVersionedDeployFiles(files, ref.Version, fileIDsToUse, false)
+			if err != nil {
+				fail(err.Error())
+				continue
+			}
 
-		mod.Version = domain.EffectiveInstalledVersion(mod.Version, filesToDownload) // #94
-
-		downloadedFileIDs := make([]string, 0, len(filesToDownload))
-		for _, f := range filesToDownload {
-			downloadedFileIDs = append(downloadedFileIDs, f.ID)
-		}
-		// #138: cache-first, by per-file completion marker - the same guard
-		// (and the same two review findings) as ApplyProfileSwitch's install
-		// loop: HasFileIDs, not bare Exists (a version directory can exist
-		// yet be only PARTIALLY populated by a broken-off download run), and
-		// by FILE ID, never FileName (an extracted archive's cache entry
-		// holds member names that match no DownloadableFile). Deploying from
-		// cache matters most for exactly this flow's drift convergence: a
-		// downgrade's archived file may have vanished upstream.
-		if !s.GetGameCache(game).HasFileIDs(game.ID, mod.SourceID, mod.ID, mod.Version, downloadedFileIDs) {
-			downloadFailed := false
-			for _, file := range filesToDownload {
-				progressFn := func(p DownloadProgress) {
-					if p.TotalBytes > 0 {
-						dl := base
-						dl.Phase, dl.Percent = ImportDownloading, p.Percentage
-						emit(dl)
-					}
+			mod.Version = domain.EffectiveInstalledVersion(mod.Version, filesToDownload) // #94
+
+			downloadedFileIDs = make([]string, 0, len(filesToDownload))
+			for _, f := range filesToDownload {
+				downloadedFileIDs = append(downloadedFileIDs, f.ID)
+			}
+			// #138: cache-first, by per-file completion marker - the same guard
+			// (and the same two review findings) as ApplyProfileSwitch's install
+			// loop: HasFileIDs, not bare Exists (a version directory can exist
+			// yet be only PARTIALLY populated by a broken-off download run), and
+			// by FILE ID, never FileName (an extracted archive's cache entry
+			// holds member names that match no DownloadableFile). Deploying from
+			// cache matters most for exactly this flow's drift convergence: a
+			// downgrade's archived file may have vanished upstream.
+			cached := s.GetGameCache(game).HasFileIDs(game.ID, mod.SourceID, mod.ID, mod.Version, downloadedFileIDs)
+			if cached && opts.Lock != nil {
+				// Frozen: a cached version counts only when its recorded
+				// digests match the lock - see FrozenCachedFiles.
+				recorded, ok, err := s.FrozenCachedFiles(opts.Lock, game, mod, downloadedFileIDs)
+				if err != nil {
+					fail(fmt.Sprintf("checking cached files against the lockfile: %v", err))
+					continue
 				}
-				if _, err := s.DownloadMod(ctx, ref.SourceID, game, mod, file, progressFn); err != nil {
-					fail(fmt.Sprintf("download failed: %v", err))
-					downloadFailed = true
-					break
+				cached = ok
+				for _, r := range recorded {
+					checksums = append(checksums, fileChecksum{fileID: r.FileID, checksum: r.Checksum, sha256: r.SHA256, size: r.Size})
 				}
 			}
-			doneEvt := base
-			doneEvt.Phase = ImportDownloadDone
-			emit(doneEvt)
+			if !cached {
+				downloadFailed := false
+				for _, file := range filesToDownload {
+					progressFn := func(p DownloadProgress) {
+						if p.TotalBytes > 0 {
+							dl := base
+							dl.Phase, dl.Percent = ImportDownloading, p.Percentage
+							emit(dl)
+						}
+					}
+					var downloadResult *DownloadModResult
+					var err error
+					if opts.Lock != nil {
+						downloadResult, err = s.DownloadModFrozen(ctx, opts.Lock, ref.SourceID, game, mod, file, progressFn)
+					} else {
+						downloadResult, err = s.DownloadMod(ctx, ref.SourceID, game, mod, file, progressFn)
+					}
+					if err != nil {
+						fail(fmt.Sprintf("download failed: %v", err))
+						downloadFailed = true
+						break
+					}
+					if downloadResult.Checksum != "" {
+						checksums = append(checksums, fileChecksum{fileID: file.ID, checksum: downloadResult.Checksum, sha256: downloadResult.SHA256, size: downloadResult.Size})
+					}
+				}
+				doneEvt := base
+				doneEvt.Phase = ImportDownloadDone
+				emit(doneEvt)
 
-			if downloadFailed {
-				continue
+				if downloadFailed {
+					continue
+				}
 			}
 		}
 
@@ -5348,6 +5718,23 @@ func (s *Service) ApplyImport(ctx context.Context, game *domain.Game, plan *Impo
 			fail(fmt.Sprintf("save failed: %v", err))
 			continue
 		}
+		for _, cs := range checksums {
+			if err := s.saveFileChecksums(mod.SourceID, mod.ID, game.ID, profile.Name, cs); err != nil {
+				msg := fmt.Sprintf("Warning: could not save checksum for file %s: %v", cs.fileID, err)
+				result.Notes = append(result.Notes, msg)
+				evt := base
+				evt.Phase, evt.Detail = ImportNote, msg
+				emit(evt)
+			}
+		}
+		if isPacked {
+			for _, msg := range s.packedChecksums(packed, game.ID, profile.Name) {
+				result.Notes = append(result.Notes, msg)
+				evt := base
+				evt.Phase, evt.Detail = ImportNote, msg
+				emit(evt)
+			}
+		}
 
 		modRef := domain.ModReference{SourceID: mod.SourceID, ModID: mod.ID, Version: mod.Version, FileIDs: downloadedFileIDs}
 		if err := pm.UpsertMod(game.ID, profile.Name, modRef); err != nil {
@@ -21,12 +21,23 @@ import (
 
 	"github.com/DonovanMods/linux-mod-manager/internal/core"
 	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	_ "modernc.org/sqlite"
 )
 
+// markCachedFileIDs writes the completion marker a finished download leaves
+// for each of fileIDs, so the cached version reads as complete.
+func markCachedFileIDs(t *testing.T, svc *core.Service, game *domain.Game, sourceID, modID, version string, fileIDs []string) {
+	t.Helper()
+	versionDir := svc.GetGameCache(game).ModPath(game.ID, sourceID, modID, version)
+	for _, id := range fileIDs {
+		require.NoError(t, cache.MarkFileComplete(versionDir, id))
+	}
+}
+
 // seedRollbackReadyMod prepares an installed mod already updated once,
 // ready to be passed to ApplyRollback: an OLD version is installed and
 // cached first, then advanced to a NEW version via the same
@@ -46,6 +57,8 @@ func seedRollbackReadyMod(t *testing.T, svc *core.Service, game *domain.Game, so
 	for path, content := range newFiles {
 		require.NoError(t, gameCache.Store(game.ID, sourceID, modID, newVersion, path, content))
 	}
+	markCachedFileIDs(t, svc, game, sourceID, modID, oldVersion, oldFileIDs)
+	markCachedFileIDs(t, svc, game, sourceID, modID, newVersion, newFileIDs)
 
 	oldMod := domain.Mod{ID: modID, SourceID: sourceID, Name: name, Version: oldVersion, GameID: game.ID}
 	im := &domain.InstalledMod{
@@ -146,8 +159,8 @@ func TestApplyRollbackNoPreviousVersion(t *testing.T) {
 
 // TestApplyRollbackMissingCache covers the second guard: PreviousVersion is
 // set, but its cache entry has been removed (pruned, or manually deleted)
-// since the update - ApplyRollback must fail with the exact pre-extraction
-// error text, again before touching hooks, Replace, or the DB.
+// since the update, and the source cannot serve it again - ApplyRollback
+// must fail before touching hooks, Replace, or the DB.
 func TestApplyRollbackMissingCache(t *testing.T) {
 	svc := newFlowsTestService(t)
 	gameDir := t.TempDir()
@@ -162,7 +175,7 @@ func TestApplyRollbackMissingCache(t *testing.T) {
 
 	result, err := svc.ApplyRollback(context.Background(), game, "default", mod.SourceID, mod.ID, core.RollbackOptions{}, nil)
 	require.Error(t, err)
-	assert.Equal(t, "previous version 1.0 not found in cache", err.Error())
+	assert.Contains(t, err.Error(), "previous version 1.0 not found in cache and could not be downloaded again: ")
 	require.NotNil(t, result)
 	assert.Empty(t, result.ModName, "no identity fields should be populated before this guard")
 }
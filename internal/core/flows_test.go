@@ -1094,6 +1094,40 @@ func TestService_DeployProfile_LinkMethodOverrideHonored(t *testing.T) {
 	assert.Equal(t, domain.LinkCopy, mod.LinkMethod, "SetModLinkMethod must record the override")
 }
 
+// TestService_DeployProfile_ReflinkReportsCopyFallback pins that a reflink
+// deploy always yields independent regular files, and that every file the
+// filesystem could not clone is counted and warned about rather than failing.
+func TestService_DeployProfile_ReflinkReportsCopyFallback(t *testing.T) {
+	svc := newFlowsTestService(t)
+	gameDir := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: gameDir, LinkMethod: domain.LinkReflink, LinkMethodExplicit: true}
+
+	seedInstalledMod(t, svc, game, "src", "1", "1.0", true, map[string][]byte{"plugin.esp": []byte("data"), "textures.pak": []byte("pak")})
+	seedProfileWithMod(t, svc, "g1", "default", "src", "1", "1.0")
+
+	result, err := svc.DeployProfile(context.Background(), game, "default", core.DeployOptions{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Deployed)
+
+	info, err := os.Lstat(filepath.Join(gameDir, "plugin.esp"))
+	require.NoError(t, err)
+	assert.True(t, info.Mode().IsRegular(), "a reflinked file is a regular file, like a copy")
+
+	// The test tmpdir may or may not support FICLONE; either way the result
+	// must be consistent with what happened.
+	if result.ReflinkFallbacks > 0 {
+		assert.Equal(t, 2, result.ReflinkFallbacks)
+		require.Len(t, result.Warnings, 1)
+		assert.Contains(t, result.Warnings[0], "copied 2 file(s) instead")
+	} else {
+		assert.Empty(t, result.Warnings)
+	}
+
+	mod, err := svc.GetInstalledMod("src", "1", "g1", "default")
+	require.NoError(t, err)
+	assert.Equal(t, domain.LinkReflink, mod.LinkMethod)
+}
+
 // setProfileLinkMethod stamps an explicit link_method onto an existing profile
 // file, as if the user had set it in the profile YAML by hand.
 func setProfileLinkMethod(t *testing.T, svc *core.Service, gameID, profileName string, method domain.LinkMethod) {
@@ -0,0 +1,345 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/db"
+)
+
+// ErrNothingToUndo is returned by Undo when no operation can be undone.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// undoOfKey carries, through the inverse flow Undo runs, the ID of the
+// history entry being undone, so the entry that flow records links back to
+// it.
+type undoOfKey struct{}
+
+// modStates snapshots the installed version and enabled state of every mod
+// installed in profileName, keyed by domain.ModKey. A read failure yields an
+// empty snapshot: history is best-effort and never fails a flow.
+func (s *Service) modStates(gameID, profileName string) map[string]db.OperationMod {
+	states := make(map[string]db.OperationMod)
+	mods, err := s.db.GetInstalledMods(gameID, profileName)
+	if err != nil {
+		return states
+	}
+	for _, m := range mods {
+		states[domain.ModKey(m.SourceID, m.ID)] = db.OperationMod{
+			SourceID: m.SourceID, ModID: m.ID, Name: m.Name,
+			AfterVersion: m.Version, AfterEnabled: m.Enabled,
+		}
+	}
+	return states
+}
+
+// changedMods lists the mods whose version or enabled state differs between
+// two modStates snapshots, plus every key in always (changed or not), in
+// key order.
+func changedMods(before, after map[string]db.OperationMod, always ...string) []db.OperationMod {
+	keys := make(map[string]bool)
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+
+	var changed []db.OperationMod
+	for _, k := range slices.Sorted(maps.Keys(keys)) {
+		b, hadBefore := before[k]
+		a, hasAfter := after[k]
+		if hadBefore && hasAfter && b.AfterVersion == a.AfterVersion && b.AfterEnabled == a.AfterEnabled && !slices.Contains(always, k) {
+			continue
+		}
+		m := a
+		if !hasAfter {
+			m = db.OperationMod{SourceID: b.SourceID, ModID: b.ModID, Name: b.Name}
+		}
+		m.BeforeVersion, m.BeforeEnabled = b.AfterVersion, b.AfterEnabled
+		changed = append(changed, m)
+	}
+	return changed
+}
+
+// loadOrder returns the mod keys of profileName in load order, or nil if the
+// profile cannot be read.
+func (s *Service) loadOrder(gameID, profileName string) []string {
+	profile, err := s.NewProfileManager().Get(gameID, profileName)
+	if err != nil {
+		return nil
+	}
+	keys := make([]string, len(profile.Mods))
+	for i, ref := range profile.Mods {
+		keys[i] = domain.ModKey(ref.SourceID, ref.ModID)
+	}
+	return keys
+}
+
+// purgedKeys returns the keys of the mods in mods that a purge left
+// undeployed or uninstalled, skipping the ones it could not purge.
+func (s *Service) purgedKeys(gameID, profileName string, mods []domain.InstalledMod) []string {
+	var keys []string
+	for _, m := range mods {
+		current, err := s.db.GetInstalledMod(m.SourceID, m.ID, gameID, profileName)
+		if err != nil || !current.Deployed {
+			keys = append(keys, domain.ModKey(m.SourceID, m.ID))
+		}
+	}
+	return keys
+}
+
+// recordOperation appends op to the operation history, linking it to the
+// entry being undone when ctx comes from Undo. Best-effort: a flow that
+// already succeeded is never failed by its history write.
+func (s *Service) recordOperation(ctx context.Context, op db.Operation) {
+	if id, ok := ctx.Value(undoOfKey{}).(int64); ok {
+		op.UndoOf = id
+	}
+	_, _ = s.db.RecordOperation(&op) //nolint:errcheck // best-effort, see doc comment
+}
+
+// History returns the operation history for gameID, or for every game when
+// gameID is empty, newest first. A positive limit caps the entries returned.
+func (s *Service) History(gameID string, limit int) ([]db.Operation, error) {
+	return s.db.ListOperations(gameID, limit)
+}
+
+// GetOperation returns the history entry with the given ID.
+func (s *Service) GetOperation(id int64) (*db.Operation, error) {
+	return s.db.GetOperation(id)
+}
+
+// Reversible reports whether Undo can revert op, returning the reason when
+// it cannot. Only the operation's kind and recorded state are considered;
+// Undo additionally checks that nothing has changed since.
+func Reversible(op *db.Operation) error {
+	if op.UndoneBy != 0 {
+		return fmt.Errorf("already undone by #%d", op.UndoneBy)
+	}
+	switch op.Operation {
+	case db.OpEnable, db.OpDisable, db.OpReorder, db.OpSwitch:
+		return nil
+	case db.OpInstall:
+		for _, m := range op.Mods {
+			if m.BeforeVersion != "" {
+				return fmt.Errorf("it replaced %s %s; use 'lmm update rollback' instead", m.Name, m.BeforeVersion)
+			}
+		}
+		return nil
+	case db.OpUpdate, db.OpRollback:
+		if len(op.Mods) != 1 {
+			return fmt.Errorf("it changed %d mods", len(op.Mods))
+		}
+		return nil
+	case db.OpUninstall:
+		return errors.New("uninstalled mods cannot be restored; reinstall them")
+	case db.OpImport:
+		return errors.New("imports cannot be undone")
+	case db.OpDeploy:
+		return errors.New("deploys cannot be undone; run 'lmm purge' instead")
+	case db.OpPurge:
+		for _, m := range op.Mods {
+			if m.AfterVersion == "" {
+				return errors.New("purged mods were uninstalled; reinstall them")
+			}
+		}
+		return errors.New("purges cannot be undone; run 'lmm deploy' instead")
+	default:
+		return fmt.Errorf("unknown operation %q", op.Operation)
+	}
+}
+
+// UndoResult reports the outcome of Undo. Warnings and Notes follow the
+// display contract of the flows Undo runs (see UninstallResult).
+type UndoResult struct {
+	Undone   db.Operation // the entry that was reverted
+	Warnings []string
+	Notes    []string
+}
+
+// LatestUndoable returns the newest history entry for gameID (every game
+// when empty) that Undo can revert: not itself an undo, not undone already,
+// and of a reversible kind.
+func (s *Service) LatestUndoable(gameID string) (*db.Operation, error) {
+	ops, err := s.db.ListOperations(gameID, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ops {
+		if ops[i].UndoOf == 0 && Reversible(&ops[i]) == nil {
+			return &ops[i], nil
+		}
+	}
+	return nil, ErrNothingToUndo
+}
+
+// Undo reverts history entry op by planning its inverse through the
+// existing flows: disabling what an enable turned on, uninstalling what an
+// install added, rolling an update back, restoring the previous load order,
+// or switching back to the previous profile. It refuses when the mods no
+// longer match what op left behind, so a later change is never clobbered.
+// The inverse flow records its own history entry, linked to op. game must
+// be the game op was recorded for.
+func (s *Service) Undo(ctx context.Context, game *domain.Game, op *db.Operation) (*UndoResult, error) {
+	if game.ID != op.GameID {
+		return nil, fmt.Errorf("operation #%d is for game %s, not %s", op.ID, op.GameID, game.ID)
+	}
+	if err := Reversible(op); err != nil {
+		return nil, fmt.Errorf("cannot undo #%d %s: %w", op.ID, op.Operation, err)
+	}
+	if err := s.checkUndoState(op); err != nil {
+		return nil, fmt.Errorf("cannot undo #%d %s: %w", op.ID, op.Operation, err)
+	}
+
+	ctx = context.WithValue(ctx, undoOfKey{}, op.ID)
+	result := &UndoResult{Undone: *op}
+	switch op.Operation {
+	case db.OpEnable:
+		for _, m := range op.Mods {
+			r, err := s.DisableMod(ctx, game, op.Profile, m.SourceID, m.ModID)
+			if r != nil {
+				result.Warnings, result.Notes = append(result.Warnings, r.Warnings...), append(result.Notes, r.Notes...)
+			}
+			if err != nil {
+				return result, err
+			}
+		}
+	case db.OpDisable:
+		for _, m := range op.Mods {
+			r, err := s.EnableMod(ctx, game, op.Profile, m.SourceID, m.ModID)
+			if r != nil {
+				result.Warnings, result.Notes = append(result.Warnings, r.Warnings...), append(result.Notes, r.Notes...)
+			}
+			if err != nil {
+				return result, err
+			}
+		}
+	case db.OpInstall:
+		for _, m := range op.Mods {
+			r, err := s.UninstallMod(ctx, game, op.Profile, m.SourceID, m.ModID, UninstallOptions{KeepCache: true})
+			if r != nil {
+				result.Warnings, result.Notes = append(result.Warnings, r.Warnings...), append(result.Notes, r.Notes...)
+			}
+			if err != nil {
+				return result, err
+			}
+		}
+	case db.OpUpdate, db.OpRollback:
+		m := op.Mods[0]
+		r, err := s.ApplyRollback(ctx, game, op.Profile, m.SourceID, m.ModID, RollbackOptions{}, nil)
+		if r != nil {
+			result.Warnings, result.Notes = append(result.Warnings, r.Warnings...), append(result.Notes, r.Notes...)
+		}
+		if err != nil {
+			return result, err
+		}
+	case db.OpReorder:
+		refs, err := s.refsInOrder(op.GameID, op.Profile, op.OrderBefore)
+		if err != nil {
+			return result, err
+		}
+		if err := s.reorderProfileMods(ctx, op.GameID, op.Profile, refs); err != nil {
+			return result, err
+		}
+	case db.OpSwitch:
+		plan, err := s.PlanProfileSwitch(ctx, game, op.FromProfile)
+		if err != nil {
+			return result, err
+		}
+		r, err := s.ApplyProfileSwitch(ctx, game, plan, nil)
+		if r != nil {
+			result.Warnings, result.Notes = append(result.Warnings, r.Warnings...), append(result.Notes, r.Notes...)
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// checkUndoState verifies that the game still looks the way op left it.
+func (s *Service) checkUndoState(op *db.Operation) error {
+	switch op.Operation {
+	case db.OpSwitch:
+		active, err := s.NewProfileManager().GetDefault(op.GameID)
+		if err != nil {
+			return fmt.Errorf("resolving active profile: %w", err)
+		}
+		if active.Name != op.Profile {
+			return fmt.Errorf("the active profile is now %s, not %s", active.Name, op.Profile)
+		}
+		return nil
+	case db.OpReorder:
+		if !slices.Equal(s.loadOrder(op.GameID, op.Profile), op.OrderAfter) {
+			return errors.New("the load order has changed since")
+		}
+		return nil
+	}
+
+	current := s.modStates(op.GameID, op.Profile)
+	var drifted []string
+	for _, m := range op.Mods {
+		key := domain.ModKey(m.SourceID, m.ModID)
+		cur, installed := current[key]
+		switch {
+		case m.AfterVersion == "" && installed:
+			drifted = append(drifted, key+" has been reinstalled")
+		case m.AfterVersion != "" && !installed:
+			drifted = append(drifted, key+" is no longer installed")
+		case installed && cur.AfterVersion != m.AfterVersion:
+			drifted = append(drifted, fmt.Sprintf("%s is now at %s, not %s", key, cur.AfterVersion, m.AfterVersion))
+		case installed && cur.AfterEnabled != m.AfterEnabled:
+			drifted = append(drifted, fmt.Sprintf("%s has been %s since", key, enabledWord(cur.AfterEnabled)))
+		}
+		if (op.Operation == db.OpUpdate || op.Operation == db.OpRollback) && installed {
+			mod, err := s.GetInstalledMod(m.SourceID, m.ModID, op.GameID, op.Profile)
+			if err == nil && mod.PreviousVersion != m.BeforeVersion {
+				drifted = append(drifted, fmt.Sprintf("%s's rollback target is %s, not %s", key, mod.PreviousVersion, m.BeforeVersion))
+			}
+		}
+	}
+	if len(drifted) > 0 {
+		return errors.New(strings.Join(drifted, "; "))
+	}
+	return nil
+}
+
+func enabledWord(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+// refsInOrder returns profileName's mod references arranged in the order of
+// keys; mods keys does not name keep their relative order at the end.
+func (s *Service) refsInOrder(gameID, profileName string, keys []string) ([]domain.ModReference, error) {
+	profile, err := s.NewProfileManager().Get(gameID, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("loading profile: %w", err)
+	}
+	rank := make(map[string]int, len(keys))
+	for i, k := range keys {
+		rank[k] = i
+	}
+	refs := slices.Clone(profile.Mods)
+	sort.SliceStable(refs, func(i, j int) bool {
+		ri, ok := rank[domain.ModKey(refs[i].SourceID, refs[i].ModID)]
+		if !ok {
+			ri = len(keys)
+		}
+		rj, ok := rank[domain.ModKey(refs[j].SourceID, refs[j].ModID)]
+		if !ok {
+			rj = len(keys)
+		}
+		return ri < rj
+	})
+	return refs, nil
+}
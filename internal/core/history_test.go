@@ -0,0 +1,204 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUndo_EnableIsRevertedAndLinked(t *testing.T) {
+	ctx := context.Background()
+	svc := newFlowsTestService(t)
+	gameDir := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: gameDir, LinkMethod: domain.LinkSymlink}
+	seedInstalledMod(t, svc, game, "src", "1", "1.0", false, map[string][]byte{"plugin.esp": []byte("data")})
+	seedInstalledMod(t, svc, game, "src", "2", "2.0", true, map[string][]byte{"other.esp": []byte("data")})
+
+	_, err := svc.EnableMod(ctx, game, "default", "src", "1")
+	require.NoError(t, err)
+	_, err = svc.UninstallMod(ctx, game, "default", "src", "2", core.UninstallOptions{})
+	require.NoError(t, err)
+
+	ops, err := svc.History("g1", 0)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	assert.Equal(t, db.OpUninstall, ops[0].Operation)
+	assert.Equal(t, db.OperationMod{SourceID: "src", ModID: "2", Name: "Test Mod", BeforeVersion: "2.0", BeforeEnabled: true}, ops[0].Mods[0])
+	assert.Equal(t, db.OpEnable, ops[1].Operation)
+
+	// The uninstall cannot be undone, so the enable before it is next.
+	op, err := svc.LatestUndoable("g1")
+	require.NoError(t, err)
+	assert.Equal(t, ops[1].ID, op.ID)
+
+	_, err = svc.Undo(ctx, game, op)
+	require.NoError(t, err)
+	mod, err := svc.GetInstalledMod("src", "1", "g1", "default")
+	require.NoError(t, err)
+	assert.False(t, mod.Enabled)
+	_, err = os.Lstat(filepath.Join(gameDir, "plugin.esp"))
+	assert.True(t, os.IsNotExist(err), "undoing an enable undeploys the mod")
+
+	ops, err = svc.History("g1", 0)
+	require.NoError(t, err)
+	require.Len(t, ops, 3)
+	assert.Equal(t, db.OpDisable, ops[0].Operation)
+	assert.Equal(t, op.ID, ops[0].UndoOf)
+	assert.Equal(t, ops[0].ID, ops[2].UndoneBy)
+
+	_, err = svc.LatestUndoable("g1")
+	assert.ErrorIs(t, err, core.ErrNothingToUndo, "an undo is never picked, and the enable is already undone")
+	_, err = svc.Undo(ctx, game, &ops[2])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already undone by")
+}
+
+func TestUndo_RefusesWhenStateChangedSince(t *testing.T) {
+	ctx := context.Background()
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkSymlink}
+	seedInstalledMod(t, svc, game, "src", "1", "1.0", false, map[string][]byte{"plugin.esp": []byte("data")})
+
+	_, err := svc.EnableMod(ctx, game, "default", "src", "1")
+	require.NoError(t, err)
+	ops, err := svc.History("g1", 0)
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	enable := ops[0]
+
+	_, err = svc.DisableMod(ctx, game, "default", "src", "1")
+	require.NoError(t, err)
+	_, err = svc.EnableMod(ctx, game, "default", "src", "1")
+	require.NoError(t, err)
+	require.NoError(t, svc.SetModEnabled("src", "1", "g1", "default", false))
+
+	_, err = svc.Undo(ctx, game, &enable)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "src:1 has been disabled since")
+}
+
+func TestUndo_ReorderRestoresLoadOrder(t *testing.T) {
+	ctx := context.Background()
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir()}
+	for _, id := range []string{"a", "b", "c"} {
+		seedProfileWithMod(t, svc, "g1", "default", "src", id, "1.0")
+	}
+	refs := func() []string {
+		profile, err := svc.NewProfileManager().Get("g1", "default")
+		require.NoError(t, err)
+		var ids []string
+		for _, r := range profile.Mods {
+			ids = append(ids, r.ModID)
+		}
+		return ids
+	}
+
+	require.NoError(t, svc.ReorderProfileMods("g1", "default", []domain.ModReference{
+		{SourceID: "src", ModID: "c", Version: "1.0"}, {SourceID: "src", ModID: "a", Version: "1.0"}, {SourceID: "src", ModID: "b", Version: "1.0"},
+	}))
+	op, err := svc.LatestUndoable("g1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"src:a", "src:b", "src:c"}, op.OrderBefore)
+	assert.Equal(t, []string{"src:c", "src:a", "src:b"}, op.OrderAfter)
+
+	_, err = svc.Undo(ctx, game, op)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, refs())
+
+	// Undoing the undo is a redo.
+	ops, err := svc.History("g1", 1)
+	require.NoError(t, err)
+	_, err = svc.Undo(ctx, game, &ops[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "a", "b"}, refs())
+
+	require.NoError(t, svc.ReorderProfileMods("g1", "default", []domain.ModReference{
+		{SourceID: "src", ModID: "b", Version: "1.0"}, {SourceID: "src", ModID: "c", Version: "1.0"}, {SourceID: "src", ModID: "a", Version: "1.0"},
+	}))
+	op, err = svc.GetOperation(op.ID)
+	require.NoError(t, err)
+	_, err = svc.Undo(ctx, game, op)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already undone")
+	_, err = svc.Undo(ctx, game, &ops[0])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "the load order has changed since")
+}
+
+func TestHistory_DeployIsRecordedButNotUndoable(t *testing.T) {
+	ctx := context.Background()
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkSymlink}
+	seedInstalledMod(t, svc, game, "src", "1", "1.0", true, map[string][]byte{"plugin.esp": []byte("data")})
+	seedProfileWithMod(t, svc, "g1", "default", "src", "1", "1.0")
+
+	result, err := svc.DeployProfile(ctx, game, "default", core.DeployOptions{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Deployed)
+
+	ops, err := svc.History("g1", 0)
+	require.NoError(t, err)
+	require.Len(t, ops, 1)
+	assert.Equal(t, db.OpDeploy, ops[0].Operation)
+	assert.Equal(t, []db.OperationMod{{SourceID: "src", ModID: "1", Name: "Test Mod", BeforeVersion: "1.0", AfterVersion: "1.0", BeforeEnabled: true, AfterEnabled: true}}, ops[0].Mods)
+
+	require.Error(t, core.Reversible(&ops[0]))
+	_, err = svc.LatestUndoable("g1")
+	assert.ErrorIs(t, err, core.ErrNothingToUndo)
+}
+
+func TestHistory_PurgeIsRecordedButNotUndoable(t *testing.T) {
+	ctx := context.Background()
+	svc := newFlowsTestService(t)
+	gameDir := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: gameDir, LinkMethod: domain.LinkSymlink}
+	seedInstalledMod(t, svc, game, "src", "1", "1.0", true, map[string][]byte{"plugin.esp": []byte("data")})
+	seedInstalledMod(t, svc, game, "src", "2", "2.0", true, map[string][]byte{"other.esp": []byte("data")})
+	seedProfileWithMod(t, svc, "g1", "default", "src", "1", "1.0")
+	seedProfileWithMod(t, svc, "g1", "default", "src", "2", "2.0")
+	_, err := svc.DeployProfile(ctx, game, "default", core.DeployOptions{}, nil)
+	require.NoError(t, err)
+
+	one, err := svc.GetInstalledMod("src", "1", "g1", "default")
+	require.NoError(t, err)
+	result, err := svc.PurgeProfile(ctx, game, "default", []domain.InstalledMod{*one}, core.PurgeOptions{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Purged)
+	_, err = os.Lstat(filepath.Join(gameDir, "plugin.esp"))
+	require.True(t, os.IsNotExist(err))
+
+	ops, err := svc.History("g1", 0)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	assert.Equal(t, db.OpPurge, ops[0].Operation)
+	assert.Equal(t, []db.OperationMod{{SourceID: "src", ModID: "1", Name: "Test Mod", BeforeVersion: "1.0", AfterVersion: "1.0", BeforeEnabled: true, AfterEnabled: true}}, ops[0].Mods,
+		"only the purged mod is listed")
+	err = core.Reversible(&ops[0])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lmm deploy")
+
+	two, err := svc.GetInstalledMod("src", "2", "g1", "default")
+	require.NoError(t, err)
+	_, err = svc.PurgeProfile(ctx, game, "default", []domain.InstalledMod{*two}, core.PurgeOptions{Uninstall: true}, nil)
+	require.NoError(t, err)
+	ops, err = svc.History("g1", 0)
+	require.NoError(t, err)
+	require.Len(t, ops, 3)
+	assert.Equal(t, db.OpPurge, ops[0].Operation)
+	assert.Equal(t, []db.OperationMod{{SourceID: "src", ModID: "2", Name: "Test Mod", BeforeVersion: "2.0", BeforeEnabled: true}}, ops[0].Mods)
+	err = core.Reversible(&ops[0])
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reinstall")
+
+	_, err = svc.LatestUndoable("g1")
+	assert.ErrorIs(t, err, core.ErrNothingToUndo)
+}
@@ -188,7 +188,7 @@ func (i *Importer) Import(ctx context.Context, archivePath string, game *domain.
 			}
 			fileCount = 1
 		}
-		if err := commitStagedCache(cachePath, stagePath); err != nil {
+		if err := commitStagedCache(i.cache, cachePath, stagePath); err != nil {
 			return nil, err
 		}
 		retainedFileID = filename
@@ -0,0 +1,325 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+)
+
+// IniDrift is one patched INI key whose on-disk state no longer matches the
+// profile's ini_patches - the game (or the user) changed it behind lmm's back.
+type IniDrift struct {
+	File, Section, Key string
+	Want               *string // the patched value; nil when the patch deletes the key
+	Actual             string  // current value (meaningful only when Present)
+	Present            bool    // whether the key exists in the file at all
+}
+
+// ApplyIniPatches merges profile.IniPatches into the INI files under the
+// game install directory. The merge is idempotent: a file already matching
+// every patch is not rewritten at all, and everything a patch doesn't name -
+// other keys, comments, blank lines, key spelling, line endings - is kept
+// as-is. Section and key names match case-insensitively, as the games that
+// read these files do. Files are processed in sorted order; paths escaping
+// the install directory are rejected like ApplyProfileOverrides'.
+func ApplyIniPatches(game *domain.Game, profile *domain.Profile) error {
+	if len(profile.IniPatches) == 0 {
+		return nil
+	}
+	base, err := installBase(game)
+	if err != nil {
+		return err
+	}
+	for _, relPath := range sortedKeys(profile.IniPatches) {
+		dest, err := confinedInstallPath(base, relPath, "ini patch")
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(dest)
+		exists := err == nil
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("reading %s: %w", relPath, err)
+		}
+		patched := patchINI(data, profile.IniPatches[relPath])
+		if bytes.Equal(patched, data) {
+			continue
+		}
+		mode := os.FileMode(0644)
+		if exists {
+			if info, err := os.Stat(dest); err == nil {
+				mode = info.Mode().Perm()
+			}
+		} else if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return fmt.Errorf("creating ini patch dir for %s: %w", relPath, err)
+		}
+		if err := os.WriteFile(dest, patched, mode); err != nil {
+			return fmt.Errorf("writing %s: %w", relPath, err)
+		}
+	}
+	return nil
+}
+
+// CheckIniPatches reports every patched key whose current state differs
+// from profile.IniPatches: a set key that is missing or holds another
+// value, or a deleted key that has reappeared. A missing file counts as
+// every set key missing. Results are sorted by file, section, then key.
+func CheckIniPatches(game *domain.Game, profile *domain.Profile) ([]IniDrift, error) {
+	if len(profile.IniPatches) == 0 {
+		return nil, nil
+	}
+	base, err := installBase(game)
+	if err != nil {
+		return nil, err
+	}
+	var drift []IniDrift
+	for _, relPath := range sortedKeys(profile.IniPatches) {
+		dest, err := confinedInstallPath(base, relPath, "ini patch")
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(dest)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("reading %s: %w", relPath, err)
+		}
+		lines, _, _ := splitINILines(data)
+		parsed := parseINILines(lines)
+		sections := profile.IniPatches[relPath]
+		for _, section := range sortedKeys(sections) {
+			for _, key := range sortedKeys(sections[section]) {
+				want := sections[section][key]
+				actual, present := lookupINIKey(lines, parsed, section, key)
+				ok := (want == nil && !present) || (want != nil && present && actual == *want)
+				if !ok {
+					drift = append(drift, IniDrift{File: relPath, Section: section, Key: key, Want: want, Actual: actual, Present: present})
+				}
+			}
+		}
+	}
+	return drift, nil
+}
+
+// iniLine is the parsed shape of one INI line: the section it belongs to
+// (as written in its header) and, for a key=value line, the key and the
+// index of its '='.
+type iniLine struct {
+	section string
+	header  bool
+	key     string
+	eq      int // index of '=' in the raw line; -1 when not a key line
+}
+
+// splitINILines splits data into lines, reporting the newline style in use
+// (CRLF if any line uses it) and whether the last line was terminated.
+func splitINILines(data []byte) (lines []string, newline string, trailing bool) {
+	newline = "\n"
+	if bytes.Contains(data, []byte("\r\n")) {
+		newline = "\r\n"
+	}
+	if len(data) == 0 {
+		return nil, newline, true
+	}
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	trailing = strings.HasSuffix(text, "\n")
+	text = strings.TrimSuffix(text, "\n")
+	return strings.Split(text, "\n"), newline, trailing
+}
+
+func parseINILines(lines []string) []iniLine {
+	parsed := make([]iniLine, len(lines))
+	section := ""
+	for i, raw := range lines {
+		t := strings.TrimSpace(raw)
+		switch {
+		case strings.HasPrefix(t, "[") && strings.Contains(t, "]"):
+			section = strings.TrimSpace(t[1:strings.Index(t, "]")])
+			parsed[i] = iniLine{section: section, header: true, eq: -1}
+		case t == "" || strings.HasPrefix(t, ";") || strings.HasPrefix(t, "#"):
+			parsed[i] = iniLine{section: section, eq: -1}
+		default:
+			eq := strings.Index(raw, "=")
+			if eq < 0 {
+				parsed[i] = iniLine{section: section, eq: -1}
+				continue
+			}
+			parsed[i] = iniLine{section: section, key: strings.TrimSpace(raw[:eq]), eq: eq}
+		}
+	}
+	return parsed
+}
+
+// lookupINIKey returns the value of the first occurrence of key in section.
+func lookupINIKey(lines []string, parsed []iniLine, section, key string) (string, bool) {
+	for i, p := range parsed {
+		if p.eq >= 0 && strings.EqualFold(p.section, section) && strings.EqualFold(p.key, key) {
+			return strings.TrimSpace(lines[i][p.eq+1:]), true
+		}
+	}
+	return "", false
+}
+
+// patchINI applies one file's section -> key -> value patches to data and
+// returns the result (data itself, unchanged, when nothing differs). Every
+// occurrence of a set key is rewritten in place, keeping its spelling and
+// the whitespace around '='; a missing key is appended to the end of its
+// section's last block, and a missing section is appended to the file (or,
+// for the "" section, its keys go at the top). A nil value removes every
+// occurrence of the key.
+func patchINI(data []byte, sections map[string]map[string]*string) []byte {
+	lines, newline, trailing := splitINILines(data)
+	for _, section := range sortedKeys(sections) {
+		for _, key := range sortedKeys(sections[section]) {
+			lines = patchINIKey(lines, section, key, sections[section][key])
+		}
+	}
+	if len(lines) == 0 {
+		if len(data) == 0 {
+			return data
+		}
+		return []byte{}
+	}
+	out := strings.Join(lines, newline)
+	if trailing {
+		out += newline
+	}
+	if out == string(data) {
+		return data
+	}
+	return []byte(out)
+}
+
+func patchINIKey(lines []string, section, key string, value *string) []string {
+	parsed := parseINILines(lines)
+
+	if value == nil {
+		kept := lines[:0:0]
+		for i, p := range parsed {
+			if p.eq >= 0 && strings.EqualFold(p.section, section) && strings.EqualFold(p.key, key) {
+				continue
+			}
+			kept = append(kept, lines[i])
+		}
+		return kept
+	}
+
+	found := false
+	for i, p := range parsed {
+		if p.eq < 0 || !strings.EqualFold(p.section, section) || !strings.EqualFold(p.key, key) {
+			continue
+		}
+		found = true
+		rest := lines[i][p.eq+1:]
+		pad := rest[:len(rest)-len(strings.TrimLeft(rest, " \t"))]
+		lines[i] = lines[i][:p.eq+1] + pad + *value
+	}
+	if found {
+		return lines
+	}
+
+	entry := key + "=" + *value
+	if section == "" {
+		// Keys before the first header: insert after the last of them.
+		at := 0
+		for i, p := range parsed {
+			if p.header {
+				break
+			}
+			if p.eq >= 0 {
+				at = i + 1
+			}
+		}
+		return insertLine(lines, at, entry)
+	}
+
+	// Append to the last block of the section: after its last non-blank line.
+	at := -1
+	for i, p := range parsed {
+		if !strings.EqualFold(p.section, section) {
+			continue
+		}
+		if p.header || strings.TrimSpace(lines[i]) != "" {
+			at = i + 1
+		}
+	}
+	if at >= 0 {
+		return insertLine(lines, at, entry)
+	}
+	if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+		lines = append(lines, "")
+	}
+	return append(lines, "["+section+"]", entry)
+}
+
+func insertLine(lines []string, at int, line string) []string {
+	lines = append(lines, "")
+	copy(lines[at+1:], lines[at:])
+	lines[at] = line
+	return lines
+}
+
+// sortedKeys returns m's keys in sorted order, for deterministic iteration.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffINI returns the patches that turn base into local at key level: every
+// key local sets to a value base lacks or holds differently, and a nil
+// (delete) for every key base has that local dropped. Comments, ordering,
+// and formatting are not represented. Sections and keys are spelled as the
+// file that holds them spells them, and only each key's first occurrence
+// counts, matching lookupINIKey.
+func diffINI(base, local []byte) map[string]map[string]*string {
+	baseLines, _, _ := splitINILines(base)
+	localLines, _, _ := splitINILines(local)
+	baseParsed, localParsed := parseINILines(baseLines), parseINILines(localLines)
+
+	patches := map[string]map[string]*string{}
+	set := func(section, key string, value *string) {
+		for s := range patches {
+			if strings.EqualFold(s, section) {
+				section = s
+				break
+			}
+		}
+		if patches[section] == nil {
+			patches[section] = map[string]*string{}
+		}
+		for k := range patches[section] {
+			if strings.EqualFold(k, key) {
+				return
+			}
+		}
+		patches[section][key] = value
+	}
+	for i, p := range localParsed {
+		if p.eq < 0 {
+			continue
+		}
+		value := strings.TrimSpace(localLines[i][p.eq+1:])
+		if want, ok := lookupINIKey(localLines, localParsed, p.section, p.key); !ok || want != value {
+			continue // a later duplicate: the first occurrence decides
+		}
+		if got, ok := lookupINIKey(baseLines, baseParsed, p.section, p.key); !ok || got != value {
+			set(p.section, p.key, &value)
+		}
+	}
+	for _, p := range baseParsed {
+		if p.eq < 0 {
+			continue
+		}
+		if _, ok := lookupINIKey(localLines, localParsed, p.section, p.key); !ok {
+			set(p.section, p.key, nil)
+		}
+	}
+	return patches
+}
@@ -0,0 +1,144 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strPtr(s string) *string { return &s }
+
+const skyrimIni = "; Skyrim settings\r\n" +
+	"[Launcher]\r\n" +
+	"bEnableFileSelection = 0\r\n" +
+	"uLastAspectRatio=1\r\n" +
+	"\r\n" +
+	"[Display]\r\n" +
+	"sOldKey=gone\r\n" +
+	"iSize W=1920\r\n"
+
+func writeIni(t *testing.T, game *domain.Game, name, content string) string {
+	t.Helper()
+	path := filepath.Join(game.InstallPath, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestApplyIniPatches_MergesCaseInsensitivelyAndIdempotently(t *testing.T) {
+	game := &domain.Game{ID: "g1", InstallPath: t.TempDir()}
+	path := writeIni(t, game, "Skyrim.ini", skyrimIni)
+
+	profile := &domain.Profile{IniPatches: domain.IniPatches{
+		"Skyrim.ini": {
+			"launcher": {"BENABLEFILESELECTION": strPtr("1")},
+			"Display":  {"soldkey": nil, "iSize H": strPtr("1080")},
+			"General":  {"sLanguage": strPtr("ENGLISH")},
+		},
+	}}
+	require.NoError(t, core.ApplyIniPatches(game, profile))
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "; Skyrim settings\r\n"+
+		"[Launcher]\r\n"+
+		"bEnableFileSelection = 1\r\n"+
+		"uLastAspectRatio=1\r\n"+
+		"\r\n"+
+		"[Display]\r\n"+
+		"iSize W=1920\r\n"+
+		"iSize H=1080\r\n"+
+		"\r\n"+
+		"[General]\r\n"+
+		"sLanguage=ENGLISH\r\n", string(got))
+
+	// A second apply is a no-op: the file isn't even rewritten.
+	old := time.Unix(1_000_000, 0)
+	require.NoError(t, os.Chtimes(path, old, old))
+	require.NoError(t, core.ApplyIniPatches(game, profile))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.True(t, info.ModTime().Equal(old), "idempotent apply must not rewrite the file")
+
+	drift, err := core.CheckIniPatches(game, profile)
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+}
+
+func TestApplyIniPatches_CreatesMissingFileAndRejectsEscapes(t *testing.T) {
+	game := &domain.Game{ID: "g1", InstallPath: t.TempDir()}
+	profile := &domain.Profile{IniPatches: domain.IniPatches{
+		"conf/new.ini": {"": {"top": strPtr("yes")}, "S": {"k": strPtr("v")}},
+	}}
+	require.NoError(t, core.ApplyIniPatches(game, profile))
+	got, err := os.ReadFile(filepath.Join(game.InstallPath, "conf", "new.ini"))
+	require.NoError(t, err)
+	assert.Equal(t, "top=yes\n\n[S]\nk=v\n", string(got))
+
+	profile.IniPatches = domain.IniPatches{"../escape.ini": {"S": {"k": strPtr("v")}}}
+	require.ErrorContains(t, core.ApplyIniPatches(game, profile), "escapes game directory")
+}
+
+func TestCheckIniPatches_ReportsDrift(t *testing.T) {
+	game := &domain.Game{ID: "g1", InstallPath: t.TempDir()}
+	writeIni(t, game, "Skyrim.ini", skyrimIni)
+
+	profile := &domain.Profile{IniPatches: domain.IniPatches{
+		"Skyrim.ini": {
+			"Launcher": {"bEnableFileSelection": strPtr("1"), "uLastAspectRatio": strPtr("1")},
+			"Display":  {"sOldKey": nil, "iSize H": strPtr("1080")},
+		},
+	}}
+	drift, err := core.CheckIniPatches(game, profile)
+	require.NoError(t, err)
+	require.Len(t, drift, 3)
+
+	assert.Equal(t, "Display", drift[0].Section)
+	assert.Equal(t, "iSize H", drift[0].Key)
+	assert.False(t, drift[0].Present)
+	assert.Equal(t, "sOldKey", drift[1].Key)
+	assert.Nil(t, drift[1].Want)
+	assert.Equal(t, "gone", drift[1].Actual)
+	assert.Equal(t, "bEnableFileSelection", drift[2].Key)
+	assert.Equal(t, "0", drift[2].Actual)
+}
+
+func TestVerify_IniDriftReportedAndFixed(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", InstallPath: t.TempDir(), ModPath: t.TempDir(), LinkMethod: domain.LinkSymlink}
+	path := writeIni(t, game, "Skyrim.ini", skyrimIni)
+
+	pm := svc.NewProfileManager()
+	_, err := pm.Create(game.ID, "default")
+	require.NoError(t, err)
+	profile, err := pm.Get(game.ID, "default")
+	require.NoError(t, err)
+	profile.IniPatches = domain.IniPatches{"Skyrim.ini": {"Launcher": {"bEnableFileSelection": strPtr("1")}}}
+	require.NoError(t, config.SaveProfile(svc.ConfigDir(), profile))
+
+	result, err := svc.Verify(context.Background(), game, "default", core.VerifyOptions{}, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Findings, 1)
+	assert.Equal(t, "ini_drift", result.Findings[0].Status)
+	assert.Equal(t, "Skyrim.ini", result.Findings[0].FileID)
+	assert.Equal(t, `[Launcher] bEnableFileSelection: expected "1", found "0"`, result.Findings[0].Note)
+	assert.Equal(t, 1, result.Warnings)
+
+	result, err = svc.Verify(context.Background(), game, "default", core.VerifyOptions{Fix: true}, nil)
+	require.NoError(t, err)
+	require.Len(t, result.Findings, 1)
+	assert.Equal(t, "fixed_ini_drift", result.Findings[0].Status)
+	assert.Equal(t, 0, result.Warnings)
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "bEnableFileSelection = 1\r\n")
+}
@@ -32,8 +32,13 @@ func NewInstaller(cache *cache.Cache, linker linker.Linker, database *db.DB) *In
 // Install deploys a mod to the game directory. If DB tracking is enabled and a
 // SaveDeployedFile fails, only the file that failed to track is rolled back so
 // the filesystem stays consistent with the database (previously deployed+tracked
-// files are left in place).
+// files are left in place). A dev mod (domain.SourceDev) is symlinked from
+// its working directory instead of the cache.
 func (i *Installer) Install(ctx context.Context, game *domain.Game, mod *domain.Mod, profileName string) error {
+	if mod.SourceID == domain.SourceDev {
+		return i.installDev(ctx, game, mod, profileName)
+	}
+
 	// Check if mod is cached
 	if !i.cache.Exists(game.ID, mod.SourceID, mod.ID, mod.Version) {
 		return fmt.Errorf("mod not in cache: %s/%s@%s", mod.SourceID, mod.ID, mod.Version)
@@ -424,8 +429,13 @@ func rollbackDeploy(lnk linker.Linker, modPath string, relativePaths []string) e
 	return firstErr
 }
 
-// Uninstall removes a mod from the game directory
+// Uninstall removes a mod from the game directory. A dev mod's links are
+// found by its tracked rows, since it has no cache entry to list.
 func (i *Installer) Uninstall(ctx context.Context, game *domain.Game, mod *domain.Mod, profileName string) error {
+	if mod.SourceID == domain.SourceDev {
+		return i.uninstallDev(ctx, game, mod, profileName)
+	}
+
 	// Deliberately the full ListFiles union, not deployableFiles (#210):
 	// removal must cover anything that might ever have been linked, including
 	// stale unclaimed files a pre-fix deploy linked. Narrowing this would
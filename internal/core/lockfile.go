@@ -0,0 +1,210 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+)
+
+// LockPathFor returns the lockfile that travels with an exported profile
+// file: survival.yaml pairs with survival.lock in the same directory.
+func LockPathFor(profileFile string) string {
+	return strings.TrimSuffix(profileFile, filepath.Ext(profileFile)) + ".lock"
+}
+
+// ProfileLockPath returns where LockProfile writes profileName's lockfile.
+func (s *Service) ProfileLockPath(gameID, profileName string) (string, error) {
+	return config.ProfileLockPath(s.configDir, gameID, profileName)
+}
+
+// LoadProfileLock reads the lockfile at path.
+func (s *Service) LoadProfileLock(path string) (*domain.ProfileLock, error) {
+	return config.LoadProfileLock(path)
+}
+
+// LockProfile pins every mod in the profile to the SHA-256 and size recorded
+// when its files were downloaded, writes the lock beside the profile, and
+// returns it with its path. Every mod must be installed at the profile's
+// version with a digest for each of its files: a lock with gaps would let a
+// frozen import skip exactly the files it exists to check. Mods installed
+// before digests were recorded need reinstalling first.
+func (s *Service) LockProfile(game *domain.Game, profileName string) (*domain.ProfileLock, string, error) {
+	pm := s.NewProfileManager()
+	profile, err := pm.Get(game.ID, profileName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	installed, err := s.GetInstalledMods(game.ID, profileName)
+	if err != nil {
+		return nil, "", fmt.Errorf("getting installed mods: %w", err)
+	}
+	installedByKey := make(map[string]domain.InstalledMod, len(installed))
+	for _, im := range installed {
+		installedByKey[domain.ModKey(im.SourceID, im.ID)] = im
+	}
+	files, err := s.GetFilesWithChecksums(game.ID, profileName)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading checksums: %w", err)
+	}
+	digests := make(map[string]map[string]DeployedFile)
+	for _, f := range files {
+		key := domain.ModKey(f.SourceID, f.ModID)
+		if digests[key] == nil {
+			digests[key] = make(map[string]DeployedFile)
+		}
+		digests[key][f.FileID] = f
+	}
+
+	lock := &domain.ProfileLock{Format: domain.ProfileLockFormat, GameID: game.ID, Profile: profile.Name}
+	var problems []string
+	for _, ref := range profile.Mods {
+		key := domain.ModKey(ref.SourceID, ref.ModID)
+		im, ok := installedByKey[key]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("%s is not installed", key))
+			continue
+		case ref.Version != "" && im.Version != ref.Version:
+			problems = append(problems, fmt.Sprintf("%s is installed at %s, not %s", key, im.Version, ref.Version))
+			continue
+		case im.DevPath != "":
+			problems = append(problems, fmt.Sprintf("%s is a dev mod with no download to pin", key))
+			continue
+		case len(im.FileIDs) == 0:
+			problems = append(problems, fmt.Sprintf("%s has no recorded files", key))
+			continue
+		}
+		entry := domain.LockedMod{SourceID: ref.SourceID, ModID: ref.ModID, Version: im.Version}
+		for _, fileID := range im.FileIDs {
+			d := digests[key][fileID]
+			if d.SHA256 == "" {
+				problems = append(problems, fmt.Sprintf("%s file %s has no recorded SHA-256", key, fileID))
+				continue
+			}
+			entry.Files = append(entry.Files, domain.LockedFile{FileID: fileID, SHA256: d.SHA256, Size: d.Size})
+		}
+		lock.Mods = append(lock.Mods, entry)
+	}
+	if len(problems) > 0 {
+		return nil, "", fmt.Errorf("cannot lock profile %s: %s", profile.Name, strings.Join(problems, "; "))
+	}
+
+	path, err := s.ProfileLockPath(game.ID, profile.Name)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := config.SaveProfileLock(path, lock); err != nil {
+		return nil, "", err
+	}
+	return lock, path, nil
+}
+
+// CheckFrozen refuses a frozen import or apply before anything changes when
+// lock belongs to another game or does not pin every mod in refs.
+func CheckFrozen(lock *domain.ProfileLock, game *domain.Game, refs []domain.ModReference) error {
+	if lock.GameID != game.ID {
+		return fmt.Errorf("lockfile is for game %q, not %q", lock.GameID, game.ID)
+	}
+	var unpinned []string
+	for _, ref := range refs {
+		if lock.Find(ref.SourceID, ref.ModID, ref.Version) == nil {
+			unpinned = append(unpinned, strings.TrimSpace(domain.ModKey(ref.SourceID, ref.ModID)+" "+ref.Version))
+		}
+	}
+	if len(unpinned) > 0 {
+		return fmt.Errorf("lockfile does not pin: %s", strings.Join(unpinned, ", "))
+	}
+	return nil
+}
+
+// FrozenCachedFiles decides whether a frozen import or apply may deploy mod's
+// cached version without downloading it: every file in fileIDs needs a
+// SHA-256 recorded when it was downloaded (in any profile) that matches its
+// pin, and no record that differs. A cache filled by an earlier non-frozen
+// install could hold exactly the re-uploaded file the lock exists to refuse,
+// so false means the caller downloads again through DownloadModFrozen. The
+// matching records are returned for the new install to keep.
+func (s *Service) FrozenCachedFiles(lock *domain.ProfileLock, game *domain.Game, mod *domain.Mod, fileIDs []string) ([]DeployedFile, bool, error) {
+	rows, err := s.db.GetVersionFileDigests(game.ID, mod.SourceID, mod.ID, mod.Version)
+	if err != nil {
+		return nil, false, err
+	}
+	recorded := make([]DeployedFile, len(rows))
+	for i, r := range rows {
+		recorded[i] = DeployedFile{SourceID: r.SourceID, ModID: r.ModID, FileID: r.FileID, Checksum: r.Checksum, SHA256: r.SHA256, Size: r.Size}
+	}
+	matched, ok := matchLockPins(lock, mod, fileIDs, recorded)
+	return matched, ok, nil
+}
+
+// matchLockPins returns one of recorded per file in fileIDs when each file
+// has a record and every record of it matches its pin in lock: the SHA-256,
+// and the size where the lock pins one.
+func matchLockPins(lock *domain.ProfileLock, mod *domain.Mod, fileIDs []string, recorded []DeployedFile) ([]DeployedFile, bool) {
+	locked := lock.Find(mod.SourceID, mod.ID, mod.Version)
+	if locked == nil || len(fileIDs) == 0 {
+		return nil, false
+	}
+	matched := make([]DeployedFile, 0, len(fileIDs))
+	for _, fileID := range fileIDs {
+		pin := locked.File(fileID)
+		if pin == nil {
+			return nil, false
+		}
+		found := false
+		for _, r := range recorded {
+			if r.FileID != fileID {
+				continue
+			}
+			if !strings.EqualFold(r.SHA256, pin.SHA256) || pin.Size > 0 && r.Size != pin.Size {
+				return nil, false
+			}
+			if !found {
+				matched = append(matched, r)
+				found = true
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return matched, true
+}
+
+// DownloadModFrozen is DownloadMod for a frozen import or apply: file must be
+// pinned in lock under mod's version, and the download is checked against the
+// pinned SHA-256 in place of whatever the source declares today. A mismatch
+// fails before anything reaches the cache. The pinned size is checked too;
+// with the digest matching it can only differ in a hand-edited lockfile.
+func (s *Service) DownloadModFrozen(ctx context.Context, lock *domain.ProfileLock, sourceID string, game *domain.Game, mod *domain.Mod, file *domain.DownloadableFile, progressFn ProgressFunc) (*DownloadModResult, error) {
+	key := domain.ModKey(mod.SourceID, mod.ID)
+	locked := lock.Find(mod.SourceID, mod.ID, mod.Version)
+	if locked == nil {
+		return nil, fmt.Errorf("%s %s is not in the lockfile", key, mod.Version)
+	}
+	pin := locked.File(file.ID)
+	if pin == nil {
+		return nil, fmt.Errorf("%s %s file %s is not in the lockfile", key, mod.Version, file.ID)
+	}
+
+	pinned := *file
+	pinned.SHA256, pinned.SHA512, pinned.SHA1, pinned.MD5 = pin.SHA256, "", "", ""
+	result, err := s.DownloadMod(ctx, sourceID, game, mod, &pinned, progressFn)
+	var mismatch *checksumMismatchError
+	if errors.As(err, &mismatch) {
+		return nil, fmt.Errorf("%s does not match the lockfile: downloaded sha256 %s, locked %s", file.FileName, mismatch.got, pin.SHA256)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if pin.Size > 0 && result.Size != pin.Size {
+		return nil, fmt.Errorf("%s does not match the lockfile: downloaded %d bytes, locked %d", file.FileName, result.Size, pin.Size)
+	}
+	return result, nil
+}
@@ -0,0 +1,225 @@
+package core_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// importWithSource imports a one-mod profile "target" into a fresh service
+// whose source serves content as file "1" of src:mod1 1.0.
+func importWithSource(t *testing.T, content []byte, lock *domain.ProfileLock) (*core.Service, *domain.Game, *core.ProfileImportResult, error) {
+	t.Helper()
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkCopy, LinkMethodExplicit: true}
+
+	mock := newMockSourceWithDownloads("src")
+	t.Cleanup(mock.Close)
+	svc.RegisterSource(mock)
+	mock.AddDownload("1", content)
+	mock.AddMod("g1", &domain.Mod{ID: "mod1", SourceID: "src", Name: "Mod One", Version: "1.0", GameID: "g1"})
+
+	data, err := config.ExportProfile(&domain.Profile{Name: "target", GameID: "g1", Mods: []domain.ModReference{{SourceID: "src", ModID: "mod1", Version: "1.0"}}})
+	require.NoError(t, err)
+	plan, err := svc.PlanImport(context.Background(), game, data)
+	require.NoError(t, err)
+	result, err := svc.ApplyImport(context.Background(), game, plan, core.ProfileImportOptions{Lock: lock}, nil)
+	return svc, game, result, err
+}
+
+func TestLockProfile_FrozenImportRefusesReuploadedFile(t *testing.T) {
+	zipPath := createTestZip(t, t.TempDir(), map[string]string{"mod1.esp": "payload"})
+	original, err := os.ReadFile(zipPath)
+	require.NoError(t, err)
+	sum := sha256.Sum256(original)
+
+	svc, game, result, err := importWithSource(t, original, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Installed)
+
+	lock, lockPath, err := svc.LockProfile(game, "target")
+	require.NoError(t, err)
+	assert.Equal(t, "g1", lock.GameID)
+	require.Len(t, lock.Mods, 1)
+	assert.Equal(t, domain.LockedMod{SourceID: "src", ModID: "mod1", Version: "1.0", Files: []domain.LockedFile{
+		{FileID: "1", SHA256: hex.EncodeToString(sum[:]), Size: int64(len(original))},
+	}}, lock.Mods[0])
+	loaded, err := svc.LoadProfileLock(lockPath)
+	require.NoError(t, err)
+	assert.Equal(t, lock, loaded, "the lockfile round-trips")
+
+	// Another machine, where the same file ID now serves different bytes.
+	reuploaded := createTestZip(t, t.TempDir(), map[string]string{"mod1.esp": "tampered"})
+	tampered, err := os.ReadFile(reuploaded)
+	require.NoError(t, err)
+	svc, game, result, err = importWithSource(t, tampered, loaded)
+	require.NoError(t, err)
+	assert.Zero(t, result.Installed)
+	assert.Equal(t, 1, result.Failed)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "does not match the lockfile")
+	assert.False(t, svc.GetGameCache(game).Exists(game.ID, "src", "mod1", "1.0"), "a mismatched download never reaches the cache")
+
+	_, _, result, err = importWithSource(t, original, loaded)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Installed, "%v", result.Warnings)
+}
+
+// importProfile imports a one-mod profile named name (src:mod1 1.0) into
+// svc, frozen when lock is non-nil.
+func importProfile(t *testing.T, svc *core.Service, game *domain.Game, name string, lock *domain.ProfileLock) *core.ProfileImportResult {
+	t.Helper()
+	data, err := config.ExportProfile(&domain.Profile{Name: name, GameID: "g1", Mods: []domain.ModReference{{SourceID: "src", ModID: "mod1", Version: "1.0"}}})
+	require.NoError(t, err)
+	plan, err := svc.PlanImport(context.Background(), game, data)
+	require.NoError(t, err)
+	result, err := svc.ApplyImport(context.Background(), game, plan, core.ProfileImportOptions{Lock: lock}, nil)
+	require.NoError(t, err)
+	return result
+}
+
+func TestApplyImport_FrozenChecksCachedFilesAgainstTheLock(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkCopy, LinkMethodExplicit: true}
+	mock := newMockSourceWithDownloads("src")
+	t.Cleanup(mock.Close)
+	svc.RegisterSource(mock)
+	mock.AddMod("g1", &domain.Mod{ID: "mod1", SourceID: "src", Name: "Mod One", Version: "1.0", GameID: "g1"})
+	original, err := os.ReadFile(createTestZip(t, t.TempDir(), map[string]string{"mod1.esp": "payload"}))
+	require.NoError(t, err)
+	tampered, err := os.ReadFile(createTestZip(t, t.TempDir(), map[string]string{"mod1.esp": "tampered"}))
+	require.NoError(t, err)
+
+	mock.AddDownload("1", original)
+	require.Equal(t, 1, importProfile(t, svc, game, "target", nil).Installed)
+	lock, _, err := svc.LockProfile(game, "target")
+	require.NoError(t, err)
+
+	// The mod is uninstalled, its cache entry emptied and refilled by a
+	// non-frozen install after the file was re-uploaded, and uninstalled
+	// again: the cache now holds the re-uploaded file.
+	require.NoError(t, svc.DeleteInstalledMod("src", "mod1", game.ID, "target"))
+	require.NoError(t, svc.GetGameCache(game).Delete(game.ID, "src", "mod1", "1.0"))
+	mock.AddDownload("1", tampered)
+	require.Equal(t, 1, importProfile(t, svc, game, "loose", nil).Installed)
+	require.NoError(t, svc.DeleteInstalledMod("src", "mod1", game.ID, "loose"))
+
+	// A frozen import does not take that entry on trust: with no matching
+	// record it downloads again and refuses what the source serves.
+	result := importProfile(t, svc, game, "frozen", lock)
+	assert.Zero(t, result.Installed)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "does not match the lockfile")
+
+	// Once the source serves the pinned file again, the download replaces
+	// the cached copy and the install records the pinned digest.
+	mock.AddDownload("1", original)
+	result = importProfile(t, svc, game, "pinned", lock)
+	require.Equal(t, 1, result.Installed, "%v", result.Warnings)
+	cached, err := os.ReadFile(svc.GetGameCache(game).GetFilePath(game.ID, "src", "mod1", "1.0", "mod1.esp"))
+	require.NoError(t, err)
+	assert.Equal(t, "payload", string(cached))
+	relocked, _, err := svc.LockProfile(game, "pinned")
+	require.NoError(t, err)
+	assert.Equal(t, lock.Mods, relocked.Mods)
+}
+
+func TestDownloadModFrozen_ChecksPinnedSize(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkCopy, LinkMethodExplicit: true}
+	mock := newMockSourceWithDownloads("src")
+	t.Cleanup(mock.Close)
+	svc.RegisterSource(mock)
+	content, err := os.ReadFile(createTestZip(t, t.TempDir(), map[string]string{"mod1.esp": "payload"}))
+	require.NoError(t, err)
+	mock.AddDownload("1", content)
+	sum := sha256.Sum256(content)
+
+	mod := &domain.Mod{ID: "mod1", SourceID: "src", Name: "Mod One", Version: "1.0", GameID: "g1"}
+	lock := &domain.ProfileLock{Format: domain.ProfileLockFormat, GameID: "g1", Mods: []domain.LockedMod{{
+		SourceID: "src", ModID: "mod1", Version: "1.0",
+		Files: []domain.LockedFile{{FileID: "1", SHA256: hex.EncodeToString(sum[:]), Size: int64(len(content)) + 1}},
+	}}}
+	_, err = svc.DownloadModFrozen(context.Background(), lock, "src", game, mod, &domain.DownloadableFile{ID: "1", FileName: "mod1.zip"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not match the lockfile")
+
+	lock.Mods[0].Files[0].Size = int64(len(content))
+	_, err = svc.DownloadModFrozen(context.Background(), lock, "src", game, mod, &domain.DownloadableFile{ID: "1", FileName: "mod1.zip"}, nil)
+	require.NoError(t, err)
+}
+
+func TestApplyImport_FrozenRefusesUnpinnedModsBeforeSaving(t *testing.T) {
+	lock := &domain.ProfileLock{Format: domain.ProfileLockFormat, GameID: "g1", Mods: []domain.LockedMod{
+		{SourceID: "src", ModID: "mod1", Version: "0.9"},
+	}}
+	svc, game, _, err := importWithSource(t, []byte("archive"), lock)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lockfile does not pin: src:mod1 1.0")
+	_, err = svc.NewProfileManager().Get(game.ID, "target")
+	assert.ErrorIs(t, err, domain.ErrProfileNotFound, "nothing is saved for a refused frozen import")
+
+	lock = &domain.ProfileLock{Format: domain.ProfileLockFormat, GameID: "other"}
+	_, _, _, err = importWithSource(t, []byte("archive"), lock)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `lockfile is for game "other"`)
+}
+
+func TestLockProfile_RefusesModsWithoutDigests(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir()}
+	require.NoError(t, svc.SaveInstalledMod(&domain.InstalledMod{
+		Mod:          domain.Mod{ID: "42", SourceID: "nexusmods", Name: "Old Mod", Version: "1.0", GameID: game.ID},
+		ProfileName:  "default",
+		UpdatePolicy: domain.UpdateNotify,
+		Enabled:      true,
+		FileIDs:      []string{"main"},
+	}))
+	require.NoError(t, svc.SaveFileChecksum("nexusmods", "42", game.ID, "default", "main", "md5only"))
+	seedProfileWithMod(t, svc, game.ID, "default", "nexusmods", "42", "1.0")
+	seedProfileWithMod(t, svc, game.ID, "default", "nexusmods", "43", "2.0")
+
+	_, _, err := svc.LockProfile(game, "default")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nexusmods:42 file main has no recorded SHA-256")
+	assert.Contains(t, err.Error(), "nexusmods:43 is not installed")
+
+	lockPath, err := svc.ProfileLockPath(game.ID, "default")
+	require.NoError(t, err)
+	assert.NoFileExists(t, lockPath, "an incomplete lock is never written")
+}
+
+func TestLockProfile_AfterUpdate(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkSymlink}
+	old := seedUpdatableMod(t, svc, game, "src", "mod1", "Mod One", "1.0", []string{"old-1"}, map[string][]byte{"mod1-old.esp": []byte("old-content")})
+
+	mock := &multiFileDownloadSource{
+		mockSourceWithDownloads: newMockSourceWithDownloads("src"),
+		files:                   []domain.DownloadableFile{{ID: "new-1", Name: "New File", FileName: "mod1-new.esp", IsPrimary: true}},
+	}
+	defer mock.Close()
+	svc.RegisterSource(mock)
+	mock.AddMod("g1", &domain.Mod{ID: "mod1", SourceID: "src", Name: "Mod One", Version: "2.0", GameID: "g1"})
+	content := []byte("new-content")
+	mock.AddDownload("new-1", content)
+
+	_, err := svc.ApplyUpdate(context.Background(), game, "default", domain.Update{InstalledMod: *old, NewVersion: "2.0"}, core.UpdateOptions{}, nil)
+	require.NoError(t, err)
+
+	lock, _, err := svc.LockProfile(game, "default")
+	require.NoError(t, err, "the update's download digest must be recorded")
+	sum := sha256.Sum256(content)
+	assert.Equal(t, []domain.LockedMod{{SourceID: "src", ModID: "mod1", Version: "2.0", Files: []domain.LockedFile{
+		{FileID: "new-1", SHA256: hex.EncodeToString(sum[:]), Size: int64(len(content))},
+	}}}, lock.Mods)
+}
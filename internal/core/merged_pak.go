@@ -365,7 +365,7 @@ func (s *Service) syncMergedPak(ctx context.Context, game *domain.Game, profileN
 		return warnings, fmt.Errorf("writing merge fingerprint: %w", err)
 	}
 
-	if err := commitStagedCache(cachePath, stagePath); err != nil {
+	if err := commitStagedCache(gameCache, cachePath, stagePath); err != nil {
 		return warnings, err
 	}
 
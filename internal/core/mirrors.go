@@ -0,0 +1,133 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	neturl "net/url"
+	"slices"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/db"
+)
+
+// mirrorFailureCooldown is how long a host whose latest download failed is
+// tried after every other mirror, rather than in its declared place.
+const mirrorFailureCooldown = time.Hour
+
+// downloadFile downloads file to archivePath from url and verifies it against
+// the source's declared checksums. A file with mirrors fails over through
+// them: each candidate gets the downloader's own retries, and a download
+// whose checksum does not match counts as that mirror failing, so every
+// mirror must serve the same bytes. Outcomes are recorded per host for files
+// with mirrors, so hosts that worked last time are tried first.
+func (s *Service) downloadFile(ctx context.Context, src source.ModSource, file *domain.DownloadableFile, url, archivePath string, progressFn ProgressFunc) (*DownloadResult, error) {
+	if len(file.Mirrors) == 0 {
+		result, err := s.downloader.DownloadWithHeaders(ctx, url, archivePath, downloadHeaders(src, url), progressFn)
+		if err != nil {
+			return nil, fmt.Errorf("downloading mod: %w", err)
+		}
+		if err := verifyDownloadChecksums(file, result); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	candidates := s.orderMirrors(src.ID(), append([]string{url}, file.Mirrors...))
+	var errs []error
+	for _, candidate := range candidates {
+		result, err := s.downloader.DownloadWithHeaders(ctx, candidate, archivePath, downloadHeaders(src, candidate), progressFn)
+		if err == nil {
+			err = verifyDownloadChecksums(file, result)
+		}
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("downloading mod: %w", ctx.Err())
+		}
+		s.recordMirrorResult(src.ID(), candidate, err)
+		if err == nil {
+			return result, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", mirrorHost(candidate), err))
+	}
+	return nil, fmt.Errorf("downloading mod: all %d mirrors failed: %w", len(candidates), errors.Join(errs...))
+}
+
+// downloadHeaders returns the source's extra headers for url, if it has any.
+// Sources decide per URL, so a mirror on another host does not receive
+// credentials meant for the source's own server.
+func downloadHeaders(src source.ModSource, url string) map[string]string {
+	if hp, ok := src.(source.DownloadHeaderProvider); ok {
+		return hp.DownloadHeaders(url)
+	}
+	return nil
+}
+
+// orderMirrors dedupes urls and orders them for a download attempt: hosts
+// whose latest download failed within mirrorFailureCooldown go last, and the
+// rest keep their declared order, with hosts that have worked before first.
+func (s *Service) orderMirrors(sourceID string, urls []string) []string {
+	var health map[string]db.MirrorHealth
+	if s.db != nil {
+		// Health is advisory: without it the declared order is used.
+		if records, err := s.db.ListMirrorHealth(sourceID); err == nil {
+			health = make(map[string]db.MirrorHealth, len(records))
+			for _, h := range records {
+				health[h.Host] = h
+			}
+		}
+	}
+
+	rank := func(u string) int {
+		h, ok := health[mirrorHost(u)]
+		switch {
+		case !ok:
+			return 1
+		case !h.Healthy() && time.Since(h.LastFailureAt) < mirrorFailureCooldown:
+			return 2
+		case h.Successes > 0 && h.Healthy():
+			return 0
+		default:
+			return 1
+		}
+	}
+
+	ordered := make([]string, 0, len(urls))
+	for _, u := range urls {
+		if u != "" && !slices.Contains(ordered, u) {
+			ordered = append(ordered, u)
+		}
+	}
+	slices.SortStableFunc(ordered, func(a, b string) int { return rank(a) - rank(b) })
+	return ordered
+}
+
+// recordMirrorResult stores one download outcome in the mirror health
+// table. A failure to record is not a download failure, so it is dropped.
+func (s *Service) recordMirrorResult(sourceID, url string, downloadErr error) {
+	if s.db == nil {
+		return
+	}
+	host := mirrorHost(url)
+	if downloadErr == nil {
+		_ = s.db.RecordMirrorSuccess(sourceID, host, time.Now())
+		return
+	}
+	_ = s.db.RecordMirrorFailure(sourceID, host, downloadErr.Error(), time.Now())
+}
+
+// mirrorHost is the host (with any port) health is tracked by, or the URL
+// itself when it does not parse.
+func mirrorHost(u string) string {
+	if parsed, err := neturl.Parse(u); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+	return u
+}
+
+// ListMirrorHealth returns the recorded mirror health for sourceID, or for
+// every source when sourceID is empty.
+func (s *Service) ListMirrorHealth(sourceID string) ([]db.MirrorHealth, error) {
+	return s.db.ListMirrorHealth(sourceID)
+}
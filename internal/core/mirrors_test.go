@@ -0,0 +1,127 @@
+package core_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/custom"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mirrorServer serves body for every request and counts them.
+func mirrorServer(t *testing.T, status int, body string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &hits
+}
+
+// setupMirrorSource registers a manifest source whose single file lists
+// primary as its url and mirrors after it.
+func setupMirrorSource(t *testing.T, sha string, primary string, mirrors ...string) (*core.Service, *domain.Game) {
+	t.Helper()
+	manifest := fmt.Sprintf(`
+version: 1
+mods:
+  - id: cool-mod
+    name: Cool Mod
+    version: 1.0.0
+    files:
+      - id: main
+        filename: cool-mod.zip
+        url: %s/cool-mod.zip
+        mirrors: [%s]
+        sha256: %s
+`, primary, strings.Join(mirrors, ", "), sha)
+	manifestSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(manifest))
+	}))
+	t.Cleanup(manifestSrv.Close)
+
+	src, err := custom.New(custom.SourceDefinition{
+		ID: "team", Name: "Team", Type: custom.TypeManifest, AllowHTTP: true,
+		Manifest: &custom.ManifestConfig{URL: manifestSrv.URL + "/mods.yaml"},
+	})
+	require.NoError(t, err)
+
+	svc, err := core.NewService(core.ServiceConfig{ConfigDir: t.TempDir(), DataDir: t.TempDir(), CacheDir: t.TempDir()})
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, svc.Close()) })
+	svc.RegisterSource(src)
+
+	game := &domain.Game{ID: "testgame", Name: "Test Game", ModPath: t.TempDir(), DeployMode: domain.DeployCopy}
+	require.NoError(t, svc.AddGame(game))
+	return svc, game
+}
+
+func downloadCoolMod(t *testing.T, svc *core.Service, game *domain.Game) error {
+	t.Helper()
+	ctx := context.Background()
+	mod, err := svc.GetMod(ctx, "team", "testgame", "cool-mod")
+	require.NoError(t, err)
+	files, err := svc.GetModFiles(ctx, "team", mod)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	_, err = svc.DownloadMod(ctx, "team", game, mod, &files[0], nil)
+	return err
+}
+
+func TestDownloadFailsOverThroughMirrors(t *testing.T) {
+	payload := "mod payload bytes"
+	sum := sha256.Sum256([]byte(payload))
+
+	down, downHits := mirrorServer(t, http.StatusNotFound, "")
+	tampered, tamperedHits := mirrorServer(t, http.StatusOK, "tampered")
+	good, goodHits := mirrorServer(t, http.StatusOK, payload)
+	svc, game := setupMirrorSource(t, hex.EncodeToString(sum[:]), down.URL, tampered.URL+"/cool-mod.zip", good.URL+"/cool-mod.zip")
+
+	require.NoError(t, downloadCoolMod(t, svc, game), "the third candidate serves the declared bytes")
+	assert.Equal(t, int32(1), downHits.Load())
+	assert.Equal(t, int32(1), tamperedHits.Load(), "a checksum mismatch moves on to the next mirror")
+	assert.Equal(t, int32(1), goodHits.Load())
+
+	health, err := svc.ListMirrorHealth("team")
+	require.NoError(t, err)
+	require.Len(t, health, 3)
+	byHost := map[string]int{}
+	for i, h := range health {
+		byHost["http://"+h.Host] = i
+	}
+	assert.Equal(t, 1, health[byHost[down.URL]].Failures)
+	assert.Contains(t, health[byHost[down.URL]].LastError, "404")
+	assert.Contains(t, health[byHost[tampered.URL]].LastError, "sha256 mismatch")
+	assert.Equal(t, 1, health[byHost[good.URL]].Successes)
+
+	// The mirror that worked is tried first next time.
+	require.NoError(t, downloadCoolMod(t, svc, game))
+	assert.Equal(t, int32(1), downHits.Load())
+	assert.Equal(t, int32(1), tamperedHits.Load())
+	assert.Equal(t, int32(2), goodHits.Load())
+}
+
+func TestDownloadAllMirrorsFail(t *testing.T) {
+	down, _ := mirrorServer(t, http.StatusNotFound, "")
+	gone, _ := mirrorServer(t, http.StatusGone, "")
+	svc, game := setupMirrorSource(t, strings.Repeat("ab", 32), down.URL, gone.URL+"/cool-mod.zip")
+
+	err := downloadCoolMod(t, svc, game)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "all 2 mirrors failed")
+	assert.Contains(t, err.Error(), "404")
+	assert.Contains(t, err.Error(), "410")
+}
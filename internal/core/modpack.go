@@ -0,0 +1,431 @@
+package core
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
+)
+
+// ModpackFormat is the .lmmpack layout version PackProfile writes. Unpack
+// refuses anything newer rather than half-reading it.
+const ModpackFormat = 1
+
+// A .lmmpack is a zip holding:
+//
+//	lmmpack.json        the modpackManifest below
+//	profile.yaml        the profile, exactly as 'lmm profile export' writes it
+//	mods/<n>/...        the cache version directory of manifest mod n,
+//	                    completion markers included
+//
+// Mods are stored by index rather than by source/mod/version so no name from
+// a source can shape a path inside the archive.
+const (
+	modpackManifestName = "lmmpack.json"
+	modpackProfileName  = "profile.yaml"
+	modpackModsDir      = "mods"
+)
+
+type modpackManifest struct {
+	Format  int          `json:"format"`
+	GameID  string       `json:"game_id"`
+	Profile string       `json:"profile"`
+	Mods    []modpackMod `json:"mods"`
+}
+
+// modpackMod carries what ApplyImport would otherwise fetch from the mod's
+// source: enough metadata to record the install, the file IDs the cached
+// version was built from, and their recorded download checksums and
+// digests (so the unpacked profile can be locked like a downloaded one).
+type modpackMod struct {
+	SourceID  string                   `json:"source_id"`
+	ModID     string                   `json:"mod_id"`
+	Version   string                   `json:"version"`
+	Name      string                   `json:"name,omitempty"`
+	Author    string                   `json:"author,omitempty"`
+	Summary   string                   `json:"summary,omitempty"`
+	SourceURL string                   `json:"source_url,omitempty"`
+	FileIDs   []string                 `json:"file_ids,omitempty"`
+	Checksums map[string]string        `json:"checksums,omitempty"`
+	Digests   map[string]modpackDigest `json:"digests,omitempty"`
+}
+
+// modpackDigest is the SHA-256 and size recorded for one downloaded file.
+type modpackDigest struct {
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// recorded returns the pack's checksums and digests in the form the
+// database records them, one entry per file with a digest.
+func (m modpackMod) recorded() []DeployedFile {
+	files := make([]DeployedFile, 0, len(m.Digests))
+	for fileID, d := range m.Digests {
+		files = append(files, DeployedFile{SourceID: m.SourceID, ModID: m.ModID, FileID: fileID, Checksum: m.Checksums[fileID], SHA256: d.SHA256, Size: d.Size})
+	}
+	return files
+}
+
+func (m modpackMod) mod(gameID string) domain.Mod {
+	name := m.Name
+	if name == "" {
+		name = m.ModID
+	}
+	return domain.Mod{
+		ID: m.ModID, SourceID: m.SourceID, Name: name, Version: m.Version,
+		Author: m.Author, Summary: m.Summary, SourceURL: m.SourceURL, GameID: gameID,
+	}
+}
+
+// PackResult reports a written modpack.
+type PackResult struct {
+	Mods int   // cache versions packed
+	Size int64 // bytes of cached content packed
+}
+
+// PackProfile writes profileName and every cache version it references to w
+// as a .lmmpack archive, so UnpackProfile can recreate the profile on a
+// machine with no network access. The version packed for each mod is the
+// profile's own (or, with none recorded, the installed one). Every version
+// must be fully cached: a pack missing one would only fail later, offline,
+// so this refuses up front and names them.
+func (s *Service) PackProfile(ctx context.Context, game *domain.Game, profileName string, w io.Writer) (*PackResult, error) {
+	pm := s.NewProfileManager()
+	profile, err := pm.Get(game.ID, profileName)
+	if err != nil {
+		return nil, err
+	}
+	profileData, err := pm.Export(game.ID, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("exporting profile: %w", err)
+	}
+
+	installed, err := s.GetInstalledMods(game.ID, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("getting installed mods: %w", err)
+	}
+	installedByKey := make(map[string]domain.InstalledMod, len(installed))
+	for _, im := range installed {
+		installedByKey[domain.ModKey(im.SourceID, im.ID)] = im
+	}
+	checksums := make(map[string]map[string]string)
+	digests := make(map[string]map[string]modpackDigest)
+	files, err := s.GetFilesWithChecksums(game.ID, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("reading checksums: %w", err)
+	}
+	for _, f := range files {
+		key := domain.ModKey(f.SourceID, f.ModID)
+		if f.Checksum != "" {
+			if checksums[key] == nil {
+				checksums[key] = make(map[string]string)
+			}
+			checksums[key][f.FileID] = f.Checksum
+		}
+		if f.SHA256 != "" {
+			if digests[key] == nil {
+				digests[key] = make(map[string]modpackDigest)
+			}
+			digests[key][f.FileID] = modpackDigest{SHA256: f.SHA256, Size: f.Size}
+		}
+	}
+
+	gameCache := s.GetGameCache(game)
+	manifest := modpackManifest{Format: ModpackFormat, GameID: game.ID, Profile: profile.Name}
+	var dirs, uncached []string
+	for _, ref := range profile.Mods {
+		key := domain.ModKey(ref.SourceID, ref.ModID)
+		im, isInstalled := installedByKey[key]
+		entry := modpackMod{SourceID: ref.SourceID, ModID: ref.ModID, Version: ref.Version, FileIDs: ref.FileIDs}
+		if isInstalled {
+			if entry.Version == "" {
+				entry.Version = im.Version
+			}
+			if entry.Version == im.Version {
+				entry.FileIDs = im.FileIDs
+				entry.Checksums = checksums[key]
+				entry.Digests = digests[key]
+			}
+			entry.Name, entry.Author, entry.Summary, entry.SourceURL = im.Name, im.Author, im.Summary, im.SourceURL
+		}
+		cached := gameCache.Exists(game.ID, ref.SourceID, ref.ModID, entry.Version)
+		if cached && len(entry.FileIDs) > 0 {
+			cached = gameCache.HasFileIDs(game.ID, ref.SourceID, ref.ModID, entry.Version, entry.FileIDs)
+		}
+		if entry.Version == "" || !cached {
+			uncached = append(uncached, fmt.Sprintf("%s %s", key, entry.Version))
+			continue
+		}
+		manifest.Mods = append(manifest.Mods, entry)
+		dirs = append(dirs, gameCache.ModPath(game.ID, ref.SourceID, ref.ModID, entry.Version))
+	}
+	if len(uncached) > 0 {
+		return nil, fmt.Errorf("not fully cached: %s (run 'lmm deploy' to download them again)", strings.Join(uncached, ", "))
+	}
+
+	zw := zip.NewWriter(w)
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding pack manifest: %w", err)
+	}
+	if err := writeZipFile(zw, modpackManifestName, manifestData); err != nil {
+		return nil, err
+	}
+	if err := writeZipFile(zw, modpackProfileName, profileData); err != nil {
+		return nil, err
+	}
+	result := &PackResult{Mods: len(dirs)}
+	for i, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		n, err := addDirToZip(zw, dir, path.Join(modpackModsDir, fmt.Sprint(i)))
+		if err != nil {
+			return nil, fmt.Errorf("packing %s: %w", domain.ModKey(manifest.Mods[i].SourceID, manifest.Mods[i].ModID), err)
+		}
+		result.Size += n
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("writing pack: %w", err)
+	}
+	return result, nil
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	if _, err := fw.Write(data); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// addDirToZip archives every regular file under root (hidden lmm markers
+// included) beneath prefix, returning the bytes added. Transient staging
+// leftovers never sit inside a version directory, so nothing is filtered.
+func addDirToZip(zw *zip.Writer, root, prefix string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		hdr.Name = path.Join(prefix, filepath.ToSlash(rel))
+		hdr.Method = zip.Deflate
+		fw, err := zw.CreateHeader(hdr)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		n, err := io.Copy(fw, f)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		total += n
+		return err
+	})
+	return total, err
+}
+
+// UnpackProfile reads a .lmmpack written by PackProfile, seeds game's cache
+// with every version it carries, and returns the import plan for its
+// profile. Unlike PlanImport this has a side effect - the seeded cache
+// entries - but nothing else is written until ApplyImport runs the plan.
+// A version already fully cached is left as it is.
+//
+// ApplyImport installs the pack's mods from that cache with no network
+// access: their metadata and file IDs come from the pack, and their source
+// identities are kept, so later updates work as for any other install.
+func (s *Service) UnpackProfile(ctx context.Context, game *domain.Game, packPath string) (*ImportPlan, error) {
+	zr, err := zip.OpenReader(packPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening pack: %w", err)
+	}
+	defer zr.Close()
+
+	byName := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		byName[f.Name] = f
+	}
+	var manifest modpackManifest
+	manifestData, err := readZipFile(byName, modpackManifestName)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("parsing pack manifest: %w", err)
+	}
+	if manifest.Format < 1 || manifest.Format > ModpackFormat {
+		return nil, fmt.Errorf("unsupported pack format %d (this lmm reads up to %d)", manifest.Format, ModpackFormat)
+	}
+	if manifest.GameID != game.ID {
+		return nil, fmt.Errorf("pack is for game %q, not %q", manifest.GameID, game.ID)
+	}
+	profileData, err := readZipFile(byName, modpackProfileName)
+	if err != nil {
+		return nil, err
+	}
+
+	gameCache := s.GetGameCache(game)
+	packed := make(map[string]modpackMod, len(manifest.Mods))
+	seeded := 0
+	for i, m := range manifest.Mods {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for _, part := range []string{m.SourceID, m.ModID, m.Version} {
+			if !safePackName(part) {
+				return nil, fmt.Errorf("pack entry %d has an invalid source, mod ID or version: %q", i, part)
+			}
+		}
+		packed[domain.ModKey(m.SourceID, m.ModID)] = m
+		if len(m.FileIDs) > 0 && gameCache.HasFileIDs(game.ID, m.SourceID, m.ModID, m.Version, m.FileIDs) {
+			continue
+		}
+		cachePath := gameCache.ModPath(game.ID, m.SourceID, m.ModID, m.Version)
+		stagePath := cachePath + ".staging"
+		if err := os.RemoveAll(stagePath); err != nil {
+			return nil, fmt.Errorf("clearing staging dir: %w", err)
+		}
+		prefix := path.Join(modpackModsDir, fmt.Sprint(i)) + "/"
+		if err := extractZipPrefix(zr.File, prefix, stagePath); err != nil {
+			_ = os.RemoveAll(stagePath)
+			return nil, fmt.Errorf("unpacking %s %s: %w", domain.ModKey(m.SourceID, m.ModID), m.Version, err)
+		}
+		if err := commitStagedCache(gameCache, cachePath, stagePath); err != nil {
+			_ = os.RemoveAll(stagePath)
+			return nil, fmt.Errorf("unpacking %s %s: %w", domain.ModKey(m.SourceID, m.ModID), m.Version, err)
+		}
+		seeded++
+	}
+
+	plan, err := s.PlanImport(ctx, game, profileData)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Profile.GameID != game.ID {
+		return nil, fmt.Errorf("pack profile is for game %q, not %q", plan.Profile.GameID, game.ID)
+	}
+	plan.Seeded = seeded
+	plan.packed = packed
+	return plan, nil
+}
+
+// safePackName reports whether a pack-supplied source, mod ID or version can
+// be used as a cache path component without leaving its directory.
+func safePackName(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.ContainsAny(s, `/\`) && !strings.HasPrefix(s, cache.ReservedPrefix)
+}
+
+func readZipFile(byName map[string]*zip.File, name string) ([]byte, error) {
+	f, ok := byName[name]
+	if !ok {
+		return nil, fmt.Errorf("not an lmm pack: %s missing", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// extractZipPrefix writes every regular-file entry under prefix into dest,
+// relative to prefix. An entry that would land outside dest is an error.
+func extractZipPrefix(files []*zip.File, prefix, dest string) error {
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return err
+	}
+	for _, f := range files {
+		rel, ok := strings.CutPrefix(f.Name, prefix)
+		if !ok || rel == "" || strings.HasSuffix(f.Name, "/") {
+			continue
+		}
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		clean := path.Clean(rel)
+		if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") || strings.Contains(rel, `\`) {
+			return fmt.Errorf("entry %q escapes its directory", f.Name)
+		}
+		target := filepath.Join(dest, filepath.FromSlash(clean))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := extractZipEntry(f, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipEntry(f *zip.File, target string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	mode := f.Mode().Perm()
+	if mode == 0 {
+		mode = 0644
+	}
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_EXCL, mode)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("duplicate entry %q", f.Name)
+		}
+		return err
+	}
+	_, err = io.Copy(out, rc)
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// packedChecksums records the pack's download checksums and digests for an
+// installed mod, returning a note for any that could not be saved.
+func (s *Service) packedChecksums(m modpackMod, gameID, profileName string) []string {
+	var notes []string
+	for fileID, sum := range m.Checksums {
+		if err := s.SaveFileChecksum(m.SourceID, m.ModID, gameID, profileName, fileID, sum); err != nil {
+			notes = append(notes, fmt.Sprintf("Warning: could not record checksum for %s: %v", fileID, err))
+		}
+	}
+	for fileID, d := range m.Digests {
+		if err := s.SaveFileDigest(m.SourceID, m.ModID, gameID, profileName, fileID, d.SHA256, d.Size); err != nil {
+			notes = append(notes, fmt.Sprintf("Warning: could not record SHA-256 digest for %s: %v", fileID, err))
+		}
+	}
+	return notes
+}
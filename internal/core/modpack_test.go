@@ -0,0 +1,160 @@
+package core_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// packedSHA256 is the download digest packFixture records for its mod.
+const packedSHA256 = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+// packFixture packs profile "default" of a service holding one installed,
+// fully cached nexusmods mod with a recorded checksum, and returns the pack's
+// path.
+func packFixture(t *testing.T) string {
+	t.Helper()
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkCopy, LinkMethodExplicit: true}
+
+	gameCache := svc.GetGameCache(game)
+	require.NoError(t, gameCache.Store(game.ID, "nexusmods", "42", "1.0", "Data/plugin.esp", []byte("plugin")))
+	require.NoError(t, cache.MarkFileComplete(gameCache.ModPath(game.ID, "nexusmods", "42", "1.0"), "main"))
+	require.NoError(t, svc.SaveInstalledMod(&domain.InstalledMod{
+		Mod:          domain.Mod{ID: "42", SourceID: "nexusmods", Name: "Packed Mod", Author: "someone", Version: "1.0", GameID: game.ID},
+		ProfileName:  "default",
+		UpdatePolicy: domain.UpdateNotify,
+		Enabled:      true,
+		FileIDs:      []string{"main"},
+	}))
+	require.NoError(t, svc.SaveFileChecksum("nexusmods", "42", game.ID, "default", "main", "abc123"))
+	require.NoError(t, svc.SaveFileDigest("nexusmods", "42", game.ID, "default", "main", packedSHA256, 2048))
+	seedProfileWithMod(t, svc, game.ID, "default", "nexusmods", "42", "1.0")
+
+	packPath := filepath.Join(t.TempDir(), "default.lmmpack")
+	f, err := os.Create(packPath)
+	require.NoError(t, err)
+	result, err := svc.PackProfile(context.Background(), game, "default", f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	assert.Equal(t, 1, result.Mods)
+	assert.Equal(t, int64(len("plugin")), result.Size, "markers are packed but only content is counted")
+	return packPath
+}
+
+func TestPackUnpackProfile_InstallsOffline(t *testing.T) {
+	ctx := context.Background()
+	packPath := packFixture(t)
+
+	// A fresh machine with no sources registered at all: any network path
+	// would fail the install.
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkCopy, LinkMethodExplicit: true}
+	require.NoError(t, svc.AddGame(game))
+
+	plan, err := svc.UnpackProfile(ctx, game, packPath)
+	require.NoError(t, err)
+	assert.Equal(t, 1, plan.Seeded)
+	assert.Equal(t, "default", plan.Profile.Name)
+	require.Len(t, plan.Missing, 1)
+	assert.True(t, svc.GetGameCache(game).HasFileIDs(game.ID, "nexusmods", "42", "1.0", []string{"main"}), "markers travel with the pack")
+
+	result, err := svc.ApplyImport(ctx, game, plan, core.ProfileImportOptions{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Installed)
+	assert.Zero(t, result.Failed, "%v", result.Warnings)
+
+	content, err := os.ReadFile(filepath.Join(game.ModPath, "Data", "plugin.esp"))
+	require.NoError(t, err)
+	assert.Equal(t, "plugin", string(content))
+
+	mod, err := svc.GetInstalledMod("nexusmods", "42", game.ID, "default")
+	require.NoError(t, err)
+	assert.Equal(t, "Packed Mod", mod.Name)
+	assert.Equal(t, "1.0", mod.Version)
+	assert.Equal(t, []string{"main"}, mod.FileIDs, "the source identity survives for later updates")
+	files, err := svc.GetFilesWithChecksums(game.ID, "default")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "abc123", files[0].Checksum)
+	assert.Equal(t, packedSHA256, files[0].SHA256)
+	assert.Equal(t, int64(2048), files[0].Size)
+
+	// The pack carried the digests, so the unpacked profile can be locked.
+	lock, _, err := svc.LockProfile(game, "default")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.LockedFile{{FileID: "main", SHA256: packedSHA256, Size: 2048}}, lock.Mods[0].Files)
+
+	// Unpacking again reuses the cache instead of rewriting it.
+	plan, err = svc.UnpackProfile(ctx, game, packPath)
+	require.NoError(t, err)
+	assert.Zero(t, plan.Seeded)
+	assert.Len(t, plan.Installed, 1)
+}
+
+func TestPackProfile_RefusesUncachedMods(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir()}
+	seedInstalledMod(t, svc, game, "nexusmods", "42", "1.0", true, nil)
+	seedProfileWithMod(t, svc, game.ID, "default", "nexusmods", "42", "1.0")
+
+	var buf bytes.Buffer
+	_, err := svc.PackProfile(context.Background(), game, "default", &buf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nexusmods:42 1.0")
+	assert.Zero(t, buf.Len(), "nothing is written for a pack that could not be complete")
+}
+
+func TestUnpackProfile_RejectsUnsafeOrForeignPacks(t *testing.T) {
+	ctx := context.Background()
+	svc := newFlowsTestService(t)
+
+	game := &domain.Game{ID: "other", Name: "Other", ModPath: t.TempDir()}
+	_, err := svc.UnpackProfile(ctx, game, packFixture(t))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `pack is for game "g1"`)
+
+	writePack := func(manifest string, extra map[string]string) string {
+		p := filepath.Join(t.TempDir(), "evil.lmmpack")
+		f, err := os.Create(p)
+		require.NoError(t, err)
+		zw := zip.NewWriter(f)
+		files := map[string]string{"lmmpack.json": manifest, "profile.yaml": "name: default\ngame_id: g1\n"}
+		for k, v := range extra {
+			files[k] = v
+		}
+		for name, body := range files {
+			w, err := zw.Create(name)
+			require.NoError(t, err)
+			_, err = w.Write([]byte(body))
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+		require.NoError(t, f.Close())
+		return p
+	}
+	game = &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir()}
+
+	_, err = svc.UnpackProfile(ctx, game, writePack(`{"format":1,"game_id":"g1","mods":[{"source_id":"nexusmods","mod_id":"42","version":"../../x"}]}`, nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid source, mod ID or version")
+
+	_, err = svc.UnpackProfile(ctx, game, writePack(`{"format":1,"game_id":"g1","mods":[{"source_id":"nexusmods","mod_id":"42","version":"1.0"}]}`,
+		map[string]string{"mods/0/../../escape.txt": "x"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "escapes")
+	assert.False(t, svc.GetGameCache(game).Exists(game.ID, "nexusmods", "42", "1.0"))
+
+	_, err = svc.UnpackProfile(ctx, game, writePack(`{"format":99,"game_id":"g1"}`, nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported pack format 99")
+}
@@ -17,26 +17,14 @@ func ApplyProfileOverrides(game *domain.Game, profile *domain.Profile) error {
 	if len(profile.Overrides) == 0 {
 		return nil
 	}
-	base, err := filepath.Abs(game.InstallPath)
+	base, err := installBase(game)
 	if err != nil {
-		return fmt.Errorf("resolving game path: %w", err)
+		return err
 	}
-	base = filepath.Clean(base)
 	for relPath, content := range profile.Overrides {
-		// Reject absolute or empty paths
-		cleaned := filepath.Clean(filepath.FromSlash(relPath))
-		if cleaned == "" || filepath.IsAbs(cleaned) {
-			return fmt.Errorf("invalid override path: %q", relPath)
-		}
-		dest := filepath.Join(base, cleaned)
-		dest = filepath.Clean(dest)
-		// Ensure dest is under base (no path traversal)
-		rel, err := filepath.Rel(base, dest)
+		dest, err := confinedInstallPath(base, relPath, "override")
 		if err != nil {
-			return fmt.Errorf("override path %q: %w", relPath, err)
-		}
-		if strings.HasPrefix(rel, "..") || rel == ".." {
-			return fmt.Errorf("override path escapes game directory: %q", relPath)
+			return err
 		}
 		dir := filepath.Dir(dest)
 		if err := os.MkdirAll(dir, 0755); err != nil {
@@ -48,3 +36,33 @@ func ApplyProfileOverrides(game *domain.Game, profile *domain.Profile) error {
 	}
 	return nil
 }
+
+// installBase returns the game's install directory as a clean absolute path,
+// the root every profile-level file edit (overrides, INI patches) is
+// confined to.
+func installBase(game *domain.Game) (string, error) {
+	base, err := filepath.Abs(game.InstallPath)
+	if err != nil {
+		return "", fmt.Errorf("resolving game path: %w", err)
+	}
+	return filepath.Clean(base), nil
+}
+
+// confinedInstallPath joins relPath onto base, rejecting empty, absolute, or
+// escaping (../../../etc/passwd) paths. kind names the caller's concept
+// ("override", "ini patch") in the error.
+func confinedInstallPath(base, relPath, kind string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(relPath))
+	if relPath == "" || cleaned == "." || filepath.IsAbs(cleaned) {
+		return "", fmt.Errorf("invalid %s path: %q", kind, relPath)
+	}
+	dest := filepath.Clean(filepath.Join(base, cleaned))
+	rel, err := filepath.Rel(base, dest)
+	if err != nil {
+		return "", fmt.Errorf("%s path %q: %w", kind, relPath, err)
+	}
+	if strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s path escapes game directory: %q", kind, relPath)
+	}
+	return dest, nil
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/DonovanMods/linux-mod-manager/internal/domain"
 	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
@@ -26,19 +27,9 @@ func NewProfileManager(configDir string, database *db.DB) *ProfileManager {
 
 // Create creates a new profile for a game
 func (pm *ProfileManager) Create(gameID, name string) (*domain.Profile, error) {
-	// Check if profile already exists
-	_, err := config.LoadProfile(pm.configDir, gameID, name)
-	if err == nil {
-		return nil, fmt.Errorf("profile already exists: %s", name)
-	}
-	// The validation error is the user-facing message; don't bury it under
-	// the existence-check wrapping.
-	if errors.Is(err, domain.ErrInvalidProfileName) || errors.Is(err, domain.ErrInvalidGameID) {
+	if err := pm.checkNewProfile(gameID, name); err != nil {
 		return nil, err
 	}
-	if err != domain.ErrProfileNotFound {
-		return nil, fmt.Errorf("checking profile: %w", err)
-	}
 
 	profile := &domain.Profile{
 		Name:   name,
@@ -53,6 +44,45 @@ func (pm *ProfileManager) Create(gameID, name string) (*domain.Profile, error) {
 	return profile, nil
 }
 
+// Extend creates a profile that extends base. It starts out identical to
+// base's effective profile; only the changes made to it afterwards are
+// stored in it, so later changes to base carry through.
+func (pm *ProfileManager) Extend(gameID, name, base string) (*domain.Profile, error) {
+	if err := pm.checkNewProfile(gameID, name); err != nil {
+		return nil, err
+	}
+	parent, err := config.LoadProfile(pm.configDir, gameID, base)
+	if err != nil {
+		return nil, fmt.Errorf("loading base profile %s: %w", base, err)
+	}
+
+	profile := *parent
+	profile.Name = name
+	profile.Extends = base
+	profile.IsDefault = false
+	if err := config.SaveProfile(pm.configDir, &profile); err != nil {
+		return nil, fmt.Errorf("saving profile: %w", err)
+	}
+	return &profile, nil
+}
+
+// checkNewProfile fails unless name is a valid profile name not yet in use
+func (pm *ProfileManager) checkNewProfile(gameID, name string) error {
+	_, err := config.LoadProfileLayer(pm.configDir, gameID, name)
+	if err == nil {
+		return fmt.Errorf("profile already exists: %s", name)
+	}
+	// The validation error is the user-facing message; don't bury it under
+	// the existence-check wrapping.
+	if errors.Is(err, domain.ErrInvalidProfileName) || errors.Is(err, domain.ErrInvalidGameID) {
+		return err
+	}
+	if err != domain.ErrProfileNotFound {
+		return fmt.Errorf("checking profile: %w", err)
+	}
+	return nil
+}
+
 // List returns all profiles for a game
 func (pm *ProfileManager) List(gameID string) ([]*domain.Profile, error) {
 	names, err := config.ListProfiles(pm.configDir, gameID)
@@ -72,16 +102,79 @@ func (pm *ProfileManager) List(gameID string) ([]*domain.Profile, error) {
 	return profiles, nil
 }
 
-// Get retrieves a specific profile
+// Get retrieves a specific profile. A profile that extends another is
+// returned resolved: its base's mods and settings with its own changes
+// applied.
 func (pm *ProfileManager) Get(gameID, name string) (*domain.Profile, error) {
 	return config.LoadProfile(pm.configDir, gameID, name)
 }
 
-// Delete removes a profile
+// Delete removes a profile. A profile other profiles extend is refused,
+// since they would no longer resolve.
 func (pm *ProfileManager) Delete(gameID, name string) error {
+	children, err := pm.Extenders(gameID, name)
+	if err != nil {
+		return err
+	}
+	if len(children) > 0 {
+		return fmt.Errorf("%w: %s is extended by %s", domain.ErrProfileExtended, name, strings.Join(children, ", "))
+	}
 	return config.DeleteProfile(pm.configDir, gameID, name)
 }
 
+// Extenders returns the profiles that directly extend name
+func (pm *ProfileManager) Extenders(gameID, name string) ([]string, error) {
+	names, err := config.ListProfiles(pm.configDir, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("listing profiles: %w", err)
+	}
+	var children []string
+	for _, other := range names {
+		layer, err := config.LoadProfileLayer(pm.configDir, gameID, other)
+		if err != nil {
+			continue // Skip profiles that can't be loaded, as List does
+		}
+		if layer.Extends == name && other != name {
+			children = append(children, other)
+		}
+	}
+	return children, nil
+}
+
+// ModOrigins reports, for each mod of name's effective profile (keyed by
+// domain.ModKey), which profile in its extends chain supplies the
+// reference: name itself when it adds or overrides the mod, otherwise the
+// nearest base that lists it.
+func (pm *ProfileManager) ModOrigins(gameID, name string) (map[string]string, error) {
+	// Resolving first rejects extends cycles and missing bases, so the walk
+	// below terminates.
+	if _, err := config.LoadProfile(pm.configDir, gameID, name); err != nil {
+		return nil, err
+	}
+	var layers []*config.ProfileConfig
+	var names []string
+	for current := name; current != ""; {
+		layer, err := config.LoadProfileLayer(pm.configDir, gameID, current)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+		names = append(names, current)
+		current = layer.Extends
+	}
+
+	origins := make(map[string]string)
+	for i := len(layers) - 1; i >= 0; i-- {
+		for _, key := range layers[i].Remove {
+			delete(origins, domain.ModKey(key.SourceID, key.ModID))
+		}
+		for _, m := range layers[i].Mods {
+			origins[domain.ModKey(m.SourceID, m.ModID)] = names[i]
+		}
+	}
+	return origins, nil
+}
+
 // SetDefault sets a profile as the default for a game
 func (pm *ProfileManager) SetDefault(gameID, name string) error {
 	// Load the profile to verify it exists
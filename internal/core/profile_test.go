@@ -692,3 +692,83 @@ func TestProfileManager_ClearModLock_NotInProfile(t *testing.T) {
 	require.Error(t, err)
 	assert.EqualError(t, err, `mod nexusmods:12345 not found in profile "test"`)
 }
+
+func TestProfileManager_Extend(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.New(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, database.Close())
+	})
+	pm := core.NewProfileManager(dir, database)
+
+	_, err = pm.Create("skyrim-se", "base")
+	require.NoError(t, err)
+	for _, id := range []string{"skyui", "ussep", "enb"} {
+		require.NoError(t, pm.AddMod("skyrim-se", "base", domain.ModReference{SourceID: "nexusmods", ModID: id, Version: "1.0"}))
+	}
+
+	child, err := pm.Extend("skyrim-se", "survival", "base")
+	require.NoError(t, err)
+	assert.Equal(t, "base", child.Extends)
+	assert.Len(t, child.Mods, 3)
+
+	// The child's own edits: drop an inherited mod, override another's
+	// version, add one of its own.
+	require.NoError(t, pm.RemoveMod("skyrim-se", "survival", "nexusmods", "enb"))
+	require.NoError(t, pm.UpsertMod("skyrim-se", "survival", domain.ModReference{SourceID: "nexusmods", ModID: "ussep", Version: "2.0"}))
+	require.NoError(t, pm.AddMod("skyrim-se", "survival", domain.ModReference{SourceID: "nexusmods", ModID: "frostfall", Version: "1.0"}))
+
+	// A change to the base propagates; the child's edits stay.
+	require.NoError(t, pm.AddMod("skyrim-se", "base", domain.ModReference{SourceID: "nexusmods", ModID: "skse", Version: "1.0"}))
+	require.NoError(t, pm.UpsertMod("skyrim-se", "base", domain.ModReference{SourceID: "nexusmods", ModID: "skyui", Version: "5.2"}))
+
+	resolved, err := pm.Get("skyrim-se", "survival")
+	require.NoError(t, err)
+	var ids []string
+	for _, m := range resolved.Mods {
+		ids = append(ids, m.ModID+"@"+m.Version)
+	}
+	assert.Equal(t, []string{"skyui@5.2", "ussep@2.0", "skse@1.0", "frostfall@1.0"}, ids)
+
+	base, err := pm.Get("skyrim-se", "base")
+	require.NoError(t, err)
+	assert.Len(t, base.Mods, 4, "removing a mod from the child leaves the base alone")
+	assert.Equal(t, "1.0", base.FindRef("nexusmods", "ussep").Version)
+
+	origins, err := pm.ModOrigins("skyrim-se", "survival")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"nexusmods:skyui":     "base",
+		"nexusmods:ussep":     "survival",
+		"nexusmods:skse":      "base",
+		"nexusmods:frostfall": "survival",
+	}, origins)
+
+	_, err = pm.Extend("skyrim-se", "survival", "base")
+	assert.Error(t, err, "name already taken")
+	_, err = pm.Extend("skyrim-se", "visuals", "missing")
+	assert.ErrorIs(t, err, domain.ErrProfileNotFound)
+}
+
+func TestProfileManager_Delete_RefusesExtendedProfile(t *testing.T) {
+	dir := t.TempDir()
+	database, err := db.New(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, database.Close())
+	})
+	pm := core.NewProfileManager(dir, database)
+
+	_, err = pm.Create("skyrim-se", "base")
+	require.NoError(t, err)
+	_, err = pm.Extend("skyrim-se", "survival", "base")
+	require.NoError(t, err)
+
+	err = pm.Delete("skyrim-se", "base")
+	require.ErrorIs(t, err, domain.ErrProfileExtended)
+	assert.Contains(t, err.Error(), "survival")
+
+	require.NoError(t, pm.Delete("skyrim-se", "survival"))
+	require.NoError(t, pm.Delete("skyrim-se", "base"))
+}
@@ -0,0 +1,364 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+)
+
+// DiffKind is how one override or INI patch differs between two profiles.
+type DiffKind string
+
+const (
+	DiffAdded   DiffKind = "added"   // only the second profile has it
+	DiffRemoved DiffKind = "removed" // only the first profile has it
+	DiffChanged DiffKind = "changed" // both have it, with different content
+)
+
+// ProfileDiff is what changes going from one profile to another. Mods are
+// matched by source and mod ID; every list is in a stable order (load
+// order for mods, path/section/key order for the rest).
+type ProfileDiff struct {
+	Added   []domain.ModReference // in the second profile only, in its load order
+	Removed []domain.ModReference // in the first profile only, in its load order
+	Changed []ModRefChange        // in both, with a different version, file set or lock
+	Moved   []LoadOrderMove       // in both, but ordered differently relative to the other shared mods
+	// LinkMethod is set when the profiles' link_method settings differ.
+	LinkMethod *LinkMethodChange
+	Overrides  []OverrideChange
+	IniPatches []IniPatchChange
+}
+
+// ModRefChange is a mod both profiles list with different references.
+type ModRefChange struct {
+	From, To domain.ModReference
+}
+
+// LoadOrderMove is a shared mod whose place in the load order differs.
+// Positions are 1-based, in each profile's full mod list.
+type LoadOrderMove struct {
+	SourceID, ModID string
+	From, To        int
+}
+
+// LinkMethodChange is a link_method difference; an empty side means the
+// profile sets none and inherits the game's.
+type LinkMethodChange struct {
+	From, To string
+}
+
+// OverrideChange is an override file that differs between the profiles.
+type OverrideChange struct {
+	Path string
+	Kind DiffKind
+}
+
+// IniPatchChange is an INI patch key that differs between the profiles.
+// From and To are the patch values, nil for a patch deleting the key (or
+// for the side that lacks the key, per Kind).
+type IniPatchChange struct {
+	File, Section, Key string
+	Kind               DiffKind
+	From, To           *string
+}
+
+// Empty reports whether the two profiles are equivalent.
+func (d *ProfileDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 && len(d.Moved) == 0 &&
+		d.LinkMethod == nil && len(d.Overrides) == 0 && len(d.IniPatches) == 0
+}
+
+// DiffProfiles compares from with to. A version or file set one side leaves
+// empty counts as unspecified rather than different: an exported profile
+// carries file IDs a hand-written one usually omits, and an unpinned
+// reference matches whatever is installed.
+func DiffProfiles(from, to *domain.Profile) *ProfileDiff {
+	diff := &ProfileDiff{}
+
+	fromRefs := make(map[string]domain.ModReference, len(from.Mods))
+	for _, m := range from.Mods {
+		fromRefs[domain.ModKey(m.SourceID, m.ModID)] = m
+	}
+	toRefs := make(map[string]domain.ModReference, len(to.Mods))
+	for _, m := range to.Mods {
+		toRefs[domain.ModKey(m.SourceID, m.ModID)] = m
+	}
+	for _, m := range from.Mods {
+		if _, ok := toRefs[domain.ModKey(m.SourceID, m.ModID)]; !ok {
+			diff.Removed = append(diff.Removed, m)
+		}
+	}
+	for _, m := range to.Mods {
+		old, ok := fromRefs[domain.ModKey(m.SourceID, m.ModID)]
+		if !ok {
+			diff.Added = append(diff.Added, m)
+			continue
+		}
+		if !sameSpecified(old.Version, m.Version) || old.Locked != m.Locked ||
+			(len(old.FileIDs) > 0 && len(m.FileIDs) > 0 && !slices.Equal(old.FileIDs, m.FileIDs)) {
+			diff.Changed = append(diff.Changed, ModRefChange{From: old, To: m})
+		}
+	}
+	diff.Moved = diffLoadOrder(from.Mods, to.Mods)
+
+	if fromMethod, toMethod := profileLinkMethod(from), profileLinkMethod(to); fromMethod != toMethod {
+		diff.LinkMethod = &LinkMethodChange{From: fromMethod, To: toMethod}
+	}
+
+	for _, path := range sortedKeys(mergeKeys(from.Overrides, to.Overrides)) {
+		old, inFrom := from.Overrides[path]
+		cur, inTo := to.Overrides[path]
+		switch {
+		case !inFrom:
+			diff.Overrides = append(diff.Overrides, OverrideChange{Path: path, Kind: DiffAdded})
+		case !inTo:
+			diff.Overrides = append(diff.Overrides, OverrideChange{Path: path, Kind: DiffRemoved})
+		case !bytes.Equal(old, cur):
+			diff.Overrides = append(diff.Overrides, OverrideChange{Path: path, Kind: DiffChanged})
+		}
+	}
+
+	for _, file := range sortedKeys(mergeKeys(from.IniPatches, to.IniPatches)) {
+		for _, section := range sortedKeys(mergeKeys(from.IniPatches[file], to.IniPatches[file])) {
+			fromKeys, toKeys := from.IniPatches[file][section], to.IniPatches[file][section]
+			for _, key := range sortedKeys(mergeKeys(fromKeys, toKeys)) {
+				old, inFrom := fromKeys[key]
+				cur, inTo := toKeys[key]
+				change := IniPatchChange{File: file, Section: section, Key: key, From: old, To: cur}
+				switch {
+				case !inFrom:
+					change.Kind = DiffAdded
+				case !inTo:
+					change.Kind = DiffRemoved
+				case (old == nil) != (cur == nil) || (old != nil && *old != *cur):
+					change.Kind = DiffChanged
+				default:
+					continue
+				}
+				diff.IniPatches = append(diff.IniPatches, change)
+			}
+		}
+	}
+
+	return diff
+}
+
+// DeployedProfile describes what is actually deployed for profileName as a
+// profile, for DiffProfiles to compare the profile against: its deployed
+// mods with their installed versions and files (in the profile's load
+// order, then any the profile no longer lists), the link method they were
+// deployed with, and the current content of every override file and INI
+// key the profile sets. Locks come from the profile itself, since a lock is
+// not deployed state.
+func (s *Service) DeployedProfile(game *domain.Game, profileName string) (*domain.Profile, error) {
+	profile, err := config.LoadProfile(s.configDir, game.ID, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("loading profile: %w", err)
+	}
+	installed, err := s.db.GetInstalledMods(game.ID, profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	deployed := &domain.Profile{Name: profileName, GameID: game.ID}
+	byKey := make(map[string]domain.InstalledMod)
+	for _, m := range installed {
+		if m.Deployed {
+			byKey[domain.ModKey(m.SourceID, m.ID)] = m
+		}
+	}
+	ref := func(m domain.InstalledMod) domain.ModReference {
+		r := domain.ModReference{SourceID: m.SourceID, ModID: m.ID, Version: m.Version, FileIDs: m.FileIDs}
+		if p := profile.FindRef(m.SourceID, m.ID); p != nil {
+			r.Locked = p.Locked
+		}
+		return r
+	}
+	methods := make(map[domain.LinkMethod]int)
+	for _, p := range profile.Mods {
+		key := domain.ModKey(p.SourceID, p.ModID)
+		if m, ok := byKey[key]; ok {
+			deployed.Mods = append(deployed.Mods, ref(m))
+			methods[m.LinkMethod]++
+			delete(byKey, key)
+		}
+	}
+	for _, m := range installed {
+		if _, ok := byKey[domain.ModKey(m.SourceID, m.ID)]; ok {
+			deployed.Mods = append(deployed.Mods, ref(m))
+			methods[m.LinkMethod]++
+		}
+	}
+
+	// The profile's own setting stands unless the mods mostly went out
+	// some other way; then the method most of them used is reported.
+	deployed.LinkMethod, deployed.LinkMethodExplicit = profile.LinkMethod, profile.LinkMethodExplicit
+	effective, err := s.GetEffectiveLinkMethod(game, profileName)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) > 0 {
+		used := effective
+		for method, count := range methods {
+			if count > methods[used] || (count == methods[used] && used != effective && method < used) {
+				used = method
+			}
+		}
+		if used != effective {
+			deployed.LinkMethod, deployed.LinkMethodExplicit = used, true
+		}
+	}
+
+	if len(profile.Overrides) == 0 && len(profile.IniPatches) == 0 {
+		return deployed, nil
+	}
+	base, err := installBase(game)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range sortedKeys(profile.Overrides) {
+		dest, err := confinedInstallPath(base, path, "override")
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(dest)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		if deployed.Overrides == nil {
+			deployed.Overrides = make(map[string][]byte)
+		}
+		deployed.Overrides[path] = data
+	}
+	for _, file := range sortedKeys(profile.IniPatches) {
+		dest, err := confinedInstallPath(base, file, "ini patch")
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(dest)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("reading %s: %w", file, err)
+		}
+		lines, _, _ := splitINILines(data)
+		parsed := parseINILines(lines)
+		for section, keys := range profile.IniPatches[file] {
+			for key, want := range keys {
+				value, present := lookupINIKey(lines, parsed, section, key)
+				// A key a patch deletes matches by being absent.
+				var current *string
+				switch {
+				case present:
+					current = &value
+				case want != nil:
+					continue
+				}
+				if deployed.IniPatches == nil {
+					deployed.IniPatches = make(domain.IniPatches)
+				}
+				if deployed.IniPatches[file] == nil {
+					deployed.IniPatches[file] = make(map[string]map[string]*string)
+				}
+				if deployed.IniPatches[file][section] == nil {
+					deployed.IniPatches[file][section] = make(map[string]*string)
+				}
+				deployed.IniPatches[file][section][key] = current
+			}
+		}
+	}
+	return deployed, nil
+}
+
+// diffLoadOrder reports the shared mods that moved: those outside the
+// longest common subsequence of the two load orders, so moving one mod to
+// the end reports that mod alone, not every mod it skipped past.
+func diffLoadOrder(from, to []domain.ModReference) []LoadOrderMove {
+	fromPos := make(map[string]int, len(from))
+	for i, m := range from {
+		fromPos[domain.ModKey(m.SourceID, m.ModID)] = i + 1
+	}
+	toPos := make(map[string]int, len(to))
+	for i, m := range to {
+		toPos[domain.ModKey(m.SourceID, m.ModID)] = i + 1
+	}
+	var a, b []string
+	for _, m := range from {
+		if key := domain.ModKey(m.SourceID, m.ModID); toPos[key] > 0 {
+			a = append(a, key)
+		}
+	}
+	for _, m := range to {
+		if key := domain.ModKey(m.SourceID, m.ModID); fromPos[key] > 0 {
+			b = append(b, key)
+		}
+	}
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	stay := make(map[string]bool)
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			stay[a[i]] = true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	var moves []LoadOrderMove
+	for _, m := range to {
+		key := domain.ModKey(m.SourceID, m.ModID)
+		if fromPos[key] > 0 && !stay[key] {
+			moves = append(moves, LoadOrderMove{SourceID: m.SourceID, ModID: m.ModID, From: fromPos[key], To: toPos[key]})
+		}
+	}
+	return moves
+}
+
+// profileLinkMethod is a profile's link_method setting as written, "" when
+// it sets none.
+func profileLinkMethod(p *domain.Profile) string {
+	if !p.LinkMethodExplicit {
+		return ""
+	}
+	return p.LinkMethod.String()
+}
+
+func sameSpecified(a, b string) bool {
+	return a == "" || b == "" || a == b
+}
+
+// mergeKeys returns the union of a's and b's keys, for sortedKeys to order.
+func mergeKeys[V any](a, b map[string]V) map[string]bool {
+	keys := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	return keys
+}
@@ -0,0 +1,115 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func refs(ids ...string) []domain.ModReference {
+	mods := make([]domain.ModReference, len(ids))
+	for i, id := range ids {
+		mods[i] = domain.ModReference{SourceID: "src", ModID: id, Version: "1.0"}
+	}
+	return mods
+}
+
+func TestDiffProfiles(t *testing.T) {
+	from := &domain.Profile{
+		Name:      "a",
+		Mods:      refs("m1", "m2", "m3", "m4"),
+		Overrides: map[string][]byte{"same.ini": []byte("x"), "gone.ini": []byte("x"), "edit.ini": []byte("x")},
+		IniPatches: domain.IniPatches{"prefs.ini": {"Display": {
+			"fov": strPtr("90"), "vsync": strPtr("1"), "old": nil,
+		}}},
+	}
+	to := &domain.Profile{
+		Name:               "b",
+		Mods:               refs("m2", "m3", "m4", "m1", "m5"),
+		LinkMethod:         domain.LinkHardlink,
+		LinkMethodExplicit: true,
+		Overrides:          map[string][]byte{"same.ini": []byte("x"), "edit.ini": []byte("y"), "new.ini": []byte("x")},
+		IniPatches: domain.IniPatches{"prefs.ini": {"Display": {
+			"fov": strPtr("110"), "old": nil, "gamma": strPtr("2"),
+		}}},
+	}
+	to.Mods = append(to.Mods[:1], to.Mods[2:]...) // drop m3
+	to.Mods[0].Version = "2.0"                    // m2
+	to.Mods[1].Locked = true                      // m4
+
+	diff := core.DiffProfiles(from, to)
+	require.False(t, diff.Empty())
+	assert.Equal(t, refs("m5"), diff.Added)
+	assert.Equal(t, refs("m3"), diff.Removed)
+	require.Len(t, diff.Changed, 2)
+	assert.Equal(t, "m2", diff.Changed[0].To.ModID)
+	assert.Equal(t, "2.0", diff.Changed[0].To.Version)
+	assert.True(t, diff.Changed[1].To.Locked)
+	assert.Equal(t, []core.LoadOrderMove{{SourceID: "src", ModID: "m1", From: 1, To: 3}}, diff.Moved,
+		"moving one mod to the end reports that mod alone")
+	assert.Equal(t, &core.LinkMethodChange{From: "", To: "hardlink"}, diff.LinkMethod)
+	assert.Equal(t, []core.OverrideChange{
+		{Path: "edit.ini", Kind: core.DiffChanged},
+		{Path: "gone.ini", Kind: core.DiffRemoved},
+		{Path: "new.ini", Kind: core.DiffAdded},
+	}, diff.Overrides)
+	assert.Equal(t, []core.IniPatchChange{
+		{File: "prefs.ini", Section: "Display", Key: "fov", Kind: core.DiffChanged, From: strPtr("90"), To: strPtr("110")},
+		{File: "prefs.ini", Section: "Display", Key: "gamma", Kind: core.DiffAdded, To: strPtr("2")},
+		{File: "prefs.ini", Section: "Display", Key: "vsync", Kind: core.DiffRemoved, From: strPtr("1")},
+	}, diff.IniPatches)
+
+	assert.True(t, core.DiffProfiles(from, from).Empty())
+}
+
+func TestDiffProfiles_UnspecifiedVersionAndFilesMatch(t *testing.T) {
+	from := &domain.Profile{Mods: []domain.ModReference{{SourceID: "src", ModID: "m1"}}}
+	to := &domain.Profile{Mods: []domain.ModReference{{SourceID: "src", ModID: "m1", Version: "1.0", FileIDs: []string{"f1"}}}}
+	assert.True(t, core.DiffProfiles(from, to).Empty())
+}
+
+func TestDeployedProfile_ComparesAgainstTheGame(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := newSnapshotGame(t, svc)
+	pm := svc.NewProfileManager()
+
+	profile, err := pm.Get(game.ID, "default")
+	require.NoError(t, err)
+	profile.Overrides = map[string][]byte{"game.ini": []byte("x=1")}
+	profile.IniPatches = domain.IniPatches{"prefs.ini": {"Display": {"fov": strPtr("90"), "old": nil}}}
+	require.NoError(t, config.SaveProfile(svc.ConfigDir(), profile))
+	_, err = svc.DeployProfile(context.Background(), game, "default", core.DeployOptions{}, nil)
+	require.NoError(t, err)
+
+	deployed, err := svc.DeployedProfile(game, "default")
+	require.NoError(t, err)
+	assert.True(t, core.DiffProfiles(profile, deployed).Empty(), "a freshly deployed profile matches the game")
+
+	// Drift: a mod added but never deployed, an override and a key edited
+	// in the game directory.
+	seedInstalledMod(t, svc, game, "src", "m2", "1.0", true, map[string][]byte{"m2.esp": []byte("two")})
+	require.NoError(t, pm.AddMod(game.ID, "default", domain.ModReference{SourceID: "src", ModID: "m2", Version: "1.0"}))
+	require.NoError(t, os.WriteFile(filepath.Join(game.InstallPath, "game.ini"), []byte("x=2"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(game.InstallPath, "prefs.ini"), []byte("[Display]\nfov=75\n"), 0644))
+
+	profile, err = pm.Get(game.ID, "default")
+	require.NoError(t, err)
+	deployed, err = svc.DeployedProfile(game, "default")
+	require.NoError(t, err)
+	diff := core.DiffProfiles(profile, deployed)
+	assert.Equal(t, "m2", diff.Removed[0].ModID)
+	assert.Empty(t, diff.Added)
+	assert.Equal(t, []core.OverrideChange{{Path: "game.ini", Kind: core.DiffChanged}}, diff.Overrides)
+	assert.Equal(t, []core.IniPatchChange{
+		{File: "prefs.ini", Section: "Display", Key: "fov", Kind: core.DiffChanged, From: strPtr("90"), To: strPtr("75")},
+	}, diff.IniPatches)
+	assert.Nil(t, diff.LinkMethod)
+}
@@ -0,0 +1,352 @@
+package core
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+)
+
+// savesStoreSuffix names the directory, next to a game's saves_path, that
+// holds every INACTIVE profile's saves when save_isolation is on. It is a
+// sibling rather than a subdirectory of the data dir so that parking and
+// restoring a profile's saves is a pair of same-filesystem os.Rename calls:
+// atomic, instant regardless of save size, and never a half-copied tree.
+const savesStoreSuffix = ".lmm-profiles"
+
+// saveBackupIDFormat is the UTC timestamp a backup is named after. It sorts
+// lexically in creation order, which is all ListSaveBackups relies on.
+const saveBackupIDFormat = "20060102T150405Z"
+
+// SaveBackup describes one timestamped save-game snapshot.
+type SaveBackup struct {
+	ID        string // timestamp ID, e.g. 20261018T153045Z (a -N suffix disambiguates same-second backups)
+	Profile   string
+	Path      string // the .zip archive under <data>/saves/<game>/<profile>/
+	CreatedAt time.Time
+	Size      int64
+}
+
+// savesStoreDir returns the directory holding inactive profiles' saves.
+func savesStoreDir(game *domain.Game) string {
+	return filepath.Clean(game.SavesPath) + savesStoreSuffix
+}
+
+// swapProfileSaves parks the live saves_path as from's saves and moves to's
+// parked saves into place (or creates an empty saves_path when to has none
+// yet - a profile's first activation starts from a clean slate). If bringing
+// to's saves in fails, from's are moved back so the live directory is never
+// left missing.
+func swapProfileSaves(game *domain.Game, from, to string) error {
+	live := filepath.Clean(game.SavesPath)
+	store := savesStoreDir(game)
+	if err := os.MkdirAll(store, 0755); err != nil {
+		return fmt.Errorf("creating profile saves store: %w", err)
+	}
+	parked := filepath.Join(store, from)
+	incoming := filepath.Join(store, to)
+
+	parkedLive := false
+	if _, err := os.Lstat(live); err == nil {
+		// Never merge into (or clobber) an existing parked tree: that would
+		// only happen if the store was edited by hand or a previous swap
+		// was interrupted, and either way a human should look first.
+		if _, err := os.Lstat(parked); err == nil {
+			return fmt.Errorf("parking saves for profile %s: %s already exists", from, parked)
+		}
+		if err := os.Rename(live, parked); err != nil {
+			return fmt.Errorf("parking saves for profile %s: %w", from, err)
+		}
+		parkedLive = true
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("checking saves path: %w", err)
+	}
+
+	var bringErr error
+	if _, err := os.Lstat(incoming); err == nil {
+		bringErr = os.Rename(incoming, live)
+	} else if errors.Is(err, os.ErrNotExist) {
+		bringErr = os.MkdirAll(live, 0755)
+	} else {
+		bringErr = err
+	}
+	if bringErr != nil {
+		bringErr = fmt.Errorf("restoring saves for profile %s: %w", to, bringErr)
+		if parkedLive {
+			if rbErr := os.Rename(parked, live); rbErr != nil {
+				return &domain.DeployError{Primary: bringErr, Rollback: rbErr}
+			}
+		}
+		return bringErr
+	}
+	return nil
+}
+
+// activeProfileName returns the game's default profile name, or "default"
+// when none exists - the same fallback PlanProfileSwitch uses for From.
+func (s *Service) activeProfileName(gameID string) string {
+	if p, err := s.NewProfileManager().GetDefault(gameID); err == nil {
+		return p.Name
+	}
+	return "default"
+}
+
+// profileSavesLocation returns where profileName's saves currently live: the
+// game's saves_path when isolation is off (every profile shares it) or the
+// profile is active, otherwise its parked directory in the store.
+func (s *Service) profileSavesLocation(game *domain.Game, profileName string) (string, error) {
+	if game.SavesPath == "" {
+		return "", fmt.Errorf("game %s: %w", game.ID, domain.ErrSavesNotConfigured)
+	}
+	if !game.SaveIsolation || profileName == s.activeProfileName(game.ID) {
+		return filepath.Clean(game.SavesPath), nil
+	}
+	return filepath.Join(savesStoreDir(game), profileName), nil
+}
+
+// checkSavesProfile validates profileName as an existing profile of game
+// (which also keeps it a single safe path segment). The implicit "default"
+// name is accepted for games that have no profiles yet.
+func (s *Service) checkSavesProfile(game *domain.Game, profileName string) error {
+	if _, err := s.NewProfileManager().Get(game.ID, profileName); err != nil {
+		if profileName == "default" && errors.Is(err, domain.ErrProfileNotFound) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// saveBackupsDir returns the directory holding profileName's save backups.
+func (s *Service) saveBackupsDir(gameID, profileName string) string {
+	return filepath.Join(s.dataDir, "saves", gameID, profileName)
+}
+
+// BackupSaves writes a timestamped zip snapshot of profileName's saves
+// (wherever they currently live - see profileSavesLocation). A profile whose
+// saves directory does not exist yet is backed up as an empty archive, so
+// the call never fails merely because the game hasn't been played.
+func (s *Service) BackupSaves(game *domain.Game, profileName string) (*SaveBackup, error) {
+	if err := s.checkSavesProfile(game, profileName); err != nil {
+		return nil, err
+	}
+	src, err := s.profileSavesLocation(game, profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := s.saveBackupsDir(game.ID, profileName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating save backup dir: %w", err)
+	}
+
+	now := time.Now().UTC()
+	id := now.Format(saveBackupIDFormat)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(filepath.Join(dir, id+".zip")); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		id = fmt.Sprintf("%s-%d", now.Format(saveBackupIDFormat), n)
+	}
+	dest := filepath.Join(dir, id+".zip")
+
+	tmp, err := os.CreateTemp(dir, ".lmm-backup-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("creating save backup: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() { _ = os.Remove(tmpPath) }() // no-op once renamed into place
+
+	if err := writeSavesZip(tmp, src); err != nil {
+		_ = tmp.Close()
+		return nil, fmt.Errorf("writing save backup: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("closing save backup: %w", err)
+	}
+	if err := os.Rename(tmpPath, dest); err != nil {
+		return nil, fmt.Errorf("finalizing save backup: %w", err)
+	}
+
+	info, err := os.Stat(dest)
+	if err != nil {
+		return nil, fmt.Errorf("reading save backup: %w", err)
+	}
+	return &SaveBackup{ID: id, Profile: profileName, Path: dest, CreatedAt: now, Size: info.Size()}, nil
+}
+
+// writeSavesZip archives every regular file and directory under root into w.
+// Symlinks and other special files are skipped: save directories don't
+// contain them in practice, and following one could pull in data from
+// outside the saves tree. A missing root produces an empty archive.
+func writeSavesZip(w io.Writer, root string) error {
+	zw := zip.NewWriter(w)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root && errors.Is(err, os.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if path == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		switch {
+		case d.IsDir():
+			_, err := zw.Create(name + "/")
+			return err
+		case d.Type().IsRegular():
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			hdr, err := zip.FileInfoHeader(info)
+			if err != nil {
+				return err
+			}
+			hdr.Name = name
+			hdr.Method = zip.Deflate
+			fw, err := zw.CreateHeader(hdr)
+			if err != nil {
+				return err
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(fw, f)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			return err
+		default:
+			return nil
+		}
+	})
+	if err != nil {
+		_ = zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// ListSaveBackups returns profileName's save backups, oldest first. A
+// profile that has never been backed up returns an empty list.
+func (s *Service) ListSaveBackups(game *domain.Game, profileName string) ([]SaveBackup, error) {
+	if err := s.checkSavesProfile(game, profileName); err != nil {
+		return nil, err
+	}
+	dir := s.saveBackupsDir(game.ID, profileName)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading save backups: %w", err)
+	}
+	var backups []SaveBackup
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".zip") || strings.HasPrefix(name, ".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, fmt.Errorf("reading save backup %s: %w", name, err)
+		}
+		id := strings.TrimSuffix(name, ".zip")
+		created := info.ModTime().UTC()
+		if ts, err := time.Parse(saveBackupIDFormat, strings.SplitN(id, "-", 2)[0]); err == nil {
+			created = ts
+		}
+		backups = append(backups, SaveBackup{
+			ID: id, Profile: profileName, Path: filepath.Join(dir, name),
+			CreatedAt: created, Size: info.Size(),
+		})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].ID < backups[j].ID })
+	return backups, nil
+}
+
+// RestoreSaves replaces profileName's saves with the contents of backup id.
+// The current saves are backed up first (returned as safety, nil when there
+// was nothing to back up), the archive is extracted beside the target, and
+// only then swapped in by rename - a failed extraction leaves the existing
+// saves untouched.
+func (s *Service) RestoreSaves(game *domain.Game, profileName, id string) (safety *SaveBackup, err error) {
+	if err := s.checkSavesProfile(game, profileName); err != nil {
+		return nil, err
+	}
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return nil, fmt.Errorf("invalid backup ID: %q", id)
+	}
+	archive := filepath.Join(s.saveBackupsDir(game.ID, profileName), id+".zip")
+	if _, err := os.Stat(archive); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("save backup %s not found for profile %s", id, profileName)
+		}
+		return nil, fmt.Errorf("reading save backup: %w", err)
+	}
+	target, err := s.profileSavesLocation(game, profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if entries, err := os.ReadDir(target); err == nil && len(entries) > 0 {
+		if safety, err = s.BackupSaves(game, profileName); err != nil {
+			return nil, fmt.Errorf("backing up current saves before restore: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return safety, fmt.Errorf("creating saves parent dir: %w", err)
+	}
+	staging, err := os.MkdirTemp(filepath.Dir(target), filepath.Base(target)+".lmm-restore-")
+	if err != nil {
+		return safety, fmt.Errorf("creating restore staging dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }() // no-op once renamed into place
+
+	if err := s.extractor.Extract(archive, staging); err != nil {
+		return safety, fmt.Errorf("extracting save backup: %w", err)
+	}
+
+	old := target + ".lmm-old"
+	if err := os.RemoveAll(old); err != nil {
+		return safety, fmt.Errorf("clearing stale restore leftovers: %w", err)
+	}
+	hadTarget := false
+	if err := os.Rename(target, old); err == nil {
+		hadTarget = true
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return safety, fmt.Errorf("moving current saves aside: %w", err)
+	}
+	if err := os.Rename(staging, target); err != nil {
+		err = fmt.Errorf("moving restored saves into place: %w", err)
+		if hadTarget {
+			if rbErr := os.Rename(old, target); rbErr != nil {
+				return safety, &domain.DeployError{Primary: err, Rollback: rbErr}
+			}
+		}
+		return safety, err
+	}
+	if hadTarget {
+		if err := os.RemoveAll(old); err != nil {
+			return safety, fmt.Errorf("removing previous saves (restore succeeded; %s can be deleted): %w", old, err)
+		}
+	}
+	return safety, nil
+}
@@ -0,0 +1,174 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIsolatedSavesGame returns a save_isolation game with "default" (active)
+// and "other" profiles, whose live saves_path holds one save file.
+func newIsolatedSavesGame(t *testing.T, svc *core.Service) *domain.Game {
+	t.Helper()
+	game := &domain.Game{
+		ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkSymlink,
+		SavesPath: filepath.Join(t.TempDir(), "Saves"), SaveIsolation: true,
+	}
+	pm := svc.NewProfileManager()
+	_, err := pm.Create(game.ID, "default")
+	require.NoError(t, err)
+	require.NoError(t, pm.SetDefault(game.ID, "default"))
+	_, err = pm.Create(game.ID, "other")
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(game.SavesPath, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(game.SavesPath, "slot1.sav"), []byte("default-save"), 0644))
+	return game
+}
+
+func TestApplyProfileSwitch_SwapsIsolatedSaves(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := newIsolatedSavesGame(t, svc)
+	ctx := context.Background()
+
+	plan, err := svc.PlanProfileSwitch(ctx, game, "other")
+	require.NoError(t, err)
+	require.True(t, plan.SwapSaves)
+
+	result, err := svc.ApplyProfileSwitch(ctx, game, plan, nil)
+	require.NoError(t, err)
+	assert.True(t, result.SavesSwapped)
+
+	// "other" has never been active: it starts from an empty saves dir.
+	entries, err := os.ReadDir(game.SavesPath)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	require.NoError(t, os.WriteFile(filepath.Join(game.SavesPath, "slot1.sav"), []byte("other-save"), 0644))
+
+	plan, err = svc.PlanProfileSwitch(ctx, game, "default")
+	require.NoError(t, err)
+	_, err = svc.ApplyProfileSwitch(ctx, game, plan, nil)
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(filepath.Join(game.SavesPath, "slot1.sav"))
+	require.NoError(t, err)
+	assert.Equal(t, "default-save", string(data))
+	data, err = os.ReadFile(filepath.Join(game.SavesPath+".lmm-profiles", "other", "slot1.sav"))
+	require.NoError(t, err)
+	assert.Equal(t, "other-save", string(data))
+}
+
+func TestApplyProfileSwitch_FailedSwitchSwapsSavesBack(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := newIsolatedSavesGame(t, svc)
+
+	// An enabled mod absent from "other" gives the switch a disable loop,
+	// whose cancellation check fails it after the saves were swapped.
+	seedInstalledMod(t, svc, game, "src", "m1", "1.0", true, map[string][]byte{"m1.esp": []byte("x")})
+	require.NoError(t, svc.NewProfileManager().AddMod(game.ID, "default", domain.ModReference{SourceID: "src", ModID: "m1", Version: "1.0"}))
+
+	plan, err := svc.PlanProfileSwitch(context.Background(), game, "other")
+	require.NoError(t, err)
+	require.NotEmpty(t, plan.ToDisable)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := svc.ApplyProfileSwitch(ctx, game, plan, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.False(t, result.SavesSwapped)
+
+	data, err := os.ReadFile(filepath.Join(game.SavesPath, "slot1.sav"))
+	require.NoError(t, err)
+	assert.Equal(t, "default-save", string(data))
+}
+
+func TestPlanProfileSwitch_NoSwapWithoutIsolation(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := newIsolatedSavesGame(t, svc)
+	game.SaveIsolation = false
+
+	plan, err := svc.PlanProfileSwitch(context.Background(), game, "other")
+	require.NoError(t, err)
+	assert.False(t, plan.SwapSaves)
+}
+
+func TestSaveBackups_BackupListRestore(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := newIsolatedSavesGame(t, svc)
+
+	backup, err := svc.BackupSaves(game, "default")
+	require.NoError(t, err)
+	require.FileExists(t, backup.Path)
+
+	// Same-second backups get distinct IDs.
+	second, err := svc.BackupSaves(game, "default")
+	require.NoError(t, err)
+	assert.NotEqual(t, backup.ID, second.ID)
+
+	backups, err := svc.ListSaveBackups(game, "default")
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, backup.ID, backups[0].ID)
+
+	require.NoError(t, os.WriteFile(filepath.Join(game.SavesPath, "slot1.sav"), []byte("ruined"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(game.SavesPath, "slot2.sav"), []byte("new"), 0644))
+
+	safety, err := svc.RestoreSaves(game, "default", backup.ID)
+	require.NoError(t, err)
+	require.NotNil(t, safety, "non-empty saves are backed up before being replaced")
+
+	data, err := os.ReadFile(filepath.Join(game.SavesPath, "slot1.sav"))
+	require.NoError(t, err)
+	assert.Equal(t, "default-save", string(data))
+	assert.NoFileExists(t, filepath.Join(game.SavesPath, "slot2.sav"))
+
+	backups, err = svc.ListSaveBackups(game, "default")
+	require.NoError(t, err)
+	assert.Len(t, backups, 3)
+}
+
+func TestSaveBackups_InactiveProfileUsesParkedSaves(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := newIsolatedSavesGame(t, svc)
+	ctx := context.Background()
+
+	plan, err := svc.PlanProfileSwitch(ctx, game, "other")
+	require.NoError(t, err)
+	_, err = svc.ApplyProfileSwitch(ctx, game, plan, nil)
+	require.NoError(t, err)
+
+	backup, err := svc.BackupSaves(game, "default")
+	require.NoError(t, err)
+	require.NoError(t, os.RemoveAll(filepath.Join(game.SavesPath+".lmm-profiles", "default")))
+
+	_, err = svc.RestoreSaves(game, "default", backup.ID)
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(game.SavesPath+".lmm-profiles", "default", "slot1.sav"))
+	require.NoError(t, err)
+	assert.Equal(t, "default-save", string(data))
+	entries, err := os.ReadDir(game.SavesPath)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "restoring an inactive profile must not touch the live saves")
+}
+
+func TestSaveBackups_Errors(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := newIsolatedSavesGame(t, svc)
+
+	_, err := svc.RestoreSaves(game, "default", "20990101T000000Z")
+	require.ErrorContains(t, err, "not found")
+	_, err = svc.RestoreSaves(game, "default", "../escape")
+	require.ErrorContains(t, err, "invalid backup ID")
+	_, err = svc.BackupSaves(game, "missing")
+	require.ErrorIs(t, err, domain.ErrProfileNotFound)
+
+	game.SavesPath = ""
+	_, err = svc.BackupSaves(game, "default")
+	require.ErrorIs(t, err, domain.ErrSavesNotConfigured)
+}
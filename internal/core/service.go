@@ -3,6 +3,7 @@ package core
 import (
 	"context"
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -36,6 +37,8 @@ type ServiceConfig struct {
 type DownloadModResult struct {
 	FilesExtracted int    // Number of files extracted
 	Checksum       string // MD5 hash of downloaded archive
+	SHA256         string // SHA-256 of downloaded archive (empty for directory ingests)
+	Size           int64  // Size of downloaded archive in bytes (0 for directory ingests)
 }
 
 // Service is the main orchestrator for mod management operations
@@ -77,10 +80,13 @@ func NewService(cfg ServiceConfig) (*Service, error) {
 		return nil, fmt.Errorf("loading games: %w", err)
 	}
 
+	globalCache := cache.New(cfg.CacheDir)
+	globalCache.SetDedup(appConfig.CacheDedup)
+
 	return &Service{
 		config:     appConfig,
 		db:         database,
-		cache:      cache.New(cfg.CacheDir),
+		cache:      globalCache,
 		registry:   source.NewRegistry(),
 		games:      games,
 		downloader: NewDownloader(nil),
@@ -91,8 +97,14 @@ func NewService(cfg ServiceConfig) (*Service, error) {
 	}, nil
 }
 
-// Close releases resources held by the service
+// Close releases resources held by the service, including any source that
+// holds its own (an exec source's running plugin).
 func (s *Service) Close() error {
+	for _, src := range s.registry.List() {
+		if c, ok := src.(io.Closer); ok {
+			_ = c.Close()
+		}
+	}
 	if s.db != nil {
 		return s.db.Close()
 	}
@@ -553,18 +565,9 @@ func (s *Service) DownloadModToCache(ctx context.Context, gameCache *cache.Cache
 	// left untouched for display purposes (the SHA256 mismatch message).
 	safeFileName := filepath.Base(file.FileName)
 	archivePath := filepath.Join(tempDir, safeFileName)
-	var headers map[string]string
-	if hp, ok := src.(source.DownloadHeaderProvider); ok {
-		headers = hp.DownloadHeaders(url)
-	}
-	downloadResult, err := s.downloader.DownloadWithHeaders(ctx, url, archivePath, headers, progressFn)
+	downloadResult, err := s.downloadFile(ctx, src, file, url, archivePath, progressFn)
 	if err != nil {
-		return nil, fmt.Errorf("downloading mod: %w", err)
-	}
-
-	if file.SHA256 != "" && !strings.EqualFold(downloadResult.SHA256, file.SHA256) {
-		return nil, fmt.Errorf("verifying download of %s: sha256 mismatch: source declares %s, downloaded file is %s",
-			file.FileName, file.SHA256, downloadResult.SHA256)
+		return nil, err
 	}
 
 	// Extract to cache location
@@ -614,10 +617,10 @@ func (s *Service) DownloadModToCache(ctx context.Context, gameCache *cache.Cache
 			}
 			members = []string{safeFileName}
 		}
-		if err := commitStagedCacheWithMarker(cachePath, stagePath, file.ID, members); err != nil {
+		if err := commitStagedCacheWithMarker(gameCache, cachePath, stagePath, file.ID, members); err != nil {
 			return nil, err
 		}
-		return &DownloadModResult{FilesExtracted: len(members), Checksum: downloadResult.Checksum}, nil
+		return &DownloadModResult{FilesExtracted: len(members), Checksum: downloadResult.Checksum, SHA256: downloadResult.SHA256, Size: downloadResult.Size}, nil
 	}
 
 	if game.DeployMode == domain.DeployCopy || !s.extractor.CanExtract(archivePath) {
@@ -628,20 +631,26 @@ func (s *Service) DownloadModToCache(ctx context.Context, gameCache *cache.Cache
 		if err := copyFileStreaming(archivePath, destPath); err != nil {
 			return nil, fmt.Errorf("copying to cache: %w", err)
 		}
-		if err := commitStagedCacheWithMarker(cachePath, stagePath, file.ID, []string{safeFileName}); err != nil {
+		if err := commitStagedCacheWithMarker(gameCache, cachePath, stagePath, file.ID, []string{safeFileName}); err != nil {
 			return nil, err
 		}
 		return &DownloadModResult{
 			FilesExtracted: 1,
 			Checksum:       downloadResult.Checksum,
+			SHA256:         downloadResult.SHA256,
+			Size:           downloadResult.Size,
 		}, nil
 	}
 
-	members, err := s.extractIntoStaging(archivePath, cachePath, stagePath)
+	var layout func(string) string
+	if lm, ok := src.(source.LayoutMapper); ok {
+		layout = func(member string) string { return lm.LayoutPath(mod, member) }
+	}
+	members, err := s.extractIntoStaging(archivePath, cachePath, stagePath, layout)
 	if err != nil {
 		return nil, fmt.Errorf("extracting mod: %w", err)
 	}
-	if err := commitStagedCacheWithMarker(cachePath, stagePath, file.ID, members); err != nil {
+	if err := commitStagedCacheWithMarker(gameCache, cachePath, stagePath, file.ID, members); err != nil {
 		return nil, err
 	}
 
@@ -654,6 +663,8 @@ func (s *Service) DownloadModToCache(ctx context.Context, gameCache *cache.Cache
 	return &DownloadModResult{
 		FilesExtracted: len(files),
 		Checksum:       downloadResult.Checksum,
+		SHA256:         downloadResult.SHA256,
+		Size:           downloadResult.Size,
 	}, nil
 }
 
@@ -695,7 +706,8 @@ func (s *Service) ingestLocalToCache(gameCache *cache.Cache, game *domain.Game,
 	defer os.RemoveAll(stagePath) //nolint:errcheck
 
 	var members []string
-	var checksum string
+	var checksum, sha string
+	var size int64
 	switch {
 	case info.IsDir():
 		if err := copyDir(localPath, stagePath); err != nil {
@@ -739,16 +751,22 @@ func (s *Service) ingestLocalToCache(gameCache *cache.Cache, game *domain.Game,
 		if checksum, err = md5File(localPath); err != nil {
 			return nil, fmt.Errorf("hashing local mod file: %w", err)
 		}
+		if sha, size, err = sha256File(localPath); err != nil {
+			return nil, fmt.Errorf("hashing local mod file: %w", err)
+		}
 	default:
-		if members, err = s.extractIntoStaging(localPath, cachePath, stagePath); err != nil {
+		if members, err = s.extractIntoStaging(localPath, cachePath, stagePath, nil); err != nil {
 			return nil, fmt.Errorf("extracting mod: %w", err)
 		}
 		if checksum, err = md5File(localPath); err != nil {
 			return nil, fmt.Errorf("hashing local mod archive: %w", err)
 		}
+		if sha, size, err = sha256File(localPath); err != nil {
+			return nil, fmt.Errorf("hashing local mod archive: %w", err)
+		}
 	}
 
-	if err := commitStagedCacheWithMarker(cachePath, stagePath, file.ID, members); err != nil {
+	if err := commitStagedCacheWithMarker(gameCache, cachePath, stagePath, file.ID, members); err != nil {
 		return nil, err
 	}
 
@@ -756,7 +774,7 @@ func (s *Service) ingestLocalToCache(gameCache *cache.Cache, game *domain.Game,
 	if err != nil {
 		return nil, err
 	}
-	return &DownloadModResult{FilesExtracted: len(files), Checksum: checksum}, nil
+	return &DownloadModResult{FilesExtracted: len(files), Checksum: checksum, SHA256: sha, Size: size}, nil
 }
 
 // md5File returns the hex MD5 of the file at path - the same fingerprint the
@@ -775,6 +793,22 @@ func md5File(path string) (string, error) {
 	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
+// sha256File returns the hex SHA-256 and size of the file at path, matching
+// DownloadResult.SHA256/Size for a fetched archive.
+func sha256File(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close() //nolint:errcheck
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
 // digestDirectoryMembers returns a deterministic hex MD5 fingerprint of a
 // directory ingest's member set: each member's root-relative slash path plus
 // the MD5 of its content under root, folded in sorted path order. root is
@@ -891,7 +925,7 @@ func prepareUnseededStaging(gameCache *cache.Cache, game *domain.Game, mod *doma
 // files survive into every later file's commit. (Directory ingests stage
 // UNSEEDED instead - prepareUnseededStaging, #166 - their single synthetic
 // file ID means there are no earlier files' markers to carry forward.)
-func commitStagedCacheWithMarker(cachePath, stagePath, fileID string, members []string) error {
+func commitStagedCacheWithMarker(gameCache *cache.Cache, cachePath, stagePath, fileID string, members []string) error {
 	if err := cache.MarkFileCompleteWithMembers(stagePath, fileID, members); err != nil {
 		return err
 	}
@@ -909,7 +943,7 @@ func commitStagedCacheWithMarker(cachePath, stagePath, fileID string, members []
 			return err
 		}
 	}
-	return commitStagedCache(cachePath, stagePath)
+	return commitStagedCache(gameCache, cachePath, stagePath)
 }
 
 // extractIntoStaging extracts archivePath into a PRISTINE sibling directory of
@@ -929,7 +963,12 @@ func commitStagedCacheWithMarker(cachePath, stagePath, fileID string, members []
 // Returned members are extractDir-relative paths of regular files only,
 // matching cache.ListFiles semantics (directories and symlinks are never
 // listed, deployed, or undeployed).
-func (s *Service) extractIntoStaging(archivePath, cachePath, stagePath string) ([]string, error) {
+//
+// A non-nil layout (a source.LayoutMapper's) relocates each regular file to
+// the path it returns, or drops it on ""; directories are then not mirrored,
+// since the archive's own tree no longer applies. A path escaping the mod
+// directory is an error, like a zip-slip entry.
+func (s *Service) extractIntoStaging(archivePath, cachePath, stagePath string, layout func(string) string) ([]string, error) {
 	extractPath := cachePath + ".extract"
 	if err := os.RemoveAll(extractPath); err != nil {
 		return nil, fmt.Errorf("clearing extraction dir: %w", err)
@@ -949,6 +988,21 @@ func (s *Service) extractIntoStaging(archivePath, cachePath, stagePath string) (
 		if err != nil {
 			return err
 		}
+		if layout != nil {
+			if d.IsDir() || !d.Type().IsRegular() {
+				return nil
+			}
+			mapped := layout(filepath.ToSlash(rel))
+			if mapped == "" {
+				return nil
+			}
+			if rel = filepath.FromSlash(mapped); !filepath.IsLocal(rel) {
+				return fmt.Errorf("archive member %s maps outside the mod directory: %s", path, mapped)
+			}
+			if err := os.MkdirAll(filepath.Dir(filepath.Join(stagePath, rel)), 0755); err != nil {
+				return err
+			}
+		}
 		dest := filepath.Join(stagePath, rel)
 		if d.IsDir() {
 			// Preserve (possibly empty) directories, as direct extraction did.
@@ -1002,7 +1056,12 @@ func relativeFileMembers(root string) ([]string, error) {
 	return members, nil
 }
 
-func commitStagedCache(cachePath, stagePath string) error {
+// commitStagedCache swaps stagePath into place as the cache entry at
+// cachePath, keeping the previous entry as a backup until the swap succeeds.
+// With cache_dedup on, the committed entry's files are then moved into the
+// cache's blob store; that is best-effort, since plain copies are still a
+// correct entry.
+func commitStagedCache(gameCache *cache.Cache, cachePath, stagePath string) error {
 	parentDir := filepath.Dir(cachePath)
 	if err := os.MkdirAll(parentDir, 0755); err != nil {
 		return fmt.Errorf("creating cache parent directory: %w", err)
@@ -1028,6 +1087,9 @@ func commitStagedCache(cachePath, stagePath string) error {
 	if err := os.RemoveAll(backupPath); err != nil {
 		return fmt.Errorf("removing old cache backup: %w", err)
 	}
+	if gameCache.Dedup() {
+		_, _ = gameCache.DedupDir(cachePath)
+	}
 	return nil
 }
 
@@ -1265,11 +1327,19 @@ func (s *Service) GlobalCacheDir() string {
 // Uses the game's cache_path if configured (game-scoped: paths omit gameID), otherwise the global cache.
 func (s *Service) GetGameCache(game *domain.Game) *cache.Cache {
 	if game.CachePath != "" {
-		return cache.NewGameScoped(game.CachePath)
+		gameCache := cache.NewGameScoped(game.CachePath)
+		gameCache.SetDedup(s.config.CacheDedup)
+		return gameCache
 	}
 	return s.cache
 }
 
+// CacheDedup reports whether config.yaml's cache_dedup is on, i.e. whether
+// newly cached files go into the content-addressed blob store.
+func (s *Service) CacheDedup() bool {
+	return s.config.CacheDedup
+}
+
 // ConfigDir returns the configuration directory
 func (s *Service) ConfigDir() string {
 	return s.configDir
@@ -1321,6 +1391,11 @@ func (s *Service) RollbackModVersion(sourceID, modID, gameID, profileName string
 	return s.db.SwapModVersions(sourceID, modID, gameID, profileName)
 }
 
+// SetModVersions moves a mod to version/fileIDs with previousVersion/previousFileIDs as its rollback target.
+func (s *Service) SetModVersions(sourceID, modID, gameID, profileName, version string, fileIDs []string, previousVersion string, previousFileIDs []string) error {
+	return s.db.SetModVersions(sourceID, modID, gameID, profileName, version, fileIDs, previousVersion, previousFileIDs)
+}
+
 // SetModUpdatePolicy sets the update policy for an installed mod
 func (s *Service) SetModUpdatePolicy(sourceID, modID, gameID, profileName string, policy domain.UpdatePolicy) error {
 	return s.db.UpdateModPolicy(sourceID, modID, gameID, profileName, policy)
@@ -1407,6 +1482,8 @@ type DeployedFile struct {
 	ModID    string
 	FileID   string
 	Checksum string
+	SHA256   string // SHA-256 of the downloaded archive (empty when not recorded)
+	Size     int64  // Size of the downloaded archive in bytes
 }
 
 // GetFilesWithChecksums returns every tracked file in the profile with its
@@ -1418,7 +1495,7 @@ func (s *Service) GetFilesWithChecksums(gameID, profileName string) ([]DeployedF
 	}
 	out := make([]DeployedFile, len(rows))
 	for i, r := range rows {
-		out[i] = DeployedFile{SourceID: r.SourceID, ModID: r.ModID, FileID: r.FileID, Checksum: r.Checksum}
+		out[i] = DeployedFile{SourceID: r.SourceID, ModID: r.ModID, FileID: r.FileID, Checksum: r.Checksum, SHA256: r.SHA256, Size: r.Size}
 	}
 	return out, nil
 }
@@ -1428,6 +1505,11 @@ func (s *Service) SaveFileChecksum(sourceID, modID, gameID, profileName, fileID,
 	return s.db.SaveFileChecksum(sourceID, modID, gameID, profileName, fileID, checksum)
 }
 
+// SaveFileDigest records the SHA-256 and size of a downloaded mod file.
+func (s *Service) SaveFileDigest(sourceID, modID, gameID, profileName, fileID, sha256 string, size int64) error {
+	return s.db.SaveFileDigest(sourceID, modID, gameID, profileName, fileID, sha256, size)
+}
+
 // GetInstalledMod retrieves a single installed mod
 func (s *Service) GetInstalledMod(sourceID, modID, gameID, profileName string) (*domain.InstalledMod, error) {
 	return s.db.GetInstalledMod(sourceID, modID, gameID, profileName)
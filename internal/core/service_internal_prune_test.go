@@ -24,8 +24,31 @@ func TestCommitStagedCacheWithMarker_UnverifiableFileIDSkipsPrune(t *testing.T)
 	require.NoError(t, os.WriteFile(filepath.Join(stagePath, cache.RetainedSourceName("exmodz")), []byte("zip"), 0o644))
 	require.NoError(t, cache.MarkFileCompleteWithMembers(stagePath, "f1", []string{"a.pak"}))
 
-	require.NoError(t, commitStagedCacheWithMarker(cachePath, stagePath, "unverifiable/id", []string{"new.dat"}))
+	require.NoError(t, commitStagedCacheWithMarker(cache.New(base), cachePath, stagePath, "unverifiable/id", []string{"new.dat"}))
 
 	_, err := os.Stat(filepath.Join(cachePath, "new.dat"))
 	require.NoError(t, err, "the unverifiable fileID's own member must survive the commit")
 }
+
+// TestCommitStagedCache_DedupsWhenEnabled: with cache_dedup on, a committed
+// entry's files are linked to the blob store, so a later version shipping
+// an identical file shares it.
+func TestCommitStagedCache_DedupsWhenEnabled(t *testing.T) {
+	gameCache := cache.New(t.TempDir())
+	gameCache.SetDedup(true)
+
+	for _, version := range []string{"1.0", "2.0"} {
+		cachePath := gameCache.ModPath("g", "src", "mod", version)
+		stagePath := cachePath + ".staging"
+		require.NoError(t, os.MkdirAll(stagePath, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(stagePath, "big.pak"), []byte("same bytes"), 0o644))
+		require.NoError(t, commitStagedCacheWithMarker(gameCache, cachePath, stagePath, "f1", []string{"big.pak"}))
+	}
+
+	a, err := os.Stat(gameCache.GetFilePath("g", "src", "mod", "1.0", "big.pak"))
+	require.NoError(t, err)
+	b, err := os.Stat(gameCache.GetFilePath("g", "src", "mod", "2.0", "big.pak"))
+	require.NoError(t, err)
+	require.True(t, os.SameFile(a, b))
+	require.True(t, gameCache.HasFileIDs("g", "src", "mod", "2.0", []string{"f1"}))
+}
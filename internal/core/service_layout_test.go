@@ -0,0 +1,82 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// layoutMockSource extends mockSourceWithDownloads with source.LayoutMapper:
+// members under "keep/" move to "moved/<mod ID>/", "escape" maps outside the
+// mod directory, and everything else is dropped.
+type layoutMockSource struct {
+	*mockSourceWithDownloads
+}
+
+func (s *layoutMockSource) LayoutPath(mod *domain.Mod, member string) string {
+	if rest, ok := strings.CutPrefix(member, "keep/"); ok {
+		return "moved/" + mod.ID + "/" + rest
+	}
+	if member == "escape" {
+		return "../escape"
+	}
+	return ""
+}
+
+func downloadWithLayout(t *testing.T, files map[string]string) (*core.Service, *domain.Game, *domain.Mod, error) {
+	t.Helper()
+
+	cfg := core.ServiceConfig{ConfigDir: t.TempDir(), DataDir: t.TempDir(), CacheDir: t.TempDir()}
+	svc, err := core.NewService(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, svc.Close()) })
+
+	mock := &layoutMockSource{newMockSourceWithDownloads("test")}
+	t.Cleanup(mock.Close)
+	svc.RegisterSource(mock)
+
+	game := &domain.Game{ID: "testgame", Name: "Test Game", ModPath: filepath.Join(t.TempDir(), "mods")}
+	require.NoError(t, svc.AddGame(game))
+
+	zipContent, err := os.ReadFile(createTestZip(t, t.TempDir(), files))
+	require.NoError(t, err)
+	mock.AddDownload("file1", zipContent)
+
+	mod := &domain.Mod{ID: "m1", SourceID: "test", Name: "Mod", Version: "1.0.0", GameID: "testgame"}
+	file := &domain.DownloadableFile{ID: "file1", Name: "File", FileName: "m1.zip"}
+	_, err = svc.DownloadMod(context.Background(), "test", game, mod, file, nil)
+	return svc, game, mod, err
+}
+
+func TestDownloadModAppliesSourceLayout(t *testing.T) {
+	svc, game, mod, err := downloadWithLayout(t, map[string]string{
+		"keep/a.dll":        "a",
+		"keep/sub/b.cfg":    "b",
+		"README.md":         "dropped",
+		"other/ignored.txt": "dropped",
+	})
+	require.NoError(t, err)
+
+	files, err := svc.GetGameCache(game).ListFiles(game.ID, mod.SourceID, mod.ID, mod.Version)
+	require.NoError(t, err)
+	sort.Strings(files)
+	assert.Equal(t, []string{
+		filepath.Join("moved", "m1", "a.dll"),
+		filepath.Join("moved", "m1", "sub", "b.cfg"),
+	}, files)
+}
+
+func TestDownloadModRejectsLayoutEscapingModDir(t *testing.T) {
+	svc, game, mod, err := downloadWithLayout(t, map[string]string{"escape": "x"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "outside the mod directory")
+	assert.False(t, svc.GetGameCache(game).Exists(game.ID, mod.SourceID, mod.ID, mod.Version))
+}
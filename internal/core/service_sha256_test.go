@@ -2,7 +2,10 @@ package core_test
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha512"
 	"encoding/hex"
 	"path/filepath"
 	"strings"
@@ -18,6 +21,13 @@ import (
 // content, with expectedSHA declared on the file. Returns the error.
 func downloadWithSHA256(t *testing.T, content []byte, expectedSHA string) (error, *domain.Game, *domain.Mod, func() bool) {
 	t.Helper()
+	return downloadWithChecksums(t, content, func(f *domain.DownloadableFile) { f.SHA256 = expectedSHA })
+}
+
+// downloadWithChecksums is downloadWithSHA256 for any mix of declared
+// checksums: declare sets them on the file before the download.
+func downloadWithChecksums(t *testing.T, content []byte, declare func(*domain.DownloadableFile)) (error, *domain.Game, *domain.Mod, func() bool) {
+	t.Helper()
 
 	cfg := core.ServiceConfig{ConfigDir: t.TempDir(), DataDir: t.TempDir(), CacheDir: t.TempDir()}
 	svc, err := core.NewService(cfg)
@@ -32,7 +42,8 @@ func downloadWithSHA256(t *testing.T, content []byte, expectedSHA string) (error
 	require.NoError(t, svc.AddGame(game))
 
 	mod := &domain.Mod{ID: "m1", SourceID: "test", Name: "Mod", Version: "1.0.0", GameID: "testgame"}
-	file := &domain.DownloadableFile{ID: "file1", Name: "File", FileName: "m1.zip", SHA256: expectedSHA}
+	file := &domain.DownloadableFile{ID: "file1", Name: "File", FileName: "m1.zip"}
+	declare(file)
 
 	mock.AddDownload(file.ID, content)
 
@@ -72,3 +83,74 @@ func TestDownloadModVerifiesDeclaredSHA256(t *testing.T) {
 		assert.True(t, cached())
 	})
 }
+
+func TestDownloadModVerifiesDeclaredSHA512AndSHA1(t *testing.T) {
+	content := []byte("mod archive bytes")
+	sum512 := sha512.Sum512(content)
+	sum1 := sha1.Sum(content)
+	good512 := hex.EncodeToString(sum512[:])
+	good1 := hex.EncodeToString(sum1[:])
+
+	t.Run("matching sha512 and sha1 pass", func(t *testing.T) {
+		err, _, _, cached := downloadWithChecksums(t, content, func(f *domain.DownloadableFile) {
+			f.SHA512, f.SHA1 = good512, good1
+		})
+		require.NoError(t, err)
+		assert.True(t, cached())
+	})
+
+	t.Run("mismatched sha512 fails", func(t *testing.T) {
+		err, _, _, cached := downloadWithChecksums(t, content, func(f *domain.DownloadableFile) {
+			f.SHA512 = strings.Repeat("ab", 64)
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sha512 mismatch")
+		assert.False(t, cached())
+	})
+
+	t.Run("sha1 alone is checked", func(t *testing.T) {
+		err, _, _, _ := downloadWithChecksums(t, content, func(f *domain.DownloadableFile) {
+			f.SHA1 = strings.Repeat("ab", 20)
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sha1 mismatch")
+	})
+
+	t.Run("sha1 is ignored next to a stronger hash", func(t *testing.T) {
+		err, _, _, cached := downloadWithChecksums(t, content, func(f *domain.DownloadableFile) {
+			f.SHA512, f.SHA1 = good512, strings.Repeat("ab", 20)
+		})
+		require.NoError(t, err)
+		assert.True(t, cached())
+	})
+}
+
+func TestDownloadModVerifiesDeclaredMD5(t *testing.T) {
+	content := []byte("mod archive bytes")
+	sum := md5.Sum(content)
+	good := hex.EncodeToString(sum[:])
+
+	t.Run("matching md5 passes", func(t *testing.T) {
+		err, _, _, cached := downloadWithChecksums(t, content, func(f *domain.DownloadableFile) { f.MD5 = good })
+		require.NoError(t, err)
+		assert.True(t, cached())
+	})
+
+	t.Run("mismatched md5 fails", func(t *testing.T) {
+		err, _, _, cached := downloadWithChecksums(t, content, func(f *domain.DownloadableFile) {
+			f.MD5 = strings.Repeat("ab", 16)
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "md5 mismatch")
+		assert.False(t, cached())
+	})
+
+	t.Run("md5 is ignored next to a stronger hash", func(t *testing.T) {
+		sum256 := sha256.Sum256(content)
+		err, _, _, cached := downloadWithChecksums(t, content, func(f *domain.DownloadableFile) {
+			f.SHA256, f.MD5 = hex.EncodeToString(sum256[:]), strings.Repeat("ab", 16)
+		})
+		require.NoError(t, err)
+		assert.True(t, cached())
+	})
+}
@@ -0,0 +1,343 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/db"
+)
+
+// snapshotFileName is the manifest inside each snapshot directory; the
+// profile files sit beside it under snapshotProfilesDir.
+const (
+	snapshotFileName    = "snapshot.json"
+	snapshotProfilesDir = "profiles"
+)
+
+// Snapshot describes one whole-game restore point: every profile file, the
+// game's installed-mod and deployed-file records, and the merged pak
+// fingerprint, as they were when it was taken. Mod files are not copied -
+// the snapshot references the cached versions in Mods, which cache gc keeps
+// for as long as the snapshot exists.
+type Snapshot struct {
+	ID            string    `json:"id"` // timestamp ID, same format and -N suffix rule as save backups
+	GameID        string    `json:"game_id"`
+	Note          string    `json:"note,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	ActiveProfile string    `json:"active_profile"`
+	Profiles      []string  `json:"profiles"`
+	// Mods is every installed mod record, across all profiles.
+	Mods []db.InstalledVersion `json:"mods"`
+	// MergedFingerprint is the merged pak's stored fingerprint, nil when the
+	// game had none.
+	MergedFingerprint *MergedFingerprint `json:"merged_fingerprint,omitempty"`
+	Path              string             `json:"-"` // the snapshot directory under <data>/snapshots/<game>/
+}
+
+// snapshotFile is the on-disk form of a snapshot's manifest.
+type snapshotFile struct {
+	Snapshot
+	Rows db.GameRows `json:"rows"`
+}
+
+// SnapshotRestoreResult reports a RestoreSnapshot.
+type SnapshotRestoreResult struct {
+	Safety   *Snapshot // the snapshot of the state the restore replaced
+	Profile  string    // the restored active profile, which was redeployed
+	Deploy   *DeployResult
+	Converge *ConvergeResult
+	// MergedPakDiffers is set when the merged pak no longer matches the
+	// snapshot's fingerprint after the redeploy, e.g. because the base game
+	// pak has changed since.
+	MergedPakDiffers bool
+	Warnings         []string
+}
+
+// snapshotsDir returns the directory holding game's snapshots.
+func (s *Service) snapshotsDir(gameID string) string {
+	return filepath.Join(s.dataDir, "snapshots", gameID)
+}
+
+// profilesDir returns where game's profile files live.
+func (s *Service) profilesDir(gameID string) string {
+	return filepath.Join(s.configDir, "games", gameID, "profiles")
+}
+
+// CreateSnapshot records a restore point for game, labelled with note.
+func (s *Service) CreateSnapshot(game *domain.Game, note string) (*Snapshot, error) {
+	names, err := config.ListProfiles(s.configDir, game.ID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	rows, err := s.db.ExportGameRows(game.ID)
+	if err != nil {
+		return nil, err
+	}
+	mods, err := s.db.GetInstalledVersions(game.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := s.snapshotsDir(game.ID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating snapshot dir: %w", err)
+	}
+	staging, err := os.MkdirTemp(dir, ".lmm-snapshot-")
+	if err != nil {
+		return nil, fmt.Errorf("creating snapshot: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(staging) }() // no-op once renamed into place
+
+	if err := os.Mkdir(filepath.Join(staging, snapshotProfilesDir), 0755); err != nil {
+		return nil, fmt.Errorf("creating snapshot: %w", err)
+	}
+	for _, name := range names {
+		for _, ext := range []string{".yaml", ".lock"} {
+			data, err := os.ReadFile(filepath.Join(s.profilesDir(game.ID), name+ext))
+			if err != nil {
+				if ext == ".lock" && errors.Is(err, os.ErrNotExist) {
+					continue
+				}
+				return nil, fmt.Errorf("reading profile %s: %w", name, err)
+			}
+			if err := os.WriteFile(filepath.Join(staging, snapshotProfilesDir, name+ext), data, 0644); err != nil {
+				return nil, fmt.Errorf("writing snapshot: %w", err)
+			}
+		}
+	}
+
+	now := time.Now().UTC()
+	snap := snapshotFile{
+		Snapshot: Snapshot{
+			GameID:        game.ID,
+			Note:          note,
+			CreatedAt:     now,
+			ActiveProfile: s.activeProfileName(game.ID),
+			Profiles:      names,
+			Mods:          mods,
+		},
+		Rows: rows,
+	}
+	if fp, ok := readMergedFingerprint(s.mergedPakCachePath(game)); ok {
+		snap.MergedFingerprint = &fp
+	}
+
+	id := now.Format(saveBackupIDFormat)
+	for n := 2; ; n++ {
+		if _, err := os.Stat(filepath.Join(dir, id)); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		id = fmt.Sprintf("%s-%d", now.Format(saveBackupIDFormat), n)
+	}
+	snap.ID = id
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding snapshot: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(staging, snapshotFileName), data, 0644); err != nil {
+		return nil, fmt.Errorf("writing snapshot: %w", err)
+	}
+	dest := filepath.Join(dir, id)
+	if err := os.Rename(staging, dest); err != nil {
+		return nil, fmt.Errorf("finalizing snapshot: %w", err)
+	}
+	snap.Path = dest
+	return &snap.Snapshot, nil
+}
+
+// mergedPakCachePath returns the cache directory holding game's merged pak
+// and its fingerprint marker.
+func (s *Service) mergedPakCachePath(game *domain.Game) string {
+	return s.GetGameCache(game).ModPath(game.ID, domain.SourceMerged, mergedPakModID, mergedPakVersion)
+}
+
+// ListSnapshots returns game's snapshots, oldest first.
+func (s *Service) ListSnapshots(game *domain.Game) ([]Snapshot, error) {
+	entries, err := os.ReadDir(s.snapshotsDir(game.ID))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading snapshots: %w", err)
+	}
+	var snaps []Snapshot
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		snap, err := s.loadSnapshot(game, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, snap.Snapshot)
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].ID < snaps[j].ID })
+	return snaps, nil
+}
+
+// loadSnapshot reads snapshot id of game.
+func (s *Service) loadSnapshot(game *domain.Game, id string) (*snapshotFile, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return nil, fmt.Errorf("invalid snapshot ID: %q", id)
+	}
+	dir := filepath.Join(s.snapshotsDir(game.ID), id)
+	data, err := os.ReadFile(filepath.Join(dir, snapshotFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("snapshot %s not found for game %s", id, game.ID)
+		}
+		return nil, fmt.Errorf("reading snapshot %s: %w", id, err)
+	}
+	var snap snapshotFile
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parsing snapshot %s: %w", id, err)
+	}
+	if snap.GameID != game.ID {
+		return nil, fmt.Errorf("snapshot %s belongs to game %s, not %s", id, snap.GameID, game.ID)
+	}
+	snap.ID, snap.Path = id, dir
+	return &snap, nil
+}
+
+// DeleteSnapshot removes snapshot id of game. The cached mod versions it
+// referenced become eligible for cache gc unless something else needs them.
+func (s *Service) DeleteSnapshot(game *domain.Game, id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return fmt.Errorf("invalid snapshot ID: %q", id)
+	}
+	dir := filepath.Join(s.snapshotsDir(game.ID), id)
+	if _, err := os.Stat(dir); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("snapshot %s not found for game %s", id, game.ID)
+		}
+		return fmt.Errorf("reading snapshot %s: %w", id, err)
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("deleting snapshot %s: %w", id, err)
+	}
+	return nil
+}
+
+// RestoreSnapshot brings game back to snapshot id. The current state is
+// snapshotted first (returned as Safety), then the profile files and the
+// game's database records are replaced, and the snapshot's active profile
+// is redeployed - downloading any version the cache no longer holds - and
+// converged, so files deployed since the snapshot are removed from the game
+// directory. progress receives the redeploy's events and may be nil.
+func (s *Service) RestoreSnapshot(ctx context.Context, game *domain.Game, id string, progress func(DeployProgress)) (*SnapshotRestoreResult, error) {
+	snap, err := s.loadSnapshot(game, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range snap.Profiles {
+		if _, err := os.Stat(filepath.Join(snap.Path, snapshotProfilesDir, name+".yaml")); err != nil {
+			return nil, fmt.Errorf("snapshot %s is incomplete: profile %s: %w", id, name, err)
+		}
+	}
+
+	result := &SnapshotRestoreResult{Profile: snap.ActiveProfile}
+	if result.Safety, err = s.CreateSnapshot(game, "before restoring "+id); err != nil {
+		return nil, fmt.Errorf("snapshotting current state before restore: %w", err)
+	}
+
+	from := s.activeProfileName(game.ID)
+	leftovers, err := s.db.ListDeployedFiles(game.ID, from)
+	if err != nil {
+		return result, err
+	}
+
+	if err := s.restoreSnapshotProfiles(game, snap); err != nil {
+		return result, err
+	}
+	if err := s.db.ReplaceGameRows(game.ID, snap.Rows); err != nil {
+		return result, fmt.Errorf("restoring database records: %w", err)
+	}
+
+	// Files deployed now but not at snapshot time would lose their owner
+	// with the rows just replaced. Re-record them against the restored
+	// profile so convergence finds and removes them.
+	restored, err := s.db.ListDeployedFiles(game.ID, snap.ActiveProfile)
+	if err != nil {
+		return result, err
+	}
+	for path, owner := range leftovers {
+		if _, ok := restored[path]; ok {
+			continue
+		}
+		if err := s.db.SaveDeployedFile(game.ID, snap.ActiveProfile, path, owner.SourceID, owner.ModID); err != nil {
+			return result, err
+		}
+	}
+
+	if from != snap.ActiveProfile && game.SaveIsolation && game.SavesPath != "" {
+		if err := swapProfileSaves(game, from, snap.ActiveProfile); err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("swapping saves to profile %s: %v", snap.ActiveProfile, err))
+		}
+	}
+
+	if result.Deploy, err = s.DeployProfile(ctx, game, snap.ActiveProfile, DeployOptions{}, progress); err != nil {
+		return result, fmt.Errorf("redeploying profile %s: %w", snap.ActiveProfile, err)
+	}
+	if result.Converge, err = s.ConvergeDeployedFiles(ctx, game, snap.ActiveProfile, false); err != nil {
+		return result, fmt.Errorf("converging deployed files: %w", err)
+	}
+
+	if snap.MergedFingerprint != nil {
+		fp, ok := readMergedFingerprint(s.mergedPakCachePath(game))
+		if equal, err := mergedFingerprintsEqual(fp, *snap.MergedFingerprint); !ok || err != nil || !equal {
+			result.MergedPakDiffers = true
+		}
+	}
+	return result, nil
+}
+
+// restoreSnapshotProfiles replaces game's profile files with snap's,
+// removing profiles (and lockfiles) created since it was taken.
+func (s *Service) restoreSnapshotProfiles(game *domain.Game, snap *snapshotFile) error {
+	dir := s.profilesDir(game.ID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating profiles dir: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("reading profiles dir: %w", err)
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !(strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".lock")) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			return fmt.Errorf("removing profile file %s: %w", name, err)
+		}
+	}
+
+	saved, err := os.ReadDir(filepath.Join(snap.Path, snapshotProfilesDir))
+	if err != nil {
+		return fmt.Errorf("reading snapshot profiles: %w", err)
+	}
+	for _, e := range saved {
+		if e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(snap.Path, snapshotProfilesDir, e.Name()))
+		if err != nil {
+			return fmt.Errorf("reading snapshot profile %s: %w", e.Name(), err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, e.Name()), data, 0644); err != nil {
+			return fmt.Errorf("restoring profile file %s: %w", e.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSnapshotGame returns a game whose "default" profile has m1 1.0
+// installed and deployed.
+func newSnapshotGame(t *testing.T, svc *core.Service) *domain.Game {
+	t.Helper()
+	game := &domain.Game{ID: "g1", Name: "Game", InstallPath: t.TempDir(), ModPath: t.TempDir(), LinkMethod: domain.LinkSymlink}
+	pm := svc.NewProfileManager()
+	_, err := pm.Create(game.ID, "default")
+	require.NoError(t, err)
+	require.NoError(t, pm.SetDefault(game.ID, "default"))
+
+	seedInstalledMod(t, svc, game, "src", "m1", "1.0", true, map[string][]byte{"m1.esp": []byte("one")})
+	require.NoError(t, pm.AddMod(game.ID, "default", domain.ModReference{SourceID: "src", ModID: "m1", Version: "1.0"}))
+	_, err = svc.DeployProfile(context.Background(), game, "default", core.DeployOptions{}, nil)
+	require.NoError(t, err)
+	return game
+}
+
+func TestSnapshots_CreateListDelete(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := newSnapshotGame(t, svc)
+
+	snaps, err := svc.ListSnapshots(game)
+	require.NoError(t, err)
+	assert.Empty(t, snaps)
+
+	first, err := svc.CreateSnapshot(game, "before update")
+	require.NoError(t, err)
+	second, err := svc.CreateSnapshot(game, "")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID+"-2", second.ID, "same-second snapshots get a suffix")
+
+	snaps, err = svc.ListSnapshots(game)
+	require.NoError(t, err)
+	require.Len(t, snaps, 2)
+	assert.Equal(t, first.ID, snaps[0].ID)
+	assert.Equal(t, "before update", snaps[0].Note)
+	assert.Equal(t, "default", snaps[0].ActiveProfile)
+	assert.Equal(t, []string{"default"}, snaps[0].Profiles)
+	require.Len(t, snaps[0].Mods, 1)
+	assert.Equal(t, "1.0", snaps[0].Mods[0].Version)
+
+	// The snapshot pins the version it references against cache gc.
+	require.NoError(t, svc.UpdateModVersion("src", "m1", game.ID, "default", "2.0"))
+	require.NoError(t, svc.NewProfileManager().UpsertMod(game.ID, "default", domain.ModReference{SourceID: "src", ModID: "m1", Version: "2.0"}))
+	entries, err := svc.ListCacheEntries(game)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0].References, "snapshot "+first.ID)
+
+	require.NoError(t, svc.DeleteSnapshot(game, first.ID))
+	require.NoError(t, svc.DeleteSnapshot(game, second.ID))
+	snaps, err = svc.ListSnapshots(game)
+	require.NoError(t, err)
+	assert.Empty(t, snaps)
+
+	err = svc.DeleteSnapshot(game, first.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+	assert.Error(t, svc.DeleteSnapshot(game, "../etc"))
+}
+
+func TestRestoreSnapshot_BringsGameBack(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := newSnapshotGame(t, svc)
+	ctx := context.Background()
+	pm := svc.NewProfileManager()
+
+	snap, err := svc.CreateSnapshot(game, "")
+	require.NoError(t, err)
+
+	// A risky session: a new mod, a new profile, an override.
+	seedInstalledMod(t, svc, game, "src", "m2", "1.0", true, map[string][]byte{"m2.esp": []byte("two")})
+	require.NoError(t, pm.AddMod(game.ID, "default", domain.ModReference{SourceID: "src", ModID: "m2", Version: "1.0"}))
+	_, err = svc.DeployProfile(ctx, game, "default", core.DeployOptions{}, nil)
+	require.NoError(t, err)
+	_, err = pm.Create(game.ID, "experiment")
+	require.NoError(t, err)
+	profile, err := pm.Get(game.ID, "default")
+	require.NoError(t, err)
+	profile.Overrides = map[string][]byte{"game.ini": []byte("x=1")}
+	require.NoError(t, config.SaveProfile(svc.ConfigDir(), profile))
+	_, err = os.Lstat(filepath.Join(game.ModPath, "m2.esp"))
+	require.NoError(t, err)
+
+	result, err := svc.RestoreSnapshot(ctx, game, snap.ID, nil)
+	require.NoError(t, err)
+	require.NotNil(t, result.Safety)
+	assert.Equal(t, "default", result.Profile)
+	require.Len(t, result.Converge.Removed, 1)
+	assert.Equal(t, "m2.esp", result.Converge.Removed[0].Path)
+
+	_, err = os.Lstat(filepath.Join(game.ModPath, "m2.esp"))
+	assert.True(t, os.IsNotExist(err), "files deployed after the snapshot must be removed")
+	_, err = os.Lstat(filepath.Join(game.ModPath, "m1.esp"))
+	assert.NoError(t, err)
+
+	_, err = svc.GetInstalledMod("src", "m2", game.ID, "default")
+	assert.Error(t, err)
+	_, err = pm.Get(game.ID, "experiment")
+	assert.ErrorIs(t, err, domain.ErrProfileNotFound)
+	profile, err = pm.Get(game.ID, "default")
+	require.NoError(t, err)
+	assert.Empty(t, profile.Overrides)
+	require.Len(t, profile.Mods, 1)
+
+	// The safety snapshot restores the session again.
+	_, err = svc.RestoreSnapshot(ctx, game, result.Safety.ID, nil)
+	require.NoError(t, err)
+	_, err = os.Lstat(filepath.Join(game.ModPath, "m2.esp"))
+	assert.NoError(t, err)
+	_, err = pm.Get(game.ID, "experiment")
+	assert.NoError(t, err)
+}
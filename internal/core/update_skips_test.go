@@ -25,11 +25,13 @@ func TestCountUpdateSkips_MatchesFilter(t *testing.T) {
 		mod("c", domain.SourceLocal, domain.UpdateNotify),
 		mod("d", domain.SourceLocal, domain.UpdatePinned), // both reasons
 		mod("e", "curseforge", domain.UpdateAuto),
+		{Mod: domain.Mod{ID: "f", SourceID: domain.SourceDev}, UpdatePolicy: domain.UpdatePinned, DevPath: "/src/f"},
 	}
 
 	skips := CountUpdateSkips(installed)
 	assert.Equal(t, 2, skips.Pinned, "b and d")
 	assert.Equal(t, 1, skips.Local, "c only — d is already counted as pinned")
+	assert.Equal(t, 1, skips.Dev, "f only — dev comes before its pin")
 
 	filtered := 0
 	for _, m := range installed {
@@ -46,4 +48,5 @@ func TestUpdateCheckable(t *testing.T) {
 	assert.True(t, UpdateCheckable(mod("a", "nexusmods", domain.UpdateAuto)))
 	assert.False(t, UpdateCheckable(mod("a", "nexusmods", domain.UpdatePinned)))
 	assert.False(t, UpdateCheckable(mod("a", domain.SourceLocal, domain.UpdateNotify)))
+	assert.False(t, UpdateCheckable(domain.InstalledMod{Mod: domain.Mod{ID: "a", SourceID: domain.SourceDev}, DevPath: "/src/a"}))
 }
@@ -86,8 +86,9 @@ func (u *Updater) CheckUpdates(ctx context.Context, game *domain.Game, installed
 }
 
 // UpdateCheckable reports whether CheckUpdates will query a source for mod.
-// Two reasons it will not: the mod is pinned (a user choice, reversible with
-// `lmm mod set-update`), or it is a local import with no remote to ask.
+// Three reasons it will not: the mod is pinned (a user choice, reversible with
+// `lmm mod set-update`), it is a local import with no remote to ask, or it is
+// a dev mod linked from a working directory.
 //
 // Exported because both interfaces need to explain the gap between "mods
 // installed" and "mods checked" - without it, a filtered mod silently vanishes
@@ -95,27 +96,30 @@ func (u *Updater) CheckUpdates(ctx context.Context, game *domain.Game, installed
 // must use this rather than re-testing the fields, so the reported counts can
 // never drift from what CheckUpdates actually skipped.
 func UpdateCheckable(mod domain.InstalledMod) bool {
-	return mod.UpdatePolicy != domain.UpdatePinned && mod.SourceID != domain.SourceLocal
+	return mod.UpdatePolicy != domain.UpdatePinned && mod.SourceID != domain.SourceLocal && !mod.IsDev()
 }
 
-// UpdateSkips counts the mods CheckUpdates will filter out, by reason. The two
+// UpdateSkips counts the mods CheckUpdates will filter out, by reason. They
 // are reported separately because the remedies differ: a pin can be lifted, a
-// local mod can never be checked.
+// local mod can never be checked, and a dev mod is the user's own work.
 type UpdateSkips struct {
 	Pinned int
 	Local  int
+	Dev    int
 }
 
 // Total is the number of mods that will not be checked at all.
-func (s UpdateSkips) Total() int { return s.Pinned + s.Local }
+func (s UpdateSkips) Total() int { return s.Pinned + s.Local + s.Dev }
 
 // CountUpdateSkips tallies why CheckUpdates will skip mods in installed. A mod
-// that is both pinned and local counts once, as pinned, so Total never exceeds
-// len(installed).
+// that matches several reasons counts once, under the first of dev, pinned,
+// local, so Total never exceeds len(installed).
 func CountUpdateSkips(installed []domain.InstalledMod) UpdateSkips {
 	var s UpdateSkips
 	for _, mod := range installed {
 		switch {
+		case mod.IsDev():
+			s.Dev++
 		case mod.UpdatePolicy == domain.UpdatePinned:
 			s.Pinned++
 		case mod.SourceID == domain.SourceLocal:
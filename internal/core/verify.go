@@ -161,7 +161,9 @@ func (s *Service) Verify(ctx context.Context, game *domain.Game, profile string,
 		// checksum/version/count passes all have nothing to do here, so
 		// this path is entirely the convergence pass - no sync phase (that
 		// only ever reacts to a --fix repair that just ran, and nothing
-		// ran here to react to).
+		// ran here to react to). ini_patches are profile-level, not
+		// mod-level, so their drift check runs here too.
+		r.iniPatchesPass()
 		r.convergencePass()
 		return result, nil
 	}
@@ -191,6 +193,7 @@ func (s *Service) Verify(ctx context.Context, game *domain.Game, profile string,
 	}
 
 	r.perFileWalk(files)
+	r.iniPatchesPass()
 
 	// #224 Task 6: --fix's merged-pak resync and the deploy-convergence
 	// sweep both close out the run, in that order - ported verbatim from
@@ -272,6 +275,75 @@ func (r *verifyRun) convergencePass() {
 	}
 }
 
+// iniPatchesPass reports every profile ini_patches key that no longer
+// matches the file on disk (CheckIniPatches) as an "ini_drift" warning -
+// the game or the user rewrote a key lmm manages. Like the merged-pak and
+// convergence checks it is profile-wide, ignoring ModFilter. Under --fix
+// the patches are re-applied (ApplyIniPatches) and each key that now
+// matches becomes a resolved "fixed_ini_drift" row instead; the Note names
+// the section, key, and what was expected versus found either way.
+func (r *verifyRun) iniPatchesPass() {
+	prof, err := config.LoadProfile(r.svc.ConfigDir(), r.game.ID, r.profile)
+	if err != nil || len(prof.IniPatches) == 0 {
+		return
+	}
+	drift, err := CheckIniPatches(r.game, prof)
+	if err != nil {
+		r.result.Warnings++
+		r.finding(VerifyFinding{Status: "skipped", Note: fmt.Sprintf("could not check ini patches: %v", err)}, VerifyEvent{})
+		return
+	}
+	if len(drift) == 0 {
+		return
+	}
+
+	stillDrifting := make(map[string]bool)
+	if r.opts.Fix {
+		if err := ApplyIniPatches(r.game, prof); err != nil {
+			r.emit(VerifyEvent{Kind: VerifyEvVerbose, Detail: fmt.Sprintf("re-applying ini patches: %v", err)})
+		}
+		after, err := CheckIniPatches(r.game, prof)
+		if err != nil {
+			after = drift // couldn't re-check: report everything as still drifting
+		}
+		for _, d := range after {
+			stillDrifting[iniDriftKey(d)] = true
+		}
+	}
+
+	for _, d := range drift {
+		f := VerifyFinding{FileID: d.File, Note: describeIniDrift(d)}
+		if r.opts.Fix && !stillDrifting[iniDriftKey(d)] {
+			f.Status = "fixed_ini_drift"
+			r.finding(f, VerifyEvent{Variant: "fixed_green"})
+			continue
+		}
+		f.Status = "ini_drift"
+		r.result.Warnings++
+		r.finding(f, VerifyEvent{})
+	}
+}
+
+func iniDriftKey(d IniDrift) string {
+	return d.File + "\x00" + strings.ToLower(d.Section) + "\x00" + strings.ToLower(d.Key)
+}
+
+// describeIniDrift renders d as "[Section] key: expected X, found Y".
+func describeIniDrift(d IniDrift) string {
+	where := d.Key
+	if d.Section != "" {
+		where = "[" + d.Section + "] " + d.Key
+	}
+	switch {
+	case d.Want == nil:
+		return fmt.Sprintf("%s: expected deleted, found %q", where, d.Actual)
+	case !d.Present:
+		return fmt.Sprintf("%s: expected %q, key missing", where, *d.Want)
+	default:
+		return fmt.Sprintf("%s: expected %q, found %q", where, *d.Want, d.Actual)
+	}
+}
+
 // fileCountPrePass ports cmd/lmm/verify.go's per-mod file-count mismatch
 // check verbatim (originally doVerify lines 339-415): report when a mod's
 // cache entry exists but is empty (0 files) despite the DB recording more
@@ -584,10 +656,10 @@ func (r *verifyRun) versionPass(installedMods []domain.InstalledMod, prof *domai
 		if r.opts.ModFilter != "" && mod.ID != r.opts.ModFilter {
 			continue
 		}
-		// Nothing to check against: local imports and manual downloads have
-		// no source to query, and a mod with no recorded file IDs predates
-		// even the buggy stamping this check exists to catch.
-		if mod.SourceID == domain.SourceLocal || mod.ManualDownload || len(mod.FileIDs) == 0 {
+		// Nothing to check against: local imports, dev mods and manual
+		// downloads have no source to query, and a mod with no recorded file
+		// IDs predates even the buggy stamping this check exists to catch.
+		if mod.SourceID == domain.SourceLocal || mod.IsDev() || mod.ManualDownload || len(mod.FileIDs) == 0 {
 			continue
 		}
 
@@ -51,7 +51,8 @@ func (r *verifyRun) redownloadModFile(ctx context.Context, mod *domain.Installed
 	if result.Checksum == "" {
 		return false, nil
 	}
-	if err := r.svc.SaveFileChecksum(mod.SourceID, mod.ID, r.game.ID, r.profile, fileID, result.Checksum); err != nil {
+	cs := fileChecksum{fileID: fileID, checksum: result.Checksum, sha256: result.SHA256, size: result.Size}
+	if err := r.svc.saveFileChecksums(mod.SourceID, mod.ID, r.game.ID, r.profile, cs); err != nil {
 		return false, fmt.Errorf("saving checksum: %w", err)
 	}
 	return true, nil
@@ -580,8 +581,8 @@ func (r *verifyRun) relinkDeployedRow(ctx context.Context, profileName string, m
 	// Undeploy-then-install, the same shape DeployProfile uses
 	// (internal/core/flows.go) and for the same reason: dst still holds
 	// whatever the previous deployment left behind (here, the dangling
-	// symlinks the cache re-key orphaned), and only the symlink and
-	// hardlink linkers' Deploy clear an existing dst themselves - the copy
+	// symlinks the cache re-key orphaned), and only the symlink, hardlink
+	// and reflink linkers' Deploy clear an existing dst themselves - the copy
 	// linker's OpenFile would follow (or trip over) a stale symlink instead
 	// of replacing it.
 	undeployErr = installer.Uninstall(ctx, r.game, &mod.Mod, profileName)
@@ -1169,6 +1169,35 @@ func TestVerify_Fix_VersionMismatch_RenameBlocked_NoRelink(t *testing.T) {
 	require.False(t, details[1].Green)
 }
 
+// TestVerify_Fix_VersionMismatch_ReflinkDeployment_TreatedLikeCopy: a
+// reflinked file is an independent regular file, exactly like a copy, so
+// the cache re-key leaves nothing dangling and verify must neither re-link
+// nor sweep it - the deployment and its recorded method survive untouched.
+func TestVerify_Fix_VersionMismatch_ReflinkDeployment_TreatedLikeCopy(t *testing.T) {
+	svc, game := newVersionRepairFixGame(t, false)
+	require.NoError(t, svc.SetModDeployed("test-src", "mod1", game.ID, "default", true))
+	require.NoError(t, svc.SetModLinkMethod("test-src", "mod1", game.ID, "default", domain.LinkReflink))
+	mod, err := svc.GetInstalledMod("test-src", "mod1", game.ID, "default")
+	require.NoError(t, err)
+	require.NoError(t, svc.NewInstallerWithLinker(game, svc.GetLinker(domain.LinkReflink)).Install(context.Background(), game, &mod.Mod, "default"))
+
+	result, _ := runVersionRepairFix(t, svc, game)
+	require.Equal(t, 0, result.Issues)
+
+	deployedPath := filepath.Join(game.ModPath, "2")
+	info, err := os.Lstat(deployedPath)
+	require.NoError(t, err)
+	require.True(t, info.Mode().IsRegular(), "a reflinked file must not be replaced by a link")
+	content, err := os.ReadFile(deployedPath)
+	require.NoError(t, err)
+	require.Equal(t, "plugin content", string(content))
+
+	mod, err = svc.GetInstalledMod("test-src", "mod1", game.ID, "default")
+	require.NoError(t, err)
+	require.True(t, mod.Deployed)
+	require.Equal(t, domain.LinkReflink, mod.LinkMethod)
+}
+
 // TestVerify_Fix_VersionMismatch_LockedPrimary_RefusesRepair is the
 // locked-primary scenario: the profile ref is locked at the recorded
 // version, so --fix must refuse the rewrite entirely - the row stays
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
+)
+
+// ModVersion is one version of an installed mod that was deployed in its
+// profile, as listed by ModVersionHistory.
+type ModVersion struct {
+	Version    string
+	FileIDs    []string
+	DeployedAt time.Time // last deployed; zero for versions recorded before the history existed
+
+	Current  bool // the installed version and files
+	Previous bool // the target of a plain rollback
+	Cached   bool // the cache still holds it; rolling back to it otherwise downloads it again
+}
+
+// ModVersionHistory returns every version and file-ID set of the installed
+// mod ever deployed in profileName, most recently deployed first.
+func (s *Service) ModVersionHistory(game *domain.Game, profileName, sourceID, modID string) ([]ModVersion, error) {
+	mod, err := s.GetInstalledMod(sourceID, modID, game.ID, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("mod not found: %s", modID)
+	}
+	return s.modVersionHistory(game, mod)
+}
+
+func (s *Service) modVersionHistory(game *domain.Game, mod *domain.InstalledMod) ([]ModVersion, error) {
+	records, err := s.db.ListModVersions(mod.SourceID, mod.ID, game.ID, mod.ProfileName)
+	if err != nil {
+		return nil, err
+	}
+	gameCache := s.GetGameCache(game)
+	versions := make([]ModVersion, len(records))
+	for i, r := range records {
+		versions[i] = ModVersion{
+			Version:    r.Version,
+			FileIDs:    r.FileIDs,
+			DeployedAt: r.DeployedAt,
+			Current:    r.Version == mod.Version && sameIDSet(r.FileIDs, mod.FileIDs),
+			Previous:   r.Version == mod.PreviousVersion && sameIDSet(r.FileIDs, mod.PreviousFileIDs),
+			Cached:     versionCached(gameCache, game.ID, mod, r.Version, r.FileIDs),
+		}
+	}
+	return versions, nil
+}
+
+// versionCached reports whether the cache holds version of mod. With known
+// file IDs it checks each file's completion marker, so a version directory a
+// broken-off download left partly populated counts as uncached and is
+// downloaded again; only legacy rows without file IDs fall back to the
+// directory's presence.
+func versionCached(gameCache *cache.Cache, gameID string, mod *domain.InstalledMod, version string, fileIDs []string) bool {
+	if len(fileIDs) == 0 {
+		return gameCache.Exists(gameID, mod.SourceID, mod.ID, version)
+	}
+	return gameCache.HasFileIDs(gameID, mod.SourceID, mod.ID, version, fileIDs)
+}
+
+// legacyCacheEntry reports whether the cache holds version of mod in a
+// directory written before completion markers existed. Such an entry never
+// reads as cached, so a rollback downloads it again first; when that fails,
+// the rollback deploys the entry as it always did rather than refusing a
+// version the cache can still serve.
+func (s *Service) legacyCacheEntry(game *domain.Game, mod *domain.InstalledMod, version string) bool {
+	gameCache := s.GetGameCache(game)
+	if !gameCache.Exists(game.ID, mod.SourceID, mod.ID, version) {
+		return false
+	}
+	manifests, err := gameCache.FileManifests(game.ID, mod.SourceID, mod.ID, version)
+	return err == nil && len(manifests) == 0
+}
+
+// RollbackTarget resolves what rolling mod back would deploy: version from
+// the mod's version history (the most recently deployed file set, when the
+// version was deployed with several), or PreviousVersion when version is
+// empty. It checks only the history, not the cache - ApplyRollback downloads
+// a version the cache no longer holds.
+func (s *Service) RollbackTarget(game *domain.Game, mod *domain.InstalledMod, version string) (*ModVersion, error) {
+	if version == "" {
+		if mod.PreviousVersion == "" {
+			return nil, fmt.Errorf("no previous version available for rollback")
+		}
+		return &ModVersion{
+			Version:  mod.PreviousVersion,
+			FileIDs:  mod.PreviousFileIDs,
+			Previous: true,
+			Cached:   versionCached(s.GetGameCache(game), game.ID, mod, mod.PreviousVersion, mod.PreviousFileIDs),
+		}, nil
+	}
+
+	history, err := s.modVersionHistory(game, mod)
+	if err != nil {
+		return nil, err
+	}
+	var known []string
+	for i := range history {
+		if history[i].Version == version && !history[i].Current {
+			return &history[i], nil
+		}
+		if !slices.Contains(known, history[i].Version) {
+			known = append(known, history[i].Version)
+		}
+	}
+	if version == mod.Version {
+		return nil, fmt.Errorf("%s is already at version %s", mod.Name, version)
+	}
+	return nil, fmt.Errorf("version %s of %s was never deployed in profile %s (known: %s)", version, mod.Name, mod.ProfileName, strings.Join(known, ", "))
+}
+
+// downloadVersionToCache downloads version (the files in fileIDs) of mod
+// into the cache again, for a rollback target the cache no longer holds. It
+// returns each download's checksum and digest for the caller to save once
+// the version's file rows exist.
+func (s *Service) downloadVersionToCache(ctx context.Context, game *domain.Game, mod *domain.InstalledMod, version string, fileIDs []string, emit func(DeployProgress)) ([]fileChecksum, error) {
+	fetched, err := s.GetMod(ctx, mod.SourceID, game.ID, mod.ID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching mod: %w", err)
+	}
+	files, err := s.GetModFiles(ctx, mod.SourceID, fetched)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mod files: %w", err)
+	}
+	selected, _, err := selectVersionedDeployFiles(files, version, fileIDs, false)
+	if err != nil {
+		return nil, err
+	}
+	fetched.Version = version
+
+	base := DeployProgress{ModName: mod.Name, ModID: mod.ID, SourceID: mod.SourceID}
+	var checksums []fileChecksum
+	for _, file := range selected {
+		progressFn := func(p DownloadProgress) {
+			if p.TotalBytes > 0 {
+				evt := base
+				evt.Phase, evt.Percent = UpdateDownloading, p.Percentage
+				emit(evt)
+			}
+		}
+		downloadResult, err := s.DownloadMod(ctx, mod.SourceID, game, fetched, file, progressFn)
+		if err != nil {
+			return nil, err
+		}
+		if downloadResult.Checksum != "" {
+			checksums = append(checksums, fileChecksum{fileID: file.ID, checksum: downloadResult.Checksum, sha256: downloadResult.SHA256, size: downloadResult.Size})
+		}
+	}
+	done := base
+	done.Phase = UpdateDownloadDone
+	emit(done)
+	return checksums, nil
+}
+
+// sameIDSet reports whether a and b hold the same file IDs, in any order.
+func sameIDSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
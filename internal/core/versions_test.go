@@ -0,0 +1,174 @@
+package core_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/core"
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedThreeVersionMod installs mod1 at 1.0 (file "1") and updates it twice,
+// to 2.0 and then 3.0, so 1.0 is out of reach of a plain rollback.
+func seedThreeVersionMod(t *testing.T, svc *core.Service, game *domain.Game) *domain.InstalledMod {
+	t.Helper()
+	seedRollbackReadyMod(t, svc, game, "src", "mod1", "Mod One", "1.0", "2.0",
+		[]string{"1"}, []string{"new-1"},
+		map[string][]byte{"mod1-old.esp": []byte("old-content")},
+		map[string][]byte{"mod1-new.esp": []byte("new-content")})
+
+	require.NoError(t, svc.GetGameCache(game).Store(game.ID, "src", "mod1", "3.0", "mod1-newer.esp", []byte("newer-content")))
+	markCachedFileIDs(t, svc, game, "src", "mod1", "3.0", []string{"new-2"})
+	v2 := domain.Mod{ID: "mod1", SourceID: "src", Name: "Mod One", Version: "2.0", GameID: game.ID}
+	v3 := v2
+	v3.Version = "3.0"
+	require.NoError(t, svc.GetInstaller(game).Replace(context.Background(), game, &v2, &v3, "default"))
+	require.NoError(t, svc.ApplyModUpdate("src", "mod1", game.ID, "default", "3.0", []string{"new-2"}))
+	require.NoError(t, svc.NewProfileManager().UpsertMod(game.ID, "default", domain.ModReference{SourceID: "src", ModID: "mod1", Version: "3.0", FileIDs: []string{"new-2"}}))
+
+	mod, err := svc.GetInstalledMod("src", "mod1", game.ID, "default")
+	require.NoError(t, err)
+	return mod
+}
+
+func TestApplyRollback_ToVersionRedownloadsAfterCacheGC(t *testing.T) {
+	svc := newFlowsTestService(t)
+	gameDir := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: gameDir, LinkMethod: domain.LinkSymlink}
+	mod := seedThreeVersionMod(t, svc, game)
+	require.Equal(t, "2.0", mod.PreviousVersion)
+
+	history, err := svc.ModVersionHistory(game, "default", "src", "mod1")
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, "3.0", history[0].Version)
+	assert.True(t, history[0].Current)
+	assert.True(t, history[1].Previous)
+
+	// The cache GC has removed 1.0; the source still serves it.
+	require.NoError(t, svc.GetGameCache(game).Delete("g1", "src", "mod1", "1.0"))
+	mock := newMockSourceWithDownloads("src")
+	defer mock.Close()
+	svc.RegisterSource(mock)
+	zipPath := createTestZip(t, t.TempDir(), map[string]string{"mod1-old.esp": "old-content"})
+	zipContent, err := os.ReadFile(zipPath)
+	require.NoError(t, err)
+	mock.AddDownload("1", zipContent)
+	mock.AddMod("g1", &domain.Mod{ID: "mod1", SourceID: "src", Name: "Mod One", Version: "3.0", GameID: "g1"})
+
+	var sawDownload bool
+	result, err := svc.ApplyRollback(context.Background(), game, "default", "src", "mod1", core.RollbackOptions{TargetVersion: "1.0"}, func(p core.DeployProgress) {
+		if p.Phase == core.UpdateDownloadDone {
+			sawDownload = true
+		}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "3.0", result.FromVersion)
+	assert.Equal(t, "1.0", result.ToVersion)
+	assert.True(t, sawDownload)
+	assert.Equal(t, 1, mock.DownloadCount())
+
+	rolledBack, err := svc.GetInstalledMod("src", "mod1", "g1", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", rolledBack.Version)
+	assert.Equal(t, []string{"1"}, rolledBack.FileIDs)
+	assert.Equal(t, "3.0", rolledBack.PreviousVersion, "the version rolled back from becomes the plain rollback target")
+	assert.Equal(t, []string{"new-2"}, rolledBack.PreviousFileIDs)
+
+	_, err = os.Lstat(filepath.Join(gameDir, "mod1-old.esp"))
+	assert.NoError(t, err, "the downloaded version must be deployed")
+
+	// The download's digest is recorded, so the profile can be locked.
+	lock, _, err := svc.LockProfile(game, "default")
+	require.NoError(t, err)
+	sum := sha256.Sum256(zipContent)
+	assert.Equal(t, []domain.LockedFile{{FileID: "1", SHA256: hex.EncodeToString(sum[:]), Size: int64(len(zipContent))}}, lock.Mods[0].Files)
+	_, err = os.Lstat(filepath.Join(gameDir, "mod1-newer.esp"))
+	assert.True(t, os.IsNotExist(err), "the version rolled back from must be undeployed")
+
+	profile, err := svc.NewProfileManager().Get("g1", "default")
+	require.NoError(t, err)
+	require.Len(t, profile.Mods, 1)
+	assert.Equal(t, "1.0", profile.Mods[0].Version)
+
+	history, err = svc.ModVersionHistory(game, "default", "src", "mod1")
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, "1.0", history[0].Version)
+	assert.True(t, history[0].Current)
+	assert.True(t, history[0].Cached)
+
+	// A plain rollback now returns to the version rolled back from.
+	_, err = svc.ApplyRollback(context.Background(), game, "default", "src", "mod1", core.RollbackOptions{}, nil)
+	require.NoError(t, err)
+	rolledBack, err = svc.GetInstalledMod("src", "mod1", "g1", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "3.0", rolledBack.Version)
+}
+
+func TestRollbackTarget_RejectsUnknownAndCurrentVersions(t *testing.T) {
+	svc := newFlowsTestService(t)
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: t.TempDir(), LinkMethod: domain.LinkSymlink}
+	mod := seedThreeVersionMod(t, svc, game)
+
+	target, err := svc.RollbackTarget(game, mod, "1.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1"}, target.FileIDs)
+	assert.True(t, target.Cached)
+	assert.False(t, target.Previous)
+
+	_, err = svc.RollbackTarget(game, mod, "3.0")
+	require.Error(t, err)
+	assert.Equal(t, "Mod One is already at version 3.0", err.Error())
+
+	_, err = svc.RollbackTarget(game, mod, "9.9")
+	require.Error(t, err)
+	assert.Equal(t, "version 9.9 of Mod One was never deployed in profile default (known: 3.0, 2.0, 1.0)", err.Error())
+}
+
+func TestApplyRollback_PartlyCachedVersionIsDownloadedAgain(t *testing.T) {
+	svc := newFlowsTestService(t)
+	gameDir := t.TempDir()
+	game := &domain.Game{ID: "g1", Name: "Game", ModPath: gameDir, LinkMethod: domain.LinkSymlink}
+	mod := seedThreeVersionMod(t, svc, game)
+
+	// A download of 1.0 broke off partway: the version directory exists,
+	// but file "1" never completed.
+	gameCache := svc.GetGameCache(game)
+	require.NoError(t, gameCache.Delete("g1", "src", "mod1", "1.0"))
+	require.NoError(t, gameCache.Store("g1", "src", "mod1", "1.0", "mod1-old.esp", []byte("old-")))
+	require.True(t, gameCache.Exists("g1", "src", "mod1", "1.0"))
+
+	target, err := svc.RollbackTarget(game, mod, "1.0")
+	require.NoError(t, err)
+	assert.False(t, target.Cached, "a version missing a file's completion marker is not cached")
+
+	mock := newMockSourceWithDownloads("src")
+	defer mock.Close()
+	svc.RegisterSource(mock)
+	zipPath := createTestZip(t, t.TempDir(), map[string]string{"mod1-old.esp": "old-content"})
+	zipContent, err := os.ReadFile(zipPath)
+	require.NoError(t, err)
+	mock.AddDownload("1", zipContent)
+	mock.AddMod("g1", &domain.Mod{ID: "mod1", SourceID: "src", Name: "Mod One", Version: "3.0", GameID: "g1"})
+
+	_, err = svc.ApplyRollback(context.Background(), game, "default", "src", "mod1", core.RollbackOptions{TargetVersion: "1.0"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, mock.DownloadCount())
+
+	data, err := os.ReadFile(filepath.Join(gameDir, "mod1-old.esp"))
+	require.NoError(t, err)
+	assert.Equal(t, "old-content", string(data))
+
+	history, err := svc.ModVersionHistory(game, "default", "src", "mod1")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0", history[0].Version)
+	assert.True(t, history[0].Cached)
+}
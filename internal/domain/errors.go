@@ -21,7 +21,7 @@ var (
 	ErrInvalidGameID = errors.New("invalid game ID")
 	// ErrInvalidLinkMethod flags a link_method value that is neither empty
 	// (which keeps the existing default) nor one of the recognized names
-	// (symlink, hardlink, copy). Config loaders wrap it with the offending
+	// (see ValidLinkMethods). Config loaders wrap it with the offending
 	// field, value, and owning game/profile so the message names exactly
 	// what's wrong and how to fix it (#172).
 	ErrInvalidLinkMethod = errors.New("invalid link method")
@@ -34,6 +34,14 @@ var (
 	ErrFileConflict      = errors.New("file conflict detected")
 	ErrDownloadFailed    = errors.New("download failed")
 	ErrLinkFailed        = errors.New("link operation failed")
+	// ErrSavesNotConfigured is returned by save-game operations (backup,
+	// restore, per-profile isolation) on a game without a saves_path.
+	ErrSavesNotConfigured = errors.New("saves path not configured")
+	// ErrProfileCycle is returned when a profile's extends chain leads back
+	// to itself.
+	ErrProfileCycle = errors.New("profile inheritance cycle")
+	// ErrProfileExtended refuses to delete a profile other profiles extend.
+	ErrProfileExtended = errors.New("profile is extended by other profiles")
 )
 
 // DeployError aggregates a primary failure with optional rollback / cleanup
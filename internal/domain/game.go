@@ -7,6 +7,7 @@ const (
 	LinkSymlink  LinkMethod = iota // Default: symlink (space efficient)
 	LinkHardlink                   // Hardlink (transparent to games)
 	LinkCopy                       // Copy (maximum compatibility)
+	LinkReflink                    // Copy-on-write clone (copy semantics, near-zero space on btrfs/XFS)
 )
 
 func (m LinkMethod) String() string {
@@ -17,6 +18,8 @@ func (m LinkMethod) String() string {
 		return "hardlink"
 	case LinkCopy:
 		return "copy"
+	case LinkReflink:
+		return "reflink"
 	default:
 		return "unknown"
 	}
@@ -26,7 +29,7 @@ func (m LinkMethod) String() string {
 // the same order as the type's constants, for use in "unrecognized value"
 // error messages — the single source of truth so those messages can't go
 // stale the way a hand-written copy did (#172 review round 1).
-const ValidLinkMethods = "symlink, hardlink, copy"
+const ValidLinkMethods = "symlink, hardlink, copy, reflink"
 
 // ParseLinkMethod converts a string to LinkMethod. An empty string is not
 // yet set and returns the default (symlink) with ok=true, so configs that
@@ -42,6 +45,8 @@ func ParseLinkMethod(s string) (method LinkMethod, ok bool) {
 		return LinkHardlink, true
 	case "copy":
 		return LinkCopy, true
+	case "reflink":
+		return LinkReflink, true
 	default:
 		return LinkSymlink, false
 	}
@@ -57,10 +62,13 @@ type Game struct {
 	LinkMethod          LinkMethod        // How to deploy mods
 	LinkMethodExplicit  bool              // True if LinkMethod was explicitly set in config
 	CachePath           string            // Optional: custom cache path for this game's mods
+	CacheQuota          int64             // Optional: cache size cap in bytes, enforced after installs; 0 = none
 	Hooks               GameHooks         // Optional: hooks for install/uninstall operations
 	DeployMode          DeployMode        // How to handle downloaded files (extract vs copy)
 	ConvertPaks         bool              // #221: convert prebuilt .pak mods into the merged pak (DeployCompile games; default true when omitted from games.yaml, must be set explicitly for direct Game literals)
 	ConvertPaksExplicit bool              // True if ConvertPaks was explicitly set in config (round-trip fidelity, like LinkMethodExplicit)
+	SavesPath           string            // Optional: the game's save-game directory (used by `lmm saves` and save isolation)
+	SaveIsolation       bool              // Opt-in: give each profile its own SavesPath contents, swapped on profile switch
 }
 
 // DeployMode determines how downloaded mod archives are handled
@@ -15,6 +15,7 @@ func TestLinkMethod_String(t *testing.T) {
 		{"symlink", LinkSymlink, "symlink"},
 		{"hardlink", LinkHardlink, "hardlink"},
 		{"copy", LinkCopy, "copy"},
+		{"reflink", LinkReflink, "reflink"},
 		{"unknown value", LinkMethod(99), "unknown"},
 	}
 
@@ -39,6 +40,7 @@ func TestParseLinkMethod(t *testing.T) {
 	}{
 		{"hardlink", "hardlink", LinkHardlink, true},
 		{"copy", "copy", LinkCopy, true},
+		{"reflink", "reflink", LinkReflink, true},
 		{"symlink explicit", "symlink", LinkSymlink, true},
 		{"empty defaults to symlink", "", LinkSymlink, true},
 		{"unknown is rejected", "bogus", LinkSymlink, false},
@@ -25,6 +25,11 @@ const SourceLocal = "local"
 // string, not a real ModSource registration.
 const SourceMerged = "lmm-merged"
 
+// SourceDev is the source ID for dev mods: working directories linked
+// straight into the game by `lmm dev link`, never cached. The installed
+// row's DevPath names the directory.
+const SourceDev = "dev"
+
 // UpdatePolicy determines how a mod handles updates
 type UpdatePolicy int
 
@@ -43,15 +48,19 @@ type ModFile struct {
 
 // DownloadableFile represents a file available for download from a mod source
 type DownloadableFile struct {
-	ID          string // Source-specific file ID
-	Name        string // Display name
-	FileName    string // Actual filename (e.g., "mod-1.0.zip")
-	Version     string // File version
-	Size        int64  // Size in bytes
-	IsPrimary   bool   // Whether this is the primary/main file
-	Category    string // Category: "MAIN", "OPTIONAL", "UPDATE", etc.
-	Description string // File description
-	SHA256      string // Expected SHA-256 of the download (hex); empty = source declares no checksum
+	ID          string   // Source-specific file ID
+	Name        string   // Display name
+	FileName    string   // Actual filename (e.g., "mod-1.0.zip")
+	Version     string   // File version
+	Size        int64    // Size in bytes
+	IsPrimary   bool     // Whether this is the primary/main file
+	Category    string   // Category: "MAIN", "OPTIONAL", "UPDATE", etc.
+	Description string   // File description
+	SHA256      string   // Expected SHA-256 of the download (hex); empty = source declares no checksum
+	SHA512      string   // Expected SHA-512 (hex), for sources that publish it instead (Modrinth)
+	SHA1        string   // Expected SHA-1 (hex); checked only when no SHA-256/SHA-512 is declared
+	MD5         string   // Expected MD5 (hex), for sources that publish only that (mod.io); checked like SHA1
+	Mirrors     []string // Alternate URLs serving the same bytes, tried in order when the download URL fails
 }
 
 // EffectiveInstalledVersion resolves the version string that describes what
@@ -114,10 +123,17 @@ type InstalledMod struct {
 	Deployed        bool       // Current state: files are in game directory
 	PreviousVersion string     // Version before last update (for rollback)
 	PreviousFileIDs []string   // File IDs before last update (for rollback)
-	LinkMethod      LinkMethod // How the mod was deployed (symlink, hardlink, copy)
+	LinkMethod      LinkMethod // How the mod was deployed (symlink, hardlink, copy, reflink)
 	FileIDs         []string   // Source-specific file IDs that were downloaded
 	ManualDownload  bool       // True if mod requires manual download (CurseForge restricted, etc.)
 	ConvertPaks     bool       // #221: pak-to-exmod conversion enabled (default true; only meaningful for DeployCompile games)
+	DevPath         string     // Working directory a dev mod links from (lmm dev link); empty for cached mods
+}
+
+// IsDev reports whether m is a dev mod deployed straight from DevPath rather
+// than from the cache.
+func (m InstalledMod) IsDev() bool {
+	return m.DevPath != ""
 }
 
 // Update represents an available update for an installed mod
@@ -19,8 +19,10 @@ type GameHooksExplicit struct {
 type Profile struct {
 	Name       string            // Profile identifier
 	GameID     string            // Which game this profile is for
+	Extends    string            // Base profile this one inherits mods and settings from (optional)
 	Mods       []ModReference    // Mods in load order (first = lowest priority)
 	Overrides  map[string][]byte // Config file overrides (path -> content)
+	IniPatches IniPatches        // Key-level INI edits, merged into files after Overrides
 	LinkMethod LinkMethod        // Override game's default link method (optional)
 	// LinkMethodExplicit distinguishes "not set" (inherit from game/global) from an
 	// explicit "symlink", which is LinkMethod's zero value. Mirrors Game.LinkMethodExplicit.
@@ -62,4 +64,66 @@ type ExportedProfile struct {
 	Mods       []ModReference    `yaml:"mods"`
 	LinkMethod string            `yaml:"link_method,omitempty"`
 	Overrides  map[string]string `yaml:"overrides,omitempty"` // path (relative to game install) -> file content
+	IniPatches IniPatches        `yaml:"ini_patches,omitempty"`
+}
+
+// IniPatches holds a profile's key-level INI edits: file (relative to the
+// game install dir) -> section -> key -> value. A nil value (YAML null)
+// deletes the key; the "" section addresses keys before the first section
+// header. Unlike Overrides, which replace a whole file, patches merge into
+// whatever the file already holds, so the game's own later writes to
+// unrelated keys survive.
+type IniPatches map[string]map[string]map[string]*string
+
+// ProfileLockFormat is the lockfile layout version 'lmm profile lock' writes.
+const ProfileLockFormat = 1
+
+// ProfileLock pins every mod file a profile uses to the exact bytes it was
+// installed from, so a frozen import or apply elsewhere can refuse a file
+// that was re-uploaded under the same ID with different content.
+type ProfileLock struct {
+	Format  int         `yaml:"format"`
+	GameID  string      `yaml:"game_id"`
+	Profile string      `yaml:"profile"`
+	Mods    []LockedMod `yaml:"mods"`
+}
+
+// LockedMod is one mod version in a ProfileLock.
+type LockedMod struct {
+	SourceID string       `yaml:"source_id"`
+	ModID    string       `yaml:"mod_id"`
+	Version  string       `yaml:"version"`
+	Files    []LockedFile `yaml:"files"`
+}
+
+// LockedFile is the downloaded archive behind one source file ID.
+type LockedFile struct {
+	FileID string `yaml:"file_id"`
+	SHA256 string `yaml:"sha256"`
+	Size   int64  `yaml:"size"`
+}
+
+// Find returns the locked entry for sourceID+modID at version, or nil when
+// the lock has none. An empty version matches whichever version is locked.
+func (l *ProfileLock) Find(sourceID, modID, version string) *LockedMod {
+	if l == nil {
+		return nil
+	}
+	for i := range l.Mods {
+		m := &l.Mods[i]
+		if m.SourceID == sourceID && m.ModID == modID && (version == "" || m.Version == version) {
+			return m
+		}
+	}
+	return nil
+}
+
+// File returns the locked file with fileID, or nil.
+func (m *LockedMod) File(fileID string) *LockedFile {
+	for i := range m.Files {
+		if m.Files[i].FileID == fileID {
+			return &m.Files[i]
+		}
+	}
+	return nil
 }
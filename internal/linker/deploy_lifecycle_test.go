@@ -11,13 +11,14 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// strategies enumerates the three deploy strategies under a common Linker
+// strategies enumerates the deploy strategies under a common Linker
 // interface, for table-driven lifecycle tests.
 func strategies() map[string]linker.Linker {
 	return map[string]linker.Linker{
 		"symlink":  linker.NewSymlink(),
 		"hardlink": linker.NewHardlink(),
 		"copy":     linker.NewCopy(),
+		"reflink":  linker.NewReflink(),
 	}
 }
 
@@ -248,8 +249,10 @@ func TestCleanupEmptyDirs_MissingRootDoesNotPanic(t *testing.T) {
 //     os.Link(src, dst).
 //   - copy.go: Deploy has no explicit removal step; it opens dst with
 //     os.O_CREATE|os.O_WRONLY|os.O_TRUNC and overwrites its content in place.
+//   - reflink.go: Deploy explicitly os.Remove(dst)s any existing entry, then
+//     clones (or copies) src into a fresh file.
 //
-// In all three cases whatever previously occupied dst — including a foreign,
+// In every case whatever previously occupied dst — including a foreign,
 // unrelated file — is silently discarded. This pairs with the existence-only
 // IsDeployed behavior pinned above: the caller has no way to detect, via this
 // package alone, that dst held something else before Deploy ran.
@@ -295,6 +298,16 @@ func TestLinker_Deploy_OverwritesExistingForeignFile(t *testing.T) {
 				assert.Equal(t, []byte("source content"), content, "dst should now hold source's content")
 			},
 		},
+		{
+			name:      "reflink",
+			newLinker: func() linker.Linker { return linker.NewReflink() },
+			verify: func(t *testing.T, srcFile, dstFile string) {
+				t.Helper()
+				content, err := os.ReadFile(dstFile)
+				require.NoError(t, err)
+				assert.Equal(t, []byte("source content"), content, "dst should now hold source's content")
+			},
+		},
 	}
 
 	for _, tt := range tests {
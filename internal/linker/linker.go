@@ -22,6 +22,8 @@ func New(method domain.LinkMethod) Linker {
 		return NewHardlink()
 	case domain.LinkCopy:
 		return NewCopy()
+	case domain.LinkReflink:
+		return NewReflink()
 	default:
 		return NewSymlink()
 	}
@@ -97,4 +97,31 @@ func TestNew_ReturnsCorrectLinker(t *testing.T) {
 	assert.Equal(t, domain.LinkSymlink, linker.New(domain.LinkSymlink).Method())
 	assert.Equal(t, domain.LinkHardlink, linker.New(domain.LinkHardlink).Method())
 	assert.Equal(t, domain.LinkCopy, linker.New(domain.LinkCopy).Method())
+	assert.Equal(t, domain.LinkReflink, linker.New(domain.LinkReflink).Method())
+}
+
+func TestReflinkLinker_Deploy(t *testing.T) {
+	dir := t.TempDir()
+	srcFile := filepath.Join(dir, "src.txt")
+	dstFile := filepath.Join(dir, "dst.txt")
+	require.NoError(t, os.WriteFile(srcFile, []byte("content"), 0644))
+
+	l := linker.NewReflink()
+	require.NoError(t, l.Deploy(srcFile, dstFile))
+
+	content, err := os.ReadFile(dstFile)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("content"), content)
+	srcInfo, err := os.Stat(srcFile)
+	require.NoError(t, err)
+	dstInfo, err := os.Stat(dstFile)
+	require.NoError(t, err)
+	assert.False(t, os.SameFile(srcInfo, dstInfo), "a clone is its own file, whether cloned or copied")
+	assert.LessOrEqual(t, l.Fallbacks(), 1, "a filesystem without FICLONE (tmpfs, ext4) falls back to one copy")
+
+	// Writing the deployed file never reaches the cache.
+	require.NoError(t, os.WriteFile(dstFile, []byte("edited"), 0644))
+	content, err = os.ReadFile(srcFile)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("content"), content)
 }
@@ -0,0 +1,121 @@
+package linker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"golang.org/x/sys/unix"
+)
+
+// ReflinkLinker deploys mods as copy-on-write clones (FICLONE). A clone is an
+// independent file to the game, like a copy, but shares its extents with the
+// cache until either side is written, so it costs next to no space on
+// filesystems that support it (btrfs, XFS). Where cloning is unsupported -
+// another filesystem, or src and dst on different ones - it copies instead
+// and counts the file in Fallbacks.
+type ReflinkLinker struct {
+	fallbacks atomic.Int64
+}
+
+// NewReflink creates a new reflink linker
+func NewReflink() *ReflinkLinker {
+	return &ReflinkLinker{}
+}
+
+// Deploy clones src to dst, copying it when the filesystem cannot clone
+func (l *ReflinkLinker) Deploy(src, dst string) (err error) {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return fmt.Errorf("creating destination dir: %w", err)
+	}
+
+	// Replace rather than truncate whatever is at dst: a stale symlink or
+	// hardlink into the cache must not have the cache file written through it.
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing existing file: %w", err)
+	}
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("opening source: %w", err)
+	}
+	defer func() {
+		if cerr := srcFile.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("closing source: %w", cerr)
+		}
+	}()
+
+	srcInfo, err := srcFile.Stat()
+	if err != nil {
+		return fmt.Errorf("stat source: %w", err)
+	}
+
+	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_EXCL, srcInfo.Mode())
+	if err != nil {
+		return fmt.Errorf("creating destination: %w", err)
+	}
+	defer func() {
+		if cerr := dstFile.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("closing destination: %w", cerr)
+		}
+		if err != nil {
+			_ = os.Remove(dst)
+		}
+	}()
+
+	cloneErr := unix.IoctlFileClone(int(dstFile.Fd()), int(srcFile.Fd()))
+	if cloneErr == nil {
+		return nil
+	}
+	if !cloneUnsupported(cloneErr) {
+		return fmt.Errorf("cloning file: %w", cloneErr)
+	}
+
+	l.fallbacks.Add(1)
+	if _, err := io.Copy(dstFile, srcFile); err != nil {
+		return fmt.Errorf("copying file: %w", err)
+	}
+	return nil
+}
+
+// cloneUnsupported reports whether a FICLONE failure means the filesystem
+// cannot clone this pair (so a plain copy is the right fallback) rather than
+// a real I/O problem.
+func cloneUnsupported(err error) bool {
+	return errors.Is(err, unix.EOPNOTSUPP) || errors.Is(err, unix.EXDEV) ||
+		errors.Is(err, unix.EINVAL) || errors.Is(err, unix.ENOTTY) || errors.Is(err, unix.ENOSYS)
+}
+
+// Fallbacks returns how many files Deploy copied because cloning was unsupported
+func (l *ReflinkLinker) Fallbacks() int {
+	return int(l.fallbacks.Load())
+}
+
+// Undeploy removes the file at dst
+func (l *ReflinkLinker) Undeploy(dst string) error {
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing file: %w", err)
+	}
+	return nil
+}
+
+// IsDeployed checks if dst exists (clones are indistinguishable from regular files)
+func (l *ReflinkLinker) IsDeployed(dst string) (bool, error) {
+	_, err := os.Stat(dst)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Method returns the link method
+func (l *ReflinkLinker) Method() domain.LinkMethod {
+	return domain.LinkReflink
+}
@@ -6,7 +6,10 @@ import (
 	"github.com/DonovanMods/linux-mod-manager/internal/source"
 	"github.com/DonovanMods/linux-mod-manager/internal/source/curseforge"
 	"github.com/DonovanMods/linux-mod-manager/internal/source/custom"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/modio"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/modrinth"
 	"github.com/DonovanMods/linux-mod-manager/internal/source/nexusmods"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/thunderstore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -42,6 +45,9 @@ func TestTypeLabels(t *testing.T) {
 		{"api", api, "api"},
 		{"nexusmods", nexusmods.New(nil, ""), "built-in"},
 		{"curseforge", curseforge.New(nil, ""), "built-in"},
+		{"modrinth", modrinth.New(nil, ""), "built-in"},
+		{"thunderstore", thunderstore.New(nil), "built-in"},
+		{"modio", modio.New(nil, ""), "built-in"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -50,7 +56,7 @@ func TestTypeLabels(t *testing.T) {
 	}
 }
 
-// TestBuiltinCapabilitiesExplicit pins that both built-ins declare
+// TestBuiltinCapabilitiesExplicit pins that the API built-ins declare
 // Capabilities() explicitly (all true) rather than relying on the
 // CapabilitiesOf default.
 func TestBuiltinCapabilitiesExplicit(t *testing.T) {
@@ -63,4 +69,12 @@ func TestBuiltinCapabilitiesExplicit(t *testing.T) {
 	cf, ok := source.ModSource(curseforge.New(nil, "")).(source.CapabilityReporter)
 	require.True(t, ok, "CurseForge must implement CapabilityReporter")
 	assert.Equal(t, all, cf.Capabilities())
+
+	mr, ok := source.ModSource(modrinth.New(nil, "")).(source.CapabilityReporter)
+	require.True(t, ok, "Modrinth must implement CapabilityReporter")
+	assert.Equal(t, all, mr.Capabilities())
+
+	mio, ok := source.ModSource(modio.New(nil, "")).(source.CapabilityReporter)
+	require.True(t, ok, "mod.io must implement CapabilityReporter")
+	assert.Equal(t, all, mio.Capabilities())
 }
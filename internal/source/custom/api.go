@@ -6,8 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -23,9 +25,14 @@ const apiRequestTimeout = 30 * time.Second
 // (same defense class as maxManifestSize).
 const maxAPIResponseSize = 10 << 20 // 10 MiB
 
-// API is a ModSource backed by a declaratively-described GET+JSON REST API
-// (design §4). Endpoints that the definition omits surface as ErrNotSupported
-// capability gaps rather than errors at load time.
+// maxAPIPages bounds how many pages of a cursor- or link-paginated endpoint
+// one operation walks, so a server that never stops offering a next page
+// can't keep lmm requesting forever.
+const maxAPIPages = 50
+
+// API is a ModSource backed by a declaratively-described JSON REST (or
+// GraphQL) API (design §4). Endpoints that the definition omits surface as
+// ErrNotSupported capability gaps rather than errors at load time.
 type API struct {
 	id        string
 	name      string
@@ -34,6 +41,7 @@ type API struct {
 	auth      *AuthConfig
 	endpoints APIEndpoints
 	mappings  APIMappings
+	allowHTTP bool
 
 	apiKey     string
 	httpClient *http.Client
@@ -56,6 +64,7 @@ func NewAPI(def SourceDefinition) (*API, error) {
 		auth:       cfg.Auth,
 		endpoints:  cfg.Endpoints,
 		mappings:   cfg.Mappings,
+		allowHTTP:  def.AllowHTTP,
 		httpClient: &http.Client{Timeout: apiRequestTimeout},
 	}, nil
 }
@@ -74,12 +83,6 @@ func (a *API) ExchangeToken(ctx context.Context, code string) (*source.Token, er
 	return nil, fmt.Errorf("source %q: authentication: %w", a.id, source.ErrNotSupported)
 }
 
-// GetDependencies implements source.ModSource; always unsupported in v1
-// (design §4).
-func (a *API) GetDependencies(ctx context.Context, mod *domain.Mod) ([]domain.ModReference, error) {
-	return nil, fmt.Errorf("source %q: dependencies: %w", a.id, source.ErrNotSupported)
-}
-
 // SetAPIKey provides the API key resolved at startup (env var or token store).
 func (a *API) SetAPIKey(key string) { a.apiKey = key }
 
@@ -91,7 +94,7 @@ func (a *API) IsAuthenticated() bool { return a.apiKey != "" }
 func (a *API) Capabilities() source.Capabilities {
 	return source.Capabilities{
 		Search:       a.endpoints.Search != nil,
-		Dependencies: false,
+		Dependencies: a.endpoints.Dependencies != nil,
 		Updates:      a.endpoints.GetMod != nil,
 		Auth:         a.auth != nil,
 		Versions:     a.endpoints.ModFiles != nil,
@@ -126,24 +129,170 @@ func buildEndpointURL(pathTemplate string, vals map[string]string) string {
 	return out
 }
 
-// getJSON performs an authenticated GET against rawURL and decodes the JSON
-// response. 401 maps to domain.ErrAuthRequired; other non-200s surface the
-// status. Errors never contain the request URL's query string (keys ride
-// there in query mode) — the inner *url.Error is unwrapped, mirroring the
-// manifest fetcher's redaction.
-func (a *API) getJSON(ctx context.Context, rawURL string) (any, error) {
+// buildEndpointBody substitutes {placeholder} tokens in a POST body template
+// with JSON-string-escaped values, without adding quotes: a placeholder
+// belongs inside a JSON string ("{query}"), or bare where the value is
+// always numeric ({page_size}). GraphQL queries take their inputs through
+// "variables" rather than splicing them into the query text.
+func buildEndpointBody(bodyTemplate string, vals map[string]string) string {
+	out := bodyTemplate
+	for name, value := range vals {
+		quoted, _ := json.Marshal(value) // marshaling a string cannot fail
+		out = strings.ReplaceAll(out, "{"+name+"}", string(quoted[1:len(quoted)-1]))
+	}
+	return out
+}
+
+// method returns the endpoint's HTTP method, GET unless it declares POST.
+func (e *EndpointConfig) method() string {
+	if strings.EqualFold(e.Method, http.MethodPost) {
+		return http.MethodPost
+	}
+	return http.MethodGet
+}
+
+// request executes an endpoint with vals substituted into its path and body
+// templates, returning the decoded response.
+func (a *API) request(ctx context.Context, ep *EndpointConfig, vals map[string]string) (any, error) {
+	doc, _, err := a.doJSON(ctx, ep.method(), a.baseURL+buildEndpointURL(ep.Path, vals), buildEndpointBody(ep.Body, vals))
+	return doc, err
+}
+
+// walkPages requests a list endpoint and, when it declares pagination, its
+// following pages, handing each decoded page to visit until visit returns
+// false, the server offers no next page, or maxAPIPages is reached. A cursor
+// endpoint's first request substitutes an empty {cursor}. A link endpoint's
+// next URL must stay on base_url's origin, since the source's key rides
+// along on every request.
+func (a *API) walkPages(ctx context.Context, ep *EndpointConfig, vals map[string]string, visit func(doc any) (bool, error)) error {
+	vals = maps.Clone(vals)
+	vals["cursor"] = ""
+	reqURL := a.baseURL + buildEndpointURL(ep.Path, vals)
+
+	for range maxAPIPages {
+		doc, header, err := a.doJSON(ctx, ep.method(), reqURL, buildEndpointBody(ep.Body, vals))
+		if err != nil {
+			return err
+		}
+		more, err := visit(doc)
+		if err != nil || !more || ep.Pagination == nil {
+			return err
+		}
+
+		switch ep.Pagination.Type {
+		case PaginationCursor:
+			v, _ := lookupPath(doc, ep.Pagination.Cursor)
+			next := coerceString(v)
+			if next == "" || next == vals["cursor"] {
+				return nil
+			}
+			vals["cursor"] = next
+			reqURL = a.baseURL + buildEndpointURL(ep.Path, vals)
+		case PaginationLink:
+			next, err := nextLink(header, reqURL)
+			if err != nil {
+				return fmt.Errorf("source %q: %w", a.id, err)
+			}
+			if next == "" {
+				return nil
+			}
+			if !sameOriginURLs(next, a.baseURL) {
+				return fmt.Errorf("source %q: next page %s is not on %s", a.id, redactedURL(next), a.baseURL)
+			}
+			reqURL = next
+		}
+	}
+	return nil
+}
+
+// listAll collects a list endpoint's items across every page.
+func (a *API) listAll(ctx context.Context, ep *EndpointConfig, vals map[string]string) ([]any, error) {
+	var all []any
+	err := a.walkPages(ctx, ep, vals, func(doc any) (bool, error) {
+		items, err := listItems(doc, ep.List)
+		if err != nil {
+			return false, fmt.Errorf("source %q: %w", a.id, err)
+		}
+		all = append(all, items...)
+		return len(items) > 0, nil
+	})
+	return all, err
+}
+
+// listItems resolves a list endpoint's results array. A null list is empty:
+// a Go-backed API's json.Marshal of a nil slice emits `null`, the standard
+// zero-hits shape for such APIs.
+func listItems(doc any, path string) ([]any, error) {
+	listVal, ok := lookupPath(doc, path)
+	if !ok {
+		return nil, fmt.Errorf("response has no %q array", path)
+	}
+	if listVal == nil {
+		return []any{}, nil
+	}
+	items, ok := listVal.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%q is not an array", path)
+	}
+	return items, nil
+}
+
+// nextLink returns the rel="next" target of an RFC 8288 Link header,
+// resolved against the URL that was requested; "" when there is none.
+func nextLink(header http.Header, requested string) (string, error) {
+	for _, value := range header.Values("Link") {
+		for _, link := range strings.Split(value, ",") {
+			target, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+			target = strings.TrimSpace(target)
+			if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			for _, param := range strings.Split(params, ";") {
+				name, rel, _ := strings.Cut(strings.TrimSpace(param), "=")
+				if !strings.EqualFold(name, "rel") || !slices.Contains(strings.Fields(strings.Trim(rel, `"`)), "next") {
+					continue
+				}
+				base, err := url.Parse(requested)
+				if err != nil {
+					return "", fmt.Errorf("parsing URL: %w", err)
+				}
+				ref, err := url.Parse(target[1 : len(target)-1])
+				if err != nil {
+					return "", fmt.Errorf("parsing next page link: %w", err)
+				}
+				return base.ResolveReference(ref).String(), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// doJSON performs an authenticated request against rawURL, sending body as
+// JSON when it is non-empty, and decodes the JSON response. 401 maps to
+// domain.ErrAuthRequired; other non-200s surface the status. Errors never
+// contain the request URL's query string (keys ride there in query mode) —
+// the inner *url.Error is unwrapped, mirroring the manifest fetcher's
+// redaction.
+func (a *API) doJSON(ctx context.Context, method, rawURL, body string) (any, http.Header, error) {
 	reqURL := rawURL
 	if a.auth != nil && a.auth.APIKey.In == "query" && a.apiKey != "" {
 		u, err := addQueryParam(reqURL, a.auth.APIKey.Name, a.apiKey)
 		if err != nil {
-			return nil, fmt.Errorf("source %q: %w", a.id, err)
+			return nil, nil, fmt.Errorf("source %q: %w", a.id, err)
 		}
 		reqURL = u
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	var reqBody io.Reader
+	if body != "" {
+		reqBody = strings.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
 	if err != nil {
-		return nil, fmt.Errorf("source %q: building request: %w", a.id, err)
+		return nil, nil, fmt.Errorf("source %q: building request: %w", a.id, err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
 	}
 	if a.auth != nil && a.auth.APIKey.In == "header" && a.apiKey != "" {
 		req.Header.Set(a.auth.APIKey.Name, a.apiKey)
@@ -155,30 +304,30 @@ func (a *API) getJSON(ctx context.Context, rawURL string) (any, error) {
 		if errors.As(err, &uerr) {
 			err = uerr.Err // strip the URL (and any query-mode key) from the message
 		}
-		return nil, fmt.Errorf("source %q: requesting %s: %w", a.id, redactedURL(rawURL), err)
+		return nil, nil, fmt.Errorf("source %q: requesting %s: %w", a.id, redactedURL(rawURL), err)
 	}
 	defer resp.Body.Close() //nolint:errcheck
 
 	if resp.StatusCode == http.StatusUnauthorized {
-		return nil, fmt.Errorf("source %q: %w", a.id, domain.ErrAuthRequired)
+		return nil, nil, fmt.Errorf("source %q: %w", a.id, domain.ErrAuthRequired)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("source %q: requesting %s: HTTP %d", a.id, redactedURL(rawURL), resp.StatusCode)
+		return nil, nil, fmt.Errorf("source %q: requesting %s: HTTP %d", a.id, redactedURL(rawURL), resp.StatusCode)
 	}
 
 	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAPIResponseSize+1))
 	if err != nil {
-		return nil, fmt.Errorf("source %q: reading response: %w", a.id, err)
+		return nil, nil, fmt.Errorf("source %q: reading response: %w", a.id, err)
 	}
 	if len(data) > maxAPIResponseSize {
-		return nil, fmt.Errorf("source %q: response from %s exceeds %d bytes", a.id, redactedURL(rawURL), maxAPIResponseSize)
+		return nil, nil, fmt.Errorf("source %q: response from %s exceeds %d bytes", a.id, redactedURL(rawURL), maxAPIResponseSize)
 	}
 
 	var doc any
 	if err := json.Unmarshal(data, &doc); err != nil {
-		return nil, fmt.Errorf("source %q: parsing response from %s: %w", a.id, redactedURL(rawURL), err)
+		return nil, nil, fmt.Errorf("source %q: parsing response from %s: %w", a.id, redactedURL(rawURL), err)
 	}
-	return doc, nil
+	return doc, resp.Header, nil
 }
 
 // redactedURL strips the query string from a URL for error messages.
@@ -227,24 +376,30 @@ func (a *API) Search(ctx context.Context, query source.SearchQuery) (source.Sear
 		"tags":      strings.Join(query.Tags, ","),
 	}
 
-	doc, err := a.getJSON(ctx, a.baseURL+buildEndpointURL(ep.Path, vals))
+	// A cursor- or link-paginated search walks to the requested page;
+	// running out of pages first is an empty page, not an error. Without
+	// pagination, {page}/{offset} already address it in one request.
+	target := 0
+	if ep.Pagination != nil {
+		target = page
+	}
+	var doc any
+	walked := 0
+	err := a.walkPages(ctx, ep, vals, func(d any) (bool, error) {
+		doc = d
+		walked++
+		return walked <= target, nil
+	})
 	if err != nil {
 		return source.SearchResult{}, fmt.Errorf("searching: %w", err)
 	}
-
-	listVal, ok := lookupPath(doc, ep.List)
-	if !ok {
-		return source.SearchResult{}, fmt.Errorf("source %q: searching: response has no %q array", a.id, ep.List)
+	if walked <= target {
+		return source.SearchResult{Mods: []domain.Mod{}, Page: page, PageSize: pageSize}, nil
 	}
-	if listVal == nil {
-		// A Go-backed API's json.Marshal of a nil slice emits `null`, the
-		// standard zero-hits shape for such APIs — treat it as empty, not
-		// an error.
-		listVal = []any{}
-	}
-	items, ok := listVal.([]any)
-	if !ok {
-		return source.SearchResult{}, fmt.Errorf("source %q: searching: %q is not an array", a.id, ep.List)
+
+	items, err := listItems(doc, ep.List)
+	if err != nil {
+		return source.SearchResult{}, fmt.Errorf("source %q: searching: %w", a.id, err)
 	}
 
 	mods := make([]domain.Mod, 0, len(items))
@@ -277,7 +432,7 @@ func (a *API) GetMod(ctx context.Context, gameID, modID string) (*domain.Mod, er
 	}
 
 	vals := map[string]string{"mod_id": modID, "game_id": gameID}
-	doc, err := a.getJSON(ctx, a.baseURL+buildEndpointURL(ep.Path, vals))
+	doc, err := a.request(ctx, ep, vals)
 	if err != nil {
 		return nil, fmt.Errorf("fetching mod %s: %w", modID, err)
 	}
@@ -298,29 +453,14 @@ func (a *API) GetModFiles(ctx context.Context, mod *domain.Mod) ([]domain.Downlo
 	}
 
 	vals := map[string]string{"mod_id": mod.ID, "game_id": mod.GameID}
-	doc, err := a.getJSON(ctx, a.baseURL+buildEndpointURL(ep.Path, vals))
+	items, err := a.listAll(ctx, ep, vals)
 	if err != nil {
 		return nil, fmt.Errorf("listing files for %s: %w", mod.ID, err)
 	}
 
-	listVal, ok := lookupPath(doc, ep.List)
-	if !ok {
-		return nil, fmt.Errorf("source %q: mod %s: response has no %q array", a.id, mod.ID, ep.List)
-	}
-	if listVal == nil {
-		// A Go-backed API's json.Marshal of a nil slice emits `null`, the
-		// standard zero-hits shape for such APIs — treat it as empty, not
-		// an error.
-		listVal = []any{}
-	}
-	items, ok := listVal.([]any)
-	if !ok {
-		return nil, fmt.Errorf("source %q: mod %s: %q is not an array", a.id, mod.ID, ep.List)
-	}
-
 	files := make([]domain.DownloadableFile, 0, len(items))
 	for i, item := range items {
-		f, err := mapFile(item, a.mappings.File)
+		f, err := mapFile(item, a.mappings.File, a.allowHTTP)
 		if err != nil {
 			return nil, fmt.Errorf("source %q: mod %s: %s[%d]: %w", a.id, mod.ID, ep.List, i, err)
 		}
@@ -332,8 +472,33 @@ func (a *API) GetModFiles(ctx context.Context, mod *domain.Mod) ([]domain.Downlo
 	return files, nil
 }
 
+// GetDependencies implements source.ModSource via the dependencies endpoint.
+// A dependency without a mapped source_id is on this source.
+func (a *API) GetDependencies(ctx context.Context, mod *domain.Mod) ([]domain.ModReference, error) {
+	ep := a.endpoints.Dependencies
+	if ep == nil {
+		return nil, fmt.Errorf("source %q: dependencies: %w", a.id, source.ErrNotSupported)
+	}
+
+	vals := map[string]string{"mod_id": mod.ID, "game_id": mod.GameID}
+	items, err := a.listAll(ctx, ep, vals)
+	if err != nil {
+		return nil, fmt.Errorf("listing dependencies of %s: %w", mod.ID, err)
+	}
+
+	refs := make([]domain.ModReference, 0, len(items))
+	for i, item := range items {
+		ref, err := mapDependency(item, a.mappings.Dependency, a.id)
+		if err != nil {
+			return nil, fmt.Errorf("source %q: mod %s: %s[%d]: %w", a.id, mod.ID, ep.List, i, err)
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
 // GetDownloadURL implements source.ModSource via the download_url endpoint.
-// Query-mode keys are appended only for same-origin download URLs (design §9).
+// A plain http URL needs allow_http, like the file's mirrors. Query-mode keys are appended only for same-origin download URLs (design §9).
 func (a *API) GetDownloadURL(ctx context.Context, mod *domain.Mod, fileID string) (string, error) {
 	ep := a.endpoints.DownloadURL
 	if ep == nil {
@@ -341,7 +506,7 @@ func (a *API) GetDownloadURL(ctx context.Context, mod *domain.Mod, fileID string
 	}
 
 	vals := map[string]string{"file_id": fileID, "mod_id": mod.ID, "game_id": mod.GameID}
-	doc, err := a.getJSON(ctx, a.baseURL+buildEndpointURL(ep.Path, vals))
+	doc, err := a.request(ctx, ep, vals)
 	if err != nil {
 		return "", fmt.Errorf("download URL for file %s: %w", fileID, err)
 	}
@@ -354,6 +519,9 @@ func (a *API) GetDownloadURL(ctx context.Context, mod *domain.Mod, fileID string
 	if dlURL == "" {
 		return "", fmt.Errorf("source %q: file %s: %q is not a URL string", a.id, fileID, ep.Field)
 	}
+	if strings.HasPrefix(dlURL, "http://") && !a.allowHTTP {
+		return "", fmt.Errorf("source %q: file %s: plain http is disabled; use https or set allow_http: true", a.id, fileID)
+	}
 
 	if a.auth != nil && a.auth.APIKey.In == "query" && a.apiKey != "" && sameOriginURLs(dlURL, a.baseURL) {
 		withKey, err := addQueryParam(dlURL, a.auth.APIKey.Name, a.apiKey)
@@ -2,6 +2,7 @@ package custom
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -92,7 +93,7 @@ func TestBuildEndpointURL(t *testing.T) {
 	assert.Equal(t, "/mods?q=cool+mod+%26+more&page=2&game=&x={unknown}", got)
 }
 
-func TestGetJSONAuthAndErrors(t *testing.T) {
+func TestDoJSONAuthAndErrors(t *testing.T) {
 	t.Run("header auth attached and 401 maps to ErrAuthRequired", func(t *testing.T) {
 		var gotKey string
 		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -110,11 +111,11 @@ func TestGetJSONAuthAndErrors(t *testing.T) {
 		a, err := NewAPI(def)
 		require.NoError(t, err)
 
-		_, err = a.getJSON(context.Background(), srv.URL+"/mods/1")
+		_, _, err = a.doJSON(context.Background(), http.MethodGet, srv.URL+"/mods/1", "")
 		assert.True(t, errors.Is(err, domain.ErrAuthRequired))
 
 		a.SetAPIKey("sekrit")
-		doc, err := a.getJSON(context.Background(), srv.URL+"/mods/1")
+		doc, _, err := a.doJSON(context.Background(), http.MethodGet, srv.URL+"/mods/1", "")
 		require.NoError(t, err)
 		assert.Equal(t, "sekrit", gotKey)
 		assert.Equal(t, map[string]any{"ok": true}, doc)
@@ -134,7 +135,7 @@ func TestGetJSONAuthAndErrors(t *testing.T) {
 		require.NoError(t, err)
 		a.SetAPIKey("sekrit")
 
-		_, err = a.getJSON(context.Background(), srv.URL+"/mods/1")
+		_, _, err = a.doJSON(context.Background(), http.MethodGet, srv.URL+"/mods/1", "")
 		require.NoError(t, err)
 		assert.Equal(t, "sekrit", gotQuery)
 	})
@@ -146,7 +147,7 @@ func TestGetJSONAuthAndErrors(t *testing.T) {
 		require.NoError(t, err)
 		a.SetAPIKey("LEAKME")
 
-		_, err = a.getJSON(context.Background(), "http://127.0.0.1:1/mods/1")
+		_, _, err = a.doJSON(context.Background(), http.MethodGet, "http://127.0.0.1:1/mods/1", "")
 		require.Error(t, err)
 		assert.NotContains(t, err.Error(), "LEAKME")
 		assert.Contains(t, err.Error(), "my-api")
@@ -159,7 +160,7 @@ func TestGetJSONAuthAndErrors(t *testing.T) {
 		defer srv.Close()
 		a, err := NewAPI(apiDef(srv.URL))
 		require.NoError(t, err)
-		_, err = a.getJSON(context.Background(), srv.URL+"/x")
+		_, _, err = a.doJSON(context.Background(), http.MethodGet, srv.URL+"/x", "")
 		assert.ErrorContains(t, err, "HTTP 500")
 	})
 }
@@ -381,6 +382,25 @@ func TestAPIGetDownloadURLQueryAuthSameOriginOnly(t *testing.T) {
 	assert.NotContains(t, u, "sekrit", "cross-origin download URL must not carry the key")
 }
 
+func TestAPIGetDownloadURLRejectsPlainHTTPWithoutAllowHTTP(t *testing.T) {
+	mux := http.NewServeMux()
+	srv := httptest.NewTLSServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/files/1/download", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"url": "http://cdn.test/a.zip"}`))
+	})
+
+	def := apiDef(srv.URL)
+	def.AllowHTTP = false
+	a, err := NewAPI(def)
+	require.NoError(t, err)
+	a.httpClient = srv.Client()
+
+	_, err = a.GetDownloadURL(context.Background(), &domain.Mod{ID: "x"}, "1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "plain http is disabled")
+}
+
 func TestAPIReadOpsMissingEndpoints(t *testing.T) {
 	def := apiDef("https://x.test")
 	def.API.Endpoints = APIEndpoints{GetMod: &EndpointConfig{Path: "/mods/{mod_id}"}}
@@ -525,3 +545,139 @@ func TestAPISearchCategoryAndTagsPlaceholders(t *testing.T) {
 		})
 	}
 }
+
+func TestAPIGetDependencies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/mods/77/deps", r.URL.Path)
+		_, _ = w.Write([]byte(`{"deps": [{"id": 12}, {"id": "skse", "source": "nexusmods"}]}`))
+	}))
+	defer srv.Close()
+
+	def := apiDef(srv.URL)
+	def.API.Endpoints.Dependencies = &EndpointConfig{Path: "/mods/{mod_id}/deps", List: "deps"}
+	def.API.Mappings.Dependency = map[string]string{"mod_id": "id", "source_id": "source"}
+	a, err := NewAPI(def)
+	require.NoError(t, err)
+	assert.True(t, a.Capabilities().Dependencies)
+
+	deps, err := a.GetDependencies(context.Background(), &domain.Mod{ID: "77", GameID: "skyrim"})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ModReference{
+		{SourceID: "my-api", ModID: "12"},
+		{SourceID: "nexusmods", ModID: "skse"},
+	}, deps)
+}
+
+func TestAPIGetModFilesCursorPagination(t *testing.T) {
+	var cursors []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		cursors = append(cursors, after)
+		switch after {
+		case "":
+			_, _ = w.Write([]byte(`{"files": [{"id": 1}, {"id": 2}], "next": "c2"}`))
+		case "c2":
+			_, _ = w.Write([]byte(`{"files": [{"id": 3}], "next": null}`))
+		}
+	}))
+	defer srv.Close()
+
+	def := apiDef(srv.URL)
+	def.API.Endpoints.ModFiles = &EndpointConfig{
+		Path: "/mods/{mod_id}/files?after={cursor}", List: "files",
+		Pagination: &PaginationConfig{Type: PaginationCursor, Cursor: "next"},
+	}
+	a, err := NewAPI(def)
+	require.NoError(t, err)
+
+	files, err := a.GetModFiles(context.Background(), &domain.Mod{ID: "77"})
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+	assert.Equal(t, "3", files[2].ID)
+	assert.Equal(t, []string{"", "c2"}, cursors)
+}
+
+func TestAPISearchLinkPagination(t *testing.T) {
+	var requests int
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+	mux.HandleFunc("/mods", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "k", r.URL.Query().Get("key"), "the query-mode key rides on every page")
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", `</mods?page=2>; rel="next", </mods?page=9>; rel="last"`)
+			_, _ = w.Write([]byte(`{"results": [{"id": 1, "name": "First"}]}`))
+		case "2":
+			w.Header().Set("Link", `<`+srv.URL+`/mods?page=3>; rel="prev next"`)
+			_, _ = w.Write([]byte(`{"results": [{"id": 2, "name": "Second"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"results": [{"id": 3, "name": "Third"}]}`))
+		}
+	})
+
+	def := apiDef(srv.URL)
+	def.API.Auth = &AuthConfig{APIKey: &APIKeyConfig{In: "query", Name: "key"}}
+	def.API.Endpoints.Search = &EndpointConfig{Path: "/mods", List: "results", Pagination: &PaginationConfig{Type: PaginationLink}}
+	a, err := NewAPI(def)
+	require.NoError(t, err)
+	a.SetAPIKey("k")
+
+	res, err := a.Search(context.Background(), source.SearchQuery{Page: 2})
+	require.NoError(t, err)
+	require.Len(t, res.Mods, 1)
+	assert.Equal(t, "Third", res.Mods[0].Name)
+	assert.Equal(t, 3, requests)
+
+	requests = 0
+	res, err = a.Search(context.Background(), source.SearchQuery{Page: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "Second", res.Mods[0].Name)
+	assert.Equal(t, 2, requests, "the walk stops at the requested page")
+
+	res, err = a.Search(context.Background(), source.SearchQuery{Page: 5})
+	require.NoError(t, err)
+	assert.Empty(t, res.Mods, "a page past the last one is empty")
+}
+
+func TestAPILinkPaginationStaysOnOrigin(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<https://elsewhere.test/files?page=2>; rel="next"`)
+		_, _ = w.Write([]byte(`{"files": [{"id": 1}]}`))
+	}))
+	defer srv.Close()
+
+	def := apiDef(srv.URL)
+	def.API.Endpoints.ModFiles.Pagination = &PaginationConfig{Type: PaginationLink}
+	a, err := NewAPI(def)
+	require.NoError(t, err)
+
+	_, err = a.GetModFiles(context.Background(), &domain.Mod{ID: "77"})
+	assert.ErrorContains(t, err, "is not on")
+}
+
+func TestAPIPostBodyTemplate(t *testing.T) {
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&gotBody))
+		_, _ = w.Write([]byte(`{"data": {"mods": {"nodes": [{"id": 1, "name": "Quoted \"Mod\""}]}}}`))
+	}))
+	defer srv.Close()
+
+	def := apiDef(srv.URL)
+	def.API.Endpoints.Search = &EndpointConfig{
+		Path: "/graphql", Method: "POST", List: "data.mods.nodes",
+		Body: `{"query": "query($q: String!) { mods(q: $q) { nodes { id name } } }", "variables": {"q": "{query}", "first": {page_size}}}`,
+	}
+	a, err := NewAPI(def)
+	require.NoError(t, err)
+
+	res, err := a.Search(context.Background(), source.SearchQuery{Query: `say "hi"`, PageSize: 5})
+	require.NoError(t, err)
+	require.Len(t, res.Mods, 1)
+	assert.Equal(t, `Quoted "Mod"`, res.Mods[0].Name)
+	assert.Equal(t, map[string]any{"q": `say "hi"`, "first": float64(5)}, gotBody["variables"])
+}
@@ -18,6 +18,10 @@ func New(def SourceDefinition) (source.ModSource, error) {
 		return NewManifest(def)
 	case TypeAPI:
 		return NewAPI(def)
+	case TypeExec:
+		return NewExec(def)
+	case TypeReleases:
+		return NewReleases(def)
 	default:
 		return nil, fmt.Errorf("source type %q is not yet supported", def.Type)
 	}
@@ -1,6 +1,7 @@
 package custom
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,6 +39,30 @@ func TestNew(t *testing.T) {
 		assert.Equal(t, "my-api", src.ID())
 	})
 
+	t.Run("exec type constructs a source", func(t *testing.T) {
+		def := SourceDefinition{
+			ID:   "my-plugin",
+			Name: "My Plugin",
+			Type: TypeExec,
+			Exec: &ExecConfig{Command: os.Args[0]},
+		}
+		src, err := New(def)
+		assert.NoError(t, err)
+		assert.Equal(t, "my-plugin", src.ID())
+	})
+
+	t.Run("releases type constructs a source", func(t *testing.T) {
+		def := SourceDefinition{
+			ID:       "my-tools",
+			Name:     "My Tools",
+			Type:     TypeReleases,
+			Releases: &ReleasesConfig{Repos: []string{"owner/repo"}},
+		}
+		src, err := New(def)
+		assert.NoError(t, err)
+		assert.Equal(t, "my-tools", src.ID())
+	})
+
 	t.Run("unknown type is rejected", func(t *testing.T) {
 		def := SourceDefinition{ID: "x", Name: "X", Type: "ftp"}
 		_, err := New(def)
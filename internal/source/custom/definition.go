@@ -4,11 +4,15 @@
 package custom
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/source/minisign"
 )
 
 // Source type identifiers for SourceDefinition.Type.
@@ -16,11 +20,19 @@ const (
 	TypeDirectory = "directory"
 	TypeManifest  = "manifest"
 	TypeAPI       = "api"
+	TypeExec      = "exec"
+	TypeReleases  = "releases"
+)
+
+// Release providers for ReleasesConfig.Provider.
+const (
+	ProviderGitHub = "github"
+	ProviderGitea  = "gitea" // also Forgejo, which keeps Gitea's API
 )
 
 // SourceDefinition is one user-defined source, parsed from a YAML file in
-// <configDir>/sources/. Exactly one of Directory/Manifest/API must be set,
-// matching Type.
+// <configDir>/sources/. Exactly one of Directory/Manifest/API/Exec/Releases
+// must be set, matching Type.
 type SourceDefinition struct {
 	ID        string           `yaml:"id"`
 	Name      string           `yaml:"name"`
@@ -29,6 +41,8 @@ type SourceDefinition struct {
 	Directory *DirectoryConfig `yaml:"directory"`
 	Manifest  *ManifestConfig  `yaml:"manifest"`
 	API       *APIConfig       `yaml:"api"`
+	Exec      *ExecConfig      `yaml:"exec"`
+	Releases  *ReleasesConfig  `yaml:"releases"`
 }
 
 // DirectoryConfig configures a local-directory source.
@@ -38,9 +52,10 @@ type DirectoryConfig struct {
 
 // ManifestConfig configures a manifest source (Phase 3).
 type ManifestConfig struct {
-	URL     string      `yaml:"url"`
-	Refresh string      `yaml:"refresh"` // Go duration string, e.g. "15m"; empty = default
-	Auth    *AuthConfig `yaml:"auth"`
+	URL       string      `yaml:"url"`
+	Refresh   string      `yaml:"refresh"` // Go duration string, e.g. "15m"; empty = default
+	Auth      *AuthConfig `yaml:"auth"`
+	PublicKey string      `yaml:"public_key"` // minisign public key; set = <url>.minisig must verify
 }
 
 // AuthConfig configures optional API-key authentication for a custom source.
@@ -67,28 +82,70 @@ type APIConfig struct {
 
 // APIEndpoints defines optional endpoint configurations for an API source.
 type APIEndpoints struct {
-	Search      *EndpointConfig `yaml:"search"`
-	GetMod      *EndpointConfig `yaml:"get_mod"`
-	ModFiles    *EndpointConfig `yaml:"mod_files"`
-	DownloadURL *EndpointConfig `yaml:"download_url"`
+	Search       *EndpointConfig `yaml:"search"`
+	GetMod       *EndpointConfig `yaml:"get_mod"`
+	ModFiles     *EndpointConfig `yaml:"mod_files"`
+	DownloadURL  *EndpointConfig `yaml:"download_url"`
+	Dependencies *EndpointConfig `yaml:"dependencies"`
 }
 
 // EndpointConfig configures a single API endpoint.
 type EndpointConfig struct {
-	Path  string `yaml:"path"`  // required; may contain {placeholders}
-	List  string `yaml:"list"`  // dot-path to results array (required for search & mod_files)
-	Total string `yaml:"total"` // optional dot-path to total count (search only)
-	Field string `yaml:"field"` // dot-path to a scalar (required for download_url)
+	Path       string            `yaml:"path"`       // required; may contain {placeholders}
+	Method     string            `yaml:"method"`     // "GET" (default) or "POST"
+	Body       string            `yaml:"body"`       // JSON body template (required for POST); may contain {placeholders}
+	List       string            `yaml:"list"`       // dot-path to results array (required for search, mod_files & dependencies)
+	Total      string            `yaml:"total"`      // optional dot-path to total count (search only)
+	Field      string            `yaml:"field"`      // dot-path to a scalar (required for download_url)
+	Pagination *PaginationConfig `yaml:"pagination"` // optional; list endpoints only
+}
+
+// Pagination types for list endpoints. Without a pagination block a list
+// endpoint is a single request, paged (search only) by {page}/{offset}.
+const (
+	PaginationCursor = "cursor" // the response carries the next page's {cursor}
+	PaginationLink   = "link"   // the response's Link header carries rel="next"
+)
+
+// PaginationConfig says how a list endpoint reaches its following pages.
+type PaginationConfig struct {
+	Type   string `yaml:"type"`   // "cursor" or "link"
+	Cursor string `yaml:"cursor"` // dot-path to the next cursor (required for cursor)
 }
 
 // APIMappings maps domain field keys to JSON dot-paths.
 type APIMappings struct {
-	Mod  map[string]string `yaml:"mod"` // domain field key -> JSON dot-path
-	File map[string]string `yaml:"file"`
+	Mod        map[string]string `yaml:"mod"` // domain field key -> JSON dot-path
+	File       map[string]string `yaml:"file"`
+	Dependency map[string]string `yaml:"dependency"`
+}
+
+// ExecConfig configures an out-of-process plugin source: lmm runs Command
+// and speaks JSON-RPC with it over stdin/stdout (see exec.go).
+type ExecConfig struct {
+	Command string            `yaml:"command"` // path (~/ expanded) or a name looked up on $PATH
+	Args    []string          `yaml:"args"`
+	Env     map[string]string `yaml:"env"`     // added to lmm's own environment
+	Timeout string            `yaml:"timeout"` // Go duration string bounding each call; empty = default
+	Auth    bool              `yaml:"auth"`    // the plugin takes an API key, passed as LMM_API_KEY
+}
+
+// ReleasesConfig configures a source serving the release assets of a fixed
+// list of GitHub or Gitea/Forgejo repositories (see releases.go).
+type ReleasesConfig struct {
+	Provider    string      `yaml:"provider"` // "github" (default) or "gitea"
+	BaseURL     string      `yaml:"base_url"` // API root; empty = https://api.github.com (required for gitea)
+	Repos       []string    `yaml:"repos"`    // "owner/repo"
+	Asset       string      `yaml:"asset"`    // regexp an asset name must match; empty = every asset
+	Prereleases bool        `yaml:"prereleases"`
+	Auth        *AuthConfig `yaml:"auth"`
 }
 
 var idPattern = regexp.MustCompile(`^[a-z0-9-]+$`)
 
+// repoPattern matches a releases source's "owner/repo" entries.
+var repoPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
 // knownModMappingKeys / knownFileMappingKeys are the domain fields a mapping
 // may target. Unknown keys are validation errors so typos surface at load
 // time instead of silently producing empty fields.
@@ -99,20 +156,35 @@ var knownModMappingKeys = map[string]bool{
 
 var knownFileMappingKeys = map[string]bool{
 	"id": true, "name": true, "filename": true, "version": true, "size": true,
+	"sha256": true, "primary": true, "category": true, "mirrors": true,
+}
+
+var knownDependencyMappingKeys = map[string]bool{
+	"mod_id": true, "source_id": true, "version": true,
+}
+
+// endpointPlaceholders are every {placeholder} an endpoint template may use.
+// Body templates are checked for JSON validity with each substituted by 0.
+var endpointPlaceholders = []string{
+	"game_id", "query", "page", "page_size", "offset", "category", "tags",
+	"mod_id", "file_id", "cursor",
 }
 
 // validateEndpointsAndMappings checks the api block's endpoint/mapping rules
-// (design §4): at least one endpoint, per-endpoint required fields, required
-// mapping keys, and no unknown mapping keys.
+// (design §4): at least one endpoint, per-endpoint required fields, request
+// method/body and pagination rules, required mapping keys, and no unknown
+// mapping keys.
 func (c *APIConfig) validateEndpointsAndMappings() error {
 	eps := []struct {
-		name string
-		ep   *EndpointConfig
+		name   string
+		ep     *EndpointConfig
+		isList bool
 	}{
-		{"search", c.Endpoints.Search},
-		{"get_mod", c.Endpoints.GetMod},
-		{"mod_files", c.Endpoints.ModFiles},
-		{"download_url", c.Endpoints.DownloadURL},
+		{"search", c.Endpoints.Search, true},
+		{"get_mod", c.Endpoints.GetMod, false},
+		{"mod_files", c.Endpoints.ModFiles, true},
+		{"download_url", c.Endpoints.DownloadURL, false},
+		{"dependencies", c.Endpoints.Dependencies, true},
 	}
 
 	defined := false
@@ -124,16 +196,24 @@ func (c *APIConfig) validateEndpointsAndMappings() error {
 		if e.ep.Path == "" {
 			return fmt.Errorf("endpoints.%s: path is required", e.name)
 		}
+		if e.isList && e.ep.List == "" {
+			return fmt.Errorf("endpoints.%s: list is required", e.name)
+		}
+		if err := e.ep.validateRequest(); err != nil {
+			return fmt.Errorf("endpoints.%s: %w", e.name, err)
+		}
+		if e.ep.Pagination != nil {
+			if !e.isList {
+				return fmt.Errorf("endpoints.%s: pagination is only supported on search, mod_files and dependencies", e.name)
+			}
+			if err := e.ep.validatePagination(); err != nil {
+				return fmt.Errorf("endpoints.%s: %w", e.name, err)
+			}
+		}
 	}
 	if !defined {
 		return errors.New("endpoints: at least one endpoint must be defined")
 	}
-	if c.Endpoints.Search != nil && c.Endpoints.Search.List == "" {
-		return errors.New("endpoints.search: list is required")
-	}
-	if c.Endpoints.ModFiles != nil && c.Endpoints.ModFiles.List == "" {
-		return errors.New("endpoints.mod_files: list is required")
-	}
 	if c.Endpoints.DownloadURL != nil && c.Endpoints.DownloadURL.Field == "" {
 		return errors.New("endpoints.download_url: field is required")
 	}
@@ -157,6 +237,63 @@ func (c *APIConfig) validateEndpointsAndMappings() error {
 			return fmt.Errorf("mappings.file: unknown key %q", k)
 		}
 	}
+	if c.Endpoints.Dependencies != nil && c.Mappings.Dependency["mod_id"] == "" {
+		return errors.New(`mappings.dependency: "mod_id" is required when dependencies is defined`)
+	}
+	for k := range c.Mappings.Dependency {
+		if !knownDependencyMappingKeys[k] {
+			return fmt.Errorf("mappings.dependency: unknown key %q", k)
+		}
+	}
+	return nil
+}
+
+// validateRequest checks an endpoint's method and body: GET sends no body,
+// POST requires one, and the body template must be valid JSON once its
+// placeholders are substituted.
+func (e *EndpointConfig) validateRequest() error {
+	switch strings.ToUpper(e.Method) {
+	case "", http.MethodGet:
+		if e.Body != "" {
+			return errors.New(`body requires method "POST"`)
+		}
+	case http.MethodPost:
+		if e.Body == "" {
+			return errors.New(`method "POST" requires a body`)
+		}
+		sample := e.Body
+		for _, p := range endpointPlaceholders {
+			sample = strings.ReplaceAll(sample, "{"+p+"}", "0")
+		}
+		if !json.Valid([]byte(sample)) {
+			return errors.New("body is not a valid JSON template")
+		}
+	default:
+		return fmt.Errorf(`method must be "GET" or "POST", got %q`, e.Method)
+	}
+	return nil
+}
+
+// validatePagination checks a list endpoint's pagination block: a cursor
+// endpoint needs the response path its next cursor is read from, and a
+// {cursor} placeholder in its path or body to send it back.
+func (e *EndpointConfig) validatePagination() error {
+	p := e.Pagination
+	switch p.Type {
+	case PaginationCursor:
+		if p.Cursor == "" {
+			return errors.New("pagination.cursor is required for cursor pagination")
+		}
+		if !strings.Contains(e.Path, "{cursor}") && !strings.Contains(e.Body, "{cursor}") {
+			return errors.New("cursor pagination requires a {cursor} placeholder in path or body")
+		}
+	case PaginationLink:
+		if p.Cursor != "" {
+			return errors.New("pagination.cursor is only valid for cursor pagination")
+		}
+	default:
+		return fmt.Errorf(`pagination.type must be %q or %q, got %q`, PaginationCursor, PaginationLink, p.Type)
+	}
 	return nil
 }
 
@@ -204,8 +341,14 @@ func (d *SourceDefinition) Validate() error {
 	if d.API != nil {
 		blocks++
 	}
+	if d.Exec != nil {
+		blocks++
+	}
+	if d.Releases != nil {
+		blocks++
+	}
 	if blocks > 1 {
-		return errors.New("exactly one of directory/manifest/api may be set")
+		return errors.New("exactly one of directory/manifest/api/exec/releases may be set")
 	}
 
 	switch d.Type {
@@ -238,6 +381,11 @@ func (d *SourceDefinition) Validate() error {
 		if err := validateAuth(d.Manifest.Auth); err != nil {
 			return fmt.Errorf("manifest: %w", err)
 		}
+		if d.Manifest.PublicKey != "" {
+			if _, err := minisign.ParsePublicKey(d.Manifest.PublicKey); err != nil {
+				return fmt.Errorf("manifest.public_key: %w", err)
+			}
+		}
 	case TypeAPI:
 		if d.API == nil {
 			return fmt.Errorf(`type %q requires an "api" block`, d.Type)
@@ -257,13 +405,71 @@ func (d *SourceDefinition) Validate() error {
 		if err := d.API.validateEndpointsAndMappings(); err != nil {
 			return fmt.Errorf("api: %w", err)
 		}
+	case TypeExec:
+		if d.Exec == nil {
+			return fmt.Errorf(`type %q requires an "exec" block`, d.Type)
+		}
+		if d.Exec.Command == "" {
+			return errors.New("exec.command is required")
+		}
+		if d.Exec.Timeout != "" {
+			timeout, err := time.ParseDuration(d.Exec.Timeout)
+			if err != nil {
+				return fmt.Errorf("exec.timeout: %w", err)
+			}
+			if timeout <= 0 {
+				return errors.New("exec.timeout must be positive")
+			}
+		}
+	case TypeReleases:
+		if d.Releases == nil {
+			return fmt.Errorf(`type %q requires a "releases" block`, d.Type)
+		}
+		if err := d.validateReleases(); err != nil {
+			return fmt.Errorf("releases: %w", err)
+		}
 	default:
-		return fmt.Errorf("unknown type %q (expected %s, %s, or %s)", d.Type, TypeDirectory, TypeManifest, TypeAPI)
+		return fmt.Errorf("unknown type %q (expected %s, %s, %s, %s, or %s)", d.Type, TypeDirectory, TypeManifest, TypeAPI, TypeExec, TypeReleases)
 	}
 
 	return nil
 }
 
+// validateReleases checks the releases block: a known provider, a usable
+// base URL (required for gitea, which has no canonical host), well-formed
+// owner/repo entries, and an asset pattern that compiles.
+func (d *SourceDefinition) validateReleases() error {
+	c := d.Releases
+	switch c.Provider {
+	case "", ProviderGitHub, ProviderGitea:
+	default:
+		return fmt.Errorf("provider must be %q or %q, got %q", ProviderGitHub, ProviderGitea, c.Provider)
+	}
+	if c.BaseURL == "" && c.Provider == ProviderGitea {
+		return errors.New(`base_url is required for provider "gitea"`)
+	}
+	if c.BaseURL != "" {
+		if !strings.HasPrefix(c.BaseURL, "https://") && !strings.HasPrefix(c.BaseURL, "http://") {
+			return errors.New("base_url must be an http(s) URL")
+		}
+		if err := d.checkURL(c.BaseURL); err != nil {
+			return fmt.Errorf("base_url: %w", err)
+		}
+	}
+	if len(c.Repos) == 0 {
+		return errors.New("repos: at least one owner/repo is required")
+	}
+	for _, r := range c.Repos {
+		if !repoPattern.MatchString(r) {
+			return fmt.Errorf("repos: %q is not owner/repo", r)
+		}
+	}
+	if _, err := regexp.Compile(c.Asset); err != nil {
+		return fmt.Errorf("asset: %w", err)
+	}
+	return validateAuth(c.Auth)
+}
+
 // checkURL rejects plain-http URLs unless allow_http is set. Non-URL values
 // (local paths) pass through untouched.
 func (d *SourceDefinition) checkURL(u string) error {
@@ -84,6 +84,16 @@ func TestSourceDefinitionValidate(t *testing.T) {
 			d.Directory = nil
 			d.Manifest = &ManifestConfig{URL: "https://x.test/m.yaml", Refresh: "soon"}
 		}, "refresh"},
+		{"manifest with public key", func(d *SourceDefinition) {
+			d.Type = TypeManifest
+			d.Directory = nil
+			d.Manifest = &ManifestConfig{URL: "https://x.test/m.yaml", PublicKey: "RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3"}
+		}, ""},
+		{"manifest with malformed public key", func(d *SourceDefinition) {
+			d.Type = TypeManifest
+			d.Directory = nil
+			d.Manifest = &ManifestConfig{URL: "https://x.test/m.yaml", PublicKey: "RWQnotakey"}
+		}, "manifest.public_key: not a minisign public key"},
 		{"valid full api", func(d *SourceDefinition) {
 			*d = validAPIDef()
 		}, ""},
@@ -131,6 +141,80 @@ func TestSourceDefinitionValidate(t *testing.T) {
 			*d = validAPIDef()
 			d.API.Mappings.File["sha512"] = "x"
 		}, `mappings.file: unknown key "sha512"`},
+		{"api dependencies endpoint", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.Dependencies = &EndpointConfig{Path: "/mods/{mod_id}/deps", List: "deps"}
+			d.API.Mappings.Dependency = map[string]string{"mod_id": "id", "source_id": "source"}
+		}, ""},
+		{"api dependencies missing list", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.Dependencies = &EndpointConfig{Path: "/mods/{mod_id}/deps"}
+			d.API.Mappings.Dependency = map[string]string{"mod_id": "id"}
+		}, "dependencies: list is required"},
+		{"api dependencies without mod_id mapping", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.Dependencies = &EndpointConfig{Path: "/mods/{mod_id}/deps", List: "deps"}
+		}, `mappings.dependency: "mod_id" is required`},
+		{"api unknown dependency mapping key", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Mappings.Dependency = map[string]string{"mod_id": "id", "optional": "x"}
+		}, `mappings.dependency: unknown key "optional"`},
+		{"api checksum file mappings", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Mappings.File["sha256"] = "hashes.sha256"
+			d.API.Mappings.File["primary"] = "is_main"
+			d.API.Mappings.File["category"] = "type"
+		}, ""},
+		{"api graphql post", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.Search = &EndpointConfig{
+				Path: "/graphql", Method: "post", List: "data.mods.nodes",
+				Body:       `{"query": "query($q: String!, $after: String) { mods(q: $q, after: $after) { nodes { id name } } }", "variables": {"q": "{query}", "after": "{cursor}", "first": {page_size}}}`,
+				Pagination: &PaginationConfig{Type: PaginationCursor, Cursor: "data.mods.pageInfo.endCursor"},
+			}
+		}, ""},
+		{"api post without body", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.GetMod.Method = "POST"
+		}, `get_mod: method "POST" requires a body`},
+		{"api get with body", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.GetMod.Body = `{"id": "{mod_id}"}`
+		}, `get_mod: body requires method "POST"`},
+		{"api unknown method", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.GetMod.Method = "PUT"
+		}, `get_mod: method must be "GET" or "POST"`},
+		{"api invalid body template", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.GetMod.Method = "POST"
+			d.API.Endpoints.GetMod.Body = `{"id": {mod_id}`
+		}, "get_mod: body is not a valid JSON template"},
+		{"api link pagination", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.ModFiles.Pagination = &PaginationConfig{Type: PaginationLink}
+		}, ""},
+		{"api pagination on non-list endpoint", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.GetMod.Pagination = &PaginationConfig{Type: PaginationLink}
+		}, "get_mod: pagination is only supported on search, mod_files and dependencies"},
+		{"api unknown pagination type", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.Search.Pagination = &PaginationConfig{Type: "page"}
+		}, "search: pagination.type must be"},
+		{"api cursor pagination missing cursor path", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.Search.Path = "/mods?after={cursor}"
+			d.API.Endpoints.Search.Pagination = &PaginationConfig{Type: PaginationCursor}
+		}, "pagination.cursor is required"},
+		{"api cursor pagination missing placeholder", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.Search.Pagination = &PaginationConfig{Type: PaginationCursor, Cursor: "next"}
+		}, "requires a {cursor} placeholder"},
+		{"api link pagination with cursor path", func(d *SourceDefinition) {
+			*d = validAPIDef()
+			d.API.Endpoints.Search.Pagination = &PaginationConfig{Type: PaginationLink, Cursor: "next"}
+		}, "only valid for cursor pagination"},
 		{"api with auth", func(d *SourceDefinition) {
 			*d = validAPIDef()
 			d.API.Auth = &AuthConfig{APIKey: &APIKeyConfig{In: "header", Name: "X-API-Key"}}
@@ -180,6 +264,74 @@ func TestSourceDefinitionValidate(t *testing.T) {
 				Auth: &AuthConfig{APIKey: &APIKeyConfig{In: "header"}},
 			}
 		}, "auth.api_key.name is required"},
+		{"valid exec", func(d *SourceDefinition) {
+			d.Type = TypeExec
+			d.Directory = nil
+			d.Exec = &ExecConfig{Command: "~/bin/my-source", Timeout: "5s"}
+		}, ""},
+		{"exec without block", func(d *SourceDefinition) {
+			d.Type = TypeExec
+			d.Directory = nil
+		}, `requires an "exec" block`},
+		{"exec missing command", func(d *SourceDefinition) {
+			d.Type = TypeExec
+			d.Directory = nil
+			d.Exec = &ExecConfig{}
+		}, "exec.command is required"},
+		{"exec bad timeout", func(d *SourceDefinition) {
+			d.Type = TypeExec
+			d.Directory = nil
+			d.Exec = &ExecConfig{Command: "my-source", Timeout: "-1s"}
+		}, "exec.timeout must be positive"},
+		{"valid releases", func(d *SourceDefinition) {
+			d.Type = TypeReleases
+			d.Directory = nil
+			d.Releases = &ReleasesConfig{Repos: []string{"BepInEx/BepInEx"}, Asset: `linux_x64.*\.zip$`}
+		}, ""},
+		{"valid gitea releases", func(d *SourceDefinition) {
+			d.Type = TypeReleases
+			d.Directory = nil
+			d.Releases = &ReleasesConfig{
+				Provider: ProviderGitea,
+				BaseURL:  "https://codeberg.org/api/v1",
+				Repos:    []string{"owner/repo"},
+				Auth:     &AuthConfig{APIKey: &APIKeyConfig{In: "header", Name: "Authorization"}},
+			}
+		}, ""},
+		{"releases without block", func(d *SourceDefinition) {
+			d.Type = TypeReleases
+			d.Directory = nil
+		}, `requires a "releases" block`},
+		{"releases unknown provider", func(d *SourceDefinition) {
+			d.Type = TypeReleases
+			d.Directory = nil
+			d.Releases = &ReleasesConfig{Provider: "gitlab", Repos: []string{"o/r"}}
+		}, "provider must be"},
+		{"gitea releases without base_url", func(d *SourceDefinition) {
+			d.Type = TypeReleases
+			d.Directory = nil
+			d.Releases = &ReleasesConfig{Provider: ProviderGitea, Repos: []string{"o/r"}}
+		}, "base_url is required"},
+		{"releases plain http base_url", func(d *SourceDefinition) {
+			d.Type = TypeReleases
+			d.Directory = nil
+			d.Releases = &ReleasesConfig{BaseURL: "http://git.lan/api/v3", Repos: []string{"o/r"}}
+		}, "plain http is disabled"},
+		{"releases without repos", func(d *SourceDefinition) {
+			d.Type = TypeReleases
+			d.Directory = nil
+			d.Releases = &ReleasesConfig{}
+		}, "at least one owner/repo"},
+		{"releases malformed repo", func(d *SourceDefinition) {
+			d.Type = TypeReleases
+			d.Directory = nil
+			d.Releases = &ReleasesConfig{Repos: []string{"https://github.com/o/r"}}
+		}, "is not owner/repo"},
+		{"releases bad asset pattern", func(d *SourceDefinition) {
+			d.Type = TypeReleases
+			d.Directory = nil
+			d.Releases = &ReleasesConfig{Repos: []string{"o/r"}, Asset: "("}
+		}, "releases: asset:"},
 	}
 
 	for _, tt := range tests {
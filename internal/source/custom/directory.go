@@ -1,10 +1,12 @@
 package custom
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/DonovanMods/linux-mod-manager/internal/domain"
@@ -63,7 +65,7 @@ func (d *Directory) ExchangeToken(ctx context.Context, code string) (*source.Tok
 
 // Capabilities implements source.CapabilityReporter.
 func (d *Directory) Capabilities() source.Capabilities {
-	return source.Capabilities{Search: true, Updates: true}
+	return source.Capabilities{Search: true, Dependencies: true, Updates: true}
 }
 
 // TypeLabel implements source.TypeLabeler.
@@ -74,6 +76,10 @@ type dirMod struct {
 	mod  domain.Mod
 	path string // absolute path to the mod directory or archive
 	size int64  // archive size in bytes; 0 for directories
+	// metaName is the mod's name in its metadata file (a Fabric/Forge mod
+	// ID, a Thunderstore package name), which other mods' dependencies use.
+	metaName string
+	deps     []string // dependencies declared in its metadata file
 }
 
 // scan reads the source directory. Subdirectories are directory mods;
@@ -114,17 +120,12 @@ func (d *Directory) scan() ([]dirMod, error) {
 			continue
 		}
 		base := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
-		mod := domain.Mod{ID: base, SourceID: d.id}
+		dm := dirMod{mod: domain.Mod{ID: base, SourceID: d.id}, path: entryPath, size: info.Size()}
+		dm.mod.Name, dm.mod.Version = nameAndVersionFrom(base)
 		if meta := metadata.ResolveArchive(entryPath); meta != nil {
-			applyMetadata(&mod, meta)
-		} else {
-			mod.Name, mod.Version = nameAndVersionFrom(base)
+			dm.setMetadata(meta)
 		}
-		mods = append(mods, dirMod{
-			mod:  mod,
-			path: entryPath,
-			size: info.Size(),
-		})
+		mods = append(mods, dm)
 	}
 
 	return mods, nil
@@ -133,27 +134,35 @@ func (d *Directory) scan() ([]dirMod, error) {
 // scanDir builds a dirMod for a mod directory, preferring well-known metadata
 // files over dirname parsing.
 func (d *Directory) scanDir(dirName, dirPath string) dirMod {
-	mod := domain.Mod{ID: dirName, SourceID: d.id}
-
+	dm := dirMod{mod: domain.Mod{ID: dirName, SourceID: d.id}, path: dirPath}
+	dm.mod.Name, dm.mod.Version = nameAndVersionFrom(dirName)
 	if info := metadata.Resolve(dirPath); info != nil {
-		applyMetadata(&mod, info)
-	} else {
-		mod.Name, mod.Version = nameAndVersionFrom(dirName)
+		dm.setMetadata(info)
 	}
+	return dm
+}
 
-	return dirMod{mod: mod, path: dirPath}
+// setMetadata records a mod's metadata file on its dirMod: the fields
+// applyMetadata copies, plus its metadata name and declared dependencies.
+func (dm *dirMod) setMetadata(info *metadata.Info) {
+	applyMetadata(&dm.mod, info)
+	dm.metaName = info.Name
+	dm.deps = info.Dependencies
 }
 
 // applyMetadata copies well-known metadata fields onto mod, used by both
 // directory mods (metadata.Resolve) and archive mods (metadata.ResolveArchive)
 // so they share the same precedence: DisplayName wins, falling back to Name,
-// and metadata always wins over any filename-derived guess.
+// and metadata wins over the filename-derived name and version already on
+// mod - except where it leaves a field empty (meta.ini names no mod, and a
+// mods.toml version is often a build placeholder).
 func applyMetadata(mod *domain.Mod, info *metadata.Info) {
-	mod.Name = info.DisplayName
-	if mod.Name == "" {
-		mod.Name = info.Name
+	if name := cmp.Or(info.DisplayName, info.Name); name != "" {
+		mod.Name = name
+	}
+	if info.Version != "" {
+		mod.Version = info.Version
 	}
-	mod.Version = info.Version
 	mod.Summary = info.Summary
 	mod.Author = info.Author
 }
@@ -212,9 +221,45 @@ func (d *Directory) GetMod(ctx context.Context, gameID, modID string) (*domain.M
 	return &mod, nil
 }
 
-// GetDependencies implements source.ModSource; directory mods declare none.
+// GetDependencies implements source.ModSource from the dependencies a mod's
+// metadata file declares (fabric.mod.json, mods.toml, Thunderstore
+// manifest.json). Each resolves to the mod in this directory whose metadata
+// name - or, failing that, directory or file name - matches, ignoring case;
+// the ones that match nothing (the loader, the game, mods from elsewhere)
+// are skipped.
 func (d *Directory) GetDependencies(ctx context.Context, mod *domain.Mod) ([]domain.ModReference, error) {
-	return nil, fmt.Errorf("source %q: dependencies: %w", d.id, source.ErrNotSupported)
+	scanned, err := d.scan()
+	if err != nil {
+		return nil, err
+	}
+	idx := slices.IndexFunc(scanned, func(dm dirMod) bool { return dm.mod.ID == mod.ID })
+	if idx < 0 {
+		return nil, fmt.Errorf("source %q: mod not found: %s", d.id, mod.ID)
+	}
+
+	byName := make(map[string]string, 2*len(scanned))
+	for _, dm := range scanned {
+		if dm.metaName != "" {
+			byName[strings.ToLower(dm.metaName)] = dm.mod.ID
+		}
+	}
+	for _, dm := range scanned {
+		if _, ok := byName[strings.ToLower(dm.mod.ID)]; !ok {
+			byName[strings.ToLower(dm.mod.ID)] = dm.mod.ID
+		}
+	}
+
+	var refs []domain.ModReference
+	seen := map[string]bool{mod.ID: true}
+	for _, dep := range scanned[idx].deps {
+		id, ok := byName[strings.ToLower(dep)]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		refs = append(refs, domain.ModReference{SourceID: d.id, ModID: id})
+	}
+	return refs, nil
 }
 
 // GetModFiles implements source.ModSource: every mod has exactly one synthetic
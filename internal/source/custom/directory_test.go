@@ -75,14 +75,41 @@ func TestDirectoryIdentityAndCapabilities(t *testing.T) {
 	d := newTestDirectory(t)
 	assert.Equal(t, "my-mods", d.ID())
 	assert.Equal(t, "My Mods", d.Name())
-	assert.Equal(t, source.Capabilities{Search: true, Updates: true}, d.Capabilities())
+	assert.Equal(t, source.Capabilities{Search: true, Dependencies: true, Updates: true}, d.Capabilities())
 	assert.Empty(t, d.AuthURL())
 
 	_, err := d.ExchangeToken(context.Background(), "code")
 	assert.True(t, errors.Is(err, source.ErrNotSupported))
+}
 
-	_, err = d.GetDependencies(context.Background(), nil)
-	assert.True(t, errors.Is(err, source.ErrNotSupported))
+// TestDirectoryDependencies covers dependencies declared in metadata files:
+// they resolve to mods in the directory by metadata name or, failing that,
+// by directory/file name, and ones naming nothing here are skipped.
+func TestDirectoryDependencies(t *testing.T) {
+	root := t.TempDir()
+	writeTestZip(t, filepath.Join(root, "sodium-0.5.8.jar"), "fabric.mod.json",
+		`{"id": "sodium", "version": "0.5.8", "name": "Sodium", "depends": {"fabricloader": ">=0.15", "fabric-api": "*", "Indium": "*"}}`)
+	writeTestZip(t, filepath.Join(root, "fabric-api-0.92.0.jar"), "fabric.mod.json",
+		`{"id": "fabric-api", "version": "0.92.0", "name": "Fabric API"}`)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "indium"), 0755))
+
+	d, err := NewDirectory(SourceDefinition{ID: "mc", Name: "MC", Type: TypeDirectory, Directory: &DirectoryConfig{Path: root}})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	deps, err := d.GetDependencies(ctx, &domain.Mod{ID: "sodium-0.5.8"})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ModReference{
+		{SourceID: "mc", ModID: "indium"},
+		{SourceID: "mc", ModID: "fabric-api-0.92.0"},
+	}, deps, "fabricloader is not in the directory and is skipped")
+
+	deps, err = d.GetDependencies(ctx, &domain.Mod{ID: "indium"})
+	require.NoError(t, err)
+	assert.Empty(t, deps, "a mod without metadata declares nothing")
+
+	_, err = d.GetDependencies(ctx, &domain.Mod{ID: "missing"})
+	assert.ErrorContains(t, err, "not found")
 }
 
 func TestDirectory_TypeLabel(t *testing.T) {
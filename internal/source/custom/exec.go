@@ -0,0 +1,838 @@
+package custom
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source"
+)
+
+// execProtocolVersion is the plugin protocol version this build speaks. The
+// initialize handshake refuses a plugin reporting any other version.
+const execProtocolVersion = 1
+
+// defaultExecTimeout bounds each call when the definition sets no timeout.
+const defaultExecTimeout = 30 * time.Second
+
+// maxExecMessage bounds one protocol message read from a plugin (same
+// defense class as maxAPIResponseSize).
+const maxExecMessage = 10 << 20 // 10 MiB
+
+// execStderrTail is how much of a plugin's stderr is kept for error reports.
+const execStderrTail = 4 << 10
+
+// execShutdownGrace is how long Close waits for a plugin to exit on its own
+// after its stdin is closed before killing it.
+const execShutdownGrace = 2 * time.Second
+
+// JSON-RPC error codes a plugin can answer with. -32601 is the spec's
+// "method not found"; the others come from the implementation-defined
+// server-error range and map onto the errors lmm's callers already branch on.
+const (
+	rpcMethodNotFound = -32601
+	rpcNotSupported   = -32001
+	rpcNotFound       = -32002
+	rpcAuthRequired   = -32003
+)
+
+// Exec is a ModSource served by an out-of-process plugin. lmm starts the
+// configured command on first use and keeps it running, exchanging one
+// JSON-RPC 2.0 message per line on the plugin's stdin/stdout. Calls are
+// serialized; a call that outlives the timeout (or whose context is
+// cancelled) kills the plugin, which is restarted on the next call. The
+// plugin's stderr is not shown, but its tail is appended to errors.
+type Exec struct {
+	id        string
+	name      string
+	command   string // resolved at construction
+	args      []string
+	env       []string // KEY=VALUE pairs added to lmm's environment
+	timeout   time.Duration
+	auth      bool
+	allowHTTP bool
+
+	mu      sync.Mutex // serializes calls; guards everything below
+	apiKey  string
+	proc    *execProcess
+	caps    *execCapabilities // from the last handshake; nil until one succeeds
+	authURL string
+	nextID  int64
+}
+
+// NewExec constructs an exec source from a validated definition. The
+// command must resolve to an executable, but nothing is started until the
+// source is first used.
+func NewExec(def SourceDefinition) (*Exec, error) {
+	cfg := def.Exec
+	command, err := resolveExecCommand(cfg.Command)
+	if err != nil {
+		return nil, err
+	}
+	timeout := defaultExecTimeout
+	if cfg.Timeout != "" {
+		if timeout, err = time.ParseDuration(cfg.Timeout); err != nil {
+			return nil, fmt.Errorf("exec.timeout: %w", err)
+		}
+	}
+	env := make([]string, 0, len(cfg.Env))
+	for k, v := range cfg.Env {
+		env = append(env, k+"="+v)
+	}
+	sort.Strings(env)
+
+	return &Exec{
+		id:        def.ID,
+		name:      def.Name,
+		command:   command,
+		args:      cfg.Args,
+		env:       env,
+		timeout:   timeout,
+		auth:      cfg.Auth,
+		allowHTTP: def.AllowHTTP,
+	}, nil
+}
+
+// resolveExecCommand expands a leading ~/ and resolves the command the way a
+// shell would: a path containing a slash is used as given (made absolute),
+// a bare name is looked up on $PATH.
+func resolveExecCommand(command string) (string, error) {
+	if strings.HasPrefix(command, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("expanding %q: %w", command, err)
+		}
+		command = filepath.Join(home, command[2:])
+	}
+	if strings.Contains(command, "/") {
+		abs, err := filepath.Abs(command)
+		if err != nil {
+			return "", fmt.Errorf("resolving %q: %w", command, err)
+		}
+		command = abs
+	}
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return "", fmt.Errorf("exec source command: %w", err)
+	}
+	return path, nil
+}
+
+// ID implements source.ModSource.
+func (e *Exec) ID() string { return e.id }
+
+// Name implements source.ModSource.
+func (e *Exec) Name() string { return e.name }
+
+// TypeLabel implements source.TypeLabeler.
+func (e *Exec) TypeLabel() string { return "exec" }
+
+// SetAPIKey provides the API key resolved at startup (env var or token
+// store). The plugin sees it as LMM_API_KEY; a running plugin is stopped so
+// the next call restarts it with the new key.
+func (e *Exec) SetAPIKey(key string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if key != e.apiKey && e.proc != nil {
+		e.stopLocked()
+	}
+	e.apiKey = key
+}
+
+// IsAuthenticated reports whether an API key is configured.
+func (e *Exec) IsAuthenticated() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.apiKey != ""
+}
+
+// AuthDeclared reports the definition's auth flag without starting the
+// plugin, so registration can decide whether to resolve a key for it.
+func (e *Exec) AuthDeclared() bool { return e.auth }
+
+// Capabilities implements source.CapabilityReporter from the plugin's
+// handshake, starting it if needed. Auth comes from the definition: the key
+// has to be resolved before the plugin starts. A plugin that fails to start
+// reports nothing but Auth; the failure itself surfaces from the next call.
+func (e *Exec) Capabilities() source.Capabilities {
+	caps, err := e.handshake(context.Background())
+	if err != nil {
+		return source.Capabilities{Auth: e.auth}
+	}
+	return source.Capabilities{
+		Search:       caps.Search,
+		Dependencies: caps.Dependencies,
+		Updates:      caps.Updates,
+		Auth:         e.auth,
+		Versions:     caps.Versions,
+	}
+}
+
+// HasGameCatalog reports whether the plugin serves list_games. Exec
+// implements source.GameCatalog for every plugin; callers check this first
+// (see source.GameCatalogOf).
+func (e *Exec) HasGameCatalog() bool {
+	caps, err := e.handshake(context.Background())
+	return err == nil && caps.Games
+}
+
+// AuthURL implements source.ModSource with the auth_url from the plugin's
+// handshake; empty when the plugin has no OAuth flow (or fails to start).
+func (e *Exec) AuthURL() string {
+	if _, err := e.handshake(context.Background()); err != nil {
+		return ""
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.authURL
+}
+
+// ExchangeToken implements source.ModSource via the exchange_token method,
+// available only to plugins that reported an auth_url.
+func (e *Exec) ExchangeToken(ctx context.Context, code string) (*source.Token, error) {
+	if e.AuthURL() == "" {
+		return nil, fmt.Errorf("source %q: authentication: %w", e.id, source.ErrNotSupported)
+	}
+	var res execToken
+	if err := e.call(ctx, "exchange_token", map[string]string{"code": code}, &res); err != nil {
+		return nil, err
+	}
+	tok := &source.Token{AccessToken: res.AccessToken, RefreshToken: res.RefreshToken}
+	if res.ExpiresAt != "" {
+		if ts, err := time.Parse(time.RFC3339, res.ExpiresAt); err == nil {
+			tok.ExpiresAt = ts
+		}
+	}
+	return tok, nil
+}
+
+// Search implements source.ModSource via the search method.
+func (e *Exec) Search(ctx context.Context, query source.SearchQuery) (source.SearchResult, error) {
+	if err := e.require(ctx, "search", func(c *execCapabilities) bool { return c.Search }); err != nil {
+		return source.SearchResult{}, err
+	}
+	params := execSearchParams{
+		GameID:   query.GameID,
+		Query:    query.Query,
+		Category: query.Category,
+		Tags:     query.Tags,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
+	var res execSearchResult
+	if err := e.call(ctx, "search", params, &res); err != nil {
+		return source.SearchResult{}, err
+	}
+	out := source.SearchResult{TotalCount: res.TotalCount, Page: res.Page, PageSize: res.PageSize}
+	if out.Page == 0 {
+		out.Page = query.Page
+	}
+	if out.PageSize == 0 {
+		out.PageSize = query.PageSize
+	}
+	for _, m := range res.Mods {
+		out.Mods = append(out.Mods, e.toMod(m, query.GameID))
+	}
+	return out, nil
+}
+
+// GetMod implements source.ModSource via the get_mod method.
+func (e *Exec) GetMod(ctx context.Context, gameID, modID string) (*domain.Mod, error) {
+	var res execMod
+	if err := e.call(ctx, "get_mod", execModParams{GameID: gameID, ModID: modID}, &res); err != nil {
+		return nil, err
+	}
+	if res.ID == "" {
+		return nil, fmt.Errorf("source %q: get_mod %s: plugin returned a mod without an id", e.id, modID)
+	}
+	mod := e.toMod(res, gameID)
+	return &mod, nil
+}
+
+// GetDependencies implements source.ModSource via the get_dependencies
+// method. References without a source_id point into this source.
+func (e *Exec) GetDependencies(ctx context.Context, mod *domain.Mod) ([]domain.ModReference, error) {
+	if err := e.require(ctx, "dependencies", func(c *execCapabilities) bool { return c.Dependencies }); err != nil {
+		return nil, err
+	}
+	var res []execRef
+	if err := e.call(ctx, "get_dependencies", execModParams{GameID: mod.GameID, ModID: mod.ID}, &res); err != nil {
+		return nil, err
+	}
+	return e.toRefs(res), nil
+}
+
+// GetModFiles implements source.ModSource via the get_mod_files method.
+func (e *Exec) GetModFiles(ctx context.Context, mod *domain.Mod) ([]domain.DownloadableFile, error) {
+	var res []execFile
+	if err := e.call(ctx, "get_mod_files", execModParams{GameID: mod.GameID, ModID: mod.ID}, &res); err != nil {
+		return nil, err
+	}
+	files := make([]domain.DownloadableFile, 0, len(res))
+	for _, f := range res {
+		if f.SHA256 != "" && !sha256Pattern.MatchString(f.SHA256) {
+			return nil, fmt.Errorf("source %q: mod %q: file %q: sha256 must be 64 hex characters", e.id, mod.ID, f.ID)
+		}
+		files = append(files, domain.DownloadableFile{
+			ID:          f.ID,
+			Name:        f.Name,
+			FileName:    f.Filename,
+			Version:     f.Version,
+			Size:        f.Size,
+			IsPrimary:   f.Primary,
+			Category:    f.Category,
+			Description: f.Description,
+			SHA256:      f.SHA256,
+		})
+	}
+	return files, nil
+}
+
+// GetDownloadURL implements source.ModSource via the get_download_url
+// method. The URL must be https (or http with allow_http), like a manifest's
+// file URLs.
+func (e *Exec) GetDownloadURL(ctx context.Context, mod *domain.Mod, fileID string) (string, error) {
+	var res struct {
+		URL string `json:"url"`
+	}
+	params := execFileParams{GameID: mod.GameID, ModID: mod.ID, FileID: fileID}
+	if err := e.call(ctx, "get_download_url", params, &res); err != nil {
+		return "", err
+	}
+	switch {
+	case strings.HasPrefix(res.URL, "https://"):
+	case strings.HasPrefix(res.URL, "http://") && e.allowHTTP:
+	case strings.HasPrefix(res.URL, "http://"):
+		return "", fmt.Errorf("source %q: file %q: plain http is disabled; use https or set allow_http: true", e.id, fileID)
+	default:
+		return "", fmt.Errorf("source %q: file %q: download url must be http(s), got %q", e.id, fileID, res.URL)
+	}
+	return res.URL, nil
+}
+
+// CheckUpdates implements source.ModSource via the check_updates method. The
+// plugin answers only for mods with an update; replies naming a mod that was
+// not asked about are ignored.
+func (e *Exec) CheckUpdates(ctx context.Context, installed []domain.InstalledMod) ([]domain.Update, error) {
+	if err := e.require(ctx, "updates", func(c *execCapabilities) bool { return c.Updates }); err != nil {
+		return nil, err
+	}
+	if len(installed) == 0 {
+		return nil, nil
+	}
+	params := execCheckUpdatesParams{Mods: make([]execInstalled, 0, len(installed))}
+	byID := make(map[string]domain.InstalledMod, len(installed))
+	for _, inst := range installed {
+		params.Mods = append(params.Mods, execInstalled{ID: inst.ID, GameID: inst.GameID, Version: inst.Version, FileIDs: inst.FileIDs})
+		byID[inst.ID] = inst
+	}
+	var res []execUpdate
+	if err := e.call(ctx, "check_updates", params, &res); err != nil {
+		return nil, err
+	}
+	var updates []domain.Update
+	for _, u := range res {
+		inst, ok := byID[u.ModID]
+		if !ok {
+			continue
+		}
+		updates = append(updates, domain.Update{
+			InstalledMod:       inst,
+			NewVersion:         u.NewVersion,
+			Changelog:          u.Changelog,
+			FileIDReplacements: u.FileIDReplacements,
+		})
+	}
+	return updates, nil
+}
+
+// DownloadHeaders implements source.DownloadHeaderProvider via the
+// download_headers method. The plugin decides which URLs get credentials;
+// a failed call sends none.
+func (e *Exec) DownloadHeaders(fileURL string) map[string]string {
+	ctx := context.Background()
+	if err := e.require(ctx, "download headers", func(c *execCapabilities) bool { return c.DownloadHeaders }); err != nil {
+		return nil
+	}
+	var res struct {
+		Headers map[string]string `json:"headers"`
+	}
+	if err := e.call(ctx, "download_headers", map[string]string{"url": fileURL}, &res); err != nil {
+		return nil
+	}
+	return res.Headers
+}
+
+// ListGames implements source.GameCatalog via the list_games method.
+func (e *Exec) ListGames(ctx context.Context) ([]source.GameEntry, error) {
+	if err := e.require(ctx, "game catalog", func(c *execCapabilities) bool { return c.Games }); err != nil {
+		return nil, err
+	}
+	var res []execGame
+	if err := e.call(ctx, "list_games", struct{}{}, &res); err != nil {
+		return nil, err
+	}
+	games := make([]source.GameEntry, 0, len(res))
+	for _, g := range res {
+		games = append(games, source.GameEntry{ID: g.ID, Name: g.Name, Slug: g.Slug})
+	}
+	return games, nil
+}
+
+// Close stops the plugin: its stdin is closed so it can exit on its own,
+// and it is killed if it has not within execShutdownGrace.
+func (e *Exec) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	p := e.proc
+	if p == nil {
+		return nil
+	}
+	_ = p.stdin.Close()
+	select {
+	case <-p.exited:
+	case <-time.After(execShutdownGrace):
+	}
+	e.stopLocked()
+	return nil
+}
+
+// toMod converts a plugin mod to a domain.Mod. A mod without a game_id
+// belongs to the game it was asked about.
+func (e *Exec) toMod(m execMod, gameID string) domain.Mod {
+	mod := domain.Mod{
+		ID:           m.ID,
+		SourceID:     e.id,
+		Name:         m.Name,
+		Version:      m.Version,
+		Author:       m.Author,
+		Summary:      m.Summary,
+		Description:  m.Description,
+		GameID:       m.GameID,
+		Category:     m.Category,
+		Downloads:    m.Downloads,
+		Endorsements: m.Endorsements,
+		PictureURL:   m.PictureURL,
+		SourceURL:    m.URL,
+		Dependencies: e.toRefs(m.Dependencies),
+	}
+	if mod.GameID == "" {
+		mod.GameID = gameID
+	}
+	if m.UpdatedAt != "" {
+		if ts, err := time.Parse(time.RFC3339, m.UpdatedAt); err == nil {
+			mod.UpdatedAt = ts // unparseable -> zero value, as for manifests
+		}
+	}
+	return mod
+}
+
+func (e *Exec) toRefs(refs []execRef) []domain.ModReference {
+	var out []domain.ModReference
+	for _, r := range refs {
+		ref := domain.ModReference{SourceID: r.SourceID, ModID: r.ModID, Version: r.Version}
+		if ref.SourceID == "" {
+			ref.SourceID = e.id
+		}
+		out = append(out, ref)
+	}
+	return out
+}
+
+// require returns ErrNotSupported when the plugin did not declare the
+// capability has checks for, so undeclared methods are never called. A plugin
+// that fails to start returns that error instead.
+func (e *Exec) require(ctx context.Context, what string, has func(*execCapabilities) bool) error {
+	caps, err := e.handshake(ctx)
+	if err != nil {
+		return err
+	}
+	if !has(caps) {
+		return fmt.Errorf("source %q: %s: %w", e.id, what, source.ErrNotSupported)
+	}
+	return nil
+}
+
+// handshake starts the plugin if needed and returns its capabilities.
+func (e *Exec) handshake(ctx context.Context) (*execCapabilities, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.startLocked(ctx); err != nil {
+		return nil, err
+	}
+	return e.caps, nil
+}
+
+// call sends one request to the plugin, starting it if needed, and decodes
+// the result into result.
+func (e *Exec) call(ctx context.Context, method string, params, result any) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if err := e.startLocked(ctx); err != nil {
+		return err
+	}
+	return e.roundTripLocked(ctx, method, params, result)
+}
+
+// startLocked starts the plugin and performs the initialize handshake,
+// unless a plugin is already running. A plugin that exited between calls is
+// simply started again.
+func (e *Exec) startLocked(ctx context.Context) error {
+	if e.proc != nil {
+		select {
+		case <-e.proc.exited:
+			e.stopLocked()
+		default:
+			return nil
+		}
+	}
+
+	// Not CommandContext: the plugin outlives any single call's context.
+	cmd := exec.Command(e.command, e.args...)
+	cmd.Env = append(os.Environ(), e.env...)
+	cmd.Env = append(cmd.Env, "LMM_SOURCE_ID="+e.id)
+	if e.apiKey != "" {
+		cmd.Env = append(cmd.Env, "LMM_API_KEY="+e.apiKey)
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("source %q: %w", e.id, err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("source %q: %w", e.id, err)
+	}
+	stderr := &tailBuffer{max: execStderrTail}
+	cmd.Stderr = stderr
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("source %q: starting plugin: %w", e.id, err)
+	}
+
+	p := &execProcess{
+		cmd:    cmd,
+		stdin:  stdin,
+		stderr: stderr,
+		lines:  make(chan []byte),
+		quit:   make(chan struct{}),
+		exited: make(chan struct{}),
+	}
+	go p.read(stdout)
+	e.proc = p
+
+	var init execInitResult
+	params := map[string]any{"protocol_version": execProtocolVersion, "source_id": e.id}
+	if err := e.roundTripLocked(ctx, "initialize", params, &init); err != nil {
+		e.stopLocked()
+		return err
+	}
+	if init.ProtocolVersion != execProtocolVersion {
+		e.stopLocked()
+		return fmt.Errorf("source %q: plugin speaks protocol version %d (lmm speaks %d)", e.id, init.ProtocolVersion, execProtocolVersion)
+	}
+	e.caps = &init.Capabilities
+	e.authURL = init.AuthURL
+	return nil
+}
+
+// roundTripLocked writes one request and waits for the response with the
+// same id. Transport failures (a dead plugin, a malformed message, the
+// timeout, ctx cancellation) kill the plugin so the next call starts clean;
+// an error response leaves it running.
+func (e *Exec) roundTripLocked(parent context.Context, method string, params, result any) error {
+	p := e.proc
+	e.nextID++
+	id := e.nextID
+	req, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: id, Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("source %q: %s: encoding request: %w", e.id, method, err)
+	}
+
+	ctx, cancel := context.WithTimeout(parent, e.timeout)
+	defer cancel()
+
+	if _, err := p.stdin.Write(append(req, '\n')); err != nil {
+		e.stopLocked()
+		return fmt.Errorf("source %q: %s: writing request: %w%s", e.id, method, err, p.stderr.note())
+	}
+	for {
+		select {
+		case line, ok := <-p.lines:
+			if !ok {
+				e.stopLocked()
+				return fmt.Errorf("source %q: %s: plugin exited (%s)%s", e.id, method, p.exitReason(), p.stderr.note())
+			}
+			var resp rpcResponse
+			if err := json.Unmarshal(line, &resp); err != nil {
+				e.stopLocked()
+				return fmt.Errorf("source %q: %s: malformed response (stdout is reserved for the protocol; log to stderr): %w", e.id, method, err)
+			}
+			if resp.ID == nil || *resp.ID != id {
+				continue // a notification, or a reply lmm stopped waiting for
+			}
+			if resp.Error != nil {
+				return e.rpcErr(method, resp.Error)
+			}
+			if result == nil {
+				return nil
+			}
+			if err := json.Unmarshal(resp.Result, result); err != nil {
+				return fmt.Errorf("source %q: %s: decoding result: %w", e.id, method, err)
+			}
+			return nil
+		case <-ctx.Done():
+			e.stopLocked()
+			if parent.Err() != nil {
+				return parent.Err()
+			}
+			return fmt.Errorf("source %q: %s: no response within %s%s", e.id, method, e.timeout, p.stderr.note())
+		}
+	}
+}
+
+// rpcErr maps a plugin's error response onto the errors callers branch on.
+func (e *Exec) rpcErr(method string, re *rpcError) error {
+	switch re.Code {
+	case rpcMethodNotFound, rpcNotSupported:
+		return fmt.Errorf("source %q: %s: %w", e.id, method, source.ErrNotSupported)
+	case rpcNotFound:
+		return fmt.Errorf("source %q: %s: %w", e.id, re.Message, domain.ErrModNotFound)
+	case rpcAuthRequired:
+		return fmt.Errorf("source %q: %s: %w", e.id, re.Message, domain.ErrAuthRequired)
+	}
+	return fmt.Errorf("source %q: %s: %s (code %d)", e.id, method, re.Message, re.Code)
+}
+
+// stopLocked kills the plugin (a no-op if it already exited) and forgets it
+// and its handshake.
+func (e *Exec) stopLocked() {
+	p := e.proc
+	if p == nil {
+		return
+	}
+	_ = p.cmd.Process.Kill()
+	close(p.quit)
+	<-p.exited
+	e.proc = nil
+	e.caps = nil
+	e.authURL = ""
+}
+
+// execProcess is one running plugin.
+type execProcess struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stderr *tailBuffer
+	lines  chan []byte   // protocol messages, closed when stdout ends
+	quit   chan struct{} // closed by stopLocked so read never blocks on lines
+	exited chan struct{} // closed once the process has been waited for
+
+	readErr error // set before lines is closed
+	waitErr error // set before exited is closed
+}
+
+// read forwards each stdout line to lines until stdout ends, then reaps the
+// process.
+func (p *execProcess) read(stdout io.Reader) {
+	defer close(p.exited)
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxExecMessage)
+	for scanner.Scan() {
+		line := append([]byte(nil), scanner.Bytes()...)
+		select {
+		case p.lines <- line:
+		case <-p.quit:
+			// Nobody is listening any more; keep draining so the process
+			// can still be reaped.
+		}
+	}
+	p.readErr = scanner.Err()
+	close(p.lines)
+	if p.readErr != nil {
+		// An oversized message leaves the plugin running with nobody reading.
+		_ = p.cmd.Process.Kill()
+	}
+	p.waitErr = p.cmd.Wait()
+}
+
+// exitReason describes why stdout ended. Only valid once exited is closed.
+func (p *execProcess) exitReason() string {
+	if p.readErr != nil {
+		return p.readErr.Error()
+	}
+	if p.waitErr != nil {
+		return p.waitErr.Error()
+	}
+	return "exit status 0"
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	mu  sync.Mutex
+	max int
+	buf []byte
+}
+
+func (t *tailBuffer) Write(b []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.buf = append(t.buf, b...)
+	if over := len(t.buf) - t.max; over > 0 {
+		t.buf = append(t.buf[:0], t.buf[over:]...)
+	}
+	return len(b), nil
+}
+
+// note formats the captured stderr for appending to an error, or "" when
+// the plugin wrote none.
+func (t *tailBuffer) note() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	s := strings.TrimSpace(string(t.buf))
+	if s == "" {
+		return ""
+	}
+	return "; plugin stderr: " + s
+}
+
+// Wire types. Field names are snake_case, matching the manifest format.
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int64  `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	ID     *int64          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type execCapabilities struct {
+	Search          bool `json:"search"`
+	Dependencies    bool `json:"dependencies"`
+	Updates         bool `json:"updates"`
+	Versions        bool `json:"versions"`
+	DownloadHeaders bool `json:"download_headers"`
+	Games           bool `json:"games"`
+}
+
+type execInitResult struct {
+	ProtocolVersion int              `json:"protocol_version"`
+	Capabilities    execCapabilities `json:"capabilities"`
+	AuthURL         string           `json:"auth_url"`
+}
+
+type execSearchParams struct {
+	GameID   string   `json:"game_id"`
+	Query    string   `json:"query"`
+	Category string   `json:"category,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Page     int      `json:"page"`
+	PageSize int      `json:"page_size"`
+}
+
+type execSearchResult struct {
+	Mods       []execMod `json:"mods"`
+	TotalCount int       `json:"total_count"`
+	Page       int       `json:"page"`
+	PageSize   int       `json:"page_size"`
+}
+
+type execModParams struct {
+	GameID string `json:"game_id"`
+	ModID  string `json:"mod_id"`
+}
+
+type execFileParams struct {
+	GameID string `json:"game_id"`
+	ModID  string `json:"mod_id"`
+	FileID string `json:"file_id"`
+}
+
+type execMod struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`
+	Version      string    `json:"version"`
+	Author       string    `json:"author"`
+	Summary      string    `json:"summary"`
+	Description  string    `json:"description"`
+	GameID       string    `json:"game_id"`
+	Category     string    `json:"category"`
+	Downloads    int64     `json:"downloads"`
+	Endorsements *int64    `json:"endorsements"`
+	PictureURL   string    `json:"picture_url"`
+	URL          string    `json:"url"`
+	UpdatedAt    string    `json:"updated_at"` // RFC 3339
+	Dependencies []execRef `json:"dependencies"`
+}
+
+type execRef struct {
+	SourceID string `json:"source_id"` // empty = this source
+	ModID    string `json:"mod_id"`
+	Version  string `json:"version"`
+}
+
+type execFile struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Filename    string `json:"filename"`
+	Version     string `json:"version"`
+	Size        int64  `json:"size"`
+	Primary     bool   `json:"primary"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	SHA256      string `json:"sha256"`
+}
+
+type execCheckUpdatesParams struct {
+	Mods []execInstalled `json:"mods"`
+}
+
+type execInstalled struct {
+	ID      string   `json:"id"`
+	GameID  string   `json:"game_id"`
+	Version string   `json:"version"`
+	FileIDs []string `json:"file_ids"`
+}
+
+type execUpdate struct {
+	ModID              string            `json:"mod_id"`
+	NewVersion         string            `json:"new_version"`
+	Changelog          string            `json:"changelog"`
+	FileIDReplacements map[string]string `json:"file_id_replacements"`
+}
+
+type execToken struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresAt    string `json:"expires_at"` // RFC 3339
+}
+
+type execGame struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+var _ source.ModSource = (*Exec)(nil)
+var _ source.CapabilityReporter = (*Exec)(nil)
+var _ source.DownloadHeaderProvider = (*Exec)(nil)
+var _ source.GameCatalog = (*Exec)(nil)
+var _ io.Closer = (*Exec)(nil)
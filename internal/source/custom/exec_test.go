@@ -0,0 +1,237 @@
+package custom
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// execPluginEnv switches the test binary into a plugin (TestExecPlugin) in
+// the mode it names.
+const execPluginEnv = "LMM_TEST_EXEC_PLUGIN"
+
+// TestExecPlugin is not a test: run with execPluginEnv set, it is the plugin
+// the exec tests talk to. Modes: "full" serves everything, "minimal"
+// declares no optional capabilities, "v2" speaks the wrong protocol version.
+func TestExecPlugin(t *testing.T) {
+	mode := os.Getenv(execPluginEnv)
+	if mode == "" {
+		t.Skip("helper process for the exec source tests")
+	}
+	runTestPlugin(mode)
+	os.Exit(0)
+}
+
+func runTestPlugin(mode string) {
+	in := bufio.NewScanner(os.Stdin)
+	out := json.NewEncoder(os.Stdout)
+	for in.Scan() {
+		var req struct {
+			ID     int64           `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.Unmarshal(in.Bytes(), &req); err != nil {
+			fmt.Fprintln(os.Stderr, "bad request:", err)
+			os.Exit(2)
+		}
+		var params map[string]any
+		_ = json.Unmarshal(req.Params, &params)
+
+		var result any
+		var rpcErr *rpcError
+		switch req.Method {
+		case "initialize":
+			version := 1
+			if mode == "v2" {
+				version = 2
+			}
+			full := mode == "full"
+			result = map[string]any{
+				"protocol_version": version,
+				"capabilities": map[string]bool{
+					"search": full, "dependencies": full, "updates": full,
+					"versions": full, "download_headers": full, "games": full,
+				},
+			}
+		case "search":
+			result = map[string]any{
+				"mods":        []map[string]any{{"id": "m1", "name": "Mod One", "version": "1.0", "updated_at": "2026-01-02T03:04:05Z"}},
+				"total_count": 1,
+			}
+		case "get_mod":
+			switch params["mod_id"] {
+			case "m1":
+				result = map[string]any{"id": "m1", "name": "Mod One", "version": "1.1", "dependencies": []map[string]string{{"mod_id": "m2"}}}
+			case "crash":
+				fmt.Fprintln(os.Stderr, "plugin blew up")
+				os.Exit(3)
+			case "hang":
+				time.Sleep(time.Minute)
+			default:
+				rpcErr = &rpcError{Code: rpcNotFound, Message: fmt.Sprintf("no mod %v", params["mod_id"])}
+			}
+		case "get_dependencies":
+			result = []map[string]string{{"mod_id": "m2"}, {"source_id": "nexusmods", "mod_id": "42"}}
+		case "get_mod_files":
+			result = []map[string]any{{"id": "f1", "filename": "mod-one.zip", "version": "1.1", "size": 10, "primary": true}}
+		case "get_download_url":
+			result = map[string]string{"url": "https://cdn.example/" + params["file_id"].(string)}
+		case "check_updates":
+			result = []map[string]any{
+				{"mod_id": "m1", "new_version": "1.1", "file_id_replacements": map[string]string{"f0": "f1"}},
+				{"mod_id": "not-asked", "new_version": "9"},
+			}
+		case "download_headers":
+			result = map[string]any{"headers": map[string]string{"Authorization": "Bearer " + os.Getenv("LMM_API_KEY")}}
+		case "list_games":
+			result = []map[string]string{{"id": "7", "name": "Some Game", "slug": "some-game"}}
+		default:
+			rpcErr = &rpcError{Code: rpcMethodNotFound, Message: "method not found"}
+		}
+		resp := map[string]any{"jsonrpc": "2.0", "id": req.ID}
+		if rpcErr != nil {
+			resp["error"] = rpcErr
+		} else {
+			resp["result"] = result
+		}
+		_ = out.Encode(resp)
+	}
+}
+
+func newTestExec(t *testing.T, mode, timeout string) *Exec {
+	t.Helper()
+	def := SourceDefinition{
+		ID:   "plug",
+		Name: "Plugin",
+		Type: TypeExec,
+		Exec: &ExecConfig{
+			Command: os.Args[0],
+			Args:    []string{"-test.run=^TestExecPlugin$"},
+			Env:     map[string]string{execPluginEnv: mode},
+			Timeout: timeout,
+		},
+	}
+	require.NoError(t, def.Validate())
+	e, err := NewExec(def)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = e.Close() })
+	return e
+}
+
+func TestExec_ServesEveryMethod(t *testing.T) {
+	e := newTestExec(t, "full", "")
+	e.SetAPIKey("secret")
+	ctx := context.Background()
+
+	assert.Equal(t, source.Capabilities{Search: true, Dependencies: true, Updates: true, Versions: true}, e.Capabilities())
+
+	res, err := e.Search(ctx, source.SearchQuery{GameID: "g1", Query: "one", Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	require.Len(t, res.Mods, 1)
+	assert.Equal(t, "plug", res.Mods[0].SourceID)
+	assert.Equal(t, "g1", res.Mods[0].GameID, "a mod without game_id belongs to the game searched")
+	assert.Equal(t, 2026, res.Mods[0].UpdatedAt.Year())
+	assert.Equal(t, 10, res.PageSize)
+
+	mod, err := e.GetMod(ctx, "g1", "m1")
+	require.NoError(t, err)
+	assert.Equal(t, "1.1", mod.Version)
+	assert.Equal(t, []domain.ModReference{{SourceID: "plug", ModID: "m2"}}, mod.Dependencies)
+
+	_, err = e.GetMod(ctx, "g1", "nope")
+	assert.ErrorIs(t, err, domain.ErrModNotFound)
+	assert.ErrorContains(t, err, "no mod nope")
+
+	deps, err := e.GetDependencies(ctx, mod)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ModReference{{SourceID: "plug", ModID: "m2"}, {SourceID: "nexusmods", ModID: "42"}}, deps)
+
+	files, err := e.GetModFiles(ctx, mod)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.DownloadableFile{{ID: "f1", FileName: "mod-one.zip", Version: "1.1", Size: 10, IsPrimary: true}}, files)
+
+	url, err := e.GetDownloadURL(ctx, mod, "f1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example/f1", url)
+
+	updates, err := e.CheckUpdates(ctx, []domain.InstalledMod{{Mod: domain.Mod{ID: "m1", Version: "1.0"}}})
+	require.NoError(t, err)
+	require.Len(t, updates, 1, "replies for mods that were not asked about are dropped")
+	assert.Equal(t, "1.1", updates[0].NewVersion)
+	assert.Equal(t, map[string]string{"f0": "f1"}, updates[0].FileIDReplacements)
+
+	assert.Equal(t, map[string]string{"Authorization": "Bearer secret"}, e.DownloadHeaders("https://cdn.example/f1"))
+
+	catalog, ok := source.GameCatalogOf(e)
+	require.True(t, ok)
+	games, err := catalog.ListGames(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []source.GameEntry{{ID: "7", Name: "Some Game", Slug: "some-game"}}, games)
+
+	_, err = e.ExchangeToken(ctx, "code")
+	assert.ErrorIs(t, err, source.ErrNotSupported, "no auth_url, no OAuth")
+}
+
+func TestExec_GatesUndeclaredCapabilities(t *testing.T) {
+	e := newTestExec(t, "minimal", "")
+	e.auth = true
+	ctx := context.Background()
+
+	assert.Equal(t, source.Capabilities{Auth: true}, e.Capabilities())
+	_, err := e.Search(ctx, source.SearchQuery{})
+	assert.ErrorIs(t, err, source.ErrNotSupported)
+	_, err = e.GetDependencies(ctx, &domain.Mod{ID: "m1"})
+	assert.ErrorIs(t, err, source.ErrNotSupported)
+	_, err = e.CheckUpdates(ctx, nil)
+	assert.ErrorIs(t, err, source.ErrNotSupported)
+	assert.Nil(t, e.DownloadHeaders("https://cdn.example/f1"))
+	_, ok := source.GameCatalogOf(e)
+	assert.False(t, ok)
+
+	// Core methods are always called; the plugin still serves them.
+	mod, err := e.GetMod(ctx, "g1", "m1")
+	require.NoError(t, err)
+	assert.Equal(t, "Mod One", mod.Name)
+}
+
+func TestExec_TimeoutKillsAndRestarts(t *testing.T) {
+	e := newTestExec(t, "full", "500ms")
+	ctx := context.Background()
+
+	_, err := e.GetMod(ctx, "g1", "hang")
+	assert.ErrorContains(t, err, "no response within 500ms")
+
+	mod, err := e.GetMod(ctx, "g1", "m1")
+	require.NoError(t, err, "the next call starts a fresh plugin")
+	assert.Equal(t, "m1", mod.ID)
+}
+
+func TestExec_ReportsStderrWhenPluginDies(t *testing.T) {
+	e := newTestExec(t, "full", "")
+	_, err := e.GetMod(context.Background(), "g1", "crash")
+	assert.ErrorContains(t, err, "plugin exited (exit status 3)")
+	assert.ErrorContains(t, err, "plugin stderr: plugin blew up")
+}
+
+func TestExec_RejectsOtherProtocolVersions(t *testing.T) {
+	e := newTestExec(t, "v2", "")
+	_, err := e.GetMod(context.Background(), "g1", "m1")
+	assert.ErrorContains(t, err, "plugin speaks protocol version 2")
+}
+
+func TestNewExec_MissingCommand(t *testing.T) {
+	def := SourceDefinition{ID: "plug", Name: "Plugin", Type: TypeExec, Exec: &ExecConfig{Command: "lmm-no-such-plugin"}}
+	_, err := NewExec(def)
+	assert.ErrorContains(t, err, "exec source command")
+}
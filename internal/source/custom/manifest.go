@@ -15,6 +15,7 @@ import (
 
 	"github.com/DonovanMods/linux-mod-manager/internal/domain"
 	"github.com/DonovanMods/linux-mod-manager/internal/source"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/minisign"
 )
 
 // defaultManifestRefresh is the remote-manifest cache TTL when the definition
@@ -26,6 +27,14 @@ const defaultManifestRefresh = 15 * time.Minute
 // server from exhausting memory.
 const maxManifestSize = 10 << 20 // 10 MiB
 
+// maxSignatureSize bounds a manifest's detached signature; a minisign
+// signature file is a few hundred bytes.
+const maxSignatureSize = 64 << 10 // 64 KiB
+
+// signatureSuffix names a signed manifest's detached signature, fetched from
+// next to the manifest itself (minisign's own naming).
+const signatureSuffix = ".minisig"
+
 // manifestFetchTimeout bounds a remote manifest fetch. Without it a hung
 // server would block the fetching goroutine indefinitely (and, before the
 // lock rework, every other operation on this source).
@@ -44,6 +53,7 @@ type Manifest struct {
 	refresh   time.Duration
 	allowHTTP bool
 	auth      *AuthConfig
+	publicKey *minisign.PublicKey // nil = unsigned manifest
 
 	apiKey     string
 	httpClient *http.Client
@@ -85,6 +95,15 @@ func NewManifest(def SourceDefinition) (*Manifest, error) {
 		u = abs
 	}
 
+	var publicKey *minisign.PublicKey
+	if cfg.PublicKey != "" {
+		pk, err := minisign.ParsePublicKey(cfg.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("manifest.public_key: %w", err) // unreachable after Validate, kept for safety
+		}
+		publicKey = &pk
+	}
+
 	return &Manifest{
 		id:         def.ID,
 		name:       def.Name,
@@ -93,6 +112,7 @@ func NewManifest(def SourceDefinition) (*Manifest, error) {
 		refresh:    refresh,
 		allowHTTP:  def.AllowHTTP,
 		auth:       cfg.Auth,
+		publicKey:  publicKey,
 		httpClient: &http.Client{Timeout: manifestFetchTimeout},
 		now:        time.Now,
 	}, nil
@@ -121,9 +141,9 @@ func (m *Manifest) IsAuthenticated() bool { return m.apiKey != "" }
 // URL so users can act on them.
 func (m *Manifest) fetch(ctx context.Context) (*manifestDoc, error) {
 	if !m.isRemote {
-		data, err := os.ReadFile(m.url)
+		data, _, err := m.load(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("source %q: reading manifest %s: %w", m.id, m.url, err)
+			return nil, err
 		}
 		// parseManifest returns a freshly allocated doc on every call (the
 		// file is re-read each time), so it is already caller-owned; no
@@ -161,18 +181,97 @@ func (m *Manifest) fetch(ctx context.Context) (*manifestDoc, error) {
 // fetchRemote downloads and parses the manifest document. Called without
 // m.mu held: this method performs the network I/O.
 func (m *Manifest) fetchRemote(ctx context.Context) (*manifestDoc, error) {
-	reqURL := m.url
+	data, _, err := m.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := parseManifest(data, m.allowHTTP)
+	if err != nil {
+		return nil, fmt.Errorf("source %q: manifest %s: %w", m.id, m.url, err)
+	}
+	return doc, nil
+}
+
+// load reads the raw manifest and, for a signed source, verifies it against
+// the detached signature next to it before anything parses it, returning the
+// signature's trusted comment. A signature that is missing or does not
+// verify is a hard error: a compromised host could otherwise swap both the
+// download URLs and their checksums.
+func (m *Manifest) load(ctx context.Context) ([]byte, string, error) {
+	data, err := m.read(ctx, m.url, "manifest", maxManifestSize)
+	if err != nil {
+		return nil, "", err
+	}
+	if m.publicKey == nil {
+		return data, "", nil
+	}
+
+	sigLoc, err := m.signatureLocation()
+	if err != nil {
+		return nil, "", fmt.Errorf("source %q: manifest %s: %w", m.id, m.url, err)
+	}
+	sig, err := m.read(ctx, sigLoc, "manifest signature", maxSignatureSize)
+	if err != nil {
+		return nil, "", err
+	}
+	trusted, err := minisign.Verify(*m.publicKey, data, sig)
+	if err != nil {
+		return nil, "", fmt.Errorf("source %q: manifest %s: signature: %w", m.id, m.url, err)
+	}
+	return data, trusted, nil
+}
+
+// VerifySignature fetches the manifest and its signature afresh, bypassing
+// the cache, and verifies them; it returns the signature's trusted comment.
+// Used by 'lmm source validate'.
+func (m *Manifest) VerifySignature(ctx context.Context) (string, error) {
+	if m.publicKey == nil {
+		return "", fmt.Errorf("source %q: manifest has no public_key", m.id)
+	}
+	_, trusted, err := m.load(ctx)
+	return trusted, err
+}
+
+// signatureLocation returns where the manifest's detached signature lives:
+// the manifest path or URL path with signatureSuffix appended (a URL's query
+// string is kept).
+func (m *Manifest) signatureLocation() (string, error) {
+	if !m.isRemote {
+		return m.url + signatureSuffix, nil
+	}
+	u, err := url.Parse(m.url)
+	if err != nil {
+		return "", fmt.Errorf("parsing URL: %w", err)
+	}
+	u.Path += signatureSuffix
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// read returns the document at loc — a local path or an http(s) URL, matching
+// the manifest's own kind — capped at limit bytes for remote reads. what
+// names the document in errors. Remote reads carry the source's API key.
+func (m *Manifest) read(ctx context.Context, loc, what string, limit int64) ([]byte, error) {
+	if !m.isRemote {
+		data, err := os.ReadFile(loc)
+		if err != nil {
+			return nil, fmt.Errorf("source %q: reading %s %s: %w", m.id, what, loc, err)
+		}
+		return data, nil
+	}
+
+	reqURL := loc
 	if m.auth != nil && m.auth.APIKey.In == "query" && m.apiKey != "" {
 		u, err := addQueryParam(reqURL, m.auth.APIKey.Name, m.apiKey)
 		if err != nil {
-			return nil, fmt.Errorf("source %q: manifest %s: %w", m.id, m.url, err)
+			return nil, fmt.Errorf("source %q: %s %s: %w", m.id, what, loc, err)
 		}
 		reqURL = u
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("source %q: manifest %s: %w", m.id, m.url, err)
+		return nil, fmt.Errorf("source %q: %s %s: %w", m.id, what, loc, err)
 	}
 	if m.auth != nil && m.auth.APIKey.In == "header" && m.apiKey != "" {
 		req.Header.Set(m.auth.APIKey.Name, m.apiKey)
@@ -183,32 +282,27 @@ func (m *Manifest) fetchRemote(ctx context.Context) (*manifestDoc, error) {
 		// *url.Error's Error() embeds the request URL verbatim, which for
 		// query-mode auth contains the API key. Unwrap to the transport
 		// error before reporting so the key never reaches the message; the
-		// (unauthenticated) m.url is still named via the format string.
+		// (unauthenticated) loc is still named via the format string.
 		var uerr *url.Error
 		if errors.As(err, &uerr) {
 			err = uerr.Err
 		}
-		return nil, fmt.Errorf("source %q: fetching manifest %s: %w", m.id, m.url, err)
+		return nil, fmt.Errorf("source %q: fetching %s %s: %w", m.id, what, loc, err)
 	}
 	defer resp.Body.Close() //nolint:errcheck
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("source %q: fetching manifest %s: HTTP %d", m.id, m.url, resp.StatusCode)
+		return nil, fmt.Errorf("source %q: fetching %s %s: HTTP %d", m.id, what, loc, resp.StatusCode)
 	}
 
-	data, err := io.ReadAll(io.LimitReader(resp.Body, maxManifestSize+1))
+	data, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
 	if err != nil {
-		return nil, fmt.Errorf("source %q: reading manifest %s: %w", m.id, m.url, err)
+		return nil, fmt.Errorf("source %q: reading %s %s: %w", m.id, what, loc, err)
 	}
-	if len(data) > maxManifestSize {
-		return nil, fmt.Errorf("source %q: manifest %s exceeds %d bytes", m.id, m.url, maxManifestSize)
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("source %q: %s %s exceeds %d bytes", m.id, what, loc, limit)
 	}
-
-	doc, err := parseManifest(data, m.allowHTTP)
-	if err != nil {
-		return nil, fmt.Errorf("source %q: manifest %s: %w", m.id, m.url, err)
-	}
-	return doc, nil
+	return data, nil
 }
 
 // deepCopyManifest returns a copy of doc that shares no mutable memory with
@@ -323,7 +417,7 @@ func (m *Manifest) GetMod(ctx context.Context, gameID, modID string) (*domain.Mo
 }
 
 // GetModFiles implements source.ModSource, mapping manifest file entries —
-// including declared sha256 checksums — onto DownloadableFiles.
+// including declared sha256 checksums and mirrors — onto DownloadableFiles.
 func (m *Manifest) GetModFiles(ctx context.Context, mod *domain.Mod) ([]domain.DownloadableFile, error) {
 	mm, err := m.findMod(ctx, mod.ID)
 	if err != nil {
@@ -339,6 +433,7 @@ func (m *Manifest) GetModFiles(ctx context.Context, mod *domain.Mod) ([]domain.D
 			Size:      f.Size,
 			IsPrimary: f.Primary,
 			SHA256:    f.SHA256,
+			Mirrors:   f.Mirrors,
 		})
 	}
 	return files, nil
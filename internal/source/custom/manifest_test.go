@@ -2,6 +2,7 @@ package custom
 
 import (
 	"context"
+	"crypto/rand"
 	"errors"
 	"fmt"
 	"net"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/DonovanMods/linux-mod-manager/internal/domain"
 	"github.com/DonovanMods/linux-mod-manager/internal/source"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/minisign"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,6 +37,7 @@ mods:
         version: 1.2.0
         size: 4
         url: https://files.test/cool-mod-1.2.0.zip
+        mirrors: [https://mirror.test/cool-mod-1.2.0.zip]
         sha256: aabbccddaabbccddaabbccddaabbccddaabbccddaabbccddaabbccddaabbccdd
         primary: true
   - id: other-mod
@@ -117,6 +120,108 @@ func TestManifestFetchLocalMissingFileNamesPath(t *testing.T) {
 	assert.Contains(t, err.Error(), "my-repo")
 }
 
+// signedManifestServer serves testManifest and its signature by key at
+// /mods.yaml and /mods.yaml.minisig; body replaces the served manifest when
+// non-empty, to simulate a host that swapped it after signing.
+func signedManifestServer(t *testing.T, key minisign.PrivateKey, body string) *httptest.Server {
+	t.Helper()
+	sig, err := minisign.Sign(key, []byte(testManifest), "file:mods.yaml")
+	require.NoError(t, err)
+	if body == "" {
+		body = testManifest
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mods.yaml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	})
+	mux.HandleFunc("/mods.yaml.minisig", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(sig)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestManifestSignedRemote(t *testing.T) {
+	key, err := minisign.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	newSigned := func(srv *httptest.Server, pub minisign.PublicKey) *Manifest {
+		def := manifestDef(srv.URL + "/mods.yaml")
+		def.AllowHTTP = true
+		def.Manifest.PublicKey = pub.String()
+		m, err := NewManifest(def)
+		require.NoError(t, err)
+		return m
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		m := newSigned(signedManifestServer(t, key, ""), key.Public())
+		doc, err := m.fetch(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, doc.Mods, 2)
+
+		trusted, err := m.VerifySignature(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "file:mods.yaml", trusted)
+	})
+
+	t.Run("swapped manifest", func(t *testing.T) {
+		m := newSigned(signedManifestServer(t, key, testManifest+"\n# evil\n"), key.Public())
+		_, err := m.fetch(context.Background())
+		require.Error(t, err)
+		assert.ErrorIs(t, err, minisign.ErrInvalidSignature)
+		assert.Contains(t, err.Error(), "my-repo")
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		other, err := minisign.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		m := newSigned(signedManifestServer(t, key, ""), other.Public())
+		_, err = m.fetch(context.Background())
+		assert.ErrorIs(t, err, minisign.ErrInvalidSignature)
+	})
+
+	t.Run("missing signature", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/mods.yaml" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(testManifest))
+		}))
+		defer srv.Close()
+		m := newSigned(srv, key.Public())
+		_, err := m.fetch(context.Background())
+		assert.ErrorContains(t, err, "fetching manifest signature "+srv.URL+"/mods.yaml.minisig: HTTP 404")
+	})
+}
+
+func TestManifestSignedLocal(t *testing.T) {
+	key, err := minisign.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "mods.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testManifest), 0644))
+
+	def := manifestDef(path)
+	def.Manifest.PublicKey = key.Public().String()
+	m, err := NewManifest(def)
+	require.NoError(t, err)
+
+	_, err = m.fetch(context.Background())
+	assert.ErrorContains(t, err, "mods.yaml.minisig", "a signed source refuses an unsigned manifest")
+
+	sig, err := minisign.Sign(key, []byte(testManifest), "")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path+".minisig", sig, 0644))
+	doc, err := m.fetch(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, doc.Mods, 2)
+
+	_, err = newLocalManifest(t).VerifySignature(context.Background())
+	assert.ErrorContains(t, err, "no public_key")
+}
+
 func TestManifestFetchRemoteTTL(t *testing.T) {
 	hits := 0
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -340,6 +445,7 @@ func TestManifestFilesAndDownloadURL(t *testing.T) {
 	assert.Equal(t, "1.2.0", f.Version)
 	assert.Equal(t, int64(4), f.Size)
 	assert.Equal(t, "aabbccddaabbccddaabbccddaabbccddaabbccddaabbccddaabbccddaabbccdd", f.SHA256)
+	assert.Equal(t, []string{"https://mirror.test/cool-mod-1.2.0.zip"}, f.Mirrors)
 	assert.True(t, f.IsPrimary)
 
 	u, err := m.GetDownloadURL(ctx, mod, "main")
@@ -12,7 +12,8 @@ import (
 var sha256Pattern = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
 
 // manifestDoc is the lmm-defined manifest format (design §3), version 1.
-// YAML 1.2 is a superset of JSON, so yaml.v3 parses both encodings.
+// YAML 1.2 is a superset of JSON, so yaml.v3 parses both encodings. Optional
+// fields are omitempty so GenerateManifest writes only what it knows.
 type manifestDoc struct {
 	Version int           `yaml:"version"`
 	Mods    []manifestMod `yaml:"mods"`
@@ -21,25 +22,26 @@ type manifestDoc struct {
 type manifestMod struct {
 	ID           string         `yaml:"id"`
 	Name         string         `yaml:"name"`
-	Version      string         `yaml:"version"`
-	Author       string         `yaml:"author"`
-	Summary      string         `yaml:"summary"`
-	GameIDs      []string       `yaml:"game_ids"` // matched against the game's mapped value; empty = all games
-	URL          string         `yaml:"url"`
-	UpdatedAt    string         `yaml:"updated_at"` // RFC 3339; unparseable -> zero value (design §4 rule)
-	Dependencies []string       `yaml:"dependencies"`
-	Files        []manifestFile `yaml:"files"`
+	Version      string         `yaml:"version,omitempty"`
+	Author       string         `yaml:"author,omitempty"`
+	Summary      string         `yaml:"summary,omitempty"`
+	GameIDs      []string       `yaml:"game_ids,omitempty"` // matched against the game's mapped value; empty = all games
+	URL          string         `yaml:"url,omitempty"`
+	UpdatedAt    string         `yaml:"updated_at,omitempty"` // RFC 3339; unparseable -> zero value (design §4 rule)
+	Dependencies []string       `yaml:"dependencies,omitempty"`
+	Files        []manifestFile `yaml:"files,omitempty"`
 }
 
 type manifestFile struct {
-	ID       string `yaml:"id"`
-	Name     string `yaml:"name"`
-	Filename string `yaml:"filename"`
-	Version  string `yaml:"version"`
-	Size     int64  `yaml:"size"`
-	URL      string `yaml:"url"`
-	SHA256   string `yaml:"sha256"` // optional; verified on download when present
-	Primary  bool   `yaml:"primary"`
+	ID       string   `yaml:"id"`
+	Name     string   `yaml:"name,omitempty"`
+	Filename string   `yaml:"filename"`
+	Version  string   `yaml:"version,omitempty"`
+	Size     int64    `yaml:"size,omitempty"`
+	URL      string   `yaml:"url"`
+	Mirrors  []string `yaml:"mirrors,omitempty"` // tried in order when url fails; same bytes, same sha256
+	SHA256   string   `yaml:"sha256,omitempty"`  // optional; verified on download when present
+	Primary  bool     `yaml:"primary,omitempty"`
 }
 
 // parseManifest decodes and validates a manifest document. allowHTTP mirrors
@@ -82,11 +84,13 @@ func parseManifest(data []byte, allowHTTP bool) (*manifestDoc, error) {
 			if f.URL == "" {
 				return nil, fmt.Errorf("mod %q: file %q: url is required", m.ID, f.ID)
 			}
-			if !strings.HasPrefix(f.URL, "https://") && !strings.HasPrefix(f.URL, "http://") {
-				return nil, fmt.Errorf("mod %q: file %q: url must be http(s)", m.ID, f.ID)
+			if err := checkFileURL("url", f.URL, allowHTTP); err != nil {
+				return nil, fmt.Errorf("mod %q: file %q: %w", m.ID, f.ID, err)
 			}
-			if strings.HasPrefix(f.URL, "http://") && !allowHTTP {
-				return nil, fmt.Errorf("mod %q: file %q: plain http is disabled; use https or set allow_http: true", m.ID, f.ID)
+			for k, mirror := range f.Mirrors {
+				if err := checkFileURL(fmt.Sprintf("mirrors[%d]", k), mirror, allowHTTP); err != nil {
+					return nil, fmt.Errorf("mod %q: file %q: %w", m.ID, f.ID, err)
+				}
 			}
 			if f.SHA256 != "" && !sha256Pattern.MatchString(f.SHA256) {
 				return nil, fmt.Errorf("mod %q: file %q: sha256 must be 64 hex characters", m.ID, f.ID)
@@ -96,3 +100,15 @@ func parseManifest(data []byte, allowHTTP bool) (*manifestDoc, error) {
 
 	return &doc, nil
 }
+
+// checkFileURL enforces the scheme rules shared by a file's url and each of
+// its mirrors; field names the offending key in the error.
+func checkFileURL(field, u string, allowHTTP bool) error {
+	if !strings.HasPrefix(u, "https://") && !strings.HasPrefix(u, "http://") {
+		return fmt.Errorf("%s must be http(s)", field)
+	}
+	if strings.HasPrefix(u, "http://") && !allowHTTP {
+		return fmt.Errorf("%s: plain http is disabled; use https or set allow_http: true", field)
+	}
+	return nil
+}
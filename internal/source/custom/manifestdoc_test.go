@@ -79,6 +79,8 @@ func TestParseManifestErrors(t *testing.T) {
 		{"http file url rejected", "version: 1\nmods:\n  - id: x\n    name: X\n    files: [{id: main, filename: x.zip, url: http://x.test/x.zip}]", "plain http"},
 		{"duplicate file id", "version: 1\nmods:\n  - id: x\n    name: X\n    files: [{id: main, filename: x.zip, url: https://x.test/x.zip}, {id: main, filename: x2.zip, url: https://x.test/x2.zip}]", `mod "x": duplicate file id "main"`},
 		{"ftp file url rejected", "version: 1\nmods:\n  - id: x\n    name: X\n    files: [{id: main, filename: x.zip, url: ftp://x.test/x.zip}]", `mod "x": file "main": url must be http(s)`},
+		{"http mirror rejected", "version: 1\nmods:\n  - id: x\n    name: X\n    files: [{id: main, filename: x.zip, url: https://x.test/x.zip, mirrors: [https://m.test/x.zip, http://m2.test/x.zip]}]", `mod "x": file "main": mirrors[1]: plain http`},
+		{"ftp mirror rejected", "version: 1\nmods:\n  - id: x\n    name: X\n    files: [{id: main, filename: x.zip, url: https://x.test/x.zip, mirrors: [ftp://m.test/x.zip]}]", `mod "x": file "main": mirrors[0] must be http(s)`},
 		{"bad sha256 format", "version: 1\nmods:\n  - id: x\n    name: X\n    files: [{id: main, filename: x.zip, url: https://x.test/x.zip, sha256: nothex}]", `mod "x": file "main": sha256 must be 64 hex characters`},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,282 @@
+package custom
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/custom/metadata"
+)
+
+// releaseExts are the archive types GenerateManifest publishes: the formats
+// the installer can extract, plus .jar like directory sources.
+var releaseExts = []string{".zip", ".jar", ".7z", ".rar"}
+
+// urlPlaceholders are the GenerateOptions.URLTemplate placeholders.
+var urlPlaceholders = []string{"{filename}", "{mod_id}", "{version}"}
+
+// GenerateOptions configures GenerateManifest.
+type GenerateOptions struct {
+	// Dir is the folder of release archives to scan (not recursive).
+	Dir string
+	// URLTemplate builds each file's download URL. {filename}, {mod_id} and
+	// {version} are replaced, path-escaped; a template without placeholders
+	// is a base URL the filename is appended to.
+	URLTemplate string
+	// MirrorTemplates build each file's mirrors, in order, the same way.
+	// Empty keeps the mirrors a rescanned file already lists.
+	MirrorTemplates []string
+	// GameIDs is given to mods new to the manifest; existing mods keep theirs.
+	GameIDs []string
+	// Existing is a manifest to merge into; nil starts a new one.
+	Existing []byte
+	// AllowHTTP permits http:// download URLs, like a definition's allow_http.
+	AllowHTTP bool
+	// Now stamps updated_at on mods whose newest release is new or changed;
+	// zero means the archive's modification time.
+	Now time.Time
+}
+
+// GenerateResult is a generated manifest and what changed.
+type GenerateResult struct {
+	Manifest []byte
+	Mods     int // mods in the manifest
+	Added    int // files new to the manifest
+	Updated  int // files already listed, rescanned from their archive
+}
+
+// release is one scanned archive.
+type release struct {
+	modID   string
+	name    string
+	author  string
+	summary string
+	modTime time.Time
+	file    manifestFile
+	changed bool // new to the manifest, or its archive's content changed
+}
+
+// GenerateManifest scans a folder of release archives and builds a version 1
+// manifest from them, merged into opts.Existing. Each archive's mod comes from
+// its metadata file (ModInfo.xml, fabric.mod.json, ...) when it has one, or
+// from its file name ("MyMod-1.2.0.zip"
+// is mod MyMod, version 1.2.0); size and sha256 are computed. Files already
+// in the manifest stay listed, so older versions remain installable; a file
+// whose archive is rescanned is replaced. For every mod that had archives,
+// the files of its newest version become primary and the mod's version and
+// metadata follow that release.
+func GenerateManifest(opts GenerateOptions) (*GenerateResult, error) {
+	if opts.URLTemplate == "" {
+		return nil, errors.New("a download URL template is required")
+	}
+
+	doc := &manifestDoc{Version: 1}
+	if opts.Existing != nil {
+		existing, err := parseManifest(opts.Existing, opts.AllowHTTP)
+		if err != nil {
+			return nil, fmt.Errorf("existing manifest: %w", err)
+		}
+		doc = existing
+	}
+
+	releases, err := scanReleases(opts.Dir)
+	if err != nil {
+		return nil, err
+	}
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("no release archives (%s) in %s", strings.Join(releaseExts, ", "), opts.Dir)
+	}
+
+	result := &GenerateResult{}
+	existingMods := len(doc.Mods)
+	index := make(map[string]int, len(doc.Mods))
+	for i, m := range doc.Mods {
+		index[m.ID] = i
+	}
+	newest := make(map[string]*release)
+	var touched []string
+
+	for _, r := range releases {
+		r.file.URL = expandReleaseURL(opts.URLTemplate, r)
+		for _, tmpl := range opts.MirrorTemplates {
+			r.file.Mirrors = append(r.file.Mirrors, expandReleaseURL(tmpl, r))
+		}
+
+		i, ok := index[r.modID]
+		if !ok {
+			i = len(doc.Mods)
+			index[r.modID] = i
+			doc.Mods = append(doc.Mods, manifestMod{ID: r.modID, Name: r.name, GameIDs: opts.GameIDs})
+		}
+		mod := &doc.Mods[i]
+		if j := slices.IndexFunc(mod.Files, func(f manifestFile) bool { return f.ID == r.file.ID }); j >= 0 {
+			r.changed = mod.Files[j].SHA256 != r.file.SHA256
+			if len(opts.MirrorTemplates) == 0 {
+				r.file.Mirrors = mod.Files[j].Mirrors
+			}
+			mod.Files[j] = r.file
+			result.Updated++
+		} else {
+			r.changed = true
+			mod.Files = append(mod.Files, r.file)
+			result.Added++
+		}
+
+		if n, ok := newest[r.modID]; !ok {
+			newest[r.modID] = r
+			touched = append(touched, r.modID)
+		} else if domain.CompareVersions(r.file.Version, n.file.Version) > 0 {
+			newest[r.modID] = r
+		}
+	}
+
+	for _, id := range touched {
+		applyNewestRelease(&doc.Mods[index[id]], newest[id], opts.Now)
+	}
+	// Existing mods keep their order, so a regenerated manifest diffs
+	// cleanly; new ones follow, sorted by ID.
+	slices.SortFunc(doc.Mods[existingMods:], func(a, b manifestMod) int { return strings.Compare(a.ID, b.ID) })
+	result.Mods = len(doc.Mods)
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("encoding manifest: %w", err)
+	}
+	// The result must load as a manifest, so a bad URL template (plain http
+	// without AllowHTTP, or not a URL at all) fails here, not at install time.
+	if _, err := parseManifest(out, opts.AllowHTTP); err != nil {
+		return nil, err
+	}
+	result.Manifest = out
+	return result, nil
+}
+
+// applyNewestRelease orders a mod's files newest version first, marks the
+// newest version's files primary, and, when r is that newest version, takes
+// the mod's version and metadata from it.
+func applyNewestRelease(mod *manifestMod, r *release, now time.Time) {
+	slices.SortStableFunc(mod.Files, func(a, b manifestFile) int {
+		return domain.CompareVersions(b.Version, a.Version)
+	})
+	top := mod.Files[0].Version
+	for i := range mod.Files {
+		mod.Files[i].Primary = domain.CompareVersions(mod.Files[i].Version, top) == 0
+	}
+	if domain.CompareVersions(r.file.Version, top) != 0 {
+		return // an older version was rescanned; the mod stays at its newest
+	}
+
+	mod.Name = r.name
+	mod.Version = r.file.Version
+	if r.author != "" {
+		mod.Author = r.author
+	}
+	if r.summary != "" {
+		mod.Summary = r.summary
+	}
+	if r.changed || mod.UpdatedAt == "" {
+		stamp := r.modTime
+		if !now.IsZero() {
+			stamp = now
+		}
+		mod.UpdatedAt = stamp.UTC().Format(time.RFC3339)
+	}
+}
+
+// scanReleases reads every release archive in dir, in name order.
+func scanReleases(dir string) ([]*release, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("scanning %s: %w", dir, err)
+	}
+	var releases []*release
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || !slices.Contains(releaseExts, strings.ToLower(filepath.Ext(name))) {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("stat %s: %w", path, err)
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		r, err := scanRelease(path, info)
+		if err != nil {
+			return nil, err
+		}
+		releases = append(releases, r)
+	}
+	return releases, nil
+}
+
+// scanRelease builds a release from one archive, preferring its metadata
+// file over file-name parsing with the same precedence as directory sources.
+func scanRelease(path string, info os.FileInfo) (*release, error) {
+	filename := filepath.Base(path)
+	base := strings.TrimSuffix(filename, filepath.Ext(filename))
+	name, version := nameAndVersionFrom(base)
+	r := &release{modID: name, name: name, modTime: info.ModTime()}
+
+	if meta := metadata.ResolveArchive(path); meta != nil {
+		mod := domain.Mod{Name: name, Version: version}
+		applyMetadata(&mod, meta)
+		r.name, version, r.author, r.summary = mod.Name, mod.Version, mod.Author, mod.Summary
+		if meta.Name != "" {
+			r.modID = meta.Name
+		}
+	}
+
+	sum, err := sha256File(path)
+	if err != nil {
+		return nil, err
+	}
+	r.file = manifestFile{
+		ID:       filename,
+		Filename: filename,
+		Version:  version,
+		Size:     info.Size(),
+		SHA256:   sum,
+	}
+	return r, nil
+}
+
+// sha256File returns the hex SHA-256 of the file at path.
+func sha256File(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("hashing %s: %w", path, err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hashing %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// expandReleaseURL fills a URL template for r. A template with none of the
+// placeholders is a base URL: the file name is appended to it.
+func expandReleaseURL(template string, r *release) string {
+	if !slices.ContainsFunc(urlPlaceholders, func(p string) bool { return strings.Contains(template, p) }) {
+		return strings.TrimSuffix(template, "/") + "/" + url.PathEscape(r.file.Filename)
+	}
+	return strings.NewReplacer(
+		"{filename}", url.PathEscape(r.file.Filename),
+		"{mod_id}", url.PathEscape(r.modID),
+		"{version}", url.PathEscape(r.file.Version),
+	).Replace(template)
+}
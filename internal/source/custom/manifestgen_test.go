@@ -0,0 +1,149 @@
+package custom
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testGenerateNow = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+func TestGenerateManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeTestZip(t, filepath.Join(dir, "backpack-1.2.0.zip"), "BiggerBackpack/ModInfo.xml", testModInfo)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "PlainMod-0.5.7z"), []byte("plain"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden.zip"), []byte("ignored"), 0644))
+
+	res, err := GenerateManifest(GenerateOptions{
+		Dir:         dir,
+		URLTemplate: "https://dl.test/{mod_id}/{version}/{filename}",
+		GameIDs:     []string{"7dtd"},
+		Now:         testGenerateNow,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Mods)
+	assert.Equal(t, 2, res.Added)
+	assert.Zero(t, res.Updated)
+
+	doc, err := parseManifest(res.Manifest, false)
+	require.NoError(t, err, "the output is a valid manifest")
+	require.Len(t, doc.Mods, 2)
+
+	backpack := doc.Mods[0]
+	assert.Equal(t, "BiggerBackpack", backpack.ID, "ModInfo.xml's Name is the mod ID")
+	assert.Equal(t, "Bigger Backpack", backpack.Name)
+	assert.Equal(t, "1.2.0", backpack.Version)
+	assert.Equal(t, "Donovan", backpack.Author)
+	assert.Equal(t, "Carry more stuff", backpack.Summary)
+	assert.Equal(t, []string{"7dtd"}, backpack.GameIDs)
+	assert.Equal(t, "2026-10-01T12:00:00Z", backpack.UpdatedAt)
+	require.Len(t, backpack.Files, 1)
+	assert.Equal(t, "https://dl.test/BiggerBackpack/1.2.0/backpack-1.2.0.zip", backpack.Files[0].URL)
+	assert.True(t, backpack.Files[0].Primary)
+
+	plain := doc.Mods[1]
+	assert.Equal(t, "PlainMod", plain.ID, "without metadata the file name is parsed")
+	assert.Equal(t, "0.5", plain.Version)
+	require.Len(t, plain.Files, 1)
+	sum := sha256.Sum256([]byte("plain"))
+	assert.Equal(t, manifestFile{
+		ID:       "PlainMod-0.5.7z",
+		Filename: "PlainMod-0.5.7z",
+		Version:  "0.5",
+		Size:     5,
+		URL:      "https://dl.test/PlainMod/0.5/PlainMod-0.5.7z",
+		SHA256:   hex.EncodeToString(sum[:]),
+		Primary:  true,
+	}, plain.Files[0])
+}
+
+func TestGenerateManifestMerge(t *testing.T) {
+	existing := []byte(`
+version: 1
+mods:
+  - id: PlainMod
+    name: Plain Mod
+    summary: Hand-written summary
+    game_ids: [skyrim]
+    dependencies: [other-mod]
+    files:
+      - id: PlainMod-0.4.zip
+        filename: PlainMod-0.4.zip
+        version: "0.4"
+        url: https://old.test/PlainMod-0.4.zip
+        primary: true
+  - id: other-mod
+    name: Other Mod
+`)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "PlainMod-0.5.zip"), []byte("new"), 0644))
+
+	res, err := GenerateManifest(GenerateOptions{
+		Dir:             dir,
+		URLTemplate:     "https://dl.test/releases/",
+		MirrorTemplates: []string{"https://mirror.test/{mod_id}/{filename}"},
+		GameIDs:         []string{"ignored-for-existing-mods"},
+		Existing:        existing,
+		Now:             testGenerateNow,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Mods)
+	assert.Equal(t, 1, res.Added)
+
+	doc, err := parseManifest(res.Manifest, false)
+	require.NoError(t, err)
+	require.Len(t, doc.Mods, 2)
+	plain := doc.Mods[0]
+	assert.Equal(t, "0.5", plain.Version)
+	assert.Equal(t, "Hand-written summary", plain.Summary, "fields the archive lacks are kept")
+	assert.Equal(t, []string{"skyrim"}, plain.GameIDs)
+	assert.Equal(t, []string{"other-mod"}, plain.Dependencies)
+	require.Len(t, plain.Files, 2, "older versions stay listed")
+	assert.Equal(t, "PlainMod-0.5.zip", plain.Files[0].ID)
+	assert.Equal(t, "https://dl.test/releases/PlainMod-0.5.zip", plain.Files[0].URL, "a plain base URL gets the file name appended")
+	assert.Equal(t, []string{"https://mirror.test/PlainMod/PlainMod-0.5.zip"}, plain.Files[0].Mirrors)
+	assert.True(t, plain.Files[0].Primary)
+	assert.Equal(t, "PlainMod-0.4.zip", plain.Files[1].ID)
+	assert.False(t, plain.Files[1].Primary, "only the newest version is primary")
+	assert.Equal(t, "Other Mod", doc.Mods[1].Name, "mods without archives are untouched")
+
+	// Rescanning replaces the file rather than listing it twice, and an
+	// unchanged archive does not bump updated_at.
+	res, err = GenerateManifest(GenerateOptions{
+		Dir: dir, URLTemplate: "https://dl.test/releases/", Existing: res.Manifest, Now: testGenerateNow.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Zero(t, res.Added)
+	assert.Equal(t, 1, res.Updated)
+	doc, err = parseManifest(res.Manifest, false)
+	require.NoError(t, err)
+	assert.Len(t, doc.Mods[0].Files, 2)
+	assert.Equal(t, "2026-10-01T12:00:00Z", doc.Mods[0].UpdatedAt)
+	assert.Equal(t, []string{"https://mirror.test/PlainMod/PlainMod-0.5.zip"}, doc.Mods[0].Files[0].Mirrors, "a rescan without mirror templates keeps the listed mirrors")
+}
+
+func TestGenerateManifestErrors(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Mod-1.0.zip"), []byte("x"), 0644))
+
+	_, err := GenerateManifest(GenerateOptions{Dir: dir})
+	assert.ErrorContains(t, err, "URL template is required")
+
+	_, err = GenerateManifest(GenerateOptions{Dir: dir, URLTemplate: "http://dl.test/"})
+	assert.ErrorContains(t, err, "allow_http", "plain http needs AllowHTTP")
+	_, err = GenerateManifest(GenerateOptions{Dir: dir, URLTemplate: "http://dl.test/", AllowHTTP: true})
+	assert.NoError(t, err)
+
+	_, err = GenerateManifest(GenerateOptions{Dir: dir, URLTemplate: "https://dl.test/", Existing: []byte("version: 2\n")})
+	assert.ErrorContains(t, err, "existing manifest")
+
+	_, err = GenerateManifest(GenerateOptions{Dir: t.TempDir(), URLTemplate: "https://dl.test/"})
+	assert.ErrorContains(t, err, "no release archives")
+}
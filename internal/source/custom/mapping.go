@@ -57,6 +57,22 @@ func coerceInt64(v any) int64 {
 	}
 }
 
+// coerceBool renders a JSON scalar as a bool: true, "true" (any case), or a
+// non-zero number. Everything else is false.
+func coerceBool(v any) bool {
+	switch t := v.(type) {
+	case bool:
+		return t
+	case string:
+		b, err := strconv.ParseBool(t)
+		return err == nil && b
+	case float64:
+		return t != 0
+	default:
+		return false
+	}
+}
+
 // pathString resolves a mapping path to a string; "" when the mapping key or
 // the path is absent.
 func pathString(doc any, mapping map[string]string, key string) string {
@@ -108,8 +124,10 @@ func mapMod(doc any, mapping map[string]string, sourceID string) (domain.Mod, er
 }
 
 // mapFile builds a domain.DownloadableFile from a decoded JSON object using
-// the definition's file mappings. id is required (design §4).
-func mapFile(doc any, mapping map[string]string) (domain.DownloadableFile, error) {
+// the definition's file mappings. id is required (design §4); mirrors maps
+// to an array of alternate download URLs, plain http ones only with
+// allowHTTP.
+func mapFile(doc any, mapping map[string]string, allowHTTP bool) (domain.DownloadableFile, error) {
 	f := domain.DownloadableFile{}
 
 	f.ID = pathString(doc, mapping, "id")
@@ -124,5 +142,53 @@ func mapFile(doc any, mapping map[string]string) (domain.DownloadableFile, error
 			f.Size = coerceInt64(v)
 		}
 	}
+	f.SHA256 = strings.ToLower(pathString(doc, mapping, "sha256"))
+	if path, ok := mapping["primary"]; ok {
+		if v, found := lookupPath(doc, path); found {
+			f.IsPrimary = coerceBool(v)
+		}
+	}
+	f.Category = pathString(doc, mapping, "category")
+	if path, ok := mapping["mirrors"]; ok {
+		if v, found := lookupPath(doc, path); found {
+			f.Mirrors = coerceURLList(v, allowHTTP)
+		}
+	}
 	return f, nil
 }
+
+// coerceURLList renders a JSON array of URL strings as a string slice,
+// dropping entries that are not https URLs (or http ones, with allowHTTP);
+// a lone string is a one-entry list.
+func coerceURLList(v any, allowHTTP bool) []string {
+	items, ok := v.([]any)
+	if !ok {
+		items = []any{v}
+	}
+	var urls []string
+	for _, item := range items {
+		s, ok := item.(string)
+		if ok && (strings.HasPrefix(s, "https://") || allowHTTP && strings.HasPrefix(s, "http://")) {
+			urls = append(urls, s)
+		}
+	}
+	return urls
+}
+
+// mapDependency builds a domain.ModReference from a decoded JSON object using
+// the definition's dependency mappings. mod_id is required; an unmapped or
+// empty source_id means the dependency is on sourceID itself.
+func mapDependency(doc any, mapping map[string]string, sourceID string) (domain.ModReference, error) {
+	ref := domain.ModReference{
+		SourceID: pathString(doc, mapping, "source_id"),
+		ModID:    pathString(doc, mapping, "mod_id"),
+		Version:  pathString(doc, mapping, "version"),
+	}
+	if ref.ModID == "" {
+		return domain.ModReference{}, fmt.Errorf(`response is missing required field "mod_id" (mapped from %q)`, mapping["mod_id"])
+	}
+	if ref.SourceID == "" {
+		ref.SourceID = sourceID
+	}
+	return ref, nil
+}
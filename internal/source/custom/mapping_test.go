@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -122,7 +123,7 @@ func TestMapFile(t *testing.T) {
 	doc := jsonDoc(t, `{"id": 900, "title": "Main File", "file_name": "cool-1.2.0.zip", "version": "1.2.0", "size_bytes": 123456}`)
 	mapping := map[string]string{"id": "id", "name": "title", "filename": "file_name", "version": "version", "size": "size_bytes"}
 
-	f, err := mapFile(doc, mapping)
+	f, err := mapFile(doc, mapping, false)
 	require.NoError(t, err)
 	assert.Equal(t, "900", f.ID)
 	assert.Equal(t, "Main File", f.Name)
@@ -130,6 +131,60 @@ func TestMapFile(t *testing.T) {
 	assert.Equal(t, "1.2.0", f.Version)
 	assert.Equal(t, int64(123456), f.Size)
 
-	_, err = mapFile(jsonDoc(t, `{"title": "no id"}`), mapping)
+	_, err = mapFile(jsonDoc(t, `{"title": "no id"}`), mapping, false)
 	assert.ErrorContains(t, err, `required field "id"`)
 }
+
+func TestMapFileChecksumPrimaryCategory(t *testing.T) {
+	mapping := map[string]string{"id": "id", "sha256": "hashes.sha256", "primary": "main", "category": "kind"}
+
+	f, err := mapFile(jsonDoc(t, `{"id": 1, "hashes": {"sha256": "ABCDEF"}, "main": true, "kind": "OPTIONAL"}`), mapping, false)
+	require.NoError(t, err)
+	assert.Equal(t, "abcdef", f.SHA256, "checksums are normalized to lowercase hex")
+	assert.True(t, f.IsPrimary)
+	assert.Equal(t, "OPTIONAL", f.Category)
+
+	for _, primary := range []string{`"true"`, `1`} {
+		f, err := mapFile(jsonDoc(t, `{"id": 1, "main": `+primary+`}`), mapping, false)
+		require.NoError(t, err)
+		assert.True(t, f.IsPrimary, primary)
+	}
+	f, err = mapFile(jsonDoc(t, `{"id": 1, "main": "no"}`), mapping, false)
+	require.NoError(t, err)
+	assert.False(t, f.IsPrimary)
+}
+
+func TestMapFileMirrors(t *testing.T) {
+	mapping := map[string]string{"id": "id", "mirrors": "links.mirrors"}
+
+	f, err := mapFile(jsonDoc(t, `{"id": 1, "links": {"mirrors": ["https://a.test/x.zip", 7, "ftp://b.test/x.zip", "http://c.test/x.zip"]}}`), mapping, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://a.test/x.zip"}, f.Mirrors, "non-URL and plain http entries are dropped")
+
+	f, err = mapFile(jsonDoc(t, `{"id": 1, "links": {"mirrors": ["https://a.test/x.zip", "http://c.test/x.zip"]}}`), mapping, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://a.test/x.zip", "http://c.test/x.zip"}, f.Mirrors, "allow_http keeps plain http mirrors")
+
+	f, err = mapFile(jsonDoc(t, `{"id": 1, "links": {"mirrors": "https://a.test/x.zip"}}`), mapping, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://a.test/x.zip"}, f.Mirrors, "a single string is a one-mirror list")
+
+	f, err = mapFile(jsonDoc(t, `{"id": 1}`), mapping, false)
+	require.NoError(t, err)
+	assert.Nil(t, f.Mirrors)
+}
+
+func TestMapDependency(t *testing.T) {
+	mapping := map[string]string{"mod_id": "mod.id", "source_id": "source", "version": "min_version"}
+
+	ref, err := mapDependency(jsonDoc(t, `{"mod": {"id": 12}, "min_version": "2.0"}`), mapping, "s")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ModReference{SourceID: "s", ModID: "12", Version: "2.0"}, ref)
+
+	ref, err = mapDependency(jsonDoc(t, `{"mod": {"id": "abc"}, "source": "nexusmods"}`), mapping, "s")
+	require.NoError(t, err)
+	assert.Equal(t, "nexusmods", ref.SourceID)
+
+	_, err = mapDependency(jsonDoc(t, `{"source": "x"}`), mapping, "s")
+	assert.ErrorContains(t, err, `required field "mod_id"`)
+}
@@ -6,24 +6,17 @@ import (
 	"strings"
 )
 
-// modInfoFileName is the name Detect and ResolveArchive look for. Matching is
-// case-insensitive (strings.EqualFold) in both, since mod packagers ship
-// inconsistent casing (ModInfo.xml, modinfo.xml, MODINFO.XML) and Linux
-// filesystems are case-sensitive (see issue #52).
+// modInfoFileName is the name ModInfoXML reads. Like every reader's File it
+// is matched case-insensitively (ModInfo.xml, modinfo.xml, MODINFO.XML).
 const modInfoFileName = "ModInfo.xml"
 
-// maxModInfoSize is the maximum allowed size (in bytes) for a ModInfo.xml entry
-// when extracted from an archive. Real ModInfo.xml files are typically a few KB;
-// this limit prevents decompression bomb attacks where a small compressed entry
-// expands to gigabytes during extraction.
-const maxModInfoSize = 1 << 20 // 1 MiB
-
 // ResolveArchive extracts metadata from a .zip or .jar archive whose
-// ModInfo.xml lives at the archive root or exactly one directory deep - the
+// metadata file lives at the archive root or exactly one directory deep - the
 // common "wrapper folder" layout used by 7 Days to Die mods (e.g. a
-// donovan-aio.zip containing donovan-aio/ModInfo.xml). Returns nil when the
-// archive can't be opened, has no ModInfo.xml, or the metadata is malformed;
-// callers fall back to filename-based detection, mirroring Resolve.
+// donovan-aio.zip containing donovan-aio/ModInfo.xml). Readers are tried in
+// priority order, as in Resolve. Returns nil when the archive can't be
+// opened, has no metadata file, or the metadata is malformed; callers fall
+// back to filename-based detection, mirroring Resolve.
 func ResolveArchive(archivePath string) *Info {
 	r, err := zip.OpenReader(archivePath)
 	if err != nil {
@@ -31,38 +24,49 @@ func ResolveArchive(archivePath string) *Info {
 	}
 	defer r.Close()
 
-	target := findModInfoEntry(r.File)
-	if target == nil {
-		return nil
+	for _, reader := range readers {
+		target := findEntry(r.File, reader.File())
+		if target == nil {
+			continue
+		}
+		data, err := readEntry(target)
+		if err != nil {
+			continue
+		}
+		info, err := reader.Parse(data)
+		if err != nil {
+			continue // malformed metadata falls back, it doesn't fail the scan
+		}
+		return info
 	}
+	return nil
+}
 
-	rc, err := target.Open()
+// readEntry reads an archive entry, refusing one larger than maxMetadataSize.
+func readEntry(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
 	if err != nil {
-		return nil
+		return nil, err
 	}
 	defer rc.Close()
 
-	data, err := io.ReadAll(io.LimitReader(rc, maxModInfoSize+1))
+	data, err := io.ReadAll(io.LimitReader(rc, maxMetadataSize+1))
 	if err != nil {
-		return nil
+		return nil, err
 	}
-
 	// Reject entries larger than the cap to prevent decompression bombs
-	if len(data) > maxModInfoSize {
-		return nil
-	}
-
-	info, err := parseModInfo(data)
-	if err != nil {
-		return nil // malformed metadata falls back, it doesn't fail the scan
+	if len(data) > maxMetadataSize {
+		return nil, io.ErrShortBuffer
 	}
-	return info
+	return data, nil
 }
 
-// findModInfoEntry locates ModInfo.xml at the archive root or exactly one
-// directory deep. A root-level match always wins over a nested one;
-// otherwise the first one-deep match in archive order is used.
-func findModInfoEntry(files []*zip.File) *zip.File {
+// findEntry locates file (slash-separated, matched case-insensitively) at the
+// archive root or exactly one directory deep. A root-level match always wins
+// over a nested one; otherwise the first one-deep match in archive order is
+// used.
+func findEntry(files []*zip.File, file string) *zip.File {
+	want := strings.Split(file, "/")
 	var nested *zip.File
 	for _, f := range files {
 		if f.FileInfo().IsDir() {
@@ -70,15 +74,25 @@ func findModInfoEntry(files []*zip.File) *zip.File {
 		}
 		parts := strings.Split(f.Name, "/")
 		switch len(parts) {
-		case 1:
-			if strings.EqualFold(parts[0], modInfoFileName) {
+		case len(want):
+			if pathEqualFold(parts, want) {
 				return f
 			}
-		case 2:
-			if nested == nil && strings.EqualFold(parts[1], modInfoFileName) {
+		case len(want) + 1:
+			if nested == nil && pathEqualFold(parts[1:], want) {
 				nested = f
 			}
 		}
 	}
 	return nested
 }
+
+// pathEqualFold reports whether two split paths match case-insensitively.
+func pathEqualFold(a, b []string) bool {
+	for i := range a {
+		if !strings.EqualFold(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
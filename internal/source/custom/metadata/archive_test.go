@@ -85,9 +85,9 @@ func TestResolveArchiveFirstNestedMatchWinsInDeterministicOrder(t *testing.T) {
 	assert.Equal(t, "BiggerBackpack", info.Name, "the first one-deep match in archive order should win")
 }
 
-// TestResolveArchiveCaseInsensitiveEntryName pins that findModInfoEntry
+// TestResolveArchiveCaseInsensitiveEntryName pins that findEntry
 // matches ModInfo.xml regardless of case (issue #52 item 5), same as the
-// on-disk Detect path.
+// on-disk detect path.
 func TestResolveArchiveCaseInsensitiveEntryName(t *testing.T) {
 	path := writeZip(t, [][2]string{{"MODINFO.XML", modInfoV2}})
 	info := ResolveArchive(path)
@@ -0,0 +1,68 @@
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FabricModJSON reads fabric.mod.json, the metadata Fabric mods carry at the
+// jar root (Quilt loads them too).
+type FabricModJSON struct{}
+
+// File implements Reader.
+func (FabricModJSON) File() string { return "fabric.mod.json" }
+
+type fabricModDoc struct {
+	ID          string            `json:"id"`
+	Version     string            `json:"version"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Authors     []json.RawMessage `json:"authors"`
+	Depends     map[string]any    `json:"depends"`
+}
+
+// Parse implements Reader. authors entries are either names or
+// {"name": ...} objects; depends maps mod IDs to version ranges.
+func (FabricModJSON) Parse(data []byte) (*Info, error) {
+	var doc fabricModDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing fabric.mod.json: %w", err)
+	}
+	if doc.ID == "" {
+		return nil, fmt.Errorf("fabric.mod.json has no id")
+	}
+
+	var authors []string
+	for _, raw := range doc.Authors {
+		var name string
+		if json.Unmarshal(raw, &name) != nil {
+			var person struct {
+				Name string `json:"name"`
+			}
+			if json.Unmarshal(raw, &person) != nil {
+				continue
+			}
+			name = person.Name
+		}
+		if name != "" {
+			authors = append(authors, name)
+		}
+	}
+
+	deps := make([]string, 0, len(doc.Depends))
+	for id := range doc.Depends {
+		deps = append(deps, id)
+	}
+	slices.Sort(deps)
+
+	return &Info{
+		Name:         doc.ID,
+		DisplayName:  doc.Name,
+		Version:      doc.Version,
+		Summary:      doc.Description,
+		Author:       strings.Join(authors, ", "),
+		Dependencies: deps,
+	}, nil
+}
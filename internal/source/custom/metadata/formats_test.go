@@ -0,0 +1,162 @@
+package metadata
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fabricModJSON = `{
+	"schemaVersion": 1,
+	"id": "sodium",
+	"version": "0.5.8+mc1.20.1",
+	"name": "Sodium",
+	"description": "Rendering engine replacement",
+	"authors": ["JellySquid", {"name": "IMS", "contact": {}}],
+	"depends": {"minecraft": "1.20.1", "fabricloader": ">=0.12.0"}
+}`
+
+const modsTOML = `modLoader="javafml" #mandatory
+loaderVersion="[47,)"
+license='MIT'
+
+[[mods]] #mandatory
+modId="jei"
+version="${file.jarVersion}"
+displayName="Just Enough Items"
+authors="mezz"
+description='''
+View items and recipes
+'''
+
+[[dependencies.jei]]
+    modId="forge"
+    mandatory=true
+    versionRange="[47,)"
+[[dependencies.jei]]
+    modId="optional-thing"
+    mandatory=false
+[[dependencies.jei]]
+    modId="neo-style"
+    type="required"
+`
+
+const thunderstoreManifest = "\xef\xbb\xbf" + `{
+	"name": "More_Suits",
+	"version_number": "1.4.1",
+	"website_url": "",
+	"description": "Adds more suits",
+	"dependencies": ["BepInEx-BepInExPack-5.4.2100", "x753-LethalLib-0.10.1"]
+}`
+
+const metaINI = `[General]
+gameName=SkyrimSE
+modid=266
+version=4.1.5
+newestVersion=4.1.5
+
+[installedFiles]
+1\modid=266
+`
+
+func TestFabricModJSON(t *testing.T) {
+	info, err := FabricModJSON{}.Parse([]byte(fabricModJSON))
+	require.NoError(t, err)
+	assert.Equal(t, &Info{
+		Name:         "sodium",
+		DisplayName:  "Sodium",
+		Version:      "0.5.8+mc1.20.1",
+		Summary:      "Rendering engine replacement",
+		Author:       "JellySquid, IMS",
+		Dependencies: []string{"fabricloader", "minecraft"},
+	}, info)
+
+	_, err = FabricModJSON{}.Parse([]byte(`{"name": "no id"}`))
+	assert.Error(t, err)
+}
+
+func TestModsTOML(t *testing.T) {
+	info, err := ModsTOML{}.Parse([]byte(modsTOML))
+	require.NoError(t, err)
+	assert.Equal(t, &Info{
+		Name:         "jei",
+		DisplayName:  "Just Enough Items",
+		Summary:      "View items and recipes",
+		Author:       "mezz",
+		Dependencies: []string{"forge", "neo-style"},
+	}, info, "a ${...} version is left for the file name to supply")
+
+	info, err = NeoForgeModsTOML{}.Parse([]byte("[[mods]]\nmodId=\"neo\"\nversion=\"2.0\" # comment\ndescription=\"\"\"\\\n  Line\\tone\"\"\"\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "neo", info.Name)
+	assert.Equal(t, "2.0", info.Version)
+	assert.Equal(t, "Line\tone", info.Summary)
+
+	_, err = ModsTOML{}.Parse([]byte(`modLoader="javafml"`))
+	assert.Error(t, err)
+}
+
+func TestThunderstoreManifest(t *testing.T) {
+	info, err := ThunderstoreManifest{}.Parse([]byte(thunderstoreManifest))
+	require.NoError(t, err, "a byte order mark is tolerated")
+	assert.Equal(t, &Info{
+		Name:         "More_Suits",
+		DisplayName:  "More Suits",
+		Version:      "1.4.1",
+		Summary:      "Adds more suits",
+		Dependencies: []string{"BepInExPack", "LethalLib"},
+	}, info)
+
+	_, err = ThunderstoreManifest{}.Parse([]byte(`{"name": "npm-package"}`))
+	assert.Error(t, err, "a manifest.json that is not Thunderstore's is rejected")
+}
+
+func TestMetaINI(t *testing.T) {
+	info, err := MetaINI{}.Parse([]byte(metaINI))
+	require.NoError(t, err)
+	assert.Equal(t, &Info{Version: "4.1.5"}, info)
+
+	info, err = MetaINI{}.Parse([]byte("[General]\nversion=d2024.1.1\n"))
+	require.NoError(t, err)
+	assert.Empty(t, info.Version, "a date placeholder is no version")
+
+	_, err = MetaINI{}.Parse([]byte("[Other]\nx=1\n"))
+	assert.Error(t, err)
+}
+
+func TestResolvePriorityAndNestedFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "meta.ini"), []byte(metaINI), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "manifest.json"), []byte(thunderstoreManifest), 0644))
+	info := Resolve(dir)
+	require.NotNil(t, info)
+	assert.Equal(t, "More_Suits", info.Name, "manifest.json outranks meta.ini")
+
+	dir = t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "meta-inf"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "meta-inf", "MODS.TOML"), []byte(modsTOML), 0644))
+	info = Resolve(dir)
+	require.NotNil(t, info, "every path component matches case-insensitively")
+	assert.Equal(t, "jei", info.Name)
+}
+
+func TestResolveArchiveFormats(t *testing.T) {
+	info := ResolveArchive(writeZip(t, [][2]string{{"META-INF/MANIFEST.MF", "x"}, {"META-INF/mods.toml", modsTOML}}))
+	require.NotNil(t, info)
+	assert.Equal(t, "jei", info.Name)
+
+	info = ResolveArchive(writeZip(t, [][2]string{{"fabric.mod.json", fabricModJSON}}))
+	require.NotNil(t, info)
+	assert.Equal(t, "sodium", info.Name)
+
+	info = ResolveArchive(writeZip(t, [][2]string{{"MoreSuits/manifest.json", thunderstoreManifest}}))
+	require.NotNil(t, info, "a wrapper folder works for every format")
+	assert.Equal(t, "More_Suits", info.Name)
+
+	info = ResolveArchive(writeZip(t, [][2]string{{"fabric.mod.json", "{broken"}, {"manifest.json", thunderstoreManifest}}))
+	require.NotNil(t, info)
+	assert.Equal(t, "More_Suits", info.Name, "an unparseable file falls through to the next format")
+}
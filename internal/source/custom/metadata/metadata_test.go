@@ -71,7 +71,7 @@ func TestResolveMalformedXML(t *testing.T) {
 }
 
 // TestResolveModInfoV1MissingNameFallsBack pins that a V1 document without a
-// <Name> element is treated as unparseable (issue #52 item 6): parseModInfo
+// <Name> element is treated as unparseable (issue #52 item 6): ModInfoXML.Parse
 // only recognizes the V1 layout via doc.ModInfo.Name.Value being non-empty,
 // so a nameless <ModInfo> block falls through to the empty V2-shaped fields,
 // which then also lacks a Name and fails - Resolve returns nil so callers
@@ -80,7 +80,7 @@ func TestResolveModInfoV1MissingNameFallsBack(t *testing.T) {
 	assert.Nil(t, Resolve(writeModDir(t, modInfoV1NoName)))
 }
 
-// TestResolveModInfoCaseInsensitiveFilename pins that Detect finds ModInfo.xml
+// TestResolveModInfoCaseInsensitiveFilename pins that Resolve finds ModInfo.xml
 // regardless of case (issue #52 item 5): some 7 Days to Die mod packagers ship
 // lowercase modinfo.xml on Linux filesystems, where filenames are case-sensitive.
 func TestResolveModInfoCaseInsensitiveFilename(t *testing.T) {
@@ -0,0 +1,47 @@
+package metadata
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MetaINI reads meta.ini, which Mod Organizer 2 writes into each mod folder
+// it installs. It names no mod (MO2 uses the folder name) and only supplies
+// a version; Resolve's callers keep the folder-derived name.
+type MetaINI struct{}
+
+// File implements Reader.
+func (MetaINI) File() string { return "meta.ini" }
+
+// Parse implements Reader, reading the [General] section. MO2 records the
+// installed version as version=, and a placeholder "0.0.0.0" or "d" date
+// version (d2024.1.1) when it has none, which is treated as no version.
+func (MetaINI) Parse(data []byte) (*Info, error) {
+	general := map[string]string{}
+	section := ""
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(strings.TrimSpace(line[1 : len(line)-1]))
+			continue
+		}
+		if section != "general" {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			general[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"`)
+		}
+	}
+	if len(general) == 0 {
+		return nil, fmt.Errorf("meta.ini has no [General] section")
+	}
+
+	version := general["version"]
+	if version == "0.0.0.0" || strings.HasPrefix(version, "d") {
+		version = ""
+	}
+	return &Info{Version: version}, nil
+}
@@ -0,0 +1,202 @@
+package metadata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ModsTOML reads META-INF/mods.toml, the metadata Forge mods (and NeoForge
+// mods before 1.20.5) carry inside their jars.
+type ModsTOML struct{}
+
+// File implements Reader.
+func (ModsTOML) File() string { return "META-INF/mods.toml" }
+
+// Parse implements Reader. The first [[mods]] entry describes the mod; its
+// [[dependencies.<modId>]] entries marked mandatory (Forge) or of type
+// "required" (NeoForge) are its dependencies. A version that is a build-time
+// placeholder (${file.jarVersion}) is left empty, so callers fall back to
+// the jar's file name.
+func (ModsTOML) Parse(data []byte) (*Info, error) {
+	tables := parseTOMLTables(data)
+
+	var mod *tomlTable
+	for i := range tables {
+		if tables[i].name == "mods" {
+			mod = &tables[i]
+			break
+		}
+	}
+	if mod == nil || mod.str("modId") == "" {
+		return nil, fmt.Errorf("mods.toml has no [[mods]] entry with a modId")
+	}
+	id := mod.str("modId")
+
+	version := mod.str("version")
+	if strings.Contains(version, "${") {
+		version = ""
+	}
+
+	var deps []string
+	for _, t := range tables {
+		if t.name != "dependencies."+id {
+			continue
+		}
+		required := t.values["mandatory"] == true || strings.EqualFold(t.str("type"), "required")
+		if dep := t.str("modId"); required && dep != "" {
+			deps = append(deps, dep)
+		}
+	}
+
+	return &Info{
+		Name:         id,
+		DisplayName:  mod.str("displayName"),
+		Version:      version,
+		Summary:      strings.TrimSpace(mod.str("description")),
+		Author:       mod.str("authors"),
+		Dependencies: deps,
+	}, nil
+}
+
+// NeoForgeModsTOML reads META-INF/neoforge.mods.toml, where NeoForge 1.20.5+
+// moved mods.toml. The format is the same.
+type NeoForgeModsTOML struct{ ModsTOML }
+
+// File implements Reader.
+func (NeoForgeModsTOML) File() string { return "META-INF/neoforge.mods.toml" }
+
+// tomlTable is one table of a TOML document: its header ("" for top-level
+// keys, "mods" for each [[mods]]) and its string and boolean values.
+type tomlTable struct {
+	name   string
+	values map[string]any
+}
+
+// str returns key's value when it is a string.
+func (t tomlTable) str(key string) string {
+	s, _ := t.values[key].(string)
+	return s
+}
+
+// parseTOMLTables reads the subset of TOML that mods.toml files use: table
+// and array-of-table headers, and bare keys with string (basic, literal,
+// and multi-line) or boolean values. Anything else - numbers, arrays, inline
+// tables, dotted keys - is skipped rather than rejected, since metadata only
+// needs a handful of string fields and a lenient read beats falling back to
+// the file name.
+func parseTOMLTables(data []byte) []tomlTable {
+	tables := []tomlTable{{values: map[string]any{}}}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' {
+			header, _, _ := strings.Cut(line, "#")
+			name := strings.TrimSpace(strings.Trim(strings.TrimSpace(header), "[]"))
+			tables = append(tables, tomlTable{name: name, values: map[string]any{}})
+			continue
+		}
+
+		key, rest, ok := strings.Cut(line, "=")
+		if !ok {
+			continue // a continuation line of a multi-line array
+		}
+		key = strings.TrimSpace(key)
+		rest = strings.TrimSpace(rest)
+		current := tables[len(tables)-1].values
+
+		switch {
+		case strings.HasPrefix(rest, `"""`), strings.HasPrefix(rest, "'''"):
+			delim := rest[:3]
+			body := rest[3:]
+			for !strings.Contains(body, delim) && i+1 < len(lines) {
+				i++
+				body += "\n" + lines[i]
+			}
+			body, _, _ = strings.Cut(body, delim)
+			body = strings.TrimPrefix(body, "\n") // a newline right after the opening delimiter is trimmed
+			if delim == `"""` {
+				body = unescapeTOML(body)
+			}
+			current[key] = body
+		case strings.HasPrefix(rest, `"`):
+			if end := closingQuote(rest[1:]); end >= 0 {
+				current[key] = unescapeTOML(rest[1 : 1+end])
+			}
+		case strings.HasPrefix(rest, "'"):
+			if end := strings.IndexByte(rest[1:], '\''); end >= 0 {
+				current[key] = rest[1 : 1+end]
+			}
+		default:
+			value, _, _ := strings.Cut(rest, "#")
+			switch strings.TrimSpace(value) {
+			case "true":
+				current[key] = true
+			case "false":
+				current[key] = false
+			}
+		}
+	}
+	return tables
+}
+
+// closingQuote returns the index of the first unescaped '"' in s, or -1.
+func closingQuote(s string) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// unescapeTOML resolves a basic string's escapes, including the line-ending
+// backslash of multi-line strings that joins it to the next non-blank text.
+func unescapeTOML(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch c := s[i]; c {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case '"', '\\':
+			b.WriteByte(c)
+		case 'u', 'U':
+			n := 4
+			if c == 'U' {
+				n = 8
+			}
+			if i+n < len(s) {
+				if r, err := strconv.ParseUint(s[i+1:i+1+n], 16, 32); err == nil {
+					b.WriteRune(rune(r))
+					i += n
+					continue
+				}
+			}
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n', ' ', '\t':
+			for i+1 < len(s) && strings.ContainsRune(" \t\n", rune(s[i+1])) {
+				i++
+			}
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -1,8 +1,16 @@
 // Package metadata extracts mod metadata from well-known files inside a mod
-// directory (e.g. 7 Days to Die's ModInfo.xml). Adding a format means adding
-// one Reader implementation and listing it in readers.
+// directory or archive (e.g. 7 Days to Die's ModInfo.xml, Fabric's
+// fabric.mod.json). Adding a format means adding one Reader implementation
+// and listing it in readers.
 package metadata
 
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
 // Info is metadata extracted from a well-known mod metadata file. Zero-value
 // fields mean the file did not provide them.
 type Info struct {
@@ -11,29 +19,49 @@ type Info struct {
 	Version     string
 	Summary     string
 	Author      string
+	// Dependencies are the Names of mods this one requires, as the format
+	// declares them (Fabric/Forge mod IDs, Thunderstore package names).
+	// They can include the loader or game itself; callers keep the ones that
+	// match mods they know.
+	Dependencies []string
 }
 
 // Reader parses one well-known metadata file format.
 type Reader interface {
-	// Detect returns the metadata file path within modDir, or "" if absent.
-	Detect(modDir string) string
-	// Read parses the metadata file at path.
-	Read(path string) (*Info, error)
+	// File is the metadata file's slash-separated path relative to the mod
+	// root. It is matched case-insensitively, on disk and in archives alike,
+	// since packagers ship inconsistent casing and Linux filesystems are
+	// case-sensitive (see issue #52).
+	File() string
+	// Parse parses the metadata file's content.
+	Parse(data []byte) (*Info, error)
 }
 
 // readers lists all known formats in priority order.
-var readers = []Reader{ModInfoXML{}}
+var readers = []Reader{
+	ModInfoXML{},
+	FabricModJSON{},
+	NeoForgeModsTOML{},
+	ModsTOML{},
+	ThunderstoreManifest{},
+	MetaINI{},
+}
+
+// maxMetadataSize is the maximum allowed size (in bytes) for a metadata file.
+// Real ones are a few KB; the cap keeps a decompression bomb in an archive
+// (a small compressed entry that expands to gigabytes) from being read.
+const maxMetadataSize = 1 << 20 // 1 MiB
 
 // Resolve extracts metadata from modDir using the first matching reader.
 // Returns nil when no known metadata file exists or it cannot be parsed;
 // callers fall back to name-based detection.
 func Resolve(modDir string) *Info {
 	for _, r := range readers {
-		path := r.Detect(modDir)
+		path := detect(modDir, r.File())
 		if path == "" {
 			continue
 		}
-		info, err := r.Read(path)
+		info, err := read(r, path)
 		if err != nil {
 			continue // malformed metadata falls back, it doesn't fail the scan
 		}
@@ -41,3 +69,47 @@ func Resolve(modDir string) *Info {
 	}
 	return nil
 }
+
+// read parses the metadata file at path with r.
+func read(r Reader, path string) (*Info, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > maxMetadataSize {
+		return nil, fmt.Errorf("%s: larger than %d bytes", path, maxMetadataSize)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return r.Parse(data)
+}
+
+// detect returns the path of file within modDir, matching each path
+// component case-insensitively, or "" if absent.
+func detect(modDir, file string) string {
+	dir := modDir
+	parts := strings.Split(file, "/")
+	for i, part := range parts {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return ""
+		}
+		found := ""
+		for _, entry := range entries {
+			if entry.IsDir() != (i < len(parts)-1) {
+				continue
+			}
+			if strings.EqualFold(entry.Name(), part) {
+				found = entry.Name()
+				break
+			}
+		}
+		if found == "" {
+			return ""
+		}
+		dir = filepath.Join(dir, found)
+	}
+	return dir
+}
@@ -0,0 +1,58 @@
+package metadata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ThunderstoreManifest reads manifest.json, the metadata at the root of every
+// Thunderstore package.
+type ThunderstoreManifest struct{}
+
+// File implements Reader.
+func (ThunderstoreManifest) File() string { return "manifest.json" }
+
+type thunderstoreManifestDoc struct {
+	Name          string   `json:"name"`
+	VersionNumber string   `json:"version_number"`
+	Description   string   `json:"description"`
+	Author        string   `json:"author"`
+	Dependencies  []string `json:"dependencies"`
+}
+
+// Parse implements Reader. Dependencies are Thunderstore dependency strings
+// ("Namespace-Name-1.2.3"); each is reduced to its package Name, which is
+// what another package's manifest declares as its own name. A manifest.json
+// without version_number is not a Thunderstore manifest.
+func (ThunderstoreManifest) Parse(data []byte) (*Info, error) {
+	// Thunderstore tooling writes UTF-8 with a byte order mark often enough
+	// that encoding/json's rejection of it would drop real packages.
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	var doc thunderstoreManifestDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing manifest.json: %w", err)
+	}
+	if doc.Name == "" || doc.VersionNumber == "" {
+		return nil, fmt.Errorf("manifest.json is not a Thunderstore manifest")
+	}
+
+	var deps []string
+	for _, dep := range doc.Dependencies {
+		parts := strings.Split(dep, "-")
+		if len(parts) != 3 || parts[1] == "" {
+			continue
+		}
+		deps = append(deps, parts[1])
+	}
+
+	return &Info{
+		Name:         doc.Name,
+		DisplayName:  strings.ReplaceAll(doc.Name, "_", " "),
+		Version:      doc.VersionNumber,
+		Summary:      doc.Description,
+		Author:       doc.Author,
+		Dependencies: deps,
+	}, nil
+}
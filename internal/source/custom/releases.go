@@ -0,0 +1,466 @@
+package custom
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source"
+)
+
+// defaultGitHubAPI is the releases source's API root when base_url is unset.
+const defaultGitHubAPI = "https://api.github.com"
+
+// Releases is a ModSource serving the release assets of a fixed list of
+// GitHub or Gitea/Forgejo repositories. Each repository is one mod, ID
+// "owner/repo"; its release tags are versions and the assets matching the
+// configured pattern are its files, with IDs being the providers' asset IDs.
+// Only the most recent page of releases is considered (100 on GitHub, 50 on
+// Gitea), which covers every version anyone reasonably rolls back to.
+type Releases struct {
+	id          string
+	name        string
+	provider    string
+	baseURL     string
+	repos       []string
+	asset       *regexp.Regexp
+	prereleases bool
+	auth        *AuthConfig
+
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewReleases constructs a releases source from a validated definition. Like
+// NewAPI it performs no I/O.
+func NewReleases(def SourceDefinition) (*Releases, error) {
+	cfg := def.Releases
+	asset, err := regexp.Compile(cfg.Asset)
+	if err != nil {
+		return nil, fmt.Errorf("source %q: asset: %w", def.ID, err)
+	}
+	provider := cfg.Provider
+	if provider == "" {
+		provider = ProviderGitHub
+	}
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultGitHubAPI
+	}
+	return &Releases{
+		id:          def.ID,
+		name:        def.Name,
+		provider:    provider,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		repos:       cfg.Repos,
+		asset:       asset,
+		prereleases: cfg.Prereleases,
+		auth:        cfg.Auth,
+		httpClient:  &http.Client{Timeout: apiRequestTimeout},
+	}, nil
+}
+
+// ghRepository is the subset of a repository object lmm reads. GitHub and
+// Gitea share these field names.
+type ghRepository struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	HTMLURL     string `json:"html_url"`
+	Owner       struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+// ghRelease is the subset of a release object lmm reads.
+type ghRelease struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+	PublishedAt time.Time `json:"published_at"`
+	Assets      []ghAsset `json:"assets"`
+}
+
+// ghAsset is one release asset. URL (the API endpoint serving the bytes) and
+// Digest ("sha256:<hex>") are GitHub-only.
+type ghAsset struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	Size               int64  `json:"size"`
+	DownloadCount      int64  `json:"download_count"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+	URL                string `json:"url"`
+	Digest             string `json:"digest"`
+}
+
+// ID implements source.ModSource.
+func (r *Releases) ID() string { return r.id }
+
+// Name implements source.ModSource.
+func (r *Releases) Name() string { return r.name }
+
+// AuthURL implements source.ModSource; releases sources use tokens, not OAuth.
+func (r *Releases) AuthURL() string { return "" }
+
+// ExchangeToken implements source.ModSource.
+func (r *Releases) ExchangeToken(ctx context.Context, code string) (*source.Token, error) {
+	return nil, fmt.Errorf("source %q: authentication: %w", r.id, source.ErrNotSupported)
+}
+
+// SetAPIKey provides the token resolved at startup (env var or token store).
+func (r *Releases) SetAPIKey(key string) { r.apiKey = key }
+
+// IsAuthenticated reports whether a token is configured.
+func (r *Releases) IsAuthenticated() bool { return r.apiKey != "" }
+
+// Capabilities implements source.CapabilityReporter. Releases carry no
+// dependency metadata; Auth reflects whether the definition declares an auth
+// block.
+func (r *Releases) Capabilities() source.Capabilities {
+	return source.Capabilities{Search: true, Dependencies: false, Updates: true, Auth: r.auth != nil, Versions: true}
+}
+
+// TypeLabel implements source.TypeLabeler.
+func (r *Releases) TypeLabel() string { return "releases" }
+
+// GetDependencies implements source.ModSource; releases have no dependency
+// metadata.
+func (r *Releases) GetDependencies(ctx context.Context, mod *domain.Mod) ([]domain.ModReference, error) {
+	return nil, fmt.Errorf("source %q: dependencies: %w", r.id, source.ErrNotSupported)
+}
+
+// authHeaderValue returns the header-mode value for the token. GitHub and
+// Gitea both expect a scheme on Authorization, so a bare token configured
+// for that header is sent as a bearer token.
+func (r *Releases) authHeaderValue() string {
+	if strings.EqualFold(r.auth.APIKey.Name, "Authorization") && !strings.Contains(r.apiKey, " ") {
+		return "Bearer " + r.apiKey
+	}
+	return r.apiKey
+}
+
+// getJSON performs an authenticated GET of path under the API root and
+// decodes the response into out, with the same status mapping, size cap and
+// URL redaction as the api source.
+func (r *Releases) getJSON(ctx context.Context, path string, out any) error {
+	rawURL := r.baseURL + path
+	reqURL := rawURL
+	if r.auth != nil && r.auth.APIKey.In == "query" && r.apiKey != "" {
+		u, err := addQueryParam(reqURL, r.auth.APIKey.Name, r.apiKey)
+		if err != nil {
+			return fmt.Errorf("source %q: %w", r.id, err)
+		}
+		reqURL = u
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fmt.Errorf("source %q: building request: %w", r.id, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if r.auth != nil && r.auth.APIKey.In == "header" && r.apiKey != "" {
+		req.Header.Set(r.auth.APIKey.Name, r.authHeaderValue())
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			err = uerr.Err // strip the URL (and any query-mode key) from the message
+		}
+		return fmt.Errorf("source %q: requesting %s: %w", r.id, redactedURL(rawURL), err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return fmt.Errorf("source %q: %w", r.id, domain.ErrAuthRequired)
+	case http.StatusNotFound:
+		return fmt.Errorf("source %q: %w: %s", r.id, domain.ErrModNotFound, redactedURL(rawURL))
+	default:
+		return fmt.Errorf("source %q: requesting %s: HTTP %d", r.id, redactedURL(rawURL), resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxAPIResponseSize+1))
+	if err != nil {
+		return fmt.Errorf("source %q: reading response: %w", r.id, err)
+	}
+	if len(data) > maxAPIResponseSize {
+		return fmt.Errorf("source %q: response from %s exceeds %d bytes", r.id, redactedURL(rawURL), maxAPIResponseSize)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("source %q: parsing response from %s: %w", r.id, redactedURL(rawURL), err)
+	}
+	return nil
+}
+
+// repoFor returns the configured owner/repo matching modID, compared
+// case-insensitively as both providers do. Mods outside the configured list
+// are not served.
+func (r *Releases) repoFor(modID string) (string, error) {
+	for _, repo := range r.repos {
+		if strings.EqualFold(repo, modID) {
+			return repo, nil
+		}
+	}
+	return "", fmt.Errorf("source %q: %w: %s", r.id, domain.ErrModNotFound, modID)
+}
+
+// listReleases returns the repository's published releases, newest first,
+// without drafts and — unless prereleases are opted into — prereleases.
+func (r *Releases) listReleases(ctx context.Context, repo string) ([]ghRelease, error) {
+	query := "?per_page=100"
+	if r.provider == ProviderGitea {
+		query = "?limit=50"
+	}
+	var all []ghRelease
+	if err := r.getJSON(ctx, "/repos/"+repo+"/releases"+query, &all); err != nil {
+		return nil, err
+	}
+	releases := all[:0]
+	for _, rel := range all {
+		if rel.Draft || (rel.Prerelease && !r.prereleases) {
+			continue
+		}
+		releases = append(releases, rel)
+	}
+	return releases, nil
+}
+
+// assets returns the release's assets whose names match the asset pattern.
+func (r *Releases) assets(rel ghRelease) []ghAsset {
+	var out []ghAsset
+	for _, a := range rel.Assets {
+		if r.asset.MatchString(a.Name) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Search implements source.ModSource with the shared client-side semantics
+// over the configured repositories. Repositories without a published
+// release are left out.
+func (r *Releases) Search(ctx context.Context, query source.SearchQuery) (source.SearchResult, error) {
+	mods := make([]domain.Mod, 0, len(r.repos))
+	for _, repo := range r.repos {
+		mod, err := r.GetMod(ctx, query.GameID, repo)
+		if errors.Is(err, domain.ErrModNotFound) {
+			continue
+		}
+		if err != nil {
+			return source.SearchResult{}, fmt.Errorf("searching: %w", err)
+		}
+		mods = append(mods, *mod)
+	}
+	return searchMods(mods, query), nil
+}
+
+// GetMod implements source.ModSource: the repository's metadata, versioned
+// by its newest release. gameID is echoed onto the returned mod; every
+// configured repository applies to every game.
+func (r *Releases) GetMod(ctx context.Context, gameID, modID string) (*domain.Mod, error) {
+	repo, err := r.repoFor(modID)
+	if err != nil {
+		return nil, err
+	}
+	var meta ghRepository
+	if err := r.getJSON(ctx, "/repos/"+repo, &meta); err != nil {
+		return nil, fmt.Errorf("fetching mod %s: %w", repo, err)
+	}
+	releases, err := r.listReleases(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("fetching mod %s: %w", repo, err)
+	}
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("source %q: %w: %s has no releases", r.id, domain.ErrModNotFound, repo)
+	}
+
+	latest := releases[0]
+	var downloads int64
+	for _, rel := range releases {
+		for _, a := range r.assets(rel) {
+			downloads += a.DownloadCount
+		}
+	}
+	mod := &domain.Mod{
+		ID:        repo,
+		SourceID:  r.id,
+		Name:      meta.Name,
+		Version:   latest.TagName,
+		Author:    meta.Owner.Login,
+		Summary:   meta.Description,
+		GameID:    gameID,
+		Downloads: downloads,
+		SourceURL: meta.HTMLURL,
+		UpdatedAt: latest.PublishedAt,
+	}
+	return mod, nil
+}
+
+// GetModFiles implements source.ModSource: one file per matching asset of
+// every release, versioned by the release tag, newest first. Only the first
+// matching asset of the newest release is primary; a pattern matching
+// several assets per release (one per platform, say) should be tightened.
+// GitHub's asset digests become SHA256 checksums.
+func (r *Releases) GetModFiles(ctx context.Context, mod *domain.Mod) ([]domain.DownloadableFile, error) {
+	repo, err := r.repoFor(mod.ID)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := r.listReleases(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("listing files for %s: %w", repo, err)
+	}
+
+	var files []domain.DownloadableFile
+	for i, rel := range releases {
+		category := "Release"
+		if rel.Prerelease {
+			category = "Prerelease"
+		}
+		for _, a := range r.assets(rel) {
+			sha256, _ := strings.CutPrefix(a.Digest, "sha256:")
+			if sha256 == a.Digest {
+				sha256 = "" // no digest, or one in another algorithm
+			}
+			files = append(files, domain.DownloadableFile{
+				ID:          strconv.FormatInt(a.ID, 10),
+				Name:        a.Name,
+				FileName:    a.Name,
+				Version:     rel.TagName,
+				Size:        a.Size,
+				IsPrimary:   i == 0 && len(files) == 0,
+				Category:    category,
+				Description: rel.Name,
+				SHA256:      sha256,
+			})
+		}
+	}
+	return files, nil
+}
+
+// GetDownloadURL implements source.ModSource. With a token on GitHub the
+// asset's API URL is returned — the only way to download from a private
+// repository — and DownloadHeaders asks it for the bytes; otherwise the
+// public browser download URL is used. Query-mode tokens are appended only
+// for same-origin URLs (design §9).
+func (r *Releases) GetDownloadURL(ctx context.Context, mod *domain.Mod, fileID string) (string, error) {
+	repo, err := r.repoFor(mod.ID)
+	if err != nil {
+		return "", err
+	}
+	releases, err := r.listReleases(ctx, repo)
+	if err != nil {
+		return "", fmt.Errorf("download URL for file %s: %w", fileID, err)
+	}
+	for _, rel := range releases {
+		for _, a := range rel.Assets {
+			if strconv.FormatInt(a.ID, 10) != fileID {
+				continue
+			}
+			dlURL := a.BrowserDownloadURL
+			if r.provider == ProviderGitHub && r.apiKey != "" && a.URL != "" {
+				dlURL = a.URL
+			}
+			if r.auth != nil && r.auth.APIKey.In == "query" && r.apiKey != "" && sameOriginURLs(dlURL, r.baseURL) {
+				withKey, err := addQueryParam(dlURL, r.auth.APIKey.Name, r.apiKey)
+				if err != nil {
+					return "", fmt.Errorf("source %q: file %s: %w", r.id, fileID, err)
+				}
+				dlURL = withKey
+			}
+			return dlURL, nil
+		}
+	}
+	return "", fmt.Errorf("source %q: mod %q: file not found: %s", r.id, repo, fileID)
+}
+
+// DownloadHeaders implements source.DownloadHeaderProvider: header-mode
+// tokens go only to downloads on the API's own origin (design §9). GitHub's
+// asset API URLs serve JSON unless asked for the bytes.
+func (r *Releases) DownloadHeaders(fileURL string) map[string]string {
+	if !sameOriginURLs(fileURL, r.baseURL) {
+		return nil
+	}
+	headers := map[string]string{}
+	if r.provider == ProviderGitHub {
+		headers["Accept"] = "application/octet-stream"
+	}
+	if r.auth != nil && r.auth.APIKey.In == "header" && r.apiKey != "" {
+		headers[r.auth.APIKey.Name] = r.authHeaderValue()
+	}
+	if len(headers) == 0 {
+		return nil
+	}
+	return headers
+}
+
+// CheckUpdates implements source.ModSource: a mod whose newest release tag
+// is newer than its installed version has an update, with the notes of every
+// release since the installed one as its changelog. Per-mod failures are
+// collected and returned alongside partial results.
+func (r *Releases) CheckUpdates(ctx context.Context, installed []domain.InstalledMod) ([]domain.Update, error) {
+	var updates []domain.Update
+	var errs []error
+	for _, inst := range installed {
+		select {
+		case <-ctx.Done():
+			return updates, ctx.Err()
+		default:
+		}
+		repo, err := r.repoFor(inst.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		releases, err := r.listReleases(ctx, repo)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", repo, err))
+			continue
+		}
+		if len(releases) == 0 || !domain.IsNewerVersion(inst.Version, releases[0].TagName) {
+			continue
+		}
+		updates = append(updates, domain.Update{
+			InstalledMod: inst,
+			NewVersion:   releases[0].TagName,
+			Changelog:    releaseNotesSince(releases, inst.Version),
+		})
+	}
+	return updates, errors.Join(errs...)
+}
+
+// releaseNotesSince joins the notes of the releases newer than version,
+// newest first, each under its tag.
+func releaseNotesSince(releases []ghRelease, version string) string {
+	var sections []string
+	for _, rel := range releases {
+		if rel.TagName == version || !domain.IsNewerVersion(version, rel.TagName) {
+			break
+		}
+		body := strings.TrimSpace(rel.Body)
+		if body == "" {
+			continue
+		}
+		sections = append(sections, "## "+rel.TagName+"\n\n"+body)
+	}
+	return strings.Join(sections, "\n\n")
+}
+
+var (
+	_ source.ModSource              = (*Releases)(nil)
+	_ source.CapabilityReporter     = (*Releases)(nil)
+	_ source.DownloadHeaderProvider = (*Releases)(nil)
+)
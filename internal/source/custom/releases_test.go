@@ -0,0 +1,221 @@
+package custom
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testReleasesJSON = `[
+	{"tag_name": "v3.0.0", "draft": true, "assets": [{"id": 30, "name": "tool-linux.zip"}]},
+	{"tag_name": "v2.0.0-rc1", "name": "RC", "body": "Try me.", "prerelease": true,
+	 "assets": [{"id": 20, "name": "tool-linux.zip", "browser_download_url": "https://dl.test/20"}]},
+	{"tag_name": "v1.1.0", "name": "One One", "body": "Fixed things.", "published_at": "2026-02-01T00:00:00Z",
+	 "assets": [
+		{"id": 11, "name": "tool-linux.zip", "size": 100, "download_count": 5,
+		 "browser_download_url": "https://dl.test/11", "url": "%s/repos/Owner/Tool/releases/assets/11",
+		 "digest": "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"},
+		{"id": 12, "name": "tool-windows.zip", "size": 100, "download_count": 50}
+	 ]},
+	{"tag_name": "v1.0.0", "body": "First.",
+	 "assets": [{"id": 10, "name": "tool-linux.zip", "download_count": 1, "digest": "md5:abc"}]}
+]`
+
+// releasesServer serves one repository, Owner/Tool, recording each request.
+func releasesServer(t *testing.T, requests *[]*http.Request) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests != nil {
+			*requests = append(*requests, r)
+		}
+		switch r.URL.Path {
+		case "/repos/Owner/Tool":
+			_, _ = w.Write([]byte(`{"name": "Tool", "description": "A modding tool", "html_url": "https://git.test/Owner/Tool", "owner": {"login": "Owner"}}`))
+		case "/repos/Owner/Tool/releases":
+			_, _ = w.Write([]byte(strings.ReplaceAll(testReleasesJSON, "%s", srv.URL)))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func releasesDef(baseURL string) SourceDefinition {
+	return SourceDefinition{
+		ID:        "tools",
+		Name:      "Tools",
+		Type:      TypeReleases,
+		AllowHTTP: true, // httptest serves plain http
+		Releases: &ReleasesConfig{
+			BaseURL: baseURL,
+			Repos:   []string{"Owner/Tool"},
+			Asset:   `linux`,
+		},
+	}
+}
+
+func TestReleasesIdentityAndCapabilities(t *testing.T) {
+	def := releasesDef("")
+	r, err := NewReleases(def)
+	require.NoError(t, err)
+	assert.Equal(t, defaultGitHubAPI, r.baseURL)
+	assert.Equal(t, ProviderGitHub, r.provider)
+	assert.Equal(t, "releases", r.TypeLabel())
+	assert.Equal(t, source.Capabilities{Search: true, Dependencies: false, Updates: true, Auth: false, Versions: true}, r.Capabilities())
+
+	_, err = r.GetDependencies(context.Background(), &domain.Mod{ID: "Owner/Tool"})
+	assert.ErrorIs(t, err, source.ErrNotSupported)
+
+	def.Releases.Auth = &AuthConfig{APIKey: &APIKeyConfig{In: "header", Name: "Authorization"}}
+	authed, err := NewReleases(def)
+	require.NoError(t, err)
+	assert.True(t, authed.Capabilities().Auth)
+}
+
+func TestReleasesGetMod(t *testing.T) {
+	srv := releasesServer(t, nil)
+	r, err := NewReleases(releasesDef(srv.URL))
+	require.NoError(t, err)
+
+	mod, err := r.GetMod(context.Background(), "game", "owner/tool")
+	require.NoError(t, err)
+	assert.Equal(t, "Owner/Tool", mod.ID, "IDs resolve to the configured spelling")
+	assert.Equal(t, "tools", mod.SourceID)
+	assert.Equal(t, "Tool", mod.Name)
+	assert.Equal(t, "Owner", mod.Author)
+	assert.Equal(t, "A modding tool", mod.Summary)
+	assert.Equal(t, "v1.1.0", mod.Version, "drafts and prereleases are skipped")
+	assert.Equal(t, int64(6), mod.Downloads, "only matching assets count")
+	assert.Equal(t, "game", mod.GameID)
+
+	_, err = r.GetMod(context.Background(), "game", "Other/Repo")
+	assert.ErrorIs(t, err, domain.ErrModNotFound, "unconfigured repositories are not served")
+}
+
+func TestReleasesPrereleasesOptIn(t *testing.T) {
+	srv := releasesServer(t, nil)
+	def := releasesDef(srv.URL)
+	def.Releases.Prereleases = true
+	r, err := NewReleases(def)
+	require.NoError(t, err)
+
+	mod, err := r.GetMod(context.Background(), "", "Owner/Tool")
+	require.NoError(t, err)
+	assert.Equal(t, "v2.0.0-rc1", mod.Version)
+}
+
+func TestReleasesGetModFiles(t *testing.T) {
+	srv := releasesServer(t, nil)
+	r, err := NewReleases(releasesDef(srv.URL))
+	require.NoError(t, err)
+
+	files, err := r.GetModFiles(context.Background(), &domain.Mod{ID: "Owner/Tool"})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, "11", files[0].ID)
+	assert.Equal(t, "tool-linux.zip", files[0].FileName)
+	assert.Equal(t, "v1.1.0", files[0].Version)
+	assert.Equal(t, "Release", files[0].Category)
+	assert.True(t, files[0].IsPrimary)
+	assert.Equal(t, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", files[0].SHA256)
+
+	assert.Equal(t, "10", files[1].ID)
+	assert.Equal(t, "v1.0.0", files[1].Version)
+	assert.False(t, files[1].IsPrimary)
+	assert.Empty(t, files[1].SHA256, "non-sha256 digests are not checksums we verify")
+}
+
+func TestReleasesDownloads(t *testing.T) {
+	var requests []*http.Request
+	srv := releasesServer(t, &requests)
+	def := releasesDef(srv.URL)
+	def.Releases.Auth = &AuthConfig{APIKey: &APIKeyConfig{In: "header", Name: "Authorization"}}
+	r, err := NewReleases(def)
+	require.NoError(t, err)
+	mod := &domain.Mod{ID: "Owner/Tool"}
+
+	t.Run("anonymous downloads use the browser URL", func(t *testing.T) {
+		u, err := r.GetDownloadURL(context.Background(), mod, "11")
+		require.NoError(t, err)
+		assert.Equal(t, "https://dl.test/11", u)
+		assert.Nil(t, r.DownloadHeaders(u))
+	})
+
+	t.Run("token downloads go through the API", func(t *testing.T) {
+		r.SetAPIKey("tok")
+		defer r.SetAPIKey("")
+
+		u, err := r.GetDownloadURL(context.Background(), mod, "11")
+		require.NoError(t, err)
+		assert.Equal(t, srv.URL+"/repos/Owner/Tool/releases/assets/11", u)
+		assert.Equal(t, map[string]string{
+			"Accept":        "application/octet-stream",
+			"Authorization": "Bearer tok",
+		}, r.DownloadHeaders(u))
+		assert.Nil(t, r.DownloadHeaders("https://dl.test/11"), "tokens stay on the API origin")
+		assert.Equal(t, "Bearer tok", requests[len(requests)-1].Header.Get("Authorization"))
+	})
+
+	t.Run("unknown asset", func(t *testing.T) {
+		_, err := r.GetDownloadURL(context.Background(), mod, "999")
+		assert.ErrorContains(t, err, "file not found")
+	})
+}
+
+func TestReleasesCheckUpdates(t *testing.T) {
+	srv := releasesServer(t, nil)
+	r, err := NewReleases(releasesDef(srv.URL))
+	require.NoError(t, err)
+
+	updates, err := r.CheckUpdates(context.Background(), []domain.InstalledMod{
+		{Mod: domain.Mod{ID: "Owner/Tool", Version: "v1.0.0"}},
+		{Mod: domain.Mod{ID: "Owner/Tool", Version: "v1.1.0"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, "v1.1.0", updates[0].NewVersion)
+	assert.Equal(t, "## v1.1.0\n\nFixed things.", updates[0].Changelog)
+}
+
+func TestReleasesSearch(t *testing.T) {
+	srv := releasesServer(t, nil)
+	def := releasesDef(srv.URL)
+	def.Releases.Repos = append(def.Releases.Repos, "Owner/Gone")
+	r, err := NewReleases(def)
+	require.NoError(t, err)
+
+	res, err := r.Search(context.Background(), source.SearchQuery{GameID: "game", Query: "modding"})
+	require.NoError(t, err)
+	require.Len(t, res.Mods, 1, "a repository that 404s is left out")
+	assert.Equal(t, "Owner/Tool", res.Mods[0].ID)
+	assert.Equal(t, "game", res.Mods[0].GameID)
+
+	res, err = r.Search(context.Background(), source.SearchQuery{Query: "nothing"})
+	require.NoError(t, err)
+	assert.Empty(t, res.Mods)
+}
+
+func TestReleasesGiteaPaging(t *testing.T) {
+	var requests []*http.Request
+	srv := releasesServer(t, &requests)
+	def := releasesDef(srv.URL)
+	def.Releases.Provider = ProviderGitea
+	r, err := NewReleases(def)
+	require.NoError(t, err)
+
+	_, err = r.GetModFiles(context.Background(), &domain.Mod{ID: "Owner/Tool"})
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "50", requests[0].URL.Query().Get("limit"))
+	assert.Nil(t, r.DownloadHeaders(srv.URL+"/x"), "Gitea downloads need no Accept override")
+}
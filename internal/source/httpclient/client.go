@@ -6,11 +6,15 @@
 package httpclient
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/DonovanMods/linux-mod-manager/internal/domain"
 )
@@ -20,9 +24,9 @@ import (
 // for an actionable error message and bounds memory use.
 const errorBodyLimit = 10 * 1024
 
-// Options configures a Client. BaseURL, AuthHeader, and AuthLabel are
-// required and validated by New (which panics on omission); the rest have
-// sensible zero-value defaults.
+// Options configures a Client. BaseURL, AuthLabel, and one of AuthHeader or
+// AuthQuery are required and validated by New (which panics on omission);
+// the rest have sensible zero-value defaults.
 type Options struct {
 	HTTPClient *http.Client
 	BaseURL    string
@@ -30,9 +34,16 @@ type Options struct {
 	// AuthHeader is the request header used to forward APIKey, e.g. "apikey"
 	// (NexusMods) or "x-api-key" (CurseForge).
 	AuthHeader string
+	// AuthQuery, when set, forwards APIKey as this query parameter instead
+	// of a header, e.g. "api_key" (mod.io). Request errors then never echo
+	// the request URL, which would carry the key.
+	AuthQuery string
 	// AuthLabel is the human-readable source name interpolated into the
 	// "<label> API key required" error returned on 401.
 	AuthLabel string
+	// UserAgent, when set, is sent on every request. Some APIs (Modrinth)
+	// ask clients to identify themselves and throttle generic agents.
+	UserAgent string
 	// ErrorMapper, when set, is consulted before the default non-2xx mapping.
 	// Return nil to defer to the default; return a non-nil error to short-
 	// circuit (e.g. translate 404 to a domain error).
@@ -46,20 +57,22 @@ type Client struct {
 	baseURL     string
 	apiKey      string
 	authHeader  string
+	authQuery   string
 	authLabel   string
+	userAgent   string
 	errorMapper func(int, []byte, string) error
 }
 
 // New returns a Client configured with opts. Panics when a required field
-// (BaseURL, AuthHeader, AuthLabel) is empty — the package is internal and
+// (BaseURL, AuthHeader or AuthQuery, AuthLabel) is empty — the package is internal and
 // only ever constructed at startup, so a missing required field is a
 // programming error worth catching loudly.
 func New(opts Options) *Client {
 	if opts.BaseURL == "" {
 		panic("httpclient.New: BaseURL is required")
 	}
-	if opts.AuthHeader == "" {
-		panic("httpclient.New: AuthHeader is required")
+	if opts.AuthHeader == "" && opts.AuthQuery == "" {
+		panic("httpclient.New: AuthHeader or AuthQuery is required")
 	}
 	if opts.AuthLabel == "" {
 		panic("httpclient.New: AuthLabel is required")
@@ -73,7 +86,9 @@ func New(opts Options) *Client {
 		baseURL:     opts.BaseURL,
 		apiKey:      opts.APIKey,
 		authHeader:  opts.AuthHeader,
+		authQuery:   opts.AuthQuery,
 		authLabel:   opts.AuthLabel,
+		userAgent:   opts.UserAgent,
 		errorMapper: opts.ErrorMapper,
 	}
 }
@@ -97,22 +112,55 @@ func (c *Client) BaseURL() string { return c.baseURL }
 func (c *Client) HTTPClient() *http.Client { return c.httpClient }
 
 // DoJSON performs an HTTP request against baseURL+path and JSON-decodes the
-// response body into result. Auth header is set when an APIKey is configured.
+// response body into result. The API key, when configured, rides in the auth
+// header or query parameter.
 // Non-2xx responses are first offered to ErrorMapper; if ErrorMapper returns
 // nil (or is unset), 401 is mapped to domain.ErrAuthRequired and other
 // statuses are surfaced as "API error (status N): <body>".
-func (c *Client) DoJSON(ctx context.Context, method, path string, result interface{}) (err error) {
-	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, nil)
+func (c *Client) DoJSON(ctx context.Context, method, path string, result interface{}) error {
+	return c.DoJSONBody(ctx, method, path, nil, result)
+}
+
+// DoJSONBody is DoJSON with a request body: a non-nil body is JSON-encoded
+// and sent with a JSON Content-Type (e.g. Modrinth's bulk POST lookups).
+func (c *Client) DoJSONBody(ctx context.Context, method, path string, body, result interface{}) (err error) {
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+	reqURL := c.baseURL + path
+	if c.authQuery != "" && c.apiKey != "" {
+		sep := "?"
+		if strings.Contains(path, "?") {
+			sep = "&"
+		}
+		reqURL += sep + url.QueryEscape(c.authQuery) + "=" + url.QueryEscape(c.apiKey)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
-	if c.apiKey != "" {
+	if c.apiKey != "" && c.authQuery == "" {
 		req.Header.Set(c.authHeader, c.apiKey)
 	}
 	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		var uerr *url.Error
+		if c.authQuery != "" && errors.As(err, &uerr) {
+			err = uerr.Err // strip the URL, and with it the key
+		}
 		return fmt.Errorf("executing request: %w", err)
 	}
 	defer func() {
@@ -2,6 +2,7 @@ package httpclient_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -177,13 +178,72 @@ func TestDoJSON_204NoContentSucceedsWithoutDecode(t *testing.T) {
 	require.NoError(t, c.DoJSON(context.Background(), http.MethodDelete, "/thing", &out))
 }
 
+func TestDoJSONBody_SendsJSONBodyAndUserAgent(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "lmm-test/1.0", r.Header.Get("User-Agent"))
+		var in struct{ Hashes []string }
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&in))
+		assert.Equal(t, []string{"abc"}, in.Hashes)
+		_, _ = w.Write([]byte(`{"id": 9}`))
+	}))
+	defer srv.Close()
+
+	c := httpclient.New(httpclient.Options{
+		BaseURL:    srv.URL,
+		AuthHeader: "apikey",
+		AuthLabel:  "Test",
+		UserAgent:  "lmm-test/1.0",
+	})
+
+	var out struct{ ID int }
+	require.NoError(t, c.DoJSONBody(context.Background(), http.MethodPost, "/lookup", map[string][]string{"hashes": {"abc"}}, &out))
+	assert.Equal(t, 9, out.ID)
+}
+
+func TestDoJSON_AuthQueryForwardsKeyAsParameter(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.URL.Query().Get("api_key"))
+		assert.Equal(t, "1", r.URL.Query().Get("page"), "existing query kept")
+		assert.Empty(t, r.Header.Get("api_key"))
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	c := httpclient.New(httpclient.Options{
+		BaseURL:   srv.URL,
+		APIKey:    "secret",
+		AuthQuery: "api_key",
+		AuthLabel: "Test",
+	})
+	var out struct{}
+	require.NoError(t, c.DoJSON(context.Background(), http.MethodGet, "/v1/things?page=1", &out))
+}
+
+func TestDoJSON_AuthQueryKeyNotInTransportErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close() // connection refused
+
+	c := httpclient.New(httpclient.Options{
+		BaseURL:   srv.URL,
+		APIKey:    "secret",
+		AuthQuery: "api_key",
+		AuthLabel: "Test",
+	})
+	var out struct{}
+	err := c.DoJSON(context.Background(), http.MethodGet, "/v1/things", &out)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
+
 func TestNew_PanicsOnMissingRequiredFields(t *testing.T) {
 	cases := []struct {
 		name string
 		opts httpclient.Options
 	}{
 		{"missing BaseURL", httpclient.Options{AuthHeader: "h", AuthLabel: "l"}},
-		{"missing AuthHeader", httpclient.Options{BaseURL: "https://x", AuthLabel: "l"}},
+		{"missing AuthHeader and AuthQuery", httpclient.Options{BaseURL: "https://x", AuthLabel: "l"}},
 		{"missing AuthLabel", httpclient.Options{BaseURL: "https://x", AuthHeader: "h"}},
 	}
 	for _, tc := range cases {
@@ -0,0 +1,242 @@
+// Package minisign reads and writes minisign (https://jedisct1.github.io/minisign/)
+// keys and detached signatures. Manifest custom sources use it to prove a
+// mod list came from its publisher: signatures made with the minisign tool
+// verify here, and signatures made here verify with minisign.
+//
+// Only unencrypted secret keys are supported for signing (minisign -G -W,
+// or GenerateKey); password-protected keys need scrypt, which lmm does not
+// carry.
+package minisign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrInvalidSignature reports a signature that does not verify against the
+// public key — the signed data, the trusted comment or the signature itself
+// was altered, or another key made it.
+var ErrInvalidSignature = errors.New("signature verification failed")
+
+// Algorithm tags. Legacy signatures sign the message itself; prehashed ones
+// (minisign's default since 0.8) sign its BLAKE2b-512 digest.
+const (
+	algLegacy    = "Ed"
+	algPrehashed = "ED"
+	kdfNone      = "\x00\x00"
+	kdfScrypt    = "Sc"
+	cksumBLAKE2b = "B2"
+)
+
+const (
+	untrustedPrefix = "untrusted comment: "
+	trustedPrefix   = "trusted comment: "
+)
+
+// KeyID identifies a key pair; signatures name the key that made them.
+type KeyID [8]byte
+
+// String renders the ID the way minisign prints it.
+func (id KeyID) String() string {
+	return fmt.Sprintf("%016X", binary.LittleEndian.Uint64(id[:]))
+}
+
+// PublicKey is a minisign public key.
+type PublicKey struct {
+	ID  KeyID
+	Key ed25519.PublicKey
+}
+
+// ParsePublicKey parses a public key from its base64 form (what minisign -P
+// takes and the second line of a .pub file), or from the whole .pub file.
+func ParsePublicKey(s string) (PublicKey, error) {
+	line := lastLine(s)
+	raw, err := base64.StdEncoding.DecodeString(line)
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize {
+		return PublicKey{}, errors.New("not a minisign public key")
+	}
+	if string(raw[:2]) != algLegacy {
+		return PublicKey{}, fmt.Errorf("unsupported public key algorithm %q", raw[:2])
+	}
+	var pk PublicKey
+	copy(pk.ID[:], raw[2:10])
+	pk.Key = ed25519.PublicKey(bytes.Clone(raw[10:]))
+	return pk, nil
+}
+
+// String returns the key's base64 form, as public_key takes it.
+func (k PublicKey) String() string {
+	raw := make([]byte, 0, 2+8+ed25519.PublicKeySize)
+	raw = append(raw, algLegacy...)
+	raw = append(raw, k.ID[:]...)
+	raw = append(raw, k.Key...)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+// MarshalText renders the key as a minisign .pub file.
+func (k PublicKey) MarshalText() ([]byte, error) {
+	return []byte(untrustedPrefix + "minisign public key " + k.ID.String() + "\n" + k.String() + "\n"), nil
+}
+
+// PrivateKey is an unencrypted minisign secret key.
+type PrivateKey struct {
+	ID  KeyID
+	Key ed25519.PrivateKey
+}
+
+// GenerateKey creates a key pair with a random key ID.
+func GenerateKey(rand io.Reader) (PrivateKey, error) {
+	var sk PrivateKey
+	if _, err := io.ReadFull(rand, sk.ID[:]); err != nil {
+		return PrivateKey{}, fmt.Errorf("generating key ID: %w", err)
+	}
+	_, key, err := ed25519.GenerateKey(rand)
+	if err != nil {
+		return PrivateKey{}, fmt.Errorf("generating key: %w", err)
+	}
+	sk.Key = key
+	return sk, nil
+}
+
+// Public returns the key pair's public half.
+func (k PrivateKey) Public() PublicKey {
+	return PublicKey{ID: k.ID, Key: k.Key.Public().(ed25519.PublicKey)}
+}
+
+// secretKeyLen is the decoded size of a secret key file's second line:
+// algorithms (6), KDF salt (32), KDF limits (16), key ID (8), key (64) and
+// checksum (32).
+const secretKeyLen = 2 + 2 + 2 + 32 + 8 + 8 + 8 + ed25519.PrivateKeySize + 32
+
+// ParsePrivateKey parses a minisign secret key file.
+func ParsePrivateKey(data []byte) (PrivateKey, error) {
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) < 2 {
+		return PrivateKey{}, errors.New("not a minisign secret key")
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(raw) != secretKeyLen {
+		return PrivateKey{}, errors.New("not a minisign secret key")
+	}
+	if string(raw[:2]) != algLegacy || string(raw[4:6]) != cksumBLAKE2b {
+		return PrivateKey{}, errors.New("unsupported secret key algorithm")
+	}
+	switch string(raw[2:4]) {
+	case kdfNone:
+	case kdfScrypt:
+		return PrivateKey{}, errors.New("password-protected secret keys are not supported; create an unencrypted one with 'minisign -G -W'")
+	default:
+		return PrivateKey{}, fmt.Errorf("unsupported secret key KDF %q", raw[2:4])
+	}
+
+	keynum := raw[2+2+2+32+8+8:]
+	var sk PrivateKey
+	copy(sk.ID[:], keynum[:8])
+	sk.Key = ed25519.PrivateKey(bytes.Clone(keynum[8 : 8+ed25519.PrivateKeySize]))
+	if !bytes.Equal(keynum[8+ed25519.PrivateKeySize:], sk.checksum()) {
+		return PrivateKey{}, errors.New("secret key checksum mismatch")
+	}
+	return sk, nil
+}
+
+// checksum is minisign's BLAKE2b-256 over the algorithm, key ID and key.
+func (k PrivateKey) checksum() []byte {
+	buf := make([]byte, 0, 2+8+ed25519.PrivateKeySize)
+	buf = append(buf, algLegacy...)
+	buf = append(buf, k.ID[:]...)
+	buf = append(buf, k.Key...)
+	return blake2b(buf, 32)
+}
+
+// MarshalText renders the key as an unencrypted minisign secret key file.
+func (k PrivateKey) MarshalText() ([]byte, error) {
+	raw := make([]byte, 0, secretKeyLen)
+	raw = append(raw, algLegacy+kdfNone+cksumBLAKE2b...)
+	raw = append(raw, make([]byte, 32+8+8)...) // no KDF: zero salt and limits
+	raw = append(raw, k.ID[:]...)
+	raw = append(raw, k.Key...)
+	raw = append(raw, k.checksum()...)
+	return []byte(untrustedPrefix + "minisign secret key (unencrypted)\n" + base64.StdEncoding.EncodeToString(raw) + "\n"), nil
+}
+
+// Sign returns a prehashed detached signature of message, in .minisig file
+// form. trustedComment is covered by the signature; it must be one line.
+func Sign(key PrivateKey, message []byte, trustedComment string) ([]byte, error) {
+	if strings.ContainsAny(trustedComment, "\r\n") {
+		return nil, errors.New("trusted comment must be a single line")
+	}
+	sig := ed25519.Sign(key.Key, blake2b(message, 64))
+
+	line := make([]byte, 0, 2+8+ed25519.SignatureSize)
+	line = append(line, algPrehashed...)
+	line = append(line, key.ID[:]...)
+	line = append(line, sig...)
+	global := ed25519.Sign(key.Key, append(bytes.Clone(sig), trustedComment...))
+
+	var b strings.Builder
+	b.WriteString(untrustedPrefix + "signature from minisign secret key\n")
+	b.WriteString(base64.StdEncoding.EncodeToString(line) + "\n")
+	b.WriteString(trustedPrefix + trustedComment + "\n")
+	b.WriteString(base64.StdEncoding.EncodeToString(global) + "\n")
+	return []byte(b.String()), nil
+}
+
+// Verify checks a .minisig signature of message against key and returns its
+// trusted comment. A malformed signature file is an error; one that parses
+// but does not verify wraps ErrInvalidSignature.
+func Verify(key PublicKey, message, signature []byte) (string, error) {
+	lines := strings.Split(strings.TrimRight(string(signature), "\r\n"), "\n")
+	if len(lines) != 4 {
+		return "", errors.New("not a minisign signature")
+	}
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+	raw, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(raw) != 2+8+ed25519.SignatureSize {
+		return "", errors.New("not a minisign signature")
+	}
+	trusted, ok := strings.CutPrefix(lines[2], trustedPrefix)
+	if !ok {
+		return "", errors.New("signature has no trusted comment")
+	}
+	global, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return "", errors.New("not a minisign signature")
+	}
+
+	var id KeyID
+	copy(id[:], raw[2:10])
+	if id != key.ID {
+		return "", fmt.Errorf("%w: signed by key %s, not %s", ErrInvalidSignature, id, key.ID)
+	}
+	sig := raw[10:]
+
+	signed := message
+	switch string(raw[:2]) {
+	case algLegacy:
+	case algPrehashed:
+		signed = blake2b(message, 64)
+	default:
+		return "", fmt.Errorf("unsupported signature algorithm %q", raw[:2])
+	}
+	if !ed25519.Verify(key.Key, signed, sig) {
+		return "", ErrInvalidSignature
+	}
+	if !ed25519.Verify(key.Key, append(bytes.Clone(sig), trusted...), global) {
+		return "", fmt.Errorf("%w: trusted comment was altered", ErrInvalidSignature)
+	}
+	return trusted, nil
+}
+
+// lastLine returns the last non-empty line of s, trimmed.
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,129 @@
+package minisign
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBLAKE2b(t *testing.T) {
+	tests := []struct {
+		in     []byte
+		sum512 string
+		sum256 string
+	}{
+		{nil, "786a02f742015903c6c6fd852552d272912f4740e15847618a86e217f71f5419d25e1031afee585313896444934eb04b903a685b1448b755d56f701afe9be2ce", "0e5751c026e543b2e8ab2eb06099daa1d1e5df47778f7787faab45cdf12fe3a8"},
+		{[]byte("abc"), "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923", "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319"},
+		{bytes.Repeat([]byte("a"), 128), "fc6c71f688f43ea7d60817478808f3cac753e61571865c95adbc2d9122c943a76b92c2cb1047ef3fe7bf6e436ec1d0a99a9e5b216780bf7fed9d7ca91d3a8f3b", "ae2aa48507885c4c950fb809b2076f959cde9f8ea6da260d9a3587df33dac450"},
+		{bytes.Repeat([]byte("a"), 129), "55e6e0eb418149a8af92fd9ddc99254781b2f522a131b4f4d984404b71a00e1167b8124d5dcddd4c6977b299392335d6edd303da6d344d74bbef2d38101b232b", "2f64744a6de0d2c0b56e64cf6e29a5aaa255010d415d51c75ccc82f73dccd865"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.sum512, hex.EncodeToString(blake2b(tt.in, 64)), "len %d", len(tt.in))
+		assert.Equal(t, tt.sum256, hex.EncodeToString(blake2b(tt.in, 32)), "len %d", len(tt.in))
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	// The example key from minisign's documentation.
+	const doc = "RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3"
+
+	pk, err := ParsePublicKey(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "E7620F1842B4E81F", pk.ID.String())
+	assert.Equal(t, doc, pk.String())
+
+	file, err := pk.MarshalText()
+	require.NoError(t, err)
+	fromFile, err := ParsePublicKey(string(file))
+	require.NoError(t, err, "a whole .pub file parses too")
+	assert.Equal(t, pk, fromFile)
+
+	_, err = ParsePublicKey("not base64!")
+	assert.Error(t, err)
+	_, err = ParsePublicKey(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Error(t, err)
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	sk, err := GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	msg := []byte("version: 1\nmods: []\n")
+
+	sig, err := Sign(sk, msg, "timestamp:1767225600\tfile:mods.yaml")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(sig), "untrusted comment: "))
+
+	trusted, err := Verify(sk.Public(), msg, sig)
+	require.NoError(t, err)
+	assert.Equal(t, "timestamp:1767225600\tfile:mods.yaml", trusted)
+
+	_, err = Verify(sk.Public(), []byte("version: 1\nmods: [evil]\n"), sig)
+	assert.ErrorIs(t, err, ErrInvalidSignature, "altered message")
+
+	tampered := strings.Replace(string(sig), "file:mods.yaml", "file:other.yaml", 1)
+	_, err = Verify(sk.Public(), msg, []byte(tampered))
+	assert.ErrorIs(t, err, ErrInvalidSignature, "altered trusted comment")
+
+	other, err := GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	_, err = Verify(other.Public(), msg, sig)
+	assert.ErrorIs(t, err, ErrInvalidSignature, "another key")
+	assert.ErrorContains(t, err, sk.ID.String())
+
+	_, err = Verify(sk.Public(), msg, []byte("garbage"))
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrInvalidSignature, "a malformed file is not a verification failure")
+
+	_, err = Sign(sk, msg, "two\nlines")
+	assert.Error(t, err)
+}
+
+// TestVerifyLegacySignature covers signatures made with minisign -l, which
+// sign the message itself rather than its BLAKE2b-512 digest.
+func TestVerifyLegacySignature(t *testing.T) {
+	sk, err := GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	msg := []byte("legacy")
+
+	sig := ed25519.Sign(sk.Key, msg)
+	line := append(append([]byte("Ed"), sk.ID[:]...), sig...)
+	global := ed25519.Sign(sk.Key, append(bytes.Clone(sig), "c"...))
+	file := "untrusted comment: x\n" + base64.StdEncoding.EncodeToString(line) +
+		"\ntrusted comment: c\n" + base64.StdEncoding.EncodeToString(global) + "\n"
+
+	trusted, err := Verify(sk.Public(), msg, []byte(file))
+	require.NoError(t, err)
+	assert.Equal(t, "c", trusted)
+}
+
+func TestPrivateKeyRoundTrip(t *testing.T) {
+	sk, err := GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	file, err := sk.MarshalText()
+	require.NoError(t, err)
+	parsed, err := ParsePrivateKey(file)
+	require.NoError(t, err)
+	assert.Equal(t, sk, parsed)
+
+	lines := strings.Split(string(file), "\n")
+	raw, err := base64.StdEncoding.DecodeString(lines[1])
+	require.NoError(t, err)
+
+	corrupt := bytes.Clone(raw)
+	corrupt[len(corrupt)-40] ^= 1 // inside the key, so the checksum no longer matches
+	_, err = ParsePrivateKey([]byte(lines[0] + "\n" + base64.StdEncoding.EncodeToString(corrupt)))
+	assert.ErrorContains(t, err, "checksum")
+
+	encrypted := bytes.Clone(raw)
+	copy(encrypted[2:4], "Sc")
+	_, err = ParsePrivateKey([]byte(lines[0] + "\n" + base64.StdEncoding.EncodeToString(encrypted)))
+	assert.ErrorContains(t, err, "password-protected")
+}
@@ -0,0 +1,187 @@
+package modio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/httpclient"
+)
+
+const (
+	defaultBaseURL = "https://api.mod.io/v1"
+
+	// maxPageSize is the largest _limit mod.io accepts.
+	maxPageSize = 100
+)
+
+// Client wraps the mod.io REST API v1. Read endpoints take the API key as
+// the api_key query parameter, and every endpoint needs one.
+type Client struct {
+	httpClient *http.Client
+	rest       *httpclient.Client
+	apiKey     string
+}
+
+// NewClient creates a new mod.io API client
+func NewClient(httpClient *http.Client, apiKey string) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	c := &Client{
+		httpClient: httpClient,
+		apiKey:     apiKey,
+	}
+	c.rest = httpclient.New(httpclient.Options{
+		HTTPClient:  httpClient,
+		BaseURL:     defaultBaseURL,
+		APIKey:      apiKey,
+		AuthQuery:   "api_key",
+		AuthLabel:   "mod.io",
+		ErrorMapper: c.mapError,
+	})
+	return c
+}
+
+// SetAPIKey sets the API key for authentication
+func (c *Client) SetAPIKey(key string) {
+	c.apiKey = key
+	c.rest.SetAPIKey(key)
+}
+
+// SetBaseURL overrides the REST API base URL — primarily used by tests that
+// front the client with an httptest server.
+func (c *Client) SetBaseURL(u string) {
+	c.rest.SetBaseURL(u)
+}
+
+// IsAuthenticated returns true if an API key is configured
+func (c *Client) IsAuthenticated() bool {
+	return c.apiKey != ""
+}
+
+// mapError reports a 401/403 sent without a key as the missing key, and
+// translates 404 to ErrModNotFound. A rejected key (401) falls through to the
+// shared client's ErrAuthRequired mapping.
+func (c *Client) mapError(status int, body []byte, path string) error {
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		if c.apiKey == "" {
+			return fmt.Errorf("%w: mod.io API key required", domain.ErrAuthRequired)
+		}
+	case http.StatusNotFound:
+		return fmt.Errorf("%w: resource not found", domain.ErrModNotFound)
+	}
+	return nil
+}
+
+// getPage fetches one page of a list endpoint.
+func getPage[T any](ctx context.Context, c *Client, path string, params url.Values, offset, limit int) (*ListResponse[T], error) {
+	q := url.Values{}
+	for k, v := range params {
+		q[k] = v
+	}
+	q.Set("_offset", strconv.Itoa(offset))
+	q.Set("_limit", strconv.Itoa(limit))
+
+	var resp ListResponse[T]
+	if err := c.rest.DoJSON(ctx, http.MethodGet, path+"?"+q.Encode(), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// getAll fetches every page of a list endpoint.
+func getAll[T any](ctx context.Context, c *Client, path string, params url.Values) ([]T, error) {
+	var all []T
+	for offset := 0; ; {
+		resp, err := getPage[T](ctx, c, path, params, offset, maxPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.Data...)
+		offset += len(resp.Data)
+		if len(resp.Data) == 0 || offset >= resp.ResultTotal {
+			return all, nil
+		}
+	}
+}
+
+// GetGames fetches every game on mod.io
+func (c *Client) GetGames(ctx context.Context) ([]Game, error) {
+	games, err := getAll[Game](ctx, c, "/games", nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting games: %w", err)
+	}
+	return games, nil
+}
+
+// GetGameByNameID fetches the game whose URL slug is nameID
+func (c *Client) GetGameByNameID(ctx context.Context, nameID string) (*Game, error) {
+	resp, err := getPage[Game](ctx, c, "/games", url.Values{"name_id": {nameID}}, 0, 1)
+	if err != nil {
+		return nil, fmt.Errorf("getting game: %w", err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("%w: no mod.io game %q", domain.ErrGameNotFound, nameID)
+	}
+	return &resp.Data[0], nil
+}
+
+// Ping makes the cheapest authenticated request there is, one game of the
+// games list, to check the configured key.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := getPage[Game](ctx, c, "/games", nil, 0, 1)
+	return err
+}
+
+// SearchMods lists a game's mods filtered by params — mod.io filter
+// parameters such as _q, tags and _sort — returning one page and the total
+// match count.
+func (c *Client) SearchMods(ctx context.Context, gameID int, params url.Values, offset, limit int) ([]Mod, int, error) {
+	resp, err := getPage[Mod](ctx, c, fmt.Sprintf("/games/%d/mods", gameID), params, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("searching mods: %w", err)
+	}
+	return resp.Data, resp.ResultTotal, nil
+}
+
+// GetMod fetches a single mod
+func (c *Client) GetMod(ctx context.Context, gameID, modID int) (*Mod, error) {
+	var mod Mod
+	if err := c.rest.DoJSON(ctx, http.MethodGet, fmt.Sprintf("/games/%d/mods/%d", gameID, modID), &mod); err != nil {
+		return nil, fmt.Errorf("getting mod: %w", err)
+	}
+	return &mod, nil
+}
+
+// GetModfiles fetches every file of a mod, newest first
+func (c *Client) GetModfiles(ctx context.Context, gameID, modID int) ([]Modfile, error) {
+	files, err := getAll[Modfile](ctx, c, fmt.Sprintf("/games/%d/mods/%d/files", gameID, modID), url.Values{"_sort": {"-date_added"}})
+	if err != nil {
+		return nil, fmt.Errorf("getting mod files: %w", err)
+	}
+	return files, nil
+}
+
+// GetModfile fetches a single file of a mod
+func (c *Client) GetModfile(ctx context.Context, gameID, modID, fileID int) (*Modfile, error) {
+	var file Modfile
+	if err := c.rest.DoJSON(ctx, http.MethodGet, fmt.Sprintf("/games/%d/mods/%d/files/%d", gameID, modID, fileID), &file); err != nil {
+		return nil, fmt.Errorf("getting mod file: %w", err)
+	}
+	return &file, nil
+}
+
+// GetDependencies fetches the mods a mod depends on
+func (c *Client) GetDependencies(ctx context.Context, gameID, modID int) ([]Dependency, error) {
+	deps, err := getAll[Dependency](ctx, c, fmt.Sprintf("/games/%d/mods/%d/dependencies", gameID, modID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting dependencies: %w", err)
+	}
+	return deps, nil
+}
@@ -0,0 +1,115 @@
+package modio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SendsKeyAsQueryParameter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/games/5/mods", r.URL.Path)
+		assert.Equal(t, "secret", r.URL.Query().Get("api_key"))
+		assert.Equal(t, "rifle", r.URL.Query().Get("_q"))
+		assert.Equal(t, "20", r.URL.Query().Get("_offset"))
+		assert.Equal(t, "10", r.URL.Query().Get("_limit"))
+		_, _ = w.Write([]byte(`{"data": [{"id": 1, "name": "Rifle"}], "result_total": 21}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), "secret")
+	client.SetBaseURL(server.URL)
+
+	mods, total, err := client.SearchMods(context.Background(), 5, map[string][]string{"_q": {"rifle"}}, 20, 10)
+	require.NoError(t, err)
+	require.Len(t, mods, 1)
+	assert.Equal(t, "Rifle", mods[0].Name)
+	assert.Equal(t, 21, total)
+}
+
+func TestClient_GetGamesPaginates(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("_offset"))
+		assert.Equal(t, "100", r.URL.Query().Get("_limit"))
+		if offset == 0 {
+			var data string
+			for i := range maxPageSize {
+				if i > 0 {
+					data += ","
+				}
+				data += fmt.Sprintf(`{"id": %d}`, i+1)
+			}
+			_, _ = fmt.Fprintf(w, `{"data": [%s], "result_total": 101}`, data)
+			return
+		}
+		assert.Equal(t, 100, offset)
+		_, _ = w.Write([]byte(`{"data": [{"id": 101, "name": "Last", "name_id": "last"}], "result_total": 101}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), "secret")
+	client.SetBaseURL(server.URL)
+
+	games, err := client.GetGames(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, games, 101)
+	assert.Equal(t, "last", games[100].NameID)
+	assert.Equal(t, 2, requests)
+}
+
+func TestClient_ErrorMapping(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/games/5/mods/404":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), "")
+	client.SetBaseURL(server.URL)
+
+	_, err := client.GetMod(context.Background(), 5, 1)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrAuthRequired)
+	assert.Contains(t, err.Error(), "mod.io API key required")
+
+	client.SetAPIKey("bad")
+	_, err = client.GetMod(context.Background(), 5, 1)
+	assert.ErrorIs(t, err, domain.ErrAuthRequired, "a rejected key is an auth error too")
+
+	_, err = client.GetMod(context.Background(), 5, 404)
+	assert.ErrorIs(t, err, domain.ErrModNotFound)
+}
+
+func TestClient_GetGameByNameID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name_id") == "drg" {
+			_, _ = w.Write([]byte(`{"data": [{"id": 2475, "name": "Deep Rock Galactic", "name_id": "drg"}], "result_total": 1}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": [], "result_total": 0}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), "secret")
+	client.SetBaseURL(server.URL)
+
+	game, err := client.GetGameByNameID(context.Background(), "drg")
+	require.NoError(t, err)
+	assert.Equal(t, 2475, game.ID)
+
+	_, err = client.GetGameByNameID(context.Background(), "nope")
+	assert.ErrorIs(t, err, domain.ErrGameNotFound)
+}
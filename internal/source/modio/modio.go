@@ -0,0 +1,408 @@
+// Package modio implements the mod.io mod source (api.mod.io/v1).
+//
+// The value a game maps for this source in games.yaml is its mod.io game,
+// either the numeric ID or the name_id from its mod.io URL (e.g. "12345" or
+// "drg" for mod.io/g/drg); 'lmm game add' lists both. Mod IDs and file IDs
+// are mod.io's numeric IDs.
+//
+// Every mod.io request needs an API key, which is free to generate at
+// https://mod.io/me/access.
+package modio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source"
+)
+
+// sourceID is the registry ID, stamped onto every mod this source returns.
+const sourceID = "modio"
+
+// ModIO implements the ModSource interface
+type ModIO struct {
+	client *Client
+	// gameIDCache caches resolved game name_ids to numeric IDs
+	gameIDCache map[string]int
+	cacheMu     sync.RWMutex
+}
+
+// New creates a new mod.io source
+func New(httpClient *http.Client, apiKey string) *ModIO {
+	return &ModIO{
+		client:      NewClient(httpClient, apiKey),
+		gameIDCache: make(map[string]int),
+	}
+}
+
+// ID returns the source identifier
+func (m *ModIO) ID() string {
+	return sourceID
+}
+
+// Name returns the display name
+func (m *ModIO) Name() string {
+	return "mod.io"
+}
+
+// AuthURL returns where mod.io API keys are generated.
+func (m *ModIO) AuthURL() string {
+	return "https://mod.io/me/access"
+}
+
+// SetAPIKey sets the API key for authentication
+func (m *ModIO) SetAPIKey(key string) {
+	m.client.SetAPIKey(key)
+}
+
+// IsAuthenticated returns true if an API key is configured
+func (m *ModIO) IsAuthenticated() bool {
+	return m.client.IsAuthenticated()
+}
+
+// ExchangeToken exchanges an OAuth code for tokens.
+// lmm uses mod.io API keys instead of OAuth.
+func (m *ModIO) ExchangeToken(ctx context.Context, code string) (*source.Token, error) {
+	return nil, fmt.Errorf("mod.io uses API key authentication, not OAuth")
+}
+
+// EnvKey implements source.EnvKeyProvider.
+func (m *ModIO) EnvKey() string {
+	return "MODIO_API_KEY"
+}
+
+// ValidateKey implements source.KeyValidator with one cheap request made
+// with key through a client scoped to this call, so validation is
+// independent of any key already configured on this source.
+func (m *ModIO) ValidateKey(ctx context.Context, key string) error {
+	client := NewClient(m.client.httpClient, key)
+	client.SetBaseURL(m.client.rest.BaseURL())
+	if err := client.Ping(ctx); err != nil {
+		return fmt.Errorf("API validation failed: %w", err)
+	}
+	return nil
+}
+
+// AuthInstructions implements source.AuthInstructionsProvider.
+func (m *ModIO) AuthInstructions() string {
+	return "To authenticate with mod.io:\n" +
+		"1. Visit https://mod.io/me/access\n" +
+		"2. Accept the API terms and generate an API key (not an OAuth token)\n" +
+		"3. Copy your API key\n"
+}
+
+// ListGames implements source.GameCatalog, with each game's name_id as its
+// slug.
+func (m *ModIO) ListGames(ctx context.Context) ([]source.GameEntry, error) {
+	games, err := m.client.GetGames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing games: %w", err)
+	}
+
+	entries := make([]source.GameEntry, len(games))
+	for i, g := range games {
+		entries[i] = source.GameEntry{ID: strconv.Itoa(g.ID), Name: g.Name, Slug: g.NameID}
+	}
+	return entries, nil
+}
+
+// TypeLabel implements source.TypeLabeler.
+func (m *ModIO) TypeLabel() string {
+	return "built-in"
+}
+
+// Capabilities implements source.CapabilityReporter. mod.io supports all
+// ModSource operations.
+func (m *ModIO) Capabilities() source.Capabilities {
+	return source.Capabilities{Search: true, Dependencies: true, Updates: true, Auth: true, Versions: true}
+}
+
+// resolveGameID converts a game identifier (numeric ID or name_id) to a
+// numeric ID. Results are cached to avoid repeated API calls.
+func (m *ModIO) resolveGameID(ctx context.Context, gameIDOrNameID string) (int, error) {
+	if id, err := strconv.Atoi(gameIDOrNameID); err == nil {
+		return id, nil
+	}
+	if gameIDOrNameID == "" {
+		return 0, fmt.Errorf("%w: no mod.io game configured", domain.ErrGameNotFound)
+	}
+
+	m.cacheMu.RLock()
+	id, ok := m.gameIDCache[gameIDOrNameID]
+	m.cacheMu.RUnlock()
+	if ok {
+		return id, nil
+	}
+
+	game, err := m.client.GetGameByNameID(ctx, gameIDOrNameID)
+	if err != nil {
+		return 0, err
+	}
+	m.cacheMu.Lock()
+	m.gameIDCache[gameIDOrNameID] = game.ID
+	m.cacheMu.Unlock()
+	return game.ID, nil
+}
+
+// ids resolves a mod's game and parses its numeric mod ID.
+func (m *ModIO) ids(ctx context.Context, gameID, modID string) (int, int, error) {
+	mID, err := strconv.Atoi(modID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid mod ID: %w", err)
+	}
+	gID, err := m.resolveGameID(ctx, gameID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return gID, mID, nil
+}
+
+// Search finds mods using mod.io's filtering: the query is a full-text _q
+// search, and Category and Tags are tags a mod must all carry. Without a
+// query, results are ordered by popularity.
+func (m *ModIO) Search(ctx context.Context, query source.SearchQuery) (source.SearchResult, error) {
+	gameID, err := m.resolveGameID(ctx, query.GameID)
+	if err != nil {
+		return source.SearchResult{}, err
+	}
+
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	pageSize = min(pageSize, maxPageSize)
+	page := max(query.Page, 0)
+
+	params := url.Values{}
+	if q := strings.TrimSpace(query.Query); q != "" {
+		params.Set("_q", q)
+	} else {
+		params.Set("_sort", "-popular")
+	}
+	tags := query.Tags
+	if query.Category != "" {
+		tags = append([]string{query.Category}, tags...)
+	}
+	if len(tags) > 0 {
+		params.Set("tags", strings.Join(tags, ","))
+	}
+
+	results, total, err := m.client.SearchMods(ctx, gameID, params, page*pageSize, pageSize)
+	if err != nil {
+		return source.SearchResult{}, err
+	}
+
+	mods := make([]domain.Mod, len(results))
+	for i, r := range results {
+		mods[i] = modToDomain(r, query.GameID)
+	}
+	return source.SearchResult{Mods: mods, TotalCount: total, Page: page, PageSize: pageSize}, nil
+}
+
+// remoteMod fetches a mod by this source's game and mod identifiers.
+func (m *ModIO) remoteMod(ctx context.Context, gameID, modID string) (*Mod, error) {
+	gID, mID, err := m.ids(ctx, gameID, modID)
+	if err != nil {
+		return nil, err
+	}
+	return m.client.GetMod(ctx, gID, mID)
+}
+
+// GetMod retrieves a specific mod
+func (m *ModIO) GetMod(ctx context.Context, gameID, modID string) (*domain.Mod, error) {
+	data, err := m.remoteMod(ctx, gameID, modID)
+	if err != nil {
+		return nil, err
+	}
+	mod := modToDomain(*data, gameID)
+	return &mod, nil
+}
+
+// GetDependencies returns the mods the mod depends on. mod.io dependencies
+// are always mods of the same game and carry no version.
+func (m *ModIO) GetDependencies(ctx context.Context, mod *domain.Mod) ([]domain.ModReference, error) {
+	gID, mID, err := m.ids(ctx, mod.GameID, mod.ID)
+	if err != nil {
+		return nil, err
+	}
+	deps, err := m.client.GetDependencies(ctx, gID, mID)
+	if err != nil {
+		return nil, err
+	}
+	refs := make([]domain.ModReference, 0, len(deps))
+	for _, d := range deps {
+		refs = append(refs, domain.ModReference{SourceID: sourceID, ModID: strconv.Itoa(d.ModID)})
+	}
+	return refs, nil
+}
+
+// GetModFiles returns every file of the mod, newest first, with mod.io's
+// MD5 checksums. The mod's live file is the primary one.
+func (m *ModIO) GetModFiles(ctx context.Context, mod *domain.Mod) ([]domain.DownloadableFile, error) {
+	gID, mID, err := m.ids(ctx, mod.GameID, mod.ID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := m.client.GetMod(ctx, gID, mID)
+	if err != nil {
+		return nil, fmt.Errorf("getting mod files: %w", err)
+	}
+	modfiles, err := m.client.GetModfiles(ctx, gID, mID)
+	if err != nil {
+		return nil, err
+	}
+
+	liveID := 0
+	if data.Modfile != nil {
+		liveID = data.Modfile.ID
+	}
+	files := make([]domain.DownloadableFile, len(modfiles))
+	for i, f := range modfiles {
+		files[i] = domain.DownloadableFile{
+			ID:          strconv.Itoa(f.ID),
+			Name:        f.Filename,
+			FileName:    f.Filename,
+			Version:     fileVersion(f),
+			Size:        f.Filesize,
+			IsPrimary:   f.ID == liveID,
+			Description: f.Changelog,
+			MD5:         f.Filehash.MD5,
+		}
+	}
+	return files, nil
+}
+
+// GetDownloadURL gets the download URL for a mod file
+func (m *ModIO) GetDownloadURL(ctx context.Context, mod *domain.Mod, fileID string) (string, error) {
+	gID, mID, err := m.ids(ctx, mod.GameID, mod.ID)
+	if err != nil {
+		return "", err
+	}
+	fID, err := strconv.Atoi(fileID)
+	if err != nil {
+		return "", fmt.Errorf("invalid file ID: %w", err)
+	}
+	f, err := m.client.GetModfile(ctx, gID, mID, fID)
+	if err != nil {
+		return "", fmt.Errorf("getting download URL: %w", err)
+	}
+	if f.Download.BinaryURL == "" {
+		return "", fmt.Errorf("mod.io file %s has no download link", fileID)
+	}
+	return f.Download.BinaryURL, nil
+}
+
+// CheckUpdates reports a mod whose live file is not among its installed
+// files. The live file replaces every installed file, and its changelog is
+// the update's.
+func (m *ModIO) CheckUpdates(ctx context.Context, installed []domain.InstalledMod) ([]domain.Update, error) {
+	var updates []domain.Update
+	var fetchErrs []error
+
+	for i, inst := range installed {
+		select {
+		case <-ctx.Done():
+			return updates, ctx.Err()
+		default:
+		}
+		if fn, ok := ctx.Value(domain.UpdateProgressContextKey).(domain.UpdateProgressFunc); ok && fn != nil {
+			fn(i+1, len(installed), inst.Name)
+		}
+
+		remote, err := m.remoteMod(ctx, inst.GameID, inst.ID)
+		if err != nil {
+			fetchErrs = append(fetchErrs, fmt.Errorf("%s (id %s): %w", inst.Name, inst.ID, err))
+			continue
+		}
+		if upd, ok := liveFileUpdate(inst, remote.Modfile); ok {
+			updates = append(updates, upd)
+		}
+	}
+
+	if len(fetchErrs) > 0 {
+		return updates, fmt.Errorf("update check skipped %d mod(s): %w", len(fetchErrs), errors.Join(fetchErrs...))
+	}
+	return updates, nil
+}
+
+// liveFileUpdate builds the update moving inst to live, if live is not
+// already installed. Mods installed without recorded file IDs fall back to
+// comparing versions.
+func liveFileUpdate(inst domain.InstalledMod, live *Modfile) (domain.Update, bool) {
+	if live == nil {
+		return domain.Update{}, false
+	}
+	liveID := strconv.Itoa(live.ID)
+	version := fileVersion(*live)
+	if len(inst.FileIDs) > 0 {
+		if slices.Contains(inst.FileIDs, liveID) {
+			return domain.Update{}, false
+		}
+	} else if version == inst.Version {
+		return domain.Update{}, false
+	}
+
+	repl := make(map[string]string, len(inst.FileIDs))
+	for _, id := range inst.FileIDs {
+		repl[id] = liveID
+	}
+	return domain.Update{
+		InstalledMod:       inst,
+		NewVersion:         version,
+		Changelog:          live.Changelog,
+		FileIDReplacements: repl,
+	}, true
+}
+
+// fileVersion is a modfile's version label. Uploaders may leave it empty,
+// in which case the file ID stands in: it still identifies the upload.
+func fileVersion(f Modfile) string {
+	if v := strings.TrimSpace(f.Version); v != "" {
+		return v
+	}
+	return strconv.Itoa(f.ID)
+}
+
+// modToDomain converts a mod.io Mod to domain.Mod, versioned by its live file
+func modToDomain(data Mod, gameID string) domain.Mod {
+	var version string
+	if data.Modfile != nil {
+		version = fileVersion(*data.Modfile)
+	}
+	var category string
+	if len(data.Tags) > 0 {
+		category = data.Tags[0].Name
+	}
+	var updatedAt time.Time
+	if data.DateUpdated > 0 {
+		updatedAt = time.Unix(data.DateUpdated, 0).UTC()
+	}
+	return domain.Mod{
+		ID:           strconv.Itoa(data.ID),
+		SourceID:     sourceID,
+		Name:         data.Name,
+		Version:      version,
+		Author:       data.SubmittedBy.Username,
+		Summary:      data.Summary,
+		Description:  data.DescriptionPlaintext,
+		GameID:       gameID,
+		Category:     category,
+		Downloads:    data.Stats.DownloadsTotal,
+		Endorsements: int64Ptr(data.Stats.RatingsPositive),
+		PictureURL:   data.Logo.Thumb320x180,
+		SourceURL:    data.ProfileURL,
+		UpdatedAt:    updatedAt,
+	}
+}
+
+// int64Ptr returns a pointer to the given int64 value.
+func int64Ptr(v int64) *int64 { return &v }
@@ -0,0 +1,230 @@
+package modio
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModIO_ImplementsModSource(t *testing.T) {
+	// Compile-time check that ModIO implements ModSource
+	var _ source.ModSource = (*ModIO)(nil)
+}
+
+// Compile-time conformance pins for the optional metadata interfaces a
+// built-in implements.
+var (
+	_ source.EnvKeyProvider           = (*ModIO)(nil)
+	_ source.KeyValidator             = (*ModIO)(nil)
+	_ source.AuthInstructionsProvider = (*ModIO)(nil)
+	_ source.GameCatalog              = (*ModIO)(nil)
+	_ source.TypeLabeler              = (*ModIO)(nil)
+	_ source.CapabilityReporter       = (*ModIO)(nil)
+)
+
+func TestModIO_Metadata(t *testing.T) {
+	m := New(nil, "")
+	assert.Equal(t, "modio", m.ID())
+	assert.Equal(t, "mod.io", m.Name())
+	assert.Equal(t, "MODIO_API_KEY", m.EnvKey())
+	assert.Equal(t, "built-in", m.TypeLabel())
+	assert.Equal(t, source.Capabilities{Search: true, Dependencies: true, Updates: true, Auth: true, Versions: true}, m.Capabilities())
+	assert.False(t, m.IsAuthenticated())
+
+	m.SetAPIKey("key")
+	assert.True(t, m.IsAuthenticated())
+}
+
+const testModJSON = `{
+	"id": 77, "game_id": 5, "name": "Better Rifle", "summary": "A better rifle",
+	"description_plaintext": "Long text", "profile_url": "https://mod.io/g/game/m/better-rifle",
+	"submitted_by": {"username": "gunsmith"}, "date_updated": 1767225600,
+	"logo": {"thumb_320x180": "https://img.test/77.png"},
+	"stats": {"downloads_total": 1200, "ratings_positive": 40},
+	"tags": [{"name": "Weapons"}],
+	"modfile": {"id": 902, "version": "1.1.0", "changelog": "Less recoil."}
+}`
+
+// newTestModIO fronts a ModIO with a server for game 5 ("game"), mod 77.
+func newTestModIO(t *testing.T, handle func(w http.ResponseWriter, r *http.Request) bool) *ModIO {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handle != nil && handle(w, r) {
+			return
+		}
+		switch r.URL.Path {
+		case "/games":
+			_, _ = w.Write([]byte(`{"data": [{"id": 5, "name": "Game", "name_id": "game"}], "result_total": 1}`))
+		case "/games/5/mods/77":
+			_, _ = w.Write([]byte(testModJSON))
+		case "/games/5/mods/77/files":
+			assert.Equal(t, "-date_added", r.URL.Query().Get("_sort"))
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": 902, "filename": "rifle-1.1.0.zip", "version": "1.1.0", "filesize": 2048,
+				 "filehash": {"md5": "0123456789abcdef0123456789abcdef"}, "changelog": "Less recoil."},
+				{"id": 901, "filename": "rifle.zip", "version": "", "filesize": 1024}
+			], "result_total": 2}`))
+		case "/games/5/mods/77/files/901":
+			_, _ = w.Write([]byte(`{"id": 901, "download": {"binary_url": "https://dl.test/901"}}`))
+		case "/games/5/mods/77/dependencies":
+			_, _ = w.Write([]byte(`{"data": [{"mod_id": 12}, {"mod_id": 13}], "result_total": 2}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	m := New(server.Client(), "key")
+	m.client.SetBaseURL(server.URL)
+	return m
+}
+
+func TestModIO_Search(t *testing.T) {
+	m := newTestModIO(t, func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path != "/games/5/mods" {
+			return false
+		}
+		q := r.URL.Query()
+		if q.Get("_q") == "" {
+			assert.Equal(t, "-popular", q.Get("_sort"), "browsing sorts by popularity")
+		} else {
+			assert.Equal(t, "rifle", q.Get("_q"))
+			assert.Empty(t, q.Get("_sort"))
+		}
+		assert.Equal(t, "Weapons,Realism", q.Get("tags"))
+		assert.Equal(t, "10", q.Get("_offset"))
+		assert.Equal(t, "10", q.Get("_limit"))
+		_, _ = w.Write([]byte(`{"data": [` + testModJSON + `], "result_total": 11}`))
+		return true
+	})
+
+	for _, query := range []string{"rifle", ""} {
+		res, err := m.Search(context.Background(), source.SearchQuery{
+			GameID: "game", Query: query, Category: "Weapons", Tags: []string{"Realism"}, Page: 1, PageSize: 10,
+		})
+		require.NoError(t, err)
+		require.Len(t, res.Mods, 1)
+		assert.Equal(t, 11, res.TotalCount)
+
+		mod := res.Mods[0]
+		assert.Equal(t, "77", mod.ID)
+		assert.Equal(t, "modio", mod.SourceID)
+		assert.Equal(t, "1.1.0", mod.Version)
+		assert.Equal(t, "gunsmith", mod.Author)
+		assert.Equal(t, "game", mod.GameID, "the configured identifier is kept")
+		assert.Equal(t, int64(1200), mod.Downloads)
+		assert.Equal(t, "Weapons", mod.Category)
+		assert.Equal(t, int64(1767225600), mod.UpdatedAt.Unix())
+	}
+}
+
+func TestModIO_ResolvesNameIDOnce(t *testing.T) {
+	var lookups int
+	m := newTestModIO(t, func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Path == "/games" {
+			lookups++
+			assert.Equal(t, "game", r.URL.Query().Get("name_id"))
+		}
+		return false
+	})
+
+	for range 2 {
+		_, err := m.GetMod(context.Background(), "game", "77")
+		require.NoError(t, err)
+	}
+	_, err := m.GetMod(context.Background(), "5", "77")
+	require.NoError(t, err)
+	assert.Equal(t, 1, lookups)
+}
+
+func TestModIO_GetModFiles(t *testing.T) {
+	m := newTestModIO(t, nil)
+
+	files, err := m.GetModFiles(context.Background(), &domain.Mod{ID: "77", GameID: "5"})
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+
+	assert.Equal(t, "902", files[0].ID)
+	assert.Equal(t, "rifle-1.1.0.zip", files[0].FileName)
+	assert.Equal(t, "1.1.0", files[0].Version)
+	assert.Equal(t, int64(2048), files[0].Size)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", files[0].MD5)
+	assert.True(t, files[0].IsPrimary, "the live file is primary")
+
+	assert.Equal(t, "901", files[1].Version, "an unversioned file is labeled by its ID")
+	assert.False(t, files[1].IsPrimary)
+}
+
+func TestModIO_GetDownloadURL(t *testing.T) {
+	m := newTestModIO(t, nil)
+
+	u, err := m.GetDownloadURL(context.Background(), &domain.Mod{ID: "77", GameID: "5"}, "901")
+	require.NoError(t, err)
+	assert.Equal(t, "https://dl.test/901", u)
+
+	_, err = m.GetDownloadURL(context.Background(), &domain.Mod{ID: "77", GameID: "5"}, "x")
+	assert.ErrorContains(t, err, "invalid file ID")
+}
+
+func TestModIO_GetDependencies(t *testing.T) {
+	m := newTestModIO(t, nil)
+
+	deps, err := m.GetDependencies(context.Background(), &domain.Mod{ID: "77", GameID: "5"})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ModReference{
+		{SourceID: "modio", ModID: "12"},
+		{SourceID: "modio", ModID: "13"},
+	}, deps)
+}
+
+func TestModIO_CheckUpdates(t *testing.T) {
+	m := newTestModIO(t, nil)
+
+	updates, err := m.CheckUpdates(context.Background(), []domain.InstalledMod{
+		{Mod: domain.Mod{ID: "77", GameID: "5", Name: "Old", Version: "1.0.0"}, FileIDs: []string{"901"}},
+		{Mod: domain.Mod{ID: "77", GameID: "5", Name: "Current", Version: "1.1.0"}, FileIDs: []string{"902"}},
+		{Mod: domain.Mod{ID: "77", GameID: "5", Name: "Imported", Version: "1.1.0"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, "Old", updates[0].InstalledMod.Name)
+	assert.Equal(t, "1.1.0", updates[0].NewVersion)
+	assert.Equal(t, "Less recoil.", updates[0].Changelog)
+	assert.Equal(t, map[string]string{"901": "902"}, updates[0].FileIDReplacements)
+}
+
+func TestModIO_CheckUpdatesCollectsErrors(t *testing.T) {
+	m := newTestModIO(t, nil)
+
+	updates, err := m.CheckUpdates(context.Background(), []domain.InstalledMod{
+		{Mod: domain.Mod{ID: "404", GameID: "5", Name: "Gone"}},
+		{Mod: domain.Mod{ID: "77", GameID: "5", Name: "Old", Version: "1.0.0"}, FileIDs: []string{"901"}},
+	})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrModNotFound)
+	assert.Len(t, updates, 1, "one failure does not hide the other mods' updates")
+}
+
+func TestModIO_ListGamesAndValidateKey(t *testing.T) {
+	m := newTestModIO(t, func(w http.ResponseWriter, r *http.Request) bool {
+		if r.URL.Query().Get("api_key") == "bad" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return true
+		}
+		return false
+	})
+
+	games, err := m.ListGames(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []source.GameEntry{{ID: "5", Name: "Game", Slug: "game"}}, games)
+
+	require.NoError(t, m.ValidateKey(context.Background(), "good"))
+	err = m.ValidateKey(context.Background(), "bad")
+	assert.ErrorIs(t, err, domain.ErrAuthRequired)
+}
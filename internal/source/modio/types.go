@@ -0,0 +1,87 @@
+package modio
+
+// ListResponse is mod.io's envelope for every list endpoint.
+type ListResponse[T any] struct {
+	Data         []T `json:"data"`
+	ResultCount  int `json:"result_count"`
+	ResultOffset int `json:"result_offset"`
+	ResultLimit  int `json:"result_limit"`
+	ResultTotal  int `json:"result_total"`
+}
+
+// Game represents a game hosted on mod.io
+type Game struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	NameID     string `json:"name_id"`
+	ProfileURL string `json:"profile_url"`
+}
+
+// Mod represents a mod on mod.io
+type Mod struct {
+	ID                   int      `json:"id"`
+	GameID               int      `json:"game_id"`
+	Name                 string   `json:"name"`
+	NameID               string   `json:"name_id"`
+	Summary              string   `json:"summary"`
+	DescriptionPlaintext string   `json:"description_plaintext"`
+	ProfileURL           string   `json:"profile_url"`
+	SubmittedBy          User     `json:"submitted_by"`
+	DateUpdated          int64    `json:"date_updated"` // Unix seconds
+	Logo                 Logo     `json:"logo"`
+	Modfile              *Modfile `json:"modfile"` // the live file; nil before the first upload
+	Stats                Stats    `json:"stats"`
+	Tags                 []Tag    `json:"tags"`
+}
+
+// User is the subset of a mod.io user lmm reads
+type User struct {
+	Username string `json:"username"`
+}
+
+// Logo holds a mod's logo thumbnails
+type Logo struct {
+	Thumb320x180 string `json:"thumb_320x180"`
+}
+
+// Stats holds a mod's aggregate statistics
+type Stats struct {
+	DownloadsTotal  int64 `json:"downloads_total"`
+	RatingsPositive int64 `json:"ratings_positive"`
+}
+
+// Tag is one tag applied to a mod
+type Tag struct {
+	Name string `json:"name"`
+}
+
+// Modfile represents one uploaded file of a mod
+type Modfile struct {
+	ID        int      `json:"id"`
+	ModID     int      `json:"mod_id"`
+	DateAdded int64    `json:"date_added"` // Unix seconds
+	Filesize  int64    `json:"filesize"`
+	Filehash  Filehash `json:"filehash"`
+	Filename  string   `json:"filename"`
+	Version   string   `json:"version"`
+	Changelog string   `json:"changelog"`
+	Download  Download `json:"download"`
+}
+
+// Filehash holds a modfile's checksums; mod.io publishes only MD5
+type Filehash struct {
+	MD5 string `json:"md5"`
+}
+
+// Download holds a modfile's download link
+type Download struct {
+	BinaryURL   string `json:"binary_url"`
+	DateExpires int64  `json:"date_expires"`
+}
+
+// Dependency is one mod another mod depends on
+type Dependency struct {
+	ModID     int    `json:"mod_id"`
+	NameID    string `json:"name_id"`
+	DateAdded int64  `json:"date_added"`
+}
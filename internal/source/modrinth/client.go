@@ -0,0 +1,177 @@
+package modrinth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/httpclient"
+)
+
+const (
+	defaultBaseURL = "https://api.modrinth.com"
+
+	// userAgent identifies lmm as Modrinth's API rules ask every client to.
+	userAgent = "DonovanMods/linux-mod-manager (https://github.com/DonovanMods/linux-mod-manager)"
+)
+
+// Client wraps the Modrinth REST API v2. Every endpoint it uses works
+// anonymously; a personal access token only adds access to private and
+// draft projects.
+type Client struct {
+	httpClient *http.Client
+	rest       *httpclient.Client
+	apiKey     string
+}
+
+// NewClient creates a new Modrinth API client
+func NewClient(httpClient *http.Client, apiKey string) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	c := &Client{
+		httpClient: httpClient,
+		apiKey:     apiKey,
+	}
+	c.rest = httpclient.New(httpclient.Options{
+		HTTPClient:  httpClient,
+		BaseURL:     defaultBaseURL,
+		APIKey:      apiKey,
+		AuthHeader:  "Authorization",
+		AuthLabel:   "Modrinth",
+		UserAgent:   userAgent,
+		ErrorMapper: c.mapError,
+	})
+	return c
+}
+
+// SetAPIKey sets the personal access token sent with every request
+func (c *Client) SetAPIKey(key string) {
+	c.apiKey = key
+	c.rest.SetAPIKey(key)
+}
+
+// SetBaseURL overrides the REST API base URL — primarily used by tests that
+// front the client with an httptest server.
+func (c *Client) SetBaseURL(u string) {
+	c.rest.SetBaseURL(u)
+}
+
+// IsAuthenticated returns true if a token is configured
+func (c *Client) IsAuthenticated() bool {
+	return c.apiKey != ""
+}
+
+// mapError translates 404 to ErrModNotFound; 401 (a bad token) falls through
+// to the shared client's ErrAuthRequired mapping.
+func (c *Client) mapError(status int, body []byte, path string) error {
+	if status == http.StatusNotFound {
+		return fmt.Errorf("%w: resource not found", domain.ErrModNotFound)
+	}
+	return nil
+}
+
+// jsonList encodes values the way Modrinth's array query parameters expect:
+// a JSON array in a single parameter.
+func jsonList(values []string) string {
+	b, _ := json.Marshal(values) // []string always marshals
+	return string(b)
+}
+
+// Search searches projects. Each inner facets slice is OR'd; the slices are
+// AND'd together.
+func (c *Client) Search(ctx context.Context, query string, facets [][]string, limit, offset int) (*SearchResponse, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100 // API max
+	}
+
+	params := url.Values{}
+	if query != "" {
+		params.Set("query", query)
+	}
+	if len(facets) > 0 {
+		b, _ := json.Marshal(facets) // [][]string always marshals
+		params.Set("facets", string(b))
+	}
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("offset", strconv.Itoa(offset))
+
+	var resp SearchResponse
+	if err := c.rest.DoJSON(ctx, http.MethodGet, "/v2/search?"+params.Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("searching projects: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetProject fetches a project by ID or slug
+func (c *Client) GetProject(ctx context.Context, idOrSlug string) (*Project, error) {
+	var resp Project
+	if err := c.rest.DoJSON(ctx, http.MethodGet, "/v2/project/"+url.PathEscape(idOrSlug), &resp); err != nil {
+		return nil, fmt.Errorf("getting project: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetProjectVersions lists a project's versions, newest first, keeping only
+// those for one of loaders and one of gameVersions (an empty list filters
+// nothing).
+func (c *Client) GetProjectVersions(ctx context.Context, idOrSlug string, loaders, gameVersions []string) ([]Version, error) {
+	params := url.Values{}
+	if len(loaders) > 0 {
+		params.Set("loaders", jsonList(loaders))
+	}
+	if len(gameVersions) > 0 {
+		params.Set("game_versions", jsonList(gameVersions))
+	}
+	path := "/v2/project/" + url.PathEscape(idOrSlug) + "/version"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	var resp []Version
+	if err := c.rest.DoJSON(ctx, http.MethodGet, path, &resp); err != nil {
+		return nil, fmt.Errorf("getting project versions: %w", err)
+	}
+	return resp, nil
+}
+
+// GetVersion fetches a single version by ID
+func (c *Client) GetVersion(ctx context.Context, versionID string) (*Version, error) {
+	var resp Version
+	if err := c.rest.DoJSON(ctx, http.MethodGet, "/v2/version/"+url.PathEscape(versionID), &resp); err != nil {
+		return nil, fmt.Errorf("getting version: %w", err)
+	}
+	return &resp, nil
+}
+
+// GetVersionFromHash fetches the version that contains the file with the
+// given SHA-1 hash
+func (c *Client) GetVersionFromHash(ctx context.Context, sha1 string) (*Version, error) {
+	path := "/v2/version_file/" + url.PathEscape(sha1) + "?algorithm=sha1"
+
+	var resp Version
+	if err := c.rest.DoJSON(ctx, http.MethodGet, path, &resp); err != nil {
+		return nil, fmt.Errorf("getting version from file hash: %w", err)
+	}
+	return &resp, nil
+}
+
+// LatestVersionsFromHashes looks up, for each file hash in req, the latest
+// version of its project matching req's loaders and game versions. The
+// result is keyed by the requested hash; hashes Modrinth does not know are
+// absent.
+func (c *Client) LatestVersionsFromHashes(ctx context.Context, req UpdateRequest) (map[string]Version, error) {
+	var resp map[string]Version
+	if err := c.rest.DoJSONBody(ctx, http.MethodPost, "/v2/version_files/update", req, &resp); err != nil {
+		return nil, fmt.Errorf("checking for updates: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,147 @@
+package modrinth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Search(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/search", r.URL.Path)
+		assert.Equal(t, "sodium", r.URL.Query().Get("query"))
+		assert.Equal(t, `[["categories:fabric"],["versions:1.20.1"]]`, r.URL.Query().Get("facets"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		assert.Equal(t, "20", r.URL.Query().Get("offset"))
+		assert.Contains(t, r.Header.Get("User-Agent"), "linux-mod-manager")
+		assert.Empty(t, r.Header.Get("Authorization"), "no token configured")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"hits": [{"project_id": "AANobbMI", "slug": "sodium", "title": "Sodium"}],
+			"offset": 20, "limit": 10, "total_hits": 21
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), "")
+	client.SetBaseURL(server.URL)
+
+	resp, err := client.Search(context.Background(), "sodium",
+		[][]string{{"categories:fabric"}, {"versions:1.20.1"}}, 10, 20)
+	require.NoError(t, err)
+	require.Len(t, resp.Hits, 1)
+	assert.Equal(t, "AANobbMI", resp.Hits[0].ProjectID)
+	assert.Equal(t, 21, resp.TotalHits)
+}
+
+func TestClient_SendsToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "mrp_token", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"id": "AANobbMI", "title": "Sodium"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), "")
+	client.SetBaseURL(server.URL)
+	client.SetAPIKey("mrp_token")
+	assert.True(t, client.IsAuthenticated())
+
+	_, err := client.GetProject(context.Background(), "sodium")
+	require.NoError(t, err)
+}
+
+func TestClient_GetProjectVersions_Filters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/project/sodium/version", r.URL.Path)
+		assert.Equal(t, `["fabric","quilt"]`, r.URL.Query().Get("loaders"))
+		assert.Equal(t, `["1.20.1"]`, r.URL.Query().Get("game_versions"))
+		_, _ = w.Write([]byte(`[{"id": "v1", "version_number": "0.5.3"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), "")
+	client.SetBaseURL(server.URL)
+
+	versions, err := client.GetProjectVersions(context.Background(), "sodium", []string{"fabric", "quilt"}, []string{"1.20.1"})
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	assert.Equal(t, "0.5.3", versions[0].VersionNumber)
+}
+
+func TestClient_GetProjectVersions_NoFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.URL.RawQuery)
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), "")
+	client.SetBaseURL(server.URL)
+
+	versions, err := client.GetProjectVersions(context.Background(), "sodium", nil, nil)
+	require.NoError(t, err)
+	assert.Empty(t, versions)
+}
+
+func TestClient_NotFoundMapsToErrModNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), "")
+	client.SetBaseURL(server.URL)
+
+	_, err := client.GetProject(context.Background(), "missing")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrModNotFound)
+}
+
+func TestClient_UnauthorizedMapsToErrAuthRequired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), "bad-token")
+	client.SetBaseURL(server.URL)
+
+	_, err := client.GetVersion(context.Background(), "v1")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrAuthRequired)
+}
+
+func TestClient_LatestVersionsFromHashes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v2/version_files/update", r.URL.Path)
+
+		var req UpdateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, UpdateRequest{
+			Hashes:       []string{"aaa"},
+			Algorithm:    "sha1",
+			Loaders:      []string{"fabric"},
+			GameVersions: []string{"1.20.1"},
+		}, req)
+
+		_, _ = w.Write([]byte(`{"aaa": {"id": "v2", "version_number": "0.5.4"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client(), "")
+	client.SetBaseURL(server.URL)
+
+	latest, err := client.LatestVersionsFromHashes(context.Background(), UpdateRequest{
+		Hashes: []string{"aaa"}, Algorithm: "sha1", Loaders: []string{"fabric"}, GameVersions: []string{"1.20.1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0.5.4", latest["aaa"].VersionNumber)
+}
@@ -0,0 +1,489 @@
+// Package modrinth implements the Modrinth mod source (api.modrinth.com/v2).
+//
+// Modrinth has no per-game IDs: its "game" is a combination of mod loader and
+// game version, so the value a game maps for this source in games.yaml is a
+// filter, "<loader>[,<loader>...][@<game-version>[,<game-version>...]]" -
+// e.g. "fabric@1.20.1", "neoforge,forge@1.21.1", "paper", or "" for no
+// filter at all. Every lookup honors it: search facets, the version list
+// behind GetModFiles, and the bulk update check.
+//
+// File IDs are the files' SHA-1 hashes, which is what Modrinth's bulk update
+// endpoint is keyed by.
+package modrinth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source"
+)
+
+// sourceID is the registry ID, stamped onto every mod this source returns.
+const sourceID = "modrinth"
+
+// sha1Pattern matches a file ID this source issued.
+var sha1Pattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// Modrinth implements the ModSource interface
+type Modrinth struct {
+	client *Client
+}
+
+// New creates a new Modrinth source
+func New(httpClient *http.Client, apiKey string) *Modrinth {
+	return &Modrinth{client: NewClient(httpClient, apiKey)}
+}
+
+// ID returns the source identifier
+func (m *Modrinth) ID() string {
+	return sourceID
+}
+
+// Name returns the display name
+func (m *Modrinth) Name() string {
+	return "Modrinth"
+}
+
+// EnvKey implements source.EnvKeyProvider.
+func (m *Modrinth) EnvKey() string {
+	return "MODRINTH_API_KEY"
+}
+
+// AuthURL returns where personal access tokens are created. A token is
+// optional: it only adds access to private and draft projects.
+func (m *Modrinth) AuthURL() string {
+	return "https://modrinth.com/settings/pats"
+}
+
+// SetAPIKey sets the personal access token
+func (m *Modrinth) SetAPIKey(key string) {
+	m.client.SetAPIKey(key)
+}
+
+// IsAuthenticated returns true if a token is configured
+func (m *Modrinth) IsAuthenticated() bool {
+	return m.client.IsAuthenticated()
+}
+
+// ExchangeToken exchanges an OAuth code for tokens.
+// lmm uses Modrinth personal access tokens instead of OAuth.
+func (m *Modrinth) ExchangeToken(ctx context.Context, code string) (*source.Token, error) {
+	return nil, fmt.Errorf("Modrinth uses personal access tokens, not OAuth")
+}
+
+// AuthInstructions implements source.AuthInstructionsProvider.
+func (m *Modrinth) AuthInstructions() string {
+	return "Modrinth works without authentication; a token only adds access to\n" +
+		"private and draft projects. To use one:\n" +
+		"1. Visit https://modrinth.com/settings/pats\n" +
+		"2. Create a personal access token (no scopes are needed for public projects)\n" +
+		"3. Copy the token\n"
+}
+
+// TypeLabel implements source.TypeLabeler.
+func (m *Modrinth) TypeLabel() string {
+	return "built-in"
+}
+
+// Capabilities implements source.CapabilityReporter. Modrinth supports all
+// ModSource operations.
+func (m *Modrinth) Capabilities() source.Capabilities {
+	return source.Capabilities{Search: true, Dependencies: true, Updates: true, Auth: true, Versions: true}
+}
+
+// gameFilter is the loader/game-version filter a games.yaml mapping
+// describes (see the package doc).
+type gameFilter struct {
+	Loaders      []string
+	GameVersions []string
+}
+
+// parseGameFilter parses "<loaders>@<game-versions>", each half an optional
+// comma-separated list.
+func parseGameFilter(gameID string) gameFilter {
+	loaders, versions, _ := strings.Cut(gameID, "@")
+	return gameFilter{Loaders: splitList(loaders), GameVersions: splitList(versions)}
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, strings.ToLower(part))
+		}
+	}
+	return out
+}
+
+// facets builds the search facets for the filter. Loaders are categories in
+// Modrinth's search index.
+func (f gameFilter) facets() [][]string {
+	var facets [][]string
+	if len(f.Loaders) > 0 {
+		var or []string
+		for _, l := range f.Loaders {
+			or = append(or, "categories:"+l)
+		}
+		facets = append(facets, or)
+	}
+	if len(f.GameVersions) > 0 {
+		var or []string
+		for _, v := range f.GameVersions {
+			or = append(or, "versions:"+v)
+		}
+		facets = append(facets, or)
+	}
+	return facets
+}
+
+// Search finds projects matching the query. Modpacks are left out: they are
+// whole instances, not something to deploy into a mod directory. Category
+// and every tag each narrow the results to projects in that category.
+func (m *Modrinth) Search(ctx context.Context, query source.SearchQuery) (source.SearchResult, error) {
+	pageSize := query.PageSize
+	if pageSize == 0 {
+		pageSize = 20
+	}
+
+	facets := [][]string{{"project_type!=modpack"}}
+	facets = append(facets, parseGameFilter(query.GameID).facets()...)
+	if query.Category != "" {
+		facets = append(facets, []string{"categories:" + query.Category})
+	}
+	for _, tag := range query.Tags {
+		facets = append(facets, []string{"categories:" + tag})
+	}
+
+	resp, err := m.client.Search(ctx, query.Query, facets, pageSize, query.Page*pageSize)
+	if err != nil {
+		return source.SearchResult{}, err
+	}
+
+	mods := make([]domain.Mod, len(resp.Hits))
+	for i, h := range resp.Hits {
+		mods[i] = hitToDomain(h, query.GameID)
+	}
+	return source.SearchResult{Mods: mods, TotalCount: resp.TotalHits, Page: query.Page, PageSize: pageSize}, nil
+}
+
+// GetMod retrieves a project by ID or slug. Version and Dependencies come
+// from the newest version matching the game filter; a project with no
+// matching version is still returned, with no version.
+func (m *Modrinth) GetMod(ctx context.Context, gameID, modID string) (*domain.Mod, error) {
+	project, err := m.client.GetProject(ctx, modID)
+	if err != nil {
+		return nil, err
+	}
+	filter := parseGameFilter(gameID)
+	versions, err := m.client.GetProjectVersions(ctx, project.ID, filter.Loaders, filter.GameVersions)
+	if err != nil {
+		return nil, err
+	}
+
+	mod := projectToDomain(*project, gameID)
+	if len(versions) > 0 {
+		mod.Version = versions[0].VersionNumber
+		if mod.Dependencies, err = m.requiredDependencies(ctx, versions[0]); err != nil {
+			return nil, err
+		}
+	}
+	return &mod, nil
+}
+
+// GetDependencies returns the required dependencies of the newest version
+// matching the game filter. Optional dependencies are the user's call,
+// incompatible ones must not be installed, and embedded ones ship inside
+// the mod, so none of those are returned.
+func (m *Modrinth) GetDependencies(ctx context.Context, mod *domain.Mod) ([]domain.ModReference, error) {
+	filter := parseGameFilter(mod.GameID)
+	versions, err := m.client.GetProjectVersions(ctx, mod.ID, filter.Loaders, filter.GameVersions)
+	if err != nil {
+		return nil, fmt.Errorf("fetching versions: %w", err)
+	}
+	if len(versions) == 0 {
+		return nil, nil
+	}
+	return m.requiredDependencies(ctx, versions[0])
+}
+
+// requiredDependencies lists v's required dependencies as project
+// references. A dependency that pins only a version is resolved to its
+// project.
+func (m *Modrinth) requiredDependencies(ctx context.Context, v Version) ([]domain.ModReference, error) {
+	var refs []domain.ModReference
+	seen := make(map[string]bool)
+	for _, dep := range v.Dependencies {
+		if dep.DependencyType != DependencyRequired {
+			continue
+		}
+		var projectID string
+		switch {
+		case dep.ProjectID != nil && *dep.ProjectID != "":
+			projectID = *dep.ProjectID
+		case dep.VersionID != nil && *dep.VersionID != "":
+			pinned, err := m.client.GetVersion(ctx, *dep.VersionID)
+			if err != nil {
+				return nil, fmt.Errorf("resolving dependency version %s: %w", *dep.VersionID, err)
+			}
+			projectID = pinned.ProjectID
+		default:
+			continue // only a file name: nothing Modrinth can serve
+		}
+		if seen[projectID] {
+			continue
+		}
+		seen[projectID] = true
+		refs = append(refs, domain.ModReference{SourceID: sourceID, ModID: projectID})
+	}
+	return refs, nil
+}
+
+// GetModFiles returns the files of every version matching the game filter,
+// newest first. Each file's ID is its SHA-1; both published hashes are
+// declared so the download is verified.
+func (m *Modrinth) GetModFiles(ctx context.Context, mod *domain.Mod) ([]domain.DownloadableFile, error) {
+	filter := parseGameFilter(mod.GameID)
+	versions, err := m.client.GetProjectVersions(ctx, mod.ID, filter.Loaders, filter.GameVersions)
+	if err != nil {
+		return nil, fmt.Errorf("getting mod files: %w", err)
+	}
+
+	var files []domain.DownloadableFile
+	for _, v := range versions {
+		description := strings.Join(v.Loaders, ", ")
+		if len(v.GameVersions) > 0 {
+			description += " for " + strings.Join(v.GameVersions, ", ")
+		}
+		for _, f := range v.Files {
+			files = append(files, domain.DownloadableFile{
+				ID:          f.Hashes.SHA1,
+				Name:        v.Name,
+				FileName:    f.Filename,
+				Version:     v.VersionNumber,
+				Size:        f.Size,
+				IsPrimary:   f.Primary || len(v.Files) == 1,
+				Category:    versionTypeName(v.VersionType),
+				Description: description,
+				SHA512:      f.Hashes.SHA512,
+				SHA1:        f.Hashes.SHA1,
+			})
+		}
+	}
+	return files, nil
+}
+
+// GetDownloadURL resolves a file ID (SHA-1) to its CDN URL.
+func (m *Modrinth) GetDownloadURL(ctx context.Context, mod *domain.Mod, fileID string) (string, error) {
+	if !sha1Pattern.MatchString(fileID) {
+		return "", fmt.Errorf("invalid file ID %q: Modrinth file IDs are SHA-1 hashes", fileID)
+	}
+	v, err := m.client.GetVersionFromHash(ctx, fileID)
+	if err != nil {
+		return "", fmt.Errorf("getting download URL: %w", err)
+	}
+	if f, ok := fileByHash(*v, fileID); ok {
+		return f.URL, nil
+	}
+	return "", fmt.Errorf("%w: file %s not in version %s", domain.ErrModNotFound, fileID, v.ID)
+}
+
+// CheckUpdates asks Modrinth, in one request per game filter, for the newest
+// matching version of every installed file. Mods whose file IDs are not
+// Modrinth hashes (e.g. imported by hand and re-linked) are checked one by
+// one through GetMod instead.
+func (m *Modrinth) CheckUpdates(ctx context.Context, installed []domain.InstalledMod) ([]domain.Update, error) {
+	var updates []domain.Update
+	var fetchErrs []error
+
+	byGame := make(map[string][]domain.InstalledMod)
+	var gameOrder []string
+	var byVersion []domain.InstalledMod
+	for _, inst := range installed {
+		if hashOf(inst) == "" {
+			byVersion = append(byVersion, inst)
+			continue
+		}
+		if _, ok := byGame[inst.GameID]; !ok {
+			gameOrder = append(gameOrder, inst.GameID)
+		}
+		byGame[inst.GameID] = append(byGame[inst.GameID], inst)
+	}
+
+	for _, gameID := range gameOrder {
+		mods := byGame[gameID]
+		filter := parseGameFilter(gameID)
+		req := UpdateRequest{Algorithm: "sha1", Loaders: filter.Loaders, GameVersions: filter.GameVersions}
+		for _, inst := range mods {
+			req.Hashes = append(req.Hashes, inst.FileIDs...)
+		}
+		latest, err := m.client.LatestVersionsFromHashes(ctx, req)
+		if err != nil {
+			fetchErrs = append(fetchErrs, err)
+			continue
+		}
+		for _, inst := range mods {
+			v, ok := latest[hashOf(inst)]
+			if !ok || v.VersionNumber == "" || v.VersionNumber == inst.Version {
+				continue
+			}
+			updates = append(updates, domain.Update{
+				InstalledMod:       inst,
+				NewVersion:         v.VersionNumber,
+				Changelog:          v.Changelog,
+				FileIDReplacements: fileReplacements(inst, latest, v),
+			})
+		}
+	}
+
+	for i, inst := range byVersion {
+		select {
+		case <-ctx.Done():
+			return updates, ctx.Err()
+		default:
+		}
+		if fn, ok := ctx.Value(domain.UpdateProgressContextKey).(domain.UpdateProgressFunc); ok && fn != nil {
+			fn(i+1, len(byVersion), inst.Name)
+		}
+		remote, err := m.GetMod(ctx, inst.GameID, inst.ID)
+		if err != nil {
+			fetchErrs = append(fetchErrs, fmt.Errorf("%s (id %s): %w", inst.Name, inst.ID, err))
+			continue
+		}
+		if remote.Version == "" || remote.Version == inst.Version {
+			continue
+		}
+		updates = append(updates, domain.Update{InstalledMod: inst, NewVersion: remote.Version})
+	}
+
+	if len(fetchErrs) > 0 {
+		return updates, fmt.Errorf("update check skipped %d lookup(s): %w", len(fetchErrs), errors.Join(fetchErrs...))
+	}
+	return updates, nil
+}
+
+// hashOf returns the first of inst's file IDs that is a Modrinth hash, or "".
+func hashOf(inst domain.InstalledMod) string {
+	for _, id := range inst.FileIDs {
+		if sha1Pattern.MatchString(id) {
+			return id
+		}
+	}
+	return ""
+}
+
+// fileReplacements maps each of inst's files Modrinth reported on to the
+// primary file of the new version v. Files it did not report on are left
+// out, so they are kept as they are.
+func fileReplacements(inst domain.InstalledMod, latest map[string]Version, v Version) map[string]string {
+	primary, ok := primaryFile(v)
+	if !ok {
+		return nil
+	}
+	repl := make(map[string]string)
+	for _, id := range inst.FileIDs {
+		if _, known := latest[id]; !known {
+			continue
+		}
+		repl[id] = primary.Hashes.SHA1
+	}
+	return repl
+}
+
+// primaryFile returns v's primary file, or its only file.
+func primaryFile(v Version) (VersionFile, bool) {
+	for _, f := range v.Files {
+		if f.Primary {
+			return f, true
+		}
+	}
+	if len(v.Files) == 1 {
+		return v.Files[0], true
+	}
+	return VersionFile{}, false
+}
+
+func fileByHash(v Version, sha1 string) (VersionFile, bool) {
+	for _, f := range v.Files {
+		if strings.EqualFold(f.Hashes.SHA1, sha1) {
+			return f, true
+		}
+	}
+	return VersionFile{}, false
+}
+
+// hitToDomain converts a search hit to domain.Mod. Search hits carry no
+// version number; GetMod fills it in.
+func hitToDomain(h SearchHit, gameID string) domain.Mod {
+	return domain.Mod{
+		ID:           h.ProjectID,
+		SourceID:     sourceID,
+		Name:         h.Title,
+		Author:       h.Author,
+		Summary:      h.Description,
+		GameID:       gameID,
+		Category:     firstOf(h.Categories),
+		Downloads:    h.Downloads,
+		Endorsements: int64Ptr(h.Follows),
+		PictureURL:   h.IconURL,
+		SourceURL:    projectURL(h.ProjectType, h.Slug),
+		UpdatedAt:    h.DateModified,
+	}
+}
+
+// projectToDomain converts a project to domain.Mod. The project's body is
+// its full description, separate from the one-line summary.
+func projectToDomain(p Project, gameID string) domain.Mod {
+	return domain.Mod{
+		ID:           p.ID,
+		SourceID:     sourceID,
+		Name:         p.Title,
+		Summary:      p.Description,
+		Description:  p.Body,
+		GameID:       gameID,
+		Category:     firstOf(p.Categories),
+		Downloads:    p.Downloads,
+		Endorsements: int64Ptr(p.Followers),
+		PictureURL:   p.IconURL,
+		SourceURL:    projectURL(p.ProjectType, p.Slug),
+		UpdatedAt:    p.Updated,
+	}
+}
+
+func projectURL(projectType, slug string) string {
+	if slug == "" {
+		return ""
+	}
+	if projectType == "" {
+		projectType = "project"
+	}
+	return "https://modrinth.com/" + projectType + "/" + slug
+}
+
+// versionTypeName converts a version type to a display name
+func versionTypeName(versionType string) string {
+	switch versionType {
+	case VersionTypeRelease:
+		return "Release"
+	case VersionTypeBeta:
+		return "Beta"
+	case VersionTypeAlpha:
+		return "Alpha"
+	default:
+		return "Unknown"
+	}
+}
+
+func firstOf(s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	return s[0]
+}
+
+// int64Ptr returns a pointer to the given int64 value.
+func int64Ptr(v int64) *int64 { return &v }
@@ -0,0 +1,352 @@
+package modrinth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModrinth_ImplementsModSource(t *testing.T) {
+	// Compile-time check that Modrinth implements ModSource
+	var _ source.ModSource = (*Modrinth)(nil)
+}
+
+// Compile-time conformance pins for the optional metadata interfaces a
+// built-in implements.
+var (
+	_ source.EnvKeyProvider           = (*Modrinth)(nil)
+	_ source.AuthInstructionsProvider = (*Modrinth)(nil)
+	_ source.TypeLabeler              = (*Modrinth)(nil)
+	_ source.CapabilityReporter       = (*Modrinth)(nil)
+)
+
+// Hashes used across the fixtures: 40 hex chars each, as real SHA-1s are.
+var (
+	sha1Old = strings.Repeat("a", 40)
+	sha1New = strings.Repeat("b", 40)
+	sha1Lib = strings.Repeat("c", 40)
+)
+
+func TestModrinth_Metadata(t *testing.T) {
+	m := New(nil, "")
+	assert.Equal(t, "modrinth", m.ID())
+	assert.Equal(t, "Modrinth", m.Name())
+	assert.Equal(t, "MODRINTH_API_KEY", m.EnvKey())
+	assert.Equal(t, "built-in", m.TypeLabel())
+	assert.Equal(t, source.Capabilities{Search: true, Dependencies: true, Updates: true, Auth: true, Versions: true}, m.Capabilities())
+	assert.False(t, m.IsAuthenticated())
+
+	m.SetAPIKey("mrp_token")
+	assert.True(t, m.IsAuthenticated())
+}
+
+func TestParseGameFilter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want gameFilter
+	}{
+		{"", gameFilter{}},
+		{"fabric", gameFilter{Loaders: []string{"fabric"}}},
+		{"fabric@1.20.1", gameFilter{Loaders: []string{"fabric"}, GameVersions: []string{"1.20.1"}}},
+		{"NeoForge, forge@1.21.1,1.21", gameFilter{Loaders: []string{"neoforge", "forge"}, GameVersions: []string{"1.21.1", "1.21"}}},
+		{"@1.20.1", gameFilter{GameVersions: []string{"1.20.1"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseGameFilter(tt.in))
+		})
+	}
+}
+
+func TestModrinth_Search(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var facets [][]string
+		require.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("facets")), &facets))
+		assert.Equal(t, [][]string{
+			{"project_type!=modpack"},
+			{"categories:fabric", "categories:quilt"},
+			{"versions:1.20.1"},
+			{"categories:optimization"},
+		}, facets)
+		assert.Equal(t, "20", r.URL.Query().Get("offset"), "page 1 of 20")
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"hits": [{
+				"project_id": "AANobbMI",
+				"slug": "sodium",
+				"project_type": "mod",
+				"title": "Sodium",
+				"description": "A modern rendering engine",
+				"author": "jellysquid3",
+				"categories": ["optimization", "fabric"],
+				"downloads": 50000000,
+				"follows": 30000,
+				"icon_url": "https://cdn.modrinth.com/sodium.png",
+				"date_modified": "2024-01-15T10:30:00Z"
+			}],
+			"offset": 20, "limit": 20, "total_hits": 21
+		}`))
+	}))
+	defer server.Close()
+
+	m := New(server.Client(), "")
+	m.client.SetBaseURL(server.URL)
+
+	result, err := m.Search(context.Background(), source.SearchQuery{
+		GameID:   "fabric,quilt@1.20.1",
+		Query:    "sodium",
+		Category: "optimization",
+		Page:     1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 21, result.TotalCount)
+	assert.Equal(t, 1, result.Page)
+	assert.Equal(t, 20, result.PageSize)
+	require.Len(t, result.Mods, 1)
+
+	mod := result.Mods[0]
+	assert.Equal(t, "AANobbMI", mod.ID)
+	assert.Equal(t, "modrinth", mod.SourceID)
+	assert.Equal(t, "Sodium", mod.Name)
+	assert.Equal(t, "jellysquid3", mod.Author)
+	assert.Equal(t, "A modern rendering engine", mod.Summary)
+	assert.Equal(t, "fabric,quilt@1.20.1", mod.GameID)
+	assert.Equal(t, "optimization", mod.Category)
+	assert.Equal(t, int64(50000000), mod.Downloads)
+	assert.Equal(t, int64Ptr(30000), mod.Endorsements)
+	assert.Equal(t, "https://modrinth.com/mod/sodium", mod.SourceURL)
+}
+
+// versionsFixture serves two versions of project P1, newest first, with a
+// required project dependency, a required version-pinned dependency, and an
+// optional and an incompatible one that must be ignored.
+const versionsFixture = `[
+	{
+		"id": "v2",
+		"project_id": "P1",
+		"name": "Sodium 0.5.4",
+		"version_number": "0.5.4",
+		"changelog": "Faster",
+		"version_type": "release",
+		"loaders": ["fabric"],
+		"game_versions": ["1.20.1"],
+		"dependencies": [
+			{"project_id": "LIB", "dependency_type": "required"},
+			{"version_id": "PINNED", "dependency_type": "required"},
+			{"project_id": "OPT", "dependency_type": "optional"},
+			{"project_id": "BAD", "dependency_type": "incompatible"},
+			{"project_id": "LIB", "dependency_type": "required"}
+		],
+		"files": [
+			{"hashes": {"sha1": "` + "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb" + `", "sha512": "ff"}, "url": "https://cdn.modrinth.com/new.jar", "filename": "sodium-0.5.4.jar", "primary": true, "size": 1000},
+			{"hashes": {"sha1": "` + "cccccccccccccccccccccccccccccccccccccccc" + `", "sha512": "ee"}, "url": "https://cdn.modrinth.com/src.jar", "filename": "sodium-0.5.4-sources.jar", "primary": false, "size": 500}
+		]
+	},
+	{
+		"id": "v1",
+		"project_id": "P1",
+		"name": "Sodium 0.5.3",
+		"version_number": "0.5.3",
+		"version_type": "beta",
+		"loaders": ["fabric", "quilt"],
+		"game_versions": ["1.20.1"],
+		"files": [
+			{"hashes": {"sha1": "` + "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" + `", "sha512": "dd"}, "url": "https://cdn.modrinth.com/old.jar", "filename": "sodium-0.5.3.jar", "primary": false, "size": 900}
+		]
+	}
+]`
+
+// newVersionsServer serves the versions fixture, project P1, and the
+// PINNED version (of project PINLIB).
+func newVersionsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/project/P1/version":
+			assert.Equal(t, `["fabric"]`, r.URL.Query().Get("loaders"))
+			assert.Equal(t, `["1.20.1"]`, r.URL.Query().Get("game_versions"))
+			_, _ = w.Write([]byte(versionsFixture))
+		case "/v2/project/sodium":
+			_, _ = w.Write([]byte(`{"id": "P1", "slug": "sodium", "project_type": "mod", "title": "Sodium", "description": "Short", "body": "Long body"}`))
+		case "/v2/version/PINNED":
+			_, _ = w.Write([]byte(`{"id": "PINNED", "project_id": "PINLIB"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestModrinth_GetMod(t *testing.T) {
+	server := newVersionsServer(t)
+	defer server.Close()
+
+	m := New(server.Client(), "")
+	m.client.SetBaseURL(server.URL)
+
+	mod, err := m.GetMod(context.Background(), "fabric@1.20.1", "sodium")
+	require.NoError(t, err)
+	assert.Equal(t, "P1", mod.ID, "slug lookups resolve to the stable project ID")
+	assert.Equal(t, "0.5.4", mod.Version)
+	assert.Equal(t, "Short", mod.Summary)
+	assert.Equal(t, "Long body", mod.Description)
+	assert.Equal(t, []domain.ModReference{
+		{SourceID: "modrinth", ModID: "LIB"},
+		{SourceID: "modrinth", ModID: "PINLIB"},
+	}, mod.Dependencies)
+}
+
+func TestModrinth_GetDependencies(t *testing.T) {
+	server := newVersionsServer(t)
+	defer server.Close()
+
+	m := New(server.Client(), "")
+	m.client.SetBaseURL(server.URL)
+
+	deps, err := m.GetDependencies(context.Background(), &domain.Mod{ID: "P1", GameID: "fabric@1.20.1"})
+	require.NoError(t, err)
+
+	// Only required dependencies, deduplicated; a version pin resolves to
+	// its project.
+	assert.Equal(t, []domain.ModReference{
+		{SourceID: "modrinth", ModID: "LIB"},
+		{SourceID: "modrinth", ModID: "PINLIB"},
+	}, deps)
+}
+
+func TestModrinth_GetModFiles(t *testing.T) {
+	server := newVersionsServer(t)
+	defer server.Close()
+
+	m := New(server.Client(), "")
+	m.client.SetBaseURL(server.URL)
+
+	files, err := m.GetModFiles(context.Background(), &domain.Mod{ID: "P1", GameID: "fabric@1.20.1"})
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	assert.Equal(t, sha1New, files[0].ID)
+	assert.Equal(t, "sodium-0.5.4.jar", files[0].FileName)
+	assert.Equal(t, "0.5.4", files[0].Version)
+	assert.Equal(t, int64(1000), files[0].Size)
+	assert.True(t, files[0].IsPrimary)
+	assert.Equal(t, "Release", files[0].Category)
+	assert.Equal(t, "fabric for 1.20.1", files[0].Description)
+	assert.Equal(t, "ff", files[0].SHA512, "SHA-512 is declared for verification")
+	assert.Equal(t, sha1New, files[0].SHA1)
+
+	assert.Equal(t, sha1Lib, files[1].ID)
+	assert.False(t, files[1].IsPrimary)
+
+	assert.Equal(t, sha1Old, files[2].ID)
+	assert.True(t, files[2].IsPrimary, "a version's only file is its primary")
+	assert.Equal(t, "Beta", files[2].Category)
+}
+
+func TestModrinth_GetDownloadURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/version_file/"+sha1New, r.URL.Path)
+		assert.Equal(t, "sha1", r.URL.Query().Get("algorithm"))
+		_, _ = w.Write([]byte(`{"id": "v2", "files": [
+			{"hashes": {"sha1": "` + sha1Lib + `"}, "url": "https://cdn.modrinth.com/src.jar"},
+			{"hashes": {"sha1": "` + sha1New + `"}, "url": "https://cdn.modrinth.com/new.jar"}
+		]}`))
+	}))
+	defer server.Close()
+
+	m := New(server.Client(), "")
+	m.client.SetBaseURL(server.URL)
+
+	url, err := m.GetDownloadURL(context.Background(), &domain.Mod{ID: "P1"}, sha1New)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.modrinth.com/new.jar", url)
+}
+
+func TestModrinth_GetDownloadURL_RejectsNonHashID(t *testing.T) {
+	m := New(nil, "")
+	_, err := m.GetDownloadURL(context.Background(), &domain.Mod{ID: "P1"}, "12345")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SHA-1")
+}
+
+func TestModrinth_CheckUpdates(t *testing.T) {
+	var bulkRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/version_files/update":
+			bulkRequests++
+			var req UpdateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "sha1", req.Algorithm)
+			assert.Equal(t, []string{"fabric"}, req.Loaders)
+			assert.Equal(t, []string{"1.20.1"}, req.GameVersions)
+			assert.ElementsMatch(t, []string{sha1Old, sha1Lib}, req.Hashes)
+			_, _ = w.Write([]byte(`{
+				"` + sha1Old + `": {"id": "v2", "version_number": "0.5.4", "changelog": "Faster", "files": [
+					{"hashes": {"sha1": "` + sha1New + `"}, "primary": true}
+				]},
+				"` + sha1Lib + `": {"id": "L1", "version_number": "1.0.0", "files": [
+					{"hashes": {"sha1": "` + sha1Lib + `"}, "primary": true}
+				]}
+			}`))
+		case "/v2/project/HANDMADE":
+			_, _ = w.Write([]byte(`{"id": "HANDMADE", "title": "Hand Imported"}`))
+		case "/v2/project/HANDMADE/version":
+			_, _ = w.Write([]byte(`[{"id": "h2", "version_number": "2.0.0"}]`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	m := New(server.Client(), "")
+	m.client.SetBaseURL(server.URL)
+
+	installed := []domain.InstalledMod{
+		{Mod: domain.Mod{ID: "P1", Name: "Sodium", Version: "0.5.3", GameID: "fabric@1.20.1"}, FileIDs: []string{sha1Old}},
+		{Mod: domain.Mod{ID: "LIB", Name: "Lib", Version: "1.0.0", GameID: "fabric@1.20.1"}, FileIDs: []string{sha1Lib}},
+		{Mod: domain.Mod{ID: "HANDMADE", Name: "Hand Imported", Version: "1.0.0", GameID: "fabric@1.20.1"}, FileIDs: []string{"local-file"}},
+	}
+
+	updates, err := m.CheckUpdates(context.Background(), installed)
+	require.NoError(t, err)
+	assert.Equal(t, 1, bulkRequests, "one bulk lookup per game filter")
+	require.Len(t, updates, 2)
+
+	assert.Equal(t, "P1", updates[0].InstalledMod.ID)
+	assert.Equal(t, "0.5.4", updates[0].NewVersion)
+	assert.Equal(t, "Faster", updates[0].Changelog)
+	assert.Equal(t, map[string]string{sha1Old: sha1New}, updates[0].FileIDReplacements)
+
+	assert.Equal(t, "HANDMADE", updates[1].InstalledMod.ID, "non-hash file IDs fall back to GetMod")
+	assert.Equal(t, "2.0.0", updates[1].NewVersion)
+}
+
+func TestModrinth_CheckUpdates_ReportsFailedLookups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	m := New(server.Client(), "")
+	m.client.SetBaseURL(server.URL)
+
+	updates, err := m.CheckUpdates(context.Background(), []domain.InstalledMod{
+		{Mod: domain.Mod{ID: "P1", Version: "0.5.3", GameID: "fabric"}, FileIDs: []string{sha1Old}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "skipped 1 lookup")
+	assert.Empty(t, updates)
+}
@@ -0,0 +1,106 @@
+package modrinth
+
+import "time"
+
+// Dependency types a version can declare.
+const (
+	DependencyRequired     = "required"
+	DependencyOptional     = "optional"
+	DependencyIncompatible = "incompatible"
+	DependencyEmbedded     = "embedded"
+)
+
+// Version types, in decreasing stability.
+const (
+	VersionTypeRelease = "release"
+	VersionTypeBeta    = "beta"
+	VersionTypeAlpha   = "alpha"
+)
+
+// SearchResponse is the /v2/search response.
+type SearchResponse struct {
+	Hits      []SearchHit `json:"hits"`
+	Offset    int         `json:"offset"`
+	Limit     int         `json:"limit"`
+	TotalHits int         `json:"total_hits"`
+}
+
+// SearchHit is one project in a search response.
+type SearchHit struct {
+	ProjectID    string    `json:"project_id"`
+	Slug         string    `json:"slug"`
+	ProjectType  string    `json:"project_type"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Author       string    `json:"author"`
+	Categories   []string  `json:"categories"`
+	Downloads    int64     `json:"downloads"`
+	Follows      int64     `json:"follows"`
+	IconURL      string    `json:"icon_url"`
+	DateModified time.Time `json:"date_modified"`
+}
+
+// Project is a Modrinth project (mod, plugin, resource pack, ...).
+type Project struct {
+	ID           string    `json:"id"`
+	Slug         string    `json:"slug"`
+	ProjectType  string    `json:"project_type"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Body         string    `json:"body"`
+	Categories   []string  `json:"categories"`
+	Loaders      []string  `json:"loaders"`
+	GameVersions []string  `json:"game_versions"`
+	Downloads    int64     `json:"downloads"`
+	Followers    int64     `json:"followers"`
+	IconURL      string    `json:"icon_url"`
+	Updated      time.Time `json:"updated"`
+}
+
+// Version is one release of a project, with its files and dependencies.
+type Version struct {
+	ID            string        `json:"id"`
+	ProjectID     string        `json:"project_id"`
+	Name          string        `json:"name"`
+	VersionNumber string        `json:"version_number"`
+	Changelog     string        `json:"changelog"`
+	VersionType   string        `json:"version_type"`
+	Loaders       []string      `json:"loaders"`
+	GameVersions  []string      `json:"game_versions"`
+	DatePublished time.Time     `json:"date_published"`
+	Dependencies  []Dependency  `json:"dependencies"`
+	Files         []VersionFile `json:"files"`
+}
+
+// Dependency is one entry in a version's dependency list. Either ID may be
+// null: a dependency can pin a version, name a project, or both.
+type Dependency struct {
+	VersionID      *string `json:"version_id"`
+	ProjectID      *string `json:"project_id"`
+	FileName       *string `json:"file_name"`
+	DependencyType string  `json:"dependency_type"`
+}
+
+// VersionFile is one downloadable file of a version.
+type VersionFile struct {
+	Hashes   FileHashes `json:"hashes"`
+	URL      string     `json:"url"`
+	Filename string     `json:"filename"`
+	Primary  bool       `json:"primary"`
+	Size     int64      `json:"size"`
+}
+
+// FileHashes are the checksums Modrinth publishes for every file.
+type FileHashes struct {
+	SHA512 string `json:"sha512"`
+	SHA1   string `json:"sha1"`
+}
+
+// UpdateRequest is the body of the bulk latest-version lookup
+// (/v2/version_files/update).
+type UpdateRequest struct {
+	Hashes       []string `json:"hashes"`
+	Algorithm    string   `json:"algorithm"`
+	Loaders      []string `json:"loaders,omitempty"`
+	GameVersions []string `json:"game_versions,omitempty"`
+}
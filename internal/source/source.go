@@ -106,12 +106,27 @@ type GameCatalog interface {
 	ListGames(ctx context.Context) ([]GameEntry, error)
 }
 
+// GameCatalogOf returns src's game catalog, if it has one. A source whose
+// catalog depends on its configuration (an exec plugin that may or may not
+// serve one) implements GameCatalog unconditionally and answers through
+// HasGameCatalog.
+func GameCatalogOf(src ModSource) (GameCatalog, bool) {
+	catalog, ok := src.(GameCatalog)
+	if !ok {
+		return nil, false
+	}
+	if h, ok := src.(interface{ HasGameCatalog() bool }); ok && !h.HasGameCatalog() {
+		return nil, false
+	}
+	return catalog, true
+}
+
 // TypeLabeler names the source's kind for listings (directory/manifest/api/
-// built-in). Absent: "unknown".
+// exec/releases/built-in). Absent: "unknown".
 type TypeLabeler interface{ TypeLabel() string }
 
 // TypeLabelOf returns src's self-reported kind ("directory"/"manifest"/
-// "api" for custom sources, "built-in" for NexusMods/CurseForge), falling
+// "api"/"exec"/"releases" for custom sources, "built-in" for NexusMods/CurseForge), falling
 // back to "unknown" when src implements no TypeLabeler. Mirrors
 // CapabilitiesOf's optional-interface pattern; the fallback is unreachable
 // in production (every real source implements TypeLabeler), reachable only
@@ -143,6 +158,16 @@ type DownloadHeaderProvider interface {
 	DownloadHeaders(fileURL string) map[string]string
 }
 
+// LayoutMapper is implemented by sources whose archives follow a packaging
+// convention other than the game's mod directory layout (Thunderstore's
+// BepInEx packages). Service.DownloadModToCache asks it where each extracted
+// member goes: LayoutPath takes and returns slash-separated paths, the
+// result relative to the game's ModPath; "" leaves the member out. Not
+// consulted for DeployCopy games, which deploy archives as-is.
+type LayoutMapper interface {
+	LayoutPath(mod *domain.Mod, member string) string
+}
+
 // MergeCompiler is implemented by sources whose compile-eligible files must
 // be merged across every enabled mod into ONE profile-level artifact rather
 // than compiled per-mod (#197: Icarus's cross-mod table merge - a whole-pak
@@ -69,6 +69,25 @@ func TestLoadKnownGames_IcarusEntry(t *testing.T) {
 	assert.Equal(t, map[string]string{"icarus": "icarus"}, info.Sources)
 }
 
+// TestLoadKnownGames_ThunderstoreEntries pins the BepInEx games wired to
+// the thunderstore source: mod_path is the BepInEx folder and the source
+// game ID is the Thunderstore community slug.
+func TestLoadKnownGames_ThunderstoreEntries(t *testing.T) {
+	games, err := LoadKnownGames(t.TempDir())
+	require.NoError(t, err)
+	for appID, community := range map[string]string{
+		"892970":  "valheim",
+		"1966720": "lethal-company",
+		"632360":  "riskofrain2",
+	} {
+		info, ok := games[appID]
+		require.True(t, ok, appID)
+		assert.Equal(t, "BepInEx", info.ModPath, appID)
+		assert.Equal(t, "", info.DeployMode, appID)
+		assert.Equal(t, map[string]string{"thunderstore": community}, info.Sources, appID)
+	}
+}
+
 // TestLoadKnownGames_OverrideFile_DeployModeAndSources pins that the two new
 // optional fields round-trip through a user's ~/.config/lmm/steam-games.yaml
 // override exactly like every existing field already does — the schema
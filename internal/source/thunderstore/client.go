@@ -0,0 +1,127 @@
+package thunderstore
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source/httpclient"
+)
+
+const (
+	defaultBaseURL = "https://thunderstore.io"
+
+	// indexRefresh is how long a fetched package index is reused. Indexes
+	// run to tens of megabytes for the big communities, so one search,
+	// install, or update run must not fetch it more than once.
+	indexRefresh = 15 * time.Minute
+)
+
+// Client reads Thunderstore's public package indexes. Thunderstore has no
+// search endpoint in its stable API: the whole index of a community is
+// fetched and cached in memory, as r2modman does.
+type Client struct {
+	rest *httpclient.Client
+	now  func() time.Time // injectable for refresh tests
+
+	mu      sync.Mutex
+	indexes map[string]cachedIndex
+}
+
+// cachedIndex is one community's package index and when it was fetched.
+type cachedIndex struct {
+	packages  []Package
+	byName    map[string]int // full_name -> index into packages
+	fetchedAt time.Time
+}
+
+// NewClient creates a new Thunderstore API client
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		rest: httpclient.New(httpclient.Options{
+			HTTPClient:  httpClient,
+			BaseURL:     defaultBaseURL,
+			AuthHeader:  "Authorization", // never sent: every endpoint used is public
+			AuthLabel:   "Thunderstore",
+			ErrorMapper: mapError,
+		}),
+		now:     time.Now,
+		indexes: make(map[string]cachedIndex),
+	}
+}
+
+// SetBaseURL overrides the API base URL — primarily used by tests that front
+// the client with an httptest server.
+func (c *Client) SetBaseURL(u string) {
+	c.rest.SetBaseURL(u)
+}
+
+// mapError translates 404 (an unknown community) to ErrGameNotFound.
+func mapError(status int, body []byte, path string) error {
+	if status == http.StatusNotFound {
+		return fmt.Errorf("%w: no Thunderstore community at %s", domain.ErrGameNotFound, path)
+	}
+	return nil
+}
+
+// Packages returns the package index of community, from cache while it is
+// fresh. The returned slice is shared and must not be modified.
+func (c *Client) Packages(ctx context.Context, community string) ([]Package, error) {
+	idx, err := c.index(ctx, community)
+	if err != nil {
+		return nil, err
+	}
+	return idx.packages, nil
+}
+
+// Package looks up one package of community by its full name
+// ("<Owner>-<Name>", case-sensitive as Thunderstore's own URLs are).
+func (c *Client) Package(ctx context.Context, community, fullName string) (*Package, error) {
+	idx, err := c.index(ctx, community)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := idx.byName[fullName]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s in community %s", domain.ErrModNotFound, fullName, community)
+	}
+	return &idx.packages[i], nil
+}
+
+// index returns community's cached index, fetching it when missing or
+// stale. The lock is not held across the fetch: two callers racing past an
+// expired entry may both fetch, which is harmless.
+func (c *Client) index(ctx context.Context, community string) (cachedIndex, error) {
+	if community == "" {
+		return cachedIndex{}, fmt.Errorf("no Thunderstore community configured: map the game to one (e.g. \"valheim\") in games.yaml")
+	}
+
+	c.mu.Lock()
+	idx, ok := c.indexes[community]
+	c.mu.Unlock()
+	if ok && c.now().Sub(idx.fetchedAt) < indexRefresh {
+		return idx, nil
+	}
+
+	var packages []Package
+	path := "/c/" + url.PathEscape(community) + "/api/v1/package/"
+	if err := c.rest.DoJSON(ctx, http.MethodGet, path, &packages); err != nil {
+		return cachedIndex{}, fmt.Errorf("fetching package index for %s: %w", community, err)
+	}
+	idx = cachedIndex{packages: packages, byName: make(map[string]int, len(packages)), fetchedAt: c.now()}
+	for i, p := range packages {
+		idx.byName[p.FullName] = i
+	}
+
+	c.mu.Lock()
+	c.indexes[community] = idx
+	c.mu.Unlock()
+	return idx, nil
+}
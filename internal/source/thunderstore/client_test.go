@@ -0,0 +1,77 @@
+package thunderstore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_PackagesCachesIndex(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/c/valheim/api/v1/package/", r.URL.Path)
+		_, _ = w.Write([]byte(`[{"name": "Mod", "full_name": "Owner-Mod", "owner": "Owner"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.SetBaseURL(server.URL)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+
+	packages, err := client.Packages(context.Background(), "valheim")
+	require.NoError(t, err)
+	require.Len(t, packages, 1)
+
+	p, err := client.Package(context.Background(), "valheim", "Owner-Mod")
+	require.NoError(t, err)
+	assert.Equal(t, "Owner", p.Owner)
+	assert.Equal(t, 1, requests, "a fresh index is reused")
+
+	now = now.Add(indexRefresh)
+	_, err = client.Packages(context.Background(), "valheim")
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests, "a stale index is fetched again")
+}
+
+func TestClient_PackageNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.SetBaseURL(server.URL)
+
+	_, err := client.Package(context.Background(), "valheim", "Owner-Missing")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrModNotFound)
+}
+
+func TestClient_UnknownCommunity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.Client())
+	client.SetBaseURL(server.URL)
+
+	_, err := client.Packages(context.Background(), "no-such-game")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrGameNotFound)
+}
+
+func TestClient_NoCommunity(t *testing.T) {
+	client := NewClient(nil)
+	_, err := client.Packages(context.Background(), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no Thunderstore community configured")
+}
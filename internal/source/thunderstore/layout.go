@@ -0,0 +1,59 @@
+package thunderstore
+
+import (
+	"path"
+	"strings"
+)
+
+// BepInEx folders whose contents are namespaced per package, the way
+// r2modman installs them: plugins/<Owner>-<Name>/..., so two packages
+// shipping a file of the same name cannot collide.
+var namespacedDirs = map[string]bool{"plugins": true, "patchers": true, "monomod": true}
+
+// BepInEx folders shared by every package: config files are read from
+// config/ itself, and core/ holds the loader's own assemblies.
+var sharedDirs = map[string]bool{"config": true, "core": true}
+
+// bepInExPath maps a member of package fullName's zip (slash-separated) to
+// its path under the game's BepInEx directory, or "" to leave it out.
+//
+//   - A leading "BepInEx/" is dropped: the mod path already is that folder.
+//   - plugins/, patchers/ and monomod/ are nested under the package's full
+//     name; config/ and core/ are kept as they are.
+//   - Anything else (loose DLLs, manifest.json, icon.png, README.md) goes
+//     to plugins/<full name>/, as r2modman puts it.
+//
+// A BepInExPack (the loader itself) ships BepInEx one level down, beside
+// doorstop files that belong in the game's root directory, outside the mod
+// path: only its BepInEx folder is mapped.
+func bepInExPath(fullName, member string) string {
+	member = strings.TrimPrefix(path.Clean("/"+member), "/")
+	parts := strings.Split(member, "/")
+
+	if isLoaderPack(fullName) {
+		if len(parts) < 3 || !strings.EqualFold(parts[1], "BepInEx") {
+			return ""
+		}
+		parts = parts[2:]
+	} else if len(parts) > 1 && strings.EqualFold(parts[0], "BepInEx") {
+		parts = parts[1:]
+	}
+
+	if len(parts) > 1 {
+		dir := strings.ToLower(parts[0])
+		switch {
+		case namespacedDirs[dir]:
+			return path.Join(append([]string{dir, fullName}, parts[1:]...)...)
+		case sharedDirs[dir]:
+			return path.Join(append([]string{dir}, parts[1:]...)...)
+		}
+	}
+	return path.Join(append([]string{"plugins", fullName}, parts...)...)
+}
+
+// isLoaderPack reports whether fullName is a BepInExPack, e.g.
+// "denikson-BepInExPack_Valheim" or "BepInEx-BepInExPack".
+func isLoaderPack(fullName string) bool {
+	_, name, _ := strings.Cut(fullName, "-")
+	return strings.HasPrefix(strings.ToLower(name), "bepinexpack")
+}
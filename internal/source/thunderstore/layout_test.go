@@ -0,0 +1,55 @@
+package thunderstore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBepInExPath(t *testing.T) {
+	const pkg = "Owner-Mod"
+	tests := []struct {
+		member string
+		want   string
+	}{
+		{"plugins/Mod.dll", "plugins/Owner-Mod/Mod.dll"},
+		{"plugins/assets/bundle", "plugins/Owner-Mod/assets/bundle"},
+		{"Plugins/Mod.dll", "plugins/Owner-Mod/Mod.dll"},
+		{"patchers/Patch.dll", "patchers/Owner-Mod/Patch.dll"},
+		{"monomod/Hook.mm.dll", "monomod/Owner-Mod/Hook.mm.dll"},
+		{"config/owner.mod.cfg", "config/owner.mod.cfg"},
+		{"core/Loader.dll", "core/Loader.dll"},
+		{"BepInEx/plugins/Mod.dll", "plugins/Owner-Mod/Mod.dll"},
+		{"BepInEx/config/owner.mod.cfg", "config/owner.mod.cfg"},
+		{"Mod.dll", "plugins/Owner-Mod/Mod.dll"},
+		{"manifest.json", "plugins/Owner-Mod/manifest.json"},
+		{"assets/bundle", "plugins/Owner-Mod/assets/bundle"},
+		{"plugins", "plugins/Owner-Mod/plugins"},
+		{"../plugins/Mod.dll", "plugins/Owner-Mod/Mod.dll"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.member, func(t *testing.T) {
+			assert.Equal(t, tt.want, bepInExPath(pkg, tt.member))
+		})
+	}
+}
+
+func TestBepInExPath_LoaderPack(t *testing.T) {
+	const pkg = "denikson-BepInExPack_Valheim"
+	tests := []struct {
+		member string
+		want   string
+	}{
+		{"BepInExPack_Valheim/BepInEx/core/BepInEx.dll", "core/BepInEx.dll"},
+		{"BepInExPack_Valheim/BepInEx/config/BepInEx.cfg", "config/BepInEx.cfg"},
+		{"BepInExPack_Valheim/BepInEx/plugins/Fix.dll", "plugins/denikson-BepInExPack_Valheim/Fix.dll"},
+		{"BepInExPack_Valheim/doorstop_config.ini", ""},
+		{"BepInExPack_Valheim/winhttp.dll", ""},
+		{"manifest.json", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.member, func(t *testing.T) {
+			assert.Equal(t, tt.want, bepInExPath(pkg, tt.member))
+		})
+	}
+}
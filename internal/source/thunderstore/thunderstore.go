@@ -0,0 +1,308 @@
+// Package thunderstore implements the Thunderstore mod source
+// (thunderstore.io), home of the r2modman ecosystem: Valheim, Lethal
+// Company, Risk of Rain 2 and most other BepInEx games.
+//
+// The value a game maps for this source in games.yaml is the Thunderstore
+// community slug (e.g. "valheim", "lethal-company", "riskofrain2"). Mod IDs
+// are package full names, "<Owner>-<Name>", and file IDs are version
+// numbers: every package version is a single zip.
+//
+// Packages follow the BepInEx layout conventions, so the game's mod_path
+// should be its BepInEx directory (see LayoutPath).
+package thunderstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source"
+)
+
+// sourceID is the registry ID, stamped onto every mod this source returns.
+const sourceID = "thunderstore"
+
+// Thunderstore implements the ModSource interface
+type Thunderstore struct {
+	client *Client
+}
+
+// New creates a new Thunderstore source
+func New(httpClient *http.Client) *Thunderstore {
+	return &Thunderstore{client: NewClient(httpClient)}
+}
+
+// ID returns the source identifier
+func (t *Thunderstore) ID() string {
+	return sourceID
+}
+
+// Name returns the display name
+func (t *Thunderstore) Name() string {
+	return "Thunderstore"
+}
+
+// AuthURL returns "": every endpoint this source uses is public.
+func (t *Thunderstore) AuthURL() string {
+	return ""
+}
+
+// ExchangeToken is unsupported: Thunderstore needs no authentication.
+func (t *Thunderstore) ExchangeToken(ctx context.Context, code string) (*source.Token, error) {
+	return nil, fmt.Errorf("source %q: authentication: %w", sourceID, source.ErrNotSupported)
+}
+
+// TypeLabel implements source.TypeLabeler.
+func (t *Thunderstore) TypeLabel() string {
+	return "built-in"
+}
+
+// Capabilities implements source.CapabilityReporter. Thunderstore supports
+// every ModSource operation but authentication.
+func (t *Thunderstore) Capabilities() source.Capabilities {
+	return source.Capabilities{Search: true, Dependencies: true, Updates: true, Auth: false, Versions: true}
+}
+
+// LayoutPath implements source.LayoutMapper: package zips are laid out for
+// BepInEx and are rearranged the way r2modman installs them.
+func (t *Thunderstore) LayoutPath(mod *domain.Mod, member string) string {
+	return bepInExPath(mod.ID, member)
+}
+
+// Search filters the community's package index. A query matches a
+// package's name, owner, or latest description; Category and every tag
+// must each be one of its categories. Deprecated packages are left out.
+// Results keep the index's own order: pinned packages first, then the most
+// recently updated.
+func (t *Thunderstore) Search(ctx context.Context, query source.SearchQuery) (source.SearchResult, error) {
+	packages, err := t.client.Packages(ctx, query.GameID)
+	if err != nil {
+		return source.SearchResult{}, err
+	}
+
+	q := strings.ToLower(strings.TrimSpace(query.Query))
+	want := query.Tags
+	if query.Category != "" {
+		want = append([]string{query.Category}, want...)
+	}
+
+	var mods []domain.Mod
+	for _, p := range packages {
+		if p.IsDeprecated || len(p.Versions) == 0 {
+			continue
+		}
+		if q != "" && !matchesQuery(p, q) {
+			continue
+		}
+		if !hasCategories(p, want) {
+			continue
+		}
+		mods = append(mods, packageToDomain(p, query.GameID))
+	}
+
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	page := query.Page
+	if page < 0 {
+		page = 0
+	}
+	start, end := len(mods), len(mods)
+	if page <= math.MaxInt/pageSize && page*pageSize < len(mods) {
+		start = page * pageSize
+		if pageSize < len(mods)-start {
+			end = start + pageSize
+		}
+	}
+	return source.SearchResult{Mods: mods[start:end], TotalCount: len(mods), Page: page, PageSize: pageSize}, nil
+}
+
+func matchesQuery(p Package, q string) bool {
+	name := strings.ToLower(p.Name)
+	return strings.Contains(name, q) ||
+		strings.Contains(strings.ReplaceAll(name, "_", " "), q) ||
+		strings.Contains(strings.ToLower(p.Owner), q) ||
+		strings.Contains(strings.ToLower(p.Versions[0].Description), q)
+}
+
+func hasCategories(p Package, want []string) bool {
+	for _, w := range want {
+		found := false
+		for _, c := range p.Categories {
+			if strings.EqualFold(c, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// GetMod retrieves a package by its full name
+func (t *Thunderstore) GetMod(ctx context.Context, gameID, modID string) (*domain.Mod, error) {
+	p, err := t.client.Package(ctx, gameID, modID)
+	if err != nil {
+		return nil, err
+	}
+	if len(p.Versions) == 0 {
+		return nil, fmt.Errorf("%w: %s has no versions", domain.ErrModNotFound, modID)
+	}
+	mod := packageToDomain(*p, gameID)
+	return &mod, nil
+}
+
+// GetDependencies returns the dependencies of the package's latest version.
+// The loader pack every BepInEx mod depends on is included: it is itself a
+// Thunderstore package.
+func (t *Thunderstore) GetDependencies(ctx context.Context, mod *domain.Mod) ([]domain.ModReference, error) {
+	remote, err := t.GetMod(ctx, mod.GameID, mod.ID)
+	if err != nil {
+		return nil, err
+	}
+	return remote.Dependencies, nil
+}
+
+// GetModFiles returns one file per active version, newest first. Only the
+// newest is primary.
+func (t *Thunderstore) GetModFiles(ctx context.Context, mod *domain.Mod) ([]domain.DownloadableFile, error) {
+	p, err := t.client.Package(ctx, mod.GameID, mod.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting mod files: %w", err)
+	}
+
+	var files []domain.DownloadableFile
+	for _, v := range p.Versions {
+		if !v.IsActive {
+			continue
+		}
+		files = append(files, domain.DownloadableFile{
+			ID:          v.VersionNumber,
+			Name:        v.FullName,
+			FileName:    v.FullName + ".zip",
+			Version:     v.VersionNumber,
+			Size:        v.FileSize,
+			IsPrimary:   len(files) == 0,
+			Description: v.Description,
+		})
+	}
+	return files, nil
+}
+
+// GetDownloadURL returns the zip URL of the version numbered fileID.
+func (t *Thunderstore) GetDownloadURL(ctx context.Context, mod *domain.Mod, fileID string) (string, error) {
+	p, err := t.client.Package(ctx, mod.GameID, mod.ID)
+	if err != nil {
+		return "", fmt.Errorf("getting download URL: %w", err)
+	}
+	for _, v := range p.Versions {
+		if v.VersionNumber == fileID {
+			return v.DownloadURL, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s has no version %s", domain.ErrModNotFound, mod.ID, fileID)
+}
+
+// CheckUpdates compares installed versions against each community's package
+// index, fetched once per community. An update replaces the installed
+// version's file with the new version's.
+func (t *Thunderstore) CheckUpdates(ctx context.Context, installed []domain.InstalledMod) ([]domain.Update, error) {
+	var updates []domain.Update
+	var fetchErrs []error
+
+	for i, inst := range installed {
+		select {
+		case <-ctx.Done():
+			return updates, ctx.Err()
+		default:
+		}
+		if fn, ok := ctx.Value(domain.UpdateProgressContextKey).(domain.UpdateProgressFunc); ok && fn != nil {
+			fn(i+1, len(installed), inst.Name)
+		}
+
+		p, err := t.client.Package(ctx, inst.GameID, inst.ID)
+		if err != nil {
+			fetchErrs = append(fetchErrs, fmt.Errorf("%s (id %s): %w", inst.Name, inst.ID, err))
+			continue
+		}
+		if len(p.Versions) == 0 {
+			continue
+		}
+		latest := p.Versions[0]
+		if latest.VersionNumber == inst.Version {
+			continue
+		}
+
+		repl := make(map[string]string, len(inst.FileIDs))
+		for _, id := range inst.FileIDs {
+			repl[id] = latest.VersionNumber
+		}
+		updates = append(updates, domain.Update{
+			InstalledMod:       inst,
+			NewVersion:         latest.VersionNumber,
+			FileIDReplacements: repl,
+		})
+	}
+
+	if len(fetchErrs) > 0 {
+		return updates, fmt.Errorf("update check skipped %d lookup(s): %w", len(fetchErrs), errors.Join(fetchErrs...))
+	}
+	return updates, nil
+}
+
+// ParseDependency parses a manifest.json dependency string,
+// "<Owner>-<Name>-<version>", into a reference to the package. Owners and
+// names cannot contain '-', so the split is unambiguous.
+func ParseDependency(dep string) (domain.ModReference, error) {
+	parts := strings.Split(dep, "-")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return domain.ModReference{}, fmt.Errorf("invalid dependency %q: want <Owner>-<Name>-<version>", dep)
+	}
+	return domain.ModReference{SourceID: sourceID, ModID: parts[0] + "-" + parts[1], Version: parts[2]}, nil
+}
+
+// packageToDomain converts a package to domain.Mod, describing its latest
+// version. Dependency strings that do not parse are skipped.
+func packageToDomain(p Package, gameID string) domain.Mod {
+	latest := p.Versions[0]
+	var downloads int64
+	for _, v := range p.Versions {
+		downloads += v.Downloads
+	}
+	var deps []domain.ModReference
+	for _, d := range latest.Dependencies {
+		if ref, err := ParseDependency(d); err == nil {
+			deps = append(deps, ref)
+		}
+	}
+	var category string
+	if len(p.Categories) > 0 {
+		category = p.Categories[0]
+	}
+	return domain.Mod{
+		ID:           p.FullName,
+		SourceID:     sourceID,
+		Name:         strings.ReplaceAll(p.Name, "_", " "),
+		Version:      latest.VersionNumber,
+		Author:       p.Owner,
+		Summary:      latest.Description,
+		GameID:       gameID,
+		Category:     category,
+		Downloads:    downloads,
+		Endorsements: int64Ptr(p.RatingScore),
+		PictureURL:   latest.Icon,
+		SourceURL:    p.PackageURL,
+		UpdatedAt:    p.DateUpdated,
+		Dependencies: deps,
+	}
+}
+
+// int64Ptr returns a pointer to the given int64 value.
+func int64Ptr(v int64) *int64 { return &v }
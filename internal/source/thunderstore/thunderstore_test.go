@@ -0,0 +1,241 @@
+package thunderstore
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/source"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThunderstore_ImplementsModSource(t *testing.T) {
+	// Compile-time check that Thunderstore implements ModSource
+	var _ source.ModSource = (*Thunderstore)(nil)
+}
+
+// Compile-time conformance pins for the optional interfaces it implements.
+var (
+	_ source.TypeLabeler        = (*Thunderstore)(nil)
+	_ source.CapabilityReporter = (*Thunderstore)(nil)
+	_ source.LayoutMapper       = (*Thunderstore)(nil)
+)
+
+// indexFixture is a small Valheim package index: a loader pack, a mod
+// depending on it, a deprecated package, and a second mod.
+const indexFixture = `[
+	{
+		"name": "BepInExPack_Valheim",
+		"full_name": "denikson-BepInExPack_Valheim",
+		"owner": "denikson",
+		"package_url": "https://thunderstore.io/c/valheim/p/denikson/BepInExPack_Valheim/",
+		"rating_score": 900,
+		"is_pinned": true,
+		"categories": ["Libraries"],
+		"versions": [
+			{"name": "BepInExPack_Valheim", "full_name": "denikson-BepInExPack_Valheim-5.4.2202", "description": "BepInEx pack for Valheim", "version_number": "5.4.2202", "dependencies": [], "download_url": "https://thunderstore.io/package/download/denikson/BepInExPack_Valheim/5.4.2202/", "downloads": 1000, "is_active": true, "file_size": 500}
+		]
+	},
+	{
+		"name": "Valheim_Plus",
+		"full_name": "Grantapher-Valheim_Plus",
+		"owner": "Grantapher",
+		"package_url": "https://thunderstore.io/c/valheim/p/Grantapher/Valheim_Plus/",
+		"date_updated": "2024-01-15T10:30:00Z",
+		"rating_score": 120,
+		"categories": ["Mods", "Tweaks"],
+		"versions": [
+			{"name": "Valheim_Plus", "full_name": "Grantapher-Valheim_Plus-0.9.12", "description": "Quality of life tweaks", "icon": "https://gcdn.thunderstore.io/vplus.png", "version_number": "0.9.12", "dependencies": ["denikson-BepInExPack_Valheim-5.4.2202", "not a dependency"], "download_url": "https://thunderstore.io/package/download/Grantapher/Valheim_Plus/0.9.12/", "downloads": 300, "is_active": true, "file_size": 2000},
+			{"name": "Valheim_Plus", "full_name": "Grantapher-Valheim_Plus-0.9.11", "description": "Quality of life tweaks", "version_number": "0.9.11", "dependencies": [], "download_url": "https://thunderstore.io/package/download/Grantapher/Valheim_Plus/0.9.11/", "downloads": 200, "is_active": false, "file_size": 1900},
+			{"name": "Valheim_Plus", "full_name": "Grantapher-Valheim_Plus-0.9.10", "description": "Quality of life tweaks", "version_number": "0.9.10", "dependencies": [], "download_url": "https://thunderstore.io/package/download/Grantapher/Valheim_Plus/0.9.10/", "downloads": 100, "is_active": true, "file_size": 1800}
+		]
+	},
+	{
+		"name": "OldPlus",
+		"full_name": "Someone-OldPlus",
+		"owner": "Someone",
+		"is_deprecated": true,
+		"categories": ["Mods"],
+		"versions": [
+			{"name": "OldPlus", "full_name": "Someone-OldPlus-1.0.0", "description": "Quality of life, abandoned", "version_number": "1.0.0", "is_active": true}
+		]
+	},
+	{
+		"name": "BetterArchery",
+		"full_name": "ishid4-BetterArchery",
+		"owner": "ishid4",
+		"categories": ["Mods"],
+		"versions": [
+			{"name": "BetterArchery", "full_name": "ishid4-BetterArchery-1.9.0", "description": "Archery overhaul", "version_number": "1.9.0", "dependencies": [], "is_active": true}
+		]
+	}
+]`
+
+func newIndexServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/c/valheim/api/v1/package/" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(indexFixture))
+	}))
+}
+
+func newTestThunderstore(t *testing.T) *Thunderstore {
+	t.Helper()
+	server := newIndexServer(t)
+	t.Cleanup(server.Close)
+	ts := New(server.Client())
+	ts.client.SetBaseURL(server.URL)
+	return ts
+}
+
+func TestThunderstore_Metadata(t *testing.T) {
+	ts := New(nil)
+	assert.Equal(t, "thunderstore", ts.ID())
+	assert.Equal(t, "Thunderstore", ts.Name())
+	assert.Equal(t, "built-in", ts.TypeLabel())
+	assert.Equal(t, source.Capabilities{Search: true, Dependencies: true, Updates: true, Versions: true}, ts.Capabilities())
+
+	_, err := ts.ExchangeToken(context.Background(), "code")
+	assert.ErrorIs(t, err, source.ErrNotSupported)
+}
+
+func TestThunderstore_Search(t *testing.T) {
+	ts := newTestThunderstore(t)
+
+	result, err := ts.Search(context.Background(), source.SearchQuery{GameID: "valheim", Query: "quality of life"})
+	require.NoError(t, err)
+	require.Len(t, result.Mods, 1, "the deprecated match is left out")
+	assert.Equal(t, 1, result.TotalCount)
+
+	mod := result.Mods[0]
+	assert.Equal(t, "Grantapher-Valheim_Plus", mod.ID)
+	assert.Equal(t, "thunderstore", mod.SourceID)
+	assert.Equal(t, "Valheim Plus", mod.Name)
+	assert.Equal(t, "0.9.12", mod.Version)
+	assert.Equal(t, "Grantapher", mod.Author)
+	assert.Equal(t, "Quality of life tweaks", mod.Summary)
+	assert.Equal(t, "valheim", mod.GameID)
+	assert.Equal(t, "Mods", mod.Category)
+	assert.Equal(t, int64(600), mod.Downloads, "downloads sum every version")
+	assert.Equal(t, int64Ptr(120), mod.Endorsements)
+	assert.Equal(t, "https://gcdn.thunderstore.io/vplus.png", mod.PictureURL)
+	assert.Equal(t, "https://thunderstore.io/c/valheim/p/Grantapher/Valheim_Plus/", mod.SourceURL)
+}
+
+func TestThunderstore_Search_NameWithSpaces(t *testing.T) {
+	ts := newTestThunderstore(t)
+
+	result, err := ts.Search(context.Background(), source.SearchQuery{GameID: "valheim", Query: "Valheim Plus"})
+	require.NoError(t, err)
+	require.Len(t, result.Mods, 1)
+	assert.Equal(t, "Grantapher-Valheim_Plus", result.Mods[0].ID)
+}
+
+func TestThunderstore_Search_CategoryAndPaging(t *testing.T) {
+	ts := newTestThunderstore(t)
+
+	result, err := ts.Search(context.Background(), source.SearchQuery{GameID: "valheim", Category: "mods", PageSize: 1, Page: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.TotalCount)
+	require.Len(t, result.Mods, 1)
+	assert.Equal(t, "ishid4-BetterArchery", result.Mods[0].ID, "index order is kept")
+
+	result, err = ts.Search(context.Background(), source.SearchQuery{GameID: "valheim", Tags: []string{"Mods", "Tweaks"}})
+	require.NoError(t, err)
+	require.Len(t, result.Mods, 1)
+	assert.Equal(t, "Grantapher-Valheim_Plus", result.Mods[0].ID)
+
+	result, err = ts.Search(context.Background(), source.SearchQuery{GameID: "valheim", Page: 10})
+	require.NoError(t, err)
+	assert.Empty(t, result.Mods)
+	assert.Equal(t, 3, result.TotalCount)
+}
+
+func TestThunderstore_GetModAndDependencies(t *testing.T) {
+	ts := newTestThunderstore(t)
+
+	mod, err := ts.GetMod(context.Background(), "valheim", "Grantapher-Valheim_Plus")
+	require.NoError(t, err)
+	assert.Equal(t, "0.9.12", mod.Version)
+
+	deps, err := ts.GetDependencies(context.Background(), mod)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ModReference{
+		{SourceID: "thunderstore", ModID: "denikson-BepInExPack_Valheim", Version: "5.4.2202"},
+	}, deps, "unparseable dependency strings are skipped")
+
+	_, err = ts.GetMod(context.Background(), "valheim", "Nobody-Nothing")
+	assert.ErrorIs(t, err, domain.ErrModNotFound)
+}
+
+func TestThunderstore_GetModFiles(t *testing.T) {
+	ts := newTestThunderstore(t)
+
+	files, err := ts.GetModFiles(context.Background(), &domain.Mod{ID: "Grantapher-Valheim_Plus", GameID: "valheim"})
+	require.NoError(t, err)
+	require.Len(t, files, 2, "inactive versions are not offered")
+
+	assert.Equal(t, "0.9.12", files[0].ID)
+	assert.Equal(t, "Grantapher-Valheim_Plus-0.9.12.zip", files[0].FileName)
+	assert.Equal(t, "0.9.12", files[0].Version)
+	assert.Equal(t, int64(2000), files[0].Size)
+	assert.True(t, files[0].IsPrimary)
+
+	assert.Equal(t, "0.9.10", files[1].ID)
+	assert.False(t, files[1].IsPrimary)
+}
+
+func TestThunderstore_GetDownloadURL(t *testing.T) {
+	ts := newTestThunderstore(t)
+	mod := &domain.Mod{ID: "Grantapher-Valheim_Plus", GameID: "valheim"}
+
+	url, err := ts.GetDownloadURL(context.Background(), mod, "0.9.10")
+	require.NoError(t, err)
+	assert.Equal(t, "https://thunderstore.io/package/download/Grantapher/Valheim_Plus/0.9.10/", url)
+
+	_, err = ts.GetDownloadURL(context.Background(), mod, "9.9.9")
+	assert.ErrorIs(t, err, domain.ErrModNotFound)
+}
+
+func TestThunderstore_CheckUpdates(t *testing.T) {
+	ts := newTestThunderstore(t)
+
+	installed := []domain.InstalledMod{
+		{Mod: domain.Mod{ID: "Grantapher-Valheim_Plus", Name: "Valheim Plus", Version: "0.9.10", GameID: "valheim"}, FileIDs: []string{"0.9.10"}},
+		{Mod: domain.Mod{ID: "ishid4-BetterArchery", Name: "Better Archery", Version: "1.9.0", GameID: "valheim"}, FileIDs: []string{"1.9.0"}},
+		{Mod: domain.Mod{ID: "Gone-Mod", Name: "Gone", Version: "1.0.0", GameID: "valheim"}, FileIDs: []string{"1.0.0"}},
+	}
+
+	updates, err := ts.CheckUpdates(context.Background(), installed)
+	require.Error(t, err, "the missing package is reported")
+	assert.Contains(t, err.Error(), "skipped 1 lookup")
+	require.Len(t, updates, 1)
+	assert.Equal(t, "Grantapher-Valheim_Plus", updates[0].InstalledMod.ID)
+	assert.Equal(t, "0.9.12", updates[0].NewVersion)
+	assert.Equal(t, map[string]string{"0.9.10": "0.9.12"}, updates[0].FileIDReplacements)
+}
+
+func TestParseDependency(t *testing.T) {
+	ref, err := ParseDependency("denikson-BepInExPack_Valheim-5.4.2202")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ModReference{SourceID: "thunderstore", ModID: "denikson-BepInExPack_Valheim", Version: "5.4.2202"}, ref)
+
+	for _, bad := range []string{"", "Owner-Name", "Owner--1.0.0", "a-b-c-d"} {
+		_, err := ParseDependency(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestThunderstore_LayoutPath(t *testing.T) {
+	ts := New(nil)
+	mod := &domain.Mod{ID: "Grantapher-Valheim_Plus"}
+	assert.Equal(t, "plugins/Grantapher-Valheim_Plus/ValheimPlus.dll", ts.LayoutPath(mod, "plugins/ValheimPlus.dll"))
+	assert.Equal(t, "config/valheim_plus.cfg", ts.LayoutPath(mod, "config/valheim_plus.cfg"))
+}
@@ -0,0 +1,37 @@
+package thunderstore
+
+import "time"
+
+// Package is one entry in a community's package index
+// (/c/<community>/api/v1/package/).
+type Package struct {
+	Name           string           `json:"name"`
+	FullName       string           `json:"full_name"` // "<Owner>-<Name>"
+	Owner          string           `json:"owner"`
+	PackageURL     string           `json:"package_url"`
+	DateCreated    time.Time        `json:"date_created"`
+	DateUpdated    time.Time        `json:"date_updated"`
+	RatingScore    int64            `json:"rating_score"`
+	IsPinned       bool             `json:"is_pinned"`
+	IsDeprecated   bool             `json:"is_deprecated"`
+	HasNSFWContent bool             `json:"has_nsfw_content"`
+	Categories     []string         `json:"categories"`
+	Versions       []PackageVersion `json:"versions"` // newest first
+}
+
+// PackageVersion is one published version of a package. Dependencies are
+// "<Owner>-<Name>-<version>" strings, as in the package's manifest.json.
+type PackageVersion struct {
+	Name          string    `json:"name"`
+	FullName      string    `json:"full_name"` // "<Owner>-<Name>-<version>"
+	Description   string    `json:"description"`
+	Icon          string    `json:"icon"`
+	VersionNumber string    `json:"version_number"`
+	Dependencies  []string  `json:"dependencies"`
+	DownloadURL   string    `json:"download_url"`
+	Downloads     int64     `json:"downloads"`
+	DateCreated   time.Time `json:"date_created"`
+	WebsiteURL    string    `json:"website_url"`
+	IsActive      bool      `json:"is_active"`
+	FileSize      int64     `json:"file_size"`
+}
@@ -0,0 +1,245 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// blobDirName is the cache-root directory of the content-addressed store:
+// <root>/.blobs/<first two hex digits>/<sha256>. The global cache's store
+// sits above the per-game directories, so identical files are shared across
+// games too; a game-scoped cache_path gets a store of its own (hardlinks
+// cannot cross filesystems anyway). The leading dot keeps it out of Entries.
+const blobDirName = ".blobs"
+
+// dedupTempPrefix names the link a file is swapped for while being replaced
+// by its blob. It is reserved, so one left behind by a crash is never taken
+// for mod content.
+const dedupTempPrefix = ReservedPrefix + "dedup-"
+
+// DedupStats reports a deduplication pass.
+type DedupStats struct {
+	Files   int   // content files now backed by the blob store
+	Linked  int   // files replaced by a link to an existing blob this pass
+	Saved   int64 // bytes those replacements freed
+	Skipped int   // files left as plain copies (e.g. a filesystem refusing the link)
+}
+
+// Add accumulates other into s.
+func (s *DedupStats) Add(other DedupStats) {
+	s.Files += other.Files
+	s.Linked += other.Linked
+	s.Saved += other.Saved
+	s.Skipped += other.Skipped
+}
+
+// SetDedup turns content-addressed storage on or off for files committed
+// through this cache (see DedupDir). Entries written while it was off stay
+// plain copies until DedupDir is run over them.
+func (c *Cache) SetDedup(enabled bool) {
+	c.dedup = enabled
+}
+
+// Dedup reports whether SetDedup enabled content-addressed storage.
+func (c *Cache) Dedup() bool {
+	return c.dedup
+}
+
+// DedupDir moves every content file under versionDir into the blob store,
+// keyed by its SHA-256, and hardlinks it back in place; a file whose content
+// is already stored is replaced by a link to the existing blob, which is
+// where the space goes. Each replacement is an atomic rename, so readers see
+// either the old copy or the link, and the version directory lists, sizes and
+// clones exactly as before. lmm's own .lmm-* entries stay plain files: markers
+// are rewritten in place and must never alias another entry's.
+//
+// A blob is re-hashed before anything is linked to it, so one modified in
+// place is dropped from the store rather than spread to other entries. A
+// file the filesystem will not link (too many links, no hardlink support)
+// is cloned from its blob instead where the filesystem supports reflinks;
+// otherwise it is left as it is and counted in Skipped, which is not an
+// error.
+func (c *Cache) DedupDir(versionDir string) (DedupStats, error) {
+	var stats DedupStats
+	err := filepath.WalkDir(versionDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != versionDir && isReserved(d.Name()) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if isReserved(d.Name()) || !d.Type().IsRegular() {
+			return nil
+		}
+		stored, saved, err := c.dedupFile(path)
+		if err != nil {
+			return err
+		}
+		if !stored {
+			stats.Skipped++
+			return nil
+		}
+		stats.Files++
+		if saved > 0 {
+			stats.Linked++
+			stats.Saved += saved
+		}
+		return nil
+	})
+	if err != nil {
+		return stats, fmt.Errorf("deduplicating %s: %w", versionDir, err)
+	}
+	return stats, nil
+}
+
+// dedupFile backs path by its blob. It reports whether path is now backed by
+// the blob store and the bytes freed by replacing it with an existing blob.
+func (c *Cache) dedupFile(path string) (bool, int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, 0, err
+	}
+	sum, err := fileSHA256(path)
+	if err != nil {
+		return false, 0, err
+	}
+	blob := filepath.Join(c.basePath, blobDirName, sum[:2], sum)
+
+	blobInfo, err := os.Stat(blob)
+	if errors.Is(err, fs.ErrNotExist) {
+		// First copy of this content: it becomes the blob.
+		if err := os.MkdirAll(filepath.Dir(blob), 0755); err != nil {
+			return false, 0, fmt.Errorf("creating blob directory: %w", err)
+		}
+		if err := os.Link(path, blob); err != nil {
+			if errors.Is(err, fs.ErrExist) {
+				return c.dedupFile(path) // stored concurrently; link to it instead
+			}
+			return false, 0, nil
+		}
+		return true, 0, nil
+	}
+	if err != nil {
+		return false, 0, fmt.Errorf("checking blob: %w", err)
+	}
+	if os.SameFile(info, blobInfo) {
+		return true, 0, nil
+	}
+	if blobInfo.Size() != info.Size() || !blobMatches(blob, sum) {
+		// A blob that no longer matches its name was modified in place
+		// (e.g. through a hardlink deploy); never spread it further. It
+		// leaves the store - the entries already linking to it keep their
+		// copy - and path takes its place.
+		if err := os.Remove(blob); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return false, 0, fmt.Errorf("dropping corrupted blob %s: %w", sum, err)
+		}
+		return c.dedupFile(path)
+	}
+
+	tmp := filepath.Join(filepath.Dir(path), dedupTempPrefix+filepath.Base(path))
+	_ = os.Remove(tmp)
+	if err := os.Link(blob, tmp); err != nil {
+		// Too many links, or a filesystem without hardlinks: a
+		// copy-on-write clone still shares the blob's extents where the
+		// filesystem supports it (btrfs, XFS).
+		if cloneFile(blob, tmp, blobInfo.Mode()) != nil {
+			return false, 0, nil
+		}
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return false, 0, fmt.Errorf("replacing %s with its blob: %w", path, err)
+	}
+	return true, info.Size(), nil
+}
+
+// PruneBlobs removes blobs no version directory links to any more, returning
+// the bytes freed. Deleting a deduplicated entry only drops its links; the
+// content goes once the last version using it is gone.
+func (c *Cache) PruneBlobs() (int64, error) {
+	root := filepath.Join(c.basePath, blobDirName)
+	var freed int64
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root && errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if st, ok := info.Sys().(*syscall.Stat_t); !ok || st.Nlink > 1 {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		freed += info.Size()
+		_ = os.Remove(filepath.Dir(path)) // only succeeds once the shard is empty
+		return nil
+	})
+	if err != nil {
+		return freed, fmt.Errorf("pruning blobs: %w", err)
+	}
+	return freed, nil
+}
+
+// blobMatches reports whether blob's content still hashes to sum.
+func blobMatches(blob, sum string) bool {
+	got, err := fileSHA256(blob)
+	return err == nil && got == sum
+}
+
+// cloneFile creates dst as a copy-on-write clone (FICLONE) of src. Unlike
+// the deploy linker it never falls back to copying: a copy would save
+// nothing.
+func cloneFile(src, dst string, mode os.FileMode) (err error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := out.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			_ = os.Remove(dst)
+		}
+	}()
+	return unix.IoctlFileClone(int(out.Fd()), int(in.Fd()))
+}
+
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hashing %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
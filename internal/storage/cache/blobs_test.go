@@ -0,0 +1,168 @@
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/cache"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sameFile(t *testing.T, a, b string) bool {
+	t.Helper()
+	ai, err := os.Stat(a)
+	require.NoError(t, err)
+	bi, err := os.Stat(b)
+	require.NoError(t, err)
+	return os.SameFile(ai, bi)
+}
+
+// storeTwoVersions caches two versions of a mod sharing textures.pak and
+// differing in plugin.esp, each with a completion marker.
+func storeTwoVersions(t *testing.T, c *cache.Cache) (v1, v2 string) {
+	t.Helper()
+	shared := []byte("large shared texture data")
+	for _, v := range []string{"1.0", "2.0"} {
+		require.NoError(t, c.Store("skyrim-se", "nexusmods", "1", v, "textures/textures.pak", shared))
+		require.NoError(t, c.Store("skyrim-se", "nexusmods", "1", v, "plugin.esp", []byte("plugin "+v)))
+		require.NoError(t, cache.MarkFileComplete(c.ModPath("skyrim-se", "nexusmods", "1", v), "main"))
+	}
+	return c.ModPath("skyrim-se", "nexusmods", "1", "1.0"), c.ModPath("skyrim-se", "nexusmods", "1", "2.0")
+}
+
+func TestCache_DedupDir(t *testing.T) {
+	dir := t.TempDir()
+	c := cache.New(dir)
+	v1, v2 := storeTwoVersions(t, c)
+	sizeBefore, err := c.Size("skyrim-se", "nexusmods", "1", "2.0")
+	require.NoError(t, err)
+
+	stats, err := c.DedupDir(v1)
+	require.NoError(t, err)
+	assert.Equal(t, cache.DedupStats{Files: 2}, stats, "the first copy of each file becomes its blob")
+
+	stats, err = c.DedupDir(v2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Files)
+	assert.Equal(t, 1, stats.Linked)
+	assert.Equal(t, int64(len("large shared texture data")), stats.Saved)
+	assert.True(t, sameFile(t, filepath.Join(v1, "textures", "textures.pak"), filepath.Join(v2, "textures", "textures.pak")))
+	assert.False(t, sameFile(t, filepath.Join(v1, "plugin.esp"), filepath.Join(v2, "plugin.esp")))
+
+	// The entry reads exactly as before.
+	files, err := c.ListFiles("skyrim-se", "nexusmods", "1", "2.0")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"plugin.esp", filepath.Join("textures", "textures.pak")}, files)
+	sizeAfter, err := c.Size("skyrim-se", "nexusmods", "1", "2.0")
+	require.NoError(t, err)
+	assert.Equal(t, sizeBefore, sizeAfter)
+	assert.True(t, c.HasFileIDs("skyrim-se", "nexusmods", "1", "2.0", []string{"main"}))
+
+	// Running again changes nothing.
+	stats, err = c.DedupDir(v2)
+	require.NoError(t, err)
+	assert.Equal(t, cache.DedupStats{Files: 2}, stats)
+
+	entries, err := c.Entries("skyrim-se")
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "the blob store is not a cache entry")
+}
+
+func TestCache_DedupDir_WritesNeverReachSharedBlobs(t *testing.T) {
+	dir := t.TempDir()
+	c := cache.New(dir)
+	v1, v2 := storeTwoVersions(t, c)
+	_, err := c.DedupDir(v1)
+	require.NoError(t, err)
+	_, err = c.DedupDir(v2)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Store("skyrim-se", "nexusmods", "1", "2.0", "textures/textures.pak", []byte("patched")))
+	data, err := os.ReadFile(filepath.Join(v1, "textures", "textures.pak"))
+	require.NoError(t, err)
+	assert.Equal(t, "large shared texture data", string(data), "storing over a shared file must not rewrite the other version")
+
+	// A clone back over a deduplicated entry replaces, never truncates.
+	snapshot := cache.New(t.TempDir())
+	require.NoError(t, c.CloneMod(snapshot, "skyrim-se", "nexusmods", "1", "1.0"))
+	require.NoError(t, snapshot.Store("skyrim-se", "nexusmods", "1", "1.0", "textures/textures.pak", []byte("other")))
+	require.NoError(t, snapshot.CloneMod(c, "skyrim-se", "nexusmods", "1", "1.0"))
+	blobs, err := filepath.Glob(filepath.Join(dir, ".blobs", "*", "*"))
+	require.NoError(t, err)
+	for _, blob := range blobs {
+		data, err := os.ReadFile(blob)
+		require.NoError(t, err)
+		assert.NotEqual(t, "other", string(data))
+	}
+}
+
+func TestCache_DedupDir_SameSizeCorruptedBlobIsDropped(t *testing.T) {
+	dir := t.TempDir()
+	c := cache.New(dir)
+	v1, v2 := storeTwoVersions(t, c)
+	_, err := c.DedupDir(v1)
+	require.NoError(t, err)
+
+	// An in-place edit through a hardlink deploy rewrites the blob too,
+	// keeping its size.
+	pak := filepath.Join(v1, "textures", "textures.pak")
+	require.NoError(t, os.WriteFile(pak, []byte("LARGE SHARED TEXTURE DATA"), 0644))
+
+	stats, err := c.DedupDir(v2)
+	require.NoError(t, err)
+	assert.Zero(t, stats.Linked, "nothing is linked to a blob that no longer matches its name")
+	data, err := os.ReadFile(filepath.Join(v2, "textures", "textures.pak"))
+	require.NoError(t, err)
+	assert.Equal(t, "large shared texture data", string(data))
+	assert.False(t, sameFile(t, pak, filepath.Join(v2, "textures", "textures.pak")))
+
+	// The intact copy replaced the corrupted blob, so later copies share it.
+	require.NoError(t, c.Store("skyrim-se", "nexusmods", "1", "3.0", "textures/textures.pak", []byte("large shared texture data")))
+	v3 := c.ModPath("skyrim-se", "nexusmods", "1", "3.0")
+	stats, err = c.DedupDir(v3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Linked)
+	assert.True(t, sameFile(t, filepath.Join(v2, "textures", "textures.pak"), filepath.Join(v3, "textures", "textures.pak")))
+}
+
+func TestCache_CloneMod_DedupsIntoDestination(t *testing.T) {
+	src := cache.New(t.TempDir())
+	dest := cache.New(t.TempDir())
+	dest.SetDedup(true)
+	storeTwoVersions(t, src)
+	storeTwoVersions(t, dest)
+	_, err := dest.DedupDir(dest.ModPath("skyrim-se", "nexusmods", "1", "2.0"))
+	require.NoError(t, err)
+
+	require.NoError(t, src.CloneMod(dest, "skyrim-se", "nexusmods", "1", "1.0"))
+	assert.True(t, sameFile(t,
+		dest.GetFilePath("skyrim-se", "nexusmods", "1", "1.0", "textures/textures.pak"),
+		dest.GetFilePath("skyrim-se", "nexusmods", "1", "2.0", "textures/textures.pak")))
+	assert.True(t, dest.HasFileIDs("skyrim-se", "nexusmods", "1", "1.0", []string{"main"}))
+}
+
+func TestCache_PruneBlobs(t *testing.T) {
+	dir := t.TempDir()
+	c := cache.New(dir)
+	v1, v2 := storeTwoVersions(t, c)
+	_, err := c.DedupDir(v1)
+	require.NoError(t, err)
+	_, err = c.DedupDir(v2)
+	require.NoError(t, err)
+
+	require.NoError(t, c.Delete("skyrim-se", "nexusmods", "1", "1.0"))
+	freed, err := c.PruneBlobs()
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("plugin 1.0")), freed, "only the blob no version links to any more goes")
+
+	data, err := os.ReadFile(filepath.Join(v2, "textures", "textures.pak"))
+	require.NoError(t, err)
+	assert.Equal(t, "large shared texture data", string(data))
+
+	freed, err = cache.New(t.TempDir()).PruneBlobs()
+	require.NoError(t, err)
+	assert.Zero(t, freed, "a cache that never deduplicated has nothing to prune")
+}
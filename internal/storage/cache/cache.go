@@ -7,12 +7,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // Cache manages the central mod file cache
 type Cache struct {
 	basePath   string
 	gameScoped bool // when true, basePath is game-specific; omit gameID from ModPath
+	dedup      bool // when true, committed entries are backed by the blob store (see DedupDir)
 }
 
 // New creates a new cache manager for the global cache (basePath/gameID/source-mod/version).
@@ -421,6 +423,11 @@ func (c *Cache) Store(gameID, sourceID, modID, version, relativePath string, con
 		return fmt.Errorf("creating cache dir: %w", err)
 	}
 
+	// Replace rather than truncate: the file may be a link into the blob
+	// store, shared with other versions.
+	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("writing cached file: %w", err)
+	}
 	if err := os.WriteFile(fullPath, content, 0644); err != nil {
 		return fmt.Errorf("writing cached file: %w", err)
 	}
@@ -519,6 +526,118 @@ func removeIfEmpty(dir string) error {
 	return os.Remove(dir)
 }
 
+// transientSuffixes mark the sibling directories lmm creates next to a
+// version directory while committing it (<version>.staging and friends).
+// They belong to an operation in progress, not to the cache's contents.
+var transientSuffixes = []string{".staging", ".extract", ".backup"}
+
+// Entry is one mod version directory in the cache.
+type Entry struct {
+	Key     string    // the "<source>-<modID>" directory the version lives under
+	Version string    // the version directory's name
+	Path    string    // the version directory
+	Size    int64     // bytes on disk, lmm's own bookkeeping included
+	ModTime time.Time // when the version directory was last committed
+}
+
+// Entries lists every mod version cached for gameID, sorted by key then
+// version. Directories lmm is still committing are skipped. A cache that
+// does not exist yet has no entries.
+func (c *Cache) Entries(gameID string) ([]Entry, error) {
+	root := c.basePath
+	if !c.gameScoped {
+		root = filepath.Join(c.basePath, gameID)
+	}
+	mods, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading cache: %w", err)
+	}
+
+	var entries []Entry
+	for _, mod := range mods {
+		if !mod.IsDir() || strings.HasPrefix(mod.Name(), ".") {
+			continue
+		}
+		versions, err := os.ReadDir(filepath.Join(root, mod.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading cache: %w", err)
+		}
+		for _, version := range versions {
+			if !version.IsDir() || isTransient(version.Name()) {
+				continue
+			}
+			path := filepath.Join(root, mod.Name(), version.Name())
+			info, err := version.Info()
+			if err != nil {
+				return nil, fmt.Errorf("reading cache: %w", err)
+			}
+			size, err := diskSize(path)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, Entry{
+				Key:     mod.Name(),
+				Version: version.Name(),
+				Path:    path,
+				Size:    size,
+				ModTime: info.ModTime(),
+			})
+		}
+	}
+	return entries, nil
+}
+
+// DeleteEntry removes a cache entry listed by Entries, and its mod's
+// container directory when that was the last version, like Delete.
+func (c *Cache) DeleteEntry(e Entry) error {
+	if err := os.RemoveAll(e.Path); err != nil {
+		return fmt.Errorf("deleting cached mod: %w", err)
+	}
+	if err := removeIfEmpty(filepath.Dir(e.Path)); err != nil {
+		return fmt.Errorf("removing empty mod cache directory: %w", err)
+	}
+	return nil
+}
+
+func isTransient(name string) bool {
+	for _, suffix := range transientSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// diskSize totals every regular file under dir. Unlike Size it counts lmm's
+// .lmm-* entries (retained sources can be as large as the mod itself), since
+// it answers how much space removing dir would free. With deduplication a
+// file shared with other versions counts toward each of them, so it can
+// overstate that.
+func diskSize(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("calculating cache size: %w", err)
+	}
+	return total, nil
+}
+
 // GetFilePath returns the full path to a cached file
 func (c *Cache) GetFilePath(gameID, sourceID, modID, version, relativePath string) string {
 	return filepath.Join(c.ModPath(gameID, sourceID, modID, version), relativePath)
@@ -548,6 +667,10 @@ func (c *Cache) CloneMod(dest *Cache, gameID, sourceID, modID, version string) e
 			return err
 		}
 	}
+	if dest.dedup {
+		// Best-effort, as after any commit: plain copies are still correct.
+		_, _ = dest.DedupDir(dest.ModPath(gameID, sourceID, modID, version))
+	}
 	return nil
 }
 
@@ -597,6 +720,11 @@ func copyFile(src, dst string) error {
 		return fmt.Errorf("stat source: %w", err)
 	}
 
+	// dst may be a link into the blob store (CloneMod restoring a live
+	// entry); truncating it would rewrite every version sharing the blob.
+	if err := os.Remove(dst); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("replacing destination: %w", err)
+	}
 	dstFile, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, srcInfo.Mode())
 	if err != nil {
 		return fmt.Errorf("creating destination: %w", err)
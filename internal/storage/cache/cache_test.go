@@ -633,3 +633,59 @@ func TestPruneUnclaimed_NeverTouchesReservedEntries(t *testing.T) {
 	_, err := os.Stat(filepath.Join(dir, cache.RetainedSourceName("exmodz")))
 	require.NoError(t, err, "reserved entries are never pruned")
 }
+
+func TestCache_Entries(t *testing.T) {
+	dir := t.TempDir()
+	c := cache.New(dir)
+
+	entries, err := c.Entries("skyrim-se")
+	require.NoError(t, err)
+	assert.Empty(t, entries, "a cache that was never written has no entries")
+
+	require.NoError(t, c.Store("skyrim-se", "nexusmods", "12345", "1.0.0", "a.txt", []byte("12345")))
+	require.NoError(t, c.Store("skyrim-se", "nexusmods", "12345", "2.0.0", "b.txt", []byte("1234567")))
+	require.NoError(t, c.Store("other-game", "nexusmods", "1", "1.0", "c.txt", []byte("x")))
+	v2 := c.ModPath("skyrim-se", "nexusmods", "12345", "2.0.0")
+	require.NoError(t, os.WriteFile(filepath.Join(v2, cache.RetainedSourceName("main")), []byte("archive"), 0644))
+	// An in-flight commit is not an entry.
+	require.NoError(t, os.MkdirAll(c.ModPath("skyrim-se", "nexusmods", "12345", "3.0.0")+".staging", 0755))
+
+	entries, err = c.Entries("skyrim-se")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "nexusmods-12345", entries[0].Key)
+	assert.Equal(t, "1.0.0", entries[0].Version)
+	assert.Equal(t, int64(5), entries[0].Size)
+	assert.Equal(t, "2.0.0", entries[1].Version)
+	assert.Equal(t, int64(14), entries[1].Size, "a retained source counts toward the space an entry holds")
+	assert.False(t, entries[1].ModTime.IsZero())
+}
+
+func TestCache_Entries_GameScoped(t *testing.T) {
+	dir := t.TempDir()
+	c := cache.NewGameScoped(dir)
+
+	require.NoError(t, c.Store("starrupture", "nexusmods", "35", "1.00", "file.pak", []byte("data")))
+	entries, err := c.Entries("starrupture")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, filepath.Join(dir, "nexusmods-35", "1.00"), entries[0].Path)
+}
+
+func TestCache_DeleteEntry(t *testing.T) {
+	dir := t.TempDir()
+	c := cache.New(dir)
+
+	require.NoError(t, c.Store("skyrim-se", "nexusmods", "12345", "1.0.0", "old.txt", []byte("old")))
+	require.NoError(t, c.Store("skyrim-se", "nexusmods", "12345", "2.0.0", "new.txt", []byte("new")))
+	entries, err := c.Entries("skyrim-se")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	require.NoError(t, c.DeleteEntry(entries[0]))
+	assert.False(t, c.Exists("skyrim-se", "nexusmods", "12345", "1.0.0"))
+	assert.True(t, c.Exists("skyrim-se", "nexusmods", "12345", "2.0.0"))
+
+	require.NoError(t, c.DeleteEntry(entries[1]))
+	assert.NoDirExists(t, filepath.Join(dir, "skyrim-se", "nexusmods-12345"))
+}
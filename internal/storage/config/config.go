@@ -18,6 +18,7 @@ type Config struct {
 	DefaultGame       string            `yaml:"default_game"`
 	Keybindings       string            `yaml:"keybindings"`
 	CachePath         string            `yaml:"cache_path"`
+	CacheDedup        bool              `yaml:"cache_dedup"`
 	HookTimeout       int               `yaml:"hook_timeout"`
 }
 
@@ -55,9 +55,14 @@ type GameConfig struct {
 	Sources     map[string]string `yaml:"sources"`
 	LinkMethod  string            `yaml:"link_method,omitempty"`
 	CachePath   string            `yaml:"cache_path,omitempty"`
+	CacheQuota  string            `yaml:"cache_quota,omitempty"` // e.g. "20GB"; parsed by ParseSize
 	Hooks       GameHooksYAML     `yaml:"hooks,omitempty"`
 	DeployMode  string            `yaml:"deploy_mode,omitempty"`
 	ConvertPaks *bool             `yaml:"convert_paks,omitempty"`
+	SavesPath   string            `yaml:"saves_path,omitempty"`
+	// SaveIsolation opts the game into per-profile saves: SavesPath's
+	// contents are swapped on every profile switch. Requires saves_path.
+	SaveIsolation bool `yaml:"save_isolation,omitempty"`
 }
 
 // GamesFile is the top-level games.yaml structure
@@ -98,6 +103,17 @@ func loadGamesLocked(configDir string) (map[string]*domain.Game, error) {
 			return nil, fmt.Errorf("%w: games.yaml: game %q: deploy_mode %q (valid: %s)",
 				domain.ErrInvalidDeployMode, id, cfg.DeployMode, domain.ValidDeployModes)
 		}
+		if cfg.SaveIsolation && cfg.SavesPath == "" {
+			return nil, fmt.Errorf("games.yaml: game %q: save_isolation requires saves_path", id)
+		}
+		var cacheQuota int64
+		if cfg.CacheQuota != "" {
+			quota, err := ParseSize(cfg.CacheQuota)
+			if err != nil {
+				return nil, fmt.Errorf("games.yaml: game %q: cache_quota: %w", id, err)
+			}
+			cacheQuota = quota
+		}
 		convertPaks := true // default: paks convert (only meaningful for DeployCompile games)
 		convertExplicit := false
 		if cfg.ConvertPaks != nil {
@@ -113,9 +129,12 @@ func loadGamesLocked(configDir string) (map[string]*domain.Game, error) {
 			LinkMethod:          linkMethod,
 			LinkMethodExplicit:  cfg.LinkMethod != "",
 			CachePath:           ExpandPath(cfg.CachePath),
+			CacheQuota:          cacheQuota,
 			DeployMode:          deployMode,
 			ConvertPaks:         convertPaks,
 			ConvertPaksExplicit: convertExplicit,
+			SavesPath:           ExpandPath(cfg.SavesPath),
+			SaveIsolation:       cfg.SaveIsolation,
 			Hooks: domain.GameHooks{
 				Install: domain.HookConfig{
 					BeforeAll:  ExpandPath(cfg.Hooks.Install.BeforeAll),
@@ -158,6 +177,9 @@ func saveGamesLocked(configDir string, games map[string]*domain.Game) error {
 			ModPath:     game.ModPath,
 			Sources:     game.SourceIDs,
 			CachePath:   game.CachePath,
+			SavesPath:   game.SavesPath,
+			// save_isolation is omitempty, so the default (off) is never written
+			SaveIsolation: game.SaveIsolation,
 			Hooks: GameHooksYAML{
 				Install: HookConfigYAML{
 					BeforeAll:  game.Hooks.Install.BeforeAll,
@@ -177,6 +199,9 @@ func saveGamesLocked(configDir string, games map[string]*domain.Game) error {
 		if game.LinkMethodExplicit {
 			cfg.LinkMethod = game.LinkMethod.String()
 		}
+		if game.CacheQuota > 0 {
+			cfg.CacheQuota = FormatSize(game.CacheQuota)
+		}
 		// Only write deploy_mode if not the default (extract)
 		if game.DeployMode != domain.DeployExtract {
 			cfg.DeployMode = game.DeployMode.String()
@@ -52,3 +52,71 @@ func TestConvertPaksDefaultAndRoundTrip(t *testing.T) {
 		t.Fatal("convert_paks: false lost on save round-trip")
 	}
 }
+
+func TestSaveIsolationRoundTripAndValidation(t *testing.T) {
+	tempDir := t.TempDir()
+	gamesYAML := `games:
+    skyrim:
+        name: Skyrim
+        install_path: /tmp/skyrim
+        mod_path: /tmp/skyrim/Data
+        saves_path: /tmp/skyrim-saves
+        save_isolation: true
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "games.yaml"), []byte(gamesYAML), 0644))
+
+	games, err := LoadGames(tempDir)
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/skyrim-saves", games["skyrim"].SavesPath)
+	require.True(t, games["skyrim"].SaveIsolation)
+
+	require.NoError(t, SaveGame(tempDir, games["skyrim"]))
+	reloaded, err := LoadGames(tempDir)
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/skyrim-saves", reloaded["skyrim"].SavesPath)
+	require.True(t, reloaded["skyrim"].SaveIsolation)
+
+	// Isolation without a saves_path has nothing to swap - fail loud.
+	bad := `games:
+    skyrim:
+        name: Skyrim
+        install_path: /tmp/skyrim
+        mod_path: /tmp/skyrim/Data
+        save_isolation: true
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "games.yaml"), []byte(bad), 0644))
+	_, err = LoadGames(tempDir)
+	require.ErrorContains(t, err, "save_isolation requires saves_path")
+}
+
+func TestCacheQuotaRoundTripAndValidation(t *testing.T) {
+	tempDir := t.TempDir()
+	gamesYAML := `games:
+    skyrim:
+        name: Skyrim
+        install_path: /tmp/skyrim
+        mod_path: /tmp/skyrim/Data
+        cache_quota: 20GB
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "games.yaml"), []byte(gamesYAML), 0644))
+
+	games, err := LoadGames(tempDir)
+	require.NoError(t, err)
+	require.Equal(t, int64(20<<30), games["skyrim"].CacheQuota)
+
+	require.NoError(t, SaveGame(tempDir, games["skyrim"]))
+	data, err := os.ReadFile(filepath.Join(tempDir, "games.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(data), "cache_quota: 20GB")
+
+	bad := `games:
+    skyrim:
+        name: Skyrim
+        install_path: /tmp/skyrim
+        mod_path: /tmp/skyrim/Data
+        cache_quota: lots
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, "games.yaml"), []byte(bad), 0644))
+	_, err = LoadGames(tempDir)
+	require.ErrorContains(t, err, `game "skyrim": cache_quota: invalid size "lots"`)
+}
@@ -26,15 +26,26 @@ type ProfileHooksYAML struct {
 	Uninstall ProfileHookConfigYAML `yaml:"uninstall"`
 }
 
-// ProfileConfig is the YAML representation of a profile
+// ProfileConfig is the YAML representation of a profile. A profile that
+// extends another holds only its own layer: Mods, Overrides and IniPatches
+// list what it adds or overrides, Remove, RemoveOverrides and
+// RemoveIniPatches what it drops, and Order any load order change (see
+// resolveProfile).
 type ProfileConfig struct {
 	Name       string               `yaml:"name"`
 	GameID     string               `yaml:"game_id"`
+	Extends    string               `yaml:"extends,omitempty"`
 	Mods       []ModReferenceConfig `yaml:"mods"`
+	Remove     []ModKeyConfig       `yaml:"remove,omitempty"`
+	Order      []string             `yaml:"order,omitempty"` // "source:mod" keys, first = lowest priority
 	LinkMethod string               `yaml:"link_method,omitempty"`
 	IsDefault  bool                 `yaml:"is_default,omitempty"`
 	Hooks      ProfileHooksYAML     `yaml:"hooks,omitempty"`
-	Overrides  map[string]string    `yaml:"overrides,omitempty"` // path (relative to game install) -> file content (INI tweaks, etc.)
+	Overrides  map[string]string    `yaml:"overrides,omitempty"`   // path (relative to game install) -> file content (INI tweaks, etc.)
+	IniPatches domain.IniPatches    `yaml:"ini_patches,omitempty"` // file -> section -> key -> value (null deletes)
+
+	RemoveOverrides  []string                       `yaml:"remove_overrides,omitempty"`   // inherited override paths to drop
+	RemoveIniPatches map[string]map[string][]string `yaml:"remove_ini_patches,omitempty"` // file -> section -> inherited patch keys to drop
 }
 
 // ModReferenceConfig is the YAML representation of a mod reference
@@ -46,6 +57,13 @@ type ModReferenceConfig struct {
 	Locked   bool     `yaml:"locked,omitempty"`
 }
 
+// ModKeyConfig names a mod without pinning anything about it, for an
+// extending profile's remove list
+type ModKeyConfig struct {
+	SourceID string `yaml:"source_id"`
+	ModID    string `yaml:"mod_id"`
+}
+
 // parseProfileHooks converts YAML hooks to domain types, tracking which were explicitly set
 func parseProfileHooks(yaml ProfileHooksYAML) (domain.GameHooks, domain.GameHooksExplicit) {
 	hooks := domain.GameHooks{}
@@ -116,8 +134,16 @@ func validateProfilePath(gameID, profileName string) error {
 	return validateSegment(profileName, domain.ErrInvalidProfileName)
 }
 
-// LoadProfile reads a profile from disk
+// LoadProfile reads a profile from disk, resolving what it inherits: a
+// profile that extends another comes back as the effective profile, with
+// its base's mods and settings merged in.
 func LoadProfile(configDir, gameID, profileName string) (*domain.Profile, error) {
+	return loadResolvedProfile(configDir, gameID, profileName, nil)
+}
+
+// LoadProfileLayer reads a profile file as written, without resolving what
+// it inherits
+func LoadProfileLayer(configDir, gameID, profileName string) (*ProfileConfig, error) {
 	if err := validateProfilePath(gameID, profileName); err != nil {
 		return nil, err
 	}
@@ -134,7 +160,12 @@ func LoadProfile(configDir, gameID, profileName string) (*domain.Profile, error)
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("parsing profile: %w", err)
 	}
+	return &cfg, nil
+}
 
+// profileFromLayer converts one profile file to the domain type, leaving
+// inheritance unresolved
+func profileFromLayer(cfg *ProfileConfig, gameID, profileName string) (*domain.Profile, error) {
 	linkMethod, ok := domain.ParseLinkMethod(cfg.LinkMethod)
 	if !ok {
 		return nil, fmt.Errorf("%w: profile %q (game %q): link_method %q (valid: %s)",
@@ -144,6 +175,7 @@ func LoadProfile(configDir, gameID, profileName string) (*domain.Profile, error)
 	profile := &domain.Profile{
 		Name:               cfg.Name,
 		GameID:             cfg.GameID,
+		Extends:            cfg.Extends,
 		LinkMethod:         linkMethod,
 		LinkMethodExplicit: cfg.LinkMethod != "",
 		IsDefault:          cfg.IsDefault,
@@ -161,6 +193,7 @@ func LoadProfile(configDir, gameID, profileName string) (*domain.Profile, error)
 	}
 
 	profile.Hooks, profile.HooksExplicit = parseProfileHooks(cfg.Hooks)
+	profile.IniPatches = cfg.IniPatches
 
 	if len(cfg.Overrides) > 0 {
 		profile.Overrides = make(map[string][]byte)
@@ -172,14 +205,26 @@ func LoadProfile(configDir, gameID, profileName string) (*domain.Profile, error)
 	return profile, nil
 }
 
-// SaveProfile writes a profile to disk
+// SaveProfile writes a profile to disk. A profile that extends another is
+// given as its effective profile, like LoadProfile returns it; only what
+// differs from its base is written, so later changes to the base still
+// reach it.
 func SaveProfile(configDir string, profile *domain.Profile) error {
 	if err := validateProfilePath(profile.GameID, profile.Name); err != nil {
 		return err
 	}
+	var edits layerEdits
+	if profile.Extends != "" {
+		base, err := loadResolvedProfile(configDir, profile.GameID, profile.Extends, []string{profile.Name})
+		if err != nil {
+			return err
+		}
+		profile, edits = layerProfile(base, profile)
+	}
 	cfg := ProfileConfig{
 		Name:      profile.Name,
 		GameID:    profile.GameID,
+		Extends:   profile.Extends,
 		IsDefault: profile.IsDefault,
 		Mods:      make([]ModReferenceConfig, len(profile.Mods)),
 	}
@@ -200,6 +245,11 @@ func SaveProfile(configDir string, profile *domain.Profile) error {
 		}
 	}
 
+	cfg.Remove = edits.Remove
+	cfg.RemoveOverrides = edits.RemoveOverrides
+	cfg.RemoveIniPatches = edits.RemoveIniPatches
+	cfg.Order = edits.Order
+
 	if len(profile.Overrides) > 0 {
 		cfg.Overrides = make(map[string]string)
 		for path, content := range profile.Overrides {
@@ -207,6 +257,10 @@ func SaveProfile(configDir string, profile *domain.Profile) error {
 		}
 	}
 
+	if len(profile.IniPatches) > 0 {
+		cfg.IniPatches = profile.IniPatches
+	}
+
 	data, err := yaml.Marshal(&cfg)
 	if err != nil {
 		return fmt.Errorf("marshaling profile: %w", err)
@@ -253,7 +307,18 @@ func ListProfiles(configDir, gameID string) ([]string, error) {
 	return profiles, nil
 }
 
-// DeleteProfile removes a profile from disk
+// ProfileLockPath returns where a profile's lockfile lives: beside the
+// profile itself, with a .lock extension so ListProfiles never mistakes it
+// for another profile.
+func ProfileLockPath(configDir, gameID, profileName string) (string, error) {
+	if err := validateProfilePath(gameID, profileName); err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "games", gameID, "profiles", profileName+".lock"), nil
+}
+
+// DeleteProfile removes a profile from disk, along with its lockfile if it
+// has one
 func DeleteProfile(configDir, gameID, profileName string) error {
 	if err := validateProfilePath(gameID, profileName); err != nil {
 		return err
@@ -265,6 +330,10 @@ func DeleteProfile(configDir, gameID, profileName string) error {
 		}
 		return fmt.Errorf("deleting profile: %w", err)
 	}
+	lockPath := filepath.Join(configDir, "games", gameID, "profiles", profileName+".lock")
+	if err := os.Remove(lockPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("deleting profile lockfile: %w", err)
+	}
 	return nil
 }
 
@@ -285,6 +354,10 @@ func ExportProfile(profile *domain.Profile) ([]byte, error) {
 		}
 	}
 
+	if len(profile.IniPatches) > 0 {
+		exported.IniPatches = profile.IniPatches
+	}
+
 	data, err := yaml.Marshal(&exported)
 	if err != nil {
 		return nil, fmt.Errorf("marshaling exported profile: %w", err)
@@ -319,5 +392,40 @@ func ImportProfile(data []byte) (*domain.Profile, error) {
 			p.Overrides[path] = []byte(content)
 		}
 	}
+	if len(exported.IniPatches) > 0 {
+		p.IniPatches = exported.IniPatches
+	}
 	return p, nil
 }
+
+// SaveProfileLock writes lock to path
+func SaveProfileLock(path string, lock *domain.ProfileLock) error {
+	data, err := yaml.Marshal(lock)
+	if err != nil {
+		return fmt.Errorf("marshaling lockfile: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating lockfile dir: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing lockfile: %w", err)
+	}
+	return nil
+}
+
+// LoadProfileLock reads a lockfile, refusing a format newer than this build
+// understands rather than verifying against half of it.
+func LoadProfileLock(path string) (*domain.ProfileLock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading lockfile: %w", err)
+	}
+	var lock domain.ProfileLock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parsing lockfile %s: %w", path, err)
+	}
+	if lock.Format < 1 || lock.Format > domain.ProfileLockFormat {
+		return nil, fmt.Errorf("lockfile %s: unsupported format %d", path, lock.Format)
+	}
+	return &lock, nil
+}
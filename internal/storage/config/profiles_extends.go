@@ -0,0 +1,333 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+)
+
+// loadResolvedProfile loads profileName and, when it extends another
+// profile, merges the base's effective profile underneath it. chain holds
+// the profiles already being resolved on the way here, so an extends loop
+// is reported instead of recursing forever.
+func loadResolvedProfile(configDir, gameID, profileName string, chain []string) (*domain.Profile, error) {
+	cfg, err := LoadProfileLayer(configDir, gameID, profileName)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := profileFromLayer(cfg, gameID, profileName)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Extends == "" {
+		return profile, nil
+	}
+
+	chain = append(slices.Clone(chain), profileName)
+	if slices.Contains(chain, cfg.Extends) {
+		return nil, fmt.Errorf("%w: %s -> %s", domain.ErrProfileCycle, strings.Join(chain, " -> "), cfg.Extends)
+	}
+	base, err := loadResolvedProfile(configDir, gameID, cfg.Extends, chain)
+	if err != nil {
+		// Not wrapped: a missing base must not read as profileName itself
+		// being missing.
+		if errors.Is(err, domain.ErrProfileNotFound) {
+			return nil, fmt.Errorf("profile %s extends %s, which does not exist", profileName, cfg.Extends)
+		}
+		return nil, fmt.Errorf("resolving %s's base profile %s: %w", profileName, cfg.Extends, err)
+	}
+	return resolveProfile(base, profile, cfg.edits()), nil
+}
+
+// layerEdits is what an extending profile's layer takes away from its base
+// or rearranges, beyond the entries it adds or overrides.
+type layerEdits struct {
+	Remove           []ModKeyConfig
+	RemoveOverrides  []string
+	RemoveIniPatches map[string]map[string][]string
+	Order            []string
+}
+
+func (cfg *ProfileConfig) edits() layerEdits {
+	return layerEdits{
+		Remove:           cfg.Remove,
+		RemoveOverrides:  cfg.RemoveOverrides,
+		RemoveIniPatches: cfg.RemoveIniPatches,
+		Order:            cfg.Order,
+	}
+}
+
+// resolveProfile merges an extending profile's own layer over its base's
+// effective profile:
+//
+//   - mods: the base's mods, minus those in edits.Remove, keep their order.
+//     A layer mod the base also lists replaces the base's reference in place
+//     (version, file IDs and lock together); the others are appended.
+//     edits.Order then rearranges the mods it names among the slots they
+//     occupy, leaving every other mod where it was.
+//   - link method, hooks: the layer's when it sets them, else the base's.
+//   - overrides and INI patches: merged per file (per key for patches), the
+//     layer winning. The base's overrides in edits.RemoveOverrides and patch
+//     keys in edits.RemoveIniPatches are not inherited; a removed patch key
+//     leaves the INI key alone, unlike a null patch value, which deletes it.
+//
+// Name, game and default flag are always the layer's own.
+func resolveProfile(base, layer *domain.Profile, edits layerEdits) *domain.Profile {
+	effective := *layer
+	effective.Mods = resolveMods(base.Mods, layer.Mods, edits.Remove, edits.Order)
+
+	if !effective.LinkMethodExplicit {
+		effective.LinkMethod, effective.LinkMethodExplicit = base.LinkMethod, base.LinkMethodExplicit
+	}
+	effective.Hooks, effective.HooksExplicit = mergeHooks(base, layer)
+
+	effective.Overrides = nil
+	for path, content := range base.Overrides {
+		if !slices.Contains(edits.RemoveOverrides, path) {
+			setOverride(&effective.Overrides, path, content)
+		}
+	}
+	for path, content := range layer.Overrides {
+		setOverride(&effective.Overrides, path, content)
+	}
+
+	effective.IniPatches = nil
+	for file, sections := range base.IniPatches {
+		for section, keys := range sections {
+			for key, value := range keys {
+				if !slices.Contains(edits.RemoveIniPatches[file][section], key) {
+					setIniPatch(&effective.IniPatches, file, section, key, value)
+				}
+			}
+		}
+	}
+	for file, sections := range layer.IniPatches {
+		for section, keys := range sections {
+			for key, value := range keys {
+				setIniPatch(&effective.IniPatches, file, section, key, value)
+			}
+		}
+	}
+
+	return &effective
+}
+
+func setOverride(overrides *map[string][]byte, path string, content []byte) {
+	if *overrides == nil {
+		*overrides = make(map[string][]byte)
+	}
+	(*overrides)[path] = content
+}
+
+func setIniPatch(patches *domain.IniPatches, file, section, key string, value *string) {
+	if *patches == nil {
+		*patches = make(domain.IniPatches)
+	}
+	if (*patches)[file] == nil {
+		(*patches)[file] = make(map[string]map[string]*string)
+	}
+	if (*patches)[file][section] == nil {
+		(*patches)[file][section] = make(map[string]*string)
+	}
+	(*patches)[file][section][key] = value
+}
+
+// resolveMods applies a layer's mods, removals and reordering to its base's
+// mods (see resolveProfile).
+func resolveMods(baseMods, layerMods []domain.ModReference, remove []ModKeyConfig, order []string) []domain.ModReference {
+	removed := make(map[string]bool, len(remove))
+	for _, key := range remove {
+		removed[domain.ModKey(key.SourceID, key.ModID)] = true
+	}
+	own := make(map[string]int, len(layerMods))
+	for i, m := range layerMods {
+		own[domain.ModKey(m.SourceID, m.ModID)] = i
+	}
+
+	mods := make([]domain.ModReference, 0, len(baseMods)+len(layerMods))
+	used := make(map[string]bool)
+	for _, m := range baseMods {
+		key := domain.ModKey(m.SourceID, m.ModID)
+		if removed[key] {
+			continue
+		}
+		if i, ok := own[key]; ok {
+			m = layerMods[i]
+			used[key] = true
+		}
+		mods = append(mods, m)
+	}
+	for _, m := range layerMods {
+		if !used[domain.ModKey(m.SourceID, m.ModID)] {
+			mods = append(mods, m)
+		}
+	}
+
+	if len(order) == 0 {
+		return mods
+	}
+	pos := make(map[string]int, len(mods))
+	for i, m := range mods {
+		pos[domain.ModKey(m.SourceID, m.ModID)] = i
+	}
+	var slots []int
+	var picked []domain.ModReference
+	for _, key := range order {
+		if i, ok := pos[key]; ok {
+			slots = append(slots, i)
+			picked = append(picked, mods[i])
+			delete(pos, key)
+		}
+	}
+	slices.Sort(slots)
+	for j, slot := range slots {
+		mods[slot] = picked[j]
+	}
+	return mods
+}
+
+// layerProfile is resolveProfile's inverse: given an extending profile's
+// effective form, it returns the layer that resolves back to it over base,
+// along with that layer's edits. Anything equal to what the base provides
+// is left out, so it keeps following the base; anything of the base's the
+// effective profile no longer has becomes a removal.
+func layerProfile(base, effective *domain.Profile) (*domain.Profile, layerEdits) {
+	layer := *effective
+	var edits layerEdits
+
+	baseRefs := make(map[string]domain.ModReference, len(base.Mods))
+	for _, m := range base.Mods {
+		baseRefs[domain.ModKey(m.SourceID, m.ModID)] = m
+	}
+	wanted := make(map[string]bool, len(effective.Mods))
+	for _, m := range effective.Mods {
+		wanted[domain.ModKey(m.SourceID, m.ModID)] = true
+	}
+
+	for _, m := range base.Mods {
+		if !wanted[domain.ModKey(m.SourceID, m.ModID)] {
+			edits.Remove = append(edits.Remove, ModKeyConfig{SourceID: m.SourceID, ModID: m.ModID})
+		}
+	}
+	layer.Mods = nil
+	for _, m := range effective.Mods {
+		if b, ok := baseRefs[domain.ModKey(m.SourceID, m.ModID)]; ok && sameModReference(b, m) {
+			continue
+		}
+		layer.Mods = append(layer.Mods, m)
+	}
+
+	// An explicit order is only written when the default layering would
+	// put the mods somewhere else; it names every mod so the order holds
+	// whatever the base later adds.
+	if !sameLoadOrder(resolveMods(base.Mods, layer.Mods, edits.Remove, nil), effective.Mods) {
+		for _, m := range effective.Mods {
+			edits.Order = append(edits.Order, domain.ModKey(m.SourceID, m.ModID))
+		}
+	}
+
+	if layer.LinkMethodExplicit && base.LinkMethodExplicit && layer.LinkMethod == base.LinkMethod {
+		layer.LinkMethodExplicit = false
+	}
+
+	layer.Overrides = nil
+	for path, content := range effective.Overrides {
+		if baseContent, ok := base.Overrides[path]; ok && string(baseContent) == string(content) {
+			continue
+		}
+		if layer.Overrides == nil {
+			layer.Overrides = make(map[string][]byte)
+		}
+		layer.Overrides[path] = content
+	}
+	for path := range base.Overrides {
+		if _, ok := effective.Overrides[path]; !ok {
+			edits.RemoveOverrides = append(edits.RemoveOverrides, path)
+		}
+	}
+	slices.Sort(edits.RemoveOverrides)
+
+	layer.IniPatches = nil
+	for file, sections := range effective.IniPatches {
+		for section, keys := range sections {
+			for key, value := range keys {
+				if baseValue, ok := base.IniPatches[file][section][key]; ok && sameIniValue(baseValue, value) {
+					continue
+				}
+				if layer.IniPatches == nil {
+					layer.IniPatches = make(domain.IniPatches)
+				}
+				if layer.IniPatches[file] == nil {
+					layer.IniPatches[file] = make(map[string]map[string]*string)
+				}
+				if layer.IniPatches[file][section] == nil {
+					layer.IniPatches[file][section] = make(map[string]*string)
+				}
+				layer.IniPatches[file][section][key] = value
+			}
+		}
+	}
+	for file, sections := range base.IniPatches {
+		for section, keys := range sections {
+			for key := range keys {
+				if _, ok := effective.IniPatches[file][section][key]; ok {
+					continue
+				}
+				if edits.RemoveIniPatches == nil {
+					edits.RemoveIniPatches = make(map[string]map[string][]string)
+				}
+				if edits.RemoveIniPatches[file] == nil {
+					edits.RemoveIniPatches[file] = make(map[string][]string)
+				}
+				edits.RemoveIniPatches[file][section] = append(edits.RemoveIniPatches[file][section], key)
+			}
+		}
+	}
+	for _, sections := range edits.RemoveIniPatches {
+		for _, keys := range sections {
+			slices.Sort(keys)
+		}
+	}
+
+	return &layer, edits
+}
+
+// mergeHooks takes each of layer's hooks it sets explicitly, and base's for
+// the rest.
+func mergeHooks(base, layer *domain.Profile) (domain.GameHooks, domain.GameHooksExplicit) {
+	hooks, explicit := layer.Hooks, layer.HooksExplicit
+	merge := func(value *string, set *bool, baseValue string, baseSet bool) {
+		if !*set {
+			*value, *set = baseValue, baseSet
+		}
+	}
+	merge(&hooks.Install.BeforeAll, &explicit.Install.BeforeAll, base.Hooks.Install.BeforeAll, base.HooksExplicit.Install.BeforeAll)
+	merge(&hooks.Install.BeforeEach, &explicit.Install.BeforeEach, base.Hooks.Install.BeforeEach, base.HooksExplicit.Install.BeforeEach)
+	merge(&hooks.Install.AfterEach, &explicit.Install.AfterEach, base.Hooks.Install.AfterEach, base.HooksExplicit.Install.AfterEach)
+	merge(&hooks.Install.AfterAll, &explicit.Install.AfterAll, base.Hooks.Install.AfterAll, base.HooksExplicit.Install.AfterAll)
+	merge(&hooks.Uninstall.BeforeAll, &explicit.Uninstall.BeforeAll, base.Hooks.Uninstall.BeforeAll, base.HooksExplicit.Uninstall.BeforeAll)
+	merge(&hooks.Uninstall.BeforeEach, &explicit.Uninstall.BeforeEach, base.Hooks.Uninstall.BeforeEach, base.HooksExplicit.Uninstall.BeforeEach)
+	merge(&hooks.Uninstall.AfterEach, &explicit.Uninstall.AfterEach, base.Hooks.Uninstall.AfterEach, base.HooksExplicit.Uninstall.AfterEach)
+	merge(&hooks.Uninstall.AfterAll, &explicit.Uninstall.AfterAll, base.Hooks.Uninstall.AfterAll, base.HooksExplicit.Uninstall.AfterAll)
+	return hooks, explicit
+}
+
+func sameModReference(a, b domain.ModReference) bool {
+	return a.Version == b.Version && a.Locked == b.Locked && slices.Equal(a.FileIDs, b.FileIDs)
+}
+
+func sameLoadOrder(a, b []domain.ModReference) bool {
+	return slices.EqualFunc(a, b, func(x, y domain.ModReference) bool {
+		return x.SourceID == y.SourceID && x.ModID == y.ModID
+	})
+}
+
+func sameIniValue(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,235 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeProfileYAML writes a profile file verbatim, the way a user editing
+// it by hand would.
+func writeProfileYAML(t *testing.T, configDir, name, content string) {
+	t.Helper()
+	dir := filepath.Join(configDir, "games", "g1", "profiles")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".yaml"), []byte(content), 0644))
+}
+
+func modKeys(mods []domain.ModReference) []string {
+	keys := make([]string, len(mods))
+	for i, m := range mods {
+		keys[i] = domain.ModKey(m.SourceID, m.ModID)
+	}
+	return keys
+}
+
+func writeBaseProfile(t *testing.T, configDir string) {
+	t.Helper()
+	writeProfileYAML(t, configDir, "base", `name: base
+game_id: g1
+link_method: hardlink
+mods:
+  - {source_id: src, mod_id: a, version: "1.0"}
+  - {source_id: src, mod_id: b, version: "1.0"}
+  - {source_id: src, mod_id: c, version: "1.0"}
+overrides:
+  game.ini: base
+  other.ini: base
+ini_patches:
+  prefs.ini:
+    Display:
+      fov: "90"
+      vsync: "1"
+`)
+}
+
+func TestLoadProfile_ResolvesExtends(t *testing.T) {
+	dir := t.TempDir()
+	writeBaseProfile(t, dir)
+	writeProfileYAML(t, dir, "survival", `name: survival
+game_id: g1
+extends: base
+mods:
+  - {source_id: src, mod_id: c, version: "2.0", locked: true}
+  - {source_id: src, mod_id: d, version: "1.0"}
+remove:
+  - {source_id: src, mod_id: b}
+overrides:
+  game.ini: survival
+ini_patches:
+  prefs.ini:
+    Display:
+      fov: "110"
+`)
+
+	profile, err := LoadProfile(dir, "g1", "survival")
+	require.NoError(t, err)
+	assert.Equal(t, "base", profile.Extends)
+	assert.Equal(t, []string{"src:a", "src:c", "src:d"}, modKeys(profile.Mods))
+	c := profile.FindRef("src", "c")
+	assert.Equal(t, "2.0", c.Version)
+	assert.True(t, c.Locked)
+	assert.Equal(t, domain.LinkHardlink, profile.LinkMethod, "link method is inherited")
+	assert.True(t, profile.LinkMethodExplicit)
+	assert.Equal(t, "survival", string(profile.Overrides["game.ini"]))
+	assert.Equal(t, "base", string(profile.Overrides["other.ini"]))
+	assert.Equal(t, "110", *profile.IniPatches["prefs.ini"]["Display"]["fov"])
+	assert.Equal(t, "1", *profile.IniPatches["prefs.ini"]["Display"]["vsync"])
+	assert.False(t, profile.IsDefault)
+
+	// A change to the base reaches the child on its next load.
+	base, err := LoadProfile(dir, "g1", "base")
+	require.NoError(t, err)
+	base.Mods = append(base.Mods, domain.ModReference{SourceID: "src", ModID: "e", Version: "1.0"})
+	base.FindRef("src", "a").Version = "1.1"
+	require.NoError(t, SaveProfile(dir, base))
+	profile, err = LoadProfile(dir, "g1", "survival")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"src:a", "src:c", "src:e", "src:d"}, modKeys(profile.Mods))
+	assert.Equal(t, "1.1", profile.FindRef("src", "a").Version)
+}
+
+func TestLoadProfile_ExtendsChainAndOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeBaseProfile(t, dir)
+	writeProfileYAML(t, dir, "survival", `name: survival
+game_id: g1
+extends: base
+mods:
+  - {source_id: src, mod_id: d}
+`)
+	// order moves c and a between the slots they hold; b and d stay put.
+	writeProfileYAML(t, dir, "hardcore", `name: hardcore
+game_id: g1
+extends: survival
+mods: []
+order: ["src:c", "src:a", "src:missing"]
+`)
+
+	profile, err := LoadProfile(dir, "g1", "hardcore")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"src:c", "src:b", "src:a", "src:d"}, modKeys(profile.Mods))
+}
+
+func TestLoadProfile_ExtendsErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeProfileYAML(t, dir, "a", "name: a\ngame_id: g1\nextends: b\nmods: []\n")
+	writeProfileYAML(t, dir, "b", "name: b\ngame_id: g1\nextends: a\nmods: []\n")
+	writeProfileYAML(t, dir, "self", "name: self\ngame_id: g1\nextends: self\nmods: []\n")
+	writeProfileYAML(t, dir, "orphan", "name: orphan\ngame_id: g1\nextends: gone\nmods: []\n")
+
+	_, err := LoadProfile(dir, "g1", "a")
+	assert.ErrorIs(t, err, domain.ErrProfileCycle)
+	_, err = LoadProfile(dir, "g1", "self")
+	assert.ErrorIs(t, err, domain.ErrProfileCycle)
+
+	_, err = LoadProfile(dir, "g1", "orphan")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrProfileNotFound, "the child exists; only its base is missing")
+	assert.Contains(t, err.Error(), "extends gone")
+}
+
+func TestSaveProfile_WritesOnlyTheLayer(t *testing.T) {
+	dir := t.TempDir()
+	writeBaseProfile(t, dir)
+	writeProfileYAML(t, dir, "survival", `name: survival
+game_id: g1
+extends: base
+mods:
+  - {source_id: src, mod_id: d}
+`)
+
+	profile, err := LoadProfile(dir, "g1", "survival")
+	require.NoError(t, err)
+
+	// Round trip: nothing inherited is copied into the child.
+	require.NoError(t, SaveProfile(dir, profile))
+	layer, err := LoadProfileLayer(dir, "g1", "survival")
+	require.NoError(t, err)
+	assert.Equal(t, "base", layer.Extends)
+	assert.Equal(t, []ModReferenceConfig{{SourceID: "src", ModID: "d"}}, layer.Mods)
+	assert.Empty(t, layer.Remove)
+	assert.Empty(t, layer.Order)
+	assert.Empty(t, layer.LinkMethod)
+	assert.Empty(t, layer.Overrides)
+	assert.Empty(t, layer.IniPatches)
+
+	// Edits to the effective profile become removes, overrides and an order.
+	profile.Mods = []domain.ModReference{
+		{SourceID: "src", ModID: "d"},
+		{SourceID: "src", ModID: "c", Version: "2.0"},
+		{SourceID: "src", ModID: "a", Version: "1.0"},
+	}
+	vsync := "0"
+	profile.IniPatches["prefs.ini"]["Display"]["vsync"] = &vsync
+	require.NoError(t, SaveProfile(dir, profile))
+
+	layer, err = LoadProfileLayer(dir, "g1", "survival")
+	require.NoError(t, err)
+	assert.Equal(t, []ModKeyConfig{{SourceID: "src", ModID: "b"}}, layer.Remove)
+	assert.Equal(t, []ModReferenceConfig{{SourceID: "src", ModID: "d"}, {SourceID: "src", ModID: "c", Version: "2.0"}}, layer.Mods)
+	assert.Equal(t, []string{"src:d", "src:c", "src:a"}, layer.Order)
+	assert.Equal(t, domain.IniPatches{"prefs.ini": {"Display": {"vsync": &vsync}}}, layer.IniPatches)
+
+	reloaded, err := LoadProfile(dir, "g1", "survival")
+	require.NoError(t, err)
+	assert.Equal(t, profile.Mods, reloaded.Mods)
+}
+
+func TestSaveProfile_RemovesInheritedOverridesAndPatchKeys(t *testing.T) {
+	dir := t.TempDir()
+	writeBaseProfile(t, dir)
+	writeProfileYAML(t, dir, "survival", "name: survival\ngame_id: g1\nextends: base\nmods: []\n")
+
+	profile, err := LoadProfile(dir, "g1", "survival")
+	require.NoError(t, err)
+	delete(profile.Overrides, "other.ini")
+	delete(profile.IniPatches["prefs.ini"]["Display"], "vsync")
+	require.NoError(t, SaveProfile(dir, profile))
+
+	layer, err := LoadProfileLayer(dir, "g1", "survival")
+	require.NoError(t, err)
+	assert.Empty(t, layer.Overrides)
+	assert.Empty(t, layer.IniPatches)
+	assert.Equal(t, []string{"other.ini"}, layer.RemoveOverrides)
+	assert.Equal(t, map[string]map[string][]string{"prefs.ini": {"Display": {"vsync"}}}, layer.RemoveIniPatches)
+
+	reloaded, err := LoadProfile(dir, "g1", "survival")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"game.ini": []byte("base")}, reloaded.Overrides)
+	fov := "90"
+	assert.Equal(t, domain.IniPatches{"prefs.ini": {"Display": {"fov": &fov}}}, reloaded.IniPatches)
+
+	// Dropping every inherited entry leaves the child with none, and
+	// putting one back clears its removal.
+	reloaded.Overrides = nil
+	reloaded.IniPatches = nil
+	require.NoError(t, SaveProfile(dir, reloaded))
+	reloaded, err = LoadProfile(dir, "g1", "survival")
+	require.NoError(t, err)
+	assert.Empty(t, reloaded.Overrides)
+	assert.Empty(t, reloaded.IniPatches)
+
+	reloaded.Overrides = map[string][]byte{"other.ini": []byte("base")}
+	require.NoError(t, SaveProfile(dir, reloaded))
+	layer, err = LoadProfileLayer(dir, "g1", "survival")
+	require.NoError(t, err)
+	assert.Empty(t, layer.Overrides, "an override equal to the base's keeps following it")
+	assert.Equal(t, []string{"game.ini"}, layer.RemoveOverrides)
+}
+
+func TestSaveProfile_RefusesExtendsCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeProfileYAML(t, dir, "child", "name: child\ngame_id: g1\nextends: base\nmods: []\n")
+	writeProfileYAML(t, dir, "base", "name: base\ngame_id: g1\nmods: []\n")
+
+	base, err := LoadProfile(dir, "g1", "base")
+	require.NoError(t, err)
+	base.Extends = "child"
+	assert.ErrorIs(t, SaveProfile(dir, base), domain.ErrProfileCycle)
+}
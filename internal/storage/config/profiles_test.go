@@ -329,6 +329,21 @@ func TestDeleteProfile(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrProfileNotFound)
 }
 
+func TestDeleteProfile_RemovesLockfile(t *testing.T) {
+	configDir := t.TempDir()
+	require.NoError(t, SaveProfile(configDir, &domain.Profile{Name: "default", GameID: "skyrim-se"}))
+	lockPath, err := ProfileLockPath(configDir, "skyrim-se", "default")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(lockPath, []byte("format: 1\n"), 0644))
+
+	names, err := ListProfiles(configDir, "skyrim-se")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default"}, names, "a lockfile is not a profile")
+
+	require.NoError(t, DeleteProfile(configDir, "skyrim-se", "default"))
+	assert.NoFileExists(t, lockPath)
+}
+
 func TestDeleteProfile_Missing(t *testing.T) {
 	configDir := t.TempDir()
 
@@ -505,3 +520,31 @@ func TestImportProfile_PreservesLockedMarker(t *testing.T) {
 	require.Len(t, imported.Mods, 1)
 	assert.True(t, imported.Mods[0].Locked, "locked marker should be preserved on import")
 }
+
+func TestProfileIniPatchesRoundTrip(t *testing.T) {
+	tempDir := t.TempDir()
+	on := "1"
+	profile := &domain.Profile{
+		Name:   "default",
+		GameID: "skyrim",
+		IniPatches: domain.IniPatches{
+			"Skyrim.ini": {"Launcher": {"bEnableFileSelection": &on, "sOldKey": nil}},
+		},
+	}
+	require.NoError(t, SaveProfile(tempDir, profile))
+
+	data, err := os.ReadFile(filepath.Join(tempDir, "games", "skyrim", "profiles", "default.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "ini_patches:")
+	assert.Contains(t, string(data), "sOldKey: null")
+
+	loaded, err := LoadProfile(tempDir, "skyrim", "default")
+	require.NoError(t, err)
+	assert.Equal(t, profile.IniPatches, loaded.IniPatches)
+
+	exported, err := ExportProfile(loaded)
+	require.NoError(t, err)
+	imported, err := ImportProfile(exported)
+	require.NoError(t, err)
+	assert.Equal(t, profile.IniPatches, imported.IniPatches)
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sizeUnits are the suffixes ParseSize accepts, largest first. Like the
+// sizes lmm prints, every unit is binary: "GB" and "GiB" are both 1024^3.
+var sizeUnits = []struct {
+	names []string
+	bytes int64
+}{
+	{[]string{"t", "tb", "tib"}, 1 << 40},
+	{[]string{"g", "gb", "gib"}, 1 << 30},
+	{[]string{"m", "mb", "mib"}, 1 << 20},
+	{[]string{"k", "kb", "kib"}, 1 << 10},
+	{[]string{"", "b"}, 1},
+}
+
+// ParseSize parses a byte size such as "20GB", "512 MiB", "1.5g" or "4096".
+// Units are case-insensitive and binary.
+func ParseSize(s string) (int64, error) {
+	trimmed := strings.TrimSpace(s)
+	i := strings.IndexFunc(trimmed, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if i < 0 {
+		i = len(trimmed)
+	}
+	number, unit := trimmed[:i], strings.ToLower(strings.TrimSpace(trimmed[i:]))
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	for _, u := range sizeUnits {
+		for _, name := range u.names {
+			if unit == name {
+				return int64(value * float64(u.bytes)), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid size %q: unknown unit %q (use B, KB, MB, GB or TB)", s, trimmed[i:])
+}
+
+// FormatSize renders n in the largest unit that represents it exactly, so a
+// size written by FormatSize reads back unchanged through ParseSize.
+func FormatSize(n int64) string {
+	for _, u := range sizeUnits[:len(sizeUnits)-1] {
+		if n != 0 && n%u.bytes == 0 {
+			return strconv.FormatInt(n/u.bytes, 10) + strings.ToUpper(u.names[1])
+		}
+	}
+	return strconv.FormatInt(n, 10)
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSize(t *testing.T) {
+	tests := map[string]int64{
+		"4096":    4096,
+		"512B":    512,
+		"1k":      1024,
+		"20GB":    20 << 30,
+		"20 GiB":  20 << 30,
+		"1.5g":    3 << 29,
+		"2TB":     2 << 40,
+		" 64 mb ": 64 << 20,
+	}
+	for in, want := range tests {
+		got, err := ParseSize(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "GB", "-1GB", "10 parsecs", "1.2.3MB"} {
+		_, err := ParseSize(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestFormatSizeRoundTrips(t *testing.T) {
+	assert.Equal(t, "20GB", FormatSize(20<<30))
+	assert.Equal(t, "1536MB", FormatSize(3<<29))
+	assert.Equal(t, "1000", FormatSize(1000))
+	for _, n := range []int64{1, 1000, 1 << 10, 3 << 29, 5 << 40} {
+		back, err := ParseSize(FormatSize(n))
+		require.NoError(t, err)
+		assert.Equal(t, n, back)
+	}
+}
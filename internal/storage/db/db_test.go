@@ -57,8 +57,22 @@ func TestNew_DropsModCacheTableOnUpgrade(t *testing.T) {
 	database, err := db.New(path)
 	require.NoError(t, err)
 
-	// Rewind to v10 by reverting schema changes from v11 and v12.
-	// v11 dropped mod_cache; v12 added convert_paks. Undo both.
+	// Rewind to v10 by reverting schema changes from v11 onward.
+	// v11 dropped mod_cache; v12 added convert_paks; v13 added dev_path;
+	// v14 added mirror_health; v15 added installed_mod_files.sha256/size;
+	// v16 added operation_history; v17 added mod_version_history.
+	_, err = database.Exec("DROP TABLE mod_version_history")
+	require.NoError(t, err, "revert v17 schema change before rewinding version tracker")
+	_, err = database.Exec("DROP TABLE operation_history")
+	require.NoError(t, err, "revert v16 schema change before rewinding version tracker")
+	_, err = database.Exec("ALTER TABLE installed_mod_files DROP COLUMN size")
+	require.NoError(t, err, "revert v15 schema change before rewinding version tracker")
+	_, err = database.Exec("ALTER TABLE installed_mod_files DROP COLUMN sha256")
+	require.NoError(t, err, "revert v15 schema change before rewinding version tracker")
+	_, err = database.Exec("DROP TABLE mirror_health")
+	require.NoError(t, err, "revert v14 schema change before rewinding version tracker")
+	_, err = database.Exec("ALTER TABLE installed_mods DROP COLUMN dev_path")
+	require.NoError(t, err, "revert v13 schema change before rewinding version tracker")
 	_, err = database.Exec("ALTER TABLE installed_mods DROP COLUMN convert_paks")
 	require.NoError(t, err, "revert v12 schema change before rewinding version tracker")
 	_, err = database.Exec("DELETE FROM schema_migrations WHERE version >= 11")
@@ -584,6 +598,38 @@ func TestGetFilesWithChecksums(t *testing.T) {
 	assert.Equal(t, "hash222", checksumMap["222"])
 }
 
+func TestSaveFileDigest(t *testing.T) {
+	database, err := db.New(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+
+	mod := &domain.InstalledMod{
+		Mod:         domain.Mod{ID: "12345", SourceID: "nexusmods", Name: "Test Mod", Version: "1.0.0", GameID: "skyrim-se"},
+		ProfileName: "default",
+		FileIDs:     []string{"111", "222"},
+	}
+	require.NoError(t, database.SaveInstalledMod(mod))
+	require.NoError(t, database.SaveFileChecksum("nexusmods", "12345", "skyrim-se", "default", "111", "md5sum"))
+	require.NoError(t, database.SaveFileDigest("nexusmods", "12345", "skyrim-se", "default", "111", "sha111", 4096))
+
+	files, err := database.GetFilesWithChecksums("skyrim-se", "default")
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	byID := make(map[string]db.FileWithChecksum)
+	for _, f := range files {
+		byID[f.FileID] = f
+	}
+	assert.Equal(t, "md5sum", byID["111"].Checksum, "the digest does not replace the MD5 checksum")
+	assert.Equal(t, "sha111", byID["111"].SHA256)
+	assert.Equal(t, int64(4096), byID["111"].Size)
+	assert.Empty(t, byID["222"].SHA256)
+	assert.Zero(t, byID["222"].Size)
+
+	err = database.SaveFileDigest("nexusmods", "12345", "skyrim-se", "default", "999", "sha999", 1)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no installed file row")
+}
+
 func TestMigrationV7_DeployedFilesTable(t *testing.T) {
 	database, err := db.New(":memory:")
 	require.NoError(t, err)
@@ -615,3 +661,50 @@ func TestMigrationV7_DeployedFilesTable(t *testing.T) {
 	assert.Equal(t, "nexusmods", sourceID)
 	assert.Equal(t, "12345", modID)
 }
+
+func TestModVersionHistory(t *testing.T) {
+	database, err := db.New(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+
+	mod := &domain.InstalledMod{
+		Mod:         domain.Mod{ID: "12345", SourceID: "nexusmods", Name: "Test Mod", Version: "1.0.0", GameID: "skyrim-se"},
+		ProfileName: "default",
+		FileIDs:     []string{"111"},
+	}
+	require.NoError(t, database.SaveInstalledMod(mod))
+	require.NoError(t, database.ApplyModUpdate("nexusmods", "12345", "skyrim-se", "default", "2.0.0", []string{"222"}))
+	require.NoError(t, database.ApplyModUpdate("nexusmods", "12345", "skyrim-se", "default", "3.0.0", []string{"333", "334"}))
+
+	versions := func() []string {
+		records, err := database.ListModVersions("nexusmods", "12345", "skyrim-se", "default")
+		require.NoError(t, err)
+		var out []string
+		for _, r := range records {
+			out = append(out, r.Version)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"3.0.0", "2.0.0", "1.0.0"}, versions())
+
+	// A swap redeploys 2.0.0: it moves to the front instead of repeating.
+	require.NoError(t, database.SwapModVersions("nexusmods", "12345", "skyrim-se", "default"))
+	assert.Equal(t, []string{"2.0.0", "3.0.0", "1.0.0"}, versions())
+
+	require.NoError(t, database.SetModVersions("nexusmods", "12345", "skyrim-se", "default", "1.0.0", []string{"111"}, "2.0.0", []string{"222"}))
+	assert.Equal(t, []string{"1.0.0", "2.0.0", "3.0.0"}, versions())
+	got, err := database.GetInstalledMod("nexusmods", "12345", "skyrim-se", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", got.Version)
+	assert.Equal(t, []string{"111"}, got.FileIDs)
+	assert.Equal(t, "2.0.0", got.PreviousVersion)
+	assert.Equal(t, []string{"222"}, got.PreviousFileIDs)
+
+	records, err := database.ListModVersions("nexusmods", "12345", "skyrim-se", "default")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"333", "334"}, records[2].FileIDs)
+	assert.False(t, records[2].DeployedAt.IsZero())
+
+	err = database.SetModVersions("nexusmods", "missing", "skyrim-se", "default", "1.0.0", nil, "", nil)
+	assert.ErrorIs(t, err, domain.ErrModNotFound)
+}
@@ -0,0 +1,187 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Operation names recorded in the history.
+const (
+	OpInstall   = "install"
+	OpUninstall = "uninstall"
+	OpEnable    = "enable"
+	OpDisable   = "disable"
+	OpReorder   = "reorder"
+	OpUpdate    = "update"
+	OpRollback  = "rollback"
+	OpSwitch    = "switch"
+	OpImport    = "import"
+	OpDeploy    = "deploy"
+	OpPurge     = "purge"
+)
+
+// ErrOperationNotFound is returned by GetOperation for an unknown ID.
+var ErrOperationNotFound = errors.New("operation not found")
+
+// Operation is one entry of the operation history: a mutating flow run
+// against one profile of one game.
+type Operation struct {
+	ID        int64
+	GameID    string
+	Profile   string
+	Operation string
+	Mods      []OperationMod
+
+	// OrderBefore and OrderAfter are the profile's load order (mod keys)
+	// around a reorder; empty for every other operation.
+	OrderBefore []string
+	OrderAfter  []string
+
+	// FromProfile is the profile a switch moved away from; Profile is the
+	// one it activated.
+	FromProfile string
+
+	// UndoOf is the ID of the entry this one reverted, or 0. UndoneBy is
+	// the ID of the later entry that reverted this one, or 0; it is derived
+	// from UndoOf when listing, never stored.
+	UndoOf   int64
+	UndoneBy int64
+
+	CreatedAt time.Time
+}
+
+// OperationMod is one mod touched by an operation. An empty version means
+// the mod was not installed on that side of the operation.
+type OperationMod struct {
+	SourceID      string `json:"source_id"`
+	ModID         string `json:"mod_id"`
+	Name          string `json:"name,omitempty"`
+	BeforeVersion string `json:"before_version,omitempty"`
+	AfterVersion  string `json:"after_version,omitempty"`
+	BeforeEnabled bool   `json:"before_enabled"`
+	AfterEnabled  bool   `json:"after_enabled"`
+}
+
+// RecordOperation appends op to the history and returns its ID. op.ID and
+// op.UndoneBy are ignored; a zero op.CreatedAt records the current time.
+func (d *DB) RecordOperation(op *Operation) (int64, error) {
+	at := op.CreatedAt
+	if at.IsZero() {
+		at = time.Now()
+	}
+	mods, err := json.Marshal(nonNil(op.Mods))
+	if err != nil {
+		return 0, fmt.Errorf("encoding operation mods: %w", err)
+	}
+	before, err := json.Marshal(nonNil(op.OrderBefore))
+	if err != nil {
+		return 0, fmt.Errorf("encoding load order: %w", err)
+	}
+	after, err := json.Marshal(nonNil(op.OrderAfter))
+	if err != nil {
+		return 0, fmt.Errorf("encoding load order: %w", err)
+	}
+
+	res, err := d.Exec(`
+        INSERT INTO operation_history (game_id, profile_name, operation, mods, order_before, order_after, from_profile, undo_of, created_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `, op.GameID, op.Profile, op.Operation, string(mods), string(before), string(after), op.FromProfile, op.UndoOf, at.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("recording operation: %w", err)
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("recording operation: %w", err)
+	}
+	return id, nil
+}
+
+// ListOperations returns the history for gameID, or for every game when
+// gameID is empty, newest first. A positive limit caps the number of
+// entries returned.
+func (d *DB) ListOperations(gameID string, limit int) ([]Operation, error) {
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := d.Query(`
+        SELECT h.id, h.game_id, h.profile_name, h.operation, h.mods, h.order_before, h.order_after,
+               h.from_profile, h.undo_of, COALESCE(MAX(u.id), 0), h.created_at
+        FROM operation_history h
+        LEFT JOIN operation_history u ON u.undo_of = h.id
+        WHERE ? = '' OR h.game_id = ?
+        GROUP BY h.id
+        ORDER BY h.id DESC
+        LIMIT ?
+    `, gameID, gameID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("listing operations: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var ops []Operation
+	for rows.Next() {
+		op, err := scanOperation(rows)
+		if err != nil {
+			return nil, err
+		}
+		ops = append(ops, *op)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing operations: %w", err)
+	}
+	return ops, nil
+}
+
+// GetOperation returns the history entry with the given ID.
+func (d *DB) GetOperation(id int64) (*Operation, error) {
+	row := d.QueryRow(`
+        SELECT h.id, h.game_id, h.profile_name, h.operation, h.mods, h.order_before, h.order_after,
+               h.from_profile, h.undo_of, COALESCE((SELECT MAX(u.id) FROM operation_history u WHERE u.undo_of = h.id), 0), h.created_at
+        FROM operation_history h
+        WHERE h.id = ?
+    `, id)
+	op, err := scanOperation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrOperationNotFound
+	}
+	return op, err
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanOperation(row rowScanner) (*Operation, error) {
+	var op Operation
+	var mods, before, after string
+	var createdAt sql.NullTime
+	if err := row.Scan(&op.ID, &op.GameID, &op.Profile, &op.Operation, &mods, &before, &after,
+		&op.FromProfile, &op.UndoOf, &op.UndoneBy, &createdAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("scanning operation: %w", err)
+	}
+	if err := json.Unmarshal([]byte(mods), &op.Mods); err != nil {
+		return nil, fmt.Errorf("decoding mods of operation %d: %w", op.ID, err)
+	}
+	if err := json.Unmarshal([]byte(before), &op.OrderBefore); err != nil {
+		return nil, fmt.Errorf("decoding load order of operation %d: %w", op.ID, err)
+	}
+	if err := json.Unmarshal([]byte(after), &op.OrderAfter); err != nil {
+		return nil, fmt.Errorf("decoding load order of operation %d: %w", op.ID, err)
+	}
+	op.CreatedAt = createdAt.Time
+	return &op, nil
+}
+
+// nonNil keeps empty lists encoding as [] rather than null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationHistory(t *testing.T) {
+	db := setupTestDB(t)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	ops, err := db.ListOperations("", 0)
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	enable, err := db.RecordOperation(&Operation{
+		GameID: "skyrim-se", Profile: "default", Operation: OpEnable, CreatedAt: t0,
+		Mods: []OperationMod{{SourceID: "nexusmods", ModID: "42", Name: "Mod", BeforeVersion: "1.0", AfterVersion: "1.0", AfterEnabled: true}},
+	})
+	require.NoError(t, err)
+	reorder, err := db.RecordOperation(&Operation{
+		GameID: "skyrim-se", Profile: "default", Operation: OpReorder, CreatedAt: t0.Add(time.Minute),
+		OrderBefore: []string{"nexusmods:42", "nexusmods:43"}, OrderAfter: []string{"nexusmods:43", "nexusmods:42"},
+	})
+	require.NoError(t, err)
+	_, err = db.RecordOperation(&Operation{GameID: "other", Profile: "default", Operation: OpSwitch, FromProfile: "old"})
+	require.NoError(t, err)
+	undo, err := db.RecordOperation(&Operation{GameID: "skyrim-se", Profile: "default", Operation: OpDisable, UndoOf: enable})
+	require.NoError(t, err)
+
+	ops, err = db.ListOperations("skyrim-se", 0)
+	require.NoError(t, err)
+	require.Len(t, ops, 3)
+	assert.Equal(t, []int64{undo, reorder, enable}, []int64{ops[0].ID, ops[1].ID, ops[2].ID}, "newest first")
+	assert.Equal(t, enable, ops[0].UndoOf)
+	assert.Equal(t, undo, ops[2].UndoneBy)
+	assert.Zero(t, ops[1].UndoneBy)
+	assert.Equal(t, []string{"nexusmods:43", "nexusmods:42"}, ops[1].OrderAfter)
+	assert.True(t, ops[1].CreatedAt.Equal(t0.Add(time.Minute)))
+	assert.Equal(t, "1.0", ops[2].Mods[0].AfterVersion)
+	assert.True(t, ops[2].Mods[0].AfterEnabled)
+
+	ops, err = db.ListOperations("", 2)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	assert.Equal(t, OpSwitch, ops[1].Operation)
+	assert.Equal(t, "old", ops[1].FromProfile)
+
+	op, err := db.GetOperation(enable)
+	require.NoError(t, err)
+	assert.Equal(t, undo, op.UndoneBy)
+	assert.Equal(t, "skyrim-se", op.GameID)
+
+	_, err = db.GetOperation(999)
+	assert.ErrorIs(t, err, ErrOperationNotFound)
+}
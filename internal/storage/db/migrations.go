@@ -34,6 +34,11 @@ func (d *DB) migrate() error {
 		migrateV10,
 		migrateV11,
 		migrateV12,
+		migrateV13,
+		migrateV14,
+		migrateV15,
+		migrateV16,
+		migrateV17,
 	}
 
 	for i := version; i < len(migrations); i++ {
@@ -197,3 +202,115 @@ func migrateV12(d *DB) error {
 	_, err := d.Exec(`ALTER TABLE installed_mods ADD COLUMN convert_paks INTEGER DEFAULT 1`)
 	return err
 }
+
+func migrateV13(d *DB) error {
+	// Dev mods (lmm dev link) deploy straight from a working directory
+	// instead of the cache; dev_path records that directory. Empty for
+	// every cached mod.
+	_, err := d.Exec(`ALTER TABLE installed_mods ADD COLUMN dev_path TEXT DEFAULT ''`)
+	return err
+}
+
+func migrateV14(d *DB) error {
+	// Per-source, per-host download outcomes for files that declare mirrors,
+	// so failover can try hosts that worked last time first and `lmm source
+	// list -v` can show which mirrors are healthy.
+	_, err := d.Exec(`
+		CREATE TABLE mirror_health (
+			source_id TEXT NOT NULL,
+			host TEXT NOT NULL,
+			successes INTEGER NOT NULL DEFAULT 0,
+			failures INTEGER NOT NULL DEFAULT 0,
+			last_success_at DATETIME,
+			last_failure_at DATETIME,
+			last_error TEXT NOT NULL DEFAULT '',
+			PRIMARY KEY(source_id, host)
+		)
+	`)
+	return err
+}
+
+func migrateV15(d *DB) error {
+	// Per-file SHA-256 and size of the downloaded archive, for profile
+	// lockfiles. checksum stays MD5: verify compares against it, and sources
+	// that publish only MD5 are checked against it at download time.
+	if _, err := d.Exec(`ALTER TABLE installed_mod_files ADD COLUMN sha256 TEXT`); err != nil {
+		return err
+	}
+	_, err := d.Exec(`ALTER TABLE installed_mod_files ADD COLUMN size INTEGER`)
+	return err
+}
+
+func migrateV16(d *DB) error {
+	// Append-only log of what each mutating flow did, for `lmm history` and
+	// `lmm undo`. mods and the load orders are JSON; undo_of links an undo
+	// to the entry it reverted, so nothing is ever rewritten.
+	_, err := d.Exec(`
+		CREATE TABLE operation_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			game_id TEXT NOT NULL,
+			profile_name TEXT NOT NULL,
+			operation TEXT NOT NULL,
+			mods TEXT NOT NULL DEFAULT '[]',
+			order_before TEXT NOT NULL DEFAULT '[]',
+			order_after TEXT NOT NULL DEFAULT '[]',
+			from_profile TEXT NOT NULL DEFAULT '',
+			undo_of INTEGER NOT NULL DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = d.Exec(`CREATE INDEX idx_operation_history_game ON operation_history(game_id, id)`)
+	return err
+}
+
+func migrateV17(d *DB) error {
+	// Every version and file-ID set ever deployed per mod and profile, so a
+	// rollback can reach further back than previous_version. file_ids is the
+	// sorted JSON list; deployed_at is the last time the pair was deployed.
+	// Seeded from the current and previous versions already recorded.
+	if _, err := d.Exec(`
+		CREATE TABLE mod_version_history (
+			source_id TEXT NOT NULL,
+			mod_id TEXT NOT NULL,
+			game_id TEXT NOT NULL,
+			profile_name TEXT NOT NULL,
+			version TEXT NOT NULL,
+			file_ids TEXT NOT NULL DEFAULT '[]',
+			deployed_at DATETIME,
+			PRIMARY KEY(source_id, mod_id, game_id, profile_name, version, file_ids)
+		)
+	`); err != nil {
+		return err
+	}
+	if _, err := d.Exec(`
+		INSERT OR IGNORE INTO mod_version_history (source_id, mod_id, game_id, profile_name, version, file_ids, deployed_at)
+		SELECT m.source_id, m.mod_id, m.game_id, m.profile_name, m.previous_version,
+			COALESCE((
+				SELECT json_group_array(value) FROM (
+					SELECT value FROM json_each(COALESCE(NULLIF(m.previous_file_ids, ''), '[]')) ORDER BY value
+				)
+			), '[]'),
+			NULL
+		FROM installed_mods m
+		WHERE m.previous_version IS NOT NULL AND m.previous_version != ''
+	`); err != nil {
+		return err
+	}
+	_, err := d.Exec(`
+		INSERT OR IGNORE INTO mod_version_history (source_id, mod_id, game_id, profile_name, version, file_ids, deployed_at)
+		SELECT m.source_id, m.mod_id, m.game_id, m.profile_name, m.version,
+			COALESCE((
+				SELECT json_group_array(file_id) FROM (
+					SELECT f.file_id FROM installed_mod_files f
+					WHERE f.source_id = m.source_id AND f.mod_id = m.mod_id AND f.game_id = m.game_id AND f.profile_name = m.profile_name
+					ORDER BY f.file_id
+				)
+			), '[]'),
+			m.installed_at
+		FROM installed_mods m
+	`)
+	return err
+}
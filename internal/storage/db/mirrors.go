@@ -0,0 +1,89 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// MirrorHealth is the download record of one host serving one source's
+// mirrored files. LastSuccessAt and LastFailureAt are zero when the host has
+// never succeeded or never failed.
+type MirrorHealth struct {
+	SourceID      string
+	Host          string
+	Successes     int
+	Failures      int
+	LastSuccessAt time.Time
+	LastFailureAt time.Time
+	LastError     string
+}
+
+// Healthy reports whether the host's most recent attempt succeeded (or it
+// has never been tried).
+func (h MirrorHealth) Healthy() bool {
+	return h.LastFailureAt.IsZero() || h.LastSuccessAt.After(h.LastFailureAt)
+}
+
+// RecordMirrorSuccess counts a successful download from host for sourceID.
+func (d *DB) RecordMirrorSuccess(sourceID, host string, at time.Time) error {
+	_, err := d.Exec(`
+        INSERT INTO mirror_health (source_id, host, successes, last_success_at)
+        VALUES (?, ?, 1, ?)
+        ON CONFLICT(source_id, host) DO UPDATE SET
+            successes = successes + 1,
+            last_success_at = excluded.last_success_at
+    `, sourceID, host, at.UTC())
+	if err != nil {
+		return fmt.Errorf("recording mirror success: %w", err)
+	}
+	return nil
+}
+
+// RecordMirrorFailure counts a failed download from host for sourceID,
+// keeping errMsg as the host's last error.
+func (d *DB) RecordMirrorFailure(sourceID, host, errMsg string, at time.Time) error {
+	_, err := d.Exec(`
+        INSERT INTO mirror_health (source_id, host, failures, last_failure_at, last_error)
+        VALUES (?, ?, 1, ?, ?)
+        ON CONFLICT(source_id, host) DO UPDATE SET
+            failures = failures + 1,
+            last_failure_at = excluded.last_failure_at,
+            last_error = excluded.last_error
+    `, sourceID, host, at.UTC(), errMsg)
+	if err != nil {
+		return fmt.Errorf("recording mirror failure: %w", err)
+	}
+	return nil
+}
+
+// ListMirrorHealth returns the mirror records for sourceID, or for every
+// source when sourceID is empty, ordered by source ID then host.
+func (d *DB) ListMirrorHealth(sourceID string) ([]MirrorHealth, error) {
+	rows, err := d.Query(`
+        SELECT source_id, host, successes, failures, last_success_at, last_failure_at, last_error
+        FROM mirror_health
+        WHERE ? = '' OR source_id = ?
+        ORDER BY source_id, host
+    `, sourceID, sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("listing mirror health: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var records []MirrorHealth
+	for rows.Next() {
+		var h MirrorHealth
+		var lastSuccess, lastFailure sql.NullTime
+		if err := rows.Scan(&h.SourceID, &h.Host, &h.Successes, &h.Failures, &lastSuccess, &lastFailure, &h.LastError); err != nil {
+			return nil, fmt.Errorf("scanning mirror health: %w", err)
+		}
+		h.LastSuccessAt = lastSuccess.Time
+		h.LastFailureAt = lastFailure.Time
+		records = append(records, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("listing mirror health: %w", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirrorHealth(t *testing.T) {
+	db := setupTestDB(t)
+	t.Cleanup(func() {
+		require.NoError(t, db.Close())
+	})
+
+	records, err := db.ListMirrorHealth("")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, db.RecordMirrorSuccess("team", "files.example.com", t0))
+	require.NoError(t, db.RecordMirrorFailure("team", "files.example.com", "connection refused", t0.Add(time.Minute)))
+	require.NoError(t, db.RecordMirrorFailure("team", "files.example.com", "HTTP 503", t0.Add(2*time.Minute)))
+	require.NoError(t, db.RecordMirrorSuccess("team", "backup.example.org", t0.Add(2*time.Minute)))
+	require.NoError(t, db.RecordMirrorSuccess("other", "cdn.example.net", t0))
+
+	records, err = db.ListMirrorHealth("team")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	// Ordered by host.
+	assert.Equal(t, "backup.example.org", records[0].Host)
+	assert.Equal(t, 1, records[0].Successes)
+	assert.Zero(t, records[0].Failures)
+	assert.True(t, records[0].LastFailureAt.IsZero())
+	assert.True(t, records[0].Healthy())
+
+	assert.Equal(t, "files.example.com", records[1].Host)
+	assert.Equal(t, 1, records[1].Successes)
+	assert.Equal(t, 2, records[1].Failures)
+	assert.True(t, records[1].LastSuccessAt.Equal(t0))
+	assert.True(t, records[1].LastFailureAt.Equal(t0.Add(2*time.Minute)))
+	assert.Equal(t, "HTTP 503", records[1].LastError)
+	assert.False(t, records[1].Healthy(), "the latest attempt failed")
+
+	records, err = db.ListMirrorHealth("")
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "other", records[0].SourceID)
+}
@@ -57,8 +57,8 @@ func (d *DB) SaveInstalledMod(mod *domain.InstalledMod) error {
 	}
 
 	_, err = tx.Exec(`
-		INSERT INTO installed_mods (source_id, mod_id, game_id, profile_name, name, version, author, update_policy, enabled, deployed, installed_at, previous_version, previous_file_ids, link_method, manual_download, summary, source_url)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO installed_mods (source_id, mod_id, game_id, profile_name, name, version, author, update_policy, enabled, deployed, installed_at, previous_version, previous_file_ids, link_method, manual_download, summary, source_url, dev_path)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT(source_id, mod_id, game_id, profile_name) DO UPDATE SET
 			name = excluded.name,
 			version = excluded.version,
@@ -70,8 +70,9 @@ func (d *DB) SaveInstalledMod(mod *domain.InstalledMod) error {
 			link_method = excluded.link_method,
 			manual_download = excluded.manual_download,
 			summary = excluded.summary,
-			source_url = excluded.source_url
-	`, mod.SourceID, mod.ID, mod.GameID, mod.ProfileName, mod.Name, mod.Version, mod.Author, mod.UpdatePolicy, mod.Enabled, mod.Deployed, time.Now(), prevVersion, prevFileIDs, mod.LinkMethod, mod.ManualDownload, mod.Summary, mod.SourceURL)
+			source_url = excluded.source_url,
+			dev_path = excluded.dev_path
+	`, mod.SourceID, mod.ID, mod.GameID, mod.ProfileName, mod.Name, mod.Version, mod.Author, mod.UpdatePolicy, mod.Enabled, mod.Deployed, time.Now(), prevVersion, prevFileIDs, mod.LinkMethod, mod.ManualDownload, mod.Summary, mod.SourceURL, mod.DevPath)
 	if err != nil {
 		return fmt.Errorf("saving installed mod: %w", err)
 	}
@@ -80,6 +81,9 @@ func (d *DB) SaveInstalledMod(mod *domain.InstalledMod) error {
 	if err := replaceModFileIDsTx(tx, mod.SourceID, mod.ID, mod.GameID, mod.ProfileName, mod.FileIDs); err != nil {
 		return err
 	}
+	if err := recordModVersionTx(tx, mod.SourceID, mod.ID, mod.GameID, mod.ProfileName, mod.Version, mod.FileIDs); err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
@@ -87,7 +91,7 @@ func (d *DB) SaveInstalledMod(mod *domain.InstalledMod) error {
 // GetInstalledMods returns all installed mods for a game/profile combination
 func (d *DB) GetInstalledMods(gameID, profileName string) (mods []domain.InstalledMod, err error) {
 	rows, err := d.Query(`
-		SELECT source_id, mod_id, game_id, profile_name, name, version, author, update_policy, enabled, deployed, installed_at, previous_version, previous_file_ids, link_method, manual_download, summary, source_url, convert_paks
+		SELECT source_id, mod_id, game_id, profile_name, name, version, author, update_policy, enabled, deployed, installed_at, previous_version, previous_file_ids, link_method, manual_download, summary, source_url, convert_paks, dev_path
 		FROM installed_mods
 		WHERE game_id = ? AND profile_name = ?
 		ORDER BY installed_at ASC
@@ -109,7 +113,7 @@ func (d *DB) GetInstalledMods(gameID, profileName string) (mods []domain.Install
 			&mod.SourceID, &mod.ID, &mod.GameID, &mod.ProfileName,
 			&mod.Name, &mod.Version, &mod.Author, &mod.UpdatePolicy,
 			&mod.Enabled, &mod.Deployed, &mod.InstalledAt, &prevVersion, &prevFileIDs, &mod.LinkMethod, &mod.ManualDownload,
-			&mod.Summary, &mod.SourceURL, &mod.ConvertPaks,
+			&mod.Summary, &mod.SourceURL, &mod.ConvertPaks, &mod.DevPath,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("scanning installed mod: %w", err)
@@ -292,14 +296,14 @@ func (d *DB) GetInstalledMod(sourceID, modID, gameID, profileName string) (*doma
 	err := d.QueryRow(`
 		SELECT source_id, mod_id, game_id, profile_name, name, version, author,
 		       update_policy, enabled, deployed, installed_at, previous_version, previous_file_ids, link_method, manual_download,
-		       summary, source_url, convert_paks
+		       summary, source_url, convert_paks, dev_path
 		FROM installed_mods
 		WHERE source_id = ? AND mod_id = ? AND game_id = ? AND profile_name = ?
 	`, sourceID, modID, gameID, profileName).Scan(
 		&mod.SourceID, &mod.ID, &mod.GameID, &mod.ProfileName,
 		&mod.Name, &mod.Version, &mod.Author, &mod.UpdatePolicy,
 		&mod.Enabled, &mod.Deployed, &mod.InstalledAt, &prevVersion, &prevFileIDs, &mod.LinkMethod, &mod.ManualDownload,
-		&mod.Summary, &mod.SourceURL, &mod.ConvertPaks,
+		&mod.Summary, &mod.SourceURL, &mod.ConvertPaks, &mod.DevPath,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -440,7 +444,7 @@ func (d *DB) UpdateModVersion(sourceID, modID, gameID, profileName, newVersion s
 		return domain.ErrModNotFound
 	}
 
-	return nil
+	return recordModVersionTx(d, sourceID, modID, gameID, profileName, newVersion, currentFileIDs)
 }
 
 // SetModLinkMethod updates the link method for an installed mod
@@ -512,6 +516,9 @@ func (d *DB) ApplyModUpdate(sourceID, modID, gameID, profileName, newVersion str
 	if err := replaceModFileIDsTx(tx, sourceID, modID, gameID, profileName, newFileIDs); err != nil {
 		return err
 	}
+	if err := recordModVersionTx(tx, sourceID, modID, gameID, profileName, newVersion, newFileIDs); err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
@@ -567,6 +574,9 @@ func (d *DB) SwapModVersions(sourceID, modID, gameID, profileName string) error
 	if err := replaceModFileIDsTx(tx, sourceID, modID, gameID, profileName, prevFileIDs); err != nil {
 		return err
 	}
+	if err := recordModVersionTx(tx, sourceID, modID, gameID, profileName, prevVal, prevFileIDs); err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
@@ -577,6 +587,8 @@ type FileWithChecksum struct {
 	ModID    string
 	FileID   string
 	Checksum string
+	SHA256   string // SHA-256 of the downloaded archive; empty if never recorded
+	Size     int64  // Size of the downloaded archive in bytes; 0 if never recorded
 }
 
 // SaveFileChecksum stores the MD5 checksum for a downloaded file. The target
@@ -602,6 +614,28 @@ func (d *DB) SaveFileChecksum(sourceID, modID, gameID, profileName, fileID, chec
 	return nil
 }
 
+// SaveFileDigest stores the SHA-256 and size of a downloaded file, the
+// identity a profile lockfile pins. Like SaveFileChecksum, the
+// installed_mod_files row must already exist.
+func (d *DB) SaveFileDigest(sourceID, modID, gameID, profileName, fileID, sha256 string, size int64) error {
+	res, err := d.Exec(`
+		UPDATE installed_mod_files SET sha256 = ?, size = ?
+		WHERE source_id = ? AND mod_id = ? AND game_id = ? AND profile_name = ? AND file_id = ?
+	`, sha256, size, sourceID, modID, gameID, profileName, fileID)
+	if err != nil {
+		return fmt.Errorf("saving file digest: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("saving file digest: rows affected: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("saving file digest: no installed file row for %s/%s file %s (game %s, profile %s)",
+			sourceID, modID, fileID, gameID, profileName)
+	}
+	return nil
+}
+
 // GetFileChecksum retrieves the checksum for a specific file
 // Returns empty string if file not found or has no checksum
 func (d *DB) GetFileChecksum(sourceID, modID, gameID, profileName, fileID string) (string, error) {
@@ -625,7 +659,7 @@ func (d *DB) GetFileChecksum(sourceID, modID, gameID, profileName, fileID string
 // GetFilesWithChecksums returns all files for a game/profile with their checksums
 func (d *DB) GetFilesWithChecksums(gameID, profileName string) (files []FileWithChecksum, err error) {
 	rows, err := d.Query(`
-		SELECT source_id, mod_id, file_id, checksum
+		SELECT source_id, mod_id, file_id, checksum, sha256, size
 		FROM installed_mod_files
 		WHERE game_id = ? AND profile_name = ?
 	`, gameID, profileName)
@@ -640,19 +674,108 @@ func (d *DB) GetFilesWithChecksums(gameID, profileName string) (files []FileWith
 
 	for rows.Next() {
 		var f FileWithChecksum
-		var checksum *string
-		if err := rows.Scan(&f.SourceID, &f.ModID, &f.FileID, &checksum); err != nil {
+		var checksum, sha256 *string
+		var size *int64
+		if err := rows.Scan(&f.SourceID, &f.ModID, &f.FileID, &checksum, &sha256, &size); err != nil {
 			return nil, fmt.Errorf("scanning file with checksum: %w", err)
 		}
 		if checksum != nil {
 			f.Checksum = *checksum
 		}
+		if sha256 != nil {
+			f.SHA256 = *sha256
+		}
+		if size != nil {
+			f.Size = *size
+		}
 		files = append(files, f)
 	}
 
 	return files, rows.Err()
 }
 
+// GetVersionFileDigests returns every recorded SHA-256 digest of a file of
+// sourceID/modID at version, across all of gameID's profiles. Rows with no
+// digest are left out; a file installed in several profiles appears once
+// per profile.
+func (d *DB) GetVersionFileDigests(gameID, sourceID, modID, version string) (files []FileWithChecksum, err error) {
+	rows, err := d.Query(`
+		SELECT f.source_id, f.mod_id, f.file_id, f.checksum, f.sha256, f.size
+		FROM installed_mod_files f
+		JOIN installed_mods m ON m.source_id = f.source_id AND m.mod_id = f.mod_id
+			AND m.game_id = f.game_id AND m.profile_name = f.profile_name
+		WHERE f.game_id = ? AND f.source_id = ? AND f.mod_id = ? AND m.version = ?
+			AND f.sha256 IS NOT NULL AND f.sha256 != ''
+	`, gameID, sourceID, modID, version)
+	if err != nil {
+		return nil, fmt.Errorf("querying file digests: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("closing rows: %w", cerr)
+		}
+	}()
+
+	for rows.Next() {
+		var f FileWithChecksum
+		var checksum *string
+		var size *int64
+		if err := rows.Scan(&f.SourceID, &f.ModID, &f.FileID, &checksum, &f.SHA256, &size); err != nil {
+			return nil, fmt.Errorf("scanning file digest: %w", err)
+		}
+		if checksum != nil {
+			f.Checksum = *checksum
+		}
+		if size != nil {
+			f.Size = *size
+		}
+		files = append(files, f)
+	}
+	return files, rows.Err()
+}
+
+// InstalledVersion is the version bookkeeping of one installed mod record.
+type InstalledVersion struct {
+	SourceID        string
+	ModID           string
+	ProfileName     string
+	Version         string
+	PreviousVersion string
+}
+
+// GetInstalledVersions returns the current and previous versions of every mod
+// installed for a game, across all of its profiles.
+func (d *DB) GetInstalledVersions(gameID string) (versions []InstalledVersion, err error) {
+	rows, err := d.Query(`
+		SELECT source_id, mod_id, profile_name, version, previous_version
+		FROM installed_mods
+		WHERE game_id = ?
+		ORDER BY profile_name, source_id, mod_id
+	`, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("querying installed versions: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("closing rows: %w", cerr)
+		}
+	}()
+
+	for rows.Next() {
+		var v InstalledVersion
+		var previous *string
+		if err := rows.Scan(&v.SourceID, &v.ModID, &v.ProfileName, &v.Version, &previous); err != nil {
+			return nil, fmt.Errorf("scanning installed version: %w", err)
+		}
+		if previous != nil {
+			v.PreviousVersion = *previous
+		}
+		versions = append(versions, v)
+	}
+
+	return versions, rows.Err()
+}
+
 // execer abstracts *sql.DB and *sql.Tx for running SQL statements.
 type execer interface {
 	Exec(query string, args ...any) (sql.Result, error)
@@ -317,3 +317,52 @@ func TestSetModConvertPaks(t *testing.T) {
 	err = database.SetModConvertPaks("icarus", "nope", "icarus", "default", true)
 	assert.ErrorIs(t, err, domain.ErrModNotFound)
 }
+
+func TestSaveInstalledMod_RoundTripsDevPath(t *testing.T) {
+	database, err := db.New(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, database.Close()) })
+
+	installTestMod(t, database)
+	got, err := database.GetInstalledMod("nexusmods", "12345", "skyrim-se", "default")
+	require.NoError(t, err)
+	assert.Empty(t, got.DevPath, "cached mods have no dev path")
+
+	dev := &domain.InstalledMod{
+		Mod:         domain.Mod{ID: "my-mod", SourceID: domain.SourceDev, Name: "my-mod", Version: "dev", GameID: "skyrim-se"},
+		ProfileName: "default",
+		Enabled:     true,
+		DevPath:     "/home/me/Projects/my-mod",
+	}
+	require.NoError(t, database.SaveInstalledMod(dev))
+
+	mods, err := database.GetInstalledMods("skyrim-se", "default")
+	require.NoError(t, err)
+	require.Len(t, mods, 2)
+	assert.Equal(t, "/home/me/Projects/my-mod", mods[1].DevPath)
+}
+
+func TestGetInstalledVersions(t *testing.T) {
+	database, err := db.New(":memory:")
+	require.NoError(t, err)
+	defer func() { _ = database.Close() }()
+
+	for _, m := range []struct{ id, game, profile string }{
+		{"12345", "skyrim-se", "default"},
+		{"12345", "skyrim-se", "survival"},
+		{"999", "other-game", "default"},
+	} {
+		require.NoError(t, database.SaveInstalledMod(&domain.InstalledMod{
+			Mod:         domain.Mod{ID: m.id, SourceID: "nexusmods", Name: "Mod", Version: "1.0.0", GameID: m.game},
+			ProfileName: m.profile,
+		}))
+	}
+	require.NoError(t, database.UpdateModVersion("nexusmods", "12345", "skyrim-se", "survival", "2.0.0"))
+
+	versions, err := database.GetInstalledVersions("skyrim-se")
+	require.NoError(t, err)
+	assert.Equal(t, []db.InstalledVersion{
+		{SourceID: "nexusmods", ModID: "12345", ProfileName: "default", Version: "1.0.0"},
+		{SourceID: "nexusmods", ModID: "12345", ProfileName: "survival", Version: "2.0.0", PreviousVersion: "1.0.0"},
+	}, versions)
+}
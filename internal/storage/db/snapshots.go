@@ -0,0 +1,183 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// GameRows holds every row one game has in the tables a snapshot covers,
+// keyed by table name, each row a column-to-value map. Values are kept in a
+// JSON-safe form (DATETIME columns as the driver's own text format), so a
+// GameRows survives encoding/json unchanged apart from numbers turning into
+// float64, which SQLite's column affinity stores back as integers.
+type GameRows map[string][]map[string]any
+
+// snapshotTables lists the per-game tables ExportGameRows captures, parents
+// before children: ReplaceGameRows inserts in this order and deletes in
+// reverse, which keeps installed_mod_files' foreign key satisfied.
+// mod_version_history is left out on purpose: it only ever grows, and a
+// restore must not forget versions deployed after the snapshot was taken.
+// Rows it carries from older snapshots are ignored.
+var snapshotTables = []string{"installed_mods", "installed_mod_files", "deployed_files"}
+
+// snapshotSkipColumns are surrogate keys ReplaceGameRows lets SQLite assign
+// afresh rather than reinserting; nothing references them.
+var snapshotSkipColumns = map[string]string{"installed_mods": "id"}
+
+// sqliteTimeFormat is the layout the driver writes time.Time values in, and
+// one it parses back for DATETIME columns.
+const sqliteTimeFormat = "2006-01-02 15:04:05.999999999-07:00"
+
+// ExportGameRows returns every row gameID has in the snapshot tables.
+func (d *DB) ExportGameRows(gameID string) (GameRows, error) {
+	out := make(GameRows, len(snapshotTables))
+	for _, table := range snapshotTables {
+		rows, err := d.exportTableRows(table, gameID)
+		if err != nil {
+			return nil, err
+		}
+		out[table] = rows
+	}
+	return out, nil
+}
+
+func (d *DB) exportTableRows(table, gameID string) (out []map[string]any, err error) {
+	rows, err := d.Query(fmt.Sprintf(`SELECT * FROM %s WHERE game_id = ?`, table), gameID)
+	if err != nil {
+		return nil, fmt.Errorf("exporting %s: %w", table, err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("closing rows: %w", cerr)
+		}
+	}()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("exporting %s: %w", table, err)
+	}
+	for rows.Next() {
+		values := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", table, err)
+		}
+		row := make(map[string]any, len(cols))
+		for i, col := range cols {
+			if col == snapshotSkipColumns[table] {
+				continue
+			}
+			switch v := values[i].(type) {
+			case time.Time:
+				row[col] = v.Format(sqliteTimeFormat)
+			case []byte:
+				row[col] = string(v)
+			default:
+				row[col] = v
+			}
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+// ReplaceGameRows swaps every row gameID has in the snapshot tables for
+// rows, in one transaction. Columns rows carries that the schema no longer
+// has are dropped; columns it lacks (added by a later migration) take their
+// defaults. Tables rows does not mention are left empty for the game.
+func (d *DB) ReplaceGameRows(gameID string, rows GameRows) error {
+	tx, err := d.Begin()
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	for _, table := range slices.Backward(snapshotTables) {
+		if _, err := tx.Exec(fmt.Sprintf(`DELETE FROM %s WHERE game_id = ?`, table), gameID); err != nil {
+			return fmt.Errorf("clearing %s: %w", table, err)
+		}
+	}
+
+	for _, table := range snapshotTables {
+		known, err := tableColumns(tx, table)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows[table] {
+			if row["game_id"] != gameID {
+				return fmt.Errorf("restoring %s: row belongs to game %v, not %s", table, row["game_id"], gameID)
+			}
+			var cols, marks []string
+			var args []any
+			for _, col := range known {
+				v, ok := row[col]
+				if !ok || col == snapshotSkipColumns[table] {
+					continue
+				}
+				cols = append(cols, col)
+				marks = append(marks, "?")
+				args = append(args, v)
+			}
+			stmt := fmt.Sprintf(`INSERT INTO %s (%s) VALUES (%s)`, table, strings.Join(cols, ", "), strings.Join(marks, ", "))
+			if _, err := tx.Exec(stmt, args...); err != nil {
+				return fmt.Errorf("restoring %s: %w", table, err)
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// tableColumns returns table's column names in schema order.
+func tableColumns(tx *sql.Tx, table string) (cols []string, err error) {
+	rows, err := tx.Query(`SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s columns: %w", table, err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("closing rows: %w", cerr)
+		}
+	}()
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("scanning %s column: %w", table, err)
+		}
+		cols = append(cols, name)
+	}
+	return cols, rows.Err()
+}
+
+// ListDeployedFiles returns the owner of every file deployed in profileName,
+// keyed by relative path.
+func (d *DB) ListDeployedFiles(gameID, profileName string) (owners map[string]FileOwner, err error) {
+	rows, err := d.Query(`
+		SELECT relative_path, source_id, mod_id FROM deployed_files
+		WHERE game_id = ? AND profile_name = ?
+	`, gameID, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("listing deployed files: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("closing rows: %w", cerr)
+		}
+	}()
+
+	owners = make(map[string]FileOwner)
+	for rows.Next() {
+		var path string
+		var owner FileOwner
+		if err := rows.Scan(&path, &owner.SourceID, &owner.ModID); err != nil {
+			return nil, fmt.Errorf("scanning deployed file: %w", err)
+		}
+		owners[path] = owner
+	}
+	return owners, rows.Err()
+}
@@ -0,0 +1,119 @@
+package db_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+	"github.com/DonovanMods/linux-mod-manager/internal/storage/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceGameRows_RoundTripsThroughJSON(t *testing.T) {
+	database, err := db.New(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, database.Close())
+	})
+
+	installTestMod(t, database)
+	require.NoError(t, database.SetModFileIDs("nexusmods", "12345", "skyrim-se", "default", []string{"f1", "f2"}))
+	require.NoError(t, database.SaveFileChecksum("nexusmods", "12345", "skyrim-se", "default", "f1", "abc"))
+	require.NoError(t, database.SaveDeployedFile("skyrim-se", "default", "meshes/a.nif", "nexusmods", "12345"))
+	require.NoError(t, database.SaveDeployedFile("other-game", "default", "keep.esp", "nexusmods", "9"))
+	before, err := database.GetInstalledMod("nexusmods", "12345", "skyrim-se", "default")
+	require.NoError(t, err)
+
+	exported, err := database.ExportGameRows("skyrim-se")
+	require.NoError(t, err)
+	data, err := json.Marshal(exported)
+	require.NoError(t, err)
+	var rows db.GameRows
+	require.NoError(t, json.Unmarshal(data, &rows))
+
+	// Diverge: a new version, a different deployed file, an extra mod.
+	require.NoError(t, database.ApplyModUpdate("nexusmods", "12345", "skyrim-se", "default", "2.0.0", []string{"f3"}))
+	require.NoError(t, database.DeleteDeployedFiles("skyrim-se", "default", "nexusmods", "12345"))
+	require.NoError(t, database.SaveDeployedFile("skyrim-se", "default", "meshes/b.nif", "nexusmods", "12345"))
+	require.NoError(t, database.SaveInstalledMod(&domain.InstalledMod{
+		Mod:         domain.Mod{ID: "777", SourceID: "nexusmods", Name: "Extra", Version: "1", GameID: "skyrim-se"},
+		ProfileName: "default",
+	}))
+
+	require.NoError(t, database.ReplaceGameRows("skyrim-se", rows))
+
+	after, err := database.GetInstalledMod("nexusmods", "12345", "skyrim-se", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", after.Version)
+	assert.ElementsMatch(t, []string{"f1", "f2"}, after.FileIDs)
+	assert.Equal(t, before.InstalledAt.Unix(), after.InstalledAt.Unix())
+	sum, err := database.GetFileChecksum("nexusmods", "12345", "skyrim-se", "default", "f1")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", sum)
+
+	_, err = database.GetInstalledMod("nexusmods", "777", "skyrim-se", "default")
+	assert.ErrorIs(t, err, domain.ErrModNotFound)
+
+	files, err := database.ListDeployedFiles("skyrim-se", "default")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]db.FileOwner{"meshes/a.nif": {SourceID: "nexusmods", ModID: "12345"}}, files)
+	other, err := database.ListDeployedFiles("other-game", "default")
+	require.NoError(t, err)
+	assert.Len(t, other, 1, "other games' rows are untouched")
+
+	last, err := database.GetLastDeployTime("skyrim-se", "default")
+	require.NoError(t, err)
+	assert.NotNil(t, last, "deployed_at must read back as a time")
+}
+
+func TestReplaceGameRows_RejectsRowsOfAnotherGame(t *testing.T) {
+	database, err := db.New(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, database.Close())
+	})
+
+	require.NoError(t, database.SaveDeployedFile("skyrim-se", "default", "meshes/a.nif", "nexusmods", "12345"))
+	rows, err := database.ExportGameRows("skyrim-se")
+	require.NoError(t, err)
+
+	err = database.ReplaceGameRows("fallout-4", rows)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "belongs to game skyrim-se")
+	files, err := database.ListDeployedFiles("skyrim-se", "default")
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestReplaceGameRows_KeepsVersionHistory(t *testing.T) {
+	database, err := db.New(":memory:")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, database.Close())
+	})
+
+	installTestMod(t, database)
+	rows, err := database.ExportGameRows("skyrim-se")
+	require.NoError(t, err)
+	assert.NotContains(t, rows, "mod_version_history")
+
+	// A version deployed after the snapshot stays in the history once the
+	// snapshot is restored, even when an older snapshot still carries
+	// history rows.
+	require.NoError(t, database.ApplyModUpdate("nexusmods", "12345", "skyrim-se", "default", "2.0.0", []string{"f3"}))
+	rows["mod_version_history"] = []map[string]any{}
+	require.NoError(t, database.ReplaceGameRows("skyrim-se", rows))
+
+	mod, err := database.GetInstalledMod("nexusmods", "12345", "skyrim-se", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", mod.Version)
+	history, err := database.ListModVersions("nexusmods", "12345", "skyrim-se", "default")
+	require.NoError(t, err)
+	var versions []string
+	for _, r := range history {
+		versions = append(versions, r.Version)
+	}
+	assert.ElementsMatch(t, []string{"1.0.0", "2.0.0"}, versions)
+}
@@ -0,0 +1,126 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/DonovanMods/linux-mod-manager/internal/domain"
+)
+
+// ModVersionRecord is one version and file-ID set of a mod that was deployed
+// in a profile. DeployedAt is the last time it was deployed, zero when
+// unknown (versions recorded before the history existed).
+type ModVersionRecord struct {
+	Version    string
+	FileIDs    []string
+	DeployedAt time.Time
+}
+
+// recordModVersionTx notes that version with fileIDs was deployed now. The
+// file IDs are stored sorted, so the same set always maps to one record.
+func recordModVersionTx(e execer, sourceID, modID, gameID, profileName, version string, fileIDs []string) error {
+	if version == "" {
+		return nil
+	}
+	var ids []string
+	for _, id := range fileIDs {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	raw, err := encodeFileIDs(slices.Compact(ids))
+	if err != nil {
+		return err
+	}
+	_, err = e.Exec(`
+		INSERT INTO mod_version_history (source_id, mod_id, game_id, profile_name, version, file_ids, deployed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(source_id, mod_id, game_id, profile_name, version, file_ids) DO UPDATE SET
+			deployed_at = excluded.deployed_at
+	`, sourceID, modID, gameID, profileName, version, raw, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("recording mod version: %w", err)
+	}
+	return nil
+}
+
+// ListModVersions returns every version of a mod deployed in profileName,
+// most recently deployed first; versions with no known deploy time come
+// last.
+func (d *DB) ListModVersions(sourceID, modID, gameID, profileName string) (records []ModVersionRecord, err error) {
+	rows, err := d.Query(`
+		SELECT version, file_ids, deployed_at FROM mod_version_history
+		WHERE source_id = ? AND mod_id = ? AND game_id = ? AND profile_name = ?
+		ORDER BY deployed_at IS NULL, deployed_at DESC, version DESC
+	`, sourceID, modID, gameID, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("listing mod versions: %w", err)
+	}
+	defer func() {
+		if cerr := rows.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("closing rows: %w", cerr)
+		}
+	}()
+
+	for rows.Next() {
+		var r ModVersionRecord
+		var raw string
+		var deployedAt sql.NullTime
+		if err := rows.Scan(&r.Version, &raw, &deployedAt); err != nil {
+			return nil, fmt.Errorf("scanning mod version: %w", err)
+		}
+		if r.FileIDs, err = decodeFileIDs(&raw); err != nil {
+			return nil, err
+		}
+		r.DeployedAt = deployedAt.Time
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// SetModVersions moves an installed mod to version/fileIDs and sets its
+// rollback target to previousVersion/previousFileIDs in one transaction -
+// the general form of ApplyModUpdate and SwapModVersions, for a rollback to
+// an older version from the history.
+func (d *DB) SetModVersions(sourceID, modID, gameID, profileName, version string, fileIDs []string, previousVersion string, previousFileIDs []string) error {
+	tx, err := d.Begin()
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var prevVersion *string
+	if previousVersion != "" {
+		prevVersion = &previousVersion
+	}
+	prevFileIDs, err := encodeFileIDs(previousFileIDs)
+	if err != nil {
+		return err
+	}
+	result, err := tx.Exec(`
+		UPDATE installed_mods
+		SET version = ?, previous_version = ?, previous_file_ids = ?
+		WHERE source_id = ? AND mod_id = ? AND game_id = ? AND profile_name = ?
+	`, version, prevVersion, prevFileIDs, sourceID, modID, gameID, profileName)
+	if err != nil {
+		return fmt.Errorf("setting mod versions: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("setting mod versions: checking rows affected: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrModNotFound
+	}
+
+	if err := replaceModFileIDsTx(tx, sourceID, modID, gameID, profileName, fileIDs); err != nil {
+		return err
+	}
+	if err := recordModVersionTx(tx, sourceID, modID, gameID, profileName, version, fileIDs); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
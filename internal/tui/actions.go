@@ -88,6 +88,11 @@ const (
 	// pendingAction's kind field - nothing reads it (no actionDoneMsg is ever
 	// built with it).
 	actionFixHealth
+	// actionAdopt is the Installed-Mods adopt-untracked action kind (see
+	// mutations.go's adoptUntrackedPrompt/resolveAdoptNameSubmitted). The
+	// default status+refresh path covers it - loadData picks up the new
+	// local mod on the next render.
+	actionAdopt
 )
 
 // pendingAction is a caller-built (Task 7) description of one mutation
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/DonovanMods/linux-mod-manager/internal/domain"
 	"github.com/DonovanMods/linux-mod-manager/internal/tui/prototype"
@@ -145,6 +147,15 @@ type ActionProvider interface {
 	// like DeleteProfile's own coreProvider/prototypeProvider methods do.
 	// progress may be nil, like every other streaming ActionProvider method.
 	Rollback(ctx context.Context, item ModItem, progress func(ActionProgress)) (ActionOutcome, error)
+	// VersionHistory lists every version of item ever deployed in the active
+	// profile, most recently deployed first - the rollback-to picker's data
+	// source ('R' on Installed Mods, see mutations.go's
+	// rollbackToSelectedMod). A local DB read, no network.
+	VersionHistory(ctx context.Context, item ModItem) ([]ModVersionView, error)
+	// RollbackTo is Rollback to a specific version from VersionHistory,
+	// downloading it again when the cache no longer holds it (network, hence
+	// progress).
+	RollbackTo(ctx context.Context, item ModItem, version string, progress func(ActionProgress)) (ActionOutcome, error)
 
 	// PlanImport parses data (an exported profile - Phase 6b Task 9's 'I'
 	// binding on Profiles, see mutations.go's importProfilePrompt) and
@@ -182,6 +193,22 @@ type ActionProvider interface {
 	// behind the Health screen's confirmation ('F', always full). progress
 	// receives one line per VerifyEvProgress / RepairDetail / Finding event.
 	RunHealthCheck(ctx context.Context, full, fix bool, progress func(ActionProgress)) (HealthView, error)
+
+	// ScanUntracked lists the top-level entries in the game's mod directory
+	// holding files no installed mod owns (core.Importer.ScanModPath,
+	// filtered through core.Service.AdoptableFiles) - the 'A' picker's
+	// candidates on Installed Mods. A local directory read, no network.
+	ScanUntracked(ctx context.Context) ([]UntrackedItem, error)
+	// Adopt brings item's files under management as a new local mod named
+	// name - the TUI equivalent of `lmm adopt <path> --name <name>`.
+	Adopt(ctx context.Context, item UntrackedItem, name string) (ActionOutcome, error)
+}
+
+// UntrackedItem is one ScanUntracked candidate: a file or directory in the
+// game's mod directory that no installed mod owns.
+type UntrackedItem struct {
+	Path string // relative to the mod directory
+	Name string // suggested mod name, parsed from Path's file name
 }
 
 // ActionOutcome is what the TUI status line renders after a successful
@@ -234,6 +261,15 @@ type ImportPlanView struct {
 	Exists                            bool // a profile with this name is already saved for the game
 }
 
+// ModVersionView is one entry in a mod's version history, mapped from
+// core.ModVersion (see coreProvider.VersionHistory). DeployedAt is zero when
+// the deploy time is unknown (versions recorded before the history existed).
+type ModVersionView struct {
+	Version                   string
+	DeployedAt                time.Time
+	Current, Previous, Cached bool
+}
+
 // SwitchPlanView is the render model for the profile-switch confirmation
 // modal, mapped from core.SwitchPlan (see coreProvider's switchPlanView) or
 // computed directly from prototype demo data.
@@ -1005,6 +1041,42 @@ func (p *prototypeProvider) Rollback(_ context.Context, item ModItem, progress f
 	return ActionOutcome{Message: fmt.Sprintf("Rolled back %q to %s", mod.Name, toVersion)}, nil
 }
 
+// VersionHistory derives the canned InstalledMods entry's history from its
+// Version and PreviousVersion alone - the demo has no deploy times, and
+// everything in it counts as cached.
+func (p *prototypeProvider) VersionHistory(_ context.Context, item ModItem) ([]ModVersionView, error) {
+	idx := p.findInstalledIndex(item.Source, item.ID)
+	if idx < 0 {
+		return nil, fmt.Errorf("mod not found: %s", item.ID)
+	}
+	mod := p.activeMods()[idx]
+	history := []ModVersionView{{Version: mod.Version, Current: true, Cached: true}}
+	if mod.PreviousVersion != "" {
+		history = append(history, ModVersionView{Version: mod.PreviousVersion, Previous: true, Cached: true})
+	}
+	return history, nil
+}
+
+// RollbackTo is Rollback to version: the canned entry's Version moves to
+// version and the version rolled back from becomes its PreviousVersion,
+// mirroring core.ApplyRollback's TargetVersion contract.
+func (p *prototypeProvider) RollbackTo(_ context.Context, item ModItem, version string, progress func(ActionProgress)) (ActionOutcome, error) {
+	idx := p.findInstalledIndex(item.Source, item.ID)
+	if idx < 0 {
+		return ActionOutcome{}, fmt.Errorf("mod not found: %s", item.ID)
+	}
+	mods := p.activeMods()
+	mod := &mods[idx]
+	if version == mod.Version {
+		return ActionOutcome{}, fmt.Errorf("%s is already at version %s", mod.Name, version)
+	}
+
+	fakeProgressTicks(progress, fmt.Sprintf("Rolling back %s", mod.Name))
+
+	mod.Version, mod.PreviousVersion = version, mod.Version
+	return ActionOutcome{Message: fmt.Sprintf("Rolled back %q to %s", mod.Name, version)}, nil
+}
+
 // PlanImport returns a canned plan (Task 9's --prototype demo): a single
 // profile named "imported", targeting the session's CURRENTLY ACTIVE game
 // (p.activeGame - see its own doc comment) so the same-game "switch to it
@@ -1081,3 +1153,38 @@ func (p *prototypeProvider) RunHealthCheck(_ context.Context, full, fix bool, pr
 		Checked:  prototypeHealthChecked,
 	}, nil
 }
+
+// ScanUntracked returns a copy of the canned Untracked set for the primary
+// game; the alt game has none, mirroring Conflicts' own primary-only demo
+// data.
+func (p *prototypeProvider) ScanUntracked(_ context.Context) ([]UntrackedItem, error) {
+	if p.altActive {
+		return nil, nil
+	}
+	items := make([]UntrackedItem, len(p.data.Untracked))
+	for i, u := range p.data.Untracked {
+		items[i] = UntrackedItem{Path: u.Path, Name: u.Name}
+	}
+	return items, nil
+}
+
+// Adopt moves the canned Untracked entry at item.Path into InstalledMods as
+// a "local" mod named name, visible in a repeated Overview and
+// ScanUntracked call - mirroring CreateProfile's own in-memory mutation.
+func (p *prototypeProvider) Adopt(_ context.Context, item UntrackedItem, name string) (ActionOutcome, error) {
+	for i, u := range p.data.Untracked {
+		if u.Path != item.Path || p.altActive {
+			continue
+		}
+		p.data.Untracked = append(p.data.Untracked[:i], p.data.Untracked[i+1:]...)
+		p.data.InstalledMods = append(p.data.InstalledMods, prototype.Mod{
+			ID:      strings.ToLower(strings.ReplaceAll(name, " ", "-")),
+			Name:    name,
+			Source:  "local",
+			Version: "unknown",
+			Status:  "installed",
+		})
+		return ActionOutcome{Message: fmt.Sprintf("Adopted %q", name)}, nil
+	}
+	return ActionOutcome{}, fmt.Errorf("nothing to adopt at %s", item.Path)
+}
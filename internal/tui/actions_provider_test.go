@@ -821,6 +821,10 @@ type recordingActions struct {
 	// rollback wiring tests assert against this, mirroring EnableCalls/
 	// DisableCalls/UninstallCalls' own single-ModItem-argument shape above.
 	RollbackCalls []ModItem
+	// VersionHistoryCalls/RollbackToCalls record the rollback-to picker's
+	// calls, mirroring AvailableVersionsCalls/SetLockCalls.
+	VersionHistoryCalls []ModItem
+	RollbackToCalls     []struct{ ModID, Version string }
 
 	// PlanImportCalls/ApplyImportCalls record each call's data argument -
 	// Task 9's import wiring tests assert against these, mirroring
@@ -841,6 +845,12 @@ type recordingActions struct {
 	// (two arguments matter here too).
 	RunHealthCheckCalls []struct{ Full, Fix bool }
 
+	// ScanUntrackedCalls counts each ScanUntracked call; AdoptCalls records
+	// each Adopt call's {path, name} arguments - the adopt wiring tests
+	// assert against these.
+	ScanUntrackedCalls int
+	AdoptCalls         []struct{ Path, Name string }
+
 	EnableOutcome, DisableOutcome, UninstallOutcome, DeployOutcome, ApplyOutcome ActionOutcome
 	ApplyInstallOutcome, ApplyUpdateOutcome                                      ActionOutcome
 	SetPolicyOutcome                                                             ActionOutcome
@@ -857,9 +867,14 @@ type recordingActions struct {
 	ApplyImportOutcome                                                           ActionOutcome
 	ExportOutcome                                                                ActionOutcome
 	AvailableVersionsOut                                                         []string
+	VersionHistoryOut                                                            []ModVersionView
+	RollbackToOutcome                                                            ActionOutcome
 	// RunHealthCheckOutcome is what RunHealthCheck returns for every call -
 	// #224 Task 8's Health-screen wiring tests assert against this.
 	RunHealthCheckOutcome HealthView
+	// ScanUntrackedOut is what ScanUntracked returns for every call.
+	ScanUntrackedOut []UntrackedItem
+	AdoptOutcome     ActionOutcome
 
 	// ApplySwitchTicks/ApplyInstallTicks/ApplyUpdateTicks/PurgeTicks, if
 	// set, are replayed through the matching method's progress callback (in
@@ -887,11 +902,13 @@ type recordingActions struct {
 	SetGameErr                                                        error
 	ReorderErr                                                        error
 	RollbackErr                                                       error
+	VersionHistoryErr, RollbackToErr                                  error
 	PlanImportErr, ApplyImportErr                                     error
 	ExportErr                                                         error
 	// RunHealthCheckErr is returned by every RunHealthCheck call - #224 Task
 	// 8's error-path wiring tests assert against this.
-	RunHealthCheckErr error
+	RunHealthCheckErr          error
+	ScanUntrackedErr, AdoptErr error
 
 	// ApplyUpdateErrByID, if set, overrides ApplyUpdateOutcome/ApplyUpdateErr
 	// for a specific UpdateItem.ID - lets a Task 5 test simulate a
@@ -1043,6 +1060,18 @@ func (r *recordingActions) Rollback(_ context.Context, item ModItem, progress fu
 	return r.RollbackOutcome, r.RollbackErr
 }
 
+// VersionHistory implements ActionProvider.
+func (r *recordingActions) VersionHistory(_ context.Context, item ModItem) ([]ModVersionView, error) {
+	r.VersionHistoryCalls = append(r.VersionHistoryCalls, item)
+	return r.VersionHistoryOut, r.VersionHistoryErr
+}
+
+// RollbackTo implements ActionProvider.
+func (r *recordingActions) RollbackTo(_ context.Context, item ModItem, version string, _ func(ActionProgress)) (ActionOutcome, error) {
+	r.RollbackToCalls = append(r.RollbackToCalls, struct{ ModID, Version string }{item.ID, version})
+	return r.RollbackToOutcome, r.RollbackToErr
+}
+
 // PlanImport implements ActionProvider (Task 9).
 func (r *recordingActions) PlanImport(_ context.Context, data []byte) (ImportPlanView, error) {
 	r.PlanImportCalls = append(r.PlanImportCalls, data)
@@ -1077,6 +1106,16 @@ func (r *recordingActions) RunHealthCheck(_ context.Context, full, fix bool, pro
 	return r.RunHealthCheckOutcome, r.RunHealthCheckErr
 }
 
+func (r *recordingActions) ScanUntracked(_ context.Context) ([]UntrackedItem, error) {
+	r.ScanUntrackedCalls++
+	return r.ScanUntrackedOut, r.ScanUntrackedErr
+}
+
+func (r *recordingActions) Adopt(_ context.Context, item UntrackedItem, name string) (ActionOutcome, error) {
+	r.AdoptCalls = append(r.AdoptCalls, struct{ Path, Name string }{item.Path, name})
+	return r.AdoptOutcome, r.AdoptErr
+}
+
 // failingActions implements ActionProvider with every method returning a
 // fixed error (Err, or a generic one if Err is unset) - for Tasks 6-7 to
 // verify error-path UI (status line rendering, modal dismissal) without
@@ -1170,6 +1209,14 @@ func (f failingActions) Rollback(context.Context, ModItem, func(ActionProgress))
 	return ActionOutcome{}, f.err()
 }
 
+func (f failingActions) VersionHistory(context.Context, ModItem) ([]ModVersionView, error) {
+	return nil, f.err()
+}
+
+func (f failingActions) RollbackTo(context.Context, ModItem, string, func(ActionProgress)) (ActionOutcome, error) {
+	return ActionOutcome{}, f.err()
+}
+
 func (f failingActions) PlanImport(context.Context, []byte) (ImportPlanView, error) {
 	return ImportPlanView{}, f.err()
 }
@@ -1186,6 +1233,14 @@ func (f failingActions) RunHealthCheck(context.Context, bool, bool, func(ActionP
 	return HealthView{}, f.err()
 }
 
+func (f failingActions) ScanUntracked(context.Context) ([]UntrackedItem, error) {
+	return nil, f.err()
+}
+
+func (f failingActions) Adopt(context.Context, UntrackedItem, string) (ActionOutcome, error) {
+	return ActionOutcome{}, f.err()
+}
+
 func TestRecordingActionsRecordsCallsAndReturnsConfiguredOutcomes(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+// --- Adopt untracked files ('A' on Installed Mods) ---
+
+// TestAdoptKeyWrongScreenIsNoop proves 'A' only fires on Installed Mods.
+func TestAdoptKeyWrongScreenIsNoop(t *testing.T) {
+	t.Parallel()
+
+	rec := &recordingActions{}
+	model := modelWithActions(t, rec)
+	model.screen = ScreenDashboard
+
+	updated, cmd := model.Update(keyRunes("A"))
+	model = updated.(Model)
+	require.Nil(t, cmd)
+	require.Nil(t, model.picker)
+	require.Zero(t, rec.ScanUntrackedCalls)
+}
+
+// TestAdoptScanPickNameAdopts drives the full round trip: 'A' dispatches
+// the async scan, the result opens a picker, choosing opens the name input
+// prefilled with the suggested name, and submitting adopts immediately.
+func TestAdoptScanPickNameAdopts(t *testing.T) {
+	t.Parallel()
+
+	rec := &recordingActions{
+		ScanUntrackedOut: []UntrackedItem{{Path: "RaceMenu", Name: "RaceMenu"}, {Path: "SMIM-2.08", Name: "SMIM"}},
+		AdoptOutcome:     ActionOutcome{Message: `Adopted "SMIM" (12 file(s))`},
+	}
+	model := modelWithActions(t, rec)
+	model.screen = ScreenInstalledMods
+
+	updated, cmd := model.Update(keyRunes("A"))
+	model = updated.(Model)
+	require.NotNil(t, cmd)
+	require.True(t, model.action.running)
+	require.Zero(t, rec.ScanUntrackedCalls, "the scan happens when the returned cmd runs, not synchronously")
+
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	require.Equal(t, 1, rec.ScanUntrackedCalls)
+	require.False(t, model.action.running)
+	require.NotNil(t, model.picker)
+	require.Len(t, model.picker.options, 2)
+	require.Equal(t, "SMIM-2.08", model.picker.options[1].Label)
+
+	updated, chooseCmd := model.Update(keyRunes("2"))
+	model = updated.(Model)
+	require.NotNil(t, chooseCmd)
+	updated, _ = model.Update(chooseCmd())
+	model = updated.(Model)
+	require.NotNil(t, model.inputModal)
+	require.Equal(t, "SMIM", model.inputModal.input.Value())
+
+	updated, submitCmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(Model)
+	require.Nil(t, model.inputModal)
+	require.NotNil(t, submitCmd)
+	require.Empty(t, rec.AdoptCalls, "nothing must mutate before the deferred cmd runs")
+
+	updated, actionCmd := model.Update(submitCmd())
+	model = updated.(Model)
+	require.True(t, model.action.running)
+	doneMsg := runActionCmd(t, actionCmd)
+	require.IsType(t, actionDoneMsg{}, doneMsg)
+	require.Equal(t, []struct{ Path, Name string }{{"SMIM-2.08", "SMIM"}}, rec.AdoptCalls)
+
+	updated, _ = model.Update(doneMsg)
+	model = updated.(Model)
+	require.Equal(t, `Adopted "SMIM" (12 file(s))`, model.action.status)
+}
+
+// TestAdoptScanEmptyReportsOnStatusLine proves an empty scan opens no
+// picker and says so.
+func TestAdoptScanEmptyReportsOnStatusLine(t *testing.T) {
+	t.Parallel()
+
+	model := modelWithActions(t, &recordingActions{})
+	model.screen = ScreenInstalledMods
+
+	updated, cmd := model.Update(keyRunes("A"))
+	model = updated.(Model)
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	require.Nil(t, model.picker)
+	require.Equal(t, "no untracked files in the mod directory", model.action.status)
+	require.False(t, model.action.statusIsError)
+}
+
+// TestAdoptScanFailureReportsError proves a failed scan lands on the status
+// line as an error.
+func TestAdoptScanFailureReportsError(t *testing.T) {
+	t.Parallel()
+
+	model := modelWithActions(t, &recordingActions{ScanUntrackedErr: errors.New("mod_path does not exist: /nope")})
+	model.screen = ScreenInstalledMods
+
+	updated, cmd := model.Update(keyRunes("A"))
+	model = updated.(Model)
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	require.Nil(t, model.picker)
+	require.Equal(t, "mod_path does not exist: /nope", model.action.status)
+	require.True(t, model.action.statusIsError)
+}
+
+// TestPrototypeProviderActions_AdoptMovesUntrackedIntoInstalled covers the
+// --prototype demo: adopting removes the canned entry and adds a local mod.
+func TestPrototypeProviderActions_AdoptMovesUntrackedIntoInstalled(t *testing.T) {
+	t.Parallel()
+
+	provider := NewPrototypeProvider()
+	actions := provider.(ActionProvider)
+	items, err := actions.ScanUntracked(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, items)
+
+	_, err = actions.Adopt(context.Background(), items[0], "My Mod")
+	require.NoError(t, err)
+
+	after, err := actions.ScanUntracked(context.Background())
+	require.NoError(t, err)
+	require.Len(t, after, len(items)-1)
+
+	_, mods, err := provider.Overview(context.Background())
+	require.NoError(t, err)
+	var found bool
+	for _, m := range mods {
+		if m.Name == "My Mod" && m.Source == "local" {
+			found = true
+		}
+	}
+	require.True(t, found, "the adopted entry must show up as an installed local mod")
+}
@@ -796,11 +796,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		return m.resolveVersionsFetched(msg)
+	case versionHistoryFetchedMsg:
+		if msg.gen != m.action.gen {
+			return m, nil
+		}
+		return m.resolveVersionHistoryFetched(msg)
+	case rollbackVersionChosenMsg:
+		return m.resolveRollbackVersionChosen(msg)
 	case versionsFetchFailedMsg:
 		if msg.gen != m.action.gen {
 			return m, nil
 		}
 		return m.resolveVersionsFetchFailed(msg)
+	case untrackedScannedMsg:
+		if msg.gen != m.action.gen {
+			return m, nil
+		}
+		return m.resolveUntrackedScanned(msg)
+	case untrackedScanFailedMsg:
+		if msg.gen != m.action.gen {
+			return m, nil
+		}
+		return m.resolveUntrackedScanFailed(msg)
+	case adoptChosenMsg:
+		return m.resolveAdoptChosen(msg)
+	case adoptNameSubmittedMsg:
+		return m.resolveAdoptNameSubmitted(msg)
 	case lockChosenMsg:
 		return m.resolveLockChosen(msg)
 	case unlockChosenMsg:
@@ -1141,6 +1162,8 @@ func (m Model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.editSelectedModLock()
 	case key.Matches(msg, m.keys.ConvertToggle):
 		return m.toggleSelectedModConvert()
+	case key.Matches(msg, m.keys.Adopt):
+		return m.adoptUntrackedPrompt()
 	// FullCheck and CreateProfile deliberately share the physical key "c"
 	// (#224 Task 11 - see keys.go's FullCheck doc comment): FullCheck's own
 	// case condition carries its screen/context guard INLINE, unlike every
@@ -1180,6 +1203,8 @@ func (m Model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.importProfilePrompt()
 	case key.Matches(msg, m.keys.ExportProfile):
 		return m.exportProfilePrompt()
+	case key.Matches(msg, m.keys.DiffProfile):
+		return m.showProfileDiff()
 	case key.Matches(msg, m.keys.ToggleAllSources):
 		return m.toggleSourcesAll()
 	case key.Matches(msg, m.keys.Purge):
@@ -1192,6 +1217,8 @@ func (m Model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.moveSelectedMod(-1)
 	case key.Matches(msg, m.keys.Rollback):
 		return m.rollbackSelectedMod()
+	case key.Matches(msg, m.keys.RollbackTo):
+		return m.rollbackToSelectedMod()
 	case key.Matches(msg, m.keys.Changelog):
 		// Fix-wave-2 smoke finding #1: 'v' on Installed Mods, OUTSIDE any
 		// modal - the modal-scoped 'v' (updatePendingActionKey, actions.go)
@@ -2707,7 +2734,9 @@ func healthRemedy(f HealthFinding) string {
 		return fmt.Sprintf("deploying raw; fix the mod or run 'lmm mod convert %s off' to silence", f.ModID)
 	case "stale_deployment":
 		return "run a fix (F) to remove"
-	case "fixed_stale_deployment", "fixed_needs_reingest":
+	case "ini_drift":
+		return "a patched ini key was changed on disk — run a fix (F) to re-apply the profile's ini_patches"
+	case "fixed_stale_deployment", "fixed_needs_reingest", "fixed_ini_drift":
 		return "resolved"
 	case "file_count_mismatch":
 		return "expected content from a download but the cache has 0 files"
@@ -2850,6 +2879,9 @@ func (m Model) helpGroups() []helpGroup {
 			// mutations.go's toggleSelectedModConvert) - listed beside Policy/
 			// Lock since it's a third item-scoped, no-confirm-modal mutation.
 			helpEntry(m.keys.ConvertToggle),
+			// Adopt is the scan-pick-name flow for hand-copied files (see
+			// mutations.go's adoptUntrackedPrompt).
+			helpEntry(m.keys.Adopt),
 			helpEntry(m.keys.Purge),
 			// MoveDown/MoveUp are Task 4's load-order reorder keys (see
 			// mutations.go's moveSelectedMod).
@@ -2858,6 +2890,9 @@ func (m Model) helpGroups() []helpGroup {
 			// Rollback is Task 6's rollback-behind-confirmation key (see
 			// mutations.go's rollbackSelectedMod).
 			helpEntry(m.keys.Rollback),
+			// RollbackTo opens the version-history picker ahead of the same
+			// confirmation (see mutations.go's rollbackToSelectedMod).
+			helpEntry(m.keys.RollbackTo),
 			// Changelog is fix-wave-2's list-scoped changelog-viewer key (see
 			// mutations.go's viewSelectedModChangelog) - distinct from the
 			// modal-scoped 'v' (updatePendingActionKey/
@@ -2920,6 +2955,9 @@ func (m Model) helpGroups() []helpGroup {
 			// ExportProfile is Task 10's export binding (see mutations.go's
 			// exportProfilePrompt).
 			helpEntry(m.keys.ExportProfile),
+			// DiffProfile opens the read-only diff overlay (see
+			// mutations.go's showProfileDiff).
+			helpEntry(m.keys.DiffProfile),
 		},
 	}
 
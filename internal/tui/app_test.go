@@ -1084,6 +1084,7 @@ func (stubProvider) Search(context.Context, string, string, int, int) (SearchPag
 	return SearchPage{}, nil
 }
 func (stubProvider) DeployedFiles(string, string) ([]string, error)    { return nil, nil }
+func (stubProvider) ProfileDiff(string) ([]string, error)              { return nil, nil }
 func (stubProvider) ListGames() ([]GameInfo, error)                    { return nil, nil }
 func (stubProvider) Conflicts(context.Context) ([]ConflictItem, error) { return nil, nil }
 func (stubProvider) Health(context.Context) (HealthView, error)        { return HealthView{}, nil }
@@ -1294,6 +1295,12 @@ func (r *recordingProvider) DeployedFiles(sourceID, modID string) ([]string, err
 	return r.DeployedFilesResult, r.DeployedFilesErr
 }
 
+// ProfileDiff delegates: no test needs a canned diff beyond what the
+// prototype provider already returns.
+func (r *recordingProvider) ProfileDiff(name string) ([]string, error) {
+	return r.delegate.ProfileDiff(name)
+}
+
 func (r *recordingProvider) Conflicts(context.Context) ([]ConflictItem, error) {
 	return r.ConflictsResult, r.ConflictsErr
 }
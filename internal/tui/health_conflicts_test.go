@@ -30,6 +30,7 @@ func (f conflictsFakeProvider) Search(context.Context, string, string, int, int)
 	return SearchPage{}, nil
 }
 func (f conflictsFakeProvider) DeployedFiles(string, string) ([]string, error) { return nil, nil }
+func (f conflictsFakeProvider) ProfileDiff(string) ([]string, error)           { return nil, nil }
 func (f conflictsFakeProvider) ListGames() ([]GameInfo, error)                 { return nil, nil }
 func (f conflictsFakeProvider) Conflicts(context.Context) ([]ConflictItem, error) {
 	return f.conflicts, nil
@@ -78,6 +79,7 @@ func (longConflictsProvider) Search(context.Context, string, string, int, int) (
 	return SearchPage{}, nil
 }
 func (longConflictsProvider) DeployedFiles(string, string) ([]string, error) { return nil, nil }
+func (longConflictsProvider) ProfileDiff(string) ([]string, error)           { return nil, nil }
 func (longConflictsProvider) ListGames() ([]GameInfo, error)                 { return nil, nil }
 func (longConflictsProvider) Conflicts(context.Context) ([]ConflictItem, error) {
 	// Generate 20 conflicts to overflow the 80x12 budget (12 lines total,
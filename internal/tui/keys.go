@@ -98,6 +98,11 @@ type KeyMap struct {
 	// the status line instead (no modal). "<" reads as "go back a version",
 	// distinct from every other single-letter/shift-letter binding above.
 	Rollback key.Binding
+	// RollbackTo is Rollback with a choice of target (see mutations.go's
+	// rollbackToSelectedMod): a picker over every version the mod ever had
+	// deployed in the profile, then the same confirmation modal - the TUI
+	// equivalent of `lmm update rollback <mod-id> --to <version>`.
+	RollbackTo key.Binding
 	// Changelog is Task 7's changelog-viewer binding (see actions.go's
 	// updatePendingActionKey/openChangelogFromUpdateModal): fires ONLY while
 	// the apply-updates confirmation modal is pending (m.pendingUpdates !=
@@ -123,6 +128,13 @@ type KeyMap struct {
 	// distinguishes a Profiles/whole-profile action" convention, distinct from
 	// any lowercase binding.
 	ExportProfile key.Binding
+	// DiffProfile is the Profiles-screen diff binding (see mutations.go's
+	// showProfileDiff): fires on ScreenProfiles with a profile row selected,
+	// opening the read-only overlay with what changes going from the active
+	// profile to the selected one - or, on the active row itself, what
+	// differs from the deployed game. The TUI equivalent of `lmm profile
+	// diff`. "=" reads as "compare", and no other binding claims it.
+	DiffProfile key.Binding
 	// ToggleAllSources is Task 4's Sources-screen scope toggle (#75, see
 	// mutations.go's toggleSourcesAll): fires ONLY on ScreenSources,
 	// flipping between the game-scoped default list and the full registry
@@ -166,6 +178,12 @@ type KeyMap struct {
 	// screen/pushed-context checks live inside fixHealthPrompt itself, like
 	// every other non-colliding binding's handler).
 	FixHealth key.Binding
+	// Adopt is the Installed-Mods adopt-untracked binding (see mutations.go's
+	// adoptUntrackedPrompt): scans the mod directory for hand-copied files no
+	// mod owns, opens a picker of them, then a name input prefilled from the
+	// picked entry - submitting adopts it (the TUI equivalent of `lmm
+	// adopt`). Capital "A" - lowercase "a" is ToggleAllSources' key.
+	Adopt key.Binding
 }
 
 // DefaultKeyMap returns the shared key bindings shown in help and used by tests.
@@ -311,6 +329,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("<"),
 			key.WithHelp("<", "rollback"),
 		),
+		RollbackTo: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "rollback to version"),
+		),
 		Changelog: key.NewBinding(
 			key.WithKeys("v"),
 			key.WithHelp("v", "changelog"),
@@ -323,6 +345,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("E"),
 			key.WithHelp("E", "export profile"),
 		),
+		DiffProfile: key.NewBinding(
+			key.WithKeys("="),
+			key.WithHelp("=", "diff profile"),
+		),
 		ToggleAllSources: key.NewBinding(
 			key.WithKeys("a"),
 			key.WithHelp("a", "toggle all sources"),
@@ -343,5 +369,9 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("F"),
 			key.WithHelp("F", "fix findings"),
 		),
+		Adopt: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "adopt untracked files"),
+		),
 	}
 }
@@ -188,6 +188,44 @@ func (m Model) showDeployedFiles() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// --- Profile diff ('=' on Profiles) ---
+
+// showProfileDiff handles '=' on Profiles: a no-op on the wrong screen, an
+// out-of-range selection, or with no DataProvider configured - mirroring
+// exportProfilePrompt's row-selection guard. Opens the same read-only
+// overlay showDeployedFiles does, listing what changes going from the
+// active profile to the selected one; on the active row itself, what
+// differs between the profile and the deployed game instead (see
+// DataProvider.ProfileDiff). Made SYNCHRONOUSLY under showDeployedFiles'
+// documented exception: both sides are local config/DB/disk reads.
+func (m Model) showProfileDiff() (tea.Model, tea.Cmd) {
+	if m.screen != ScreenProfiles || m.provider == nil {
+		return m, nil
+	}
+	idx := m.selected[ScreenProfiles]
+	if idx < 0 || idx >= len(m.profiles) {
+		return m, nil
+	}
+	profile := m.profiles[idx]
+
+	lines, err := m.provider.ProfileDiff(profile.Name)
+	if err != nil {
+		m.action.status = singleLine(err.Error())
+		m.action.statusIsError = true
+		return m, nil
+	}
+
+	title := fmt.Sprintf("Diff — active → %s", profile.Name)
+	if profile.Active {
+		title = fmt.Sprintf("Diff — %s → deployed", profile.Name)
+	}
+	if len(lines) == 0 {
+		lines = []string{"no differences"}
+	}
+	m = m.promptOverlay(infoOverlay{title: title, lines: lines})
+	return m, nil
+}
+
 // --- Rollback ('<' on Installed Mods) ---
 
 // rollbackSelectedMod handles '<' on Installed Mods (Task 6): a no-op on the
@@ -242,6 +280,159 @@ func (m Model) rollbackSelectedMod() (Model, tea.Cmd) {
 	return model.promptAction(pa), nil
 }
 
+// --- Rollback to a version ('R' on Installed Mods) ---
+
+// rollbackToModalTitle formats the title for the rollback-to version
+// picker, mirroring lockModalTitle's own shape.
+func rollbackToModalTitle(modName string) string {
+	return fmt.Sprintf("Roll back to — %s", modName)
+}
+
+// versionHistoryFetchedMsg carries a successful
+// ActionProvider.VersionHistory result, tagged with the generation
+// established when the fetch was dispatched (see rollbackToSelectedMod) -
+// mirrors versionsFetchedMsg's own gen-guard shape. A failed fetch reuses
+// versionsFetchFailedMsg: its resolver only renders the error.
+type versionHistoryFetchedMsg struct {
+	gen     int
+	item    ModItem
+	history []ModVersionView
+}
+
+// rollbackVersionChosenMsg carries the version picked in the rollback-to
+// picker - mirrors lockChosenMsg's role (see policyChosenMsg's doc comment
+// for why the pick travels through Update()).
+type rollbackVersionChosenMsg struct {
+	item    ModItem
+	version ModVersionView
+}
+
+// rollbackToSelectedMod handles 'R' on Installed Mods: '<' with a choice of
+// target - any version the mod ever had deployed in the profile, not only
+// PreviousVersion. Guards mirror editSelectedModLock (wrong screen, no
+// ActionProvider, single-flight, empty list), plus rollbackSelectedMod's
+// synchronous locked refusal. The history fetch follows editSelectedModLock's
+// async gen-tagged pattern even though it is a local read: it checks the
+// cache once per version.
+func (m Model) rollbackToSelectedMod() (Model, tea.Cmd) {
+	if m.screen != ScreenInstalledMods || m.actions == nil {
+		return m, nil
+	}
+	if m.action.running || m.action.pending != nil {
+		return m, nil
+	}
+	item, ok := m.selectedMod()
+	if !ok {
+		return m, nil
+	}
+	if item.Locked {
+		m.action.status = fmt.Sprintf("%s is locked at v%s — unlock or move the lock (L) to roll back", item.Name, item.LockedVersion)
+		m.action.statusIsError = true
+		return m, nil
+	}
+
+	if m.action.cancel != nil {
+		m.action.cancel()
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.action.cancel = cancel
+	m.action.gen++
+	gen := m.action.gen
+	m.action.running = true
+	m.action.status = fmt.Sprintf("Reading version history for %s…", item.Name)
+	m.action.statusIsError = false
+
+	return m, func() tea.Msg {
+		history, err := m.actions.VersionHistory(ctx, item)
+		if err != nil {
+			return versionsFetchFailedMsg{gen: gen, err: err}
+		}
+		return versionHistoryFetchedMsg{gen: gen, item: item, history: history}
+	}
+}
+
+// rollbackToPickerOptions builds one pickerOption per non-current entry in
+// history, noting the plain-rollback target ("previous"), when it was last
+// deployed, and a version the cache no longer holds ("not cached"). The
+// returned slice holds the matching entries in the same order.
+func rollbackToPickerOptions(history []ModVersionView) ([]pickerOption, []ModVersionView) {
+	var options []pickerOption
+	var targets []ModVersionView
+	for _, v := range history {
+		if v.Current {
+			continue
+		}
+		var notes []string
+		if v.Previous {
+			notes = append(notes, "previous")
+		}
+		if !v.DeployedAt.IsZero() {
+			notes = append(notes, v.DeployedAt.Local().Format("2006-01-02"))
+		}
+		if !v.Cached {
+			notes = append(notes, "not cached")
+		}
+		options = append(options, pickerOption{Label: "v" + v.Version, Note: strings.Join(notes, ", ")})
+		targets = append(targets, v)
+	}
+	return options, targets
+}
+
+// resolveVersionHistoryFetched handles a fresh versionHistoryFetchedMsg:
+// a benign status line when the mod has no earlier version, else the
+// rollback-to picker, whose choose dispatches rollbackVersionChosenMsg.
+func (m Model) resolveVersionHistoryFetched(msg versionHistoryFetchedMsg) (Model, tea.Cmd) {
+	m.action.running = false
+	if m.action.cancel != nil {
+		m.action.cancel()
+		m.action.cancel = nil
+	}
+	if m.action.draining {
+		return m.resolveDrainedQuit()
+	}
+
+	item := msg.item
+	options, targets := rollbackToPickerOptions(msg.history)
+	if len(options) == 0 {
+		m.action.status = "no earlier version to roll back to"
+		m.action.statusIsError = false
+		return m, nil
+	}
+
+	picker := pendingPicker{
+		title:   rollbackToModalTitle(item.Name),
+		options: options,
+		choose: func(idx int) tea.Cmd {
+			version := targets[idx]
+			return func() tea.Msg { return rollbackVersionChosenMsg{item: item, version: version} }
+		},
+	}
+	return m.promptPicker(picker), nil
+}
+
+// resolveRollbackVersionChosen handles a rollbackVersionChosenMsg: unlike
+// the lock picker, the pick is NOT the confirmation - a rollback redeploys
+// files and runs hooks, so it opens the same y/n modal '<' does, noting a
+// download when the version is no longer cached. Single-flight drop guard
+// as in resolveLockChosen.
+func (m Model) resolveRollbackVersionChosen(msg rollbackVersionChosenMsg) (Model, tea.Cmd) {
+	if m.action.running || m.action.pending != nil {
+		m.setIdleStatus("busy — choice ignored", false)
+		return m, nil
+	}
+	item := msg.item
+	version := msg.version.Version
+	title := fmt.Sprintf("Roll back %q v%s → v%s?", item.Name, item.Version, version)
+	effect := "Replaces deployed files with that version; rollback hooks will run."
+	if !msg.version.Cached {
+		effect = "Downloads that version again and replaces deployed files with it; rollback hooks will run."
+	}
+	model, pa := m.buildAction(actionRollback, title, m.gameProfileDetail(effect), "", func(ctx context.Context, progress func(ActionProgress)) (ActionOutcome, error) {
+		return m.actions.RollbackTo(ctx, item, version, progress)
+	})
+	return model.promptAction(pa), nil
+}
+
 // --- List-scoped changelog viewing ('v' on Installed Mods, no modal) ---
 
 // viewSelectedModChangelog handles 'v' on Installed Mods OUTSIDE any modal
@@ -1902,8 +2093,9 @@ func (m Model) resolveFullHealthCheckFailure(msg fullHealthCheckFailedMsg) (Mode
 // core/verify.go's real repair coverage.
 //
 // Also excludes any fixed_* status (fixed_stale_deployment,
-// fixed_needs_reingest today - same prefix check healthStatusClass uses,
-// app.go, to bucket a resolved row into its "ok" tint): a row already
+// fixed_needs_reingest, fixed_ini_drift today - same prefix check
+// healthStatusClass uses, app.go, to bucket a resolved row into its "ok"
+// tint): a row already
 // repaired by a prior fix run is exactly as unactionable as "ok" itself, and
 // without this a view containing only fixed_*/ok rows after a successful
 // fix would still let 'F' open an empty "Fix N finding(s)?" modal (Copilot
@@ -1957,6 +2149,8 @@ func healthFixCategoryLine(status string, n int) string {
 			word = "deployments"
 		}
 		return fmt.Sprintf("%d stale %s — remove", n, word)
+	case "ini_drift":
+		return fmt.Sprintf("%d drifted ini key(s) — re-apply patches", n)
 	case "version_mismatch":
 		word := "mismatch"
 		if n != 1 {
@@ -1998,8 +2192,8 @@ func healthFixDetailLines(findings []HealthFinding) []string {
 // healthFixResultLine renders one finding as a fix-results overlay row: a
 // "✓" line for a fixed_* row (Status itself already says what was fixed -
 // core/verify.go only ever produces "fixed_stale_deployment"/
-// "fixed_needs_reingest"), a "✗" line for anything still outstanding
-// (Note appended after an em dash when present, e.g. a locked mod's
+// "fixed_needs_reingest"/"fixed_ini_drift"), a "✗" line for anything still
+// outstanding (Note appended after an em dash when present, e.g. a locked mod's
 // version_mismatch/"locked" refusal).
 func healthFixResultLine(f HealthFinding) string {
 	glyph := "✗"
@@ -3105,3 +3299,160 @@ func (m Model) toggleSourcesAll() (Model, tea.Cmd) {
 	m.selected[ScreenSources] = 0
 	return m, nil
 }
+
+// --- Adopt untracked files ('A' on Installed Mods) ---
+
+// untrackedScannedMsg carries a successful ActionProvider.ScanUntracked
+// result, tagged with the generation established when the scan was
+// dispatched (see adoptUntrackedPrompt) - mirrors versionsFetchedMsg's own
+// gen-guard shape.
+type untrackedScannedMsg struct {
+	gen   int
+	items []UntrackedItem
+}
+
+// untrackedScanFailedMsg carries a failed ScanUntracked call, tagged like
+// untrackedScannedMsg.
+type untrackedScanFailedMsg struct {
+	gen int
+	err error
+}
+
+// adoptChosenMsg carries the entry picked in the untracked-files picker -
+// routed through Update() for the same reason lockChosenMsg is
+// (pendingPicker.choose can only return a tea.Cmd).
+type adoptChosenMsg struct{ item UntrackedItem }
+
+// adoptNameSubmittedMsg carries the picked entry and the name typed into
+// the "adopt — mod name" input modal, routed through Update() like
+// exportPathSubmittedMsg.
+type adoptNameSubmittedMsg struct {
+	item UntrackedItem
+	name string
+}
+
+// adoptUntrackedPrompt handles 'A' on Installed Mods: a no-op on the wrong
+// screen, with no ActionProvider configured, or while another action/fetch
+// is in flight - editSelectedModLock's guard shape minus the selected-row
+// requirement (adopting acts on the mod directory, not a listed mod). The
+// scan is a directory walk plus one ownership lookup per file, so it runs
+// async like AvailableVersions rather than blocking the render loop.
+func (m Model) adoptUntrackedPrompt() (Model, tea.Cmd) {
+	if m.screen != ScreenInstalledMods || m.actions == nil {
+		return m, nil
+	}
+	if m.action.running || m.action.pending != nil {
+		return m, nil
+	}
+
+	if m.action.cancel != nil {
+		m.action.cancel()
+	}
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.action.cancel = cancel
+	m.action.gen++
+	gen := m.action.gen
+	m.action.running = true
+	m.action.status = "Scanning mod directory for untracked files…"
+	m.action.statusIsError = false
+
+	return m, func() tea.Msg {
+		items, err := m.actions.ScanUntracked(ctx)
+		if err != nil {
+			return untrackedScanFailedMsg{gen: gen, err: err}
+		}
+		return untrackedScannedMsg{gen: gen, items: items}
+	}
+}
+
+// resolveUntrackedScanned handles a fresh untrackedScannedMsg: an empty
+// result lands on the status line, otherwise a picker of the entries opens
+// whose choice dispatches adoptChosenMsg.
+func (m Model) resolveUntrackedScanned(msg untrackedScannedMsg) (Model, tea.Cmd) {
+	m.action.running = false
+	if m.action.cancel != nil {
+		m.action.cancel()
+		m.action.cancel = nil
+	}
+	if m.action.draining {
+		return m.resolveDrainedQuit()
+	}
+	if len(msg.items) == 0 {
+		m.action.status = "no untracked files in the mod directory"
+		m.action.statusIsError = false
+		return m, nil
+	}
+	m.action.status = ""
+
+	items := msg.items
+	options := make([]pickerOption, len(items))
+	for i, it := range items {
+		options[i] = pickerOption{Label: it.Path}
+	}
+	picker := pendingPicker{
+		title:   "Adopt untracked files",
+		options: options,
+		choose: func(idx int) tea.Cmd {
+			item := items[idx]
+			return func() tea.Msg { return adoptChosenMsg{item: item} }
+		},
+	}
+	return m.promptPicker(picker), nil
+}
+
+// resolveUntrackedScanFailed handles a fresh untrackedScanFailedMsg: status
+// line error, no picker, mirroring resolveVersionsFetchFailed.
+func (m Model) resolveUntrackedScanFailed(msg untrackedScanFailedMsg) (Model, tea.Cmd) {
+	m.action.running = false
+	if m.action.cancel != nil {
+		m.action.cancel()
+		m.action.cancel = nil
+	}
+	if m.action.draining {
+		return m.resolveDrainedQuit()
+	}
+	m.action.status = singleLine(msg.err.Error())
+	m.action.statusIsError = true
+	return m, nil
+}
+
+// resolveAdoptChosen opens the "adopt — mod name" input modal for the
+// picked entry, prefilled with its suggested name so the common case is
+// just enter - exportProfilePrompt's prefilled-input shape.
+func (m Model) resolveAdoptChosen(msg adoptChosenMsg) (Model, tea.Cmd) {
+	if m.action.running || m.action.pending != nil {
+		m.setIdleStatus("busy — choice ignored", false)
+		return m, nil
+	}
+	item := msg.item
+	input := newInputModalTextInput("mod name", 128, m.availableWidth(), m.theme.Panel.GetHorizontalFrameSize())
+	input.SetValue(item.Name)
+	input.CursorEnd()
+
+	pi := pendingInput{
+		title:    fmt.Sprintf("adopt %s — mod name", item.Path),
+		input:    input,
+		hint:     "enter adopt · esc cancel",
+		validate: func(string) string { return "" },
+		submit: func(value string) tea.Cmd {
+			return func() tea.Msg { return adoptNameSubmittedMsg{item: item, name: value} }
+		},
+	}
+	return m.promptInput(pi), nil
+}
+
+// resolveAdoptNameSubmitted builds the actionAdopt action and confirms it
+// immediately - the submitted name IS the confirmation, mirroring
+// resolveLockChosen's single-flight guard and immediate confirm.
+func (m Model) resolveAdoptNameSubmitted(msg adoptNameSubmittedMsg) (Model, tea.Cmd) {
+	if m.action.running || m.action.pending != nil {
+		m.setIdleStatus("busy — choice ignored", false)
+		return m, nil
+	}
+	item, name := msg.item, msg.name
+	model, pa := m.buildAction(actionAdopt, fmt.Sprintf("Adopt %s", item.Path), nil, "", func(ctx context.Context, _ func(ActionProgress)) (ActionOutcome, error) {
+		return m.actions.Adopt(ctx, item, name)
+	})
+	model.action.running = true
+	return model, pa.confirm()
+}
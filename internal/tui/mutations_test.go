@@ -964,6 +964,7 @@ func (f *fakeSwitchableProvider) Search(context.Context, string, string, int, in
 }
 
 func (f *fakeSwitchableProvider) DeployedFiles(string, string) ([]string, error) { return nil, nil }
+func (f *fakeSwitchableProvider) ProfileDiff(string) ([]string, error)           { return nil, nil }
 func (f *fakeSwitchableProvider) ListGames() ([]GameInfo, error)                 { return nil, nil }
 func (f *fakeSwitchableProvider) Conflicts(context.Context) ([]ConflictItem, error) {
 	return nil, nil
@@ -1098,6 +1099,7 @@ func (p *searchCancelProvider) Search(ctx context.Context, _, _ string, _, _ int
 	return SearchPage{Results: []ModItem{{ID: "x", Name: "X"}}}, nil
 }
 func (p *searchCancelProvider) DeployedFiles(string, string) ([]string, error) { return nil, nil }
+func (p *searchCancelProvider) ProfileDiff(string) ([]string, error)           { return nil, nil }
 func (p *searchCancelProvider) ListGames() ([]GameInfo, error)                 { return p.listGames, nil }
 func (p *searchCancelProvider) Conflicts(context.Context) ([]ConflictItem, error) {
 	return nil, nil
@@ -60,8 +60,10 @@ func (m Model) promptOverlay(o infoOverlay) Model {
 // other key is swallowed so nothing behind the overlay can react to it.
 // Invariant this relies on: the overlay only ever opens from Installed
 // Mods' Files binding (Task 4's showDeployedFiles, mutations.go, guards on
-// m.screen == ScreenInstalledMods) or on top of the apply-updates modal
-// (Task 7's changelog viewer) - the search input only ever focuses on
+// m.screen == ScreenInstalledMods), from Profiles' diff binding
+// (showProfileDiff, guarding on m.screen == ScreenProfiles) or on top of
+// the apply-updates modal (Task 7's changelog viewer) - the search input
+// only ever focuses on
 // ScreenSearch (gotoScreenFocused) - so it can never be focused while the
 // overlay is up, meaning a plain "q" here always quits reliably, never
 // types into a field. That invariant is exactly why updatePickerKey
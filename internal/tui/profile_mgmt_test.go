@@ -385,3 +385,51 @@ func TestProfileKeysSwallowedByFocusedSearchInput(t *testing.T) {
 	require.Nil(t, updated.inputModal)
 	require.Nil(t, updated.action.pending)
 }
+
+// --- Profile diff ('=' on Profiles) ---
+
+// TestDiffProfileKeyOpensOverlay covers showProfileDiff against the
+// prototype provider: '=' on a non-active row diffs the active profile
+// against it; on the active row, against what is deployed, which the demo
+// reports as matching.
+func TestDiffProfileKeyOpensOverlay(t *testing.T) {
+	t.Parallel()
+
+	model := modelWithProvider(t, NewPrototypeProvider())
+	model.screen = ScreenProfiles
+	require.True(t, model.profiles[0].Active)
+	model.selected[ScreenProfiles] = 1
+	name := model.profiles[1].Name
+
+	updated, cmd := model.Update(keyRunes("="))
+	model = updated.(Model)
+	require.Nil(t, cmd, "the diff is a local read, made synchronously")
+	require.NotNil(t, model.overlay)
+	require.Equal(t, "Diff — active → "+name, model.overlay.title)
+	require.Equal(t, []string{"Mods:", "  42 mod(s) → 18 mod(s)"}, model.overlay.lines)
+
+	updated, _ = model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	model = updated.(Model)
+	require.Nil(t, model.overlay)
+
+	model.selected[ScreenProfiles] = 0
+	updated, _ = model.Update(keyRunes("="))
+	model = updated.(Model)
+	require.NotNil(t, model.overlay)
+	require.Equal(t, "Diff — "+model.profiles[0].Name+" → deployed", model.overlay.title)
+	require.Equal(t, []string{"no differences"}, model.overlay.lines)
+}
+
+// TestDiffProfileKeyIgnoredOffProfiles proves '=' does nothing on any other
+// screen.
+func TestDiffProfileKeyIgnoredOffProfiles(t *testing.T) {
+	t.Parallel()
+
+	model := modelWithProvider(t, NewPrototypeProvider())
+	model.screen = ScreenInstalledMods
+
+	updated, cmd := model.Update(keyRunes("="))
+	model = updated.(Model)
+	require.Nil(t, cmd)
+	require.Nil(t, model.overlay)
+}
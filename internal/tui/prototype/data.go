@@ -25,6 +25,17 @@ type Data struct {
 	// variants. The alt game has none - see prototypeProvider.Conflicts' own
 	// doc comment.
 	Conflicts []Conflict
+	// Untracked is the PRIMARY game's canned set of hand-copied mod
+	// directory entries no installed mod owns, feeding
+	// prototypeProvider.ScanUntracked/Adopt for the 'A' adopt demo. Adopt
+	// removes the entry it adopts, so the demo's picker visibly shrinks.
+	Untracked []Untracked
+}
+
+// Untracked is one canned mod-directory entry awaiting adoption.
+type Untracked struct {
+	Path string
+	Name string
 }
 
 type Game struct {
@@ -200,6 +211,10 @@ func Load() Data {
 			// In-sync: owner and winner already agree.
 			{Path: "textures/frost.dds", Owner: "USSEP", Winner: "USSEP", AlsoIn: []string{"Immersive Armors"}, Stale: false},
 		},
+		Untracked: []Untracked{
+			{Path: "RaceMenu", Name: "RaceMenu"},
+			{Path: "SMIM-2.08", Name: "SMIM"},
+		},
 		Profiles: []Profile{
 			{Name: "survival", Active: true, ModCount: 42},
 			{Name: "vanilla-plus", Active: false, ModCount: 18},
@@ -3,6 +3,7 @@ package tui
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -244,3 +245,98 @@ func TestRollbackKeyInertWhileRunning(t *testing.T) {
 	require.Nil(t, model.action.pending)
 	require.Empty(t, rec.RollbackCalls)
 }
+
+// TestRollbackToKeyPicksVersionThenConfirms covers 'R' end to end: async
+// history fetch -> picker over the non-current versions (annotated previous/
+// deploy date/not cached) -> the pick opens the ordinary rollback confirm
+// modal -> confirm calls RollbackTo with the picked version.
+func TestRollbackToKeyPicksVersionThenConfirms(t *testing.T) {
+	t.Parallel()
+
+	deployed := time.Date(2026, 3, 1, 12, 0, 0, 0, time.Local)
+	rec := &recordingActions{VersionHistoryOut: []ModVersionView{
+		{Version: "11", Current: true, Cached: true, DeployedAt: deployed},
+		{Version: "10", Previous: true, Cached: true, DeployedAt: deployed},
+		{Version: "9", Cached: false},
+	}}
+	model := modelWithActions(t, rec)
+	model.screen = ScreenInstalledMods
+	model.selected[ScreenInstalledMods] = rollbackReadyModIndex
+
+	updated, cmd := model.Update(keyRunes("R"))
+	model = updated.(Model)
+	require.NotNil(t, cmd)
+	require.True(t, model.action.running)
+	require.Equal(t, "Reading version history for SKSE Address Library…", model.action.status)
+	require.Empty(t, rec.VersionHistoryCalls, "the provider call happens when the returned cmd runs, not synchronously")
+
+	msg := cmd()
+	require.IsType(t, versionHistoryFetchedMsg{}, msg)
+	updated, _ = model.Update(msg)
+	model = updated.(Model)
+	require.NotNil(t, model.picker)
+	require.Equal(t, "Roll back to — SKSE Address Library", model.picker.title)
+	require.Equal(t, []pickerOption{
+		{Label: "v10", Note: "previous, 2026-03-01"},
+		{Label: "v9", Note: "not cached"},
+	}, model.picker.options)
+
+	updated, chooseCmd := model.Update(keyRunes("2"))
+	model = updated.(Model)
+	require.NotNil(t, chooseCmd)
+	updated, _ = model.Update(chooseCmd())
+	model = updated.(Model)
+	require.NotNil(t, model.action.pending)
+	require.Equal(t, actionRollback, model.action.pending.kind)
+	require.Equal(t, `Roll back "SKSE Address Library" v11 → v9?`, model.action.pending.title)
+	require.Contains(t, model.action.pending.detail, "Downloads that version again and replaces deployed files with it; rollback hooks will run.")
+	require.Empty(t, rec.RollbackToCalls, "nothing must mutate before confirm")
+
+	confirmed, confirmCmd := model.Update(keyRunes("y"))
+	model = confirmed.(Model)
+	require.NotNil(t, confirmCmd)
+	require.IsType(t, actionDoneMsg{}, runActionCmd(t, confirmCmd))
+	require.Equal(t, []struct{ ModID, Version string }{{"skse-address-library", "9"}}, rec.RollbackToCalls)
+	require.Empty(t, rec.RollbackCalls)
+}
+
+// TestRollbackToKeyNoEarlierVersion: a history holding only the current
+// version is a benign status line, not a picker with nothing to choose.
+func TestRollbackToKeyNoEarlierVersion(t *testing.T) {
+	t.Parallel()
+
+	rec := &recordingActions{VersionHistoryOut: []ModVersionView{{Version: "5.2", Current: true, Cached: true}}}
+	model := modelWithActions(t, rec)
+	model.screen = ScreenInstalledMods
+	model.selected[ScreenInstalledMods] = noPreviousVersionModIndex
+
+	updated, cmd := model.Update(keyRunes("R"))
+	model = updated.(Model)
+	require.NotNil(t, cmd)
+	updated, _ = model.Update(cmd())
+	model = updated.(Model)
+	require.Nil(t, model.picker)
+	require.False(t, model.action.running)
+	require.Equal(t, "no earlier version to roll back to", model.action.status)
+	require.False(t, model.action.statusIsError)
+}
+
+// TestRollbackToKeyLockedRefusesBeforeFetch mirrors
+// TestRollbackKeyLockedRefusesBeforeModal for 'R': no history fetch at all.
+func TestRollbackToKeyLockedRefusesBeforeFetch(t *testing.T) {
+	t.Parallel()
+
+	rec := &recordingActions{}
+	model := modelWithActions(t, rec)
+	model.screen = ScreenInstalledMods
+	model.selected[ScreenInstalledMods] = rollbackReadyModIndex
+	model.mods[rollbackReadyModIndex].Locked = true
+	model.mods[rollbackReadyModIndex].LockedVersion = "11"
+
+	updated, cmd := model.Update(keyRunes("R"))
+	model = updated.(Model)
+	require.Nil(t, cmd)
+	require.False(t, model.action.running)
+	require.True(t, model.action.statusIsError)
+	require.Empty(t, rec.VersionHistoryCalls)
+}
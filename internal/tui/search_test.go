@@ -532,6 +532,7 @@ func (noSourcesProvider) Search(context.Context, string, string, int, int) (Sear
 	return SearchPage{}, nil
 }
 func (noSourcesProvider) DeployedFiles(string, string) ([]string, error)    { return nil, nil }
+func (noSourcesProvider) ProfileDiff(string) ([]string, error)              { return nil, nil }
 func (noSourcesProvider) ListGames() ([]GameInfo, error)                    { return nil, nil }
 func (noSourcesProvider) Conflicts(context.Context) ([]ConflictItem, error) { return nil, nil }
 func (noSourcesProvider) Health(context.Context) (HealthView, error)        { return HealthView{}, nil }
@@ -205,7 +205,7 @@ type ModItem struct {
 type SourceInfo struct {
 	ID           string
 	Name         string
-	Type         string // "built-in", "directory", "manifest", or "api"
+	Type         string // "built-in", "directory", "manifest", "api", "exec", or "releases"
 	Auth         string // "yes", "no", or "n/a" (source has no auth capability)
 	Capabilities string // compact list, e.g. "search,updates"
 	// InUse marks a row as one of the active game's configured sources.
@@ -423,6 +423,12 @@ type DataProvider interface {
 	// overlay (Task 4). An empty slice with a nil error means the mod is
 	// known but has nothing currently deployed (e.g. disabled).
 	DeployedFiles(sourceID, modID string) ([]string, error)
+	// ProfileDiff lists display lines describing what changes going from the
+	// active profile to the named one - or, when name IS the active profile,
+	// what differs between it and what is actually deployed - for the
+	// Profiles screen's read-only diff overlay. A local config/DB/disk read,
+	// no network. An empty slice with a nil error means no differences.
+	ProfileDiff(name string) ([]string, error)
 	// ListGames lists every game configured for this session's underlying
 	// app data, sorted by Name, for the in-TUI game switcher (Task 8's 'g'
 	// binding - see mutations.go's openGameSwitcher). Exactly one entry has
@@ -650,7 +656,7 @@ var prototypeSourceInUse = map[string]bool{"nexusmods": true}
 func (p *prototypeProvider) SourceInfos(all bool) []SourceInfo {
 	full := []SourceInfo{
 		{ID: "curseforge", Name: "CurseForge", Type: "built-in", Auth: "n/a", Capabilities: "search,updates"},
-		{ID: "local-mods", Name: "Local Mods", Type: "directory", Auth: "n/a", Capabilities: "search,updates"},
+		{ID: "local-mods", Name: "Local Mods", Type: "directory", Auth: "n/a", Capabilities: "search,deps,updates"},
 		{ID: "nexusmods", Name: "Nexus Mods", Type: "built-in", Auth: "yes", Capabilities: "search,deps,updates,auth"},
 	}
 	if !all {
@@ -776,6 +782,32 @@ func (p *prototypeProvider) DeployedFiles(sourceID, modID string) ([]string, err
 	return files, nil
 }
 
+// ProfileDiff compares the canned profiles' mod counts only - the demo
+// data has no per-profile mod lists - and reports the active profile as
+// matching what is deployed. Never errors for a known profile name.
+func (p *prototypeProvider) ProfileDiff(name string) ([]string, error) {
+	var from, to *prototype.Profile
+	for i := range p.data.Profiles {
+		profile := &p.data.Profiles[i]
+		if profile.Active {
+			from = profile
+		}
+		if profile.Name == name {
+			to = profile
+		}
+	}
+	if to == nil {
+		return nil, fmt.Errorf("profile not found: %s", name)
+	}
+	if from == nil || from.Name == to.Name {
+		return nil, nil
+	}
+	return []string{
+		"Mods:",
+		fmt.Sprintf("  %d mod(s) → %d mod(s)", from.ModCount, to.ModCount),
+	}, nil
+}
+
 // Conflicts returns the PRIMARY game's canned conflict set (see
 // prototype.Data.Conflicts' doc comment); the alt game (see altActive's own
 // doc comment) has no canned conflicts at all - its minimal 1-2 mod demo set
@@ -591,6 +591,107 @@ func (p *coreProvider) DeployedFiles(sourceID, modID string) ([]string, error) {
 	return paths, nil
 }
 
+// ProfileDiff diffs the active profile against name with core.DiffProfiles,
+// or against core.DeployedProfile's view of the game directory when name is
+// the active profile - the same two comparisons `lmm profile diff` makes.
+func (p *coreProvider) ProfileDiff(name string) ([]string, error) {
+	game := p.currentGame()
+	active := p.currentProfile()
+	pm := p.svc.NewProfileManager()
+
+	from, err := pm.Get(game.ID, active)
+	if err != nil {
+		return nil, fmt.Errorf("loading profile %s: %w", active, err)
+	}
+	var to *domain.Profile
+	if name == active {
+		to, err = p.svc.DeployedProfile(game, active)
+		if err != nil {
+			return nil, fmt.Errorf("reading deployed state: %w", err)
+		}
+	} else if to, err = pm.Get(game.ID, name); err != nil {
+		return nil, fmt.Errorf("loading profile %s: %w", name, err)
+	}
+	return profileDiffLines(core.DiffProfiles(from, to)), nil
+}
+
+// profileDiffLines renders diff as the overlay's plain-text lines, grouped
+// and marked (+ added, - removed, ~ changed, ↕ moved) like the CLI's text
+// output, minus its colors.
+func profileDiffLines(diff *core.ProfileDiff) []string {
+	if diff.Empty() {
+		return nil
+	}
+	state := func(m domain.ModReference) string {
+		s := m.Version
+		if s == "" {
+			s = "(any version)"
+		}
+		if m.Locked {
+			s += " (locked)"
+		}
+		return s
+	}
+	describe := func(m domain.ModReference) string {
+		return domain.ModKey(m.SourceID, m.ModID) + " " + state(m)
+	}
+
+	var lines []string
+	if len(diff.Added) > 0 || len(diff.Removed) > 0 || len(diff.Changed) > 0 || len(diff.Moved) > 0 {
+		lines = append(lines, "Mods:")
+		for _, m := range diff.Added {
+			lines = append(lines, "  + "+describe(m))
+		}
+		for _, m := range diff.Removed {
+			lines = append(lines, "  - "+describe(m))
+		}
+		for _, c := range diff.Changed {
+			lines = append(lines, fmt.Sprintf("  ~ %s → %s", describe(c.From), state(c.To)))
+		}
+		for _, mv := range diff.Moved {
+			lines = append(lines, fmt.Sprintf("  ↕ %s #%d → #%d", domain.ModKey(mv.SourceID, mv.ModID), mv.From, mv.To))
+		}
+	}
+	if diff.LinkMethod != nil {
+		orDefault := func(m string) string {
+			if m == "" {
+				return "(game default)"
+			}
+			return m
+		}
+		lines = append(lines, fmt.Sprintf("Link method: %s → %s", orDefault(diff.LinkMethod.From), orDefault(diff.LinkMethod.To)))
+	}
+	marks := map[core.DiffKind]string{core.DiffAdded: "+", core.DiffRemoved: "-", core.DiffChanged: "~"}
+	if len(diff.Overrides) > 0 {
+		lines = append(lines, "Overrides:")
+		for _, o := range diff.Overrides {
+			lines = append(lines, fmt.Sprintf("  %s %s", marks[o.Kind], o.Path))
+		}
+	}
+	if len(diff.IniPatches) > 0 {
+		lines = append(lines, "INI patches:")
+		value := func(v *string) string {
+			if v == nil {
+				return "(deleted)"
+			}
+			return *v
+		}
+		for _, ini := range diff.IniPatches {
+			line := fmt.Sprintf("  %s %s [%s] %s", marks[ini.Kind], ini.File, ini.Section, ini.Key)
+			switch ini.Kind {
+			case core.DiffAdded:
+				line += " = " + value(ini.To)
+			case core.DiffRemoved:
+				line += " = " + value(ini.From)
+			default:
+				line += ": " + value(ini.From) + " → " + value(ini.To)
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
 // Conflicts lists every file conflict the active profile currently has
 // (Task 3), delegating directly to svc.GetProfileConflicts and mapping each
 // core.ProfileConflict to its TUI render model - Owner/Winner/AlsoIn take
@@ -1375,6 +1476,7 @@ func (p *coreProvider) ApplyInstall(ctx context.Context, item ModItem, progress
 	if slices.Contains(result.Failed, item.Name) {
 		message = fmt.Sprintf("Installed %d of %d mod(s)", len(result.Installed), len(plan.Dependencies)+1)
 	}
+	message += cacheQuotaSuffix(result.CacheQuota)
 	return ActionOutcome{
 		Message:  message,
 		Warnings: warnings,
@@ -1407,6 +1509,8 @@ func (p *coreProvider) ApplyInstall(ctx context.Context, item ModItem, progress
 // that was never established - a pinned mod may well have a newer version.
 func notCheckedMessage(name string, mod domain.InstalledMod) string {
 	switch {
+	case mod.IsDev():
+		return fmt.Sprintf("%q is a dev mod — linked from %s", name, mod.DevPath)
 	case mod.UpdatePolicy == domain.UpdatePinned:
 		return fmt.Sprintf("%q is pinned — not checked (P to change)", name)
 	case mod.SourceID == domain.SourceLocal:
@@ -1428,6 +1532,9 @@ func updateSkipWarning(skips core.UpdateSkips) string {
 	if skips.Local > 0 {
 		parts = append(parts, fmt.Sprintf("%d local mod%s (no remote source)", skips.Local, tuiPlural(skips.Local)))
 	}
+	if skips.Dev > 0 {
+		parts = append(parts, fmt.Sprintf("%d dev mod%s (linked from a working directory)", skips.Dev, tuiPlural(skips.Dev)))
+	}
 	if len(parts) == 0 {
 		return ""
 	}
@@ -1555,28 +1662,54 @@ func (p *coreProvider) ApplyUpdate(ctx context.Context, u UpdateItem, progress f
 	if err != nil {
 		return ActionOutcome{}, mapUpdateNetworkError(fmt.Sprintf("updating %s", u.Name), u.Source, err)
 	}
+	msg := fmt.Sprintf("Updated %q to %s", u.Name, upd.NewVersion)
+	// Conflicting config merges already arrive as Warnings; only the clean
+	// ones need mentioning here.
+	if kept := cleanConfigMerges(result.ConfigMerges); kept > 0 {
+		msg += fmt.Sprintf(" (kept local edits to %d config file(s))", kept)
+	}
+	msg += cacheQuotaSuffix(result.CacheQuota)
 	return ActionOutcome{
-		Message:  fmt.Sprintf("Updated %q to %s", u.Name, upd.NewVersion),
+		Message:  msg,
 		Warnings: mergeDiagnostics(result.Warnings, result.Notes),
 	}, nil
 }
 
+// cacheQuotaSuffix describes a cache_quota pass that removed anything, for
+// appending to an install or update outcome message.
+func cacheQuotaSuffix(quota *core.CacheGCResult) string {
+	if quota == nil || len(quota.Removed) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" (cache quota: removed %d old version(s), freed %s)", len(quota.Removed), installSizeLabel(quota.Freed))
+}
+
+// cleanConfigMerges counts the config merges ApplyUpdate completed without
+// leaving a local copy behind.
+func cleanConfigMerges(merges []core.ConfigMerge) int {
+	n := 0
+	for _, m := range merges {
+		if m.LocalCopy == "" {
+			n++
+		}
+	}
+	return n
+}
+
 // rollbackProgressLine composes an ActionProgress from one core.DeployProgress
-// event during ApplyRollback, mirroring updateProgressLine's shape - but
-// ApplyRollback (Phase 6b Task 5) never downloads anything (the previous
-// version's files already live in the cache - see its own doc comment), so
-// it has no percentage-based phase worth surfacing on the status line the
-// way updateProgressLine's UpdateDownloading case does. Every phase
-// ApplyRollback actually emits (UpdateBeforeEachForced/UpdateWarning/
+// event during ApplyRollback, mirroring updateProgressLine's shape: only
+// UpdateDownloading - emitted when the target version is no longer cached
+// and ApplyRollback downloads it again - is worth a status line. Every
+// other phase ApplyRollback emits (UpdateBeforeEachForced/UpdateWarning/
 // UpdateNote, reused verbatim from ApplyUpdate - see RollbackResult's own
 // doc comment) already reaches the caller through the completed
 // RollbackResult's Warnings/Notes fields (mergeDiagnostics, Rollback below) -
 // exactly mirroring how coreProvider.ApplyUpdate's own updateProgressLine
-// leaves those same three phases unmapped, for the identical reason. Kept as
-// an explicit named function (rather than a literal always-false closure) so
-// a future phase addition has an obvious, precedented place to compose a
-// line.
-func rollbackProgressLine(core.DeployProgress) (ActionProgress, bool) {
+// leaves those same three phases unmapped, for the identical reason.
+func rollbackProgressLine(p core.DeployProgress) (ActionProgress, bool) {
+	if p.Phase == core.UpdateDownloading {
+		return ActionProgress{Line: fmt.Sprintf("Downloading %s: %.0f%%", p.ModName, p.Percent), Percent: p.Percent}, true
+	}
 	return ActionProgress{}, false
 }
 
@@ -1590,13 +1723,35 @@ func rollbackProgressLine(core.DeployProgress) (ActionProgress, bool) {
 // repeats the same guard defense-in-depth, so a stale selection still fails
 // cleanly here rather than rolling back the wrong thing.
 func (p *coreProvider) Rollback(ctx context.Context, item ModItem, progress func(ActionProgress)) (ActionOutcome, error) {
+	return p.RollbackTo(ctx, item, "", progress)
+}
+
+// VersionHistory maps svc.ModVersionHistory for the active game and profile
+// onto ModVersionViews - a local DB read, plus a cache existence check per
+// version.
+func (p *coreProvider) VersionHistory(_ context.Context, item ModItem) ([]ModVersionView, error) {
+	versions, err := p.svc.ModVersionHistory(p.currentGame(), p.currentProfile(), item.Source, item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("reading version history for %s: %w", item.Name, err)
+	}
+	views := make([]ModVersionView, len(versions))
+	for i, v := range versions {
+		views[i] = ModVersionView{Version: v.Version, DeployedAt: v.DeployedAt, Current: v.Current, Previous: v.Previous, Cached: v.Cached}
+	}
+	return views, nil
+}
+
+// RollbackTo is Rollback with RollbackOptions.TargetVersion set to version
+// ("" = PreviousVersion, which is how Rollback itself calls it).
+func (p *coreProvider) RollbackTo(ctx context.Context, item ModItem, version string, progress func(ActionProgress)) (ActionOutcome, error) {
 	game := p.currentGame()
 	profile := p.currentProfile()
 	opts := core.RollbackOptions{
-		Hooks:       p.resolvedHooks(game, profile),
-		HookRunner:  p.hookRunner(),
-		HookContext: p.hookContext(game),
-		Force:       false,
+		Hooks:         p.resolvedHooks(game, profile),
+		HookRunner:    p.hookRunner(),
+		HookContext:   p.hookContext(game),
+		Force:         false,
+		TargetVersion: version,
 	}
 
 	adapter := deployProgressAdapter(progress, rollbackProgressLine)
@@ -2047,3 +2202,48 @@ func healthView(res *core.VerifyResult, full bool) HealthView {
 	}
 	return v
 }
+
+// ScanUntracked lists ScanModPath's untracked entries for the active game,
+// keeping only those AdoptableFiles still finds something to adopt in:
+// ScanModPath's tracked check matches mod names, not file ownership, so a
+// directory every file of which some mod already deployed would otherwise
+// be offered and then fail with "no untracked files".
+func (p *coreProvider) ScanUntracked(ctx context.Context) ([]UntrackedItem, error) {
+	game := p.currentGame()
+	profileName := p.currentProfile()
+	installed, err := p.svc.GetInstalledMods(game.ID, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("loading installed mods for %s/%s: %w", game.ID, profileName, err)
+	}
+	results, err := p.svc.NewImporter(game).ScanModPath(ctx, game, installed, core.ScanOptions{ProfileName: profileName, DryRun: true})
+	if err != nil {
+		return nil, fmt.Errorf("scanning mod directory: %w", err)
+	}
+	var items []UntrackedItem
+	for _, r := range results {
+		if r.AlreadyTracked || r.Mod == nil {
+			continue
+		}
+		files, _, err := p.svc.AdoptableFiles(game, profileName, []string{r.FilePath})
+		if err != nil || len(files) == 0 {
+			continue
+		}
+		items = append(items, UntrackedItem{Path: r.FileName, Name: r.Mod.Name})
+	}
+	return items, nil
+}
+
+// Adopt brings item under management via core.Service.AdoptFiles. Warnings
+// are the flow's own non-fatal diagnostics (checksum/profile writes, merged
+// pak sync).
+func (p *coreProvider) Adopt(ctx context.Context, item UntrackedItem, name string) (ActionOutcome, error) {
+	result, err := p.svc.AdoptFiles(ctx, p.currentGame(), p.currentProfile(), []string{item.Path}, core.AdoptOptions{Name: name})
+	if err != nil {
+		return ActionOutcome{}, fmt.Errorf("adopting %s: %w", item.Path, err)
+	}
+	msg := fmt.Sprintf("Adopted %q (%d file(s))", result.Mod.Name, len(result.Files))
+	if len(result.Skipped) > 0 {
+		msg += fmt.Sprintf(", skipped %d", len(result.Skipped))
+	}
+	return ActionOutcome{Message: msg, Warnings: result.Warnings}, nil
+}
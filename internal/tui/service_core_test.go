@@ -668,6 +668,31 @@ func TestCoreProviderDeployedFilesEmpty(t *testing.T) {
 	require.Empty(t, got)
 }
 
+// --- coreProvider: ProfileDiff ---
+
+// TestCoreProviderProfileDiff covers both comparisons: another profile is
+// diffed against the active one, and the active profile itself against the
+// game - the fixture's SkyUI row is deployed without the profile listing it.
+func TestCoreProviderProfileDiff(t *testing.T) {
+	provider, svc, game := newCoreProviderFixture(t)
+
+	pm := svc.NewProfileManager()
+	_, err := pm.Create(game.ID, "other")
+	require.NoError(t, err)
+	require.NoError(t, pm.AddMod(game.ID, "other", domain.ModReference{SourceID: "nexusmods", ModID: "103", Version: "1.0", Locked: true}))
+
+	got, err := provider.ProfileDiff("other")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Mods:", "  + nexusmods:103 1.0 (locked)"}, got)
+
+	got, err = provider.ProfileDiff("default")
+	require.NoError(t, err)
+	require.Equal(t, []string{"Mods:", "  + nexusmods:101 5.2"}, got)
+
+	_, err = provider.ProfileDiff("missing")
+	require.Error(t, err)
+}
+
 // --- coreProvider: Conflicts (Task 3) ---
 
 // TestCoreProviderConflicts guards coreProvider.Conflicts' mapping from
@@ -459,6 +459,7 @@ func (longSourcesProvider) Search(context.Context, string, string, int, int) (Se
 	return SearchPage{}, nil
 }
 func (longSourcesProvider) DeployedFiles(string, string) ([]string, error)    { return nil, nil }
+func (longSourcesProvider) ProfileDiff(string) ([]string, error)              { return nil, nil }
 func (longSourcesProvider) ListGames() ([]GameInfo, error)                    { return nil, nil }
 func (longSourcesProvider) Conflicts(context.Context) ([]ConflictItem, error) { return nil, nil }
 func (longSourcesProvider) Health(context.Context) (HealthView, error)        { return HealthView{}, nil }